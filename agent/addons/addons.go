@@ -42,6 +42,7 @@ type IntrospectionConfig struct {
 	Clock              clock.Clock
 	LocalHub           introspection.SimpleHub
 	CentralHub         introspection.StructuredHub
+	LeaseStore         introspection.LeaseStore
 
 	NewSocketName func(names.Tag) string
 	WorkerFunc    func(config introspection.Config) (worker.Worker, error)
@@ -71,7 +72,7 @@ func StartIntrospection(cfg IntrospectionConfig) error {
 		Clock:              cfg.Clock,
 		LocalHub:           cfg.LocalHub,
 		CentralHub:         cfg.CentralHub,
-		// TODO(leases) - add lease introspection
+		LeaseStore:         cfg.LeaseStore,
 	})
 	if err != nil {
 		return errors.Trace(err)
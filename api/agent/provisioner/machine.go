@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/errors"
@@ -84,7 +85,9 @@ type MachineProvisioner interface {
 
 	// SetInstanceInfo sets the provider specific instance id, nonce, metadata,
 	// network config for this machine. Once set, the instance id cannot be changed.
+	// Any trace span held in ctx is propagated to the API server.
 	SetInstanceInfo(
+		ctx context.Context,
 		id instance.Id, displayName string, nonce string, characteristics *instance.HardwareCharacteristics,
 		networkConfig []params.NetworkConfig, volumes []params.Volume,
 		volumeAttachments map[string]params.VolumeAttachmentInfo, charmProfiles []string,
@@ -340,6 +343,7 @@ func (m *Machine) DistributionGroup() ([]instance.Id, error) {
 
 // SetInstanceInfo implements MachineProvisioner.SetInstanceInfo.
 func (m *Machine) SetInstanceInfo(
+	ctx context.Context,
 	id instance.Id, displayName string, nonce string, characteristics *instance.HardwareCharacteristics,
 	networkConfig []params.NetworkConfig, volumes []params.Volume,
 	volumeAttachments map[string]params.VolumeAttachmentInfo, charmProfiles []string,
@@ -358,7 +362,7 @@ func (m *Machine) SetInstanceInfo(
 			CharmProfiles:     charmProfiles,
 		}},
 	}
-	err := m.st.facade.FacadeCall("SetInstanceInfo", args, &result)
+	err := m.st.facade.FacadeCallContext(ctx, "SetInstanceInfo", args, &result)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@
 package provisioner_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -299,7 +300,7 @@ func (s *provisionerSuite) TestSetInstanceInfo(c *gc.C) {
 		},
 	}
 
-	err = apiMachine.SetInstanceInfo(
+	err = apiMachine.SetInstanceInfo(context.Background(),
 		"i-will", "", "fake_nonce", &hwChars, nil, volumes, volumeAttachments, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -309,7 +310,7 @@ func (s *provisionerSuite) TestSetInstanceInfo(c *gc.C) {
 	c.Assert(instanceId, gc.Equals, instance.Id("i-will"))
 
 	// Try it again - should fail.
-	err = apiMachine.SetInstanceInfo("i-wont", "", "fake", nil, nil, nil, nil, nil)
+	err = apiMachine.SetInstanceInfo(context.Background(), "i-wont", "", "fake", nil, nil, nil, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot record provisioning info for "i-wont": cannot set instance data for machine "1": already set`)
 
 	// Now try to get machine 0's instance id.
@@ -359,7 +360,7 @@ func (s *provisionerSuite) TestAvailabilityZone(c *gc.C) {
 	availabilityZone := "ru-north-siberia"
 	hwChars := instance.MustParseHardware(fmt.Sprintf("availability-zone=%s", availabilityZone))
 
-	err = apiMachine.SetInstanceInfo(
+	err = apiMachine.SetInstanceInfo(context.Background(),
 		"azinst", "", "nonce", &hwChars, nil, nil, nil, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -388,7 +389,7 @@ func (s *provisionerSuite) TestSetInstanceInfoProfiles(c *gc.C) {
 	hwChars := instance.MustParseHardware("cores=123", "mem=4G")
 
 	profiles := []string{"juju-default-profile-0", "juju-default-lxd-2"}
-	err = apiMachine.SetInstanceInfo(
+	err = apiMachine.SetInstanceInfo(context.Background(),
 		"profileinst", "", "nonce", &hwChars, nil, nil, nil, profiles,
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -434,7 +435,7 @@ func (s *provisionerSuite) TestDistributionGroup(c *gc.C) {
 	apiMachine = s.assertGetOneMachine(c, machine1.MachineTag())
 	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 
-	err = apiMachine.SetInstanceInfo("i-d", "", "fake", nil, nil, nil, nil, nil)
+	err = apiMachine.SetInstanceInfo(context.Background(), "i-d", "", "fake", nil, nil, nil, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	instances, err = apiMachine.DistributionGroup()
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package uniter
 
 import (
+	"context"
 	"time"
 
 	"github.com/juju/charm/v12"
@@ -818,6 +819,12 @@ func (u *Unit) SetState(unitState params.SetUnitStateArg) error {
 	return u.st.SetState(unitState)
 }
 
+// SetStateContext is like SetState, but propagates any trace span held
+// in ctx to the API server.
+func (u *Unit) SetStateContext(ctx context.Context, unitState params.SetUnitStateArg) error {
+	return u.st.SetStateContext(ctx, unitState)
+}
+
 // CommitHookChanges batches together all required API calls for applying
 // a set of changes after a hook successfully completes and executes them in a
 // single transaction.
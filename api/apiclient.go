@@ -61,6 +61,7 @@ var logger = loggo.GetLogger("juju.api")
 
 type rpcConnection interface {
 	Call(req rpc.Request, params, response interface{}) error
+	CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error
 	Dead() <-chan struct{}
 	Close() error
 }
@@ -1248,7 +1249,13 @@ func isX509Error(err error) bool {
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, vers int, id, method string, args, response interface{}) error {
-	return s.client.Call(rpc.Request{
+	return s.APICallContext(context.Background(), facade, vers, id, method, args, response)
+}
+
+// APICallContext is like APICall, but propagates any trace span held
+// in ctx to the API server.
+func (s *state) APICallContext(ctx context.Context, facade string, vers int, id, method string, args, response interface{}) error {
+	return s.client.CallContext(ctx, rpc.Request{
 		Type:    facade,
 		Version: vers,
 		Id:      id,
@@ -1288,6 +1288,9 @@ func (s *apiclientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Replay:        true,
 		NoTail:        true,
 		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:       time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+		MessageRegex:  "refused|reset",
+		Filter:        "label.app = mysql and level >= WARNING",
 	}
 
 	urlValues := url.Values{
@@ -1303,6 +1306,9 @@ func (s *apiclientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"replay":        {"true"},
 		"noTail":        {"true"},
 		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":       {"2016-11-30T12:48:00Z"},
+		"messageRegex":  {"refused|reset"},
+		"filter":        {"label.app = mysql and level >= WARNING"},
 	}
 
 	client := apiclient.NewClient(s.APIState, jtesting.NoopLogger{})
@@ -1438,6 +1444,10 @@ func (f *fakeRPCConnection) Close() error {
 }
 
 func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{}) error {
+	return f.CallContext(context.Background(), req, params, response)
+}
+
+func (f *fakeRPCConnection) CallContext(_ context.Context, req rpc.Request, params, response interface{}) error {
 	f.stub.AddCall(req.Type+"."+req.Action, req.Version, params)
 	if f.response != nil {
 		rv := reflect.ValueOf(response)
@@ -8,11 +8,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"gopkg.in/httprequest.v1"
 	"gopkg.in/macaroon.v2"
+
+	"github.com/juju/juju/rpc/params"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/caller_mock.go github.com/juju/juju/api/base APICaller,FacadeCaller
@@ -28,6 +33,11 @@ type APICaller interface {
 	// call's result if the call is successful.
 	APICall(objType string, version int, id, request string, params, response interface{}) error
 
+	// APICallContext is like APICall, but if ctx holds a trace span,
+	// the span is propagated to the API server so that the request is
+	// traced as part of it.
+	APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error
+
 	// BestFacadeVersion returns the newest version of 'objType' that this
 	// client can use with the current API server.
 	BestFacadeVersion(facade string) int
@@ -117,6 +127,11 @@ type FacadeCaller interface {
 	// also known to the client.
 	FacadeCall(request string, params, response interface{}) error
 
+	// FacadeCallContext is like FacadeCall, but if ctx holds a trace
+	// span, the span is propagated to the API server so that the
+	// request is traced as part of it.
+	FacadeCallContext(ctx context.Context, request string, params, response interface{}) error
+
 	// Name returns the facade name.
 	Name() string
 
@@ -141,10 +156,84 @@ var _ FacadeCaller = facadeCaller{}
 // FacadeCall will place a request against the API using the requested
 // Facade and the best version that the API server supports that is
 // also known to the client. (id is always passed as the empty string.)
+//
+// Calls rejected by the controller's API rate limits are retried, after
+// waiting for the time advised by the controller, up to
+// rateLimitRetryAttempts times.
 func (fc facadeCaller) FacadeCall(request string, params, response interface{}) error {
-	return fc.caller.APICall(
-		fc.facadeName, fc.bestVersion, "",
-		request, params, response)
+	return fc.retryRateLimited(context.Background(), func() error {
+		return fc.caller.APICall(
+			fc.facadeName, fc.bestVersion, "",
+			request, params, response)
+	})
+}
+
+// FacadeCallContext is like FacadeCall, but propagates any trace span
+// held in ctx to the API server.
+func (fc facadeCaller) FacadeCallContext(ctx context.Context, request string, params, response interface{}) error {
+	return fc.retryRateLimited(ctx, func() error {
+		return fc.caller.APICallContext(ctx,
+			fc.facadeName, fc.bestVersion, "",
+			request, params, response)
+	})
+}
+
+// retryRateLimited makes the call, retrying it if it is rejected by the
+// controller's API rate limits until ctx is done.
+func (fc facadeCaller) retryRateLimited(ctx context.Context, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := call()
+		delay, ok := rateLimitRetryDelay(err)
+		if !ok || attempt > rateLimitRetryAttempts {
+			return err
+		}
+		select {
+		case <-rateLimitClock.After(delay):
+		case <-ctx.Done():
+			return err
+		case <-fc.caller.Context().Done():
+			return err
+		}
+	}
+}
+
+const (
+	// rateLimitRetryAttempts is the number of times a call rejected by
+	// the controller's API rate limits is retried.
+	rateLimitRetryAttempts = 3
+
+	// defaultRateLimitRetryDelay is the time waited before retrying a
+	// rate limited call if the controller doesn't say how long to wait.
+	defaultRateLimitRetryDelay = time.Second
+
+	// maxRateLimitRetryDelay is the longest time waited before retrying
+	// a rate limited call.
+	maxRateLimitRetryDelay = time.Minute
+)
+
+// rateLimitClock is used to wait before retrying rate limited calls.
+var rateLimitClock clock.Clock = clock.WallClock
+
+// rateLimitRetryDelay returns the time to wait before retrying a call
+// which failed with the given error, and false if the error isn't a
+// rate limit error.
+func rateLimitRetryDelay(err error) (time.Duration, bool) {
+	if !params.IsCodeRateLimitExceeded(err) {
+		return 0, false
+	}
+	type infoUnmarshaler interface {
+		UnmarshalInfo(to interface{}) error
+	}
+	var info params.RateLimitExceededErrorInfo
+	u, ok := errors.Cause(err).(infoUnmarshaler)
+	if !ok || u.UnmarshalInfo(&info) != nil || info.RetryAfter <= 0 {
+		return defaultRateLimitRetryDelay, true
+	}
+	delay := time.Duration(info.RetryAfter * float64(time.Second))
+	if delay > maxRateLimitRetryDelay {
+		delay = maxRateLimitRetryDelay
+	}
+	return delay, true
 }
 
 // Name returns the facade name.
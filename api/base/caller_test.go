@@ -0,0 +1,106 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type FacadeCallerSuite struct {
+	testing.IsolationSuite
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&FacadeCallerSuite{})
+
+func (s *FacadeCallerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	base.PatchRateLimitClock(s, s.clock)
+}
+
+func rateLimitError(retryAfter float64) error {
+	return &params.Error{
+		Message: "rate limit exceeded",
+		Code:    params.CodeRateLimitExceeded,
+		Info:    params.RateLimitExceededErrorInfo{RetryAfter: retryAfter}.AsMap(),
+	}
+}
+
+func (s *FacadeCallerSuite) TestFacadeCallRetriesRateLimited(c *gc.C) {
+	var calls int
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, args, response interface{}) error {
+		c.Check(objType, gc.Equals, "Client")
+		c.Check(request, gc.Equals, "FullStatus")
+		calls++
+		if calls < 3 {
+			return rateLimitError(2.5)
+		}
+		return nil
+	})
+	facade := base.NewFacadeCaller(caller, "Client")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- facade.FacadeCall("FullStatus", nil, nil)
+	}()
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(2500*time.Millisecond, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for call")
+	}
+	c.Assert(calls, gc.Equals, 3)
+}
+
+func (s *FacadeCallerSuite) TestFacadeCallGivesUpRateLimited(c *gc.C) {
+	var calls int
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, args, response interface{}) error {
+		calls++
+		// The delay is capped, so the caller doesn't wait an hour.
+		return rateLimitError(3600)
+	})
+	facade := base.NewFacadeCaller(caller, "Client")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- facade.FacadeCall("FullStatus", nil, nil)
+	}()
+	for i := 0; i < 3; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(params.IsCodeRateLimitExceeded(err), jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for call")
+	}
+	c.Assert(calls, gc.Equals, 4)
+}
+
+func (s *FacadeCallerSuite) TestFacadeCallOtherErrorsNotRetried(c *gc.C) {
+	var calls int
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, args, response interface{}) error {
+		calls++
+		return &params.Error{Message: "boom", Code: params.CodeTryAgain}
+	})
+	err := base.NewFacadeCaller(caller, "Client").FacadeCall("FullStatus", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(calls, gc.Equals, 1)
+}
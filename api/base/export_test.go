@@ -0,0 +1,18 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"github.com/juju/clock"
+)
+
+type Patcher interface {
+	PatchValue(destination, source interface{})
+}
+
+// PatchRateLimitClock replaces the clock used to wait before retrying
+// rate limited calls.
+func PatchRateLimitClock(p Patcher, clock clock.Clock) {
+	p.PatchValue(&rateLimitClock, clock)
+}
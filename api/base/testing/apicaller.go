@@ -28,6 +28,10 @@ func (f APICallerFunc) APICall(objType string, version int, id, request string,
 	return f(objType, version, id, request, params, response)
 }
 
+func (f APICallerFunc) APICallContext(_ context.Context, objType string, version int, id, request string, params, response interface{}) error {
+	return f(objType, version, id, request, params, response)
+}
+
 func (APICallerFunc) BestFacadeVersion(facade string) int {
 	// TODO(fwereade): this should return something arbitrary (e.g. 37)
 	// so that it can't be confused with mere uninitialized data.
@@ -180,6 +184,11 @@ func (c notifyingAPICaller) APICall(objType string, version int, id, request str
 	return c.APICaller.APICall(objType, version, id, request, params, response)
 }
 
+func (c notifyingAPICaller) APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error {
+	c.called <- struct{}{}
+	return c.APICaller.APICallContext(ctx, objType, version, id, request, params, response)
+}
+
 // NotifyingAPICaller returns an APICaller implementation which sends a
 // message on the given channel every time it receives a call.
 func NotifyingAPICaller(c *gc.C, called chan<- struct{}, caller base.APICaller) base.APICaller {
@@ -228,6 +237,11 @@ func (s *StubFacadeCaller) FacadeCall(request string, params, response interface
 	return nil
 }
 
+// FacadeCallContext implements api/base.FacadeCaller.
+func (s *StubFacadeCaller) FacadeCallContext(_ context.Context, request string, params, response interface{}) error {
+	return s.FacadeCall(request, params, response)
+}
+
 // Name implements api/base.FacadeCaller.
 func (s *StubFacadeCaller) Name() string {
 	s.Stub.AddCall("Name")
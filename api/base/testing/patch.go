@@ -4,6 +4,8 @@
 package testing
 
 import (
+	"context"
+
 	"github.com/juju/juju/api/base"
 )
 
@@ -26,3 +28,7 @@ type facadeWrapper struct {
 func (f *facadeWrapper) FacadeCall(request string, params, response interface{}) error {
 	return f.facadeCall(request, params, response)
 }
+
+func (f *facadeWrapper) FacadeCallContext(_ context.Context, request string, params, response interface{}) error {
+	return f.facadeCall(request, params, response)
+}
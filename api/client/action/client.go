@@ -107,6 +107,33 @@ func (c *Client) EnqueueOperation(actions []Action) (EnqueuedActions, error) {
 	return unmarshallEnqueuedActions(results)
 }
 
+// EnqueueOperationInBatches queues up the actions as an operation whose
+// tasks are enqueued in batches, with any on application leaders last.
+// Only the first batch is enqueued immediately; the results for the
+// other tasks have no action until the controller enqueues them.
+func (c *Client) EnqueueOperationInBatches(actions []Action, rollout Rollout) (EnqueuedActions, error) {
+	if c.facade.BestAPIVersion() < 8 {
+		return EnqueuedActions{}, errors.NotSupportedf("running actions in batches on this version of Juju")
+	}
+	arg := params.Actions{
+		Actions: make([]params.Action, len(actions)),
+		Rollout: rolloutParams(&rollout),
+	}
+	for i, a := range actions {
+		arg.Actions[i] = params.Action{
+			Receiver:   a.Receiver,
+			Name:       a.Name,
+			Parameters: a.Parameters,
+		}
+	}
+	results := params.EnqueuedActions{}
+	err := c.facade.FacadeCall("EnqueueOperation", arg, &results)
+	if err != nil {
+		return EnqueuedActions{}, errors.Trace(err)
+	}
+	return unmarshallEnqueuedActions(results)
+}
+
 // Cancel attempts to cancel a queued up Action from running.
 func (c *Client) Cancel(actionIDs []string) ([]ActionResult, error) {
 	arg := params.Entities{Entities: make([]params.Entity, len(actionIDs))}
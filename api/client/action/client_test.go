@@ -4,6 +4,8 @@
 package action_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
@@ -300,3 +302,77 @@ func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
 		OperationID: "1",
 	})
 }
+
+func (s *actionSuite) TestEnqueueOperationInBatches(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := []action.Action{{
+		Receiver:   "unit-mysql-0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{},
+	}}
+	fArgs := params.Actions{
+		Actions: []params.Action{{
+			Receiver:   "unit-mysql-0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{},
+		}},
+		Rollout: &params.RolloutSpec{BatchSize: 1, StopOnFailure: true},
+	}
+	res := new(params.EnqueuedActions)
+	ress := params.EnqueuedActions{
+		OperationTag: "operation-1",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{Tag: "action-2", Receiver: "unit-mysql-0", Name: "backup"},
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("EnqueueOperation", fArgs, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	result, err := client.EnqueueOperationInBatches(args, action.Rollout{BatchSize: 1, StopOnFailure: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, action.EnqueuedActions{
+		OperationID: "1",
+		Actions: []action.ActionResult{{
+			Action: &action.Action{ID: "2", Receiver: "unit-mysql-0", Name: "backup"},
+		}},
+	})
+}
+
+func (s *actionSuite) TestOperationWithRollout(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	arg := params.Entities{Entities: []params.Entity{{Tag: "operation-1"}}}
+	res := new(params.OperationResults)
+	ress := params.OperationResults{
+		Results: []params.OperationResult{{
+			OperationTag: "operation-1",
+			Status:       "running",
+			Rollout: &params.RolloutInfo{
+				BatchSize:       2,
+				BatchDelay:      time.Minute,
+				Batches:         3,
+				BatchesEnqueued: 2,
+			},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().FacadeCall("Operations", arg, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	result, err := client.Operation("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Rollout, jc.DeepEquals, &action.RolloutInfo{
+		Rollout: action.Rollout{
+			BatchSize:  2,
+			BatchDelay: time.Minute,
+		},
+		Batches:         3,
+		BatchesEnqueued: 2,
+	})
+}
@@ -23,9 +23,31 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) (Enque
 	return unmarshallEnqueuedActions(results)
 }
 
+// RunOnAllMachinesInBatches runs the command on all the machines with
+// the specified timeout, a batch of machines at a time.
+func (c *Client) RunOnAllMachinesInBatches(commands string, timeout time.Duration, rollout Rollout) (EnqueuedActions, error) {
+	if c.facade.BestAPIVersion() < 8 {
+		return EnqueuedActions{}, errors.NotSupportedf("running commands in batches on this version of Juju")
+	}
+	var results params.EnqueuedActions
+	args := params.RunParams{
+		Commands: commands,
+		Timeout:  timeout,
+		Rollout:  rolloutParams(&rollout),
+	}
+	err := c.facade.FacadeCall("RunOnAllMachines", args, &results)
+	if err != nil {
+		return EnqueuedActions{}, errors.Trace(err)
+	}
+	return unmarshallEnqueuedActions(results)
+}
+
 // Run the Commands specified on the machines identified through the ids
 // provided in the machines, applications and units slices.
 func (c *Client) Run(run RunParams) (EnqueuedActions, error) {
+	if run.Rollout != nil && c.facade.BestAPIVersion() < 8 {
+		return EnqueuedActions{}, errors.NotSupportedf("running commands in batches on this version of Juju")
+	}
 	args := params.RunParams{
 		Commands:        run.Commands,
 		Timeout:         run.Timeout,
@@ -33,6 +55,7 @@ func (c *Client) Run(run RunParams) (EnqueuedActions, error) {
 		Applications:    run.Applications,
 		Units:           run.Units,
 		WorkloadContext: run.WorkloadContext,
+		Rollout:         rolloutParams(run.Rollout),
 	}
 	var results params.EnqueuedActions
 	err := c.facade.FacadeCall("Run", args, &results)
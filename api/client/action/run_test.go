@@ -6,6 +6,7 @@ package action_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
@@ -93,3 +94,106 @@ func (s *actionSuite) TestRun(c *gc.C) {
 			}}},
 	})
 }
+
+func (s *actionSuite) TestRunInBatches(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.RunParams{
+		Commands:     "pwd",
+		Timeout:      time.Millisecond,
+		Applications: []string{"mysql"},
+		Rollout: &params.RolloutSpec{
+			BatchSize:     1,
+			BatchDelay:    time.Minute,
+			StopOnFailure: true,
+		},
+	}
+	res := new(params.EnqueuedActions)
+	ress := params.EnqueuedActions{
+		OperationTag: "operation-1",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{
+				Name:     "juju-exec",
+				Tag:      "action-2",
+				Receiver: "unit-mysql-1",
+			},
+		}, {
+			Status: "pending",
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("Run", args, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	result, err := client.Run(action.RunParams{
+		Commands:     "pwd",
+		Timeout:      time.Millisecond,
+		Applications: []string{"mysql"},
+		Rollout: &action.Rollout{
+			BatchSize:     1,
+			BatchDelay:    time.Minute,
+			StopOnFailure: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, action.EnqueuedActions{
+		OperationID: "1",
+		Actions: []action.ActionResult{{
+			Action: &action.Action{
+				Name:     "juju-exec",
+				ID:       "2",
+				Receiver: "unit-mysql-1",
+			}}, {
+			Status: "pending",
+		}},
+	})
+}
+
+func (s *actionSuite) TestRunInBatchesNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	_, err := client.Run(action.RunParams{
+		Commands: "pwd",
+		Units:    []string{"mysql/0"},
+		Rollout:  &action.Rollout{BatchSize: 1},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *actionSuite) TestRunOnAllMachinesInBatches(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.RunParams{
+		Commands: "pwd",
+		Timeout:  time.Millisecond,
+		Rollout:  &params.RolloutSpec{BatchSize: 2},
+	}
+	res := new(params.EnqueuedActions)
+	ress := params.EnqueuedActions{
+		OperationTag: "operation-1",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{
+				Name:     "juju-exec",
+				Tag:      "action-2",
+				Receiver: "machine-0",
+			},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("RunOnAllMachines", args, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	result, err := client.RunOnAllMachinesInBatches("pwd", time.Millisecond, action.Rollout{BatchSize: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OperationID, gc.Equals, "1")
+	c.Assert(result.Actions, gc.HasLen, 1)
+}
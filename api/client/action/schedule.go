@@ -0,0 +1,123 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// Schedule describes an action which the controller runs on a
+// schedule, starting an operation for each run.
+type Schedule struct {
+	Name string
+
+	// Cron is a standard 5 field cron expression, evaluated in UTC.
+	// Only one of Cron and Interval is set.
+	Cron     string
+	Interval time.Duration
+
+	// Targets are units, such as mysql/0 or mysql/leader, and
+	// applications.
+	Targets    []string
+	Action     string
+	Parameters map[string]interface{}
+
+	// ConcurrencyPolicy is one of "allow", "forbid" or "replace",
+	// and determines what happens when a run is due while the
+	// operation of the previous run is active.
+	ConcurrencyPolicy string
+
+	Created time.Time
+	NextRun time.Time
+
+	// Runs holds the most recent runs, oldest first.
+	Runs []ScheduledRun
+}
+
+// ScheduledRun records a run of a schedule.
+type ScheduledRun struct {
+	Time time.Time
+	// OperationID identifies the operation started by the run, if any.
+	OperationID string
+	// Skipped records why no operation was started.
+	Skipped string
+}
+
+// AddSchedule adds a schedule on which to run an action.
+func (c *Client) AddSchedule(schedule Schedule) error {
+	if c.facade.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	arg := params.AddActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:              schedule.Name,
+			Cron:              schedule.Cron,
+			Interval:          schedule.Interval,
+			Targets:           schedule.Targets,
+			Action:            schedule.Action,
+			Parameters:        schedule.Parameters,
+			ConcurrencyPolicy: schedule.ConcurrencyPolicy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddSchedules", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSchedules returns the action schedules of the model.
+func (c *Client) ListSchedules() ([]Schedule, error) {
+	if c.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	var results params.ActionSchedules
+	if err := c.facade.FacadeCall("ListSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Schedule, len(results.Schedules))
+	for i, schedule := range results.Schedules {
+		out[i] = Schedule{
+			Name:              schedule.Name,
+			Cron:              schedule.Cron,
+			Interval:          schedule.Interval,
+			Targets:           schedule.Targets,
+			Action:            schedule.Action,
+			Parameters:        schedule.Parameters,
+			ConcurrencyPolicy: schedule.ConcurrencyPolicy,
+			Created:           schedule.Created,
+			NextRun:           schedule.NextRun,
+		}
+		for _, run := range schedule.Runs {
+			outRun := ScheduledRun{Time: run.Time, Skipped: run.Skipped}
+			if run.OperationTag != "" {
+				tag, err := names.ParseOperationTag(run.OperationTag)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				outRun.OperationID = tag.Id()
+			}
+			out[i].Runs = append(out[i].Runs, outRun)
+		}
+	}
+	return out, nil
+}
+
+// RemoveSchedule removes the action schedule with the given name.
+func (c *Client) RemoveSchedule(name string) error {
+	if c.facade.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	arg := params.ActionScheduleNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveSchedules", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	basemocks "github.com/juju/juju/api/base/mocks"
+	"github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/rpc/params"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestAddSchedule(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	arg := params.AddActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:              "nightly",
+			Cron:              "0 3 * * *",
+			Targets:           []string{"mysql"},
+			Action:            "backup",
+			Parameters:        map[string]interface{}{"full": true},
+			ConcurrencyPolicy: "forbid",
+		}},
+	}
+	res := new(params.ErrorResults)
+	ress := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(9)
+	mockFacadeCaller.EXPECT().FacadeCall("AddSchedules", arg, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	err := client.AddSchedule(action.Schedule{
+		Name:              "nightly",
+		Cron:              "0 3 * * *",
+		Targets:           []string{"mysql"},
+		Action:            "backup",
+		Parameters:        map[string]interface{}{"full": true},
+		ConcurrencyPolicy: "forbid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *scheduleSuite) TestAddScheduleError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.ErrorResults)
+	ress := params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: `schedule "nightly" already exists`, Code: params.CodeAlreadyExists},
+	}}}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(9)
+	mockFacadeCaller.EXPECT().FacadeCall("AddSchedules", gomock.Any(), res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	err := client.AddSchedule(action.Schedule{Name: "nightly"})
+	c.Assert(err, gc.ErrorMatches, `schedule "nightly" already exists`)
+}
+
+func (s *scheduleSuite) TestAddScheduleNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	err := client.AddSchedule(action.Schedule{Name: "nightly"})
+	c.Assert(err, gc.ErrorMatches, "scheduling actions on this version of Juju not supported")
+}
+
+func (s *scheduleSuite) TestListSchedules(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	res := new(params.ActionSchedules)
+	ress := params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:              "hourly",
+			Interval:          time.Hour,
+			Targets:           []string{"mysql/leader"},
+			Action:            "backup",
+			ConcurrencyPolicy: "allow",
+			Created:           created,
+			NextRun:           created.Add(3 * time.Hour),
+			Runs: []params.ScheduledRun{
+				{Time: created.Add(time.Hour), OperationTag: "operation-1"},
+				{Time: created.Add(2 * time.Hour), Skipped: "no units to run on"},
+			},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(9)
+	mockFacadeCaller.EXPECT().FacadeCall("ListSchedules", nil, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	schedules, err := client.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []action.Schedule{{
+		Name:              "hourly",
+		Interval:          time.Hour,
+		Targets:           []string{"mysql/leader"},
+		Action:            "backup",
+		ConcurrencyPolicy: "allow",
+		Created:           created,
+		NextRun:           created.Add(3 * time.Hour),
+		Runs: []action.ScheduledRun{
+			{Time: created.Add(time.Hour), OperationID: "1"},
+			{Time: created.Add(2 * time.Hour), Skipped: "no units to run on"},
+		},
+	}})
+}
+
+func (s *scheduleSuite) TestRemoveSchedule(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	arg := params.ActionScheduleNames{Names: []string{"hourly"}}
+	res := new(params.ErrorResults)
+	ress := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(9)
+	mockFacadeCaller.EXPECT().FacadeCall("RemoveSchedules", arg, res).SetArg(2, ress).Return(nil)
+	client := action.NewClientFromCaller(mockFacadeCaller)
+
+	err := client.RemoveSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -29,9 +29,28 @@ type Operation struct {
 	Completed time.Time
 	Status    string
 	Actions   []ActionResult
+	Rollout   *RolloutInfo
 	Error     error
 }
 
+// Rollout describes how the tasks of an operation are enqueued in
+// batches, each batch being enqueued once the previous one has
+// finished.
+type Rollout struct {
+	BatchSize     int
+	BatchDelay    time.Duration
+	StopOnFailure bool
+}
+
+// RolloutInfo describes the progress of an operation whose tasks are
+// enqueued in batches.
+type RolloutInfo struct {
+	Rollout
+	Batches         int
+	BatchesEnqueued int
+	Stopped         string
+}
+
 // ActionMessage represents a logged message on an action.
 type ActionMessage struct {
 	Timestamp time.Time
@@ -100,6 +119,10 @@ type RunParams struct {
 	// WorkloadContext for CAAS is true when the Commands should be run on
 	// the workload not the operator.
 	WorkloadContext bool
+
+	// Rollout, if set, runs the commands in batches rather than on
+	// all the targets at once.
+	Rollout *Rollout
 }
 
 func unmarshallEnqueuedActions(in params.EnqueuedActions) (EnqueuedActions, error) {
@@ -197,9 +220,32 @@ func unmarshallOperation(in params.OperationResult) Operation {
 	for i, a := range in.Actions {
 		result.Actions[i] = unmarshallActionResult(a)
 	}
+	if in.Rollout != nil {
+		result.Rollout = &RolloutInfo{
+			Rollout: Rollout{
+				BatchSize:     in.Rollout.BatchSize,
+				BatchDelay:    in.Rollout.BatchDelay,
+				StopOnFailure: in.Rollout.StopOnFailure,
+			},
+			Batches:         in.Rollout.Batches,
+			BatchesEnqueued: in.Rollout.BatchesEnqueued,
+			Stopped:         in.Rollout.Stopped,
+		}
+	}
 	return result
 }
 
+func rolloutParams(rollout *Rollout) *params.RolloutSpec {
+	if rollout == nil {
+		return nil
+	}
+	return &params.RolloutSpec{
+		BatchSize:     rollout.BatchSize,
+		BatchDelay:    rollout.BatchDelay,
+		StopOnFailure: rollout.StopOnFailure,
+	}
+}
+
 func unmarshallActionSpecs(in map[string]params.ActionSpec) map[string]ActionSpec {
 	result := make(map[string]ActionSpec)
 	for k, v := range in {
@@ -632,6 +632,28 @@ func (c *Client) ScaleApplication(in ScaleApplicationParams) (params.ScaleApplic
 	return results.Results[0], nil
 }
 
+// SetAutoscalePolicy sets the autoscale policy of an application. A nil
+// policy stops the application being autoscaled.
+func (c *Client) SetAutoscalePolicy(applicationName string, policy *params.AutoscalePolicy) error {
+	if c.facade.BestAPIVersion() < 20 {
+		return errors.NotSupportedf("autoscaling applications on this version of Juju")
+	}
+	if !names.IsValidApplication(applicationName) {
+		return errors.NotValidf("application %q", applicationName)
+	}
+	args := params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: names.NewApplicationTag(applicationName).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // GetConstraints returns the constraints for the given applications.
 func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, error) {
 	var allConstraints []constraints.Value
@@ -981,6 +981,47 @@ func (s *applicationSuite) TestScaleApplication(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestSetAutoscalePolicy(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	policy := &params.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  time.Minute,
+	}
+	args := params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-foo",
+			Policy:         policy,
+		}},
+	}
+	result := new(params.ErrorResults)
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	}
+	mockFacadeCaller := mocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(20)
+	mockFacadeCaller.EXPECT().FacadeCall("SetAutoscalePolicies", args, result).SetArg(2, results).Return(nil)
+
+	client := application.NewClientFromCaller(mockFacadeCaller)
+	err := client.SetAutoscalePolicy("foo", policy)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestSetAutoscalePolicyNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := mocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(19)
+
+	client := application.NewClientFromCaller(mockFacadeCaller)
+	err := client.SetAutoscalePolicy("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on this version of Juju not supported")
+}
+
 func (s *applicationSuite) TestChangeScaleApplication(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,39 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List returns the metadata of the scheduled backups held by the
+// controller, oldest first.
+func (c *Client) List() ([]params.BackupsMetadataResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("listing backups on this juju version")
+	}
+	var result params.BackupsListResult
+	if err := c.facade.FacadeCall("List", params.BackupsListArgs{}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.List, nil
+}
+
+// Prune removes all but the newest keep full scheduled backups, along
+// with the incremental backups building upon them. If keep is zero, the
+// controller's backup-retention-count is used. The metadata of the
+// removed backups is returned.
+func (c *Client) Prune(keep int) ([]params.BackupsMetadataResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("pruning backups on this juju version")
+	}
+	var result params.BackupsListResult
+	args := params.BackupsPruneArgs{Keep: keep}
+	if err := c.facade.FacadeCall("Prune", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.List, nil
+}
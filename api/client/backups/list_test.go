@@ -0,0 +1,56 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+type listSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) TestList(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	result := params.BackupsListResult{
+		List: []params.BackupsMetadataResult{{ID: "juju-backup-a.tar.gz"}},
+	}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("List", params.BackupsListArgs{}, gomock.Any()).SetArg(2, result)
+
+	got, err := s.newClient().List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, result.List)
+}
+
+func (s *listSuite) TestListNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(3)
+
+	_, err := s.newClient().List()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *listSuite) TestPrune(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	result := params.BackupsListResult{
+		List: []params.BackupsMetadataResult{{ID: "juju-backup-a.tar.gz"}},
+	}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Prune", params.BackupsPruneArgs{Keep: 2}, gomock.Any()).SetArg(2, result)
+
+	got, err := s.newClient().Prune(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, result.List)
+}
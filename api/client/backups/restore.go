@@ -0,0 +1,28 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// Restore restores the controller from a backup: either the stored
+// scheduled backup with the given ID, along with the backups it builds
+// upon, or the backup archive at the given path on the controller.
+func (c *Client) Restore(backupID, fileName string) (params.BackupsRestoreResult, error) {
+	var result params.BackupsRestoreResult
+	if c.facade.BestAPIVersion() < 5 {
+		return result, errors.NotSupportedf("restoring backups on this juju version")
+	}
+	args := params.BackupsRestoreArgs{
+		BackupID: backupID,
+		FileName: fileName,
+	}
+	if err := c.facade.FacadeCall("Restore", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
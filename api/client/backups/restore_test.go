@@ -0,0 +1,43 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+type restoreSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	result := params.BackupsRestoreResult{
+		Restored: []params.BackupsMetadataResult{{ID: "juju-backup-a.tar.gz"}},
+	}
+	args := params.BackupsRestoreArgs{BackupID: "juju-backup-a.tar.gz"}
+	s.facade.EXPECT().BestAPIVersion().Return(5)
+	s.facade.EXPECT().FacadeCall("Restore", args, gomock.Any()).SetArg(2, result)
+
+	got, err := s.newClient().Restore("juju-backup-a.tar.gz", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, result)
+}
+
+func (s *restoreSuite) TestRestoreNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+
+	_, err := s.newClient().Restore("juju-backup-a.tar.gz", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
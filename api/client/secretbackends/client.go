@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/status"
@@ -159,3 +160,46 @@ func (api *Client) RemoveSecretBackend(name string, force bool) error {
 	}
 	return params.TranslateWellKnownError(results.OneError())
 }
+
+// MigrateSecrets starts moving secret revisions in the specified model
+// to the named backend, returning the number of revisions to be moved.
+// The revisions are moved by the controller; use SecretsMigrationStatus
+// to follow its progress. For a dry run, nothing is moved and the
+// result holds the revisions which would be.
+func (api *Client) MigrateSecrets(args params.MigrateSecretsArgs) (params.MigrateSecretsResult, error) {
+	if api.BestAPIVersion() < 2 {
+		return params.MigrateSecretsResult{}, errors.NotSupportedf("migrating secrets on this juju version")
+	}
+
+	var result params.MigrateSecretsResult
+	err := api.facade.FacadeCall("MigrateSecrets", args, &result)
+	if err != nil {
+		return params.MigrateSecretsResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// SecretsMigrationStatus returns the progress of the current or most
+// recent migration of the specified model's secrets.
+func (api *Client) SecretsMigrationStatus(modelUUID string) (params.SecretsMigrationResult, error) {
+	if api.BestAPIVersion() < 2 {
+		return params.SecretsMigrationResult{}, errors.NotSupportedf("migrating secrets on this juju version")
+	}
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.SecretsMigrationResults
+	err := api.facade.FacadeCall("SecretsMigrationStatus", args, &results)
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.SecretsMigrationResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.SecretsMigrationResult{}, params.TranslateWellKnownError(result.Error)
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ package secretbackends_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -177,3 +178,107 @@ func (s *SecretBackendsSuite) TestUpdateSecretsBackend(c *gc.C) {
 	err := client.UpdateSecretBackend(backend, true)
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *SecretBackendsSuite) TestMigrateSecrets(c *gc.C) {
+	args := params.MigrateSecretsArgs{
+		ModelUUID:    coretesting.ModelTag.Id(),
+		BackendName:  "myvault",
+		Applications: []string{"mariadb"},
+		DryRun:       true,
+	}
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "SecretBackends")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "MigrateSecrets")
+			c.Check(arg, jc.DeepEquals, args)
+			c.Assert(result, gc.FitsTypeOf, &params.MigrateSecretsResult{})
+			*(result.(*params.MigrateSecretsResult)) = params.MigrateSecretsResult{
+				Revisions: []params.MigrateSecretRevisionResult{{
+					URI:           "secret:9m4e2mr0ui3e8a215n4g",
+					Revision:      1,
+					FromBackendID: "internal-id",
+					ToBackendID:   "vault-id",
+					Checksum:      "deadbeef",
+				}},
+				Total: 1,
+			}
+			return nil
+		}), BestVersion: 2,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	result, err := client.MigrateSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{
+		Revisions: []params.MigrateSecretRevisionResult{{
+			URI:           "secret:9m4e2mr0ui3e8a215n4g",
+			Revision:      1,
+			FromBackendID: "internal-id",
+			ToBackendID:   "vault-id",
+			Checksum:      "deadbeef",
+		}},
+		Total: 1,
+	})
+}
+
+func (s *SecretBackendsSuite) TestMigrateSecretsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected api call")
+			return nil
+		}), BestVersion: 1,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	_, err := client.MigrateSecrets(params.MigrateSecretsArgs{BackendName: "myvault"})
+	c.Assert(err, gc.ErrorMatches, "migrating secrets on this juju version not supported")
+}
+
+func (s *SecretBackendsSuite) TestSecretsMigrationStatus(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "SecretBackends")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SecretsMigrationStatus")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.SecretsMigrationResults{})
+			*(result.(*params.SecretsMigrationResults)) = params.SecretsMigrationResults{
+				Results: []params.SecretsMigrationResult{{
+					BackendName: "myvault",
+					Status:      "running",
+					Total:       3,
+					Moved:       1,
+				}},
+			}
+			return nil
+		}), BestVersion: 2,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	result, err := client.SecretsMigrationStatus(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretsMigrationResult{
+		BackendName: "myvault",
+		Status:      "running",
+		Total:       3,
+		Moved:       1,
+	})
+}
+
+func (s *SecretBackendsSuite) TestSecretsMigrationStatusError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.SecretsMigrationResults)) = params.SecretsMigrationResults{
+				Results: []params.SecretsMigrationResult{{
+					Error: &params.Error{Code: params.CodeNotFound, Message: "secrets migration not found"},
+				}},
+			}
+			return nil
+		}), BestVersion: 2,
+	}
+	client := secretbackends.NewClient(apiCaller)
+	_, err := client.SecretsMigrationStatus(coretesting.ModelTag.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -30,6 +30,11 @@ type SecretDetails struct {
 	Revisions []secrets.SecretRevisionMetadata
 	Value     secrets.SecretValue
 	Error     string
+
+	// RevisionConsumers holds the consumers tracking each
+	// revision, keyed on revision. It is only populated by
+	// ListSecretHistory.
+	RevisionConsumers map[int][]string
 }
 
 func toGrantInfo(grants []params.AccessInfo) []secrets.AccessInfo {
@@ -58,6 +63,32 @@ func (api *Client) ListSecrets(reveal bool, filter secrets.Filter) ([]SecretDeta
 		uri := filter.URI.String()
 		arg.Filter.URI = &uri
 	}
+	return api.listSecrets(arg)
+}
+
+// ListSecretHistory lists the available secrets, including the
+// author of each revision and the consumers tracking it.
+func (api *Client) ListSecretHistory(filter secrets.Filter) ([]SecretDetails, error) {
+	if api.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("secret history on this juju version")
+	}
+	arg := params.ListSecretsArgs{
+		ShowConsumers: true,
+		Filter: params.SecretsFilter{
+			OwnerTag: filter.OwnerTag,
+			Revision: filter.Revision,
+			Label:    filter.Label,
+		},
+	}
+	if filter.URI != nil {
+		uri := filter.URI.String()
+		arg.Filter.URI = &uri
+	}
+	return api.listSecrets(arg)
+}
+
+func (api *Client) listSecrets(arg params.ListSecretsArgs) ([]SecretDetails, error) {
+	reveal := arg.ShowSecrets
 	var response params.ListSecretResults
 	err := api.facade.FacadeCall("ListSecrets", arg, &response)
 	if err != nil {
@@ -94,6 +125,14 @@ func (api *Client) ListSecrets(reveal bool, filter secrets.Filter) ([]SecretDeta
 				CreateTime:  r.CreateTime,
 				UpdateTime:  r.UpdateTime,
 				ExpireTime:  r.ExpireTime,
+				Author:      r.Author,
+				Rotated:     r.Rotated,
+			}
+			if arg.ShowConsumers {
+				if details.RevisionConsumers == nil {
+					details.RevisionConsumers = make(map[int][]string)
+				}
+				details.RevisionConsumers[r.Revision] = r.Consumers
 			}
 		}
 		if reveal && r.Value != nil {
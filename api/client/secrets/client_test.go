@@ -149,6 +149,71 @@ func (s *SecretsSuite) TestListSecretsError(c *gc.C) {
 	c.Assert(result[0].Error, gc.Equals, "boom")
 }
 
+func (s *SecretsSuite) TestListSecretHistory(c *gc.C) {
+	uri := secrets.NewURI()
+	now := time.Now()
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "ListSecrets")
+			uriStr := uri.String()
+			c.Check(arg, jc.DeepEquals, params.ListSecretsArgs{
+				ShowConsumers: true,
+				Filter:        params.SecretsFilter{URI: &uriStr},
+			})
+			*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+				[]params.ListSecretResult{{
+					URI:            uri.String(),
+					LatestRevision: 2,
+					Revisions: []params.SecretRevision{{
+						Revision:   1,
+						CreateTime: now,
+						Author:     "unit-mysql-0",
+						Consumers:  []string{"unit-gitlab-0"},
+					}, {
+						Revision:   2,
+						CreateTime: now,
+						Author:     "unit-mysql-0",
+						Rotated:    true,
+					}},
+				}},
+			}
+			return nil
+		}), BestVersion: 3,
+	}
+	client := apisecrets.NewClient(apiCaller)
+	result, err := client.ListSecretHistory(secrets.Filter{URI: uri})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Revisions, jc.DeepEquals, []secrets.SecretRevisionMetadata{{
+		Revision:   1,
+		CreateTime: now,
+		Author:     "unit-mysql-0",
+	}, {
+		Revision:   2,
+		CreateTime: now,
+		Author:     "unit-mysql-0",
+		Rotated:    true,
+	}})
+	c.Assert(result[0].RevisionConsumers, jc.DeepEquals, map[int][]string{
+		1: {"unit-gitlab-0"},
+		2: nil,
+	})
+}
+
+func (s *SecretsSuite) TestListSecretHistoryNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected api call")
+			return nil
+		}), BestVersion: 2,
+	}
+	client := apisecrets.NewClient(apiCaller)
+	_, err := client.ListSecretHistory(secrets.Filter{})
+	c.Assert(err, gc.ErrorMatches, "secret history on this juju version not supported")
+}
+
 func (s *SecretsSuite) TestCreateSecretError(c *gc.C) {
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		return nil
@@ -60,6 +60,16 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned. The connection is closed once a record
+	// logged after EndTime is seen.
+	EndTime time.Time
+	// MessageRegex, if set, is a regular expression which the message
+	// of each returned record must match.
+	MessageRegex string
+	// Filter, if set, is a boolean filter expression evaluated by the
+	// server against each record. See core/logger.Filter for the syntax.
+	Filter string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -89,6 +99,15 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
+	if args.Filter != "" {
+		attrs.Set("filter", args.Filter)
+	}
 	return attrs
 }
 
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	base "github.com/juju/juju/api/base"
@@ -67,6 +68,20 @@ func (mr *MockFacadeCallerMockRecorder) FacadeCall(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FacadeCall", reflect.TypeOf((*MockFacadeCaller)(nil).FacadeCall), arg0, arg1, arg2)
 }
 
+// FacadeCallContext mocks base method.
+func (m *MockFacadeCaller) FacadeCallContext(arg0 context.Context, arg1 string, arg2, arg3 any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FacadeCallContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FacadeCallContext indicates an expected call of FacadeCallContext.
+func (mr *MockFacadeCallerMockRecorder) FacadeCallContext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FacadeCallContext", reflect.TypeOf((*MockFacadeCaller)(nil).FacadeCallContext), arg0, arg1, arg2, arg3)
+}
+
 // Name mocks base method.
 func (m *MockFacadeCaller) Name() string {
 	m.ctrl.T.Helper()
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
@@ -48,12 +50,27 @@ func (u *UnitStateAPI) State() (params.UnitStateResult, error) {
 // SetState sets the state persisted by the charm running in this unit
 // and the state internal to the uniter for this unit.
 func (u *UnitStateAPI) SetState(unitState params.SetUnitStateArg) error {
+	return u.setState(u.facade.FacadeCall, unitState)
+}
+
+// SetStateContext is like SetState, but propagates any trace span held
+// in ctx to the API server.
+func (u *UnitStateAPI) SetStateContext(ctx context.Context, unitState params.SetUnitStateArg) error {
+	return u.setState(func(request string, params, response interface{}) error {
+		return u.facade.FacadeCallContext(ctx, request, params, response)
+	}, unitState)
+}
+
+func (u *UnitStateAPI) setState(
+	facadeCall func(request string, params, response interface{}) error,
+	unitState params.SetUnitStateArg,
+) error {
 	unitState.Tag = u.tag.String()
 	var results params.ErrorResults
 	args := params.SetUnitStateArgs{
 		Args: []params.SetUnitStateArg{unitState},
 	}
-	err := u.facade.FacadeCall("SetState", args, &results)
+	err := facadeCall("SetState", args, &results)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/rpc/params"
+)
+
+// RolloutResult describes what was done to advance the rollout of an
+// operation.
+type RolloutResult struct {
+	OperationID string
+	// BatchEnqueued is the number (from 1) of the batch enqueued, if any.
+	BatchEnqueued int
+	// NextBatchIn is how long is left of the delay before the
+	// next batch is enqueued, if the rollout is waiting on it.
+	NextBatchIn time.Duration
+	Finished    bool
+	Error       error
+}
+
+// Client allows access to the action rollout API endpoint.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the action rollout API.
+func NewClient(caller base.APICaller) (*Client, error) {
+	_, isModel := caller.ModelTag()
+	if !isModel {
+		return nil, errors.New("expected model specific API connection")
+	}
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "ActionRollout"),
+	}, nil
+}
+
+// WatchRollouts returns a watcher notifying when an operation of the
+// model is enqueued or changes, including when one of its tasks
+// completes.
+func (c *Client) WatchRollouts() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchRollouts", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, params.TranslateWellKnownError(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// AdvanceRollouts enqueues the next batch of each rolling operation
+// whose current batch has finished, and completes those with no more
+// batches to enqueue.
+func (c *Client) AdvanceRollouts() ([]RolloutResult, error) {
+	var results params.RolloutResults
+	if err := c.facade.FacadeCall("AdvanceRollouts", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]RolloutResult, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseOperationTag(result.OperationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out[i] = RolloutResult{
+			OperationID:   tag.Id(),
+			BatchEnqueued: result.BatchEnqueued,
+			NextBatchIn:   result.NextBatchIn,
+			Finished:      result.Finished,
+		}
+		if result.Error != nil {
+			out[i].Error = result.Error
+		}
+	}
+	return out, nil
+}
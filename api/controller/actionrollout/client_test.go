@@ -0,0 +1,83 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller/actionrollout"
+	"github.com/juju/juju/rpc/params"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func newClient(f basetesting.APICallerFunc) (*actionrollout.Client, error) {
+	return actionrollout.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 1})
+}
+
+func (s *clientSuite) TestAdvanceRollouts(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionRollout")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "AdvanceRollouts")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.RolloutResults{})
+		*(result.(*params.RolloutResults)) = params.RolloutResults{
+			Results: []params.RolloutResult{
+				{OperationTag: "operation-1", BatchEnqueued: 2},
+				{OperationTag: "operation-3", NextBatchIn: time.Minute},
+				{OperationTag: "operation-5", Finished: true},
+				{OperationTag: "operation-7", Error: &params.Error{Message: "boom"}},
+			},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := client.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []actionrollout.RolloutResult{
+		{OperationID: "1", BatchEnqueued: 2},
+		{OperationID: "3", NextBatchIn: time.Minute},
+		{OperationID: "5", Finished: true},
+		{OperationID: "7", Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *clientSuite) TestAdvanceRolloutsError(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.AdvanceRollouts()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) TestWatchRolloutsError(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionRollout")
+		c.Check(request, gc.Equals, "WatchRollouts")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "model not found"},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.WatchRollouts()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/rpc/params"
+)
+
+// ScheduledRunResult describes the run of an action schedule which
+// was due.
+type ScheduledRunResult struct {
+	Schedule string
+	// OperationID identifies the operation started by the run, if any.
+	OperationID string
+	// Skipped records why no operation was started.
+	Skipped string
+	NextRun time.Time
+	Error   error
+}
+
+// Client allows access to the action scheduler API endpoint.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the action scheduler API.
+func NewClient(caller base.APICaller) (*Client, error) {
+	_, isModel := caller.ModelTag()
+	if !isModel {
+		return nil, errors.New("expected model specific API connection")
+	}
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "ActionScheduler"),
+	}, nil
+}
+
+// WatchActionSchedules returns a watcher notifying when an action
+// schedule of the model is added or removed.
+func (c *Client) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, params.TranslateWellKnownError(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// RunDueSchedules starts an operation for each action schedule which
+// is due, and moves each of them on to its next run. It also returns
+// when the first schedule is next due, which is zero if the model has
+// no schedules.
+func (c *Client) RunDueSchedules() ([]ScheduledRunResult, time.Time, error) {
+	var results params.ScheduledRunResults
+	if err := c.facade.FacadeCall("RunDueSchedules", nil, &results); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	out := make([]ScheduledRunResult, len(results.Results))
+	for i, result := range results.Results {
+		out[i] = ScheduledRunResult{
+			Schedule: result.Schedule,
+			Skipped:  result.Skipped,
+			NextRun:  result.NextRun,
+		}
+		if result.OperationTag != "" {
+			tag, err := names.ParseOperationTag(result.OperationTag)
+			if err != nil {
+				return nil, time.Time{}, errors.Trace(err)
+			}
+			out[i].OperationID = tag.Id()
+		}
+		if result.Error != nil {
+			out[i].Error = result.Error
+		}
+	}
+	var nextDue time.Time
+	if results.NextDue != nil {
+		nextDue = *results.NextDue
+	}
+	return out, nextDue, nil
+}
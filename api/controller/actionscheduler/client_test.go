@@ -0,0 +1,99 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller/actionscheduler"
+	"github.com/juju/juju/rpc/params"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func newClient(f basetesting.APICallerFunc) (*actionscheduler.Client, error) {
+	return actionscheduler.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 1})
+}
+
+func (s *clientSuite) TestRunDueSchedules(c *gc.C) {
+	next := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RunDueSchedules")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ScheduledRunResults{})
+		*(result.(*params.ScheduledRunResults)) = params.ScheduledRunResults{
+			Results: []params.ScheduledRunResult{
+				{Schedule: "hourly", OperationTag: "operation-1", NextRun: next},
+				{Schedule: "nightly", Skipped: "no units to run on", NextRun: next},
+				{Schedule: "weekly", Error: &params.Error{Message: "boom"}},
+			},
+			NextDue: &next,
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, nextDue, err := client.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nextDue, gc.Equals, next)
+	c.Assert(results, jc.DeepEquals, []actionscheduler.ScheduledRunResult{
+		{Schedule: "hourly", OperationID: "1", NextRun: next},
+		{Schedule: "nightly", Skipped: "no units to run on", NextRun: next},
+		{Schedule: "weekly", Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *clientSuite) TestRunDueSchedulesNoSchedules(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ScheduledRunResults)) = params.ScheduledRunResults{
+			Results: []params.ScheduledRunResult{},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, nextDue, err := client.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+	c.Assert(nextDue.IsZero(), jc.IsTrue)
+}
+
+func (s *clientSuite) TestRunDueSchedulesError(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = client.RunDueSchedules()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) TestWatchActionSchedulesError(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "WatchActionSchedules")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "model not found"},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
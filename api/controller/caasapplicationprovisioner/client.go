@@ -149,6 +149,8 @@ type ProvisioningInfo struct {
 	CharmURL             *charm.URL
 	Trust                bool
 	Scale                int
+	MaxUnavailable       string
+	SpreadBy             string
 }
 
 // ProvisioningInfo returns the info needed to provision an operator for an application.
@@ -185,6 +187,8 @@ func (c *Client) ProvisioningInfo(applicationName string) (ProvisioningInfo, err
 		CharmModifiedVersion: r.CharmModifiedVersion,
 		Trust:                r.Trust,
 		Scale:                r.Scale,
+		MaxUnavailable:       r.MaxUnavailable,
+		SpreadBy:             r.SpreadBy,
 	}
 	for _, fs := range r.Filesystems {
 		f, err := filesystemFromParams(fs)
@@ -187,6 +187,8 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 				CharmURL:             "ch:charm-1",
 				Trust:                true,
 				Scale:                3,
+				MaxUnavailable:       "1",
+				SpreadBy:             "node",
 			}}}
 		return nil
 	})
@@ -205,6 +207,8 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 		CharmURL:             &charm.URL{Schema: "ch", Name: "charm", Revision: 1},
 		Trust:                true,
 		Scale:                3,
+		MaxUnavailable:       "1",
+		SpreadBy:             "node",
 	})
 }
 
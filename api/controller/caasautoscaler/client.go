@@ -0,0 +1,108 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Application is an application with an autoscale policy.
+type Application struct {
+	Name   string
+	Scale  int
+	Policy application.AutoscalePolicy
+	Status application.AutoscaleStatus
+}
+
+// Client allows access to the CAAS autoscaler API endpoint.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the CAAS autoscaler API.
+func NewClient(caller base.APICaller) (*Client, error) {
+	_, isModel := caller.ModelTag()
+	if !isModel {
+		return nil, errors.New("expected model specific API connection")
+	}
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "CAASAutoscaler"),
+	}, nil
+}
+
+// AutoscaledApplications returns the applications with an autoscale
+// policy.
+func (c *Client) AutoscaledApplications() ([]Application, error) {
+	var result params.AutoscaledApplicationsResult
+	if err := c.facade.FacadeCall("AutoscaledApplications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	apps := make([]Application, len(result.Applications))
+	for i, app := range result.Applications {
+		tag, err := names.ParseApplicationTag(app.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apps[i] = Application{
+			Name:  tag.Id(),
+			Scale: app.Scale,
+			Policy: application.AutoscalePolicy{
+				MinUnits:     app.Policy.MinUnits,
+				MaxUnits:     app.Policy.MaxUnits,
+				TargetCPU:    app.Policy.TargetCPU,
+				TargetMemory: app.Policy.TargetMemory,
+				Cooldown:     app.Policy.Cooldown,
+			},
+			Status: application.AutoscaleStatus{
+				Utilization: application.Utilization{
+					Units:  app.Status.UnitsMeasured,
+					CPU:    app.Status.CPU,
+					Memory: app.Status.Memory,
+				},
+				TargetScale: app.Status.TargetScale,
+				Message:     app.Status.Message,
+			},
+		}
+		if app.Status.LastScaled != nil {
+			apps[i].Status.LastScaled = *app.Status.LastScaled
+		}
+	}
+	return apps, nil
+}
+
+// SetAutoscaleStatus records what the autoscaler observed of the
+// application. If scale is not nil, the application is scaled to it.
+func (c *Client) SetAutoscaleStatus(appName string, status application.AutoscaleStatus, scale *int) error {
+	arg := params.SetAutoscaleStatusArg{
+		ApplicationTag: names.NewApplicationTag(appName).String(),
+		Status: params.AutoscaleStatus{
+			UnitsMeasured: status.Utilization.Units,
+			CPU:           status.Utilization.CPU,
+			Memory:        status.Utilization.Memory,
+			TargetScale:   status.TargetScale,
+			Message:       status.Message,
+		},
+		Scale: scale,
+	}
+	if !status.LastScaled.IsZero() {
+		lastScaled := status.LastScaled
+		arg.Status.LastScaled = &lastScaled
+	}
+	var results params.ErrorResults
+	args := params.SetAutoscaleStatusArgs{
+		Args: []params.SetAutoscaleStatusArg{arg},
+	}
+	if err := c.facade.FacadeCall("SetAutoscaleStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller/caasautoscaler"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func newClient(f basetesting.APICallerFunc) (*caasautoscaler.Client, error) {
+	return caasautoscaler.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 1})
+}
+
+func (s *clientSuite) TestAutoscaledApplications(c *gc.C) {
+	cpu := 72.5
+	lastScaled := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASAutoscaler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "AutoscaledApplications")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.AutoscaledApplicationsResult{})
+		*(result.(*params.AutoscaledApplicationsResult)) = params.AutoscaledApplicationsResult{
+			Applications: []params.AutoscaledApplication{{
+				ApplicationTag: "application-gitlab",
+				Policy: params.AutoscalePolicy{
+					MinUnits:  1,
+					MaxUnits:  5,
+					TargetCPU: 60,
+					Cooldown:  3 * time.Minute,
+				},
+				Status: params.AutoscaleStatus{
+					UnitsMeasured: 2,
+					CPU:           &cpu,
+					TargetScale:   3,
+					LastScaled:    &lastScaled,
+				},
+				Scale: 3,
+			}},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apps, err := client.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, jc.DeepEquals, []caasautoscaler.Application{{
+		Name:  "gitlab",
+		Scale: 3,
+		Policy: application.AutoscalePolicy{
+			MinUnits:  1,
+			MaxUnits:  5,
+			TargetCPU: 60,
+			Cooldown:  3 * time.Minute,
+		},
+		Status: application.AutoscaleStatus{
+			Utilization: application.Utilization{Units: 2, CPU: &cpu},
+			TargetScale: 3,
+			LastScaled:  lastScaled,
+		},
+	}})
+}
+
+func (s *clientSuite) TestAutoscaledApplicationsError(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.AutoscaledApplicationsResult)) = params.AutoscaledApplicationsResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.AutoscaledApplications()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestSetAutoscaleStatus(c *gc.C) {
+	cpu := 90.0
+	lastScaled := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	scale := 4
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASAutoscaler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetAutoscaleStatus")
+		c.Check(arg, jc.DeepEquals, params.SetAutoscaleStatusArgs{
+			Args: []params.SetAutoscaleStatusArg{{
+				ApplicationTag: "application-gitlab",
+				Status: params.AutoscaleStatus{
+					UnitsMeasured: 3,
+					CPU:           &cpu,
+					TargetScale:   4,
+					LastScaled:    &lastScaled,
+				},
+				Scale: &scale,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SetAutoscaleStatus("gitlab", application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 3, CPU: &cpu},
+		TargetScale: 4,
+		LastScaled:  lastScaled,
+	}, &scale)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/common"
 	charmscommon "github.com/juju/juju/api/common/charms"
 	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
@@ -40,6 +41,7 @@ func NewClientLegacy(caller base.APICaller) *Client {
 // ClientSidecar allows access to the CAAS firewaller API endpoint for sidecar applications.
 type ClientSidecar struct {
 	*Client
+	*common.ModelWatcher
 }
 
 // NewClientSidecar returns a client used to access the CAAS unit provisioner API.
@@ -54,6 +56,7 @@ func NewClientSidecar(caller base.APICaller) *ClientSidecar {
 			CharmInfoClient:            charmInfoClient,
 			ApplicationCharmInfoClient: appCharmInfoClient,
 		},
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
 	}
 }
 
@@ -112,6 +115,59 @@ func (c *ClientSidecar) GetOpenedPorts(appName string) (network.GroupedPortRange
 	return out, nil
 }
 
+// ApplicationIngressRules returns the ingress allowed to the given
+// application's pods from the pods of related applications and from the
+// networks the application is exposed to.
+func (c *ClientSidecar) ApplicationIngressRules(appName string) ([]caas.NetworkPolicyRule, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.ApplicationIngressRulesResults
+	if err := c.facade.FacadeCall("ApplicationIngressRules", entities(appTag), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	rules := make([]caas.NetworkPolicyRule, len(results.Results[0].Rules))
+	for i, rule := range results.Results[0].Rules {
+		rules[i] = caas.NetworkPolicyRule{
+			FromApplication: rule.SourceApplication,
+			FromCIDRs:       rule.SourceCIDRs,
+		}
+		for _, pr := range rule.PortRanges {
+			rules[i].PortRanges = append(rules[i].PortRanges, pr.NetworkPortRange())
+		}
+	}
+	return rules, nil
+}
+
+// WatchApplicationIngressRules returns a NotifyWatcher that notifies
+// when the given application's relations are added, removed, suspended
+// or resumed, or when the ingress networks of its cross model relations
+// change.
+func (c *ClientSidecar) WatchApplicationIngressRules(appName string) (watcher.NotifyWatcher, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationIngressRules", entities(appTag), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0]), nil
+}
+
 func applicationTag(application string) (names.ApplicationTag, error) {
 	if !names.IsValidApplication(application) {
 		return names.ApplicationTag{}, errors.NotValidf("application name %q", application)
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller/caasfirewaller"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
@@ -127,6 +128,66 @@ func (s *firewallerSidecarSuite) TestGetOpenedPorts(c *gc.C) {
 	})
 }
 
+func (s *firewallerSidecarSuite) TestApplicationIngressRules(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ApplicationIngressRules")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ApplicationIngressRulesResults{})
+		*(result.(*params.ApplicationIngressRulesResults)) = params.ApplicationIngressRulesResults{
+			Results: []params.ApplicationIngressRulesResult{{
+				Rules: []params.ApplicationIngressRule{{
+					SourceApplication: "postgresql",
+					PortRanges:        []params.PortRange{{FromPort: 5432, ToPort: 5432, Protocol: "tcp"}},
+				}, {
+					SourceCIDRs: []string{"0.0.0.0/0"},
+					PortRanges:  []params.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+				}},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientSidecar(apiCaller)
+	rules, err := client.ApplicationIngressRules("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []caas.NetworkPolicyRule{{
+		FromApplication: "postgresql",
+		PortRanges:      []network.PortRange{network.MustParsePortRange("5432/tcp")},
+	}, {
+		FromCIDRs:  []string{"0.0.0.0/0"},
+		PortRanges: []network.PortRange{network.MustParsePortRange("80/tcp")},
+	}})
+}
+
+func (s *firewallerSidecarSuite) TestWatchApplicationIngressRules(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationIngressRules")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: `application "gitlab" not found`},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientSidecar(apiCaller)
+	watcher, err := client.WatchApplicationIngressRules("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *firewallerBaseSuite) TestIsExposed(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, s.objType)
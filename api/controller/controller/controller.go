@@ -327,16 +327,63 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := initiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// DryRunMigration checks whether a model could be migrated to another
+// controller, without starting a migration, and returns a report of
+// everything which was found.
+func (c *Client) DryRunMigration(spec MigrationSpec) (params.MigrationReport, error) {
+	if c.BestAPIVersion() < 12 {
+		return params.MigrationReport{}, errors.NotSupportedf("DryRunMigration")
+	}
+	args, err := initiateMigrationArgs(spec)
+	if err != nil {
+		return params.MigrationReport{}, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("DryRunMigration", args, &response); err != nil {
+		return params.MigrationReport{}, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return params.MigrationReport{}, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return params.MigrationReport{}, errors.Trace(result.Error)
+	}
+	if result.Report == nil {
+		return params.MigrationReport{}, errors.New("missing migration report")
+	}
+	return *result.Report, nil
+}
+
+func initiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -349,19 +396,61 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
+	}, nil
+}
+
+// ResumeMigration resumes the failed migration of the specified
+// model, retrying the phase which failed.
+func (c *Client) ResumeMigration(modelUUID string) error {
+	return errors.Trace(c.failedMigrationCall("ResumeMigration", modelUUID))
+}
+
+// AbortMigration aborts the failed migration of the specified model.
+func (c *Client) AbortMigration(modelUUID string) error {
+	return errors.Trace(c.failedMigrationCall("AbortMigration", modelUUID))
+}
+
+// MigrationProgress returns the progress of the latest migration of
+// each of the specified models, in the same order. Models which have
+// been migrated away report the DONE phase.
+func (c *Client) MigrationProgress(modelUUIDs ...string) ([]params.MigrationProgressResult, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotSupportedf("MigrationProgress")
 	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
+	args := params.Entities{
+		Entities: make([]params.Entity, len(modelUUIDs)),
 	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
+	for i, modelUUID := range modelUUIDs {
+		if !names.IsValidModel(modelUUID) {
+			return nil, errors.NotValidf("model UUID %q", modelUUID)
+		}
+		args.Entities[i].Tag = names.NewModelTag(modelUUID).String()
 	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
+	var response params.MigrationProgressResults
+	if err := c.facade.FacadeCall("MigrationProgress", args, &response); err != nil {
+		return nil, errors.Trace(err)
 	}
-	return result.MigrationId, nil
+	if len(response.Results) != len(modelUUIDs) {
+		return nil, errors.Errorf("expected %d results, got %d", len(modelUUIDs), len(response.Results))
+	}
+	return response.Results, nil
+}
+
+func (c *Client) failedMigrationCall(method, modelUUID string) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("resuming or aborting a failed migration on this version of Juju")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.NotValidf("model UUID %q", modelUUID)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -171,6 +171,156 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestResumeMigration(c *gc.C) {
+	s.checkFailedMigrationCall(c, "ResumeMigration", (*controller.Client).ResumeMigration)
+}
+
+func (s *Suite) TestAbortMigration(c *gc.C) {
+	s.checkFailedMigrationCall(c, "AbortMigration", (*controller.Client).AbortMigration)
+}
+
+func (s *Suite) checkFailedMigrationCall(c *gc.C, method string, call func(*controller.Client, string) error) {
+	modelUUID := utils.MustNewUUID().String()
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: apiservererrors.ServerError(errors.NotValidf("migration")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := call(client, modelUUID)
+	c.Assert(err, gc.ErrorMatches, "migration not valid")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller." + method, []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+		}}},
+	})
+}
+
+func (s *Suite) TestResumeMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ResumeMigration(utils.MustNewUUID().String())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestMigrationProgress(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	results := []params.MigrationProgressResult{{
+		ModelTag:    names.NewModelTag(modelUUID).String(),
+		MigrationId: modelUUID + ":0",
+		Phase:       "IMPORT",
+		FailedPhase: "IMPORT",
+	}}
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.MigrationProgressResults) = params.MigrationProgressResults{Results: results}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	out, err := client.MigrationProgress(modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, results)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationProgress", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+		}}},
+	})
+}
+
+func (s *Suite) TestMigrationProgressNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationProgress(utils.MustNewUUID().String())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) makeDryRunClient(results params.MigrationDryRunResults) (*controller.Client, *jujutesting.Stub) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.MigrationDryRunResults) = results
+			return nil
+		},
+	}
+	return controller.NewClient(apiCaller), &stub
+}
+
+func (s *Suite) TestDryRunMigration(c *gc.C) {
+	report := params.MigrationReport{
+		Blockers: []params.MigrationIssue{{Controller: "target", Message: "upgrade in progress"}},
+		Cloud:    "aws",
+		Tools:    []params.MigrationBinary{{Name: "3.1.0-ubuntu-amd64", Size: 1024}},
+	}
+	client, stub := s.makeDryRunClient(params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{Report: &report}},
+	})
+	spec := makeSpec()
+	out, err := client.DryRunMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, report)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestDryRunMigrationError(c *gc.C) {
+	client, _ := s.makeDryRunClient(params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			Error: apiservererrors.ServerError(errors.New("boom")),
+		}},
+	})
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestDryRunMigrationValidationError(c *gc.C) {
+	client, stub := s.makeDryRunClient(params.MigrationDryRunResults{})
+	spec := makeSpec()
+	spec.ModelUUID = "not-a-uuid"
+	_, err := client.DryRunMigration(spec)
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: model UUID not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestDryRunMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -98,6 +98,22 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 		}
 	}
 
+	var checkpoint migration.Checkpoint
+	if cp := status.Checkpoint; cp != nil {
+		checkpoint = migration.Checkpoint{
+			Imported:  cp.Imported,
+			Charms:    cp.Charms,
+			Tools:     cp.Tools,
+			Resources: cp.Resources,
+			LogsSent:  cp.LogsSent,
+		}
+		if cp.FailedPhase != "" {
+			if checkpoint.FailedPhase, ok = migration.ParsePhase(cp.FailedPhase); !ok {
+				return empty, errors.New("unable to parse failed phase")
+			}
+		}
+	}
+
 	return migration.MigrationStatus{
 		MigrationId:      status.MigrationId,
 		ModelUUID:        modelTag.Id(),
@@ -111,9 +127,43 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		Checkpoint: checkpoint,
 	}, nil
 }
 
+// WatchStatus returns a watcher which reports when the status of the
+// model's migration changes, such as when a failed migration is
+// resumed.
+func (c *Client) WatchStatus() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := c.caller.FacadeCall("WatchStatus", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return c.newWatcher(c.caller.RawAPICaller(), result), nil
+}
+
+// SetCheckpoint records the progress made by the currently active
+// model migration.
+func (c *Client) SetCheckpoint(checkpoint migration.Checkpoint) error {
+	args := params.SetMigrationCheckpointArgs{
+		Checkpoint: params.MigrationCheckpoint{
+			Imported:  checkpoint.Imported,
+			Charms:    checkpoint.Charms,
+			Tools:     checkpoint.Tools,
+			Resources: checkpoint.Resources,
+			LogsSent:  checkpoint.LogsSent,
+		},
+	}
+	if checkpoint.IsFailed() {
+		args.Checkpoint.FailedPhase = checkpoint.FailedPhase.String()
+	}
+	return c.caller.FacadeCall("SetCheckpoint", args, nil)
+}
+
 // SetPhase updates the phase of the currently active model migration.
 func (c *Client) SetPhase(phase migration.Phase) error {
 	args := params.SetMigrationPhaseArgs{
@@ -99,6 +99,11 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			MigrationId:      "id",
 			Phase:            "IMPORT",
 			PhaseChangedTime: timestamp,
+			Checkpoint: &params.MigrationCheckpoint{
+				FailedPhase: "IMPORT",
+				Imported:    true,
+				Charms:      []string{"ch:foo-1"},
+			},
 		}
 		return nil
 	})
@@ -122,6 +127,11 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		Checkpoint: migration.Checkpoint{
+			FailedPhase: migration.IMPORT,
+			Imported:    true,
+			Charms:      []string{"ch:foo-1"},
+		},
 	})
 }
 
@@ -149,6 +159,55 @@ func (s *ClientSuite) TestSetPhaseError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestWatchStatus(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			NotifyWatcherId: "123",
+		}
+		return nil
+	})
+	expectWatch := &struct{ watcher.NotifyWatcher }{}
+	newWatcher := func(caller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(caller, gc.NotNil)
+		c.Check(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "123"})
+		return expectWatch
+	}
+	client := migrationmaster.NewClient(apiCaller, newWatcher)
+	w, err := client.WatchStatus()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWatch)
+	stub.CheckCalls(c, []jujutesting.StubCall{{FuncName: "MigrationMaster.WatchStatus", Args: []interface{}{"", nil}}})
+}
+
+func (s *ClientSuite) TestSetCheckpoint(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetCheckpoint(migration.Checkpoint{
+		FailedPhase: migration.IMPORT,
+		Imported:    true,
+		Resources:   []string{"foo/bar"},
+		LogsSent:    10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationCheckpointArgs{
+		Checkpoint: params.MigrationCheckpoint{
+			FailedPhase: "IMPORT",
+			Imported:    true,
+			Resources:   []string{"foo/bar"},
+			LogsSent:    10,
+		},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "MigrationMaster.SetCheckpoint", Args: []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestSetStatusMessage(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
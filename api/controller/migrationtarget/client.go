@@ -51,6 +51,26 @@ func (c *Client) BestFacadeVersion() int {
 // Prechecks checks that the target controller is able to accept the
 // model being migrated.
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
+	return errors.Trace(c.caller.FacadeCall("Prechecks", migrationModelInfo(model), nil))
+}
+
+// DryRunImport asks the target controller to check whether the
+// serialized model could be imported, without importing it. All of
+// the problems found are reported.
+func (c *Client) DryRunImport(model coremigration.ModelInfo, bytes []byte) (params.MigrationTargetReport, error) {
+	var report params.MigrationTargetReport
+	if c.caller.BestAPIVersion() < 4 {
+		return report, errors.NotSupportedf("DryRunImport")
+	}
+	args := params.DryRunImportArgs{
+		Model: migrationModelInfo(model),
+		Bytes: bytes,
+	}
+	err := c.caller.FacadeCall("DryRunImport", args, &report)
+	return report, errors.Trace(err)
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
 	// Pass all the known facade versions to the controller so that it
 	// can check that the target controller supports them. Passing all of them
 	// ensures that we don't have to update this code when new facades are
@@ -61,7 +81,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		versions[name] = version
 	}
 
-	args := params.MigrationModelInfo{
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
@@ -69,7 +89,6 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		ControllerAgentVersion: model.ControllerAgentVersion,
 		FacadeVersions:         versions,
 	}
-	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
 }
 
 // Import takes a serialized model and imports it into the target
@@ -84,6 +84,48 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	c.Assert(arg, mc, expectedArg)
 }
 
+func (s *ClientSuite) TestDryRunImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*result.(*params.MigrationTargetReport) = params.MigrationTargetReport{
+			Blockers: []string{"model named \"name\" already exists"},
+			Warnings: []string{"credential will be created"},
+		}
+		return nil
+	}), BestVersion: 4}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	report, err := client.DryRunImport(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.MigrationTargetReport{
+		Blockers: []string{"model named \"name\" already exists"},
+		Warnings: []string{"credential will be created"},
+	})
+
+	stub.CheckCallNames(c, "MigrationTarget.DryRunImport")
+	arg := stub.Calls()[0].Args[1].(params.DryRunImportArgs)
+	c.Assert(arg.Bytes, jc.DeepEquals, []byte("foo"))
+	c.Assert(arg.Model.UUID, gc.Equals, "uuid")
+	c.Assert(arg.Model.OwnerTag, gc.Equals, ownerTag.String())
+	c.Assert(arg.Model.FacadeVersions, gc.Not(gc.HasLen), 0)
+}
+
+func (s *ClientSuite) TestDryRunImportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.DryRunImport(coremigration.ModelInfo{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -0,0 +1,158 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/rpc/params"
+)
+
+// The statuses of a secrets migration.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Revision identifies a secret revision.
+type Revision struct {
+	URI      string
+	Revision int
+}
+
+// Failure records a secret revision which could not be moved.
+type Failure struct {
+	Revision
+	Message string
+}
+
+// Migration describes a model's secrets migration.
+type Migration struct {
+	BackendID string
+	Status    string
+	Total     int
+	Moved     int
+	Failures  []Failure
+
+	// Revisions are the revisions still to be moved.
+	Revisions []Revision
+}
+
+// Finished returns true if the migration will move no more revisions.
+func (m Migration) Finished() bool {
+	return m.Status == StatusCompleted || m.Status == StatusFailed
+}
+
+// Client allows access to the secrets migration API endpoint.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the secrets migration API.
+func NewClient(caller base.APICaller) (*Client, error) {
+	_, isModel := caller.ModelTag()
+	if !isModel {
+		return nil, errors.New("expected model specific API connection")
+	}
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "SecretsMigration"),
+	}, nil
+}
+
+// WatchSecretsMigration returns a watcher notifying when the model's
+// secrets migration is started or makes progress.
+func (c *Client) WatchSecretsMigration() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchSecretsMigration", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, params.TranslateWellKnownError(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// GetSecretsMigration returns the model's current or most recent
+// secrets migration, or a NotFound error if there is none.
+func (c *Client) GetSecretsMigration() (Migration, error) {
+	var result params.SecretsMigrationResult
+	if err := c.facade.FacadeCall("GetSecretsMigration", nil, &result); err != nil {
+		return Migration{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return Migration{}, params.TranslateWellKnownError(result.Error)
+	}
+	m := Migration{
+		BackendID: result.BackendID,
+		Status:    result.Status,
+		Total:     result.Total,
+		Moved:     result.Moved,
+	}
+	for _, f := range result.Failures {
+		m.Failures = append(m.Failures, Failure{
+			Revision: Revision{URI: f.URI, Revision: f.Revision},
+			Message:  f.Message,
+		})
+	}
+	for _, rev := range result.Revisions {
+		m.Revisions = append(m.Revisions, Revision{URI: rev.URI, Revision: rev.Revision})
+	}
+	return m, nil
+}
+
+// MigrateSecretRevisions moves the content of the given secret revisions
+// to the given backend, returning an error for each revision which could
+// not be moved.
+func (c *Client) MigrateSecretRevisions(backendID string, revisions []Revision) ([]error, error) {
+	arg := params.MigrateSecretRevisionsArgs{
+		BackendID: backendID,
+		Revisions: make([]params.SecretsMigrationRevision, len(revisions)),
+	}
+	for i, rev := range revisions {
+		arg.Revisions[i] = params.SecretsMigrationRevision{URI: rev.URI, Revision: rev.Revision}
+	}
+	var results params.MigrateSecretRevisionResults
+	if err := c.facade.FacadeCall("MigrateSecretRevisions", arg, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(revisions) {
+		return nil, errors.Errorf("expected %d results, got %d", len(revisions), len(results.Results))
+	}
+	errs := make([]error, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			errs[i] = result.Error
+		}
+	}
+	return errs, nil
+}
+
+// SetSecretsMigrationProgress records the progress of the model's
+// secrets migration.
+func (c *Client) SetSecretsMigrationProgress(status string, moved int, failures []Failure) error {
+	arg := params.SecretsMigrationProgressArg{
+		Status: status,
+		Moved:  moved,
+	}
+	for _, f := range failures {
+		arg.Failures = append(arg.Failures, params.SecretsMigrationFailure{
+			URI:      f.URI,
+			Revision: f.Revision.Revision,
+			Message:  f.Message,
+		})
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SetSecretsMigrationProgress", arg, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.TranslateWellKnownError(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller/secretsmigration"
+	"github.com/juju/juju/rpc/params"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func newClient(f basetesting.APICallerFunc) (*secretsmigration.Client, error) {
+	return secretsmigration.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 1})
+}
+
+func (s *clientSuite) TestGetSecretsMigration(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsMigration")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "GetSecretsMigration")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.SecretsMigrationResult{})
+		*(result.(*params.SecretsMigrationResult)) = params.SecretsMigrationResult{
+			BackendID: "backend-id",
+			Status:    "running",
+			Total:     3,
+			Moved:     1,
+			Failures:  []params.SecretsMigrationFailure{{URI: "secret:a", Revision: 1, Message: "boom"}},
+			Revisions: []params.SecretsMigrationRevision{{URI: "secret:b", Revision: 2}},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := client.GetSecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, secretsmigration.Migration{
+		BackendID: "backend-id",
+		Status:    secretsmigration.StatusRunning,
+		Total:     3,
+		Moved:     1,
+		Failures: []secretsmigration.Failure{{
+			Revision: secretsmigration.Revision{URI: "secret:a", Revision: 1},
+			Message:  "boom",
+		}},
+		Revisions: []secretsmigration.Revision{{URI: "secret:b", Revision: 2}},
+	})
+	c.Assert(m.Finished(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestGetSecretsMigrationNotFound(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretsMigrationResult)) = params.SecretsMigrationResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "secrets migration not found"},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.GetSecretsMigration()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *clientSuite) TestMigrateSecretRevisions(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsMigration")
+		c.Check(request, gc.Equals, "MigrateSecretRevisions")
+		c.Check(arg, jc.DeepEquals, params.MigrateSecretRevisionsArgs{
+			BackendID: "backend-id",
+			Revisions: []params.SecretsMigrationRevision{
+				{URI: "secret:a", Revision: 1},
+				{URI: "secret:b", Revision: 2},
+			},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.MigrateSecretRevisionResults{})
+		*(result.(*params.MigrateSecretRevisionResults)) = params.MigrateSecretRevisionResults{
+			Results: []params.MigrateSecretRevisionResult{
+				{URI: "secret:a", Revision: 1},
+				{URI: "secret:b", Revision: 2, Error: &params.Error{Message: "boom"}},
+			},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := client.MigrateSecretRevisions("backend-id", []secretsmigration.Revision{
+		{URI: "secret:a", Revision: 1},
+		{URI: "secret:b", Revision: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestSetSecretsMigrationProgress(c *gc.C) {
+	client, err := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsMigration")
+		c.Check(request, gc.Equals, "SetSecretsMigrationProgress")
+		c.Check(arg, jc.DeepEquals, params.SecretsMigrationProgressArg{
+			Status:   "failed",
+			Moved:    1,
+			Failures: []params.SecretsMigrationFailure{{URI: "secret:a", Revision: 1, Message: "boom"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "finished"},
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.SetSecretsMigrationProgress(secretsmigration.StatusFailed, 1, []secretsmigration.Failure{{
+		Revision: secretsmigration.Revision{URI: "secret:a", Revision: 1},
+		Message:  "boom",
+	}})
+	c.Assert(err, gc.ErrorMatches, "finished")
+}
@@ -21,27 +21,30 @@ func SupportedFacadeVersions() facades.FacadeVersions {
 // New facades should start at 1.
 // We no longer support facade versions at 0.
 var facadeVersions = facades.FacadeVersions{
-	"Action":                       {7},
+	"Action":                       {7, 8, 9},
 	"ActionPruner":                 {1},
-	"Agent":                        {3},
+	"ActionRollout":                {1},
+	"ActionScheduler":              {1},
+	"Agent":                        {3, 4},
 	"AgentLifeFlag":                {1},
 	"AgentTools":                   {1},
 	"AllModelWatcher":              {4},
 	"AllWatcher":                   {3},
 	"Annotations":                  {2},
-	"Application":                  {15, 16, 17, 18, 19},
+	"Application":                  {15, 16, 17, 18, 19, 20},
 	"ApplicationOffers":            {4},
 	"ApplicationScaler":            {1},
-	"Backups":                      {3},
+	"Backups":                      {3, 4, 5},
 	"Block":                        {2},
 	"Bundle":                       {6},
 	"CAASAgent":                    {2},
 	"CAASAdmission":                {1},
 	"CAASApplication":              {1},
 	"CAASApplicationProvisioner":   {1},
+	"CAASAutoscaler":               {1},
 	"CAASModelConfigManager":       {1},
 	"CAASFirewaller":               {1},
-	"CAASFirewallerSidecar":        {1},
+	"CAASFirewallerSidecar":        {1, 2},
 	"CAASModelOperator":            {1},
 	"CAASOperator":                 {1},
 	"CAASOperatorProvisioner":      {1},
@@ -53,7 +56,7 @@ var facadeVersions = facades.FacadeVersions{
 	"Cleaner":                      {2},
 	"Client":                       {6, 7},
 	"Cloud":                        {7},
-	"Controller":                   {11},
+	"Controller":                   {11, 12},
 	"CredentialManager":            {1},
 	"CredentialValidator":          {2},
 	"CrossController":              {1},
@@ -88,10 +91,10 @@ var facadeVersions = facades.FacadeVersions{
 	"MetricsDebug":                 {2},
 	"MetricsManager":               {1},
 	"MigrationFlag":                {1},
-	"MigrationMaster":              {3},
+	"MigrationMaster":              {3, 4},
 	"MigrationMinion":              {1},
 	"MigrationStatusWatcher":       {1},
-	"MigrationTarget":              {1, 2, 3},
+	"MigrationTarget":              {1, 2, 3, 4},
 	"ModelConfig":                  {3},
 	"ModelGeneration":              {4},
 	"ModelManager":                 {9},
@@ -113,13 +116,14 @@ var facadeVersions = facades.FacadeVersions{
 	"ResourcesHookContext":         {1},
 	"RetryStrategy":                {1},
 	"SecretsTriggerWatcher":        {1},
-	"SecretBackends":               {1},
+	"SecretBackends":               {1, 2},
 	"SecretBackendsManager":        {1},
 	"SecretBackendsRotateWatcher":  {1},
 	"SecretsRevisionWatcher":       {1},
-	"Secrets":                      {1, 2},
+	"Secrets":                      {1, 2, 3},
 	"SecretsManager":               {1, 2},
 	"SecretsDrain":                 {1},
+	"SecretsMigration":             {1},
 	"UserSecretsDrain":             {1},
 	"UserSecretsManager":           {1},
 	"Singular":                     {2},
@@ -145,6 +145,18 @@ func (a *admin) login(ctx context.Context, req params.LoginRequest, loginVersion
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	// Calls made by controller agents are never rate limited, as doing
+	// so would slow the controller's own workers, and are the only calls
+	// allowed while the controller is being restored.
+	if !authResult.controllerMachineLogin {
+		apiRoot = restrictRoot(apiRoot, rejectDuringRestore(a.srv.restoreInProgress))
+		apiRoot = restrictRoot(apiRoot, rateLimitCalls(
+			a.srv.getAPIRateLimiter,
+			a.srv.metricsCollector,
+			a.rateLimitEntity(authResult),
+			a.root.model.UUID(),
+		))
+	}
 
 	var facadeFilters []facadeFilterFunc
 	var modelTag string
@@ -179,6 +191,20 @@ func (a *admin) login(ctx context.Context, req params.LoginRequest, loginVersion
 	}, nil
 }
 
+// rateLimitEntity returns the name of the logged in entity used by
+// user scoped rate limits. Users are named as in the rules; agents by
+// their tag, so they can't share a bucket with a user of the same name.
+func (a *admin) rateLimitEntity(authResult *authResult) string {
+	switch {
+	case authResult.anonymousLogin:
+		return api.AnonymousUsername
+	case authResult.userLogin:
+		return a.root.authInfo.Entity.Tag().Id()
+	default:
+		return a.root.authInfo.Entity.Tag().String()
+	}
+}
+
 func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult, cfg auditlog.Config) (*auditlog.Recorder, error) {
 	if !authResult.userLogin || !cfg.Enabled {
 		return nil, nil
@@ -73,9 +73,12 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollout"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
+	"github.com/juju/juju/apiserver/facades/controller/caasautoscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasmodelconfigmanager"
 	"github.com/juju/juju/apiserver/facades/controller/caasmodeloperator"
@@ -101,6 +104,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/secretbackendmanager"
+	"github.com/juju/juju/apiserver/facades/controller/secretsmigration"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
@@ -184,6 +188,8 @@ func AllFacades() *facade.Registry {
 
 	action.Register(registry)
 	actionpruner.Register(registry)
+	actionrollout.Register(registry)
+	actionscheduler.Register(registry)
 	agent.Register(registry)
 	agenttools.Register(registry)
 	annotations.Register(registry)
@@ -206,6 +212,7 @@ func AllFacades() *facade.Registry {
 	caasagent.Register(registry)
 	caasapplication.Register(registry)
 	caasapplicationprovisioner.Register(registry)
+	caasautoscaler.Register(registry)
 	caasfirewaller.Register(registry)
 	caasoperator.Register(registry)
 	caasmodeloperator.Register(registry)
@@ -269,6 +276,7 @@ func AllFacades() *facade.Registry {
 	secretbackendmanager.Register(registry)
 	secretsmanager.Register(registry)
 	secretsdrain.Register(registry)
+	secretsmigration.Register(registry)
 	usersecrets.Register(registry)
 	usersecretsdrain.Register(registry)
 	sshclient.Register(registry)
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,10 +46,13 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
+	corecharm "github.com/juju/juju/core/charm"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
+	coreratelimit "github.com/juju/juju/core/ratelimit"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pubsub/apiserver"
 	controllermsg "github.com/juju/juju/pubsub/controller"
@@ -118,6 +122,15 @@ type Server struct {
 	agentRateLimitRate time.Duration
 	agentRateLimit     *ratelimit.Bucket
 
+	// apiRateLimiter applies the API rate limits, from controller
+	// config, to calls made by users and non-controller agents. It is
+	// nil if there are no limits.
+	apiRateLimiter *coreratelimit.Limiter
+
+	// restoring is true while the controller databases are being
+	// restored from a backup.
+	restoring bool
+
 	// resourceLock is used to limit the number of
 	// concurrent resource downloads to units.
 	resourceLock resource.ResourceDownloadLock
@@ -307,6 +320,8 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to get controller config")
 	}
 
+	charmCache := newCharmCache(cfg.DataDir, controllerConfig, cfg.MetricsCollector)
+
 	shared, err := newSharedServerContext(sharedServerConfig{
 		statePool:           cfg.StatePool,
 		controller:          cfg.Controller,
@@ -317,6 +332,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("juju.apiserver"),
 		charmhubHTTPClient:  cfg.CharmhubHTTPClient,
+		charmCache:          charmCache,
 		dbGetter:            cfg.DBGetter,
 	})
 	if err != nil {
@@ -383,6 +399,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		healthStatus: "starting",
 	}
 	srv.updateAgentRateLimiter(controllerConfig)
+	srv.updateAPIRateLimiter(controllerConfig)
 	srv.updateResourceDownloadLimiters(controllerConfig)
 
 	// We are able to get the current controller config before subscribing to changes
@@ -396,19 +413,38 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 				return
 			}
 			srv.updateAgentRateLimiter(data.Config)
+			srv.updateAPIRateLimiter(data.Config)
 			srv.updateResourceDownloadLimiters(data.Config)
 		})
 	if err != nil {
 		logger.Criticalf("programming error in subscribe function: %v", err)
 		return nil, errors.Trace(err)
 	}
+	unsubscribeRestoring, err := cfg.Hub.Subscribe(
+		controllermsg.Restoring,
+		func(topic string, data controllermsg.RestoringMessage, err error) {
+			if err != nil {
+				logger.Criticalf("programming error in %s message data: %v", topic, err)
+				return
+			}
+			srv.setRestoreInProgress(data.InProgress)
+		})
+	if err != nil {
+		unsubscribeControllerConfig()
+		logger.Criticalf("programming error in subscribe function: %v", err)
+		return nil, errors.Trace(err)
+	}
+	unsubscribeControllerMessages := func() {
+		unsubscribeControllerConfig()
+		unsubscribeRestoring()
+	}
 
 	srv.shared.cancel = srv.tomb.Dying()
 
 	// The auth context for authenticating access to application offers.
 	srv.offerAuthCtxt, err = newOfferAuthcontext(cfg.StatePool)
 	if err != nil {
-		unsubscribeControllerConfig()
+		unsubscribeControllerMessages()
 		return nil, errors.Trace(err)
 	}
 
@@ -434,7 +470,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		srv.tomb.Kill(dependency.ErrBounce)
 	})
 	if err != nil {
-		unsubscribeControllerConfig()
+		unsubscribeControllerMessages()
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
@@ -444,7 +480,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
 		defer unsubscribe()
-		defer unsubscribeControllerConfig()
+		defer unsubscribeControllerMessages()
 		return srv.loop(ready)
 	})
 
@@ -520,6 +556,43 @@ func (srv *Server) updateAgentRateLimiter(cfg controller.Config) {
 	}
 }
 
+func (srv *Server) updateAPIRateLimiter(cfg controller.Config) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	rules := cfg.APIRateLimitRules()
+	if len(rules) == 0 {
+		srv.apiRateLimiter = nil
+		return
+	}
+	// Keep the existing limiter, and the state of its buckets, if the
+	// rules haven't changed.
+	if srv.apiRateLimiter != nil && reflect.DeepEqual(srv.apiRateLimiter.Rules(), rules) {
+		return
+	}
+	srv.apiRateLimiter = coreratelimit.NewLimiter(rules, srv.clock)
+}
+
+func (srv *Server) setRestoreInProgress(restoring bool) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if restoring {
+		logger.Infof("restore in progress; refusing calls other than from controller agents")
+	}
+	srv.restoring = restoring
+}
+
+func (srv *Server) restoreInProgress() bool {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.restoring
+}
+
+func (srv *Server) getAPIRateLimiter() *coreratelimit.Limiter {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.apiRateLimiter
+}
+
 func (srv *Server) updateResourceDownloadLimiters(cfg controller.Config) {
 	srv.mu.Lock()
 	defer srv.mu.Unlock()
@@ -557,6 +630,55 @@ func (srv *Server) getAgentToken() error {
 	return nil
 }
 
+// newCharmCache returns the cache of downloaded charms shared by all
+// the models on the controller, or nil if it can't be created. Failing
+// to create the cache isn't fatal, as charms are then downloaded every
+// time they're needed.
+func newCharmCache(dataDir string, controllerConfig controller.Config, collector *Collector) *charmdownloader.Cache {
+	if dataDir == "" {
+		return nil
+	}
+	charmCache, err := charmdownloader.NewCache(charmdownloader.CacheConfig{
+		Dir:     filepath.Join(dataDir, "charm-cache"),
+		MaxSize: charmCacheSize(controllerConfig),
+		Logger:  logger.Child("charmcache"),
+		Metrics: charmCacheMetricsCollectorWrapper{collector: collector},
+	})
+	if err != nil {
+		logger.Warningf("unable to create charm cache: %v", err)
+		return nil
+	}
+	return charmCache
+}
+
+// charmCacheSize returns the maximum size in bytes of the charm cache.
+func charmCacheSize(controllerConfig controller.Config) int64 {
+	return int64(controllerConfig.CharmCacheSizeMB()) * 1024 * 1024
+}
+
+// charmCacheMetricsCollectorWrapper defines a wrapper for recording the
+// activity of the charm cache with the metrics collector.
+type charmCacheMetricsCollectorWrapper struct {
+	collector *Collector
+}
+
+func (w charmCacheMetricsCollectorWrapper) Hit() {
+	w.collector.CharmCacheRequests.WithLabelValues("hit").Inc()
+}
+
+func (w charmCacheMetricsCollectorWrapper) Miss() {
+	w.collector.CharmCacheRequests.WithLabelValues("miss").Inc()
+}
+
+func (w charmCacheMetricsCollectorWrapper) Evicted() {
+	w.collector.CharmCacheEvictions.Inc()
+}
+
+func (w charmCacheMetricsCollectorWrapper) Usage(size int64, entries int) {
+	w.collector.CharmCacheSize.Set(float64(size))
+	w.collector.CharmCacheEntries.Set(float64(entries))
+}
+
 // logsinkMetricsCollectorWrapper defines a wrapper for exposing the essentials
 // for the logsink api handler to interact with the metrics collector.
 type logsinkMetricsCollectorWrapper struct {
@@ -797,6 +919,14 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 			}
 			return nil
 		},
+		SigningPolicyFunc: func(req *http.Request, what string) error {
+			st, err := httpCtxt.stateForRequestUnauthenticated(req)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer st.Release()
+			return checkUploadSigningPolicy(st.State, what, corecharm.ResourceSignatureNamespace)
+		},
 	}
 	unitResourcesHandler := &UnitResourcesHandler{
 		NewOpener: func(req *http.Request, tagKinds ...string) (resources.Opener, state.PoolHelper, error) {
@@ -39,6 +39,10 @@ const (
 
 	// MetricLabelVersion is the metric for the Juju Version of the controller
 	MetricLabelVersion = "version"
+
+	// MetricLabelResult defines a result constant for the
+	// CharmCacheRequests Label
+	MetricLabelResult = "result"
 )
 
 // MetricAPIConnectionsLabelNames defines a series of labels for the
@@ -76,6 +80,20 @@ var MetricTotalRequestsLabelNames = []string{
 	MetricLabelHost,
 }
 
+// MetricRateLimitedRequestsLabelNames defines a series of labels for the
+// RateLimitedRequests metric.
+var MetricRateLimitedRequestsLabelNames = []string{
+	MetricLabelModelUUID,
+	metricobserver.MetricLabelFacade,
+	metricobserver.MetricLabelMethod,
+}
+
+// MetricCharmCacheRequestsLabelNames defines a series of labels for the
+// CharmCacheRequests metric.
+var MetricCharmCacheRequestsLabelNames = []string{
+	MetricLabelResult,
+}
+
 // Collector is a prometheus.Collector that collects metrics based
 // on apiserver status.
 type Collector struct {
@@ -94,6 +112,13 @@ type Collector struct {
 	TotalRequests         *prometheus.CounterVec
 	TotalRequestErrors    *prometheus.CounterVec
 	TotalRequestsDuration *prometheus.SummaryVec
+
+	RateLimitedRequests *prometheus.CounterVec
+
+	CharmCacheRequests  *prometheus.CounterVec
+	CharmCacheEvictions prometheus.Counter
+	CharmCacheSize      prometheus.Gauge
+	CharmCacheEntries   prometheus.Gauge
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -194,6 +219,38 @@ func NewMetricsCollector() *Collector {
 				0.99: 0.001,
 			},
 		}, MetricTotalRequestsLabelNames),
+
+		RateLimitedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Total number of API requests rejected by rate limits",
+		}, MetricRateLimitedRequestsLabelNames),
+
+		CharmCacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "charm_cache_requests_total",
+			Help:      "Total number of charm downloads served from, or added to, the charm cache",
+		}, MetricCharmCacheRequestsLabelNames),
+		CharmCacheEvictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "charm_cache_evictions_total",
+			Help:      "Total number of charms evicted from the charm cache",
+		}),
+		CharmCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "charm_cache_size_bytes",
+			Help:      "Current size of the charms in the charm cache",
+		}),
+		CharmCacheEntries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "charm_cache_entries",
+			Help:      "Current number of charms in the charm cache",
+		}),
 		BuildInfo: buildInfo,
 	}
 }
@@ -210,6 +267,11 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.TotalRequests.Describe(ch)
 	c.TotalRequestErrors.Describe(ch)
 	c.TotalRequestsDuration.Describe(ch)
+	c.RateLimitedRequests.Describe(ch)
+	c.CharmCacheRequests.Describe(ch)
+	c.CharmCacheEvictions.Describe(ch)
+	c.CharmCacheSize.Describe(ch)
+	c.CharmCacheEntries.Describe(ch)
 	c.BuildInfo.Describe(ch)
 }
 
@@ -225,5 +287,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.TotalRequests.Collect(ch)
 	c.TotalRequestErrors.Collect(ch)
 	c.TotalRequestsDuration.Collect(ch)
+	c.RateLimitedRequests.Collect(ch)
+	c.CharmCacheRequests.Collect(ch)
+	c.CharmCacheEvictions.Collect(ch)
+	c.CharmCacheSize.Collect(ch)
+	c.CharmCacheEntries.Collect(ch)
 	c.BuildInfo.Collect(ch)
 }
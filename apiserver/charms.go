@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"io"
 	"mime"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facades/client/charms/services"
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/charm/downloader"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -267,6 +269,12 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		return nil, errors.NewBadRequest(err, "")
 	}
 
+	if schema == "local" {
+		if err := checkUploadSigningPolicy(st, fmt.Sprintf("local charm %q", name), corecharm.CharmSignatureNamespace); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// We got it, now let's reserve a charm URL for it in state.
 	curl := &charm.URL{
 		Schema:       schema,
@@ -590,6 +598,26 @@ func modelIsImporting(st *state.State) (bool, error) {
 	return model.MigrationMode() == state.MigrationModeImporting, nil
 }
 
+// checkUploadSigningPolicy checks that the model's signing policy
+// accepts the uploaded charm or resource. Uploads carry no signature,
+// so they're refused when the model only allows signed charms and
+// resources. Uploads made while importing a migrated model were
+// accepted by the source model, so aren't checked.
+func checkUploadSigningPolicy(st *state.State, what, namespace string) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.MigrationMode() == state.MigrationModeImporting {
+		return nil
+	}
+	policy, err := services.NewSigningPolicy(model, nil, logger.Child("signatures"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(policy.CheckSigner(what, namespace, nil))
+}
+
 func emitUnsupportedMethodErr(method string) error {
 	return errors.MethodNotAllowedf("unsupported method: %q", method)
 }
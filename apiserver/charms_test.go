@@ -17,13 +17,16 @@ import (
 	"path/filepath"
 
 	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v3"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
+	pkitest "github.com/juju/juju/pki/test"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -251,6 +254,36 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) setCharmSigningConfig(c *gc.C, signedOnly bool) {
+	_, allowedSigner, err := pkitest.NewSSHSigner("publisher@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.UpdateModelConfig(map[string]interface{}{
+		config.CharmSigningKeysKey: allowedSigner,
+		config.SignedCharmsOnlyKey: signedOnly,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmsSuite) TestUploadRejectedWhenSignedCharmsOnly(c *gc.C) {
+	s.setCharmSigningConfig(c, true)
+
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI("?series=quantal"), "application/zip", &fileReader{path: ch.Path})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `.*local charm "dummy" is not signed, and the model only allows signed charms and resources`)
+	_, err := s.State.Charm("local:quantal/dummy-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmsSuite) TestUploadAllowedWhenSignaturesOptional(c *gc.C) {
+	s.setCharmSigningConfig(c, false)
+
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI("?series=quantal"), "application/zip", &fileReader{path: ch.Path})
+	s.assertUploadResponse(c, resp, "local:quantal/dummy-1")
+}
+
 func (s *charmsSuite) TestUploadWithMultiSeriesCharm(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	resp := s.uploadRequest(c, s.charmsURL("").String(), "application/zip", &fileReader{path: ch.Path})
@@ -0,0 +1,38 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+// AutoscalePolicyToParams converts an application's autoscale policy to
+// its params representation.
+func AutoscalePolicyToParams(policy application.AutoscalePolicy) params.AutoscalePolicy {
+	return params.AutoscalePolicy{
+		MinUnits:     policy.MinUnits,
+		MaxUnits:     policy.MaxUnits,
+		TargetCPU:    policy.TargetCPU,
+		TargetMemory: policy.TargetMemory,
+		Cooldown:     policy.Cooldown,
+	}
+}
+
+// AutoscaleStatusToParams converts what the autoscaler observed of an
+// application to its params representation.
+func AutoscaleStatusToParams(status application.AutoscaleStatus) params.AutoscaleStatus {
+	result := params.AutoscaleStatus{
+		UnitsMeasured: status.Utilization.Units,
+		CPU:           status.Utilization.CPU,
+		Memory:        status.Utilization.Memory,
+		TargetScale:   status.TargetScale,
+		Message:       status.Message,
+	}
+	if !status.LastScaled.IsZero() {
+		lastScaled := status.LastScaled
+		result.LastScaled = &lastScaled
+	}
+	return result
+}
@@ -0,0 +1,363 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/leadership"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+// SecretsMigrationState provides access to the secrets of a model
+// whose content is moved between backends.
+type SecretsMigrationState interface {
+	ListSecrets(state.SecretsFilter) ([]*coresecrets.SecretMetadata, error)
+	ListSecretRevisions(uri *coresecrets.URI) ([]*coresecrets.SecretRevisionMetadata, error)
+	GetSecretValue(*coresecrets.URI, int) (coresecrets.SecretValue, *coresecrets.ValueRef, error)
+	ChangeSecretBackend(state.ChangeSecretBackendParams) error
+}
+
+// MigrationRevision is a secret revision to move to another backend.
+type MigrationRevision struct {
+	URI      *coresecrets.URI
+	Revision int
+	ValueRef *coresecrets.ValueRef
+}
+
+// SecretsMigration moves the content of a model's secret revisions
+// from the backends holding them to a target backend.
+type SecretsMigration struct {
+	st             SecretsMigrationState
+	controllerUUID string
+	configs        map[string]provider.ModelBackendConfig
+	targetID       string
+	backends       map[string]provider.SecretsBackend
+}
+
+// NewSecretsMigration returns a SecretsMigration moving content to the
+// backend with the given ID, using the model's admin backend configs.
+func NewSecretsMigration(
+	st SecretsMigrationState, controllerUUID string, cfgInfo *provider.ModelBackendConfigInfo, targetID string,
+) (*SecretsMigration, error) {
+	if _, ok := cfgInfo.Configs[targetID]; !ok {
+		return nil, errors.NotFoundf("secret backend %q", targetID)
+	}
+	return &SecretsMigration{
+		st:             st,
+		controllerUUID: controllerUUID,
+		configs:        cfgInfo.Configs,
+		targetID:       targetID,
+		backends:       make(map[string]provider.SecretsBackend),
+	}, nil
+}
+
+// BackendID returns the ID of the backend holding the content referred
+// to by the value reference.
+func (m *SecretsMigration) BackendID(ref *coresecrets.ValueRef) string {
+	if ref == nil {
+		return m.controllerUUID
+	}
+	return ref.BackendID
+}
+
+// Revisions returns the secret revisions not already on the target
+// backend, for the given secrets or those owned by the given
+// applications, or for all the model's secrets if neither are given.
+func (m *SecretsMigration) Revisions(uris, applications []string) ([]MigrationRevision, error) {
+	mds, err := m.st.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wantURIs := set.NewStrings()
+	for _, u := range uris {
+		uri, err := coresecrets.ParseURI(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		wantURIs.Add(uri.ID)
+	}
+	wantApps := set.NewStrings(applications...)
+
+	sort.Slice(mds, func(i, j int) bool {
+		return mds[i].URI.ID < mds[j].URI.ID
+	})
+	found := set.NewStrings()
+	var result []MigrationRevision
+	for _, md := range mds {
+		if !wantURIs.IsEmpty() && !wantURIs.Contains(md.URI.ID) {
+			continue
+		}
+		if !wantApps.IsEmpty() && !wantApps.Contains(ownerApplication(md.OwnerTag)) {
+			continue
+		}
+		found.Add(md.URI.ID)
+		revs, err := m.st.ListSecretRevisions(md.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rev := range revs {
+			if m.BackendID(rev.ValueRef) == m.targetID {
+				continue
+			}
+			result = append(result, MigrationRevision{
+				URI:      md.URI,
+				Revision: rev.Revision,
+				ValueRef: rev.ValueRef,
+			})
+		}
+	}
+	if missing := wantURIs.Difference(found); !missing.IsEmpty() {
+		return nil, errors.NotFoundf("secrets %v", missing.SortedValues())
+	}
+	return result, nil
+}
+
+// ownerApplication returns the application owning a secret,
+// or owning the unit which owns it.
+func ownerApplication(ownerTag string) string {
+	tag, err := names.ParseTag(ownerTag)
+	if err != nil {
+		return ""
+	}
+	switch tag.Kind() {
+	case names.ApplicationTagKind:
+		return tag.Id()
+	case names.UnitTagKind:
+		app, _ := names.UnitApplication(tag.Id())
+		return app
+	}
+	return ""
+}
+
+// InitTarget initialises the target backend for the model.
+func (m *SecretsMigration) InitTarget() error {
+	if m.targetID == m.controllerUUID {
+		return nil
+	}
+	cfg := m.configs[m.targetID]
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(p.Initialise(&cfg), "initialising secrets provider")
+}
+
+func (m *SecretsMigration) backend(id string) (provider.SecretsBackend, error) {
+	if b, ok := m.backends[id]; ok {
+		return b, nil
+	}
+	cfg, ok := m.configs[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret backend %q", id)
+	}
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b, err := p.NewBackend(&cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m.backends[id] = b
+	return b, nil
+}
+
+// content returns the content of the secret revision, and the backend
+// reference the content was read from.
+func (m *SecretsMigration) content(uri *coresecrets.URI, revision int) (coresecrets.SecretValue, *coresecrets.ValueRef, error) {
+	val, ref, err := m.st.GetSecretValue(uri, revision)
+	if err != nil || ref == nil {
+		return val, nil, errors.Trace(err)
+	}
+	b, err := m.backend(ref.BackendID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	val, err = provider.GetContent(context.TODO(), b, ref.RevisionID, val)
+	return val, ref, errors.Trace(err)
+}
+
+// Checksum returns the checksum of the current content of the secret
+// revision, without moving it.
+func (m *SecretsMigration) Checksum(uri *coresecrets.URI, revision int) (string, error) {
+	val, _, err := m.content(uri, revision)
+	if err != nil {
+		return "", errors.Annotatef(err, "reading secret %s/%d", uri.ID, revision)
+	}
+	return ContentChecksum(val), nil
+}
+
+// Migrate moves the content of the secret revision to the target
+// backend and returns its checksum. The content is verified against the
+// checksum once it has been saved to the target backend, and only then
+// is the revision changed to refer to the target backend, using token
+// to check the owner's leadership, and the content removed from the old
+// backend. If the content cannot be saved, verified or the revision
+// changed, the revision is left on the old backend.
+// A revision already on the target backend is left alone.
+func (m *SecretsMigration) Migrate(uri *coresecrets.URI, revision int, token leadership.Token) (string, error) {
+	val, oldRef, err := m.content(uri, revision)
+	if err != nil {
+		return "", errors.Annotatef(err, "reading secret %s/%d", uri.ID, revision)
+	}
+	checksum := ContentChecksum(val)
+	if m.BackendID(oldRef) == m.targetID {
+		return checksum, nil
+	}
+
+	if m.targetID == m.controllerUUID {
+		err = m.migrateToInternal(uri, revision, token, val, oldRef, checksum)
+	} else {
+		err = m.migrateToExternal(uri, revision, token, val, checksum)
+	}
+	if err != nil {
+		return checksum, errors.Trace(err)
+	}
+
+	if oldRef != nil {
+		// The content is no longer referred to, so remove it from the
+		// old backend; failing to do so does not fail the migration.
+		old, err := m.backend(oldRef.BackendID)
+		if err == nil {
+			err = old.DeleteContent(context.TODO(), oldRef.RevisionID)
+		}
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			logger.Warningf("cannot remove secret %s/%d from backend %q: %v", uri.ID, revision, oldRef.BackendID, err)
+		}
+	}
+	return checksum, nil
+}
+
+func (m *SecretsMigration) migrateToInternal(
+	uri *coresecrets.URI, revision int, token leadership.Token,
+	val coresecrets.SecretValue, oldRef *coresecrets.ValueRef, checksum string,
+) error {
+	err := m.st.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		Token:    token,
+		URI:      uri,
+		Revision: revision,
+		Data:     val.EncodedValues(),
+	})
+	if err != nil {
+		return errors.Annotatef(err, "changing backend of secret %s/%d", uri.ID, revision)
+	}
+	saved, ref, err := m.st.GetSecretValue(uri, revision)
+	if err == nil && (ref != nil || ContentChecksum(saved) != checksum) {
+		err = errors.Errorf("checksum mismatch")
+	}
+	if err == nil {
+		return nil
+	}
+	// Put the revision back on the old backend.
+	rollbackErr := m.st.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		Token:    token,
+		URI:      uri,
+		Revision: revision,
+		ValueRef: oldRef,
+	})
+	if rollbackErr != nil {
+		logger.Errorf("cannot roll back secret %s/%d to backend %q: %v", uri.ID, revision, m.BackendID(oldRef), rollbackErr)
+	}
+	return errors.Annotatef(err, "verifying secret %s/%d", uri.ID, revision)
+}
+
+func (m *SecretsMigration) migrateToExternal(
+	uri *coresecrets.URI, revision int, token leadership.Token,
+	val coresecrets.SecretValue, checksum string,
+) error {
+	target, err := m.backend(m.targetID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	revisionID, sealed, err := provider.SaveContent(context.TODO(), target, uri, revision, val)
+	if err != nil {
+		return errors.Annotatef(err, "saving secret %s/%d", uri.ID, revision)
+	}
+	saved, err := provider.GetContent(context.TODO(), target, revisionID, sealed)
+	if err == nil && ContentChecksum(saved) != checksum {
+		err = errors.Errorf("checksum mismatch")
+	}
+	if err != nil {
+		m.removeFromTarget(target, uri, revision, revisionID)
+		return errors.Annotatef(err, "verifying secret %s/%d", uri.ID, revision)
+	}
+	var data coresecrets.SecretData
+	if sealed != nil {
+		data = sealed.EncodedValues()
+	}
+	err = m.st.ChangeSecretBackend(state.ChangeSecretBackendParams{
+		Token:    token,
+		URI:      uri,
+		Revision: revision,
+		ValueRef: &coresecrets.ValueRef{
+			BackendID:  m.targetID,
+			RevisionID: revisionID,
+		},
+		Data: data,
+	})
+	if err != nil {
+		m.removeFromTarget(target, uri, revision, revisionID)
+		return errors.Annotatef(err, "changing backend of secret %s/%d", uri.ID, revision)
+	}
+	return nil
+}
+
+// removeFromTarget removes content saved to the target backend for a
+// revision which could not be moved.
+func (m *SecretsMigration) removeFromTarget(target provider.SecretsBackend, uri *coresecrets.URI, revision int, revisionID string) {
+	if err := target.DeleteContent(context.TODO(), revisionID); err != nil && !errors.Is(err, errors.NotFound) {
+		logger.Warningf("cannot remove secret %s/%d from backend %q: %v", uri.ID, revision, m.targetID, err)
+	}
+}
+
+// SecretsMigrationResult returns the params describing a secrets migration.
+func SecretsMigrationResult(m *state.SecretsMigration) params.SecretsMigrationResult {
+	result := params.SecretsMigrationResult{
+		BackendID:    m.BackendID,
+		BackendName:  m.BackendName,
+		URIs:         m.URIs,
+		Applications: m.Applications,
+		Status:       string(m.Status),
+		Total:        m.Total,
+		Moved:        m.Moved,
+		Started:      m.Started,
+		Updated:      m.Updated,
+	}
+	for _, f := range m.Failures {
+		result.Failures = append(result.Failures, params.SecretsMigrationFailure{
+			URI:      f.URI,
+			Revision: f.Revision,
+			Message:  f.Message,
+		})
+	}
+	return result
+}
+
+// ContentChecksum returns a checksum of the secret content,
+// independent of the order of its keys.
+func ContentChecksum(val coresecrets.SecretValue) string {
+	data := val.EncodedValues()
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", k, data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
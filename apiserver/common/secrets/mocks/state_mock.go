@@ -153,6 +153,21 @@ func (mr *MockSecretsStoreMockRecorder) ListModelSecrets(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModelSecrets", reflect.TypeOf((*MockSecretsStore)(nil).ListModelSecrets), arg0)
 }
 
+// ListSecretRevisionConsumers mocks base method.
+func (m *MockSecretsStore) ListSecretRevisionConsumers(arg0 *secrets.URI) (map[int][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisionConsumers", arg0)
+	ret0, _ := ret[0].(map[int][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisionConsumers indicates an expected call of ListSecretRevisionConsumers.
+func (mr *MockSecretsStoreMockRecorder) ListSecretRevisionConsumers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisionConsumers", reflect.TypeOf((*MockSecretsStore)(nil).ListSecretRevisionConsumers), arg0)
+}
+
 // ListSecretRevisions mocks base method.
 func (m *MockSecretsStore) ListSecretRevisions(arg0 *secrets.URI) ([]*secrets.SecretRevisionMetadata, error) {
 	m.ctrl.T.Helper()
@@ -281,6 +296,20 @@ func (m *MockSecretBackendsStorage) EXPECT() *MockSecretBackendsStorageMockRecor
 	return m.recorder
 }
 
+// AddSecretBackendModelKey mocks base method.
+func (m *MockSecretBackendsStorage) AddSecretBackendModelKey(arg0, arg1 string, arg2 secrets.WrappedKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSecretBackendModelKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSecretBackendModelKey indicates an expected call of AddSecretBackendModelKey.
+func (mr *MockSecretBackendsStorageMockRecorder) AddSecretBackendModelKey(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecretBackendModelKey", reflect.TypeOf((*MockSecretBackendsStorage)(nil).AddSecretBackendModelKey), arg0, arg1, arg2)
+}
+
 // CreateSecretBackend mocks base method.
 func (m *MockSecretBackendsStorage) CreateSecretBackend(arg0 state.CreateSecretBackendParams) (string, error) {
 	m.ctrl.T.Helper()
@@ -340,6 +369,36 @@ func (mr *MockSecretBackendsStorageMockRecorder) GetSecretBackendByID(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretBackendByID", reflect.TypeOf((*MockSecretBackendsStorage)(nil).GetSecretBackendByID), arg0)
 }
 
+// GetSecretBackendModelKey mocks base method.
+func (m *MockSecretBackendsStorage) GetSecretBackendModelKey(arg0, arg1 string) (*secrets.WrappedKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretBackendModelKey", arg0, arg1)
+	ret0, _ := ret[0].(*secrets.WrappedKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretBackendModelKey indicates an expected call of GetSecretBackendModelKey.
+func (mr *MockSecretBackendsStorageMockRecorder) GetSecretBackendModelKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretBackendModelKey", reflect.TypeOf((*MockSecretBackendsStorage)(nil).GetSecretBackendModelKey), arg0, arg1)
+}
+
+// ListSecretBackendModelKeys mocks base method.
+func (m *MockSecretBackendsStorage) ListSecretBackendModelKeys(arg0 string) (map[string]secrets.WrappedKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretBackendModelKeys", arg0)
+	ret0, _ := ret[0].(map[string]secrets.WrappedKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretBackendModelKeys indicates an expected call of ListSecretBackendModelKeys.
+func (mr *MockSecretBackendsStorageMockRecorder) ListSecretBackendModelKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretBackendModelKeys", reflect.TypeOf((*MockSecretBackendsStorage)(nil).ListSecretBackendModelKeys), arg0)
+}
+
 // ListSecretBackends mocks base method.
 func (m *MockSecretBackendsStorage) ListSecretBackends() ([]*secrets.SecretBackend, error) {
 	m.ctrl.T.Helper()
@@ -382,3 +441,17 @@ func (mr *MockSecretBackendsStorageMockRecorder) UpdateSecretBackend(arg0 any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretBackend", reflect.TypeOf((*MockSecretBackendsStorage)(nil).UpdateSecretBackend), arg0)
 }
+
+// UpdateSecretBackendModelKey mocks base method.
+func (m *MockSecretBackendsStorage) UpdateSecretBackendModelKey(arg0, arg1 string, arg2 secrets.WrappedKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecretBackendModelKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecretBackendModelKey indicates an expected call of UpdateSecretBackendModelKey.
+func (mr *MockSecretBackendsStorageMockRecorder) UpdateSecretBackendModelKey(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretBackendModelKey", reflect.TypeOf((*MockSecretBackendsStorage)(nil).UpdateSecretBackendModelKey), arg0, arg1, arg2)
+}
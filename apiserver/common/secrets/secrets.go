@@ -107,10 +107,11 @@ func AdminBackendConfigInfo(model Model) (*provider.ModelBackendConfigInfo, erro
 		return nil, errors.Trace(err)
 	}
 	for _, b := range backends {
-		if b.Name == backendName {
+		active := b.Name == backendName
+		if active {
 			info.ActiveID = b.ID
 		}
-		info.Configs[b.ID] = provider.ModelBackendConfig{
+		cfg := provider.ModelBackendConfig{
 			ControllerUUID: model.ControllerUUID(),
 			ModelUUID:      model.UUID(),
 			ModelName:      model.Name(),
@@ -119,6 +120,10 @@ func AdminBackendConfigInfo(model Model) (*provider.ModelBackendConfigInfo, erro
 				Config:      b.Config,
 			},
 		}
+		if cfg.ModelKey, err = backendModelKey(backendState, b.ID, &cfg, active); err != nil {
+			return nil, errors.Annotatef(err, "getting model key for secret backend %q", b.Name)
+		}
+		info.Configs[b.ID] = cfg
 	}
 	if info.ActiveID == "" {
 		return nil, errors.NotFoundf("secret backend %q", backendName)
@@ -126,6 +131,38 @@ func AdminBackendConfigInfo(model Model) (*provider.ModelBackendConfigInfo, erro
 	return &info, nil
 }
 
+// backendModelKey returns the wrapped data key of the model for
+// backends which encrypt content with model keys. A key is created for
+// the active backend if the model does not have one yet; the model has
+// no content in other backends without one.
+func backendModelKey(
+	backendState state.SecretBackendsStorage, backendID string, cfg *provider.ModelBackendConfig, create bool,
+) (*coresecrets.WrappedKey, error) {
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys, ok := p.(provider.SupportModelKeys)
+	if !ok {
+		return nil, nil
+	}
+	key, err := backendState.GetSecretBackendModelKey(backendID, cfg.ModelUUID)
+	if errors.Is(err, errors.NotFound) && create {
+		if key, err = keys.NewModelKey(cfg); err != nil {
+			return nil, errors.Trace(err)
+		}
+		err = backendState.AddSecretBackendModelKey(backendID, cfg.ModelUUID, *key)
+		if errors.Is(err, errors.AlreadyExists) {
+			// Another controller added the key first.
+			key, err = backendState.GetSecretBackendModelKey(backendID, cfg.ModelUUID)
+		}
+	}
+	if errors.Is(err, errors.NotFound) {
+		return nil, nil
+	}
+	return key, errors.Trace(err)
+}
+
 // DrainBackendConfigInfo returns the secret backend config for the drain worker to use.
 func DrainBackendConfigInfo(backendID string, model Model, authTag names.Tag, leadershipChecker leadership.Checker) (*provider.ModelBackendConfigInfo, error) {
 	adminModelCfg, err := AdminBackendConfigInfo(model)
@@ -4,9 +4,12 @@
 package secrets_test
 
 import (
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -21,6 +24,7 @@ import (
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/secrets/provider"
 	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/encrypted"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
@@ -203,6 +207,68 @@ func (s *secretsSuite) assertAdminBackendConfigInfoDefault(
 	c.Assert(info, jc.DeepEquals, expected)
 }
 
+func (s *secretsSuite) TestAdminBackendConfigInfoModelKey(c *gc.C) {
+	s.assertAdminBackendConfigInfoModelKey(c, false)
+}
+
+func (s *secretsSuite) TestAdminBackendConfigInfoCreatesModelKey(c *gc.C) {
+	s.assertAdminBackendConfigInfoModelKey(c, true)
+}
+
+func (s *secretsSuite) assertAdminBackendConfigInfoModelKey(c *gc.C, create bool) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	model := mocks.NewMockModel(ctrl)
+	backendState := mocks.NewMockSecretBackendsStorage(ctrl)
+	s.PatchValue(&secrets.GetSecretBackendsState, func(secrets.Model) state.SecretBackendsStorage { return backendState })
+
+	keyDir := c.MkDir()
+	err := os.MkdirAll(filepath.Join(keyDir, "master"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(keyDir, "master", "first.key"), make([]byte, 32), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"secret-backend": "myencrypted"})
+	model.EXPECT().ControllerUUID().Return(coretesting.ControllerTag.Id()).AnyTimes()
+	model.EXPECT().UUID().Return(coretesting.ModelTag.Id()).AnyTimes()
+	model.EXPECT().Name().Return("fred").AnyTimes()
+	model.EXPECT().Config().Return(cfg, nil)
+	model.EXPECT().Type().Return(state.ModelTypeIAAS)
+	backendState.EXPECT().ListSecretBackends().Return([]*coresecrets.SecretBackend{{
+		ID:          "encrypted-backend-id",
+		Name:        "myencrypted",
+		BackendType: encrypted.BackendType,
+		Config:      map[string]interface{}{"key-dir": keyDir, "master-key-id": "first"},
+	}}, nil)
+
+	existing := &coresecrets.WrappedKey{MasterKeyID: "first", Key: []byte("wrapped")}
+	if create {
+		backendState.EXPECT().GetSecretBackendModelKey("encrypted-backend-id", coretesting.ModelTag.Id()).
+			Return(nil, errors.NotFoundf("model key"))
+		backendState.EXPECT().AddSecretBackendModelKey("encrypted-backend-id", coretesting.ModelTag.Id(), gomock.Any()).
+			DoAndReturn(func(_, _ string, key coresecrets.WrappedKey) error {
+				c.Check(key.MasterKeyID, gc.Equals, "first")
+				c.Check(key.Key, gc.Not(gc.HasLen), 0)
+				return nil
+			})
+	} else {
+		backendState.EXPECT().GetSecretBackendModelKey("encrypted-backend-id", coretesting.ModelTag.Id()).
+			Return(existing, nil)
+	}
+
+	info, err := secrets.AdminBackendConfigInfo(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ActiveID, gc.Equals, "encrypted-backend-id")
+	modelKey := info.Configs["encrypted-backend-id"].ModelKey
+	c.Assert(modelKey, gc.NotNil)
+	c.Check(modelKey.MasterKeyID, gc.Equals, "first")
+	if !create {
+		c.Check(modelKey, jc.DeepEquals, existing)
+	}
+	c.Check(info.Configs[jujuBackendID].ModelKey, gc.IsNil)
+}
+
 func (s *secretsSuite) TestBackendConfigInfoLeaderUnit(c *gc.C) {
 	s.assertBackendConfigInfoLeaderUnit(c, []string{"backend-id"})
 }
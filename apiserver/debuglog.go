@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/websocket"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
@@ -86,6 +88,12 @@ func newDebugLogHandler(
 //	replay -> string - one of [true, false], if true, start the file from the start
 //	noTail -> string - one of [true, false], if true, existing logs are sent back,
 //	   - but the command does not wait for new ones.
+//	startTime -> string - RFC3339 time, only lines logged at or after this time are sent
+//	endTime -> string - RFC3339 time, only lines logged at or before this time are sent
+//	   - the response ends at the first line logged after this time
+//	messageRegex -> string - only lines whose message matches this regular expression are sent
+//	filter -> string - only lines matching this filter expression are sent
+//	   - see core/logger.Filter for the expression syntax
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -193,6 +201,9 @@ type debugLogParams struct {
 	excludeModule []string
 	includeLabel  []string
 	excludeLabel  []string
+	endTime       time.Time
+	messageRegex  *regexp.Regexp
+	filter        corelogger.Filter
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -248,6 +259,33 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return params, errors.Errorf("message regex %q is not valid: %v", value, err)
+		}
+		params.messageRegex = re
+	}
+
+	if value := queryMap.Get("filter"); value != "" {
+		filter, err := corelogger.ParseFilter(value)
+		if err != nil {
+			return params, errors.Trace(err)
+		}
+		params.filter = filter
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -56,6 +56,12 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
+			if !reqParams.endTime.IsZero() && rec.Time.After(reqParams.endTime) {
+				// Records are returned in time order, so there's
+				// nothing more to send.
+				return nil
+			}
+
 			if err := socket.sendLogRecord(formatLogRecord(rec)); err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -80,6 +86,11 @@ func makeLogTailerParams(reqParams debugLogParams) corelogger.LogTailerParams {
 		ExcludeModule: reqParams.excludeModule,
 		IncludeLabel:  reqParams.includeLabel,
 		ExcludeLabel:  reqParams.excludeLabel,
+		EndTime:       reqParams.endTime,
+		Filter:        reqParams.filter,
+	}
+	if reqParams.messageRegex != nil {
+		tailerParams.MessageRegex = reqParams.messageRegex.String()
 	}
 	if reqParams.fromTheStart {
 		tailerParams.InitialLines = 0
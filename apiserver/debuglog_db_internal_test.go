@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -222,6 +223,107 @@ func (s *debugLogDBIntSuite) TestMaxLines(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestFilterParamConversion(c *gc.C) {
+	reqParams, err := readDebugLogParams(url.Values{
+		"backlog":      {"2"},
+		"endTime":      {"2016-11-30T11:51:00Z"},
+		"messageRegex": {"^connection"},
+		"filter":       {"label.app = foo or level < WARNING"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
+		called = true
+
+		// The filters are applied by the tailer, so that they are
+		// taken into account when selecting the initial lines.
+		c.Assert(params.InitialLines, gc.Equals, 2)
+		c.Assert(params.EndTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+		c.Assert(params.MessageRegex, gc.Equals, "^connection")
+		c.Assert(params.Filter, gc.NotNil)
+		c.Assert(params.Filter.String(), gc.Equals, `(label.app = "foo" or level < "WARNING")`)
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err = handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestEndTime(c *gc.C) {
+	tailer := newFakeLogTailer()
+	for i := 0; i < 3; i++ {
+		tailer.logsCh <- &corelogger.LogRecord{
+			Time:     time.Date(2015, 6, 19, 15, 34, 37+i, 0, time.UTC),
+			Entity:   "machine-99",
+			Module:   "some.where",
+			Location: "code.go:42",
+			Level:    loggo.INFO,
+			Message:  "stuff happened",
+		}
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{
+		endTime: time.Date(2015, 6, 19, 15, 34, 38, 0, time.UTC),
+	}, nil)
+
+	s.assertOutput(c, []string{
+		"ok", // sendOk() call needs to happen first.
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 stuff happened\n",
+		"machine-99: 2015-06-19 15:34:38 INFO some.where code.go:42 stuff happened\n",
+	})
+
+	// The request stops at the first line logged after the end time.
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadDebugLogParamsFilters(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":    {"2016-11-30T10:51:00Z"},
+		"endTime":      {"2016-11-30T11:51:00Z"},
+		"messageRegex": {"refused|reset"},
+		"filter":       {"not label = http"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params.startTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Check(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+	c.Check(params.messageRegex.String(), gc.Equals, "refused|reset")
+	c.Check(params.filter.String(), gc.Equals, `not label = "http"`)
+}
+
+func (s *debugLogDBIntSuite) TestReadDebugLogParamsBadFilters(c *gc.C) {
+	for i, test := range []struct {
+		query url.Values
+		err   string
+	}{{
+		query: url.Values{"endTime": {"yesterday"}},
+		err:   `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-30T10:51:00Z"},
+			"endTime":   {"2016-11-30T09:51:00Z"},
+		},
+		err: `end time "2016-11-30T09:51:00Z" is before start time`,
+	}, {
+		query: url.Values{"messageRegex": {"("}},
+		err:   `message regex "\(" is not valid: .*`,
+	}, {
+		query: url.Values{"filter": {"colour = red"}},
+		err:   `parsing filter "colour = red": unknown field "colour"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) runRequest(params debugLogParams, stop chan struct{}) chan error {
 	done := make(chan error)
 	go func() {
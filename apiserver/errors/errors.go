@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -127,6 +128,8 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusConflict
 	case params.CodeNotLeader:
 		status = http.StatusTemporaryRedirect
+	case params.CodeRateLimitExceeded:
+		status = http.StatusTooManyRequests
 	}
 	return err1, status
 }
@@ -153,6 +156,7 @@ func ServerError(err error) *params.Error {
 		redirectError                *RedirectError
 		upgradeSeriesValidationError *UpgradeSeriesValidationError
 		accessRequiredError          *AccessRequiredError
+		rateLimitExceededError       *RateLimitExceededError
 	)
 	// Skip past annotations when looking for the code.
 	err = errors.Cause(err)
@@ -249,6 +253,9 @@ func ServerError(err error) *params.Error {
 	case errors.As(err, &accessRequiredError):
 		code = params.CodeAccessRequired
 		info = accessRequiredError.AsMap()
+	case errors.As(err, &rateLimitExceededError):
+		code = params.CodeRateLimitExceeded
+		info = rateLimitExceededError.AsMap()
 	default:
 		code = params.ErrCode(err)
 	}
@@ -353,6 +360,16 @@ func RestoreError(err error) error {
 		return fmt.Errorf(msg+"%w", errors.Hide(DeadlineExceededError))
 	case params.IsCodeTryAgain(err):
 		return ErrTryAgain
+	case params.IsCodeRateLimitExceeded(err):
+		e, ok := err.(*params.Error)
+		if !ok {
+			return err
+		}
+		var info params.RateLimitExceededErrorInfo
+		if err := e.UnmarshalInfo(&info); err != nil {
+			return err
+		}
+		return NewRateLimitExceededError(time.Duration(info.RetryAfter * float64(time.Second)))
 	default:
 		// Handle all other codes here.
 		return params.TranslateWellKnownError(err)
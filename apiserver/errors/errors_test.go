@@ -8,6 +8,7 @@ import (
 	stderrors "errors"
 	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
@@ -245,6 +246,18 @@ var errorTransformTests = []struct {
 	targetTester: func(e error) bool {
 		return errors.HasType[*apiservererrors.NotLeaderError](e)
 	},
+}, {
+	err:    apiservererrors.NewRateLimitExceededError(1500 * time.Millisecond),
+	code:   params.CodeRateLimitExceeded,
+	status: http.StatusTooManyRequests,
+	helperFunc: func(err error) bool {
+		err1, ok := err.(*params.Error)
+		return ok && reflect.DeepEqual(err1.Info, map[string]interface{}{"retry-after": 1.5})
+	},
+	targetTester: func(e error) bool {
+		var rateErr *apiservererrors.RateLimitExceededError
+		return errors.As(e, &rateErr) && rateErr.RetryAfter() == 1500*time.Millisecond
+	},
 }, {
 	err:    apiservererrors.DeadlineExceededError,
 	code:   params.CodeDeadlineExceeded,
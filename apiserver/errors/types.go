@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/collections/transform"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/core/base"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
 )
 
 const (
@@ -174,6 +176,36 @@ func NewNotLeaderError(serverAddress, serverID string) error {
 	}
 }
 
+// RateLimitExceededError is returned when a call is rejected because
+// the caller has exceeded one of the controller's API rate limits.
+type RateLimitExceededError struct {
+	retryAfter time.Duration
+}
+
+// NewRateLimitExceededError returns a RateLimitExceededError advising
+// the caller to retry after the given duration.
+func NewRateLimitExceededError(retryAfter time.Duration) error {
+	return &RateLimitExceededError{retryAfter: retryAfter}
+}
+
+// RetryAfter returns how long the caller should wait before making the
+// call again.
+func (e *RateLimitExceededError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// AsMap returns the data for the info part of an error param struct.
+func (e *RateLimitExceededError) AsMap() map[string]interface{} {
+	return params.RateLimitExceededErrorInfo{
+		RetryAfter: e.retryAfter.Seconds(),
+	}.AsMap()
+}
+
+// Error implements the error interface.
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry after %v", e.retryAfter)
+}
+
 // AccessRequiredError is the error returned when an api
 // request needs a login token with specified permissions.
 type AccessRequiredError struct {
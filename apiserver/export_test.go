@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/stateauthenticator"
 	"github.com/juju/juju/core/permission"
+	coreratelimit "github.com/juju/juju/core/ratelimit"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 )
@@ -127,6 +128,13 @@ func TestingUpgradingRoot() rpc.Root {
 	return restrictRoot(r, upgradeMethodsOnly)
 }
 
+// TestingRestoringRoot returns a srvRoot restricted while the given
+// function reports that a restore is in progress.
+func TestingRestoringRoot(restoring func() bool) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, rejectDuringRestore(restoring))
+}
+
 // TestingMigratingRoot returns a resricted srvRoot in a migration
 // scenario.
 func TestingMigratingRoot() rpc.Root {
@@ -168,6 +176,15 @@ func TestingRestrictedRoot(check func(string, string) error) rpc.Root {
 	return restrictRoot(r, check)
 }
 
+// TestingRateLimitedRoot returns a srvRoot with calls by the user in
+// the model limited by the limiter.
+func TestingRateLimitedRoot(limiter *coreratelimit.Limiter, collector *Collector, user, modelUUID string) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, rateLimitCalls(func() *coreratelimit.Limiter {
+		return limiter
+	}, collector, user, modelUUID))
+}
+
 // PatchGetMigrationBackend overrides the getMigrationBackend function
 // to support testing.
 func PatchGetMigrationBackend(p Patcher, ctrlSt controllerBackend, st migrationBackend) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/cache"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -37,6 +38,7 @@ type Context struct {
 	SingularClaimer_    lease.Claimer
 	CharmhubHTTPClient_ facade.HTTPClient
 	ControllerDB_       coredatabase.TrackedDB
+	CharmCache_         charmdownloader.CharmCache
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -155,3 +157,8 @@ func (context Context) HTTPClient(purpose facade.HTTPClientPurpose) facade.HTTPC
 func (context Context) ControllerDB() (coredatabase.TrackedDB, error) {
 	return context.ControllerDB_, nil
 }
+
+// CharmCache implements facade.Context.
+func (context Context) CharmCache() charmdownloader.CharmCache {
+	return context.CharmCache_
+}
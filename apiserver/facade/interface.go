@@ -11,6 +11,7 @@ import (
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/core/cache"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -150,6 +151,10 @@ type Context interface {
 
 	// ControllerDB returns a TrackedDB reference for the controller database.
 	ControllerDB() (coredatabase.TrackedDB, error)
+
+	// CharmCache returns the cache of downloaded charm archives shared
+	// by all models on the controller, or nil if there is no cache.
+	CharmCache() charmdownloader.CharmCache
 }
 
 // RequestRecorder is implemented by types that can record information about
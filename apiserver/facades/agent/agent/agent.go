@@ -22,7 +22,7 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
-// AgentAPI implements the version 3 of the API provided to an agent.
+// AgentAPI implements the version 4 of the API provided to an agent.
 type AgentAPI struct {
 	*common.PasswordChanger
 	*common.RebootFlagClearer
@@ -31,10 +31,16 @@ type AgentAPI struct {
 	cloudspec.CloudSpecer
 
 	st        *state.State
+	ctrlSt    *state.State
 	auth      facade.Authorizer
 	resources facade.Resources
 }
 
+// AgentAPIV3 implements the version 3 of the API provided to an agent.
+type AgentAPIV3 struct {
+	*AgentAPI
+}
+
 func (api *AgentAPI) GetEntities(args params.Entities) params.AgentGetEntitiesResults {
 	results := params.AgentGetEntitiesResults{
 		Entities: make([]params.AgentGetEntitiesResult, len(args.Entities)),
@@ -167,3 +173,22 @@ func (api *AgentAPI) WatchCredentials(args params.Entities) (params.NotifyWatchR
 	}
 	return results, nil
 }
+
+// WatchControllerConfig returns a NotifyWatcher which notifies when the
+// controller config changes.
+func (api *AgentAPI) WatchControllerConfig() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	w := api.ctrlSt.WatchControllerConfig()
+	// Consume the initial event. Technically, API calls to Watch
+	// 'transmit' the initial event in the Watch response. But
+	// NotifyWatchers have no state to transmit.
+	if _, ok := <-w.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(w)
+	} else {
+		result.Error = apiservererrors.ServerError(watcher.EnsureErr(w))
+	}
+	return result, nil
+}
+
+// WatchControllerConfig isn't on the v3 API.
+func (*AgentAPIV3) WatchControllerConfig(_, _ struct{}) {}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/agent"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -311,6 +312,33 @@ func (s *agentSuite) TestWatchCredentials(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *agentSuite) TestWatchControllerConfig(c *gc.C) {
+	api, err := agent.NewAgentAPIV4(facadetest.Context{
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.WatchControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewNotifyWatcherC(c, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OpenTelemetryEndpoint: "localhost:4318",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	authorizer := apiservertesting.FakeAuthorizer{
 		Tag:        names.NewMachineTag("1"),
@@ -5,4 +5,5 @@ package agent
 
 var (
 	NewAgentAPIV3 = newAgentAPIV3
+	NewAgentAPIV4 = newAgentAPIV4
 )
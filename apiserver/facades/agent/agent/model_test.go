@@ -24,12 +24,23 @@ type modelSuite struct {
 	resources  *common.Resources
 
 	machine0 *state.Machine
-	api      *agent.AgentAPI
+	api      *agent.AgentAPIV3
 }
 
 var _ = gc.Suite(&modelSuite{})
 
 func (s *modelSuite) SetUpTest(c *gc.C) {
+	s.setUpTest(c)
+
+	var err error
+	s.api, err = agent.NewAgentAPIV3(s.facadeContext())
+	c.Assert(err, jc.ErrorIsNil)
+	s.ModelWatcherTest = commontesting.NewModelWatcherTest(
+		s.api, s.State, s.resources,
+	)
+}
+
+func (s *modelSuite) setUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
 
 	var err error
@@ -41,15 +52,32 @@ func (s *modelSuite) SetUpTest(c *gc.C) {
 	}
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+}
 
-	s.api, err = agent.NewAgentAPIV3(facadetest.Context{
+func (s *modelSuite) facadeContext() facadetest.Context {
+	return facadetest.Context{
 		State_:     s.State,
 		StatePool_: s.StatePool,
 		Resources_: s.resources,
 		Auth_:      s.authorizer,
-	})
+	}
+}
+
+type modelV4Suite struct {
+	modelSuite
+
+	apiV4 *agent.AgentAPI
+}
+
+var _ = gc.Suite(&modelV4Suite{})
+
+func (s *modelV4Suite) SetUpTest(c *gc.C) {
+	s.setUpTest(c)
+
+	var err error
+	s.apiV4, err = agent.NewAgentAPIV4(s.facadeContext())
 	c.Assert(err, jc.ErrorIsNil)
 	s.ModelWatcherTest = commontesting.NewModelWatcherTest(
-		s.api, s.State, s.resources,
+		s.apiV4, s.State, s.resources,
 	)
 }
@@ -18,12 +18,25 @@ import (
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Agent", 3, func(ctx facade.Context) (facade.Facade, error) {
 		return newAgentAPIV3(ctx)
+	}, reflect.TypeOf((*AgentAPIV3)(nil)))
+	registry.MustRegister("Agent", 4, func(ctx facade.Context) (facade.Facade, error) {
+		return newAgentAPIV4(ctx)
 	}, reflect.TypeOf((*AgentAPI)(nil)))
 }
 
 // newAgentAPIV3 returns an object implementing version 3 of the Agent API
 // with the given authorizer representing the currently logged in client.
-func newAgentAPIV3(ctx facade.Context) (*AgentAPI, error) {
+func newAgentAPIV3(ctx facade.Context) (*AgentAPIV3, error) {
+	api, err := newAgentAPIV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &AgentAPIV3{AgentAPI: api}, nil
+}
+
+// newAgentAPIV4 returns an object implementing version 4 of the Agent API
+// with the given authorizer representing the currently logged in client.
+func newAgentAPIV4(ctx facade.Context) (*AgentAPI, error) {
 	auth := ctx.Auth()
 	// Agents are defined to be any user that's not a client user.
 	if !auth.AuthMachineAgent() && !auth.AuthUnitAgent() {
@@ -58,6 +71,7 @@ func newAgentAPIV3(ctx facade.Context) (*AgentAPI, error) {
 			common.AuthFuncForTag(model.ModelTag()),
 		),
 		st:        st,
+		ctrlSt:    systemState,
 		auth:      auth,
 		resources: resources,
 	}, nil
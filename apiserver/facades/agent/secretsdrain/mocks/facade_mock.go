@@ -14,6 +14,7 @@ import (
 
 	facade "github.com/juju/juju/apiserver/facade"
 	cache "github.com/juju/juju/core/cache"
+	downloader "github.com/juju/juju/core/charm/downloader"
 	database "github.com/juju/juju/core/database"
 	leadership "github.com/juju/juju/core/leadership"
 	lease "github.com/juju/juju/core/lease"
@@ -90,6 +91,20 @@ func (mr *MockContextMockRecorder) Cancel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockContext)(nil).Cancel))
 }
 
+// CharmCache mocks base method.
+func (m *MockContext) CharmCache() downloader.CharmCache {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmCache")
+	ret0, _ := ret[0].(downloader.CharmCache)
+	return ret0
+}
+
+// CharmCache indicates an expected call of CharmCache.
+func (mr *MockContextMockRecorder) CharmCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmCache", reflect.TypeOf((*MockContext)(nil).CharmCache))
+}
+
 // Controller mocks base method.
 func (m *MockContext) Controller() *cache.Controller {
 	m.ctrl.T.Helper()
@@ -266,7 +266,7 @@ func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, er
 	md, err := s.secretsState.CreateSecret(uri, state.CreateSecretParams{
 		Version:            secrets.Version,
 		Owner:              secretOwner,
-		UpdateSecretParams: fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime),
+		UpdateSecretParams: fromUpsertParams(arg.UpsertSecretArg, s.authTag, token, nextRotateTime),
 	})
 	if err != nil {
 		return "", errors.Trace(err)
@@ -286,7 +286,7 @@ func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, er
 	return md.URI.String(), nil
 }
 
-func fromUpsertParams(p params.UpsertSecretArg, token leadership.Token, nextRotateTime *time.Time) state.UpdateSecretParams {
+func fromUpsertParams(p params.UpsertSecretArg, author names.Tag, token leadership.Token, nextRotateTime *time.Time) state.UpdateSecretParams {
 	var valueRef *coresecrets.ValueRef
 	if p.Content.ValueRef != nil {
 		valueRef = &coresecrets.ValueRef{
@@ -296,6 +296,7 @@ func fromUpsertParams(p params.UpsertSecretArg, token leadership.Token, nextRota
 	}
 	return state.UpdateSecretParams{
 		LeaderToken:    token,
+		Author:         author,
 		RotatePolicy:   p.RotatePolicy,
 		NextRotateTime: nextRotateTime,
 		ExpireTime:     p.ExpireTime,
@@ -344,7 +345,7 @@ func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
 	if !md.RotatePolicy.WillRotate() && arg.RotatePolicy.WillRotate() {
 		nextRotateTime = arg.RotatePolicy.NextRotateTime(s.clock.Now())
 	}
-	_, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime))
+	_, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.UpsertSecretArg, s.authTag, token, nextRotateTime))
 	return errors.Trace(err)
 }
 
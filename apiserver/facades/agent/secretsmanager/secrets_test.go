@@ -247,6 +247,7 @@ func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
 		Owner:   names.NewApplicationTag("mariadb"),
 		UpdateSecretParams: state.UpdateSecretParams{
 			LeaderToken:    s.token,
+			Author:         s.authTag,
 			RotatePolicy:   ptr(coresecrets.RotateDaily),
 			NextRotateTime: ptr(s.clock.Now().AddDate(0, 0, 1)),
 			ExpireTime:     ptr(s.clock.Now()),
@@ -319,6 +320,7 @@ func (s *SecretsManagerSuite) TestCreateSecretDuplicateLabel(c *gc.C) {
 		Owner:   names.NewApplicationTag("mariadb"),
 		UpdateSecretParams: state.UpdateSecretParams{
 			LeaderToken: s.token,
+			Author:      s.authTag,
 			Label:       ptr("foobar"),
 			Data:        map[string]string{"foo": "bar"},
 		},
@@ -351,6 +353,7 @@ func (s *SecretsManagerSuite) TestUpdateSecrets(c *gc.C) {
 
 	p := state.UpdateSecretParams{
 		LeaderToken:    s.token,
+		Author:         s.authTag,
 		RotatePolicy:   ptr(coresecrets.RotateDaily),
 		NextRotateTime: ptr(s.clock.Now().AddDate(0, 0, 1)),
 		ExpireTime:     ptr(s.clock.Now()),
@@ -431,6 +434,7 @@ func (s *SecretsManagerSuite) TestUpdateSecretDuplicateLabel(c *gc.C) {
 
 	p := state.UpdateSecretParams{
 		LeaderToken: s.token,
+		Author:      s.authTag,
 		Label:       ptr("foobar"),
 	}
 	uri := coresecrets.NewURI()
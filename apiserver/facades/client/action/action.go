@@ -31,9 +31,22 @@ type ActionAPI struct {
 
 type TagToActionReceiverFunc func(findEntity func(names.Tag) (state.Entity, error)) func(tag string) (state.ActionReceiver, error)
 
+// APIv9 provides the Action API facade for version 9.
+type APIv9 struct {
+	*ActionAPI
+}
+
+// APIv8 provides the Action API facade for version 8.
+// Version 9 adds action schedules.
+type APIv8 struct {
+	*APIv9
+}
+
 // APIv7 provides the Action API facade for version 7.
+// Version 8 adds rollouts, enqueuing the tasks of an operation in
+// batches.
 type APIv7 struct {
-	*ActionAPI
+	*APIv8
 }
 
 func newActionAPI(
@@ -24,8 +24,11 @@ type State interface {
 // Model describes model state used by the action facade.
 type Model interface {
 	ActionByTag(tag names.ActionTag) (state.Action, error)
+	AddActionSchedule(schedule state.ActionSchedule) error
 	AddAction(receiver state.ActionReceiver, operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (state.Action, error)
+	AllActionSchedules() ([]state.ActionSchedule, error)
 	EnqueueOperation(summary string, count int) (string, error)
+	EnqueueRolloutOperation(summary string, count int, rollout state.OperationRollout) (string, error)
 	FailOperationEnqueuing(operationID, failMessage string, count int) error
 	FindActionsByName(name string) ([]state.Action, error)
 	ListOperations(actionNames []string, actionReceivers []names.Tag, operationStatus []state.ActionStatus,
@@ -33,6 +36,8 @@ type Model interface {
 	) ([]state.OperationInfo, bool, error)
 	ModelTag() names.ModelTag
 	OperationWithActions(id string) (*state.OperationInfo, error)
+	RemoveActionSchedule(name string) error
+	StartRolloutBatch(operationID string, batch int) error
 	Type() state.ModelType
 }
 
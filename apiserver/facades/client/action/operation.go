@@ -67,6 +67,9 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 		}
 	}
 	summary := fmt.Sprintf("%v run on %v", operationName, strings.Join(receivers, ","))
+	if arg.Rollout != nil {
+		return a.enqueueRollout(summary, arg)
+	}
 	operationID, err := a.model.EnqueueOperation(summary, len(receivers))
 	if err != nil {
 		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
@@ -195,6 +198,7 @@ func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.Operat
 			Completed:    r.Operation.Completed(),
 			Status:       string(r.Operation.Status()),
 			Actions:      make([]params.ActionResult, len(r.Actions)),
+			Rollout:      rolloutInfo(r.Operation.Rollout()),
 		}
 		for j, a := range r.Actions {
 			receiver, err := names.ActionReceiverTag(a.Receiver())
@@ -238,6 +242,7 @@ func (a *ActionAPI) Operations(arg params.Entities) (params.OperationResults, er
 			Completed:    op.Operation.Completed(),
 			Status:       string(op.Operation.Status()),
 			Actions:      make([]params.ActionResult, len(op.Actions)),
+			Rollout:      rolloutInfo(op.Operation.Rollout()),
 		}
 		for j, a := range op.Actions {
 			receiver, err := names.ActionReceiverTag(a.Receiver())
@@ -198,6 +198,35 @@ func (mr *MockModelMockRecorder) AddAction(arg0, arg1, arg2, arg3, arg4, arg5 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAction", reflect.TypeOf((*MockModel)(nil).AddAction), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// AddActionSchedule mocks base method.
+func (m *MockModel) AddActionSchedule(arg0 state.ActionSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActionSchedule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddActionSchedule indicates an expected call of AddActionSchedule.
+func (mr *MockModelMockRecorder) AddActionSchedule(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionSchedule", reflect.TypeOf((*MockModel)(nil).AddActionSchedule), arg0)
+}
+
+// AllActionSchedules mocks base method.
+func (m *MockModel) AllActionSchedules() ([]state.ActionSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllActionSchedules")
+	ret0, _ := ret[0].([]state.ActionSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllActionSchedules indicates an expected call of AllActionSchedules.
+func (mr *MockModelMockRecorder) AllActionSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllActionSchedules", reflect.TypeOf((*MockModel)(nil).AllActionSchedules))
+}
+
 // EnqueueOperation mocks base method.
 func (m *MockModel) EnqueueOperation(arg0 string, arg1 int) (string, error) {
 	m.ctrl.T.Helper()
@@ -213,6 +242,21 @@ func (mr *MockModelMockRecorder) EnqueueOperation(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueOperation", reflect.TypeOf((*MockModel)(nil).EnqueueOperation), arg0, arg1)
 }
 
+// EnqueueRolloutOperation mocks base method.
+func (m *MockModel) EnqueueRolloutOperation(arg0 string, arg1 int, arg2 state.OperationRollout) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueRolloutOperation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueRolloutOperation indicates an expected call of EnqueueRolloutOperation.
+func (mr *MockModelMockRecorder) EnqueueRolloutOperation(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueRolloutOperation", reflect.TypeOf((*MockModel)(nil).EnqueueRolloutOperation), arg0, arg1, arg2)
+}
+
 // FailOperationEnqueuing mocks base method.
 func (m *MockModel) FailOperationEnqueuing(arg0, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
@@ -287,6 +331,34 @@ func (mr *MockModelMockRecorder) OperationWithActions(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationWithActions", reflect.TypeOf((*MockModel)(nil).OperationWithActions), arg0)
 }
 
+// RemoveActionSchedule mocks base method.
+func (m *MockModel) RemoveActionSchedule(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActionSchedule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveActionSchedule indicates an expected call of RemoveActionSchedule.
+func (mr *MockModelMockRecorder) RemoveActionSchedule(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActionSchedule", reflect.TypeOf((*MockModel)(nil).RemoveActionSchedule), arg0)
+}
+
+// StartRolloutBatch mocks base method.
+func (m *MockModel) StartRolloutBatch(arg0 string, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRolloutBatch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRolloutBatch indicates an expected call of StartRolloutBatch.
+func (mr *MockModelMockRecorder) StartRolloutBatch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRolloutBatch", reflect.TypeOf((*MockModel)(nil).StartRolloutBatch), arg0, arg1)
+}
+
 // Type mocks base method.
 func (m *MockModel) Type() state.ModelType {
 	m.ctrl.T.Helper()
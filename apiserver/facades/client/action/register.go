@@ -16,13 +16,37 @@ func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Action", 7, func(ctx facade.Context) (facade.Facade, error) {
 		return newActionAPIV7(ctx)
 	}, reflect.TypeOf((*APIv7)(nil)))
+	registry.MustRegister("Action", 8, func(ctx facade.Context) (facade.Facade, error) {
+		return newActionAPIV8(ctx)
+	}, reflect.TypeOf((*APIv8)(nil)))
+	registry.MustRegister("Action", 9, func(ctx facade.Context) (facade.Facade, error) {
+		return newActionAPIV9(ctx)
+	}, reflect.TypeOf((*APIv9)(nil)))
 }
 
 // newActionAPIV7 returns an initialized ActionAPI for version 7.
 func newActionAPIV7(ctx facade.Context) (*APIv7, error) {
-	api, err := newActionAPI(&stateShim{st: ctx.State()}, ctx.Resources(), ctx.Auth(), ctx.LeadershipReader)
+	api, err := newActionAPIV8(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv7{api}, nil
 }
+
+// newActionAPIV8 returns an initialized ActionAPI for version 8.
+func newActionAPIV8(ctx facade.Context) (*APIv8, error) {
+	api, err := newActionAPIV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// newActionAPIV9 returns an initialized ActionAPI for version 9.
+func newActionAPIV9(ctx facade.Context) (*APIv9, error) {
+	api, err := newActionAPI(&stateShim{st: ctx.State()}, ctx.Resources(), ctx.Auth(), ctx.LeadershipReader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// rolloutTask is a task of a rollout along with the index of the
+// action it was requested by.
+type rolloutTask struct {
+	index int
+	task  state.RolloutTask
+}
+
+// enqueueRollout queues up the actions as an operation whose tasks are
+// enqueued in batches, with the tasks on application leaders in the
+// last batches. Only the first batch is enqueued here; the rest are
+// enqueued by the controller as each batch finishes, so the rollout
+// carries on without the client. The results for tasks not yet
+// enqueued are pending and have no action.
+func (a *ActionAPI) enqueueRollout(summary string, arg params.Actions) (string, params.ActionResults, error) {
+	spec := arg.Rollout
+	if spec.BatchSize < 1 {
+		return "", params.ActionResults{}, errors.NotValidf("batch size %d", spec.BatchSize)
+	}
+	if spec.BatchDelay < 0 {
+		return "", params.ActionResults{}, errors.NotValidf("negative batch delay")
+	}
+	leaders, err := a.leadership.Leaders()
+	if err != nil {
+		return "", params.ActionResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
+	var tasks, leaderTasks []rolloutTask
+	for i, action := range arg.Actions {
+		receiver := action.Receiver
+		if strings.HasSuffix(receiver, "leader") {
+			app := strings.Split(receiver, "/")[0]
+			leader, ok := leaders[app]
+			if !ok {
+				err := errors.Errorf("could not determine leader for %q", app)
+				response.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+			receiver = names.NewUnitTag(leader).String()
+		}
+		task := rolloutTask{
+			index: i,
+			task: state.RolloutTask{
+				Receiver:       receiver,
+				Name:           action.Name,
+				Parameters:     action.Parameters,
+				Parallel:       action.Parallel,
+				ExecutionGroup: action.ExecutionGroup,
+			},
+		}
+		if isLeader(receiver, leaders) {
+			leaderTasks = append(leaderTasks, task)
+		} else {
+			tasks = append(tasks, task)
+		}
+	}
+	tasks = append(tasks, leaderTasks...)
+	if len(tasks) == 0 {
+		// Nothing can be enqueued; record the operation as failed.
+		operationID, err := a.model.EnqueueOperation(summary, len(arg.Actions))
+		if err != nil {
+			return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
+		}
+		err = a.handleFailedActionEnqueuing(operationID, response, len(arg.Actions))
+		return operationID, response, errors.Trace(err)
+	}
+
+	rollout := state.OperationRollout{
+		BatchSize:     spec.BatchSize,
+		BatchDelay:    spec.BatchDelay,
+		StopOnFailure: spec.StopOnFailure,
+	}
+	for start := 0; start < len(tasks); start += spec.BatchSize {
+		end := start + spec.BatchSize
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+		batch := make([]state.RolloutTask, end-start)
+		for i, t := range tasks[start:end] {
+			batch[i] = t.task
+		}
+		rollout.Batches = append(rollout.Batches, batch)
+	}
+	operationID, err := a.model.EnqueueRolloutOperation(summary, len(tasks), rollout)
+	if err != nil {
+		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
+	}
+	if err := a.model.StartRolloutBatch(operationID, 0); err != nil {
+		return "", params.ActionResults{}, errors.Annotate(err, "starting first batch")
+	}
+
+	tagToActionReceiver := a.tagToActionReceiverFn(a.state.FindEntity)
+	for i, t := range tasks {
+		if i >= spec.BatchSize {
+			response.Results[t.index].Status = string(state.ActionPending)
+			continue
+		}
+		receiver, err := tagToActionReceiver(t.task.Receiver)
+		if err != nil {
+			response.Results[t.index].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		enqueued, err := a.model.AddAction(
+			receiver, operationID, t.task.Name, t.task.Parameters, t.task.Parallel, t.task.ExecutionGroup,
+		)
+		if err != nil {
+			response.Results[t.index].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		response.Results[t.index] = common.MakeActionResult(receiver.Tag(), enqueued)
+	}
+
+	err = a.handleFailedActionEnqueuing(operationID, response, len(arg.Actions))
+	return operationID, response, errors.Trace(err)
+}
+
+// isLeader returns true if the receiver is the unit leading its
+// application.
+func isLeader(receiver string, leaders map[string]string) bool {
+	tag, err := names.ParseUnitTag(receiver)
+	if err != nil {
+		return false
+	}
+	app, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return false
+	}
+	return leaders[app] == tag.Id()
+}
+
+// rolloutInfo converts the rollout of an operation to its params
+// representation.
+func rolloutInfo(rollout *state.OperationRollout) *params.RolloutInfo {
+	if rollout == nil {
+		return nil
+	}
+	return &params.RolloutInfo{
+		BatchSize:       rollout.BatchSize,
+		BatchDelay:      rollout.BatchDelay,
+		StopOnFailure:   rollout.StopOnFailure,
+		Batches:         len(rollout.Batches),
+		BatchesEnqueued: rollout.BatchesEnqueued,
+		Stopped:         rollout.Stopped,
+	}
+}
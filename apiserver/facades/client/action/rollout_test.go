@@ -0,0 +1,178 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+type rolloutSuite struct {
+	action.MockBaseSuite
+
+	model *action.MockModel
+}
+
+var _ = gc.Suite(&rolloutSuite{})
+
+func (s *rolloutSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.Authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.Authorizer.EXPECT().HasPermission(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.Authorizer.EXPECT().AuthClient().Return(true)
+
+	s.model = action.NewMockModel(ctrl)
+	s.model.EXPECT().ModelTag().Return(names.NewModelTag("model-tag")).MinTimes(1)
+
+	s.State = action.NewMockState(ctrl)
+	s.State.EXPECT().Model().Return(s.model, nil)
+
+	s.ActionReceiver = action.NewMockActionReceiver(ctrl)
+	s.Leadership = action.NewMockReader(ctrl)
+	return ctrl
+}
+
+func (s *rolloutSuite) expectAddAction(ctrl *gomock.Controller, unit, id string) {
+	enqueued := action.NewMockAction(ctrl)
+	s.model.EXPECT().AddAction(s.ActionReceiver, "1", "backup", map[string]interface{}{}, nil, nil).Return(enqueued, nil)
+	s.ActionReceiver.EXPECT().Tag().Return(names.NewUnitTag(unit))
+	aExp := enqueued.EXPECT()
+	aExp.ActionTag().Return(names.NewActionTag(id))
+	aExp.Status().Return(state.ActionPending)
+	aExp.Name().Return("backup")
+	aExp.Parameters().Return(map[string]interface{}{})
+	aExp.Messages().Return(nil)
+	aExp.Results().Return(map[string]interface{}{}, "")
+	aExp.Started().Return(time.Time{})
+	aExp.Completed().Return(time.Time{})
+	aExp.Enqueued().Return(time.Now())
+	aExp.Parallel().Return(false)
+	aExp.ExecutionGroup().Return("")
+}
+
+func rolloutTask(unit string) state.RolloutTask {
+	return state.RolloutTask{
+		Receiver:   names.NewUnitTag(unit).String(),
+		Name:       "backup",
+		Parameters: map[string]interface{}{},
+	}
+}
+
+func backupActions(receivers ...string) []params.Action {
+	result := make([]params.Action, len(receivers))
+	for i, receiver := range receivers {
+		result[i] = params.Action{
+			Receiver:   receiver,
+			Name:       "backup",
+			Parameters: map[string]interface{}{},
+		}
+	}
+	return result
+}
+
+func (s *rolloutSuite) TestEnqueueRolloutLeaderLast(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.Leadership.EXPECT().Leaders().Return(map[string]string{"mysql": "mysql/0"}, nil)
+	s.model.EXPECT().EnqueueRolloutOperation(gomock.Any(), 3, state.OperationRollout{
+		BatchSize:     2,
+		BatchDelay:    time.Minute,
+		StopOnFailure: true,
+		Batches: [][]state.RolloutTask{
+			{rolloutTask("mysql/1"), rolloutTask("mysql/2")},
+			{rolloutTask("mysql/0")},
+		},
+	}).Return("1", nil)
+	s.model.EXPECT().StartRolloutBatch("1", 0).Return(nil)
+	s.expectAddAction(ctrl, "mysql/1", "2")
+	s.expectAddAction(ctrl, "mysql/2", "3")
+
+	api := s.NewActionAPI(c)
+	r, err := api.EnqueueOperation(params.Actions{
+		Actions: backupActions("unit-mysql-0", "unit-mysql-1", "unit-mysql-2"),
+		Rollout: &params.RolloutSpec{
+			BatchSize:     2,
+			BatchDelay:    time.Minute,
+			StopOnFailure: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.OperationTag, gc.Equals, "operation-1")
+	c.Assert(r.Actions, gc.HasLen, 3)
+	c.Assert(r.Actions[0].Action, gc.IsNil)
+	c.Assert(r.Actions[0].Error, gc.IsNil)
+	c.Assert(r.Actions[0].Status, gc.Equals, "pending")
+	c.Assert(r.Actions[1].Action.Tag, gc.Equals, "action-2")
+	c.Assert(r.Actions[1].Action.Receiver, gc.Equals, "unit-mysql-1")
+	c.Assert(r.Actions[2].Action.Tag, gc.Equals, "action-3")
+	c.Assert(r.Actions[2].Action.Receiver, gc.Equals, "unit-mysql-2")
+}
+
+func (s *rolloutSuite) TestEnqueueRolloutResolvesLeader(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.Leadership.EXPECT().Leaders().Return(map[string]string{"mysql": "mysql/1"}, nil)
+	s.model.EXPECT().EnqueueRolloutOperation(gomock.Any(), 2, state.OperationRollout{
+		BatchSize: 1,
+		Batches: [][]state.RolloutTask{
+			{rolloutTask("wordpress/0")},
+			{rolloutTask("mysql/1")},
+		},
+	}).Return("1", nil)
+	s.model.EXPECT().StartRolloutBatch("1", 0).Return(nil)
+	s.expectAddAction(ctrl, "wordpress/0", "2")
+	s.model.EXPECT().FailOperationEnqueuing("1", `error(s) enqueueing action(s): could not determine leader for "redis"`, 2)
+
+	api := s.NewActionAPI(c)
+	r, err := api.EnqueueOperation(params.Actions{
+		Actions: backupActions("mysql/leader", "redis/leader", "unit-wordpress-0"),
+		Rollout: &params.RolloutSpec{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Actions, gc.HasLen, 3)
+	c.Assert(r.Actions[0].Status, gc.Equals, "pending")
+	c.Assert(r.Actions[1].Error, gc.DeepEquals, &params.Error{Message: `could not determine leader for "redis"`})
+	c.Assert(r.Actions[2].Action.Tag, gc.Equals, "action-2")
+}
+
+func (s *rolloutSuite) TestEnqueueRolloutInvalidBatchSize(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	api := s.NewActionAPI(c)
+	_, err := api.EnqueueOperation(params.Actions{
+		Actions: backupActions("unit-mysql-0"),
+		Rollout: &params.RolloutSpec{BatchSize: 0},
+	})
+	c.Assert(err, gc.ErrorMatches, "batch size 0 not valid")
+}
+
+func (s *rolloutSuite) TestEnqueueRolloutNothingToEnqueue(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.Leadership.EXPECT().Leaders().Return(map[string]string{}, nil)
+	s.model.EXPECT().EnqueueOperation(gomock.Any(), 1).Return("1", nil)
+	s.model.EXPECT().FailOperationEnqueuing("1", `error(s) enqueueing action(s): could not determine leader for "mysql"`, 0)
+
+	api := s.NewActionAPI(c)
+	r, err := api.EnqueueOperation(params.Actions{
+		Actions: backupActions("mysql/leader"),
+		Rollout: &params.RolloutSpec{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Actions, gc.HasLen, 1)
+	c.Assert(r.Actions[0].Error, gc.NotNil)
+}
@@ -46,6 +46,7 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.EnqueuedActions, e
 	if err != nil {
 		return results, errors.Trace(err)
 	}
+	actionParams.Rollout = run.Rollout
 	return a.EnqueueOperation(actionParams)
 }
 
@@ -80,6 +81,7 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Enque
 	if err != nil {
 		return results, errors.Trace(err)
 	}
+	actionParams.Rollout = run.Rollout
 	return a.EnqueueOperation(actionParams)
 }
 
@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// AddSchedules adds schedules on which to run actions. The actions
+// are run by the controller, which starts an operation for each run.
+func (a *ActionAPI) AddSchedules(args params.AddActionSchedules) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Schedules))}
+	for i, arg := range args.Schedules {
+		results.Results[i].Error = apiservererrors.ServerError(a.addSchedule(arg))
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) addSchedule(arg params.ActionSchedule) error {
+	if actions.IsJujuExecAction(arg.Action) {
+		// Scheduling commands is the same as running them.
+		if err := a.checkCanAdmin(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, target := range arg.Targets {
+		if err := validateScheduleTarget(target); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return a.model.AddActionSchedule(state.ActionSchedule{
+		Name: arg.Name,
+		Schedule: actions.Schedule{
+			Cron:     arg.Cron,
+			Interval: arg.Interval,
+		},
+		Targets:           arg.Targets,
+		Action:            arg.Action,
+		Parameters:        arg.Parameters,
+		ConcurrencyPolicy: actions.ConcurrencyPolicy(arg.ConcurrencyPolicy),
+	})
+}
+
+// validateScheduleTarget returns an error unless the target is a unit,
+// the leader unit of an application or an application.
+func validateScheduleTarget(target string) error {
+	if strings.HasSuffix(target, "/leader") {
+		target = strings.TrimSuffix(target, "/leader")
+	} else if names.IsValidUnit(target) {
+		return nil
+	}
+	if !names.IsValidApplication(target) {
+		return errors.NotValidf("target %q", target)
+	}
+	return nil
+}
+
+// ListSchedules returns the action schedules of the model along with
+// their most recent runs.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		out := params.ActionSchedule{
+			Name:              schedule.Name,
+			Cron:              schedule.Schedule.Cron,
+			Interval:          schedule.Schedule.Interval,
+			Targets:           schedule.Targets,
+			Action:            schedule.Action,
+			Parameters:        schedule.Parameters,
+			ConcurrencyPolicy: string(schedule.ConcurrencyPolicy),
+			Created:           schedule.Created,
+			NextRun:           schedule.NextRun,
+		}
+		for _, run := range schedule.Runs {
+			outRun := params.ScheduledRun{Time: run.Time, Skipped: run.Skipped}
+			if run.OperationID != "" {
+				outRun.OperationTag = names.NewOperationTag(run.OperationID).String()
+			}
+			out.Runs = append(out.Runs, outRun)
+		}
+		result.Schedules[i] = out
+	}
+	return result, nil
+}
+
+// RemoveSchedules removes the action schedules with the given names.
+// Operations already started by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Names))}
+	for i, name := range args.Names {
+		results.Results[i].Error = apiservererrors.ServerError(a.model.RemoveActionSchedule(name))
+	}
+	return results, nil
+}
+
+// AddSchedules isn't on the v8 API.
+func (*APIv8) AddSchedules(_, _ struct{}) {}
+
+// ListSchedules isn't on the v8 API.
+func (*APIv8) ListSchedules(_, _ struct{}) {}
+
+// RemoveSchedules isn't on the v8 API.
+func (*APIv8) RemoveSchedules(_, _ struct{}) {}
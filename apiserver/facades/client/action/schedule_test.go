@@ -0,0 +1,186 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+type scheduleSuite struct {
+	action.MockBaseSuite
+
+	model *action.MockModel
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+var modelTag = names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+
+func (s *scheduleSuite) setupMocks(c *gc.C, admin bool) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.Authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.Authorizer.EXPECT().AuthClient().Return(true)
+	s.Authorizer.EXPECT().HasPermission(permission.ReadAccess, modelTag).Return(nil).AnyTimes()
+	s.Authorizer.EXPECT().HasPermission(permission.WriteAccess, modelTag).Return(nil).AnyTimes()
+	if admin {
+		s.Authorizer.EXPECT().HasPermission(permission.AdminAccess, modelTag).Return(nil).AnyTimes()
+	} else {
+		s.Authorizer.EXPECT().HasPermission(permission.AdminAccess, modelTag).Return(apiservererrors.ErrPerm).AnyTimes()
+	}
+
+	s.model = action.NewMockModel(ctrl)
+	s.model.EXPECT().ModelTag().Return(modelTag).AnyTimes()
+
+	s.State = action.NewMockState(ctrl)
+	s.State.EXPECT().Model().Return(s.model, nil)
+	s.State.EXPECT().GetBlockForType(gomock.Any()).Return(nil, false, nil).AnyTimes()
+
+	s.Leadership = action.NewMockReader(ctrl)
+	return ctrl
+}
+
+func (s *scheduleSuite) TestAddSchedules(c *gc.C) {
+	defer s.setupMocks(c, false).Finish()
+
+	s.model.EXPECT().AddActionSchedule(state.ActionSchedule{
+		Name:              "nightly",
+		Schedule:          actions.Schedule{Cron: "0 3 * * *"},
+		Targets:           []string{"mysql", "redis/leader", "wordpress/0"},
+		Action:            "backup",
+		Parameters:        map[string]interface{}{"full": true},
+		ConcurrencyPolicy: actions.ConcurrencyReplace,
+	}).Return(nil)
+	s.model.EXPECT().AddActionSchedule(gomock.Any()).Return(errors.AlreadyExistsf(`schedule "hourly"`))
+
+	api := s.NewActionAPI(c)
+	results, err := api.AddSchedules(params.AddActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:              "nightly",
+			Cron:              "0 3 * * *",
+			Targets:           []string{"mysql", "redis/leader", "wordpress/0"},
+			Action:            "backup",
+			Parameters:        map[string]interface{}{"full": true},
+			ConcurrencyPolicy: "replace",
+		}, {
+			Name:     "hourly",
+			Interval: time.Hour,
+			Targets:  []string{"mysql"},
+			Action:   "backup",
+		}, {
+			Name:     "weekly",
+			Interval: time.Hour,
+			Targets:  []string{"unit-mysql-0"},
+			Action:   "backup",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `schedule "hourly" already exists`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `target "unit-mysql-0" not valid`)
+}
+
+func (s *scheduleSuite) TestAddSchedulesJujuExecRequiresAdmin(c *gc.C) {
+	defer s.setupMocks(c, false).Finish()
+
+	api := s.NewActionAPI(c)
+	results, err := api.AddSchedules(params.AddActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "cleanup",
+			Interval:   time.Hour,
+			Targets:    []string{"mysql"},
+			Action:     "juju-exec",
+			Parameters: map[string]interface{}{"command": "rm -rf /tmp/*"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *scheduleSuite) TestAddSchedulesJujuExecAsAdmin(c *gc.C) {
+	defer s.setupMocks(c, true).Finish()
+
+	s.model.EXPECT().AddActionSchedule(gomock.Any()).Return(nil)
+
+	api := s.NewActionAPI(c)
+	results, err := api.AddSchedules(params.AddActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "cleanup",
+			Interval:   time.Hour,
+			Targets:    []string{"mysql"},
+			Action:     "juju-exec",
+			Parameters: map[string]interface{}{"command": "rm -rf /tmp/*"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *scheduleSuite) TestListSchedules(c *gc.C) {
+	defer s.setupMocks(c, false).Finish()
+
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	s.model.EXPECT().AllActionSchedules().Return([]state.ActionSchedule{{
+		Name:              "hourly",
+		Schedule:          actions.Schedule{Interval: time.Hour},
+		Targets:           []string{"mysql"},
+		Action:            "backup",
+		ConcurrencyPolicy: actions.ConcurrencyForbid,
+		Created:           created,
+		NextRun:           created.Add(3 * time.Hour),
+		Runs: []state.ScheduledRun{
+			{Time: created.Add(time.Hour), OperationID: "1"},
+			{Time: created.Add(2 * time.Hour), Skipped: "no units to run on"},
+		},
+	}}, nil)
+
+	api := s.NewActionAPI(c)
+	result, err := api.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:              "hourly",
+			Interval:          time.Hour,
+			Targets:           []string{"mysql"},
+			Action:            "backup",
+			ConcurrencyPolicy: "forbid",
+			Created:           created,
+			NextRun:           created.Add(3 * time.Hour),
+			Runs: []params.ScheduledRun{
+				{Time: created.Add(time.Hour), OperationTag: "operation-1"},
+				{Time: created.Add(2 * time.Hour), Skipped: "no units to run on"},
+			},
+		}},
+	})
+}
+
+func (s *scheduleSuite) TestRemoveSchedules(c *gc.C) {
+	defer s.setupMocks(c, false).Finish()
+
+	s.model.EXPECT().RemoveActionSchedule("hourly").Return(nil)
+	s.model.EXPECT().RemoveActionSchedule("nightly").Return(errors.NotFoundf(`schedule "nightly"`))
+
+	api := s.NewActionAPI(c)
+	results, err := api.RemoveSchedules(params.ActionScheduleNames{Names: []string{"hourly", "nightly"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `schedule "nightly" not found`)
+	c.Assert(results.Results[1].Error.Code, gc.Equals, params.CodeNotFound)
+}
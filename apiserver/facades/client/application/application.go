@@ -31,6 +31,7 @@ import (
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/charmhub"
+	coreapplication "github.com/juju/juju/core/application"
 	corebase "github.com/juju/juju/core/base"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/config"
@@ -61,9 +62,14 @@ var ClassifyDetachedStorage = storagecommon.ClassifyDetachedStorage
 
 var logger = loggo.GetLogger("juju.apiserver.application")
 
+// APIv20 provides the Application API facade for version 20.
+type APIv20 struct {
+	*APIBase
+}
+
 // APIv19 provides the Application API facade for version 19.
 type APIv19 struct {
-	*APIBase
+	*APIv20
 }
 
 // APIv18 provides the Application API facade for version 18.
@@ -735,6 +741,11 @@ func parseCharmSettings(
 	if err != nil {
 		return nil, nil, nil, nil, errors.Trace(err)
 	}
+	if modelType == state.ModelTypeCAAS {
+		if err := k8s.ValidateConfig(appConfig.Attributes()); err != nil {
+			return nil, nil, nil, nil, errors.Trace(err)
+		}
+	}
 
 	// If there isn't a charm YAML, then we can just return the charmConfig as
 	// the settings and no need to attempt to parse an empty yaml.
@@ -1897,6 +1908,68 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 	}, nil
 }
 
+// SetAutoscalePolicies sets, or removes, the autoscale policies of the
+// specified applications. An autoscaled application is scaled by the
+// controller to keep the resource usage of its units near a target.
+func (api *APIBase) SetAutoscalePolicies(args params.SetAutoscalePoliciesArgs) (params.ErrorResults, error) {
+	if api.modelType != state.ModelTypeCAAS {
+		return params.ErrorResults{}, errors.NotSupportedf("autoscaling applications on a non-container model")
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	setPolicy := func(arg params.SetAutoscalePolicyArg) error {
+		appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := appTag.Id()
+		app, err := api.backend.Application(name)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("application %q does not exist", name)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if arg.Policy == nil {
+			return errors.Trace(app.SetAutoscalePolicy(nil))
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ch.Meta().Deployment != nil {
+			if ch.Meta().Deployment.DeploymentMode == charm.ModeOperator {
+				return errors.NotSupportedf("autoscale an %q application", charm.ModeOperator)
+			}
+			if ch.Meta().Deployment.DeploymentType == charm.DeploymentDaemon {
+				return errors.NotSupportedf("autoscale a %q application", charm.DeploymentDaemon)
+			}
+		}
+		policy := coreapplication.AutoscalePolicy{
+			MinUnits:     arg.Policy.MinUnits,
+			MaxUnits:     arg.Policy.MaxUnits,
+			TargetCPU:    arg.Policy.TargetCPU,
+			TargetMemory: arg.Policy.TargetMemory,
+			Cooldown:     arg.Policy.Cooldown,
+		}
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(app.SetAutoscalePolicy(&policy))
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = apiservererrors.ServerError(setPolicy(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// SetAutoscalePolicies isn't on the v19 API.
+func (*APIv19) SetAutoscalePolicies(_, _ struct{}) {}
+
 // GetConstraints returns the constraints for a given application.
 func (api *APIBase) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -34,6 +34,7 @@ import (
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
 	coreassumes "github.com/juju/juju/core/assumes"
 	corecharm "github.com/juju/juju/core/charm"
 	coreconfig "github.com/juju/juju/core/config"
@@ -1863,6 +1864,38 @@ func (s *ApplicationSuite) TestDeployCAASInvalidServiceType(c *gc.C) {
 	c.Assert(result.OneError(), gc.ErrorMatches, `service type "ClusterIP" not valid`)
 }
 
+func (s *ApplicationSuite) TestDeployCAASInvalidMaxUnavailable(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	ch := s.expectCharm(ctrl,
+		&charm.Meta{
+			// To ensure we don't require k8s operator storage
+			MinJujuVersion: version.Number{Major: 2, Minor: 8, Patch: 1},
+		},
+		&charm.Manifest{},
+		&charm.Config{},
+	)
+	s.backend.EXPECT().Charm(gomock.Any()).Return(ch, nil)
+	s.expectDefaultK8sModelConfig()
+
+	curl := "local:foo-0"
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        curl,
+			CharmOrigin:     createCharmOriginFromURL(curl),
+			NumUnits:        1,
+			Config:          map[string]string{"kubernetes-max-unavailable": "0%"},
+		}},
+	}
+	result, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.OneError(), gc.ErrorMatches, `invalid kubernetes-max-unavailable: max unavailable "0%", expected a percentage between 1% and 100% not valid`)
+}
+
 func (s *ApplicationSuite) TestDeployCAASBlockStorageRejected(c *gc.C) {
 	s.modelType = state.ModelTypeCAAS
 	ctrl := s.setup(c)
@@ -2365,6 +2398,108 @@ func (s *ApplicationSuite) TestScaleApplicationsIAASModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "scaling applications on a non-container model not supported")
 }
 
+func (s *ApplicationSuite) TestSetAutoscalePolicies(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	app := s.expectDefaultApplication(ctrl)
+	app.EXPECT().SetAutoscalePolicy(&coreapplication.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  time.Minute,
+	}).Return(nil)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy: &params.AutoscalePolicy{
+				MinUnits:  1,
+				MaxUnits:  5,
+				TargetCPU: 60,
+				Cooldown:  time.Minute,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesRemove(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	app := s.expectDefaultApplication(ctrl)
+	app.EXPECT().SetAutoscalePolicy(nil).Return(nil)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesInvalid(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	app := s.expectDefaultApplication(ctrl)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy: &params.AutoscalePolicy{
+				MinUnits: 3,
+				MaxUnits: 2,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "max units 2 less than min units 3 not valid")
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesNotAllowedForDaemonSet(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	ch := s.expectCharm(ctrl, &charm.Meta{
+		Deployment: &charm.Deployment{
+			DeploymentType: charm.DeploymentDaemon,
+		},
+	}, nil, nil)
+	app := s.expectApplicationWithCharm(ctrl, ch, "postgresql")
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy:         &params.AutoscalePolicy{MinUnits: 1, MaxUnits: 2, TargetCPU: 50},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `autoscale a "daemon" application not supported`)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesIAASModel(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on a non-container model not supported")
+}
+
 func (s *ApplicationSuite) expectRelation(ctrl *gomock.Controller, name string, suspended bool) *mocks.MockRelation {
 	rel := mocks.NewMockRelation(ctrl)
 	rel.EXPECT().Tag().Return(names.NewRelationTag(name)).AnyTimes()
@@ -20,6 +20,7 @@ import (
 	services "github.com/juju/juju/apiserver/facades/client/charms/services"
 	cloud "github.com/juju/juju/cloud"
 	controller "github.com/juju/juju/controller"
+	application0 "github.com/juju/juju/core/application"
 	config "github.com/juju/juju/core/config"
 	constraints "github.com/juju/juju/core/constraints"
 	crossmodel "github.com/juju/juju/core/crossmodel"
@@ -1371,6 +1372,20 @@ func (mr *MockApplicationMockRecorder) Relations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relations", reflect.TypeOf((*MockApplication)(nil).Relations))
 }
 
+// SetAutoscalePolicy mocks base method.
+func (m *MockApplication) SetAutoscalePolicy(arg0 *application0.AutoscalePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoscalePolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutoscalePolicy indicates an expected call of SetAutoscalePolicy.
+func (mr *MockApplicationMockRecorder) SetAutoscalePolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoscalePolicy", reflect.TypeOf((*MockApplication)(nil).SetAutoscalePolicy), arg0)
+}
+
 // SetCharm mocks base method.
 func (m *MockApplication) SetCharm(arg0 state.SetCharmConfig) error {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ package backups
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/mgo/v3"
 	"github.com/juju/names/v5"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/controller"
 	corebase "github.com/juju/juju/core/base"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
@@ -34,17 +36,45 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (controller.StateServingInfo, error)
 	ControllerNodes() ([]state.ControllerNode, error)
+	APIHostPortsForClients() ([]corenetwork.SpaceHostPorts, error)
+	APIHostPortsForAgents() ([]corenetwork.SpaceHostPorts, error)
+	SetAPIHostPorts([]corenetwork.SpaceHostPorts) error
+	AgentHosts() ([]AgentHost, error)
 }
 
+var logger = loggo.GetLogger("juju.apiserver.backups")
+
 // API provides backup-specific API methods.
 type API struct {
 	backend Backend
 	paths   *backups.Paths
+	hub     facade.Hub
 
 	// machineID is the ID of the machine where the API server is running.
 	machineID string
 }
 
+// APIv4 provides the Backups API facade for version 4, which has no
+// Restore method.
+type APIv4 struct {
+	*API
+}
+
+// APIv3 provides the Backups API facade for version 3, which has no
+// List or Prune methods.
+type APIv3 struct {
+	*APIv4
+}
+
+// Restore isn't on the v4 API.
+func (*APIv4) Restore(_ struct{}) {}
+
+// List isn't on the v3 API.
+func (*APIv3) List(_ struct{}) {}
+
+// Prune isn't on the v3 API.
+func (*APIv3) Prune(_ struct{}) {}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -149,6 +179,7 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.ControllerMachineID = meta.Controller.MachineID
 	result.ControllerMachineInstanceID = meta.Controller.MachineInstanceID
 	result.Filename = filename
+	result.Parent = meta.Parent
 
 	return result
 }
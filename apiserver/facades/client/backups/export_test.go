@@ -3,7 +3,16 @@
 
 package backups
 
+import "github.com/juju/juju/apiserver/facade"
+
 var (
 	NewBackups     = &newBackups
 	WaitUntilReady = &waitUntilReady
+	NewStorage     = &newStorage
+	RepointAgents  = &repointAgents
 )
+
+// SetHub sets the hub on which the API publishes restores.
+func SetHub(api *API, hub facade.Hub) {
+	api.hub = hub
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+var newStorage = func(cfg controller.Config, dataDir string, controllerNodes int) (backups.ArchiveStorage, error) {
+	return backups.NewStorage(cfg, dataDir, controllerNodes, backups.NewS3ObjectStore)
+}
+
+// storage returns the storage holding the scheduled backups, as
+// configured in the controller config.
+func (a *API) storage() (backups.ArchiveStorage, controller.Config, error) {
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	nodes, err := a.backend.ControllerNodes()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	storage, err := newStorage(cfg, a.paths.DataDir, len(nodes))
+	return storage, cfg, errors.Trace(err)
+}
+
+// List returns the metadata of the stored scheduled backups, oldest
+// first.
+func (a *API) List(_ params.BackupsListArgs) (params.BackupsListResult, error) {
+	var result params.BackupsListResult
+	storage, _, err := a.storage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	metaList, err := storage.List(context.TODO())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.List = listResults(metaList)
+	return result, nil
+}
+
+// Prune removes all but the newest args.Keep full backups from the
+// storage, along with the incremental backups building upon them. The
+// backup-retention-count controller config is used if args.Keep is zero.
+// The metadata of the removed backups is returned.
+func (a *API) Prune(args params.BackupsPruneArgs) (params.BackupsListResult, error) {
+	var result params.BackupsListResult
+	storage, cfg, err := a.storage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	keep := args.Keep
+	if keep == 0 {
+		keep = cfg.BackupRetentionCount()
+	}
+	removed, err := backups.Prune(context.TODO(), storage, keep)
+	result.List = listResults(removed)
+	return result, errors.Trace(err)
+}
+
+func listResults(metaList []*backups.Metadata) []params.BackupsMetadataResult {
+	results := make([]params.BackupsMetadataResult, len(metaList))
+	for i, meta := range metaList {
+		results[i] = CreateResult(meta, meta.ID())
+	}
+	return results
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) setStorage(c *gc.C) backups.ArchiveStorage {
+	storage := backups.NewDirStorage(c.MkDir())
+	s.PatchValue(backupsAPI.NewStorage, func(controller.Config, string, int) (backups.ArchiveStorage, error) {
+		return storage, nil
+	})
+	return storage
+}
+
+func (s *backupsSuite) addStored(c *gc.C, storage backups.ArchiveStorage, id string, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	err := storage.Add(context.Background(), strings.NewReader("archive"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	storage := s.setStorage(c)
+	now := time.Now().UTC().Round(time.Second)
+	second := s.addStored(c, storage, "juju-backup-b.tar.gz", now)
+	first := s.addStored(c, storage, "juju-backup-a.tar.gz", now.Add(-time.Hour))
+
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.List, gc.HasLen, 2)
+	c.Check(result.List[0].ID, gc.Equals, first.ID())
+	c.Check(result.List[0].Filename, gc.Equals, first.ID())
+	c.Check(result.List[1].ID, gc.Equals, second.ID())
+}
+
+func (s *backupsSuite) TestListEmpty(c *gc.C) {
+	s.setStorage(c)
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.List, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestPrune(c *gc.C) {
+	storage := s.setStorage(c)
+	now := time.Now().UTC()
+	s.addStored(c, storage, "juju-backup-a.tar.gz", now.Add(-2*time.Hour))
+	s.addStored(c, storage, "juju-backup-b.tar.gz", now.Add(-time.Hour))
+	s.addStored(c, storage, "juju-backup-c.tar.gz", now)
+
+	result, err := s.api.Prune(params.BackupsPruneArgs{Keep: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.List, gc.HasLen, 2)
+	c.Check(result.List[0].ID, gc.Equals, "juju-backup-a.tar.gz")
+	c.Check(result.List[1].ID, gc.Equals, "juju-backup-b.tar.gz")
+
+	stored, err := storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, gc.HasLen, 1)
+	c.Check(stored[0].ID(), gc.Equals, "juju-backup-c.tar.gz")
+}
+
+func (s *backupsSuite) TestPruneDefaultsToRetentionCount(c *gc.C) {
+	storage := s.setStorage(c)
+	now := time.Now().UTC()
+	for i, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		s.addStored(c, storage, "juju-backup-"+id+".tar.gz", now.Add(time.Duration(i)*time.Minute))
+	}
+
+	result, err := s.api.Prune(params.BackupsPruneArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.List, gc.HasLen, 8-controller.DefaultBackupRetentionCount)
+	c.Check(result.List[0].ID, gc.Equals, "juju-backup-a.tar.gz")
+}
+
+func (s *backupsSuite) TestPruneInvalidKeep(c *gc.C) {
+	s.setStorage(c)
+	_, err := s.api.Prune(params.BackupsPruneArgs{Keep: -1})
+	c.Assert(err, gc.ErrorMatches, "keeping -1 backups not valid")
+}
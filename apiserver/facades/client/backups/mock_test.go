@@ -21,6 +21,7 @@ type stateShim struct {
 	isController     *bool
 	controllerNodesF func() ([]state.ControllerNode, error)
 	machineF         func(id string) (backups.Machine, error)
+	agentHostsF      func() ([]backups.AgentHost, error)
 }
 
 func (s *stateShim) IsController() bool {
@@ -50,6 +51,13 @@ func (s stateShim) Machine(id string) (backups.Machine, error) {
 	return s.machineF(id)
 }
 
+func (s stateShim) AgentHosts() ([]backups.AgentHost, error) {
+	if s.agentHostsF == nil {
+		return nil, nil
+	}
+	return s.agentHostsF()
+}
+
 type testMachine struct {
 	*state.Machine
 }
@@ -14,10 +14,32 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Backups", 3, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV3(ctx)
+	}, reflect.TypeOf((*APIv3)(nil)))
+	registry.MustRegister("Backups", 4, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV4(ctx)
+	}, reflect.TypeOf((*APIv4)(nil)))
+	registry.MustRegister("Backups", 5, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacade(ctx)
 	}, reflect.TypeOf((*API)(nil)))
 }
 
+func newFacadeV3(ctx facade.Context) (*APIv3, error) {
+	api, err := newFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{APIv4: api}, nil
+}
+
+func newFacadeV4(ctx facade.Context) (*APIv4, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{API: api}, nil
+}
+
 // newFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*API, error) {
 	st := ctx.State()
@@ -25,5 +47,10 @@ func newFacade(ctx facade.Context) (*API, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewAPI(&stateShim{st, model}, ctx.Resources(), ctx.Auth())
+	api, err := NewAPI(&stateShim{State: st, Model: model, pool: ctx.StatePool()}, ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.hub = ctx.Hub()
+	return api, nil
 }
@@ -0,0 +1,248 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	controllermsg "github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+var repointAgents = backups.RepointAgents
+
+// AgentHost identifies a machine, hosting juju agents, that may need to
+// be re-pointed at the controller after a restore.
+type AgentHost struct {
+	Model   string
+	Machine string
+	Address string
+
+	// HostKeys are the SSH host keys recorded for the machine. The
+	// machine is only connected to if it presents one of them.
+	HostKeys []string
+}
+
+// restoreArchive is a backup archive to be restored.
+type restoreArchive struct {
+	id   string
+	open func() (io.ReadCloser, error)
+}
+
+// Restore restores the controller databases from a backup: either a
+// stored scheduled backup, along with the backups it builds upon, or an
+// archive file on the controller. Only a controller with a single node
+// can be restored. While the restore runs the controller is quiesced:
+// the API server refuses calls other than from controller agents, and
+// the Dqlite node is stopped.
+//
+// If the controller addresses recorded in the restored database differ
+// from the current ones, the agents on each machine are re-pointed at
+// the current addresses. Agents in kubernetes models are re-pointed by
+// the provisioners, which apply the current addresses to the workloads
+// when they start. The controller agent restarts once the restore is
+// complete, to pick up the restored state.
+func (a *API) Restore(args params.BackupsRestoreArgs) (params.BackupsRestoreResult, error) {
+	var result params.BackupsRestoreResult
+	if (args.BackupID == "") == (args.FileName == "") {
+		return result, errors.NotValidf("restore without exactly one of backup ID and file name")
+	}
+
+	nodes, err := a.backend.ControllerNodes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(nodes) != 1 {
+		return result, errors.NotSupportedf("restoring a controller with %d nodes", len(nodes))
+	}
+
+	archives, err := a.restoreArchives(args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	if err := a.publishRestoring(true); err != nil {
+		return result, errors.Annotate(err, "quiescing controller")
+	}
+	result, err = a.restore(archives)
+	if err != nil {
+		// Resume the controller, which carries on with whatever state
+		// the failed restore left behind.
+		if pubErr := a.publishRestoring(false); pubErr != nil {
+			logger.Errorf("cannot resume controller after failed restore: %v", pubErr)
+		}
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// publishRestoring tells the controller whether a restore is in
+// progress, and waits for the subscribers to act on it.
+func (a *API) publishRestoring(inProgress bool) error {
+	if a.hub == nil {
+		return nil
+	}
+	done, err := a.hub.Publish(controllermsg.Restoring, controllermsg.RestoringMessage{
+		InProgress: inProgress,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	done()
+	return nil
+}
+
+// restore restores each of the archives in turn, then re-points the
+// agents if need be.
+func (a *API) restore(archives []restoreArchive) (params.BackupsRestoreResult, error) {
+	var result params.BackupsRestoreResult
+
+	// The addresses are recorded before the restore, as the restored
+	// database holds the addresses at the time of the backup.
+	hostPorts, err := a.backend.APIHostPortsForClients()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	session := a.backend.MongoSession().Copy()
+	defer session.Close()
+	mgoInfo, err := mongoInfo(a.paths.DataDir, a.machineID)
+	if err != nil {
+		return result, errors.Annotatef(err, "getting mongo info")
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, sessionShim{session})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	backupsMethods := newBackups(a.paths)
+	controllerUUID := a.backend.ControllerTag().Id()
+	for _, archive := range archives {
+		meta, err := restoreOne(backupsMethods, archive, dbInfo, controllerUUID)
+		if err != nil {
+			return result, errors.Annotatef(err, "restoring backup %q", archive.id)
+		}
+		result.Restored = append(result.Restored, CreateResult(meta, archive.id))
+	}
+
+	restoredHostPorts, err := a.backend.APIHostPortsForClients()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !reflect.DeepEqual(hostPorts, restoredHostPorts) {
+		if err := a.backend.SetAPIHostPorts(hostPorts); err != nil {
+			return result, errors.Annotate(err, "resetting controller addresses")
+		}
+		machines, err := a.repointAgents()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Machines = machines
+	}
+
+	if a.hub != nil {
+		msg := controllermsg.RestoredMessage{}
+		for _, restored := range result.Restored {
+			msg.BackupIDs = append(msg.BackupIDs, restored.ID)
+		}
+		if _, err := a.hub.Publish(controllermsg.Restored, msg); err != nil {
+			return result, errors.Annotate(err, "publishing restore")
+		}
+	}
+	return result, nil
+}
+
+// restoreArchives returns the archives to restore, in order.
+func (a *API) restoreArchives(args params.BackupsRestoreArgs) ([]restoreArchive, error) {
+	if args.FileName != "" {
+		if !filepath.IsAbs(args.FileName) || !strings.HasPrefix(filepath.Base(args.FileName), backups.FilenamePrefix) {
+			return nil, errors.NotValidf("backup file %q", args.FileName)
+		}
+		return []restoreArchive{{
+			id: filepath.Base(args.FileName),
+			open: func() (io.ReadCloser, error) {
+				f, err := os.Open(args.FileName)
+				if os.IsNotExist(err) {
+					return nil, errors.NotFoundf("backup file %q", args.FileName)
+				}
+				return f, errors.Trace(err)
+			},
+		}}, nil
+	}
+
+	storage, _, err := a.storage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	chain, err := backups.RestoreChain(context.TODO(), storage, args.BackupID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archives := make([]restoreArchive, len(chain))
+	for i, meta := range chain {
+		id := meta.ID()
+		archives[i] = restoreArchive{
+			id: id,
+			open: func() (io.ReadCloser, error) {
+				return storage.Get(context.TODO(), id)
+			},
+		}
+	}
+	return archives, nil
+}
+
+func restoreOne(
+	backupsMethods backups.Backups, archive restoreArchive, dbInfo *backups.DBInfo, controllerUUID string,
+) (*backups.Metadata, error) {
+	r, err := archive.open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	meta, err := backupsMethods.Restore(r, dbInfo, controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The metadata held in an archive does not record its ID.
+	meta.SetID(archive.id)
+	return meta, nil
+}
+
+// repointAgents updates the agents on each machine to connect to the
+// current controller addresses.
+func (a *API) repointAgents() ([]params.BackupsRestoreMachineResult, error) {
+	hostPorts, err := a.backend.APIHostPortsForAgents()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var addrs []string
+	for _, server := range hostPorts {
+		addrs = append(addrs, server.HostPorts().Strings()...)
+	}
+
+	hosts, err := a.backend.AgentHosts()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing machines to update")
+	}
+	results := make([]params.BackupsRestoreMachineResult, len(hosts))
+	for i, host := range hosts {
+		results[i].Model = host.Model
+		results[i].Machine = host.Machine
+		if err := repointAgents(a.paths.DataDir, host.Address, host.HostKeys, addrs); err != nil {
+			logger.Warningf("cannot update agents on machine %s in model %s: %v", host.Machine, host.Model, err)
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
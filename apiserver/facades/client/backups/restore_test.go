@@ -0,0 +1,195 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/core/network"
+	controllermsg "github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type published struct {
+	topic string
+	data  interface{}
+}
+
+type recordingHub struct {
+	published []published
+}
+
+func (h *recordingHub) Publish(topic string, data interface{}) (func(), error) {
+	h.published = append(h.published, published{topic: topic, data: data})
+	return func() {}, nil
+}
+
+// restoringBackups simulates a restore by running a function in place
+// of replacing the database.
+type restoringBackups struct {
+	backupstesting.FakeBackups
+	restored  []string
+	onRestore func()
+	err       error
+}
+
+func (b *restoringBackups) Restore(archive io.Reader, dbInfo *backups.DBInfo, controllerUUID string) (*backups.Metadata, error) {
+	data, err := io.ReadAll(archive)
+	if err != nil {
+		return nil, err
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.restored = append(b.restored, string(data))
+	if b.onRestore != nil {
+		b.onRestore()
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Controller.UUID = controllerUUID
+	return meta, nil
+}
+
+func (s *backupsSuite) newRestoreAPI(c *gc.C, hosts []backupsAPI.AgentHost) (*backupsAPI.API, *recordingHub, *restoringBackups) {
+	shim := &stateShim{
+		State: s.State,
+		Model: s.Model,
+		controllerNodesF: func() ([]state.ControllerNode, error) {
+			return []state.ControllerNode{nil}, nil
+		},
+		machineF: func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
+		agentHostsF: func() ([]backupsAPI.AgentHost, error) {
+			return hosts, nil
+		},
+	}
+	api, err := backupsAPI.NewAPI(shim, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	hub := &recordingHub{}
+	backupsAPI.SetHub(api, hub)
+
+	fake := &restoringBackups{}
+	s.PatchValue(backupsAPI.NewBackups, func(*backups.Paths) backups.Backups {
+		return fake
+	})
+	return api, hub, fake
+}
+
+func (s *backupsSuite) TestRestoreInvalidArgs(c *gc.C) {
+	api, _, _ := s.newRestoreAPI(c, nil)
+	_, err := api.Restore(params.BackupsRestoreArgs{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = api.Restore(params.BackupsRestoreArgs{BackupID: "juju-backup-a.tar.gz", FileName: "/juju-backup-a.tar.gz"})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = api.Restore(params.BackupsRestoreArgs{FileName: "juju-backup-a.tar.gz"})
+	c.Check(err, gc.ErrorMatches, `backup file "juju-backup-a.tar.gz" not valid`)
+}
+
+func (s *backupsSuite) TestRestoreRequiresSingleNode(c *gc.C) {
+	_, err := s.api.Restore(params.BackupsRestoreArgs{BackupID: "juju-backup-a.tar.gz"})
+	c.Check(err, gc.ErrorMatches, "restoring a controller with 0 nodes not supported")
+}
+
+func (s *backupsSuite) TestRestoreStoredChain(c *gc.C) {
+	storage := s.setStorage(c)
+	now := time.Now().UTC().Round(time.Second)
+	full := s.addStored(c, storage, "juju-backup-a.tar.gz", now.Add(-time.Hour))
+	incremental := backups.NewMetadata()
+	incremental.SetID("juju-backup-a-incremental.tar.gz")
+	incremental.Started = now
+	incremental.Parent = full.ID()
+	err := storage.Add(context.Background(), strings.NewReader("incremental"), incremental)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, hub, fake := s.newRestoreAPI(c, nil)
+	result, err := api.Restore(params.BackupsRestoreArgs{BackupID: incremental.ID()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.restored, jc.DeepEquals, []string{"archive", "incremental"})
+	c.Assert(result.Restored, gc.HasLen, 2)
+	c.Check(result.Restored[0].ID, gc.Equals, full.ID())
+	c.Check(result.Restored[1].ID, gc.Equals, incremental.ID())
+	c.Check(result.Machines, gc.HasLen, 0)
+
+	// The controller is quiesced before the restore, and restarts
+	// once it is complete.
+	c.Check(hub.published, jc.DeepEquals, []published{{
+		topic: controllermsg.Restoring,
+		data:  controllermsg.RestoringMessage{InProgress: true},
+	}, {
+		topic: controllermsg.Restored,
+		data: controllermsg.RestoredMessage{
+			BackupIDs: []string{full.ID(), incremental.ID()},
+		},
+	}})
+}
+
+func (s *backupsSuite) TestRestoreFailureResumesController(c *gc.C) {
+	storage := s.setStorage(c)
+	s.addStored(c, storage, "juju-backup-a.tar.gz", time.Now())
+
+	api, hub, fake := s.newRestoreAPI(c, nil)
+	fake.err = errors.New("boom")
+	_, err := api.Restore(params.BackupsRestoreArgs{BackupID: "juju-backup-a.tar.gz"})
+	c.Assert(err, gc.ErrorMatches, `restoring backup "juju-backup-a.tar.gz": boom`)
+
+	c.Check(hub.published, jc.DeepEquals, []published{{
+		topic: controllermsg.Restoring,
+		data:  controllermsg.RestoringMessage{InProgress: true},
+	}, {
+		topic: controllermsg.Restoring,
+		data:  controllermsg.RestoringMessage{InProgress: false},
+	}})
+}
+
+func (s *backupsSuite) TestRestoreRepointsAgents(c *gc.C) {
+	storage := s.setStorage(c)
+	s.addStored(c, storage, "juju-backup-a.tar.gz", time.Now())
+
+	current, err := s.State.APIHostPortsForClients()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var repointed []string
+	s.PatchValue(backupsAPI.RepointAgents, func(dataDir, host string, hostKeys, addrs []string) error {
+		if host == "10.0.0.2" {
+			return errors.New("boom")
+		}
+		c.Check(hostKeys, jc.DeepEquals, []string{"ssh-rsa AAAA1"})
+		repointed = append(repointed, host)
+		return nil
+	})
+	api, _, fake := s.newRestoreAPI(c, []backupsAPI.AgentHost{
+		{Model: s.Model.UUID(), Machine: "1", Address: "10.0.0.1", HostKeys: []string{"ssh-rsa AAAA1"}},
+		{Model: s.Model.UUID(), Machine: "2", Address: "10.0.0.2"},
+	})
+	// The restored database records the addresses at backup time.
+	fake.onRestore = func() {
+		err := s.State.SetAPIHostPorts([]network.SpaceHostPorts{
+			network.NewSpaceHostPorts(17070, "10.9.9.9"),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := api.Restore(params.BackupsRestoreArgs{BackupID: "juju-backup-a.tar.gz"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(repointed, jc.DeepEquals, []string{"10.0.0.1"})
+	c.Assert(result.Machines, gc.HasLen, 2)
+	c.Check(result.Machines[0].Error, gc.IsNil)
+	c.Check(result.Machines[1].Error, gc.ErrorMatches, "boom")
+
+	restored, err := s.State.APIHostPortsForClients()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restored, jc.DeepEquals, current)
+}
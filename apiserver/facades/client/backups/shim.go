@@ -25,6 +25,8 @@ import (
 type stateShim struct {
 	*state.State
 	*state.Model
+
+	pool *state.StatePool
 }
 
 // MachineBase implements backups.Backend
@@ -76,6 +78,62 @@ func (s stateShim) Machine(id string) (Machine, error) {
 	return m, nil
 }
 
+// AgentHosts returns the provisioned machines, other than the
+// controllers, across all models. Kubernetes models have no machines.
+func (s *stateShim) AgentHosts() ([]AgentHost, error) {
+	uuids, err := s.State.AllModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hosts []AgentHost
+	for _, uuid := range uuids {
+		st, err := s.pool.Get(uuid)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		hosts, err = appendAgentHosts(hosts, st.State)
+		st.Release()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return hosts, nil
+}
+
+func appendAgentHosts(hosts []AgentHost, st *state.State) ([]AgentHost, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsManager() {
+			continue
+		}
+		if _, err := m.InstanceId(); errors.Is(err, errors.NotProvisioned) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addr, err := m.PublicAddress()
+		if corenetwork.IsNoAddressError(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys, err := st.GetSSHHostKeys(m.MachineTag())
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+		hosts = append(hosts, AgentHost{
+			Model:    st.ModelUUID(),
+			Machine:  m.Id(),
+			Address:  addr.Value,
+			HostKeys: keys,
+		})
+	}
+	return hosts, nil
+}
+
 // Machine represent machine used in backups.
 type Machine interface {
 
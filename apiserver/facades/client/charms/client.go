@@ -4,6 +4,8 @@
 package charms
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
@@ -331,6 +333,12 @@ func (a *API) queueAsyncCharmDownload(args params.AddCharmWithAuth) (corecharm.O
 	}
 	metaRes := essentialMeta[0]
 
+	// Fail early if the charm isn't signed as the model requires, rather
+	// than once the charm is downloaded.
+	if err := a.checkCharmSigner(charmURL.Name, metaRes.ResolvedOrigin); err != nil {
+		return corecharm.Origin{}, errors.Trace(err)
+	}
+
 	_, err = a.backendState.AddCharmMetadata(state.CharmInfo{
 		Charm: corecharm.NewCharmInfoAdapter(metaRes),
 		ID:    args.URL,
@@ -342,6 +350,23 @@ func (a *API) queueAsyncCharmDownload(args params.AddCharmWithAuth) (corecharm.O
 	return metaRes.ResolvedOrigin, nil
 }
 
+// checkCharmSigner checks that the signature of the charm resolved to
+// origin, if any, is acceptable to the model's signing policy. The
+// signature is looked up by the archive hash in the resolved origin, and
+// the signed content is only verified once the charm is downloaded.
+func (a *API) checkCharmSigner(charmName string, origin corecharm.Origin) error {
+	policy, err := services.NewSigningPolicy(a.backendModel, a.charmhubHTTPClient, logger.Child("signatures"))
+	if err != nil || !policy.Enabled() || origin.Hash == "" {
+		return errors.Trace(err)
+	}
+	what := fmt.Sprintf("charm %q", charmName)
+	sig, err := policy.Signature(context.TODO(), what, "sha256:"+origin.Hash)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(policy.CheckSigner(what, corecharm.CharmSignatureNamespace, sig))
+}
+
 // ResolveCharms resolves the given charm URLs with an optionally specified
 // preferred channel.  Channel provided via CharmOrigin.
 func (a *API) ResolveCharms(args params.ResolveCharmsWithChannel) (params.ResolveCharmWithChannelResults, error) {
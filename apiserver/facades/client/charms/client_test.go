@@ -5,6 +5,9 @@ package charms_test
 
 import (
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/charm/v12"
 	"github.com/juju/errors"
@@ -25,6 +28,7 @@ import (
 	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/cache"
 	corecharm "github.com/juju/juju/core/charm"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	"github.com/juju/juju/core/constraints"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/instance"
@@ -32,8 +36,11 @@ import (
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/pki/ssh"
+	pkitest "github.com/juju/juju/pki/test"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -72,6 +79,7 @@ func (ctx *charmsSuiteContext) LeadershipReader(string) (leadership.Reader, erro
 func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error)               { return nil, nil }
 func (ctx *charmsSuiteContext) HTTPClient(facade.HTTPClientPurpose) facade.HTTPClient { return nil }
 func (ctx *charmsSuiteContext) ControllerDB() (coredatabase.TrackedDB, error)         { return nil, nil }
+func (ctx *charmsSuiteContext) CharmCache() charmdownloader.CharmCache                { return nil }
 
 func (s *charmsSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
@@ -352,6 +360,7 @@ func (s *charmsMockSuite) TestAddCharmCharmhub(c *gc.C) {
 
 	s.state.EXPECT().Charm(curl).Return(nil, errors.NotFoundf("%q", curl))
 	s.repoFactory.EXPECT().GetCharmRepository(gomock.Any()).Return(s.repository, nil)
+	s.expectModelConfig(c, nil)
 
 	expMeta := new(charm.Meta)
 	expManifest := new(charm.Manifest)
@@ -401,6 +410,64 @@ func (s *charmsMockSuite) TestAddCharmCharmhub(c *gc.C) {
 	})
 }
 
+func (s *charmsMockSuite) TestAddCharmCharmhubUnsignedRequired(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	_, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectModelConfig(c, coretesting.Attrs{
+		"charm-signing-keys": keys,
+		"signed-charms-only": true,
+	})
+	s.expectCharmhubEssentialMetadata("chtest")
+
+	_, err = s.api(c).AddCharm(s.charmhubAddCharmArgs("chtest"))
+	c.Assert(err, gc.ErrorMatches, `charm "chtest" is not signed, and the model only allows signed charms and resources`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *charmsMockSuite) TestAddCharmCharmhubSigned(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	signer, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.Sign(signer, corecharm.CharmSignatureNamespace, strings.NewReader("archive"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectModelConfig(c, coretesting.Attrs{
+		"charm-signing-keys":   keys,
+		"signed-charms-only":   true,
+		"charm-signatures-url": s.signatureMirror(c, sig),
+	})
+	s.expectCharmhubEssentialMetadata("chtest")
+	s.state.EXPECT().AddCharmMetadata(gomock.Any()).Return(nil, nil)
+
+	_, err = s.api(c).AddCharm(s.charmhubAddCharmArgs("chtest"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmsMockSuite) TestAddCharmCharmhubUntrustedSigner(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	signer, _, err := pkitest.NewSSHSigner("mallory@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.Sign(signer, corecharm.CharmSignatureNamespace, strings.NewReader("archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectModelConfig(c, coretesting.Attrs{
+		"charm-signing-keys":   keys,
+		"signed-charms-only":   true,
+		"charm-signatures-url": s.signatureMirror(c, sig),
+	})
+	s.expectCharmhubEssentialMetadata("chtest")
+
+	_, err = s.api(c).AddCharm(s.charmhubAddCharmArgs("chtest"))
+	c.Assert(err, gc.ErrorMatches, `cannot verify signature of charm "chtest": signing key SHA256:.* not trusted`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
 func (s *charmsMockSuite) TestQueueAsyncCharmDownloadResolvesAgainOriginForAlreadyDownloadedCharm(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -651,6 +718,53 @@ func (s *charmsMockSuite) setupMocks(c *gc.C) *gomock.Controller {
 	return ctrl
 }
 
+func (s *charmsMockSuite) expectModelConfig(c *gc.C, attrs coretesting.Attrs) {
+	cfg := coretesting.CustomModelConfig(c, attrs)
+	s.model.EXPECT().Config().Return(cfg, nil)
+}
+
+func (s *charmsMockSuite) expectCharmhubEssentialMetadata(curl string) {
+	s.state.EXPECT().Charm(curl).Return(nil, errors.NotFoundf("%q", curl))
+	s.repoFactory.EXPECT().GetCharmRepository(gomock.Any()).Return(s.repository, nil)
+	s.repository.EXPECT().GetEssentialMetadata(gomock.Any()).Return([]corecharm.EssentialMetadata{{
+		Meta:     new(charm.Meta),
+		Manifest: new(charm.Manifest),
+		Config:   new(charm.Config),
+		ResolvedOrigin: corecharm.Origin{
+			Source:   "charm-hub",
+			Channel:  &charm.Channel{Risk: "stable"},
+			Platform: corecharm.Platform{OS: "ubuntu", Channel: "20.04"},
+			Hash:     testCharmHash,
+		},
+	}}, nil)
+}
+
+// testCharmHash is the SHA256 hash of the charms resolved by
+// expectCharmhubEssentialMetadata.
+const testCharmHash = "4e97ed7423be2ea12939e8fdd592cfb3dcd4d0097d7d193ef998ab6b4db70461"
+
+// signatureMirror returns the URL of a signature mirror serving the
+// signature of the charms resolved by expectCharmhubEssentialMetadata.
+func (s *charmsMockSuite) signatureMirror(c *gc.C, sig []byte) string {
+	dir := c.MkDir()
+	err := os.Mkdir(filepath.Join(dir, "sha256"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(dir, "sha256", testCharmHash+".sig"), sig, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return "file://" + filepath.ToSlash(dir)
+}
+
+func (s *charmsMockSuite) charmhubAddCharmArgs(curl string) params.AddCharmWithOrigin {
+	return params.AddCharmWithOrigin{
+		URL: curl,
+		Origin: params.CharmOrigin{
+			Source: "charm-hub",
+			Base:   params.Base{Name: "ubuntu", Channel: "20.04/stable"},
+			Risk:   "stable",
+		},
+	}
+}
+
 func (s *charmsMockSuite) expectResolveWithPreferredChannel(times int, err error) {
 	s.repoFactory.EXPECT().GetCharmRepository(gomock.Any()).Return(s.repository, nil).Times(times)
 	s.repository.EXPECT().ResolveWithPreferredChannel(
@@ -67,6 +67,8 @@ func newFacadeBase(ctx facade.Context) (*API, error) {
 		return nil, errors.Trace(err)
 	}
 
+	charmCache := ctx.CharmCache()
+
 	commonState := &charmscommon.StateShim{st}
 	charmInfoAPI, err := charmscommon.NewCharmInfoAPI(commonState, authorizer)
 	if err != nil {
@@ -86,6 +88,7 @@ func newFacadeBase(ctx facade.Context) (*API, error) {
 			return services.NewCharmRepoFactory(cfg)
 		},
 		newDownloader: func(cfg services.CharmDownloaderConfig) (charmsinterfaces.Downloader, error) {
+			cfg.CharmCache = charmCache
 			return services.NewCharmDownloader(cfg)
 		},
 		tag:             m.ModelTag(),
@@ -27,6 +27,10 @@ type CharmDownloaderConfig struct {
 
 	StateBackend StateBackend
 	ModelBackend ModelBackend
+
+	// An optional cache of downloaded charms, shared by all models on
+	// the controller.
+	CharmCache charmdownloader.CharmCache
 }
 
 // NewCharmDownloader wires the provided configuration options into a new
@@ -47,7 +51,30 @@ func NewCharmDownloader(cfg CharmDownloaderConfig) (*charmdownloader.Downloader,
 		}),
 	}
 
-	return charmdownloader.NewDownloader(cfg.Logger.ChildWithLabels("charmdownloader", corelogger.CHARMHUB), storage, repoFactory), nil
+	signingPolicy, err := NewSigningPolicy(cfg.ModelBackend, cfg.CharmhubHTTPClient, cfg.Logger.Child("signatures"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return charmdownloader.NewDownloader(cfg.Logger.ChildWithLabels("charmdownloader", corelogger.CHARMHUB), storage, repoFactory, cfg.CharmCache, signingPolicy), nil
+}
+
+// NewSigningPolicy returns the policy for checking the signatures of
+// charms and resources configured for the model. Signatures are fetched
+// from the model's signature mirror, if it has one, using httpClient.
+func NewSigningPolicy(modelBackend ModelBackend, httpClient charmhub.HTTPClient, logger loggo.Logger) (corecharm.SigningPolicy, error) {
+	cfg, err := modelBackend.Config()
+	if err != nil {
+		return corecharm.SigningPolicy{}, errors.Trace(err)
+	}
+	var source corecharm.SignatureSource
+	if mirrorURL := cfg.CharmSignaturesURL(); mirrorURL != "" {
+		if source, err = charmhub.NewSignatureMirror(mirrorURL, httpClient, logger); err != nil {
+			return corecharm.SigningPolicy{}, errors.Annotate(err, "invalid charm signing config")
+		}
+	}
+	policy, err := corecharm.NewSigningPolicy(cfg.CharmSigningKeys(), cfg.SignedCharmsOnly(), source)
+	return policy, errors.Annotate(err, "invalid charm signing config")
 }
 
 // repoFactoryShim wraps a CharmRepoFactory and is compatible with the
@@ -1494,6 +1494,13 @@ func (context *statusContext) processApplication(application *state.Application)
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
 		processedStatus.Scale = application.GetScale()
+		if policy, err := application.AutoscalePolicy(); err == nil {
+			autoscaleStatus, _ := application.AutoscaleStatus()
+			processedStatus.Autoscale = &params.ApplicationAutoscaleStatus{
+				Policy: common.AutoscalePolicyToParams(policy),
+				Status: common.AutoscaleStatusToParams(autoscaleStatus),
+			}
+		}
 	}
 	processedStatus.EndpointBindings = context.allAppsUnitsCharmBindings.endpointBindings[application.Name()]
 	return processedStatus
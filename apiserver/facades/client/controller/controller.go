@@ -62,9 +62,15 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv11 provides the Controller API v11. It doesn't
+// support resuming or aborting failed migrations.
+type ControllerAPIv11 struct {
+	*ControllerAPI
+}
+
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = newControllerAPIv11
+var LatestAPI = newControllerAPIv12
 
 // TestingAPI is an escape hatch for requesting a controller API that won't
 // allow auth to correctly happen for ModelStatus. I'm not convicned this
@@ -616,41 +622,124 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, err := c.migrationModelState(spec.ModelTag)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	targetInfo, err := migrationTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Check if the migration is likely to succeed.
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := runMigrationPrechecks(
+		hostedState.State, systemState,
+		&targetInfo, c.presence,
+	); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// DryRunMigration checks whether one or more models could be migrated
+// to other controllers, reporting all of the problems found rather
+// than just the first. No migration is started and the models are
+// left untouched.
+func (c *ControllerAPI) DryRunMigration(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		report, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Report = &report
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) (params.MigrationReport, error) {
+	hostedState, err := c.migrationModelState(spec.ModelTag)
+	if err != nil {
+		return params.MigrationReport{}, errors.Trace(err)
+	}
 	defer hostedState.Release()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+	targetInfo, err := migrationTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return params.MigrationReport{}, errors.Trace(err)
+	}
+
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return params.MigrationReport{}, errors.Trace(err)
+	}
+	report, err := runMigrationDryRun(hostedState.State, systemState, &targetInfo, c.presence)
+	return report, errors.Trace(err)
+}
+
+// migrationModelState returns the state for the model to be migrated.
+// The caller is responsible for releasing it.
+func (c *ControllerAPI) migrationModelState(tag string) (*state.PooledState, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, errors.NotFoundf("model")
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	return hostedState, errors.Trace(err)
+}
+
+// migrationTargetInfo constructs the target info for a migration from
+// the details provided by the client.
+func migrationTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return coremigration.TargetInfo{}, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -658,29 +747,129 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		AuthTag:         authTag,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
+	}, nil
+}
+
+// ResumeMigration resumes the failed migrations of the given models,
+// retrying the phase which failed.
+func (c *ControllerAPI) ResumeMigration(args params.Entities) (params.ErrorResults, error) {
+	return c.forEachFailedMigration(args, func(mig state.ModelMigration) error {
+		return mig.Resume()
+	})
+}
+
+// AbortMigration aborts the failed migrations of the given models.
+func (c *ControllerAPI) AbortMigration(args params.Entities) (params.ErrorResults, error) {
+	return c.forEachFailedMigration(args, func(mig state.ModelMigration) error {
+		return mig.Abort()
+	})
+}
+
+// MigrationProgress reports the phase reached by the latest migration
+// of each of the given models. Models which have been migrated away
+// and removed from this controller report the DONE phase.
+func (c *ControllerAPI) MigrationProgress(args params.Entities) (params.MigrationProgressResults, error) {
+	results := params.MigrationProgressResults{
+		Results: make([]params.MigrationProgressResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return results, errors.Trace(err)
 	}
+	for i, arg := range args.Entities {
+		result := &results.Results[i]
+		result.ModelTag = arg.Tag
+		modelTag, err := names.ParseModelTag(arg.Tag)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if err := c.migrationProgress(modelTag, result); err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
 
-	// Check if the migration is likely to succeed.
+func (c *ControllerAPI) migrationProgress(modelTag names.ModelTag, result *params.MigrationProgressResult) error {
 	systemState, err := c.statePool.SystemState()
 	if err != nil {
-		return "", errors.Trace(err)
+		return errors.Trace(err)
 	}
-	if err := runMigrationPrechecks(
-		hostedState.State, systemState,
-		&targetInfo, c.presence,
-	); err != nil {
-		return "", errors.Trace(err)
+	// Once a migration completes the model is removed, so its
+	// migration can only be found through the controller model.
+	mig, err := systemState.CompletedMigrationForModel(modelTag.Id())
+	if errors.Is(err, errors.NotFound) {
+		err = c.withLatestMigration(modelTag, func(latest state.ModelMigration) error {
+			mig = latest
+			return nil
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
 	}
 
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	phase, err := mig.Phase()
 	if err != nil {
-		return "", errors.Trace(err)
+		return errors.Trace(err)
 	}
-	return mig.Id(), nil
+	checkpoint, err := mig.Checkpoint()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result.MigrationId = mig.Id()
+	result.Phase = phase.String()
+	result.Message = mig.StatusMessage()
+	if checkpoint.IsFailed() {
+		result.FailedPhase = checkpoint.FailedPhase.String()
+	}
+	return nil
+}
+
+// ResumeMigration isn't on the v11 API.
+func (c *ControllerAPIv11) ResumeMigration(_, _ struct{}) {}
+
+// AbortMigration isn't on the v11 API.
+func (c *ControllerAPIv11) AbortMigration(_, _ struct{}) {}
+
+// DryRunMigration isn't on the v11 API.
+func (c *ControllerAPIv11) DryRunMigration(_, _ struct{}) {}
+
+// MigrationProgress isn't on the v11 API.
+func (c *ControllerAPIv11) MigrationProgress(_, _ struct{}) {}
+
+func (c *ControllerAPI) forEachFailedMigration(
+	args params.Entities, f func(state.ModelMigration) error,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		modelTag, err := names.ParseModelTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = c.withLatestMigration(modelTag, f)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) withLatestMigration(modelTag names.ModelTag, f func(state.ModelMigration) error) error {
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	mig, err := hostedState.LatestMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f(mig))
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	coremigration "github.com/juju/juju/core/migration"
 	coremultiwatcher "github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/docker"
@@ -676,6 +677,228 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestDryRunMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := params.MigrationReport{
+		Blockers: []params.MigrationIssue{{Controller: "source", Message: "machine 0 is dying"}},
+		Cloud:    "dummy",
+		Charms:   []params.MigrationBinary{{Name: "ch:mysql-1", Size: 1024}},
+	}
+	targets := controller.SetDryRunResult(s, report, nil)
+
+	controllerTag := randomControllerTag()
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: controllerTag,
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Report, jc.DeepEquals, &report)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	c.Assert(*targets, gc.HasLen, 1)
+	c.Check((*targets)[0].ControllerTag.String(), gc.Equals, controllerTag)
+	c.Check((*targets)[0].Addrs, jc.DeepEquals, []string{"1.1.1.1:1111"})
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDryRunMigrationFailure(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, params.MigrationReport{}, errors.New("boom"))
+	out, err := s.controller.DryRunMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Report, gc.IsNil)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestDryRunMigrationRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: common.NewResources(),
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.DryRunMigration(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) makeFailedMigration(c *gc.C) *state.State {
+	st := s.Factory.MakeModel(c, nil)
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: s.Owner,
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1"),
+			Password:      "secret1",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.IMPORT), jc.ErrorIsNil)
+	err = mig.SetCheckpoint(coremigration.Checkpoint{
+		FailedPhase: coremigration.IMPORT,
+		Imported:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return st
+}
+
+func (s *controllerSuite) TestResumeMigration(c *gc.C) {
+	st := s.makeFailedMigration(c)
+	defer st.Close()
+
+	out, err := s.controller.ResumeMigration(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(st.ModelUUID()).String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.IMPORT)
+	checkpoint, err := mig.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checkpoint, jc.DeepEquals, coremigration.Checkpoint{Imported: true})
+}
+
+func (s *controllerSuite) TestAbortMigration(c *gc.C) {
+	st := s.makeFailedMigration(c)
+	defer st.Close()
+
+	out, err := s.controller.AbortMigration(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(st.ModelUUID()).String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.ABORT)
+}
+
+func (s *controllerSuite) TestResumeMigrationErrors(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	out, err := s.controller.ResumeMigration(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: names.NewModelTag(st.ModelUUID()).String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "migration not found")
+}
+
+func (s *controllerSuite) TestResumeMigrationRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: common.NewResources(),
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.ResumeMigration(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestMigrationProgress(c *gc.C) {
+	st := s.makeFailedMigration(c)
+	defer st.Close()
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("import failed"), jc.ErrorIsNil)
+
+	idle := s.Factory.MakeModel(c, nil)
+	defer idle.Close()
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewModelTag(st.ModelUUID()).String()},
+			{Tag: names.NewModelTag(idle.ModelUUID()).String()},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 3)
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationProgressResult{
+		ModelTag:    names.NewModelTag(st.ModelUUID()).String(),
+		MigrationId: mig.Id(),
+		Phase:       "IMPORT",
+		Message:     "import failed",
+		FailedPhase: "IMPORT",
+	})
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "migration not found")
+	c.Check(out.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestMigrationProgressRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: common.NewResources(),
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.MigrationProgress(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -0,0 +1,270 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/description/v5"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller/migrationtarget"
+	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/facade"
+	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+const (
+	sourceController = "source"
+	targetController = "target"
+)
+
+// runMigrationDryRun runs all of the source and target prechecks for
+// a migration, along with a simulated import of the model into the
+// target controller, and reports everything that was found. No
+// migration is created and the model isn't quiesced.
+var runMigrationDryRun = func(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence,
+) (params.MigrationReport, error) {
+	var report params.MigrationReport
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return report, errors.Annotate(err, "creating backend")
+	}
+	issues, err := migration.SourcePrecheckIssues(
+		backend,
+		presence.ModelPresence(st.ModelUUID()),
+		presence.ModelPresence(ctlrSt.ModelUUID()),
+		cloudspec.MakeCloudSpecGetterForModel(st),
+	)
+	if err != nil {
+		return report, errors.Annotate(err, "running source prechecks")
+	}
+	report.Blockers = append(report.Blockers, migrationIssues(sourceController, issues)...)
+
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+
+	// Leadership isn't needed to check whether the model can be
+	// imported, so there's no need to ask for it.
+	model, err := st.Export(map[string]string{})
+	if err != nil {
+		return report, errors.Annotate(err, "exporting model")
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return report, errors.Annotate(err, "serializing model")
+	}
+	describeModel(&report, model)
+	m, err := st.Model()
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	schedules, err := m.AllActionSchedules()
+	if err != nil {
+		return report, errors.Annotate(err, "retrieving action schedules")
+	}
+	describeActionSchedules(&report, schedules)
+	if report.Charms, err = charmSizes(st, model); err != nil {
+		return report, errors.Trace(err)
+	}
+	if model.Type() == string(coremodel.IAAS) {
+		report.Tools = toolsSizes(model)
+	}
+	report.Resources = resourceSizes(model)
+
+	blockers, warnings := dryRunTarget(targetInfo, modelInfo, srcUserList, bytes)
+	report.Blockers = append(report.Blockers, migrationIssues(targetController, blockers)...)
+	report.Warnings = append(report.Warnings, migrationIssues(targetController, warnings)...)
+	return report, nil
+}
+
+// dryRunTarget asks the target controller whether it could accept the
+// model, returning the blockers and warnings it reports. Failing to
+// talk to the target controller is itself a blocker.
+func dryRunTarget(
+	targetInfo *coremigration.TargetInfo, modelInfo coremigration.ModelInfo, srcUserList userList, bytes []byte,
+) (blockers, warnings []string) {
+	targetConn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return []string{fmt.Sprintf("cannot connect to target controller: %v", err)}, nil
+	}
+	defer targetConn.Close()
+
+	dstUserList, err := getTargetControllerUsers(targetConn)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot retrieve target controller users: %v", err)}, nil
+	}
+	if err := srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		blockers = append(blockers, err.Error())
+	}
+
+	client := migrationtarget.NewClient(targetConn)
+	if targetInfo.CACert == "" {
+		if _, err := client.CACert(); params.IsCodeNotImplemented(err) {
+			return append(blockers, "controller API version is too old"), warnings
+		} else if err != nil {
+			return append(blockers, fmt.Sprintf("cannot retrieve CA certificate: %v", err)), warnings
+		}
+	}
+
+	if client.BestFacadeVersion() < 4 {
+		warnings = append(warnings, "target controller does not support a simulated import, only its prechecks were run")
+		if err := client.Prechecks(modelInfo); err != nil {
+			blockers = append(blockers, err.Error())
+		}
+		return blockers, warnings
+	}
+	targetReport, err := client.DryRunImport(modelInfo, bytes)
+	if err != nil {
+		return append(blockers, fmt.Sprintf("simulated import failed: %v", err)), warnings
+	}
+	return append(blockers, targetReport.Blockers...), append(warnings, targetReport.Warnings...)
+}
+
+// describeModel records the cloud details the target controller will
+// need for the model, and warns about cross model relations.
+func describeModel(report *params.MigrationReport, model description.Model) {
+	report.Cloud = model.Cloud()
+	report.CloudRegion = model.CloudRegion()
+	if creds := model.CloudCredential(); creds != nil {
+		report.CloudCredential = fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	}
+
+	var warnings []string
+	for _, app := range model.Applications() {
+		for _, offer := range app.Offers() {
+			warnings = append(warnings, fmt.Sprintf(
+				"offer %q will be moved to the target controller, consuming models will be redirected to it", offer.OfferName()))
+		}
+	}
+	for _, remoteApp := range model.RemoteApplications() {
+		if remoteApp.IsConsumerProxy() {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"remote application %q must be reachable from the target controller", remoteApp.Name()))
+	}
+	report.Warnings = append(report.Warnings, migrationIssues(sourceController, warnings)...)
+}
+
+// describeActionSchedules warns that the model's action schedules,
+// which aren't in the model description, won't be migrated.
+func describeActionSchedules(report *params.MigrationReport, schedules []state.ActionSchedule) {
+	var warnings []string
+	for _, schedule := range schedules {
+		warnings = append(warnings, fmt.Sprintf(
+			"action schedule %q will not be migrated, add it again once the migration has completed", schedule.Name))
+	}
+	report.Warnings = append(report.Warnings, migrationIssues(sourceController, warnings)...)
+}
+
+// charmSizes returns the sizes of the charms which would be uploaded
+// to the target controller.
+func charmSizes(st *state.State, model description.Model) ([]params.MigrationBinary, error) {
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	seen := make(map[string]bool)
+	var out []params.MigrationBinary
+	for _, app := range model.Applications() {
+		curl := app.CharmURL()
+		if seen[curl] {
+			continue
+		}
+		seen[curl] = true
+
+		ch, err := st.Charm(curl)
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving charm %q", curl)
+		}
+		var size int64
+		if path := ch.StoragePath(); path != "" {
+			r, length, err := stor.Get(path)
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving charm %q archive", curl)
+			}
+			_ = r.Close()
+			size = length
+		}
+		out = append(out, params.MigrationBinary{Name: curl, Size: size})
+	}
+	sortBinaries(out)
+	return out, nil
+}
+
+// toolsSizes returns the sizes of the agent binaries which would be
+// uploaded to the target controller.
+func toolsSizes(model description.Model) []params.MigrationBinary {
+	sizes := make(map[string]int64)
+	var addMachine func(description.Machine)
+	addMachine = func(machine description.Machine) {
+		if tools := machine.Tools(); tools != nil {
+			sizes[tools.Version().String()] = tools.Size()
+		}
+		for _, container := range machine.Containers() {
+			addMachine(container)
+		}
+	}
+	for _, machine := range model.Machines() {
+		addMachine(machine)
+	}
+	for _, app := range model.Applications() {
+		for _, unit := range app.Units() {
+			if tools := unit.Tools(); tools != nil {
+				sizes[tools.Version().String()] = tools.Size()
+			}
+		}
+	}
+
+	out := make([]params.MigrationBinary, 0, len(sizes))
+	for name, size := range sizes {
+		out = append(out, params.MigrationBinary{Name: name, Size: size})
+	}
+	sortBinaries(out)
+	return out
+}
+
+// resourceSizes returns the sizes of the resources which would be
+// uploaded to the target controller.
+func resourceSizes(model description.Model) []params.MigrationBinary {
+	var out []params.MigrationBinary
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			var size int64
+			if rev := res.ApplicationRevision(); rev != nil {
+				size = rev.Size()
+			}
+			out = append(out, params.MigrationBinary{
+				Name: app.Name() + "/" + res.Name(),
+				Size: size,
+			})
+		}
+	}
+	sortBinaries(out)
+	return out
+}
+
+func sortBinaries(binaries []params.MigrationBinary) {
+	sort.Slice(binaries, func(i, j int) bool {
+		return binaries[i].Name < binaries[j].Name
+	})
+}
+
+func migrationIssues(controller string, messages []string) []params.MigrationIssue {
+	var out []params.MigrationIssue
+	for _, message := range messages {
+		out = append(out, params.MigrationIssue{Controller: controller, Message: message})
+	}
+	return out
+}
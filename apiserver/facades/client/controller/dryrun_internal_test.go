@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/description/v5"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+var _ = gc.Suite(&dryRunSuite{})
+
+type dryRunSuite struct{}
+
+func (s *dryRunSuite) makeModel() description.Model {
+	owner := names.NewUserTag("fred")
+	model := description.NewModel(description.ModelArgs{
+		Type:        "iaas",
+		Owner:       owner,
+		Config:      map[string]interface{}{"name": "some-model"},
+		Cloud:       "aws",
+		CloudRegion: "us-east-1",
+	})
+	model.SetCloudCredential(description.CloudCredentialArgs{
+		Owner: owner,
+		Cloud: names.NewCloudTag("aws"),
+		Name:  "default",
+	})
+
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("3.1.0-ubuntu-amd64"),
+		Size:    100,
+	})
+	container := machine.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+	container.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("3.1.0-ubuntu-arm64"),
+		Size:    200,
+	})
+
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		CharmURL: "ch:mysql-1",
+	})
+	unit := app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("mysql/0")})
+	unit.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("3.1.0-ubuntu-amd64"),
+		Size:    100,
+	})
+	res := app.AddResource(description.ResourceArgs{Name: "snap"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{Revision: 3, Size: 300})
+	app.AddResource(description.ResourceArgs{Name: "config"})
+	app.AddOffer(description.ApplicationOfferArgs{OfferName: "db"})
+
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag: names.NewApplicationTag("wordpress"),
+	})
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:             names.NewApplicationTag("remote-consumer"),
+		IsConsumerProxy: true,
+	})
+	return model
+}
+
+func (s *dryRunSuite) TestDescribeModel(c *gc.C) {
+	var report params.MigrationReport
+	describeModel(&report, s.makeModel())
+	c.Assert(report, jc.DeepEquals, params.MigrationReport{
+		Cloud:           "aws",
+		CloudRegion:     "us-east-1",
+		CloudCredential: "aws/fred/default",
+		Warnings: []params.MigrationIssue{{
+			Controller: "source",
+			Message:    `offer "db" will be moved to the target controller, consuming models will be redirected to it`,
+		}, {
+			Controller: "source",
+			Message:    `remote application "wordpress" must be reachable from the target controller`,
+		}},
+	})
+}
+
+func (s *dryRunSuite) TestDescribeActionSchedules(c *gc.C) {
+	var report params.MigrationReport
+	describeActionSchedules(&report, nil)
+	c.Assert(report.Warnings, gc.HasLen, 0)
+
+	describeActionSchedules(&report, []state.ActionSchedule{{Name: "hourly"}, {Name: "nightly"}})
+	c.Assert(report.Warnings, jc.DeepEquals, []params.MigrationIssue{{
+		Controller: "source",
+		Message:    `action schedule "hourly" will not be migrated, add it again once the migration has completed`,
+	}, {
+		Controller: "source",
+		Message:    `action schedule "nightly" will not be migrated, add it again once the migration has completed`,
+	}})
+}
+
+func (s *dryRunSuite) TestToolsSizes(c *gc.C) {
+	c.Assert(toolsSizes(s.makeModel()), jc.DeepEquals, []params.MigrationBinary{
+		{Name: "3.1.0-ubuntu-amd64", Size: 100},
+		{Name: "3.1.0-ubuntu-arm64", Size: 200},
+	})
+}
+
+func (s *dryRunSuite) TestResourceSizes(c *gc.C) {
+	c.Assert(resourceSizes(s.makeModel()), jc.DeepEquals, []params.MigrationBinary{
+		{Name: "mysql/config", Size: 0},
+		{Name: "mysql/snap", Size: 300},
+	})
+}
+
+func (s *dryRunSuite) TestMigrationIssues(c *gc.C) {
+	c.Assert(migrationIssues(targetController, nil), gc.HasLen, 0)
+	c.Assert(migrationIssues(targetController, []string{"a", "b"}), jc.DeepEquals, []params.MigrationIssue{
+		{Controller: "target", Message: "a"},
+		{Controller: "target", Message: "b"},
+	})
+}
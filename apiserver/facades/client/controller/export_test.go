@@ -6,6 +6,7 @@ package controller
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
 
@@ -19,6 +20,15 @@ func SetPrecheckResult(p patcher, err error) {
 	})
 }
 
+func SetDryRunResult(p patcher, report params.MigrationReport, err error) *[]migration.TargetInfo {
+	var targets []migration.TargetInfo
+	p.PatchValue(&runMigrationDryRun, func(_, _ *state.State, targetInfo *migration.TargetInfo, _ facade.Presence) (params.MigrationReport, error) {
+		targets = append(targets, *targetInfo)
+		return report, err
+	})
+	return &targets
+}
+
 func NewControllerAPIForTest(backend Backend) *ControllerAPI {
 	return &ControllerAPI{state: backend}
 }
@@ -13,11 +13,23 @@ import (
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Controller", 11, func(ctx facade.Context) (facade.Facade, error) {
 		return newControllerAPIv11(ctx)
+	}, reflect.TypeOf((*ControllerAPIv11)(nil)))
+	registry.MustRegister("Controller", 12, func(ctx facade.Context) (facade.Facade, error) {
+		return newControllerAPIv12(ctx)
 	}, reflect.TypeOf((*ControllerAPI)(nil)))
 }
 
 // newControllerAPIv11 creates a new ControllerAPIv11
-func newControllerAPIv11(ctx facade.Context) (*ControllerAPI, error) {
+func newControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	api, err := newControllerAPIv12(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerAPIv11{api}, nil
+}
+
+// newControllerAPIv12 creates a new ControllerAPI
+func newControllerAPIv12(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -0,0 +1,148 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider/juju"
+	"github.com/juju/juju/secrets/provider/kubernetes"
+	"github.com/juju/juju/state"
+)
+
+// MigrateSecrets starts moving the revisions of a model's secrets from
+// the backends holding them to the named backend. The revisions are
+// moved by a worker on the controller; see SecretsMigrationStatus for
+// its progress. Only one migration of a model's secrets can be in
+// progress at a time.
+// For a dry run, nothing is moved and the result holds the revisions
+// which would be moved, with the checksum of their current content.
+func (s *SecretBackendsAPI) MigrateSecrets(arg params.MigrateSecretsArgs) (params.MigrateSecretsResult, error) {
+	var result params.MigrateSecretsResult
+	if err := s.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if arg.BackendName == "" {
+		return result, errors.NotValidf("missing backend name")
+	}
+	model, release, err := s.statePool.ModelSecrets(arg.ModelUUID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer release()
+
+	targetID, err := s.migrationBackendID(model, arg.BackendName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cfgInfo, err := model.AdminBackendConfigInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	m, err := commonsecrets.NewSecretsMigration(model, s.controllerUUID, cfgInfo, targetID)
+	if errors.Is(err, errors.NotFound) {
+		return result, errors.NotFoundf("secret backend %q for model %q", arg.BackendName, model.Name())
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	revisions, err := m.Revisions(arg.URIs, arg.Applications)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Total = len(revisions)
+	if !arg.DryRun {
+		if result.Total == 0 {
+			return result, nil
+		}
+		err = model.StartSecretsMigration(state.SecretsMigrationParams{
+			BackendID:    targetID,
+			BackendName:  arg.BackendName,
+			URIs:         arg.URIs,
+			Applications: arg.Applications,
+			Total:        result.Total,
+		})
+		return result, errors.Trace(err)
+	}
+
+	result.Revisions = make([]params.MigrateSecretRevisionResult, len(revisions))
+	for i, rev := range revisions {
+		r := &result.Revisions[i]
+		r.URI = rev.URI.String()
+		r.Revision = rev.Revision
+		r.FromBackendID = m.BackendID(rev.ValueRef)
+		r.ToBackendID = targetID
+		checksum, err := m.Checksum(rev.URI, rev.Revision)
+		r.Checksum = checksum
+		r.Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// SecretsMigrationStatus returns the progress of the current or most
+// recent migration of the secrets of each model.
+func (s *SecretBackendsAPI) SecretsMigrationStatus(args params.Entities) (params.SecretsMigrationResults, error) {
+	result := params.SecretsMigrationResults{
+		Results: make([]params.SecretsMigrationResult, len(args.Entities)),
+	}
+	if err := s.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		r, err := s.secretsMigrationStatus(arg.Tag)
+		if err != nil {
+			r.Error = apiservererrors.ServerError(err)
+		}
+		result.Results[i] = r
+	}
+	return result, nil
+}
+
+func (s *SecretBackendsAPI) secretsMigrationStatus(tag string) (params.SecretsMigrationResult, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	model, release, err := s.statePool.ModelSecrets(modelTag.Id())
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	defer release()
+
+	m, err := model.SecretsMigration()
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	return commonsecrets.SecretsMigrationResult(m), nil
+}
+
+// migrationBackendID returns the ID of the named backend.
+func (s *SecretBackendsAPI) migrationBackendID(model ModelSecrets, name string) (string, error) {
+	switch {
+	case name == juju.BackendName:
+		return s.controllerUUID, nil
+	case name == kubernetes.BuiltInName(model.Name()):
+		// The built-in backend of a k8s model has the model UUID as
+		// its ID, which is looked up in the model's backend config.
+		cfgInfo, err := model.AdminBackendConfigInfo()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		for id, cfg := range cfgInfo.Configs {
+			if cfg.BackendType == kubernetes.BackendType && cfg.ModelUUID == id {
+				return id, nil
+			}
+		}
+		return "", errors.NotFoundf("secret backend %q", name)
+	}
+	b, err := s.backendState.GetSecretBackend(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return b.ID, nil
+}
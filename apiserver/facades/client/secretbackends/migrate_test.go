@@ -0,0 +1,290 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/secretbackends"
+	"github.com/juju/juju/apiserver/facades/client/secretbackends/mocks"
+	"github.com/juju/juju/core/permission"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MigrateSuite struct {
+	testing.IsolationSuite
+
+	authorizer   *facademocks.MockAuthorizer
+	backendState *mocks.MockSecretsBackendState
+	secretsState *mocks.MockSecretsState
+	statePool    *mocks.MockStatePool
+	model        *mocks.MockModelSecrets
+	provider     *mocks.MockSecretBackendProvider
+	oldBackend   *mocks.MockSecretsBackend
+	newBackend   *mocks.MockSecretsBackend
+
+	facade *secretbackends.SecretBackendsAPI
+	uri1   *coresecrets.URI
+	uri2   *coresecrets.URI
+}
+
+var _ = gc.Suite(&MigrateSuite{})
+
+const (
+	oldBackendID = "old-id"
+	newBackendID = "new-id"
+)
+
+var controllerBackendID = coretesting.ControllerTag.Id()
+
+func (s *MigrateSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.backendState = mocks.NewMockSecretsBackendState(ctrl)
+	s.secretsState = mocks.NewMockSecretsState(ctrl)
+	s.statePool = mocks.NewMockStatePool(ctrl)
+	s.model = mocks.NewMockModelSecrets(ctrl)
+	s.provider = mocks.NewMockSecretBackendProvider(ctrl)
+	s.oldBackend = mocks.NewMockSecretsBackend(ctrl)
+	s.newBackend = mocks.NewMockSecretsBackend(ctrl)
+
+	s.authorizer.EXPECT().AuthClient().Return(true)
+	var err error
+	s.facade, err = secretbackends.NewTestAPI(
+		s.backendState, s.secretsState, s.statePool, s.authorizer, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return s.provider, nil
+	})
+	s.provider.EXPECT().NewBackend(gomock.Any()).DoAndReturn(
+		func(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+			if cfg.BackendType == "new" {
+				return s.newBackend, nil
+			}
+			return s.oldBackend, nil
+		}).AnyTimes()
+
+	s.uri1 = coresecrets.NewURI()
+	s.uri2 = coresecrets.NewURI()
+	return ctrl
+}
+
+func (s *MigrateSuite) expectModel(backendName, backendID string) {
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+	s.statePool.EXPECT().ModelSecrets(coretesting.ModelTag.Id()).Return(s.model, func() bool { return true }, nil)
+	s.model.EXPECT().Name().Return("fred").AnyTimes()
+	if backendName != "internal" {
+		s.backendState.EXPECT().GetSecretBackend(backendName).Return(&coresecrets.SecretBackend{
+			ID:   backendID,
+			Name: backendName,
+		}, nil)
+	}
+	s.model.EXPECT().AdminBackendConfigInfo().Return(&provider.ModelBackendConfigInfo{
+		ActiveID: controllerBackendID,
+		Configs: map[string]provider.ModelBackendConfig{
+			controllerBackendID: {BackendConfig: provider.BackendConfig{BackendType: "controller"}},
+			oldBackendID:        {BackendConfig: provider.BackendConfig{BackendType: "old"}},
+			newBackendID:        {BackendConfig: provider.BackendConfig{BackendType: "new"}},
+		},
+	}, nil)
+}
+
+// expectSecrets sets up two secrets: the first, owned by mariadb, has
+// one revision in the database and one on the new backend; the second,
+// owned by a unit of mysql, has a revision on the old backend.
+func (s *MigrateSuite) expectSecrets() {
+	s.model.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{
+		{URI: s.uri1, OwnerTag: "application-mariadb"},
+		{URI: s.uri2, OwnerTag: "unit-mysql-0"},
+	}, nil)
+	s.model.EXPECT().ListSecretRevisions(s.uri1).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 1},
+		{Revision: 2, ValueRef: &coresecrets.ValueRef{BackendID: newBackendID, RevisionID: "rev-2"}},
+	}, nil).AnyTimes()
+	s.model.EXPECT().ListSecretRevisions(s.uri2).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 1, ValueRef: &coresecrets.ValueRef{BackendID: oldBackendID, RevisionID: "old-rev-1"}},
+	}, nil).AnyTimes()
+}
+
+var fooValue = coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+
+func (s *MigrateSuite) TestMigrateSecretsStartsMigration(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("new", newBackendID)
+	s.expectSecrets()
+
+	// The revisions are moved by the controller, so
+	// nothing is read or changed here.
+	s.model.EXPECT().StartSecretsMigration(state.SecretsMigrationParams{
+		BackendID:    newBackendID,
+		BackendName:  "new",
+		Applications: []string{"mariadb"},
+		Total:        1,
+	}).Return(nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:    coretesting.ModelTag.Id(),
+		BackendName:  "new",
+		Applications: []string{"mariadb"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{Total: 1})
+}
+
+func (s *MigrateSuite) TestMigrateSecretsToInternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("internal", controllerBackendID)
+	s.expectSecrets()
+
+	s.model.EXPECT().StartSecretsMigration(state.SecretsMigrationParams{
+		BackendID:    controllerBackendID,
+		BackendName:  "internal",
+		Applications: []string{"mysql"},
+		Total:        1,
+	}).Return(nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:    coretesting.ModelTag.Id(),
+		BackendName:  "internal",
+		Applications: []string{"mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 1)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsNothingToMove(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("new", newBackendID)
+	s.expectSecrets()
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:    coretesting.ModelTag.Id(),
+		BackendName:  "new",
+		Applications: []string{"postgresql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 0)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsInProgress(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("new", newBackendID)
+	s.expectSecrets()
+
+	s.model.EXPECT().StartSecretsMigration(gomock.Any()).Return(
+		errors.AlreadyExistsf(`secrets migration to backend "internal"`))
+
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		BackendName: "new",
+	})
+	c.Assert(err, jc.ErrorIs, errors.AlreadyExists)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsDryRun(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("new", newBackendID)
+	s.expectSecrets()
+
+	oldRef := &coresecrets.ValueRef{BackendID: oldBackendID, RevisionID: "old-rev-1"}
+	s.model.EXPECT().GetSecretValue(s.uri1, 1).Return(fooValue, nil, nil)
+	s.model.EXPECT().GetSecretValue(s.uri2, 1).Return(nil, oldRef, nil)
+	s.oldBackend.EXPECT().GetContent(gomock.Any(), "old-rev-1").Return(fooValue, nil)
+
+	result, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		BackendName: "new",
+		DryRun:      true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Revisions, gc.HasLen, 2)
+	c.Check(result.Total, gc.Equals, 2)
+	for _, rev := range result.Revisions {
+		c.Check(rev.Error, gc.IsNil)
+		c.Check(rev.ToBackendID, gc.Equals, newBackendID)
+	}
+	c.Check(result.Revisions[0].FromBackendID, gc.Equals, controllerBackendID)
+	c.Check(result.Revisions[1].FromBackendID, gc.Equals, oldBackendID)
+	// The same content has the same checksum, wherever it is held.
+	c.Check(result.Revisions[0].Checksum, gc.Equals, result.Revisions[1].Checksum)
+}
+
+func (s *MigrateSuite) TestSecretsMigrationStatus(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+	s.statePool.EXPECT().ModelSecrets(coretesting.ModelTag.Id()).Return(s.model, func() bool { return true }, nil)
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.model.EXPECT().SecretsMigration().Return(&state.SecretsMigration{
+		SecretsMigrationParams: state.SecretsMigrationParams{
+			BackendID:   newBackendID,
+			BackendName: "new",
+			Total:       3,
+		},
+		Status:   state.SecretsMigrationFailed,
+		Moved:    2,
+		Failures: []state.SecretsMigrationFailure{{URI: s.uri1.String(), Revision: 1, Message: "boom"}},
+		Started:  started,
+		Updated:  started,
+	}, nil)
+
+	result, err := s.facade.SecretsMigrationStatus(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0], jc.DeepEquals, params.SecretsMigrationResult{
+		BackendID:   newBackendID,
+		BackendName: "new",
+		Status:      "failed",
+		Total:       3,
+		Moved:       2,
+		Failures:    []params.SecretsMigrationFailure{{URI: s.uri1.String(), Revision: 1, Message: "boom"}},
+		Started:     started,
+		Updated:     started,
+	})
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid model tag`)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsUnknownSecret(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectModel("new", newBackendID)
+	s.expectSecrets()
+
+	uri := coresecrets.NewURI()
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		BackendName: "new",
+		URIs:        []string{uri.String()},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MigrateSuite) TestMigrateSecretsPermissionDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
+		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
+
+	_, err := s.facade.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		BackendName: "new",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
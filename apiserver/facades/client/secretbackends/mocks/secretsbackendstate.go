@@ -99,6 +99,21 @@ func (mr *MockSecretsBackendStateMockRecorder) GetSecretBackendByID(arg0 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretBackendByID", reflect.TypeOf((*MockSecretsBackendState)(nil).GetSecretBackendByID), arg0)
 }
 
+// ListSecretBackendModelKeys mocks base method.
+func (m *MockSecretsBackendState) ListSecretBackendModelKeys(arg0 string) (map[string]secrets.WrappedKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretBackendModelKeys", arg0)
+	ret0, _ := ret[0].(map[string]secrets.WrappedKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretBackendModelKeys indicates an expected call of ListSecretBackendModelKeys.
+func (mr *MockSecretsBackendStateMockRecorder) ListSecretBackendModelKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretBackendModelKeys", reflect.TypeOf((*MockSecretsBackendState)(nil).ListSecretBackendModelKeys), arg0)
+}
+
 // ListSecretBackends mocks base method.
 func (m *MockSecretsBackendState) ListSecretBackends() ([]*secrets.SecretBackend, error) {
 	m.ctrl.T.Helper()
@@ -127,3 +142,17 @@ func (mr *MockSecretsBackendStateMockRecorder) UpdateSecretBackend(arg0 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretBackend", reflect.TypeOf((*MockSecretsBackendState)(nil).UpdateSecretBackend), arg0)
 }
+
+// UpdateSecretBackendModelKey mocks base method.
+func (m *MockSecretsBackendState) UpdateSecretBackendModelKey(arg0, arg1 string, arg2 secrets.WrappedKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecretBackendModelKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecretBackendModelKey indicates an expected call of UpdateSecretBackendModelKey.
+func (mr *MockSecretsBackendStateMockRecorder) UpdateSecretBackendModelKey(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretBackendModelKey", reflect.TypeOf((*MockSecretsBackendState)(nil).UpdateSecretBackendModelKey), arg0, arg1, arg2)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/facades/client/secretbackends (interfaces: StatePool)
+// Source: github.com/juju/juju/apiserver/facades/client/secretbackends (interfaces: StatePool,ModelSecrets)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/state.go github.com/juju/juju/apiserver/facades/client/secretbackends StatePool
+//	mockgen -package mocks -destination mocks/state.go github.com/juju/juju/apiserver/facades/client/secretbackends StatePool,ModelSecrets
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,6 +13,10 @@ import (
 	reflect "reflect"
 
 	common "github.com/juju/juju/apiserver/common"
+	secretbackends "github.com/juju/juju/apiserver/facades/client/secretbackends"
+	secrets "github.com/juju/juju/core/secrets"
+	provider "github.com/juju/juju/secrets/provider"
+	state "github.com/juju/juju/state"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,3 +58,160 @@ func (mr *MockStatePoolMockRecorder) GetModel(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockStatePool)(nil).GetModel), arg0)
 }
+
+// ModelSecrets mocks base method.
+func (m *MockStatePool) ModelSecrets(arg0 string) (secretbackends.ModelSecrets, func() bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelSecrets", arg0)
+	ret0, _ := ret[0].(secretbackends.ModelSecrets)
+	ret1, _ := ret[1].(func() bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ModelSecrets indicates an expected call of ModelSecrets.
+func (mr *MockStatePoolMockRecorder) ModelSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelSecrets", reflect.TypeOf((*MockStatePool)(nil).ModelSecrets), arg0)
+}
+
+// MockModelSecrets is a mock of ModelSecrets interface.
+type MockModelSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelSecretsMockRecorder
+}
+
+// MockModelSecretsMockRecorder is the mock recorder for MockModelSecrets.
+type MockModelSecretsMockRecorder struct {
+	mock *MockModelSecrets
+}
+
+// NewMockModelSecrets creates a new mock instance.
+func NewMockModelSecrets(ctrl *gomock.Controller) *MockModelSecrets {
+	mock := &MockModelSecrets{ctrl: ctrl}
+	mock.recorder = &MockModelSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelSecrets) EXPECT() *MockModelSecretsMockRecorder {
+	return m.recorder
+}
+
+// AdminBackendConfigInfo mocks base method.
+func (m *MockModelSecrets) AdminBackendConfigInfo() (*provider.ModelBackendConfigInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminBackendConfigInfo")
+	ret0, _ := ret[0].(*provider.ModelBackendConfigInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminBackendConfigInfo indicates an expected call of AdminBackendConfigInfo.
+func (mr *MockModelSecretsMockRecorder) AdminBackendConfigInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminBackendConfigInfo", reflect.TypeOf((*MockModelSecrets)(nil).AdminBackendConfigInfo))
+}
+
+// ChangeSecretBackend mocks base method.
+func (m *MockModelSecrets) ChangeSecretBackend(arg0 state.ChangeSecretBackendParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSecretBackend", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSecretBackend indicates an expected call of ChangeSecretBackend.
+func (mr *MockModelSecretsMockRecorder) ChangeSecretBackend(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSecretBackend", reflect.TypeOf((*MockModelSecrets)(nil).ChangeSecretBackend), arg0)
+}
+
+// GetSecretValue mocks base method.
+func (m *MockModelSecrets) GetSecretValue(arg0 *secrets.URI, arg1 int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValue", arg0, arg1)
+	ret0, _ := ret[0].(secrets.SecretValue)
+	ret1, _ := ret[1].(*secrets.ValueRef)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue.
+func (mr *MockModelSecretsMockRecorder) GetSecretValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockModelSecrets)(nil).GetSecretValue), arg0, arg1)
+}
+
+// ListSecretRevisions mocks base method.
+func (m *MockModelSecrets) ListSecretRevisions(arg0 *secrets.URI) ([]*secrets.SecretRevisionMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisions", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretRevisionMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisions indicates an expected call of ListSecretRevisions.
+func (mr *MockModelSecretsMockRecorder) ListSecretRevisions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisions", reflect.TypeOf((*MockModelSecrets)(nil).ListSecretRevisions), arg0)
+}
+
+// ListSecrets mocks base method.
+func (m *MockModelSecrets) ListSecrets(arg0 state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockModelSecretsMockRecorder) ListSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockModelSecrets)(nil).ListSecrets), arg0)
+}
+
+// Name mocks base method.
+func (m *MockModelSecrets) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockModelSecretsMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockModelSecrets)(nil).Name))
+}
+
+// SecretsMigration mocks base method.
+func (m *MockModelSecrets) SecretsMigration() (*state.SecretsMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretsMigration")
+	ret0, _ := ret[0].(*state.SecretsMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretsMigration indicates an expected call of SecretsMigration.
+func (mr *MockModelSecretsMockRecorder) SecretsMigration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretsMigration", reflect.TypeOf((*MockModelSecrets)(nil).SecretsMigration))
+}
+
+// StartSecretsMigration mocks base method.
+func (m *MockModelSecrets) StartSecretsMigration(arg0 state.SecretsMigrationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSecretsMigration", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSecretsMigration indicates an expected call of StartSecretsMigration.
+func (mr *MockModelSecretsMockRecorder) StartSecretsMigration(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSecretsMigration", reflect.TypeOf((*MockModelSecrets)(nil).StartSecretsMigration), arg0)
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/juju/clock"
+	"github.com/juju/errors"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
@@ -16,10 +17,21 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("SecretBackends", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newSecretBackendsAPIV1(ctx)
+	}, reflect.TypeOf((*SecretBackendsAPIV1)(nil)))
+	registry.MustRegister("SecretBackends", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newSecretBackendsAPI(ctx)
 	}, reflect.TypeOf((*SecretBackendsAPI)(nil)))
 }
 
+func newSecretBackendsAPIV1(context facade.Context) (*SecretBackendsAPIV1, error) {
+	api, err := newSecretBackendsAPI(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretBackendsAPIV1{SecretBackendsAPI: api}, nil
+}
+
 // newSecretBackendsAPI creates a SecretBackendsAPI.
 func newSecretBackendsAPI(context facade.Context) (*SecretBackendsAPI, error) {
 	if !context.Auth().AuthClient() {
@@ -33,6 +33,15 @@ type SecretBackendsAPI struct {
 	statePool    StatePool
 }
 
+// SecretBackendsAPIV1 is the server implementation for version 1
+// of the SecretBackends facade.
+type SecretBackendsAPIV1 struct {
+	*SecretBackendsAPI
+}
+
+// MigrateSecrets isn't on the v1 API.
+func (*SecretBackendsAPIV1) MigrateSecrets(_ struct{}) {}
+
 func (s *SecretBackendsAPI) checkCanAdmin() error {
 	return s.authorizer.HasPermission(permission.SuperuserAccess, names.NewControllerTag(s.controllerUUID))
 }
@@ -166,6 +175,11 @@ func (s *SecretBackendsAPI) updateBackend(arg params.UpdateSecretBackendArg) err
 			return errors.Trace(err)
 		}
 	}
+	if keys, ok := p.(provider.SupportModelKeys); ok {
+		if err := s.rewrapModelKeys(keys, existing, cfg); err != nil {
+			return errors.Annotate(err, "re-wrapping model keys")
+		}
+	}
 	var nextRotateTime *time.Time
 	if arg.TokenRotateInterval != nil && *arg.TokenRotateInterval > 0 {
 		if !provider.HasAuthRefresh(p) {
@@ -189,6 +203,37 @@ func (s *SecretBackendsAPI) updateBackend(arg params.UpdateSecretBackendArg) err
 	return err
 }
 
+// rewrapModelKeys re-wraps the data key of each model using the
+// backend with the master key named in the new config. The keys are
+// re-wrapped before the config is updated, so that a master key which
+// is not installed is rejected.
+func (s *SecretBackendsAPI) rewrapModelKeys(keys provider.SupportModelKeys, backend *secrets.SecretBackend, cfg map[string]interface{}) error {
+	modelKeys, err := s.backendState.ListSecretBackendModelKeys(backend.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for modelUUID, key := range modelKeys {
+		rewrapped, err := keys.RewrapModelKey(&provider.ModelBackendConfig{
+			ControllerUUID: s.controllerUUID,
+			ModelUUID:      modelUUID,
+			BackendConfig: provider.BackendConfig{
+				BackendType: backend.BackendType,
+				Config:      cfg,
+			},
+		}, key)
+		if err != nil {
+			return errors.Annotatef(err, "model %q", modelUUID)
+		}
+		if rewrapped.MasterKeyID == key.MasterKeyID {
+			continue
+		}
+		if err := s.backendState.UpdateSecretBackendModelKey(backend.ID, modelUUID, *rewrapped); err != nil {
+			return errors.Annotatef(err, "model %q", modelUUID)
+		}
+	}
+	return nil
+}
+
 // ListSecretBackends lists available secret backends.
 func (s *SecretBackendsAPI) ListSecretBackends(arg params.ListSecretBackendsArgs) (params.ListSecretBackendsResults, error) {
 	result := params.ListSecretBackendsResults{}
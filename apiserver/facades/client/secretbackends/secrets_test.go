@@ -4,6 +4,9 @@
 package secretbackends_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/clock"
@@ -28,6 +31,7 @@ import (
 	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/encrypted"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -444,6 +448,63 @@ func (s *SecretsSuite) TestUpdateSecretBackends(c *gc.C) {
 	})
 }
 
+func (s *SecretsSuite) TestUpdateSecretBackendsRotateMasterKey(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	keyDir := c.MkDir()
+	err = os.MkdirAll(filepath.Join(keyDir, "master"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, id := range []string{"first", "second"} {
+		err = os.WriteFile(filepath.Join(keyDir, "master", id+".key"), bytes.Repeat([]byte{byte(i + 1)}, 32), 0600)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	oldConfig := map[string]interface{}{"key-dir": keyDir, "master-key-id": "first"}
+
+	p, err := provider.Provider(encrypted.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	modelKey, err := p.(provider.SupportModelKeys).NewModelKey(&provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		BackendConfig:  provider.BackendConfig{BackendType: encrypted.BackendType, Config: oldConfig},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backendState.EXPECT().GetSecretBackend("myencrypted").Return(&secrets.SecretBackend{
+		ID:          "backend-id",
+		BackendType: encrypted.BackendType,
+		Config:      oldConfig,
+	}, nil)
+	s.backendState.EXPECT().ListSecretBackendModelKeys("backend-id").Return(map[string]secrets.WrappedKey{
+		coretesting.ModelTag.Id(): *modelKey,
+	}, nil)
+	s.backendState.EXPECT().UpdateSecretBackendModelKey("backend-id", coretesting.ModelTag.Id(), gomock.Any()).
+		DoAndReturn(func(_, _ string, key secrets.WrappedKey) error {
+			c.Check(key.MasterKeyID, gc.Equals, "second")
+			c.Check(key.Key, gc.Not(jc.DeepEquals), modelKey.Key)
+			return nil
+		})
+	s.backendState.EXPECT().UpdateSecretBackend(gomock.Any()).DoAndReturn(func(arg state.UpdateSecretBackendParams) error {
+		c.Check(arg.Config["master-key-id"], gc.Equals, "second")
+		return nil
+	})
+
+	results, err := facade.UpdateSecretBackends(params.UpdateSecretBackendArgs{
+		Args: []params.UpdateSecretBackendArg{{
+			Name:   "myencrypted",
+			Config: map[string]interface{}{"master-key-id": "second"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
 func (s *SecretsSuite) TestUpdateSecretBackendsPermissionDenied(c *gc.C) {
 	defer s.setup(c).Finish()
 
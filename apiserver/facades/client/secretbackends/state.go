@@ -8,7 +8,9 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/common"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 )
 
@@ -20,6 +22,8 @@ type SecretsBackendState interface {
 	ListSecretBackends() ([]*secrets.SecretBackend, error)
 	GetSecretBackend(name string) (*secrets.SecretBackend, error)
 	GetSecretBackendByID(ID string) (*secrets.SecretBackend, error)
+	ListSecretBackendModelKeys(backendID string) (map[string]secrets.WrappedKey, error)
+	UpdateSecretBackendModelKey(backendID, modelUUID string, key secrets.WrappedKey) error
 }
 
 type SecretsState interface {
@@ -28,6 +32,17 @@ type SecretsState interface {
 
 type StatePool interface {
 	GetModel(modelUUID string) (common.Model, func() bool, error)
+	ModelSecrets(modelUUID string) (ModelSecrets, func() bool, error)
+}
+
+// ModelSecrets provides access to the secrets of a model,
+// and the backends holding their content.
+type ModelSecrets interface {
+	commonsecrets.SecretsMigrationState
+	Name() string
+	AdminBackendConfigInfo() (*provider.ModelBackendConfigInfo, error)
+	StartSecretsMigration(state.SecretsMigrationParams) error
+	SecretsMigration() (*state.SecretsMigration, error)
 }
 
 type statePoolShim struct {
@@ -41,3 +56,37 @@ func (s *statePoolShim) GetModel(modelUUID string) (common.Model, func() bool, e
 	}
 	return m, hp.Release, nil
 }
+
+func (s *statePoolShim) ModelSecrets(modelUUID string) (ModelSecrets, func() bool, error) {
+	m, hp, err := s.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return &modelSecretsShim{
+		SecretsStore: state.NewSecrets(m.State()),
+		st:           m.State(),
+		model:        commonsecrets.SecretsModel(m),
+	}, hp.Release, nil
+}
+
+type modelSecretsShim struct {
+	state.SecretsStore
+	st    *state.State
+	model commonsecrets.Model
+}
+
+func (m *modelSecretsShim) Name() string {
+	return m.model.Name()
+}
+
+func (m *modelSecretsShim) AdminBackendConfigInfo() (*provider.ModelBackendConfigInfo, error) {
+	return commonsecrets.AdminBackendConfigInfo(m.model)
+}
+
+func (m *modelSecretsShim) StartSecretsMigration(p state.SecretsMigrationParams) error {
+	return m.st.StartSecretsMigration(p)
+}
+
+func (m *modelSecretsShim) SecretsMigration() (*state.SecretsMigration, error) {
+	return m.st.SecretsMigration()
+}
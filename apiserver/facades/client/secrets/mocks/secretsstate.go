@@ -122,6 +122,21 @@ func (mr *MockSecretsStateMockRecorder) GetSecretValue(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretsState)(nil).GetSecretValue), arg0, arg1)
 }
 
+// ListSecretRevisionConsumers mocks base method.
+func (m *MockSecretsState) ListSecretRevisionConsumers(arg0 *secrets.URI) (map[int][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisionConsumers", arg0)
+	ret0, _ := ret[0].(map[int][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisionConsumers indicates an expected call of ListSecretRevisionConsumers.
+func (mr *MockSecretsStateMockRecorder) ListSecretRevisionConsumers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisionConsumers", reflect.TypeOf((*MockSecretsState)(nil).ListSecretRevisionConsumers), arg0)
+}
+
 // ListSecretRevisions mocks base method.
 func (m *MockSecretsState) ListSecretRevisions(arg0 *secrets.URI) ([]*secrets.SecretRevisionMetadata, error) {
 	m.ctrl.T.Helper()
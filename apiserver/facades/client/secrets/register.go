@@ -23,6 +23,10 @@ func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Secrets", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newSecretsAPI(ctx)
 	}, reflect.TypeOf((*SecretsAPI)(nil)))
+	// Version 3 adds revision authors and consumers to ListSecrets.
+	registry.MustRegister("Secrets", 3, func(ctx facade.Context) (facade.Facade, error) {
+		return newSecretsAPI(ctx)
+	}, reflect.TypeOf((*SecretsAPI)(nil)))
 }
 
 func newSecretsAPIV1(context facade.Context) (*SecretsAPIV1, error) {
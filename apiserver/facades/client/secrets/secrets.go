@@ -99,7 +99,15 @@ func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretR
 		return params.ListSecretResults{}, errors.Trace(err)
 	}
 	revisionMetadata := make(map[string][]*coresecrets.SecretRevisionMetadata)
+	revisionConsumers := make(map[string]map[int][]string)
 	for _, md := range metadata {
+		if arg.ShowConsumers {
+			consumers, err := s.secretsState.ListSecretRevisionConsumers(md.URI)
+			if err != nil {
+				return params.ListSecretResults{}, errors.Trace(err)
+			}
+			revisionConsumers[md.URI.ID] = consumers
+		}
 		if arg.Filter.Revision == nil {
 			revs, err := s.secretsState.ListSecretRevisions(md.URI)
 			if err != nil {
@@ -157,6 +165,9 @@ func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretR
 				UpdateTime:  r.UpdateTime,
 				ExpireTime:  r.ExpireTime,
 				BackendName: backendName,
+				Author:      r.Author,
+				Rotated:     r.Rotated,
+				Consumers:   revisionConsumers[m.URI.ID][r.Revision],
 			})
 		}
 		if arg.ShowSecrets {
@@ -215,7 +226,7 @@ func (s *SecretsAPI) secretContentFromBackend(uri *coresecrets.URI, rev int) (co
 		if !ok {
 			return nil, errors.NotFoundf("external secret backend %q, have %q", backendID, s.backends)
 		}
-		val, err = backend.GetContent(context.TODO(), ref.RevisionID)
+		val, err = provider.GetContent(context.TODO(), backend, ref.RevisionID, val)
 		if err == nil || !errors.Is(err, errors.NotFound) || lastBackendID == backendID {
 			return val, errors.Trace(err)
 		}
@@ -304,7 +315,7 @@ func (s *SecretsAPI) createSecret(backend provider.SecretsBackend, arg params.Cr
 	if len(arg.Content.Data) == 0 {
 		return "", errors.NotValidf("empty secret value")
 	}
-	revId, err := backend.SaveContent(context.TODO(), uri, 1, coresecrets.NewSecretValue(arg.Content.Data))
+	revId, sealed, err := provider.SaveContent(context.TODO(), backend, uri, 1, coresecrets.NewSecretValue(arg.Content.Data))
 	if err != nil && !errors.Is(err, errors.NotSupported) {
 		return "", errors.Trace(err)
 	}
@@ -320,7 +331,7 @@ func (s *SecretsAPI) createSecret(backend provider.SecretsBackend, arg params.Cr
 				}
 			}
 		}()
-		arg.Content.Data = nil
+		arg.Content.Data = sealedData(sealed)
 		arg.Content.ValueRef = &params.SecretValueRef{
 			BackendID:  s.activeBackendID,
 			RevisionID: revId,
@@ -330,7 +341,7 @@ func (s *SecretsAPI) createSecret(backend provider.SecretsBackend, arg params.Cr
 	md, err := s.secretsState.CreateSecret(uri, state.CreateSecretParams{
 		Version:            secrets.Version,
 		Owner:              secretOwner,
-		UpdateSecretParams: fromUpsertParams(nil, arg.UpsertSecretArg),
+		UpdateSecretParams: fromUpsertParams(nil, s.authTag, arg.UpsertSecretArg),
 	})
 	if err != nil {
 		return "", errors.Trace(err)
@@ -356,7 +367,16 @@ func (s *SecretsAPI) createSecret(backend provider.SecretsBackend, arg params.Cr
 	return md.URI.String(), nil
 }
 
-func fromUpsertParams(autoPrune *bool, p params.UpsertSecretArg) state.UpdateSecretParams {
+// sealedData returns the sealed content, if any, which a backend
+// needs Juju to store along with the reference to the content.
+func sealedData(sealed coresecrets.SecretValue) map[string]string {
+	if sealed == nil {
+		return nil
+	}
+	return sealed.EncodedValues()
+}
+
+func fromUpsertParams(autoPrune *bool, author names.Tag, p params.UpsertSecretArg) state.UpdateSecretParams {
 	var valueRef *coresecrets.ValueRef
 	if p.Content.ValueRef != nil {
 		valueRef = &coresecrets.ValueRef{
@@ -366,6 +386,7 @@ func fromUpsertParams(autoPrune *bool, p params.UpsertSecretArg) state.UpdateSec
 	}
 	return state.UpdateSecretParams{
 		AutoPrune:   autoPrune,
+		Author:      author,
 		LeaderToken: successfulToken{},
 		Description: p.Description,
 		Label:       p.Label,
@@ -423,7 +444,7 @@ func (s *SecretsAPI) updateSecret(backend provider.SecretsBackend, arg params.Up
 		return errors.Trace(err)
 	}
 	if len(arg.Content.Data) > 0 {
-		revId, err := backend.SaveContent(context.TODO(), uri, md.LatestRevision+1, coresecrets.NewSecretValue(arg.Content.Data))
+		revId, sealed, err := provider.SaveContent(context.TODO(), backend, uri, md.LatestRevision+1, coresecrets.NewSecretValue(arg.Content.Data))
 		if err != nil && !errors.Is(err, errors.NotSupported) {
 			return errors.Trace(err)
 		}
@@ -439,14 +460,14 @@ func (s *SecretsAPI) updateSecret(backend provider.SecretsBackend, arg params.Up
 					}
 				}
 			}()
-			arg.Content.Data = nil
+			arg.Content.Data = sealedData(sealed)
 			arg.Content.ValueRef = &params.SecretValueRef{
 				BackendID:  s.activeBackendID,
 				RevisionID: revId,
 			}
 		}
 	}
-	md, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.AutoPrune, arg.UpsertSecretArg))
+	md, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.AutoPrune, s.authTag, arg.UpsertSecretArg))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -245,6 +245,70 @@ func (s *SecretsSuite) assertListSecrets(c *gc.C, reveal, withBackend bool) {
 	})
 }
 
+func (s *SecretsSuite) TestListSecretsShowConsumers(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.ReadAccess, coretesting.ModelTag).Return(nil)
+
+	facade, err := apisecrets.NewTestAPI(s.authTag, s.authorizer, s.secretsState, s.secretConsumer, nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	uri := coresecrets.NewURI()
+	uriStr := uri.String()
+	s.secretsState.EXPECT().ListSecrets(state.SecretsFilter{URI: uri}).Return(
+		[]*coresecrets.SecretMetadata{{
+			URI:            uri,
+			Version:        1,
+			OwnerTag:       "application-mysql",
+			LatestRevision: 2,
+			CreateTime:     now,
+			UpdateTime:     now,
+		}}, nil,
+	)
+	s.secretsState.EXPECT().SecretGrants(uri, coresecrets.RoleView).Return(nil, nil)
+	s.secretsState.EXPECT().ListSecretRevisionConsumers(uri).Return(map[int][]string{
+		1: {"unit-gitlab-0"},
+		2: {"unit-gitlab-1", "unit-wordpress-0"},
+	}, nil)
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision:   1,
+		CreateTime: now,
+		UpdateTime: now,
+		Author:     "unit-mysql-0",
+	}, {
+		Revision:   2,
+		CreateTime: now,
+		UpdateTime: now,
+		Author:     "unit-mysql-1",
+		Rotated:    true,
+	}}, nil)
+
+	results, err := facade.ListSecrets(params.ListSecretsArgs{
+		Filter:        params.SecretsFilter{URI: &uriStr},
+		ShowConsumers: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Revisions, jc.DeepEquals, []params.SecretRevision{{
+		Revision:    1,
+		BackendName: ptr("internal"),
+		CreateTime:  now,
+		UpdateTime:  now,
+		Author:      "unit-mysql-0",
+		Consumers:   []string{"unit-gitlab-0"},
+	}, {
+		Revision:    2,
+		BackendName: ptr("internal"),
+		CreateTime:  now,
+		UpdateTime:  now,
+		Author:      "unit-mysql-1",
+		Rotated:     true,
+		Consumers:   []string{"unit-gitlab-1", "unit-wordpress-0"},
+	}})
+}
+
 func (s *SecretsSuite) TestListSecretsPermissionDenied(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -339,6 +403,7 @@ func (s *SecretsSuite) assertCreateSecrets(c *gc.C, isInternal bool, finalStepFa
 		c.Assert(params.Owner, gc.Equals, coretesting.ModelTag)
 		c.Assert(params.UpdateSecretParams.Description, gc.DeepEquals, ptr("this is a user secret."))
 		c.Assert(params.UpdateSecretParams.Label, gc.DeepEquals, ptr("label"))
+		c.Assert(params.UpdateSecretParams.Author, gc.Equals, s.authTag)
 		if isInternal {
 			c.Assert(params.UpdateSecretParams.ValueRef, gc.IsNil)
 			c.Assert(params.UpdateSecretParams.Data, gc.DeepEquals, coresecrets.SecretData(map[string]string{"foo": "bar"}))
@@ -446,6 +511,7 @@ func (s *SecretsSuite) assertUpdateSecrets(c *gc.C, uri *coresecrets.URI, isInte
 		c.Assert(params.Description, gc.DeepEquals, ptr("this is a user secret."))
 		c.Assert(params.Label, gc.DeepEquals, ptr("label"))
 		c.Assert(params.AutoPrune, gc.DeepEquals, ptr(true))
+		c.Assert(params.Author, gc.Equals, s.authTag)
 		if isInternal {
 			c.Assert(params.ValueRef, gc.IsNil)
 			c.Assert(params.Data, gc.DeepEquals, coresecrets.SecretData(map[string]string{"foo": "bar"}))
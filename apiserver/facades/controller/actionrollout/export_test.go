@@ -0,0 +1,16 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewStateFacade returns a Facade backed by the given state.
+func NewStateFacade(st *state.State, m *state.Model, resources facade.Resources, clock clock.Clock, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st: st, Model: m}, resources, clock, auth)
+}
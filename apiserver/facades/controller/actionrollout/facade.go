@@ -0,0 +1,171 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+	// ActiveRollouts returns the operations whose tasks are being
+	// enqueued in batches and which have not yet completed.
+	ActiveRollouts() ([]state.OperationInfo, error)
+
+	// StartRolloutBatch records that the given batch of an
+	// operation's rollout is being enqueued.
+	StartRolloutBatch(operationID string, batch int) error
+
+	// FinishRollout completes an operation whose rollout will
+	// enqueue no further tasks.
+	FinishRollout(operationID, reason string) error
+
+	// FailOperationEnqueuing records that some of an operation's
+	// tasks could not be enqueued.
+	FailOperationEnqueuing(operationID, failMessage string, count int) error
+
+	// ActionReceiver returns the receiver with the given tag.
+	ActionReceiver(tag string) (state.ActionReceiver, error)
+
+	// AddAction enqueues a task of an operation.
+	AddAction(
+		receiver state.ActionReceiver, operationID, name string, payload map[string]interface{},
+		parallel *bool, executionGroup *string,
+	) (state.Action, error)
+
+	// WatchRollouts notifies when an operation is enqueued or
+	// changes, including when one of its tasks completes.
+	WatchRollouts() state.NotifyWatcher
+}
+
+// Facade allows the action rollout worker to enqueue the batches of
+// rolling operations as the batches before them finish.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+	clock     clock.Clock
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, resources facade.Resources, clock clock.Clock, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{backend: backend, resources: resources, clock: clock}, nil
+}
+
+// WatchRollouts returns a watcher notifying when an operation of the
+// model is enqueued or changes, so that the batches of rollouts can be
+// advanced as their tasks complete.
+func (f *Facade) WatchRollouts() (params.NotifyWatchResult, error) {
+	w := f.backend.WatchRollouts()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{NotifyWatcherId: f.resources.Register(w)}, nil
+	}
+	return params.NotifyWatchResult{Error: apiservererrors.ServerError(watcher.EnsureErr(w))}, nil
+}
+
+// AdvanceRollouts moves on each active rollout of the model whose
+// current batch has finished: the next batch is enqueued once the
+// batch delay has passed, unless a task has failed and the rollout
+// stops on failure, in which case the operation is completed. Rollouts
+// waiting out their batch delay report how long is left, as there is
+// no change to an operation to notify when the delay has passed.
+func (f *Facade) AdvanceRollouts() (params.RolloutResults, error) {
+	infos, err := f.backend.ActiveRollouts()
+	if err != nil {
+		return params.RolloutResults{}, errors.Trace(err)
+	}
+	results := params.RolloutResults{
+		Results: make([]params.RolloutResult, 0, len(infos)),
+	}
+	for _, info := range infos {
+		result, err := f.advance(info)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		}
+		result.OperationTag = info.Operation.Tag().String()
+		results.Results = append(results.Results, result)
+	}
+	return results, nil
+}
+
+func (f *Facade) advance(info state.OperationInfo) (params.RolloutResult, error) {
+	var result params.RolloutResult
+	op := info.Operation
+	rollout := op.Rollout()
+	if rollout == nil {
+		return result, nil
+	}
+
+	var lastCompleted time.Time
+	var failed bool
+	for _, a := range info.Actions {
+		switch a.Status() {
+		case state.ActionPending, state.ActionRunning, state.ActionAborting:
+			// The current batch has not finished.
+			return result, nil
+		case state.ActionFailed, state.ActionError, state.ActionCancelled, state.ActionAborted:
+			failed = true
+		}
+		if a.Completed().After(lastCompleted) {
+			lastCompleted = a.Completed()
+		}
+	}
+
+	next := rollout.BatchesEnqueued
+	if next >= len(rollout.Batches) {
+		// Normally the operation completes with its last task;
+		// this catches operations whose last tasks could not
+		// be enqueued.
+		result.Finished = true
+		return result, errors.Trace(f.backend.FinishRollout(op.Id(), ""))
+	}
+	if rollout.StopOnFailure && (failed || op.Fail() != "") {
+		reason := fmt.Sprintf("rollout stopped after batch %d of %d failed", next, len(rollout.Batches))
+		result.Finished = true
+		return result, errors.Trace(f.backend.FinishRollout(op.Id(), reason))
+	}
+	if wait := lastCompleted.Add(rollout.BatchDelay).Sub(f.clock.Now()); wait > 0 {
+		result.NextBatchIn = wait
+		return result, nil
+	}
+
+	if err := f.backend.StartRolloutBatch(op.Id(), next); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.BatchEnqueued = next + 1
+	var failMessages []string
+	for _, task := range rollout.Batches[next] {
+		receiver, err := f.backend.ActionReceiver(task.Receiver)
+		if err == nil {
+			_, err = f.backend.AddAction(
+				receiver, op.Id(), task.Name, task.Parameters, task.Parallel, task.ExecutionGroup,
+			)
+		}
+		if err != nil {
+			failMessages = append(failMessages, err.Error())
+		}
+	}
+	if len(failMessages) == 0 {
+		return result, nil
+	}
+	failMessage := fmt.Sprintf("error(s) enqueueing action(s): %s", strings.Join(failMessages, ", "))
+	if op.Fail() != "" {
+		failMessage = op.Fail() + "; " + failMessage
+	}
+	count := op.SpawnedTaskCount() - len(failMessages)
+	return result, errors.Trace(f.backend.FailOperationEnqueuing(op.Id(), failMessage, count))
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollout"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollout/mocks"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+type facadeSuite struct {
+	backend   *mocks.MockBackend
+	operation *mocks.MockOperation
+	receiver  *mocks.MockActionReceiver
+	resources *common.Resources
+	clock     *testclock.Clock
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *facadeSuite) setup(c *gc.C) (*gomock.Controller, *actionrollout.Facade) {
+	ctrl := gomock.NewController(c)
+	s.backend = mocks.NewMockBackend(ctrl)
+	s.operation = mocks.NewMockOperation(ctrl)
+	s.receiver = mocks.NewMockActionReceiver(ctrl)
+	s.resources = common.NewResources()
+	s.clock = testclock.NewClock(now)
+	s.operation.EXPECT().Id().Return("1").AnyTimes()
+	s.operation.EXPECT().Tag().Return(names.NewOperationTag("1")).AnyTimes()
+
+	facade, err := actionrollout.NewFacade(s.backend, s.resources, s.clock, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return ctrl, facade
+}
+
+func (s *facadeSuite) action(ctrl *gomock.Controller, status state.ActionStatus, completed time.Time) state.Action {
+	a := mocks.NewMockAction(ctrl)
+	a.EXPECT().Status().Return(status).AnyTimes()
+	a.EXPECT().Completed().Return(completed).AnyTimes()
+	return a
+}
+
+func (s *facadeSuite) rollout(enqueued int, stopOnFailure bool) *state.OperationRollout {
+	task := func(unit string) state.RolloutTask {
+		return state.RolloutTask{
+			Receiver:   names.NewUnitTag(unit).String(),
+			Name:       "backup",
+			Parameters: map[string]interface{}{},
+		}
+	}
+	return &state.OperationRollout{
+		BatchSize:     2,
+		BatchDelay:    time.Minute,
+		StopOnFailure: stopOnFailure,
+		Batches: [][]state.RolloutTask{
+			{task("mysql/1"), task("mysql/2")},
+			{task("mysql/3"), task("mysql/0")},
+		},
+		BatchesEnqueued: enqueued,
+	}
+}
+
+func (s *facadeSuite) expectActiveRollout(actions ...state.Action) {
+	s.backend.EXPECT().ActiveRollouts().Return([]state.OperationInfo{{
+		Operation: s.operation,
+		Actions:   actions,
+	}}, nil)
+}
+
+func (s *facadeSuite) TestNewFacadeRequiresController(c *gc.C) {
+	_, err := actionrollout.NewFacade(nil, nil, nil, apiservertesting.FakeAuthorizer{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestWatchRollouts(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+	defer s.resources.StopAll()
+
+	s.backend.EXPECT().WatchRollouts().Return(apiservertesting.NewFakeNotifyWatcher())
+
+	result, err := facade.WatchRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *facadeSuite) TestBatchRunning(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now),
+		s.action(ctrl, state.ActionRunning, time.Time{}),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, false))
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RolloutResults{
+		Results: []params.RolloutResult{{OperationTag: "operation-1"}},
+	})
+}
+
+func (s *facadeSuite) TestBatchDelay(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-30*time.Second)),
+		s.action(ctrl, state.ActionCompleted, now.Add(-20*time.Second)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, false))
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag: "operation-1",
+		NextBatchIn:  40 * time.Second,
+	}})
+}
+
+func (s *facadeSuite) TestEnqueueNextBatch(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-2*time.Minute)),
+		s.action(ctrl, state.ActionFailed, now.Add(-time.Minute)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, false))
+	s.backend.EXPECT().StartRolloutBatch("1", 1).Return(nil)
+	s.backend.EXPECT().ActionReceiver("unit-mysql-3").Return(s.receiver, nil)
+	s.backend.EXPECT().ActionReceiver("unit-mysql-0").Return(s.receiver, nil)
+	s.backend.EXPECT().AddAction(s.receiver, "1", "backup", map[string]interface{}{}, nil, nil).Return(nil, nil).Times(2)
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag:  "operation-1",
+		BatchEnqueued: 2,
+	}})
+}
+
+func (s *facadeSuite) TestEnqueueNextBatchFailures(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-2*time.Minute)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, false))
+	s.operation.EXPECT().Fail().Return("error(s) enqueueing action(s): boom").AnyTimes()
+	s.operation.EXPECT().SpawnedTaskCount().Return(3)
+	s.backend.EXPECT().StartRolloutBatch("1", 1).Return(nil)
+	s.backend.EXPECT().ActionReceiver("unit-mysql-3").Return(nil, errors.NotFoundf("unit mysql/3"))
+	s.backend.EXPECT().ActionReceiver("unit-mysql-0").Return(s.receiver, nil)
+	s.backend.EXPECT().AddAction(s.receiver, "1", "backup", map[string]interface{}{}, nil, nil).Return(nil, nil)
+	s.backend.EXPECT().FailOperationEnqueuing("1",
+		"error(s) enqueueing action(s): boom; error(s) enqueueing action(s): unit mysql/3 not found", 2)
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag:  "operation-1",
+		BatchEnqueued: 2,
+	}})
+}
+
+func (s *facadeSuite) TestStopOnFailure(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-2*time.Minute)),
+		s.action(ctrl, state.ActionFailed, now.Add(-2*time.Minute)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, true))
+	s.backend.EXPECT().FinishRollout("1", "rollout stopped after batch 1 of 2 failed").Return(nil)
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag: "operation-1",
+		Finished:     true,
+	}})
+}
+
+func (s *facadeSuite) TestAllBatchesEnqueued(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-2*time.Minute)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(2, false))
+	s.backend.EXPECT().FinishRollout("1", "").Return(nil)
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag: "operation-1",
+		Finished:     true,
+	}})
+}
+
+func (s *facadeSuite) TestStartBatchError(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectActiveRollout(
+		s.action(ctrl, state.ActionCompleted, now.Add(-2*time.Minute)),
+	)
+	s.operation.EXPECT().Rollout().Return(s.rollout(1, false))
+	s.backend.EXPECT().StartRolloutBatch("1", 1).Return(errors.New("boom"))
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag: "operation-1",
+		Error:        &params.Error{Message: "boom"},
+	}})
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/apiserver/facades/controller/actionrollout (interfaces: Backend)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/actionrollout Backend
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	state "github.com/juju/juju/state"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackend is a mock of Backend interface.
+type MockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendMockRecorder
+}
+
+// MockBackendMockRecorder is the mock recorder for MockBackend.
+type MockBackendMockRecorder struct {
+	mock *MockBackend
+}
+
+// NewMockBackend creates a new mock instance.
+func NewMockBackend(ctrl *gomock.Controller) *MockBackend {
+	mock := &MockBackend{ctrl: ctrl}
+	mock.recorder = &MockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
+	return m.recorder
+}
+
+// ActionReceiver mocks base method.
+func (m *MockBackend) ActionReceiver(arg0 string) (state.ActionReceiver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionReceiver", arg0)
+	ret0, _ := ret[0].(state.ActionReceiver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionReceiver indicates an expected call of ActionReceiver.
+func (mr *MockBackendMockRecorder) ActionReceiver(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionReceiver", reflect.TypeOf((*MockBackend)(nil).ActionReceiver), arg0)
+}
+
+// ActiveRollouts mocks base method.
+func (m *MockBackend) ActiveRollouts() ([]state.OperationInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveRollouts")
+	ret0, _ := ret[0].([]state.OperationInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveRollouts indicates an expected call of ActiveRollouts.
+func (mr *MockBackendMockRecorder) ActiveRollouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveRollouts", reflect.TypeOf((*MockBackend)(nil).ActiveRollouts))
+}
+
+// AddAction mocks base method.
+func (m *MockBackend) AddAction(arg0 state.ActionReceiver, arg1, arg2 string, arg3 map[string]any, arg4 *bool, arg5 *string) (state.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAction", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(state.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAction indicates an expected call of AddAction.
+func (mr *MockBackendMockRecorder) AddAction(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAction", reflect.TypeOf((*MockBackend)(nil).AddAction), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// FailOperationEnqueuing mocks base method.
+func (m *MockBackend) FailOperationEnqueuing(arg0, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOperationEnqueuing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailOperationEnqueuing indicates an expected call of FailOperationEnqueuing.
+func (mr *MockBackendMockRecorder) FailOperationEnqueuing(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOperationEnqueuing", reflect.TypeOf((*MockBackend)(nil).FailOperationEnqueuing), arg0, arg1, arg2)
+}
+
+// FinishRollout mocks base method.
+func (m *MockBackend) FinishRollout(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRollout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishRollout indicates an expected call of FinishRollout.
+func (mr *MockBackendMockRecorder) FinishRollout(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRollout", reflect.TypeOf((*MockBackend)(nil).FinishRollout), arg0, arg1)
+}
+
+// StartRolloutBatch mocks base method.
+func (m *MockBackend) StartRolloutBatch(arg0 string, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRolloutBatch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRolloutBatch indicates an expected call of StartRolloutBatch.
+func (mr *MockBackendMockRecorder) StartRolloutBatch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRolloutBatch", reflect.TypeOf((*MockBackend)(nil).StartRolloutBatch), arg0, arg1)
+}
+
+// WatchRollouts mocks base method.
+func (m *MockBackend) WatchRollouts() state.NotifyWatcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRollouts")
+	ret0, _ := ret[0].(state.NotifyWatcher)
+	return ret0
+}
+
+// WatchRollouts indicates an expected call of WatchRollouts.
+func (mr *MockBackendMockRecorder) WatchRollouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRollouts", reflect.TypeOf((*MockBackend)(nil).WatchRollouts))
+}
@@ -0,0 +1,17 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/juju/testing"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/actionrollout Backend
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/state_mock.go github.com/juju/juju/state Operation,Action,ActionReceiver
+
+func TestAll(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
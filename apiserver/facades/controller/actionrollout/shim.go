@@ -0,0 +1,23 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State and its *Model to implement Backend.
+type backendShim struct {
+	*state.Model
+	st *state.State
+}
+
+// ActionReceiver is part of the Backend interface.
+func (shim backendShim) ActionReceiver(tag string) (state.ActionReceiver, error) {
+	receiver, err := common.TagToActionReceiverFn(shim.st.FindEntity)(tag)
+	return receiver, errors.Trace(err)
+}
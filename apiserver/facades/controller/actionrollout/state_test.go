@@ -0,0 +1,94 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionrollout"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type stateSuite struct {
+	statetesting.StateSuite
+
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&stateSuite{})
+
+func (s *stateSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		s.units = append(s.units, s.Factory.MakeUnit(c, &factory.UnitParams{Application: application}))
+	}
+}
+
+func (s *stateSuite) TestAdvanceRunningRollout(c *gc.C) {
+	task := func(unit *state.Unit) state.RolloutTask {
+		return state.RolloutTask{
+			Receiver:   unit.Tag().String(),
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{},
+		}
+	}
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, state.OperationRollout{
+		BatchSize: 2,
+		Batches: [][]state.RolloutTask{
+			{task(s.units[0]), task(s.units[1])},
+			{task(s.units[2])},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources := common.NewResources()
+	defer resources.StopAll()
+	facade, err := actionrollout.NewStateFacade(
+		s.State, s.Model, resources, testclock.NewClock(time.Now()), apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].BatchEnqueued, gc.Equals, 1)
+
+	// Start and finish the tasks of the first batch as the
+	// unit agents would, which marks the operation as running.
+	info, err := s.Model.OperationWithActions(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Actions, gc.HasLen, 2)
+	for _, a := range info.Actions {
+		a, err = a.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	results, err = facade.AdvanceRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutResult{{
+		OperationTag:  "operation-" + operationID,
+		BatchEnqueued: 2,
+	}})
+
+	info, err = s.Model.OperationWithActions(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Actions, gc.HasLen, 3)
+	c.Assert(info.Operation.Status(), gc.Equals, state.ActionRunning)
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+	// DueActionSchedules returns the action schedules due to run
+	// at the given time.
+	DueActionSchedules(now time.Time) ([]state.ActionSchedule, error)
+
+	// NextActionScheduleRun returns when the first of the action
+	// schedules is next due to run.
+	NextActionScheduleRun() (time.Time, error)
+
+	// WatchActionSchedules notifies when an action schedule is
+	// added or removed.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// RecordScheduledRun adds a run to the history of an action
+	// schedule and moves its next run on to the given time.
+	RecordScheduledRun(name string, next time.Time, run state.ScheduledRun) error
+
+	// OperationWithActions returns the operation with the given ID
+	// along with its tasks.
+	OperationWithActions(id string) (*state.OperationInfo, error)
+
+	// EnqueueOperation records the start of an operation.
+	EnqueueOperation(summary string, count int) (string, error)
+
+	// FailOperationEnqueuing records that some of an operation's
+	// tasks could not be enqueued.
+	FailOperationEnqueuing(operationID, failMessage string, count int) error
+
+	// ApplicationUnits returns the names of the units of the
+	// given application.
+	ApplicationUnits(name string) ([]string, error)
+
+	// ActionReceiver returns the receiver with the given tag.
+	ActionReceiver(tag string) (state.ActionReceiver, error)
+
+	// AddAction enqueues a task of an operation.
+	AddAction(
+		receiver state.ActionReceiver, operationID, name string, payload map[string]interface{},
+		parallel *bool, executionGroup *string,
+	) (state.Action, error)
+}
+
+// Facade allows the action scheduler worker to run the actions whose
+// schedules are due.
+type Facade struct {
+	backend    Backend
+	resources  facade.Resources
+	leadership leadership.Reader
+	clock      clock.Clock
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(
+	backend Backend, resources facade.Resources, leadership leadership.Reader, clock clock.Clock, auth facade.Authorizer,
+) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{backend: backend, resources: resources, leadership: leadership, clock: clock}, nil
+}
+
+// WatchActionSchedules returns a watcher notifying when an action
+// schedule of the model is added or removed, so that the next
+// scheduled run can be waited for.
+func (f *Facade) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	w := f.backend.WatchActionSchedules()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{NotifyWatcherId: f.resources.Register(w)}, nil
+	}
+	return params.NotifyWatchResult{Error: apiservererrors.ServerError(watcher.EnsureErr(w))}, nil
+}
+
+// RunDueSchedules starts an operation for each action schedule of the
+// model which is due, unless the operation of its previous run is
+// still active and the schedule's concurrency policy forbids it. Runs
+// missed while no controller was available are not made up for. A
+// schedule whose operation cannot be started remains due, so that the
+// run is tried again. The time the first schedule is next due is
+// returned along with the runs.
+func (f *Facade) RunDueSchedules() (params.ScheduledRunResults, error) {
+	now := f.clock.Now()
+	schedules, err := f.backend.DueActionSchedules(now)
+	if err != nil {
+		return params.ScheduledRunResults{}, errors.Trace(err)
+	}
+	results := params.ScheduledRunResults{
+		Results: make([]params.ScheduledRunResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result, err := f.run(schedule, now)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		}
+		result.Schedule = schedule.Name
+		results.Results[i] = result
+	}
+	nextDue, err := f.backend.NextActionScheduleRun()
+	if errors.Is(err, errors.NotFound) {
+		return results, nil
+	} else if err != nil {
+		return params.ScheduledRunResults{}, errors.Trace(err)
+	}
+	results.NextDue = &nextDue
+	return results, nil
+}
+
+func (f *Facade) run(schedule state.ActionSchedule, now time.Time) (params.ScheduledRunResult, error) {
+	var result params.ScheduledRunResult
+	next, err := schedule.Schedule.Next(schedule.NextRun)
+	if err == nil && !next.After(now) {
+		next, err = schedule.Schedule.Next(now)
+	}
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	run := state.ScheduledRun{Time: schedule.NextRun}
+	run.Skipped, err = f.applyConcurrencyPolicy(schedule)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var enqueueErr error
+	if run.Skipped == "" {
+		run.OperationID, run.Skipped, enqueueErr = f.enqueue(schedule)
+		if enqueueErr != nil && run.OperationID == "" {
+			// Nothing was enqueued, so the run is left due.
+			return result, errors.Trace(enqueueErr)
+		}
+	}
+	// Once an operation has been started, the run is recorded even
+	// if not all of its tasks could be enqueued.
+	if err := f.backend.RecordScheduledRun(schedule.Name, next, run); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.NextRun = next
+	if run.OperationID != "" {
+		result.OperationTag = names.NewOperationTag(run.OperationID).String()
+	}
+	result.Skipped = run.Skipped
+	return result, errors.Trace(enqueueErr)
+}
+
+// applyConcurrencyPolicy applies the schedule's concurrency policy to
+// the operation of its previous run, returning why the run should be
+// skipped, if it should.
+func (f *Facade) applyConcurrencyPolicy(schedule state.ActionSchedule) (string, error) {
+	if schedule.ConcurrencyPolicy == actions.ConcurrencyAllow {
+		return "", nil
+	}
+	var previous string
+	for i := len(schedule.Runs) - 1; i >= 0 && previous == ""; i-- {
+		previous = schedule.Runs[i].OperationID
+	}
+	if previous == "" {
+		return "", nil
+	}
+	info, err := f.backend.OperationWithActions(previous)
+	if errors.Is(err, errors.NotFound) {
+		// The operation has been pruned.
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch info.Operation.Status() {
+	case state.ActionPending, state.ActionRunning, state.ActionAborting:
+	default:
+		return "", nil
+	}
+	if schedule.ConcurrencyPolicy != actions.ConcurrencyReplace {
+		return fmt.Sprintf("operation %s of the previous run has not finished", previous), nil
+	}
+	for _, a := range info.Actions {
+		switch a.Status() {
+		case state.ActionPending, state.ActionRunning:
+			if _, err := a.Cancel(); err != nil {
+				return "", errors.Annotatef(err, "cancelling task %s", a.Id())
+			}
+		}
+	}
+	return "", nil
+}
+
+// enqueue starts an operation running the schedule's action on its
+// targets, returning the operation's ID or why there was nothing to
+// run.
+func (f *Facade) enqueue(schedule state.ActionSchedule) (string, string, error) {
+	receivers, failMessages, err := f.resolveTargets(schedule.Targets)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if len(receivers) == 0 && len(failMessages) == 0 {
+		return "", "no units to run on", nil
+	}
+
+	summary := fmt.Sprintf("%v run on %v by schedule %v",
+		schedule.Action, strings.Join(schedule.Targets, ","), schedule.Name)
+	count := len(receivers) + len(failMessages)
+	operationID, err := f.backend.EnqueueOperation(summary, count)
+	if err != nil {
+		return "", "", errors.Annotate(err, "creating operation for actions")
+	}
+	for _, tag := range receivers {
+		receiver, err := f.backend.ActionReceiver(tag)
+		if err == nil {
+			_, err = f.backend.AddAction(receiver, operationID, schedule.Action, schedule.Parameters, nil, nil)
+		}
+		if err != nil {
+			failMessages = append(failMessages, err.Error())
+		}
+	}
+	if len(failMessages) == 0 {
+		return operationID, "", nil
+	}
+	failMessage := fmt.Sprintf("error(s) enqueueing action(s): %s", strings.Join(failMessages, ", "))
+	err = f.backend.FailOperationEnqueuing(operationID, failMessage, count-len(failMessages))
+	return operationID, "", errors.Trace(err)
+}
+
+// resolveTargets returns the tags of the units the targets refer to,
+// along with why any of the targets could not be resolved.
+func (f *Facade) resolveTargets(targets []string) ([]string, []string, error) {
+	var leaders map[string]string
+	var receivers, failMessages []string
+	for _, target := range targets {
+		switch {
+		case strings.HasSuffix(target, "/leader"):
+			if leaders == nil {
+				var err error
+				if leaders, err = f.leadership.Leaders(); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
+			}
+			app := strings.TrimSuffix(target, "/leader")
+			leader, ok := leaders[app]
+			if !ok {
+				failMessages = append(failMessages, fmt.Sprintf("could not determine leader for %q", app))
+				continue
+			}
+			receivers = append(receivers, names.NewUnitTag(leader).String())
+		case names.IsValidUnit(target):
+			receivers = append(receivers, names.NewUnitTag(target).String())
+		default:
+			units, err := f.backend.ApplicationUnits(target)
+			if err != nil {
+				failMessages = append(failMessages, err.Error())
+				continue
+			}
+			for _, unit := range units {
+				receivers = append(receivers, names.NewUnitTag(unit).String())
+			}
+		}
+	}
+	return receivers, failMessages, nil
+}
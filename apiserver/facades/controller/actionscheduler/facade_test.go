@@ -0,0 +1,349 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler/mocks"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+type facadeSuite struct {
+	backend    *mocks.MockBackend
+	leadership *mocks.MockReader
+	receiver   *fakeReceiver
+	resources  *common.Resources
+	clock      *testclock.Clock
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+var (
+	now = time.Date(2024, 5, 1, 12, 0, 10, 0, time.UTC)
+	due = now.Add(-10 * time.Second)
+)
+
+func (s *facadeSuite) setup(c *gc.C) (*gomock.Controller, *actionscheduler.Facade) {
+	ctrl := gomock.NewController(c)
+	s.backend = mocks.NewMockBackend(ctrl)
+	s.leadership = mocks.NewMockReader(ctrl)
+	s.receiver = &fakeReceiver{}
+	s.resources = common.NewResources()
+	s.clock = testclock.NewClock(now)
+
+	facade, err := actionscheduler.NewFacade(
+		s.backend, s.resources, s.leadership, s.clock, apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return ctrl, facade
+}
+
+func (s *facadeSuite) schedule(policy actions.ConcurrencyPolicy, runs ...state.ScheduledRun) state.ActionSchedule {
+	return state.ActionSchedule{
+		Name:              "nightly",
+		Schedule:          actions.Schedule{Interval: time.Hour},
+		Targets:           []string{"mysql/0"},
+		Action:            "backup",
+		Parameters:        map[string]interface{}{"full": true},
+		ConcurrencyPolicy: policy,
+		NextRun:           due,
+		Runs:              runs,
+	}
+}
+
+func (s *facadeSuite) operation(status state.ActionStatus, actions ...state.Action) *state.OperationInfo {
+	return &state.OperationInfo{Operation: &fakeOperation{status: status}, Actions: actions}
+}
+
+func (s *facadeSuite) expectEnqueue(summary string, units ...string) {
+	s.backend.EXPECT().EnqueueOperation(summary, len(units)).Return("7", nil)
+	for _, unit := range units {
+		s.backend.EXPECT().ActionReceiver(unit).Return(s.receiver, nil)
+	}
+	s.backend.EXPECT().AddAction(
+		s.receiver, "7", "backup", map[string]interface{}{"full": true}, nil, nil,
+	).Return(nil, nil).Times(len(units))
+}
+
+func (s *facadeSuite) expectNextRun() {
+	s.backend.EXPECT().NextActionScheduleRun().Return(due.Add(time.Hour), nil)
+}
+
+func (s *facadeSuite) TestNewFacadeRequiresController(c *gc.C) {
+	_, err := actionscheduler.NewFacade(nil, nil, nil, nil, apiservertesting.FakeAuthorizer{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestRunDueSchedules(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid)
+	schedule.Targets = []string{"mysql", "redis/leader", "wordpress/0"}
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().ApplicationUnits("mysql").Return([]string{"mysql/0", "mysql/1"}, nil)
+	s.leadership.EXPECT().Leaders().Return(map[string]string{"redis": "redis/2"}, nil)
+	s.expectEnqueue("backup run on mysql,redis/leader,wordpress/0 by schedule nightly",
+		"unit-mysql-0", "unit-mysql-1", "unit-redis-2", "unit-wordpress-0")
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	next := due.Add(time.Hour)
+	c.Assert(results, jc.DeepEquals, params.ScheduledRunResults{
+		Results: []params.ScheduledRunResult{{
+			Schedule:     "nightly",
+			OperationTag: "operation-7",
+			NextRun:      due.Add(time.Hour),
+		}},
+		NextDue: &next,
+	})
+}
+
+func (s *facadeSuite) TestRunDueSchedulesNoSchedules(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.backend.EXPECT().DueActionSchedules(now).Return(nil, nil)
+	s.backend.EXPECT().NextActionScheduleRun().Return(time.Time{}, errors.NotFoundf("action schedules"))
+
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ScheduledRunResults{
+		Results: []params.ScheduledRunResult{},
+	})
+}
+
+func (s *facadeSuite) TestWatchActionSchedules(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+	defer s.resources.StopAll()
+
+	s.backend.EXPECT().WatchActionSchedules().Return(apiservertesting.NewFakeNotifyWatcher())
+
+	result, err := facade.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *facadeSuite) TestRunSkipsMissedRuns(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid)
+	schedule.NextRun = now.Add(-3 * time.Hour)
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.expectEnqueue("backup run on mysql/0 by schedule nightly", "unit-mysql-0")
+	s.backend.EXPECT().RecordScheduledRun("nightly", now.Add(time.Hour), state.ScheduledRun{Time: schedule.NextRun, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].NextRun, gc.Equals, now.Add(time.Hour))
+}
+
+func (s *facadeSuite) TestConcurrencyForbid(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid,
+		state.ScheduledRun{Time: due.Add(-time.Hour), OperationID: "5"},
+		state.ScheduledRun{Time: due.Add(-time.Minute), Skipped: "boom"},
+	)
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().OperationWithActions("5").Return(s.operation(state.ActionRunning), nil)
+	skipped := "operation 5 of the previous run has not finished"
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, Skipped: skipped}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ScheduledRunResult{{
+		Schedule: "nightly",
+		Skipped:  skipped,
+		NextRun:  due.Add(time.Hour),
+	}})
+}
+
+func (s *facadeSuite) TestConcurrencyForbidPreviousFinished(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid, state.ScheduledRun{Time: due.Add(-time.Hour), OperationID: "5"})
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().OperationWithActions("5").Return(s.operation(state.ActionCompleted), nil)
+	s.expectEnqueue("backup run on mysql/0 by schedule nightly", "unit-mysql-0")
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].OperationTag, gc.Equals, "operation-7")
+}
+
+func (s *facadeSuite) TestConcurrencyReplace(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	running := &fakeAction{status: state.ActionRunning}
+	completed := &fakeAction{status: state.ActionCompleted}
+
+	schedule := s.schedule(actions.ConcurrencyReplace, state.ScheduledRun{Time: due.Add(-time.Hour), OperationID: "5"})
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().OperationWithActions("5").Return(s.operation(state.ActionRunning, running, completed), nil)
+	s.expectEnqueue("backup run on mysql/0 by schedule nightly", "unit-mysql-0")
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].OperationTag, gc.Equals, "operation-7")
+	c.Assert(running.cancelled, jc.IsTrue)
+	c.Assert(completed.cancelled, jc.IsFalse)
+}
+
+func (s *facadeSuite) TestConcurrencyAllow(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyAllow, state.ScheduledRun{Time: due.Add(-time.Hour), OperationID: "5"})
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.expectEnqueue("backup run on mysql/0 by schedule nightly", "unit-mysql-0")
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].OperationTag, gc.Equals, "operation-7")
+}
+
+func (s *facadeSuite) TestEnqueueFailures(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid)
+	schedule.Targets = []string{"mysql", "redis/leader", "wordpress/0"}
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().ApplicationUnits("mysql").Return(nil, errors.NotFoundf(`application "mysql"`))
+	s.leadership.EXPECT().Leaders().Return(map[string]string{}, nil)
+	s.backend.EXPECT().EnqueueOperation("backup run on mysql,redis/leader,wordpress/0 by schedule nightly", 3).Return("7", nil)
+	s.backend.EXPECT().ActionReceiver("unit-wordpress-0").Return(s.receiver, nil)
+	s.backend.EXPECT().AddAction(
+		s.receiver, "7", "backup", map[string]interface{}{"full": true}, nil, nil,
+	).Return(nil, nil)
+	s.backend.EXPECT().FailOperationEnqueuing("7",
+		`error(s) enqueueing action(s): application "mysql" not found, could not determine leader for "redis"`, 1,
+	).Return(nil)
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].OperationTag, gc.Equals, "operation-7")
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *facadeSuite) TestNoUnits(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	schedule := s.schedule(actions.ConcurrencyForbid)
+	schedule.Targets = []string{"mysql"}
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{schedule}, nil)
+	s.backend.EXPECT().ApplicationUnits("mysql").Return(nil, nil)
+	s.backend.EXPECT().RecordScheduledRun("nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, Skipped: "no units to run on"}).Return(nil)
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ScheduledRunResult{{
+		Schedule: "nightly",
+		Skipped:  "no units to run on",
+		NextRun:  due.Add(time.Hour),
+	}})
+}
+
+func (s *facadeSuite) TestEnqueueError(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{s.schedule(actions.ConcurrencyForbid)}, nil)
+	s.backend.EXPECT().EnqueueOperation("backup run on mysql/0 by schedule nightly", 1).Return("", errors.New("boom"))
+
+	// The run is not recorded, so that it is still due.
+	s.backend.EXPECT().NextActionScheduleRun().Return(due, nil)
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ScheduledRunResults{
+		Results: []params.ScheduledRunResult{{
+			Schedule: "nightly",
+			Error:    &params.Error{Message: "creating operation for actions: boom"},
+		}},
+		NextDue: &due,
+	})
+}
+
+func (s *facadeSuite) TestRecordScheduledRunError(c *gc.C) {
+	ctrl, facade := s.setup(c)
+	defer ctrl.Finish()
+
+	s.backend.EXPECT().DueActionSchedules(now).Return([]state.ActionSchedule{s.schedule(actions.ConcurrencyForbid)}, nil)
+	s.expectEnqueue("backup run on mysql/0 by schedule nightly", "unit-mysql-0")
+	s.backend.EXPECT().RecordScheduledRun(
+		"nightly", due.Add(time.Hour), state.ScheduledRun{Time: due, OperationID: "7"},
+	).Return(errors.New("boom"))
+
+	s.expectNextRun()
+	results, err := facade.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ScheduledRunResult{{
+		Schedule: "nightly",
+		Error:    &params.Error{Message: "boom"},
+	}})
+}
+
+// fakeOperation, fakeAction and fakeReceiver implement only the
+// methods of the state entities which the facade uses.
+type fakeOperation struct {
+	state.Operation
+	status state.ActionStatus
+}
+
+func (op *fakeOperation) Status() state.ActionStatus {
+	return op.status
+}
+
+type fakeAction struct {
+	state.Action
+	status    state.ActionStatus
+	cancelled bool
+}
+
+func (a *fakeAction) Status() state.ActionStatus {
+	return a.status
+}
+
+func (a *fakeAction) Cancel() (state.Action, error) {
+	a.cancelled = true
+	return a, nil
+}
+
+type fakeReceiver struct {
+	state.ActionReceiver
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/apiserver/facades/controller/actionscheduler (interfaces: Backend)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/actionscheduler Backend
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	state "github.com/juju/juju/state"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackend is a mock of Backend interface.
+type MockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendMockRecorder
+}
+
+// MockBackendMockRecorder is the mock recorder for MockBackend.
+type MockBackendMockRecorder struct {
+	mock *MockBackend
+}
+
+// NewMockBackend creates a new mock instance.
+func NewMockBackend(ctrl *gomock.Controller) *MockBackend {
+	mock := &MockBackend{ctrl: ctrl}
+	mock.recorder = &MockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
+	return m.recorder
+}
+
+// ActionReceiver mocks base method.
+func (m *MockBackend) ActionReceiver(arg0 string) (state.ActionReceiver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionReceiver", arg0)
+	ret0, _ := ret[0].(state.ActionReceiver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionReceiver indicates an expected call of ActionReceiver.
+func (mr *MockBackendMockRecorder) ActionReceiver(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionReceiver", reflect.TypeOf((*MockBackend)(nil).ActionReceiver), arg0)
+}
+
+// AddAction mocks base method.
+func (m *MockBackend) AddAction(arg0 state.ActionReceiver, arg1, arg2 string, arg3 map[string]any, arg4 *bool, arg5 *string) (state.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAction", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(state.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAction indicates an expected call of AddAction.
+func (mr *MockBackendMockRecorder) AddAction(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAction", reflect.TypeOf((*MockBackend)(nil).AddAction), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// ApplicationUnits mocks base method.
+func (m *MockBackend) ApplicationUnits(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationUnits", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationUnits indicates an expected call of ApplicationUnits.
+func (mr *MockBackendMockRecorder) ApplicationUnits(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationUnits", reflect.TypeOf((*MockBackend)(nil).ApplicationUnits), arg0)
+}
+
+// DueActionSchedules mocks base method.
+func (m *MockBackend) DueActionSchedules(arg0 time.Time) ([]state.ActionSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueActionSchedules", arg0)
+	ret0, _ := ret[0].([]state.ActionSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueActionSchedules indicates an expected call of DueActionSchedules.
+func (mr *MockBackendMockRecorder) DueActionSchedules(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueActionSchedules", reflect.TypeOf((*MockBackend)(nil).DueActionSchedules), arg0)
+}
+
+// EnqueueOperation mocks base method.
+func (m *MockBackend) EnqueueOperation(arg0 string, arg1 int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueOperation", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueOperation indicates an expected call of EnqueueOperation.
+func (mr *MockBackendMockRecorder) EnqueueOperation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueOperation", reflect.TypeOf((*MockBackend)(nil).EnqueueOperation), arg0, arg1)
+}
+
+// FailOperationEnqueuing mocks base method.
+func (m *MockBackend) FailOperationEnqueuing(arg0, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOperationEnqueuing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailOperationEnqueuing indicates an expected call of FailOperationEnqueuing.
+func (mr *MockBackendMockRecorder) FailOperationEnqueuing(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOperationEnqueuing", reflect.TypeOf((*MockBackend)(nil).FailOperationEnqueuing), arg0, arg1, arg2)
+}
+
+// NextActionScheduleRun mocks base method.
+func (m *MockBackend) NextActionScheduleRun() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextActionScheduleRun")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextActionScheduleRun indicates an expected call of NextActionScheduleRun.
+func (mr *MockBackendMockRecorder) NextActionScheduleRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextActionScheduleRun", reflect.TypeOf((*MockBackend)(nil).NextActionScheduleRun))
+}
+
+// OperationWithActions mocks base method.
+func (m *MockBackend) OperationWithActions(arg0 string) (*state.OperationInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperationWithActions", arg0)
+	ret0, _ := ret[0].(*state.OperationInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperationWithActions indicates an expected call of OperationWithActions.
+func (mr *MockBackendMockRecorder) OperationWithActions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationWithActions", reflect.TypeOf((*MockBackend)(nil).OperationWithActions), arg0)
+}
+
+// RecordScheduledRun mocks base method.
+func (m *MockBackend) RecordScheduledRun(arg0 string, arg1 time.Time, arg2 state.ScheduledRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordScheduledRun", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordScheduledRun indicates an expected call of RecordScheduledRun.
+func (mr *MockBackendMockRecorder) RecordScheduledRun(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordScheduledRun", reflect.TypeOf((*MockBackend)(nil).RecordScheduledRun), arg0, arg1, arg2)
+}
+
+// WatchActionSchedules mocks base method.
+func (m *MockBackend) WatchActionSchedules() state.NotifyWatcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchActionSchedules")
+	ret0, _ := ret[0].(state.NotifyWatcher)
+	return ret0
+}
+
+// WatchActionSchedules indicates an expected call of WatchActionSchedules.
+func (mr *MockBackendMockRecorder) WatchActionSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchActionSchedules", reflect.TypeOf((*MockBackend)(nil).WatchActionSchedules))
+}
@@ -0,0 +1,17 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/actionscheduler Backend
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/leadership_mock.go github.com/juju/juju/core/leadership Reader
+
+func TestAll(t *testing.T) {
+	gc.TestingT(t)
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"reflect"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// Register is called to expose a package of facades onto a given registry.
+func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("ActionScheduler", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacade(ctx)
+	}, reflect.TypeOf((*Facade)(nil)))
+}
+
+// newFacade provides the required signature for facade registration.
+func newFacade(ctx facade.Context) (*Facade, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	leadership, err := ctx.LeadershipReader(m.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewFacade(backendShim{st: st, Model: m}, ctx.Resources(), leadership, clock.WallClock, ctx.Auth())
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State and its *Model to implement Backend.
+type backendShim struct {
+	*state.Model
+	st *state.State
+}
+
+// ActionReceiver is part of the Backend interface.
+func (shim backendShim) ActionReceiver(tag string) (state.ActionReceiver, error) {
+	receiver, err := common.TagToActionReceiverFn(shim.st.FindEntity)(tag)
+	return receiver, errors.Trace(err)
+}
+
+// ApplicationUnits is part of the Backend interface.
+func (shim backendShim) ApplicationUnits(name string) ([]string, error) {
+	app, err := shim.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]string, len(units))
+	for i, unit := range units {
+		result[i] = unit.Name()
+	}
+	return result, nil
+}
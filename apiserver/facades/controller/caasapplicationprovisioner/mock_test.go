@@ -230,6 +230,7 @@ type mockApplication struct {
 	unitsWatcher         *statetesting.MockStringsWatcher
 	unitsChanges         chan []string
 	watcher              *statetesting.MockNotifyWatcher
+	configWatcher        *statetesting.MockNotifyWatcher
 	charmPending         bool
 	provisioningState    *state.ApplicationProvisioningState
 }
@@ -363,6 +364,11 @@ func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
 
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
 func (a *mockApplication) SetProvisioningState(ps state.ApplicationProvisioningState) error {
 	a.MethodCall(a, "SetProvisioningState", ps)
 	err := a.NextErr()
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/cloudconfig/podcfg"
 	"github.com/juju/juju/controller"
@@ -238,11 +239,12 @@ func (a *API) watchProvisioningInfo(appName names.ApplicationTag) (params.Notify
 	}
 
 	appWatcher := app.Watch()
+	appConfigWatcher := app.WatchApplicationConfig()
 	controllerConfigWatcher := a.ctrlSt.WatchControllerConfig()
 	controllerAPIHostPortsWatcher := a.ctrlSt.WatchAPIHostPortsForAgents()
 	modelConfigWatcher := model.WatchForModelConfigChanges()
 
-	multiWatcher := common.NewMultiNotifyWatcher(appWatcher, controllerConfigWatcher, controllerAPIHostPortsWatcher, modelConfigWatcher)
+	multiWatcher := common.NewMultiNotifyWatcher(appWatcher, appConfigWatcher, controllerConfigWatcher, controllerAPIHostPortsWatcher, modelConfigWatcher)
 
 	if _, ok := <-multiWatcher.Changes(); ok {
 		result.NotifyWatcherId = a.resources.Register(multiWatcher)
@@ -372,6 +374,8 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 		CharmURL:             *charmURL,
 		Trust:                appConfig.GetBool(application.TrustConfigOptionName, false),
 		Scale:                app.GetScale(),
+		MaxUnavailable:       appConfig.GetString(k8sprovider.MaxUnavailableConfigKey, ""),
+		SpreadBy:             appConfig.GetString(k8sprovider.SpreadByConfigKey, ""),
 	}, nil
 }
 
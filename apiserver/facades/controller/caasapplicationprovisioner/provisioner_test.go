@@ -95,7 +95,9 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 		charmModifiedVersion: 10,
 		scale:                3,
 		config: config.ConfigAttributes{
-			"trust": true,
+			"trust":                      true,
+			"kubernetes-max-unavailable": "25%",
+			"kubernetes-spread-by":       "zone",
 		},
 	}
 	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
@@ -116,6 +118,8 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 			CharmModifiedVersion: 10,
 			Scale:                3,
 			Trust:                true,
+			MaxUnavailable:       "25%",
+			SpreadBy:             "zone",
 		}},
 	})
 }
@@ -136,6 +140,7 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoPendingCharmError(
 
 func (s *CAASApplicationProvisionerSuite) TestWatchProvisioningInfo(c *gc.C) {
 	appChanged := make(chan struct{}, 1)
+	appConfigChanged := make(chan struct{}, 1)
 	portsChanged := make(chan struct{}, 1)
 	modelConfigChanged := make(chan struct{}, 1)
 	controllerConfigChanged := make(chan struct{}, 1)
@@ -148,9 +153,11 @@ func (s *CAASApplicationProvisionerSuite) TestWatchProvisioningInfo(c *gc.C) {
 			meta: &charm.Meta{},
 			url:  "cs:gitlab",
 		},
-		watcher: statetesting.NewMockNotifyWatcher(appChanged),
+		watcher:       statetesting.NewMockNotifyWatcher(appChanged),
+		configWatcher: statetesting.NewMockNotifyWatcher(appConfigChanged),
 	}
 	appChanged <- struct{}{}
+	appConfigChanged <- struct{}{}
 	portsChanged <- struct{}{}
 	modelConfigChanged <- struct{}{}
 	controllerConfigChanged <- struct{}{}
@@ -0,0 +1,139 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+	// AutoscaledApplications returns the applications with an
+	// autoscale policy.
+	AutoscaledApplications() ([]Application, error)
+
+	// Application returns the named application.
+	Application(name string) (Application, error)
+}
+
+// Application exposes the application functionality required by
+// Facade.
+type Application interface {
+	Name() string
+	GetScale() int
+	SetScale(scale int, generation int64, force bool) error
+	AutoscalePolicy() (application.AutoscalePolicy, error)
+	AutoscaleStatus() (application.AutoscaleStatus, error)
+	SetAutoscaleStatus(application.AutoscaleStatus) error
+}
+
+// Facade allows the autoscaler worker to read the autoscale policies of
+// applications, and to rescale them.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// AutoscaledApplications returns the applications with an autoscale
+// policy, along with their scale and what the autoscaler last observed
+// of them.
+func (f *Facade) AutoscaledApplications() (params.AutoscaledApplicationsResult, error) {
+	apps, err := f.backend.AutoscaledApplications()
+	if err != nil {
+		return params.AutoscaledApplicationsResult{
+			Error: apiservererrors.ServerError(err),
+		}, nil
+	}
+	result := params.AutoscaledApplicationsResult{
+		Applications: make([]params.AutoscaledApplication, 0, len(apps)),
+	}
+	for _, app := range apps {
+		policy, err := app.AutoscalePolicy()
+		if errors.Is(err, errors.NotFound) {
+			// The policy was removed since the applications were listed.
+			continue
+		} else if err != nil {
+			return params.AutoscaledApplicationsResult{
+				Error: apiservererrors.ServerError(err),
+			}, nil
+		}
+		status, err := app.AutoscaleStatus()
+		if err != nil {
+			return params.AutoscaledApplicationsResult{
+				Error: apiservererrors.ServerError(err),
+			}, nil
+		}
+		result.Applications = append(result.Applications, params.AutoscaledApplication{
+			ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+			Policy:         common.AutoscalePolicyToParams(policy),
+			Status:         common.AutoscaleStatusToParams(status),
+			Scale:          app.GetScale(),
+		})
+	}
+	return result, nil
+}
+
+// SetAutoscaleStatus records what the autoscaler observed of the
+// applications, and rescales those it has chosen a new scale for.
+func (f *Facade) SetAutoscaleStatus(args params.SetAutoscaleStatusArgs) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := f.setAutoscaleStatus(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results
+}
+
+func (f *Facade) setAutoscaleStatus(arg params.SetAutoscaleStatusArg) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := f.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Scale != nil && *arg.Scale != app.GetScale() {
+		// Scale as "juju scale-application" does, so that the change
+		// is applied by the application provisioner.
+		if err := app.SetScale(*arg.Scale, 0, true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(app.SetAutoscaleStatus(statusFromParams(arg.Status)))
+}
+
+// statusFromParams converts the params representation of what the
+// autoscaler observed of an application.
+func statusFromParams(status params.AutoscaleStatus) application.AutoscaleStatus {
+	result := application.AutoscaleStatus{
+		Utilization: application.Utilization{
+			Units:  status.UnitsMeasured,
+			CPU:    status.CPU,
+			Memory: status.Memory,
+		},
+		TargetScale: status.TargetScale,
+		Message:     status.Message,
+	}
+	if status.LastScaled != nil {
+		result.LastScaled = status.LastScaled.UTC()
+	}
+	return result
+}
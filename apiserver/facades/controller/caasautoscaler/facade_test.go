@@ -0,0 +1,165 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/caasautoscaler"
+	"github.com/juju/juju/apiserver/facades/controller/caasautoscaler/mocks"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+
+	backend *mocks.MockBackend
+	app     *mocks.MockApplication
+	facade  *caasautoscaler.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.backend = mocks.NewMockBackend(ctrl)
+	s.app = mocks.NewMockApplication(ctrl)
+
+	var err error
+	s.facade, err = caasautoscaler.NewFacade(s.backend, apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return ctrl
+}
+
+func (s *facadeSuite) TestPermission(c *gc.C) {
+	_, err := caasautoscaler.NewFacade(nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("fred"),
+	})
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *facadeSuite) TestAutoscaledApplications(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	removed := mocks.NewMockApplication(ctrl)
+	s.backend.EXPECT().AutoscaledApplications().Return([]caasautoscaler.Application{s.app, removed}, nil)
+
+	policy := application.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  3 * time.Minute,
+	}
+	cpu := 72.5
+	lastScaled := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	s.app.EXPECT().AutoscalePolicy().Return(policy, nil)
+	s.app.EXPECT().AutoscaleStatus().Return(application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 2, CPU: &cpu},
+		TargetScale: 3,
+		LastScaled:  lastScaled,
+	}, nil)
+	s.app.EXPECT().Name().Return("gitlab")
+	s.app.EXPECT().GetScale().Return(3)
+	removed.EXPECT().AutoscalePolicy().Return(application.AutoscalePolicy{}, errors.NotFoundf("autoscale policy"))
+
+	result, err := s.facade.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AutoscaledApplicationsResult{
+		Applications: []params.AutoscaledApplication{{
+			ApplicationTag: "application-gitlab",
+			Policy: params.AutoscalePolicy{
+				MinUnits:  1,
+				MaxUnits:  5,
+				TargetCPU: 60,
+				Cooldown:  3 * time.Minute,
+			},
+			Status: params.AutoscaleStatus{
+				UnitsMeasured: 2,
+				CPU:           &cpu,
+				TargetScale:   3,
+				LastScaled:    &lastScaled,
+			},
+			Scale: 3,
+		}},
+	})
+}
+
+func (s *facadeSuite) TestAutoscaledApplicationsError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.backend.EXPECT().AutoscaledApplications().Return(nil, errors.New("boom"))
+
+	result, err := s.facade.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestSetAutoscaleStatus(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cpu := 90.0
+	lastScaled := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	scale := 4
+	s.backend.EXPECT().Application("gitlab").Return(s.app, nil)
+	s.app.EXPECT().GetScale().Return(3)
+	s.app.EXPECT().SetScale(4, int64(0), true).Return(nil)
+	s.app.EXPECT().SetAutoscaleStatus(application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 3, CPU: &cpu},
+		TargetScale: 4,
+		LastScaled:  lastScaled,
+	}).Return(nil)
+
+	result := s.facade.SetAutoscaleStatus(params.SetAutoscaleStatusArgs{
+		Args: []params.SetAutoscaleStatusArg{{
+			ApplicationTag: "application-gitlab",
+			Status: params.AutoscaleStatus{
+				UnitsMeasured: 3,
+				CPU:           &cpu,
+				TargetScale:   4,
+				LastScaled:    &lastScaled,
+			},
+			Scale: &scale,
+		}, {
+			ApplicationTag: "unit-gitlab-0",
+		}},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+}
+
+func (s *facadeSuite) TestSetAutoscaleStatusWithoutScaling(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.backend.EXPECT().Application("gitlab").Return(s.app, nil)
+	s.app.EXPECT().SetAutoscaleStatus(application.AutoscaleStatus{
+		TargetScale: 3,
+		Message:     "resource metrics API not found",
+	}).Return(nil)
+
+	result := s.facade.SetAutoscaleStatus(params.SetAutoscaleStatusArgs{
+		Args: []params.SetAutoscaleStatusArg{{
+			ApplicationTag: "application-gitlab",
+			Status: params.AutoscaleStatus{
+				TargetScale: 3,
+				Message:     "resource metrics API not found",
+			},
+		}},
+	})
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/apiserver/facades/controller/caasautoscaler (interfaces: Backend,Application)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/caasautoscaler Backend,Application
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	caasautoscaler "github.com/juju/juju/apiserver/facades/controller/caasautoscaler"
+	application "github.com/juju/juju/core/application"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackend is a mock of Backend interface.
+type MockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendMockRecorder
+}
+
+// MockBackendMockRecorder is the mock recorder for MockBackend.
+type MockBackendMockRecorder struct {
+	mock *MockBackend
+}
+
+// NewMockBackend creates a new mock instance.
+func NewMockBackend(ctrl *gomock.Controller) *MockBackend {
+	mock := &MockBackend{ctrl: ctrl}
+	mock.recorder = &MockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
+	return m.recorder
+}
+
+// Application mocks base method.
+func (m *MockBackend) Application(arg0 string) (caasautoscaler.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Application", arg0)
+	ret0, _ := ret[0].(caasautoscaler.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Application indicates an expected call of Application.
+func (mr *MockBackendMockRecorder) Application(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Application", reflect.TypeOf((*MockBackend)(nil).Application), arg0)
+}
+
+// AutoscaledApplications mocks base method.
+func (m *MockBackend) AutoscaledApplications() ([]caasautoscaler.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscaledApplications")
+	ret0, _ := ret[0].([]caasautoscaler.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoscaledApplications indicates an expected call of AutoscaledApplications.
+func (mr *MockBackendMockRecorder) AutoscaledApplications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscaledApplications", reflect.TypeOf((*MockBackend)(nil).AutoscaledApplications))
+}
+
+// MockApplication is a mock of Application interface.
+type MockApplication struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMockRecorder
+}
+
+// MockApplicationMockRecorder is the mock recorder for MockApplication.
+type MockApplicationMockRecorder struct {
+	mock *MockApplication
+}
+
+// NewMockApplication creates a new mock instance.
+func NewMockApplication(ctrl *gomock.Controller) *MockApplication {
+	mock := &MockApplication{ctrl: ctrl}
+	mock.recorder = &MockApplicationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
+	return m.recorder
+}
+
+// AutoscalePolicy mocks base method.
+func (m *MockApplication) AutoscalePolicy() (application.AutoscalePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscalePolicy")
+	ret0, _ := ret[0].(application.AutoscalePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoscalePolicy indicates an expected call of AutoscalePolicy.
+func (mr *MockApplicationMockRecorder) AutoscalePolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscalePolicy", reflect.TypeOf((*MockApplication)(nil).AutoscalePolicy))
+}
+
+// AutoscaleStatus mocks base method.
+func (m *MockApplication) AutoscaleStatus() (application.AutoscaleStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscaleStatus")
+	ret0, _ := ret[0].(application.AutoscaleStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoscaleStatus indicates an expected call of AutoscaleStatus.
+func (mr *MockApplicationMockRecorder) AutoscaleStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscaleStatus", reflect.TypeOf((*MockApplication)(nil).AutoscaleStatus))
+}
+
+// GetScale mocks base method.
+func (m *MockApplication) GetScale() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScale")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetScale indicates an expected call of GetScale.
+func (mr *MockApplicationMockRecorder) GetScale() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScale", reflect.TypeOf((*MockApplication)(nil).GetScale))
+}
+
+// Name mocks base method.
+func (m *MockApplication) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockApplicationMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockApplication)(nil).Name))
+}
+
+// SetAutoscaleStatus mocks base method.
+func (m *MockApplication) SetAutoscaleStatus(arg0 application.AutoscaleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoscaleStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutoscaleStatus indicates an expected call of SetAutoscaleStatus.
+func (mr *MockApplicationMockRecorder) SetAutoscaleStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoscaleStatus", reflect.TypeOf((*MockApplication)(nil).SetAutoscaleStatus), arg0)
+}
+
+// SetScale mocks base method.
+func (m *MockApplication) SetScale(arg0 int, arg1 int64, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScale", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScale indicates an expected call of SetScale.
+func (mr *MockApplicationMockRecorder) SetScale(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScale", reflect.TypeOf((*MockApplication)(nil).SetScale), arg0, arg1, arg2)
+}
@@ -0,0 +1,16 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/caasautoscaler Backend,Application
+
+func TestAll(t *testing.T) {
+	gc.TestingT(t)
+}
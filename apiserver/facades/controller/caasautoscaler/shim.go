@@ -0,0 +1,37 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State to implement Backend.
+type backendShim struct {
+	st *state.State
+}
+
+// AutoscaledApplications is part of the Backend interface.
+func (shim backendShim) AutoscaledApplications() ([]Application, error) {
+	apps, err := shim.st.AutoscaledApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// Application is part of the Backend interface.
+func (shim backendShim) Application(name string) (Application, error) {
+	app, err := shim.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
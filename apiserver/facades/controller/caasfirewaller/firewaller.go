@@ -5,7 +5,9 @@ package caasfirewaller
 
 import (
 	"sort"
+	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
@@ -134,9 +136,16 @@ func (f *Facade) WatchApplications() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
 }
 
+// FacadeSidecarV1 provides access to version 1 of the CAASFirewaller
+// API facade for sidecar applications.
+type FacadeSidecarV1 struct {
+	*FacadeSidecar
+}
+
 // FacadeSidecar provides access to the CAASFirewaller API facade for sidecar applications.
 type FacadeSidecar struct {
 	*Facade
+	*common.ModelWatcher
 
 	accessModel common.GetAuthFunc
 }
@@ -147,6 +156,7 @@ func newFacadeSidecar(
 	st CAASFirewallerState,
 	commonCharmsAPI *charmscommon.CharmInfoAPI,
 	appCharmInfoAPI *charmscommon.ApplicationCharmInfoAPI,
+	modelWatcher *common.ModelWatcher,
 ) (*FacadeSidecar, error) {
 	if !authorizer.AuthController() {
 		return nil, apiservererrors.ErrPerm
@@ -154,7 +164,8 @@ func newFacadeSidecar(
 	accessApplication := common.AuthFuncForTagKind(names.ApplicationTagKind)
 
 	return &FacadeSidecar{
-		accessModel: common.AuthFuncForTagKind(names.ModelTagKind),
+		ModelWatcher: modelWatcher,
+		accessModel:  common.AuthFuncForTagKind(names.ModelTagKind),
 		Facade: &Facade{
 			LifeGetter: common.NewLifeGetter(
 				st, common.AuthAny(
@@ -266,3 +277,199 @@ func (f *FacadeSidecar) applicationOpenedPortsForEndpoint(endpointName string, p
 	}
 	return o
 }
+
+// ApplicationIngressRules returns, for each given application tag, the
+// pods of related applications and the networks allowed to connect to
+// the application's pods, and on which of its opened ports. Related
+// applications may connect on the ports opened for the relation's
+// endpoint, and exposed ports may be connected to from the networks
+// they are exposed to.
+func (f *FacadeSidecar) ApplicationIngressRules(args params.Entities) (params.ApplicationIngressRulesResults, error) {
+	results := params.ApplicationIngressRulesResults{
+		Results: make([]params.ApplicationIngressRulesResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		rules, err := f.applicationIngressRules(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Rules = rules
+	}
+	return results, nil
+}
+
+// WatchApplicationIngressRules returns a NotifyWatcher for each given
+// application tag, which notifies when the application's relations
+// are added, removed, suspended or resumed, or when the ingress networks
+// of its cross model relations change. Each of these may change the
+// application's ingress rules.
+func (f *FacadeSidecar) WatchApplicationIngressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationIngressRules(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *FacadeSidecar) watchApplicationIngressRules(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w, err := newIngressRulesWatcher(f.state, app)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+func (f *FacadeSidecar) applicationIngressRules(tagString string) ([]params.ApplicationIngressRule, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	openedPortRanges, err := app.OpenedPortRanges()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var rules []params.ApplicationIngressRule
+	for _, rel := range relations {
+		if rel.Suspended() {
+			continue
+		}
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		portRanges := endpointPortRanges(openedPortRanges, ep.Name)
+		if len(portRanges) == 0 {
+			continue
+		}
+		isCrossModel, err := rel.IsCrossModel()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isCrossModel {
+			// The pods of applications in other models can't be
+			// selected, so allow the relation's ingress networks.
+			cidrs, err := f.state.RelationIngressNetworks(rel.String())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if len(cidrs) > 0 {
+				rules = append(rules, params.ApplicationIngressRule{
+					SourceCIDRs: cidrs,
+					PortRanges:  portRanges,
+				})
+			}
+			continue
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, other := range related {
+			rules = append(rules, params.ApplicationIngressRule{
+				SourceApplication: other.ApplicationName,
+				PortRanges:        portRanges,
+			})
+		}
+	}
+
+	if app.IsExposed() {
+		exposedEndpoints := app.ExposedEndpoints()
+		for endpointName, exposeDetails := range exposedEndpoints {
+			// Spaces aren't modelled for Kubernetes, so only the
+			// exposed CIDRs are allowed.
+			if len(exposeDetails.ExposeToCIDRs) == 0 {
+				continue
+			}
+			var portRanges []params.PortRange
+			if endpointName != "" {
+				portRanges = endpointPortRanges(openedPortRanges, endpointName)
+			} else {
+				// Exposing all endpoints allows the ports opened for
+				// all endpoints except the ones exposed separately.
+				for name, pgs := range openedPortRanges {
+					if _, ok := exposedEndpoints[name]; ok && name != "" {
+						continue
+					}
+					for _, pg := range pgs {
+						portRanges = append(portRanges, params.FromNetworkPortRange(pg))
+					}
+				}
+				sortParamsPortRanges(portRanges)
+			}
+			if len(portRanges) == 0 {
+				continue
+			}
+			rules = append(rules, params.ApplicationIngressRule{
+				SourceCIDRs: set.NewStrings(exposeDetails.ExposeToCIDRs...).SortedValues(),
+				PortRanges:  portRanges,
+			})
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].SourceApplication != rules[j].SourceApplication {
+			return rules[i].SourceApplication < rules[j].SourceApplication
+		}
+		return strings.Join(rules[i].SourceCIDRs, ",") < strings.Join(rules[j].SourceCIDRs, ",")
+	})
+	return rules, nil
+}
+
+// endpointPortRanges returns the port ranges opened for the endpoint,
+// including those opened for all endpoints.
+func endpointPortRanges(openedPortRanges network.GroupedPortRanges, endpointName string) []params.PortRange {
+	var result []params.PortRange
+	for _, pg := range append(openedPortRanges[endpointName], openedPortRanges[""]...) {
+		result = append(result, params.FromNetworkPortRange(pg))
+	}
+	sortParamsPortRanges(result)
+	return result
+}
+
+func sortParamsPortRanges(portRanges []params.PortRange) {
+	sort.Slice(portRanges, func(i, j int) bool {
+		a, b := portRanges[i].NetworkPortRange(), portRanges[j].NetworkPortRange()
+		return a.LessThan(b)
+	})
+}
+
+// ModelConfig isn't on version 1 of the facade.
+func (*FacadeSidecarV1) ModelConfig(_, _ struct{}) {}
+
+// WatchForModelConfigChanges isn't on version 1 of the facade.
+func (*FacadeSidecarV1) WatchForModelConfigChanges(_, _ struct{}) {}
+
+// ApplicationIngressRules isn't on version 1 of the facade.
+func (*FacadeSidecarV1) ApplicationIngressRules(_, _ struct{}) {}
+
+// WatchApplicationIngressRules isn't on version 1 of the facade.
+func (*FacadeSidecarV1) WatchApplicationIngressRules(_, _ struct{}) {}
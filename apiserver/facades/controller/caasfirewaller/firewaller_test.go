@@ -90,6 +90,7 @@ var _ = gc.Suite(&firewallerSidecarSuite{
 				st,
 				commonCharmsAPI,
 				appCharmInfoAPI,
+				nil,
 			)
 		},
 	},
@@ -169,6 +170,121 @@ func (s *firewallerSidecarSuite) TestGetApplicationOpenedPorts(c *gc.C) {
 	})
 }
 
+func (s *firewallerSidecarSuite) TestApplicationIngressRules(c *gc.C) {
+	s.st.application.appPortRanges = network.GroupedPortRanges{
+		"":      []network.PortRange{network.MustParsePortRange("80/tcp")},
+		"db":    []network.PortRange{network.MustParsePortRange("5432/tcp")},
+		"admin": []network.PortRange{network.MustParsePortRange("8443/tcp")},
+	}
+	relationEndpoints := func(localName, remoteApp string) []state.Endpoint {
+		return []state.Endpoint{{
+			ApplicationName: "gitlab",
+			Relation:        charm.Relation{Name: localName},
+		}, {
+			ApplicationName: remoteApp,
+			Relation:        charm.Relation{Name: "server"},
+		}}
+	}
+	s.st.application.relations = []caasfirewaller.Relation{
+		&mockRelation{key: "gitlab:db postgresql:server", endpoints: relationEndpoints("db", "postgresql")},
+		&mockRelation{key: "gitlab:db mysql:server", endpoints: relationEndpoints("db", "mysql"), suspended: true},
+		&mockRelation{key: "gitlab:cache redis:server", endpoints: relationEndpoints("cache", "redis")},
+		&mockRelation{key: "gitlab:db remote-pg:server", endpoints: relationEndpoints("db", "remote-pg"), crossModel: true},
+	}
+	s.st.ingressNetworks = map[string][]string{
+		"gitlab:db remote-pg:server": {"10.0.0.0/24"},
+	}
+	s.st.application.exposed = true
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"":      {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"admin": {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+		"db":    {ExposeToSpaceIDs: []string{"1"}},
+	}
+
+	results, err := s.facade.ApplicationIngressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	tcp := func(port int) params.PortRange {
+		return params.PortRange{FromPort: port, ToPort: port, Protocol: "tcp"}
+	}
+	c.Assert(results.Results[0].Rules, jc.DeepEquals, []params.ApplicationIngressRule{{
+		SourceCIDRs: []string{"0.0.0.0/0"},
+		PortRanges:  []params.PortRange{tcp(80)},
+	}, {
+		SourceCIDRs: []string{"10.0.0.0/24"},
+		PortRanges:  []params.PortRange{tcp(80), tcp(5432)},
+	}, {
+		SourceCIDRs: []string{"192.168.0.0/16"},
+		PortRanges:  []params.PortRange{tcp(80), tcp(8443)},
+	}, {
+		SourceApplication: "postgresql",
+		PortRanges:        []params.PortRange{tcp(80), tcp(5432)},
+	}, {
+		SourceApplication: "redis",
+		PortRanges:        []params.PortRange{tcp(80)},
+	}})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+}
+
+func (s *firewallerSidecarSuite) TestWatchApplicationIngressRules(c *gc.C) {
+	relationsChanges := make(chan []string, 1)
+	ingressChanges := make(chan []string, 1)
+	s.st.application.relationsWatcher = statetesting.NewMockStringsWatcher(relationsChanges)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.relationsWatcher) })
+	crossModel := &mockRelation{
+		key:            "gitlab:db remote-pg:server",
+		crossModel:     true,
+		ingressWatcher: statetesting.NewMockStringsWatcher(ingressChanges),
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, crossModel.ingressWatcher) })
+	s.st.relations = map[string]*mockRelation{
+		"gitlab:db postgresql:server": {key: "gitlab:db postgresql:server"},
+		"gitlab:db remote-pg:server":  crossModel,
+	}
+
+	relationsChanges <- []string{"gitlab:db postgresql:server", "gitlab:db remote-pg:server"}
+	ingressChanges <- []string{"10.0.0.0/24"}
+	results, err := s.facade.WatchApplicationIngressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+
+	w, ok := s.resources.Get("1").(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, w)
+	// The initial ingress networks are reported with the relation.
+	wc.AssertNoChange()
+
+	// A relation being suspended changes the relation.
+	relationsChanges <- []string{"gitlab:db postgresql:server"}
+	wc.AssertOneChange()
+
+	ingressChanges <- []string{"10.0.0.0/24", "10.0.1.0/24"}
+	wc.AssertOneChange()
+
+	// Once the cross model relation is removed, its
+	// ingress networks are no longer watched.
+	delete(s.st.relations, "gitlab:db remote-pg:server")
+	relationsChanges <- []string{"gitlab:db remote-pg:server"}
+	wc.AssertOneChange()
+	crossModel.CheckCallNames(c, "IsCrossModel", "WatchRelationIngressNetworks")
+	workertest.CheckKilled(c, crossModel.ingressWatcher)
+}
+
 type facadeCommon interface {
 	IsExposed(args params.Entities) (params.BoolResults, error)
 	ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error)
@@ -182,6 +298,8 @@ type facadeSidecar interface {
 	facadeCommon
 	WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error)
 	GetOpenedPorts(arg params.Entity) (params.ApplicationOpenedPortsResults, error)
+	ApplicationIngressRules(args params.Entities) (params.ApplicationIngressRulesResults, error)
+	WatchApplicationIngressRules(args params.Entities) (params.NotifyWatchResults, error)
 }
 
 func (s *firewallerBaseSuite) SetUpTest(c *gc.C) {
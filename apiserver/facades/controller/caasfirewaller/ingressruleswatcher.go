@@ -0,0 +1,212 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/state"
+)
+
+// ingressRulesWatcher notifies when the ingress rules of an application
+// may have changed because one of its relations was added, removed,
+// suspended or resumed, or because the ingress networks of one of its
+// cross model relations changed.
+type ingressRulesWatcher struct {
+	catacomb catacomb.Catacomb
+
+	backend CAASFirewallerState
+	app     Application
+
+	out chan struct{}
+
+	// Channel for relationIngressWorkers to report changes to
+	// the ingress networks of individual relations.
+	networkChanges chan string
+
+	// A map of relation key to the worker watching the
+	// ingress networks of that cross model relation.
+	relations map[string]*relationIngressWorker
+}
+
+func newIngressRulesWatcher(backend CAASFirewallerState, app Application) (*ingressRulesWatcher, error) {
+	w := &ingressRulesWatcher{
+		backend:        backend,
+		app:            app,
+		out:            make(chan struct{}),
+		networkChanges: make(chan string),
+		relations:      make(map[string]*relationIngressWorker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+func (w *ingressRulesWatcher) loop() error {
+	defer close(w.out)
+
+	rw := w.app.WatchRelations()
+	if err := w.catacomb.Add(rw); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The initial event is sent once the initial
+	// relations have been seen.
+	var out chan<- struct{}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case out <- struct{}{}:
+			out = nil
+
+		case keys, ok := <-rw.Changes():
+			if !ok {
+				return w.catacomb.ErrDying()
+			}
+			for _, key := range keys {
+				if err := w.relationChanged(key); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			out = w.out
+
+		case <-w.networkChanges:
+			out = w.out
+		}
+	}
+}
+
+// relationChanged starts watching the ingress networks of the relation
+// with the given key if it is a cross model relation, and stops
+// watching them once the relation has been removed.
+func (w *ingressRulesWatcher) relationChanged(key string) error {
+	rel, err := w.backend.KeyRelation(key)
+	if errors.Is(err, errors.NotFound) {
+		return errors.Trace(w.untrackRelation(key))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := w.relations[key]; ok {
+		return nil
+	}
+	isCrossModel, err := rel.IsCrossModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isCrossModel {
+		return nil
+	}
+	rw, err := newRelationIngressWorker(key, rel, w.networkChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(rw); err != nil {
+		return errors.Trace(err)
+	}
+	w.relations[key] = rw
+	return nil
+}
+
+func (w *ingressRulesWatcher) untrackRelation(key string) error {
+	rw, ok := w.relations[key]
+	if !ok {
+		return nil
+	}
+	delete(w.relations, key)
+	return errors.Trace(worker.Stop(rw))
+}
+
+// Changes returns the event channel for this watcher.
+func (w *ingressRulesWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+// Kill asks the watcher to stop without waiting for it do so.
+func (w *ingressRulesWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait waits for the watcher to die and returns any
+// error encountered when it was running.
+func (w *ingressRulesWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Stop kills the watcher, then waits for it to die.
+func (w *ingressRulesWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Err returns any error encountered while the watcher
+// has been running.
+func (w *ingressRulesWatcher) Err() error {
+	return w.catacomb.Err()
+}
+
+var _ state.NotifyWatcher = (*ingressRulesWatcher)(nil)
+
+func newRelationIngressWorker(key string, rel Relation, out chan<- string) (*relationIngressWorker, error) {
+	w := &relationIngressWorker{
+		key: key,
+		rel: rel,
+		out: out,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// relationIngressWorker watches for changes to the ingress networks
+// of a relation and notifies the out channel when it sees them.
+type relationIngressWorker struct {
+	catacomb catacomb.Catacomb
+	key      string
+	rel      Relation
+	out      chan<- string
+}
+
+func (w *relationIngressWorker) loop() error {
+	nw := w.rel.WatchRelationIngressNetworks()
+	if err := w.catacomb.Add(nw); err != nil {
+		return errors.Trace(err)
+	}
+	// The relation being added is reported by the relations
+	// watcher, so the initial event isn't passed on.
+	initial := true
+	var out chan<- string
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-nw.Changes():
+			if !ok {
+				return w.catacomb.ErrDying()
+			}
+			if initial {
+				initial = false
+				continue
+			}
+			out = w.out
+		case out <- w.key:
+			out = nil
+		}
+	}
+}
+
+func (w *relationIngressWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+func (w *relationIngressWorker) Wait() error {
+	return w.catacomb.Wait()
+}
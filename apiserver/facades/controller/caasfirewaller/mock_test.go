@@ -5,6 +5,7 @@ package caasfirewaller_test
 
 import (
 	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/testing"
 
@@ -22,6 +23,25 @@ type mockState struct {
 	applicationsWatcher *statetesting.MockStringsWatcher
 	openPortsWatcher    *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	ingressNetworks     map[string][]string
+	relations           map[string]*mockRelation
+}
+
+func (st *mockState) KeyRelation(key string) (caasfirewaller.Relation, error) {
+	st.MethodCall(st, "KeyRelation", key)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	rel, ok := st.relations[key]
+	if !ok {
+		return nil, errors.NotFoundf("relation %q", key)
+	}
+	return rel, nil
+}
+
+func (st *mockState) RelationIngressNetworks(relationKey string) ([]string, error) {
+	st.MethodCall(st, "RelationIngressNetworks", relationKey)
+	return st.ingressNetworks[relationKey], st.NextErr()
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -73,8 +93,31 @@ type mockApplication struct {
 	exposed      bool
 	watcher      state.NotifyWatcher
 
-	charm         mockCharm
-	appPortRanges network.GroupedPortRanges
+	charm            mockCharm
+	appPortRanges    network.GroupedPortRanges
+	exposedEndpoints map[string]state.ExposedEndpoint
+	relations        []caasfirewaller.Relation
+	relationsWatcher state.StringsWatcher
+}
+
+func (a *mockApplication) Name() string {
+	a.MethodCall(a, "Name")
+	return "gitlab"
+}
+
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) Relations() ([]caasfirewaller.Relation, error) {
+	a.MethodCall(a, "Relations")
+	return a.relations, a.NextErr()
+}
+
+func (a *mockApplication) WatchRelations() state.StringsWatcher {
+	a.MethodCall(a, "WatchRelations")
+	return a.relationsWatcher
 }
 
 func (a *mockApplication) Life() state.Life {
@@ -107,6 +150,56 @@ func (a *mockApplication) Charm() (charmscommon.Charm, bool, error) {
 	return &a.charm, false, nil
 }
 
+type mockRelation struct {
+	testing.Stub
+	key        string
+	suspended  bool
+	crossModel bool
+	endpoints  []state.Endpoint
+
+	ingressWatcher state.StringsWatcher
+}
+
+func (r *mockRelation) String() string {
+	return r.key
+}
+
+func (r *mockRelation) Suspended() bool {
+	r.MethodCall(r, "Suspended")
+	return r.suspended
+}
+
+func (r *mockRelation) Endpoint(applicationName string) (state.Endpoint, error) {
+	r.MethodCall(r, "Endpoint", applicationName)
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == applicationName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", applicationName)
+}
+
+func (r *mockRelation) RelatedEndpoints(applicationName string) ([]state.Endpoint, error) {
+	r.MethodCall(r, "RelatedEndpoints", applicationName)
+	var result []state.Endpoint
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName != applicationName {
+			result = append(result, ep)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockRelation) IsCrossModel() (bool, error) {
+	r.MethodCall(r, "IsCrossModel")
+	return r.crossModel, nil
+}
+
+func (r *mockRelation) WatchRelationIngressNetworks() state.StringsWatcher {
+	r.MethodCall(r, "WatchRelationIngressNetworks")
+	return r.ingressWatcher
+}
+
 type mockCharm struct {
 	testing.Stub
 	charmscommon.Charm // Override only the methods the tests use
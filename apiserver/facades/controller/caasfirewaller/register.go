@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/common"
 	charmscommon "github.com/juju/juju/apiserver/common/charms"
 	"github.com/juju/juju/apiserver/facade"
 )
@@ -19,6 +20,9 @@ func Register(registry facade.FacadeRegistry) {
 	}, reflect.TypeOf((*Facade)(nil)))
 
 	registry.MustRegister("CAASFirewallerSidecar", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newStateFacadeSidecarV1(ctx)
+	}, reflect.TypeOf((*FacadeSidecarV1)(nil)))
+	registry.MustRegister("CAASFirewallerSidecar", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newStateFacadeSidecar(ctx)
 	}, reflect.TypeOf((*FacadeSidecar)(nil)))
 }
@@ -47,6 +51,15 @@ func newStateFacadeLegacy(ctx facade.Context) (*Facade, error) {
 	)
 }
 
+// newStateFacadeSidecarV1 provides the signature required for facade registration.
+func newStateFacadeSidecarV1(ctx facade.Context) (*FacadeSidecarV1, error) {
+	api, err := newStateFacadeSidecar(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeSidecarV1{api}, nil
+}
+
 // newStateFacadeSidecar provides the signature required for facade registration.
 func newStateFacadeSidecar(ctx facade.Context) (*FacadeSidecar, error) {
 	authorizer := ctx.Auth()
@@ -61,11 +74,16 @@ func newStateFacadeSidecar(ctx facade.Context) (*FacadeSidecar, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return newFacadeSidecar(
 		resources,
 		authorizer,
 		&stateShim{ctx.State()},
 		commonCharmsAPI,
 		appCharmInfoAPI,
+		common.NewModelWatcher(model, resources, authorizer),
 	)
 }
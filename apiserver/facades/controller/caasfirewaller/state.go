@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
 	charmscommon "github.com/juju/juju/apiserver/common/charms"
@@ -20,6 +21,13 @@ type CAASFirewallerState interface {
 
 	WatchApplications() state.StringsWatcher
 	WatchOpenedPorts() state.StringsWatcher
+
+	// RelationIngressNetworks returns the networks allowed to connect
+	// to the local end of a cross model relation.
+	RelationIngressNetworks(relationKey string) ([]string, error)
+
+	// KeyRelation returns the relation with the given key.
+	KeyRelation(string) (Relation, error)
 }
 
 // Application provides the subset of application state
@@ -30,6 +38,21 @@ type Application interface {
 	Watch() state.NotifyWatcher
 	Charm() (ch charmscommon.Charm, force bool, err error)
 	OpenedPortRanges() (network.GroupedPortRanges, error)
+	Name() string
+	ExposedEndpoints() map[string]state.ExposedEndpoint
+	Relations() ([]Relation, error)
+	WatchRelations() state.StringsWatcher
+}
+
+// Relation provides the subset of relation state
+// required by the CAAS operator facade.
+type Relation interface {
+	String() string
+	Suspended() bool
+	Endpoint(string) (state.Endpoint, error)
+	RelatedEndpoints(string) ([]state.Endpoint, error)
+	IsCrossModel() (bool, error)
+	WatchRelationIngressNetworks() state.StringsWatcher
 }
 
 type stateShim struct {
@@ -44,6 +67,24 @@ func (s *stateShim) Application(id string) (Application, error) {
 	return &applicationShim{app}, nil
 }
 
+func (s *stateShim) KeyRelation(key string) (Relation, error) {
+	rel, err := s.State.KeyRelation(key)
+	if err != nil {
+		return nil, err
+	}
+	return &relationShim{rel}, nil
+}
+
+func (s *stateShim) RelationIngressNetworks(relationKey string) ([]string, error) {
+	networks, err := state.NewRelationIngressNetworks(s.State).Networks(relationKey)
+	if errors.Is(err, errors.NotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return networks.CIDRS(), nil
+}
+
 type applicationShim struct {
 	*state.Application
 }
@@ -59,3 +100,24 @@ func (a *applicationShim) OpenedPortRanges() (network.GroupedPortRanges, error)
 	}
 	return pg.ByEndpoint(), nil
 }
+
+func (a *applicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = &relationShim{rel}
+	}
+	return result, nil
+}
+
+type relationShim struct {
+	*state.Relation
+}
+
+func (r *relationShim) IsCrossModel() (bool, error) {
+	_, isCrossModel, err := r.Relation.RemoteApplication()
+	return isCrossModel, err
+}
@@ -31,6 +31,7 @@ func newFacadeV1(ctx facade.Context) (*CharmDownloaderAPI, error) {
 		return nil, errors.Trace(err)
 	}
 	resourcesBackend := resourcesShim{ctx.Resources()}
+	charmCache := ctx.CharmCache()
 
 	return newAPI(
 		authorizer,
@@ -43,6 +44,7 @@ func newFacadeV1(ctx facade.Context) (*CharmDownloaderAPI, error) {
 			return storage.NewStorage(modelUUID, rawState.MongoSession())
 		},
 		func(cfg services.CharmDownloaderConfig) (Downloader, error) {
+			cfg.CharmCache = charmCache
 			return services.NewCharmDownloader(cfg)
 		},
 	), nil
@@ -19,6 +19,7 @@ type Backend interface {
 	migration.StateExporter
 
 	WatchForMigration() state.NotifyWatcher
+	WatchMigrationStatus() state.NotifyWatcher
 	LatestMigration() (state.ModelMigration, error)
 	ModelUUID() string
 	ModelName() (string, error)
@@ -104,6 +104,10 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 	if err != nil {
 		return empty, errors.Annotate(err, "marshalling macaroons")
 	}
+	checkpoint, err := mig.Checkpoint()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving checkpoint")
+	}
 	return params.MasterMigrationStatus{
 		Spec: params.MigrationSpec{
 			ModelTag: names.NewModelTag(mig.ModelUUID()).String(),
@@ -119,9 +123,40 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
+		Checkpoint:       checkpointToParams(checkpoint),
 	}, nil
 }
 
+func checkpointToParams(checkpoint coremigration.Checkpoint) *params.MigrationCheckpoint {
+	result := &params.MigrationCheckpoint{
+		Imported:  checkpoint.Imported,
+		Charms:    checkpoint.Charms,
+		Tools:     checkpoint.Tools,
+		Resources: checkpoint.Resources,
+		LogsSent:  checkpoint.LogsSent,
+	}
+	if checkpoint.IsFailed() {
+		result.FailedPhase = checkpoint.FailedPhase.String()
+	}
+	return result
+}
+
+// WatchStatus starts watching for changes to the status of the
+// model's migration, such as the migration being resumed after a
+// failure. The returned id should be used with the NotifyWatcher
+// facade to receive events.
+func (api *API) WatchStatus() params.NotifyWatchResult {
+	watch := api.backend.WatchMigrationStatus()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}
+	}
+	return params.NotifyWatchResult{
+		Error: apiservererrors.ServerError(watcher.EnsureErr(watch)),
+	}
+}
+
 // ModelInfo returns essential information about the model to be
 // migrated.
 func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
@@ -239,6 +274,31 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetCheckpoint records the progress made by the active model
+// migration, so that it can be resumed if it fails.
+func (api *API) SetCheckpoint(args params.SetMigrationCheckpointArgs) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	checkpoint := coremigration.Checkpoint{
+		Imported:  args.Checkpoint.Imported,
+		Charms:    args.Checkpoint.Charms,
+		Tools:     args.Checkpoint.Tools,
+		Resources: args.Checkpoint.Resources,
+		LogsSent:  args.Checkpoint.LogsSent,
+	}
+	if args.Checkpoint.FailedPhase != "" {
+		phase, ok := coremigration.ParsePhase(args.Checkpoint.FailedPhase)
+		if !ok {
+			return errors.Errorf("invalid phase: %q", args.Checkpoint.FailedPhase)
+		}
+		checkpoint.FailedPhase = phase
+	}
+	err = mig.SetCheckpoint(checkpoint)
+	return errors.Annotate(err, "failed to set checkpoint")
+}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -464,3 +524,15 @@ func revisionToSerialized(rr description.ResourceRevision) params.SerializedMode
 		Username:       rr.Username(),
 	}
 }
+
+// APIV3 implements version 3 of the MigrationMaster API, which can't
+// checkpoint migrations.
+type APIV3 struct {
+	*API
+}
+
+// SetCheckpoint isn't on version 3 of the facade.
+func (*APIV3) SetCheckpoint(_, _ struct{}) {}
+
+// WatchStatus isn't on version 3 of the facade.
+func (*APIV3) WatchStatus(_, _ struct{}) {}
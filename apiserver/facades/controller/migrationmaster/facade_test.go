@@ -134,6 +134,11 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	exp.Id().Return("ID")
 	now := time.Now()
 	exp.PhaseChangedTime().Return(now)
+	exp.Checkpoint().Return(coremigration.Checkpoint{
+		FailedPhase: coremigration.IMPORT,
+		Imported:    true,
+		Charms:      []string{"ch:foo-1"},
+	}, nil)
 
 	s.backend.EXPECT().LatestMigration().Return(mig, nil)
 
@@ -156,9 +161,42 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 		MigrationId:      "ID",
 		Phase:            "IMPORT",
 		PhaseChangedTime: now,
+		Checkpoint: &params.MigrationCheckpoint{
+			FailedPhase: "IMPORT",
+			Imported:    true,
+			Charms:      []string{"ch:foo-1"},
+		},
 	})
 }
 
+func (s *Suite) TestWatchStatus(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	// Watcher with an initial event in the pipe.
+	w := mocks.NewMockNotifyWatcher(ctrl)
+	w.EXPECT().Stop().Return(nil).AnyTimes()
+
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	w.EXPECT().Changes().Return(ch).Times(2)
+
+	s.backend.EXPECT().WatchMigrationStatus().Return(w)
+
+	result := s.mustMakeAPI(c).WatchStatus()
+	c.Assert(result.Error, gc.IsNil)
+
+	resource := s.resources.Get(result.NotifyWatcherId)
+	watcher, _ := resource.(state.NotifyWatcher)
+	c.Assert(watcher, gc.NotNil)
+
+	select {
+	case <-watcher.Changes():
+		c.Fatalf("initial event not consumed")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *Suite) TestModelInfo(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -248,6 +286,44 @@ func (s *Suite) TestSetPhaseError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set phase: blam")
 }
 
+func (s *Suite) TestSetCheckpoint(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetCheckpoint(coremigration.Checkpoint{
+		FailedPhase: coremigration.PROCESSRELATIONS,
+		Imported:    true,
+		Tools:       []string{"3.3.0-ubuntu-amd64"},
+		Resources:   []string{"foo/bar"},
+	}).Return(nil)
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetCheckpoint(params.SetMigrationCheckpointArgs{
+		Checkpoint: params.MigrationCheckpoint{
+			FailedPhase: "PROCESSRELATIONS",
+			Imported:    true,
+			Tools:       []string{"3.3.0-ubuntu-amd64"},
+			Resources:   []string{"foo/bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestSetCheckpointBadPhase(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetCheckpoint(params.SetMigrationCheckpointArgs{
+		Checkpoint: params.MigrationCheckpoint{FailedPhase: "wat"},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid phase: "wat"`)
+}
+
 func (s *Suite) TestSetStatusMessage(c *gc.C) {
 	ctrl := s.setupMocks(c)
 	defer ctrl.Finish()
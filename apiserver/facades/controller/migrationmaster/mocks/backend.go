@@ -191,6 +191,20 @@ func (mr *MockBackendMockRecorder) WatchForMigration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchForMigration", reflect.TypeOf((*MockBackend)(nil).WatchForMigration))
 }
 
+// WatchMigrationStatus mocks base method.
+func (m *MockBackend) WatchMigrationStatus() state.NotifyWatcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchMigrationStatus")
+	ret0, _ := ret[0].(state.NotifyWatcher)
+	return ret0
+}
+
+// WatchMigrationStatus indicates an expected call of WatchMigrationStatus.
+func (mr *MockBackendMockRecorder) WatchMigrationStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchMigrationStatus", reflect.TypeOf((*MockBackend)(nil).WatchMigrationStatus))
+}
+
 // MockControllerState is a mock of ControllerState interface.
 type MockControllerState struct {
 	ctrl     *gomock.Controller
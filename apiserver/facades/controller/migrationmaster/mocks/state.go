@@ -43,6 +43,20 @@ func (m *MockModelMigration) EXPECT() *MockModelMigrationMockRecorder {
 	return m.recorder
 }
 
+// Abort mocks base method.
+func (m *MockModelMigration) Abort() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockModelMigrationMockRecorder) Abort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockModelMigration)(nil).Abort))
+}
+
 // Attempt mocks base method.
 func (m *MockModelMigration) Attempt() int {
 	m.ctrl.T.Helper()
@@ -57,6 +71,21 @@ func (mr *MockModelMigrationMockRecorder) Attempt() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempt", reflect.TypeOf((*MockModelMigration)(nil).Attempt))
 }
 
+// Checkpoint mocks base method.
+func (m *MockModelMigration) Checkpoint() (migration.Checkpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint")
+	ret0, _ := ret[0].(migration.Checkpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockModelMigrationMockRecorder) Checkpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockModelMigration)(nil).Checkpoint))
+}
+
 // EndTime mocks base method.
 func (m *MockModelMigration) EndTime() time.Time {
 	m.ctrl.T.Helper()
@@ -185,6 +214,34 @@ func (mr *MockModelMigrationMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockModelMigration)(nil).Refresh))
 }
 
+// Resume mocks base method.
+func (m *MockModelMigration) Resume() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockModelMigrationMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockModelMigration)(nil).Resume))
+}
+
+// SetCheckpoint mocks base method.
+func (m *MockModelMigration) SetCheckpoint(arg0 migration.Checkpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCheckpoint", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCheckpoint indicates an expected call of SetCheckpoint.
+func (mr *MockModelMigrationMockRecorder) SetCheckpoint(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckpoint", reflect.TypeOf((*MockModelMigration)(nil).SetCheckpoint), arg0)
+}
+
 // SetPhase mocks base method.
 func (m *MockModelMigration) SetPhase(arg0 migration.Phase) error {
 	m.ctrl.T.Helper()
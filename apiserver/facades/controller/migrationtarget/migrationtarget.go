@@ -5,8 +5,10 @@ package migrationtarget
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
+	"github.com/juju/description/v5"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
@@ -50,6 +52,11 @@ type APIV2 struct {
 	*APIV1
 }
 
+// APIV3 implements the V3 version of the API facade.
+type APIV3 struct {
+	*API
+}
+
 // NewAPI returns a new APIV1. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(
@@ -87,43 +94,67 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
+	if err := api.checkSourceFacades(model); err != nil {
+		return errors.Trace(err)
+	}
+	backend, modelInfo, err := api.precheckBackend(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(api.state.ControllerModelUUID()),
+	)
+}
+
+// checkSourceFacades ensures that the source controller has the
+// facades required to perform a migration to this controller.
+func (api *API) checkSourceFacades(model params.MigrationModelInfo) error {
 	// If there are no required migration facade versions, then we
 	// don't need to check anything.
-	if len(api.requiredMigrationFacadeVersions) > 0 {
-		// Ensure that when attempting to migrate a model, the source
-		// controller has the required facades for the migration.
-		sourceFacadeVersions := facades.FacadeVersions{}
-		for name, versions := range model.FacadeVersions {
-			sourceFacadeVersions[name] = versions
-		}
-		if !facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
-			majorMinor := fmt.Sprintf("%d.%d",
-				model.ControllerAgentVersion.Major,
-				model.ControllerAgentVersion.Minor,
-			)
-
-			// If the patch is zero, then we don't need to mention it.
-			var patchMessage string
-			if model.ControllerAgentVersion.Patch > 0 {
-				patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
-			}
+	if len(api.requiredMigrationFacadeVersions) == 0 {
+		return nil
+	}
+	// Ensure that when attempting to migrate a model, the source
+	// controller has the required facades for the migration.
+	sourceFacadeVersions := facades.FacadeVersions{}
+	for name, versions := range model.FacadeVersions {
+		sourceFacadeVersions[name] = versions
+	}
+	if facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
+		return nil
+	}
+	majorMinor := fmt.Sprintf("%d.%d",
+		model.ControllerAgentVersion.Major,
+		model.ControllerAgentVersion.Minor,
+	)
 
-			return errors.Errorf(`
+	// If the patch is zero, then we don't need to mention it.
+	var patchMessage string
+	if model.ControllerAgentVersion.Patch > 0 {
+		patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
+	}
+
+	return errors.Errorf(`
 Source controller does not support required facades for performing migration.
 Upgrade the controller to a newer version of %s%s or migrate to a controller
 with an earlier version of the target controller and try again.
 
 `[1:], majorMinor, patchMessage)
-		}
-	}
+}
 
+// precheckBackend returns the backend used to run the target
+// prechecks, along with the details of the model being migrated.
+func (api *API) precheckBackend(model params.MigrationModelInfo) (migration.PrecheckBackend, coremigration.ModelInfo, error) {
 	ownerTag, err := names.ParseUserTag(model.OwnerTag)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, errors.Trace(err)
 	}
 	controllerState, err := api.pool.SystemState()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, errors.Trace(err)
 	}
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -131,20 +162,114 @@ with an earlier version of the target controller and try again.
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, coremigration.ModelInfo{}, errors.Annotate(err, "creating backend")
+	}
+	return backend, coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}, nil
+}
+
+// DryRunImport reports whether the model described could be imported
+// into the target controller, without making any changes. All of the
+// problems found are returned, rather than just the first.
+func (api *API) DryRunImport(args params.DryRunImportArgs) (params.MigrationTargetReport, error) {
+	var report params.MigrationTargetReport
+	if err := api.checkSourceFacades(args.Model); err != nil {
+		report.Blockers = append(report.Blockers, err.Error())
 	}
-	return migration.TargetPrecheck(
+
+	backend, modelInfo, err := api.precheckBackend(args.Model)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	issues, err := migration.TargetPrecheckIssues(
 		backend,
 		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
+		modelInfo,
+		api.presence.ModelPresence(api.state.ControllerModelUUID()),
 	)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	report.Blockers = append(report.Blockers, issues...)
+
+	model, err := description.Deserialize(args.Bytes)
+	if err != nil {
+		report.Blockers = append(report.Blockers, fmt.Sprintf("cannot read model description: %v", err))
+		return report, nil
+	}
+	if err := model.Validate(); err != nil {
+		report.Blockers = append(report.Blockers, fmt.Sprintf("invalid model description: %v", err))
+	}
+
+	blockers, warnings, err := api.checkCloud(model)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	report.Blockers = append(report.Blockers, blockers...)
+	report.Warnings = append(report.Warnings, warnings...)
+
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return report, errors.Annotate(err, "retrieving controller version")
+	}
+	if modelInfo.AgentVersion.Compare(controllerVersion) < 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"model version %s is older than the target controller (%s), the model can be upgraded after migration",
+			modelInfo.AgentVersion, controllerVersion))
+	}
+	return report, nil
+}
+
+// checkCloud ensures that the cloud, region and credential used by the
+// model are usable on this controller, following the same rules as
+// the model import.
+func (api *API) checkCloud(model description.Model) (blockers, warnings []string, _ error) {
+	cloud, err := api.state.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		blockers = append(blockers, fmt.Sprintf("cloud %q not found on target controller", model.Cloud()))
+	} else if err != nil {
+		return nil, nil, errors.Annotatef(err, "retrieving cloud %q", model.Cloud())
+	} else if region := model.CloudRegion(); region != "" {
+		found := false
+		for _, r := range cloud.Regions {
+			if r.Name == region {
+				found = true
+				break
+			}
+		}
+		if !found {
+			blockers = append(blockers, fmt.Sprintf("cloud %q has no region %q on target controller", cloud.Name, region))
+		}
+	}
+
+	creds := model.CloudCredential()
+	if creds == nil {
+		return blockers, warnings, nil
+	}
+	credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	if !names.IsValidCloudCredential(credID) {
+		blockers = append(blockers, fmt.Sprintf("cloud credential ID %q not valid", credID))
+		return blockers, warnings, nil
+	}
+	existing, err := api.state.CloudCredential(names.NewCloudCredentialTag(credID))
+	switch {
+	case errors.IsNotFound(err):
+		warnings = append(warnings, fmt.Sprintf("credential %q will be created on target controller", credID))
+	case err != nil:
+		return nil, nil, errors.Annotatef(err, "retrieving credential %q", credID)
+	case existing.AuthType != creds.AuthType():
+		blockers = append(blockers, fmt.Sprintf("credential %q auth type mismatch: %q != %q", credID, existing.AuthType, creds.AuthType()))
+	case !reflect.DeepEqual(existing.Attributes, creds.Attributes()):
+		blockers = append(blockers, fmt.Sprintf("credential %q attributes differ on target controller", credID))
+	case existing.Revoked:
+		blockers = append(blockers, fmt.Sprintf("credential %q is revoked", credID))
+	}
+	return blockers, warnings, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -391,3 +516,9 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// DryRunImport isn't on the V1 API.
+func (*APIV1) DryRunImport(_, _ struct{}) {}
+
+// DryRunImport isn't on the V3 API.
+func (*APIV3) DryRunImport(_, _ struct{}) {}
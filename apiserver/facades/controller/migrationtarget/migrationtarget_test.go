@@ -5,6 +5,7 @@ package migrationtarget_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/description/v5"
@@ -81,7 +82,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 4)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := aFactory(&facadetest.Context{
@@ -93,6 +94,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV3(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV3))
+}
+
 func (s *Suite) TestFacadeRegisteredV2(c *gc.C) {
 	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
@@ -192,6 +206,87 @@ with an earlier version of the target controller and try again.
 `[1:])
 }
 
+func (s *Suite) TestDryRunImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	report, err := api.DryRunImport(params.DryRunImportArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               s.Owner.String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, gc.HasLen, 0)
+
+	// Nothing was imported.
+	_, _, err = s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestDryRunImportCollectsBlockers(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPIWithFacadeVersions(c, facades.FacadeVersions{
+		"MigrationTarget": []int{1},
+	})
+	report, err := api.DryRunImport(params.DryRunImportArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   utils.MustNewUUID().String(),
+			Name:                   "some-model",
+			OwnerTag:               s.Owner.String(),
+			AgentVersion:           modelVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, gc.HasLen, 3)
+	c.Check(report.Blockers[0], gc.Matches, "(?s)Source controller does not support required facades.*")
+	c.Check(report.Blockers[1], gc.Matches, "model has higher version than target controller .*")
+	c.Check(report.Blockers[2], gc.Matches, "cannot read model description: .*")
+}
+
+func (s *Suite) TestDryRunImportUnknownCloud(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Type:        "iaas",
+		Owner:       s.Owner,
+		Config:      map[string]interface{}{"name": "some-model", "uuid": utils.MustNewUUID().String()},
+		Cloud:       "nowhere",
+		CloudRegion: "over-the-rainbow",
+	})
+	model.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:    s.Owner,
+		Cloud:    names.NewCloudTag("nowhere"),
+		Name:     "secret",
+		AuthType: "userpass",
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	report, err := api.DryRunImport(params.DryRunImportArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   model.Tag().Id(),
+			Name:                   "some-model",
+			OwnerTag:               s.Owner.String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(strings.Join(report.Blockers, "\n"), jc.Contains, `cloud "nowhere" not found on target controller`)
+	c.Check(report.Warnings, jc.DeepEquals, []string{
+		fmt.Sprintf(`credential "nowhere/%s/secret" will be created on target controller`, s.Owner.Id()),
+	})
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -0,0 +1,210 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/leadership"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes the model state required by Facade.
+type Backend interface {
+	commonsecrets.SecretsMigrationState
+
+	// GetSecret returns the metadata of the given secret.
+	GetSecret(*coresecrets.URI) (*coresecrets.SecretMetadata, error)
+
+	// SecretsMigration returns the model's secrets migration.
+	SecretsMigration() (*state.SecretsMigration, error)
+
+	// SetSecretsMigrationProgress records the progress of the
+	// model's secrets migration.
+	SetSecretsMigrationProgress(state.SecretsMigrationProgress) error
+
+	// WatchSecretsMigration notifies when the model's secrets
+	// migration is started or makes progress.
+	WatchSecretsMigration() state.NotifyWatcher
+}
+
+// Facade allows the secrets migration worker to move the revisions of
+// a model's secrets to another backend.
+type Facade struct {
+	backend           Backend
+	resources         facade.Resources
+	controllerUUID    string
+	secretsConsumer   commonsecrets.SecretsConsumer
+	leadershipChecker leadership.Checker
+	leadershipReader  leadership.Reader
+	adminConfigGetter func() (*provider.ModelBackendConfigInfo, error)
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(
+	backend Backend,
+	resources facade.Resources,
+	auth facade.Authorizer,
+	controllerUUID string,
+	secretsConsumer commonsecrets.SecretsConsumer,
+	leadershipChecker leadership.Checker,
+	leadershipReader leadership.Reader,
+	adminConfigGetter func() (*provider.ModelBackendConfigInfo, error),
+) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{
+		backend:           backend,
+		resources:         resources,
+		controllerUUID:    controllerUUID,
+		secretsConsumer:   secretsConsumer,
+		leadershipChecker: leadershipChecker,
+		leadershipReader:  leadershipReader,
+		adminConfigGetter: adminConfigGetter,
+	}, nil
+}
+
+// WatchSecretsMigration returns a watcher notifying when the model's
+// secrets migration is started or makes progress.
+func (f *Facade) WatchSecretsMigration() (params.NotifyWatchResult, error) {
+	w := f.backend.WatchSecretsMigration()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{NotifyWatcherId: f.resources.Register(w)}, nil
+	}
+	return params.NotifyWatchResult{Error: apiservererrors.ServerError(watcher.EnsureErr(w))}, nil
+}
+
+// GetSecretsMigration returns the model's current or most recent
+// secrets migration, and if it has not finished, the revisions still
+// to be moved.
+func (f *Facade) GetSecretsMigration() (params.SecretsMigrationResult, error) {
+	m, err := f.backend.SecretsMigration()
+	if err != nil {
+		return params.SecretsMigrationResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	result := commonsecrets.SecretsMigrationResult(m)
+	if m.Status.Finished() {
+		return result, nil
+	}
+	migration, err := f.migration(m.BackendID)
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	revisions, err := migration.Revisions(m.URIs, m.Applications)
+	if err != nil {
+		return params.SecretsMigrationResult{}, errors.Trace(err)
+	}
+	for _, rev := range revisions {
+		result.Revisions = append(result.Revisions, params.SecretsMigrationRevision{
+			URI:      rev.URI.String(),
+			Revision: rev.Revision,
+		})
+	}
+	return result, nil
+}
+
+func (f *Facade) migration(backendID string) (*commonsecrets.SecretsMigration, error) {
+	cfgInfo, err := f.adminConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return commonsecrets.NewSecretsMigration(f.backend, f.controllerUUID, cfgInfo, backendID)
+}
+
+// MigrateSecretRevisions moves the content of the given secret
+// revisions to the given backend. Each revision is changed to refer to
+// the new backend in the same way as its owner would change it, so a
+// revision of a secret owned by an application is only changed while
+// that application's leader remains the leader.
+func (f *Facade) MigrateSecretRevisions(args params.MigrateSecretRevisionsArgs) (params.MigrateSecretRevisionResults, error) {
+	migration, err := f.migration(args.BackendID)
+	if err != nil {
+		return params.MigrateSecretRevisionResults{}, errors.Trace(err)
+	}
+	if len(args.Revisions) > 0 {
+		if err := migration.InitTarget(); err != nil {
+			return params.MigrateSecretRevisionResults{}, errors.Trace(err)
+		}
+	}
+	leaders, err := f.leadershipReader.Leaders()
+	if err != nil {
+		return params.MigrateSecretRevisionResults{}, errors.Trace(err)
+	}
+	results := params.MigrateSecretRevisionResults{
+		Results: make([]params.MigrateSecretRevisionResult, len(args.Revisions)),
+	}
+	for i, arg := range args.Revisions {
+		r := &results.Results[i]
+		r.URI = arg.URI
+		r.Revision = arg.Revision
+		r.ToBackendID = args.BackendID
+		checksum, err := f.migrateRevision(migration, leaders, arg)
+		r.Checksum = checksum
+		r.Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (f *Facade) migrateRevision(
+	migration *commonsecrets.SecretsMigration, leaders map[string]string, arg params.SecretsMigrationRevision,
+) (string, error) {
+	uri, err := coresecrets.ParseURI(arg.URI)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	token, err := f.ownerToken(uri, leaders)
+	if err != nil {
+		return "", errors.Annotatef(err, "changing secret %s/%d", uri.ID, arg.Revision)
+	}
+	return migration.Migrate(uri, arg.Revision, token)
+}
+
+// ownerToken returns the token the owner of the secret would use to
+// change it, which for a secret owned by an application checks that
+// the application's current leader is still the leader.
+func (f *Facade) ownerToken(uri *coresecrets.URI, leaders map[string]string) (leadership.Token, error) {
+	md, err := f.backend.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owner, err := names.ParseTag(md.OwnerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if owner.Kind() == names.ApplicationTagKind {
+		leader, ok := leaders[owner.Id()]
+		if !ok {
+			return nil, errors.NotFoundf("leader of application %q", owner.Id())
+		}
+		owner = names.NewUnitTag(leader)
+	}
+	return commonsecrets.CanManage(f.secretsConsumer, f.leadershipChecker, owner, uri)
+}
+
+// SetSecretsMigrationProgress records the progress of the model's
+// secrets migration.
+func (f *Facade) SetSecretsMigrationProgress(arg params.SecretsMigrationProgressArg) (params.ErrorResult, error) {
+	p := state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationStatus(arg.Status),
+		Moved:  arg.Moved,
+	}
+	for _, failure := range arg.Failures {
+		p.Failures = append(p.Failures, state.SecretsMigrationFailure{
+			URI:      failure.URI,
+			Revision: failure.Revision,
+			Message:  failure.Message,
+		})
+	}
+	err := f.backend.SetSecretsMigrationProgress(p)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
@@ -0,0 +1,348 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/apiserver/facades/controller/secretsmigration"
+	"github.com/juju/juju/apiserver/facades/controller/secretsmigration/mocks"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/leadership"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+
+	backend           *mocks.MockBackend
+	secretsConsumer   *mocks.MockSecretsConsumer
+	leadershipChecker *mocks.MockChecker
+	leadershipReader  *mocks.MockReader
+	token             *mocks.MockToken
+	provider          *mocks.MockSecretBackendProvider
+	oldBackend        *mocks.MockSecretsBackend
+	newBackend        *mocks.MockSecretsBackend
+	resources         *common.Resources
+
+	facade *secretsmigration.Facade
+	uri1   *coresecrets.URI
+	uri2   *coresecrets.URI
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+const (
+	oldBackendID = "old-id"
+	newBackendID = "new-id"
+)
+
+var (
+	controllerBackendID = coretesting.ControllerTag.Id()
+	fooValue            = coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+)
+
+func (s *facadeSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.backend = mocks.NewMockBackend(ctrl)
+	s.secretsConsumer = mocks.NewMockSecretsConsumer(ctrl)
+	s.leadershipChecker = mocks.NewMockChecker(ctrl)
+	s.leadershipReader = mocks.NewMockReader(ctrl)
+	s.token = mocks.NewMockToken(ctrl)
+	s.provider = mocks.NewMockSecretBackendProvider(ctrl)
+	s.oldBackend = mocks.NewMockSecretsBackend(ctrl)
+	s.newBackend = mocks.NewMockSecretsBackend(ctrl)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return &provider.ModelBackendConfigInfo{
+			ActiveID: controllerBackendID,
+			Configs: map[string]provider.ModelBackendConfig{
+				controllerBackendID: {BackendConfig: provider.BackendConfig{BackendType: "controller"}},
+				oldBackendID:        {BackendConfig: provider.BackendConfig{BackendType: "old"}},
+				newBackendID:        {BackendConfig: provider.BackendConfig{BackendType: "new"}},
+			},
+		}, nil
+	}
+	var err error
+	s.facade, err = secretsmigration.NewFacade(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true},
+		controllerBackendID, s.secretsConsumer, s.leadershipChecker, s.leadershipReader,
+		adminConfigGetter,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return s.provider, nil
+	})
+	s.provider.EXPECT().NewBackend(gomock.Any()).DoAndReturn(
+		func(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+			if cfg.BackendType == "new" {
+				return s.newBackend, nil
+			}
+			return s.oldBackend, nil
+		}).AnyTimes()
+
+	s.uri1 = coresecrets.NewURI()
+	s.uri2 = coresecrets.NewURI()
+	return ctrl
+}
+
+// expectSecrets sets up two secrets: the first, owned by mariadb, has
+// one revision in the database and one on the new backend; the second,
+// owned by a unit of mysql, has a revision on the old backend.
+func (s *facadeSuite) expectSecrets() {
+	s.backend.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{
+		{URI: s.uri1, OwnerTag: "application-mariadb"},
+		{URI: s.uri2, OwnerTag: "unit-mysql-0"},
+	}, nil)
+	s.backend.EXPECT().ListSecretRevisions(s.uri1).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 1},
+		{Revision: 2, ValueRef: &coresecrets.ValueRef{BackendID: newBackendID, RevisionID: "rev-2"}},
+	}, nil).AnyTimes()
+	s.backend.EXPECT().ListSecretRevisions(s.uri2).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 1, ValueRef: &coresecrets.ValueRef{BackendID: oldBackendID, RevisionID: "old-rev-1"}},
+	}, nil).AnyTimes()
+}
+
+// expectLeader sets up mariadb/1 as the leader of mariadb, able to
+// manage the mariadb owned secret.
+func (s *facadeSuite) expectLeader(tokenErr error) {
+	s.leadershipReader.EXPECT().Leaders().Return(map[string]string{"mariadb": "mariadb/1"}, nil)
+	s.backend.EXPECT().GetSecret(s.uri1).Return(&coresecrets.SecretMetadata{
+		URI: s.uri1, OwnerTag: "application-mariadb",
+	}, nil)
+	leader := names.NewUnitTag("mariadb/1")
+	s.secretsConsumer.EXPECT().SecretAccess(s.uri1, leader).Return(coresecrets.RoleView, nil)
+	s.secretsConsumer.EXPECT().SecretAccess(s.uri1, names.NewApplicationTag("mariadb")).Return(coresecrets.RoleManage, nil)
+	s.leadershipChecker.EXPECT().LeadershipCheck("mariadb", "mariadb/1").Return(s.token)
+	s.token.EXPECT().Check().Return(tokenErr).AnyTimes()
+}
+
+func (s *facadeSuite) TestNewFacadeNotController(c *gc.C) {
+	_, err := secretsmigration.NewFacade(
+		nil, nil, apiservertesting.FakeAuthorizer{}, controllerBackendID, nil, nil, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestWatchSecretsMigration(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.backend.EXPECT().WatchSecretsMigration().Return(apiservertesting.NewFakeNotifyWatcher())
+
+	result, err := s.facade.WatchSecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *facadeSuite) TestGetSecretsMigration(c *gc.C) {
+	defer s.setup(c).Finish()
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.EXPECT().SecretsMigration().Return(&state.SecretsMigration{
+		SecretsMigrationParams: state.SecretsMigrationParams{
+			BackendID:   newBackendID,
+			BackendName: "new",
+			Total:       2,
+		},
+		Status:  state.SecretsMigrationPending,
+		Started: started,
+		Updated: started,
+	}, nil)
+	s.expectSecrets()
+
+	result, err := s.facade.GetSecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretsMigrationResult{
+		BackendID:   newBackendID,
+		BackendName: "new",
+		Status:      "pending",
+		Total:       2,
+		Started:     started,
+		Updated:     started,
+		Revisions: []params.SecretsMigrationRevision{
+			{URI: s.uri1.String(), Revision: 1},
+			{URI: s.uri2.String(), Revision: 1},
+		},
+	})
+}
+
+func (s *facadeSuite) TestGetSecretsMigrationFinished(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.backend.EXPECT().SecretsMigration().Return(&state.SecretsMigration{
+		SecretsMigrationParams: state.SecretsMigrationParams{
+			BackendID:   newBackendID,
+			BackendName: "new",
+			Total:       2,
+		},
+		Status: state.SecretsMigrationCompleted,
+		Moved:  2,
+	}, nil)
+
+	result, err := s.facade.GetSecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status, gc.Equals, "completed")
+	c.Assert(result.Revisions, gc.HasLen, 0)
+}
+
+func (s *facadeSuite) TestGetSecretsMigrationNotFound(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.backend.EXPECT().SecretsMigration().Return(nil, errors.NotFoundf("secrets migration"))
+
+	result, err := s.facade.GetSecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *facadeSuite) TestMigrateSecretRevisionsToExternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectLeader(nil)
+
+	s.provider.EXPECT().Initialise(gomock.Any()).DoAndReturn(func(cfg *provider.ModelBackendConfig) error {
+		c.Check(cfg.BackendType, gc.Equals, "new")
+		return nil
+	})
+	s.backend.EXPECT().GetSecretValue(s.uri1, 1).Return(fooValue, nil, nil)
+	s.newBackend.EXPECT().SaveContent(gomock.Any(), s.uri1, 1, fooValue).Return("rev-1", nil)
+	s.newBackend.EXPECT().GetContent(gomock.Any(), "rev-1").Return(fooValue, nil)
+	s.backend.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Check(arg.URI, jc.DeepEquals, s.uri1)
+		c.Check(arg.Revision, gc.Equals, 1)
+		c.Check(arg.ValueRef, jc.DeepEquals, &coresecrets.ValueRef{BackendID: newBackendID, RevisionID: "rev-1"})
+		c.Check(arg.Data, gc.HasLen, 0)
+		// The write is checked against the leadership of mariadb/1.
+		c.Check(arg.Token, gc.Equals, s.token)
+		return nil
+	})
+
+	result, err := s.facade.MigrateSecretRevisions(params.MigrateSecretRevisionsArgs{
+		BackendID: newBackendID,
+		Revisions: []params.SecretsMigrationRevision{{URI: s.uri1.String(), Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Checksum, gc.HasLen, 64)
+	result.Results[0].Checksum = ""
+	c.Check(result.Results[0], jc.DeepEquals, params.MigrateSecretRevisionResult{
+		URI:         s.uri1.String(),
+		Revision:    1,
+		ToBackendID: newBackendID,
+	})
+}
+
+func (s *facadeSuite) TestMigrateSecretRevisionsNotLeader(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectLeader(leadership.NewNotLeaderError("mariadb/1", "mariadb"))
+	s.provider.EXPECT().Initialise(gomock.Any()).Return(nil)
+
+	result, err := s.facade.MigrateSecretRevisions(params.MigrateSecretRevisionsArgs{
+		BackendID: newBackendID,
+		Revisions: []params.SecretsMigrationRevision{{URI: s.uri1.String(), Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `changing secret .*/1: .*not leader.*`)
+}
+
+func (s *facadeSuite) TestMigrateSecretRevisionsNoLeader(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.leadershipReader.EXPECT().Leaders().Return(map[string]string{}, nil)
+	s.backend.EXPECT().GetSecret(s.uri1).Return(&coresecrets.SecretMetadata{
+		URI: s.uri1, OwnerTag: "application-mariadb",
+	}, nil)
+	s.provider.EXPECT().Initialise(gomock.Any()).Return(nil)
+
+	result, err := s.facade.MigrateSecretRevisions(params.MigrateSecretRevisionsArgs{
+		BackendID: newBackendID,
+		Revisions: []params.SecretsMigrationRevision{{URI: s.uri1.String(), Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `changing secret .*/1: leader of application "mariadb" not found`)
+}
+
+func (s *facadeSuite) TestMigrateSecretRevisionsChecksumMismatch(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectLeader(nil)
+
+	s.provider.EXPECT().Initialise(gomock.Any()).Return(nil)
+	s.backend.EXPECT().GetSecretValue(s.uri1, 1).Return(fooValue, nil, nil)
+	s.newBackend.EXPECT().SaveContent(gomock.Any(), s.uri1, 1, fooValue).Return("rev-1", nil)
+	s.newBackend.EXPECT().GetContent(gomock.Any(), "rev-1").Return(
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"}), nil)
+	// The copied content is removed, and the revision left as is.
+	s.newBackend.EXPECT().DeleteContent(gomock.Any(), "rev-1").Return(nil)
+
+	result, err := s.facade.MigrateSecretRevisions(params.MigrateSecretRevisionsArgs{
+		BackendID: newBackendID,
+		Revisions: []params.SecretsMigrationRevision{{URI: s.uri1.String(), Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `verifying secret .*/1: checksum mismatch`)
+}
+
+func (s *facadeSuite) TestMigrateSecretRevisionsToInternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.leadershipReader.EXPECT().Leaders().Return(map[string]string{}, nil)
+	s.backend.EXPECT().GetSecret(s.uri2).Return(&coresecrets.SecretMetadata{
+		URI: s.uri2, OwnerTag: "unit-mysql-0",
+	}, nil)
+	// The owner unit manages its own secret without a leadership check.
+	s.secretsConsumer.EXPECT().SecretAccess(s.uri2, names.NewUnitTag("mysql/0")).Return(coresecrets.RoleManage, nil)
+
+	oldRef := &coresecrets.ValueRef{BackendID: oldBackendID, RevisionID: "old-rev-1"}
+	s.backend.EXPECT().GetSecretValue(s.uri2, 1).Return(nil, oldRef, nil)
+	s.oldBackend.EXPECT().GetContent(gomock.Any(), "old-rev-1").Return(fooValue, nil)
+	s.backend.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Check(arg.ValueRef, gc.IsNil)
+		c.Check(arg.Data, jc.DeepEquals, coresecrets.SecretData{"foo": "YmFy"})
+		return nil
+	})
+	s.backend.EXPECT().GetSecretValue(s.uri2, 1).Return(fooValue, nil, nil)
+	s.oldBackend.EXPECT().DeleteContent(gomock.Any(), "old-rev-1").Return(nil)
+
+	result, err := s.facade.MigrateSecretRevisions(params.MigrateSecretRevisionsArgs{
+		BackendID: controllerBackendID,
+		Revisions: []params.SecretsMigrationRevision{{URI: s.uri2.String(), Revision: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].ToBackendID, gc.Equals, controllerBackendID)
+}
+
+func (s *facadeSuite) TestSetSecretsMigrationProgress(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.backend.EXPECT().SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationFailed,
+		Moved:  1,
+		Failures: []state.SecretsMigrationFailure{{
+			URI: s.uri1.String(), Revision: 1, Message: "boom",
+		}},
+	}).Return(nil)
+
+	result, err := s.facade.SetSecretsMigrationProgress(params.SecretsMigrationProgressArg{
+		Status: "failed",
+		Moved:  1,
+		Failures: []params.SecretsMigrationFailure{{
+			URI: s.uri1.String(), Revision: 1, Message: "boom",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/apiserver/facades/controller/secretsmigration (interfaces: Backend)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/backend_mock.go github.com/juju/juju/apiserver/facades/controller/secretsmigration Backend
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	secrets "github.com/juju/juju/core/secrets"
+	state "github.com/juju/juju/state"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackend is a mock of Backend interface.
+type MockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendMockRecorder
+}
+
+// MockBackendMockRecorder is the mock recorder for MockBackend.
+type MockBackendMockRecorder struct {
+	mock *MockBackend
+}
+
+// NewMockBackend creates a new mock instance.
+func NewMockBackend(ctrl *gomock.Controller) *MockBackend {
+	mock := &MockBackend{ctrl: ctrl}
+	mock.recorder = &MockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
+	return m.recorder
+}
+
+// ChangeSecretBackend mocks base method.
+func (m *MockBackend) ChangeSecretBackend(arg0 state.ChangeSecretBackendParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeSecretBackend", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeSecretBackend indicates an expected call of ChangeSecretBackend.
+func (mr *MockBackendMockRecorder) ChangeSecretBackend(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSecretBackend", reflect.TypeOf((*MockBackend)(nil).ChangeSecretBackend), arg0)
+}
+
+// GetSecret mocks base method.
+func (m *MockBackend) GetSecret(arg0 *secrets.URI) (*secrets.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0)
+	ret0, _ := ret[0].(*secrets.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockBackendMockRecorder) GetSecret(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockBackend)(nil).GetSecret), arg0)
+}
+
+// GetSecretValue mocks base method.
+func (m *MockBackend) GetSecretValue(arg0 *secrets.URI, arg1 int) (secrets.SecretValue, *secrets.ValueRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValue", arg0, arg1)
+	ret0, _ := ret[0].(secrets.SecretValue)
+	ret1, _ := ret[1].(*secrets.ValueRef)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue.
+func (mr *MockBackendMockRecorder) GetSecretValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockBackend)(nil).GetSecretValue), arg0, arg1)
+}
+
+// ListSecretRevisions mocks base method.
+func (m *MockBackend) ListSecretRevisions(arg0 *secrets.URI) ([]*secrets.SecretRevisionMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretRevisions", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretRevisionMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretRevisions indicates an expected call of ListSecretRevisions.
+func (mr *MockBackendMockRecorder) ListSecretRevisions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretRevisions", reflect.TypeOf((*MockBackend)(nil).ListSecretRevisions), arg0)
+}
+
+// ListSecrets mocks base method.
+func (m *MockBackend) ListSecrets(arg0 state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0)
+	ret0, _ := ret[0].([]*secrets.SecretMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockBackendMockRecorder) ListSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockBackend)(nil).ListSecrets), arg0)
+}
+
+// SecretsMigration mocks base method.
+func (m *MockBackend) SecretsMigration() (*state.SecretsMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretsMigration")
+	ret0, _ := ret[0].(*state.SecretsMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretsMigration indicates an expected call of SecretsMigration.
+func (mr *MockBackendMockRecorder) SecretsMigration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretsMigration", reflect.TypeOf((*MockBackend)(nil).SecretsMigration))
+}
+
+// SetSecretsMigrationProgress mocks base method.
+func (m *MockBackend) SetSecretsMigrationProgress(arg0 state.SecretsMigrationProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecretsMigrationProgress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecretsMigrationProgress indicates an expected call of SetSecretsMigrationProgress.
+func (mr *MockBackendMockRecorder) SetSecretsMigrationProgress(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecretsMigrationProgress", reflect.TypeOf((*MockBackend)(nil).SetSecretsMigrationProgress), arg0)
+}
+
+// WatchSecretsMigration mocks base method.
+func (m *MockBackend) WatchSecretsMigration() state.NotifyWatcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSecretsMigration")
+	ret0, _ := ret[0].(state.NotifyWatcher)
+	return ret0
+}
+
+// WatchSecretsMigration indicates an expected call of WatchSecretsMigration.
+func (mr *MockBackendMockRecorder) WatchSecretsMigration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSecretsMigration", reflect.TypeOf((*MockBackend)(nil).WatchSecretsMigration))
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/apiserver/common/secrets (interfaces: SecretsConsumer)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/commonsecrets_mock.go github.com/juju/juju/apiserver/common/secrets SecretsConsumer
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	secrets "github.com/juju/juju/core/secrets"
+	names "github.com/juju/names/v5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSecretsConsumer is a mock of SecretsConsumer interface.
+type MockSecretsConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsConsumerMockRecorder
+}
+
+// MockSecretsConsumerMockRecorder is the mock recorder for MockSecretsConsumer.
+type MockSecretsConsumerMockRecorder struct {
+	mock *MockSecretsConsumer
+}
+
+// NewMockSecretsConsumer creates a new mock instance.
+func NewMockSecretsConsumer(ctrl *gomock.Controller) *MockSecretsConsumer {
+	mock := &MockSecretsConsumer{ctrl: ctrl}
+	mock.recorder = &MockSecretsConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsConsumer) EXPECT() *MockSecretsConsumerMockRecorder {
+	return m.recorder
+}
+
+// SecretAccess mocks base method.
+func (m *MockSecretsConsumer) SecretAccess(arg0 *secrets.URI, arg1 names.Tag) (secrets.SecretRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretAccess", arg0, arg1)
+	ret0, _ := ret[0].(secrets.SecretRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretAccess indicates an expected call of SecretAccess.
+func (mr *MockSecretsConsumerMockRecorder) SecretAccess(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretAccess", reflect.TypeOf((*MockSecretsConsumer)(nil).SecretAccess), arg0, arg1)
+}
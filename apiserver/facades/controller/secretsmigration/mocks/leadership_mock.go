@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/core/leadership (interfaces: Checker,Reader,Token)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/leadership_mock.go github.com/juju/juju/core/leadership Checker,Reader,Token
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	leadership "github.com/juju/juju/core/leadership"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChecker is a mock of Checker interface.
+type MockChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckerMockRecorder
+}
+
+// MockCheckerMockRecorder is the mock recorder for MockChecker.
+type MockCheckerMockRecorder struct {
+	mock *MockChecker
+}
+
+// NewMockChecker creates a new mock instance.
+func NewMockChecker(ctrl *gomock.Controller) *MockChecker {
+	mock := &MockChecker{ctrl: ctrl}
+	mock.recorder = &MockCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecker) EXPECT() *MockCheckerMockRecorder {
+	return m.recorder
+}
+
+// LeadershipCheck mocks base method.
+func (m *MockChecker) LeadershipCheck(arg0, arg1 string) leadership.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeadershipCheck", arg0, arg1)
+	ret0, _ := ret[0].(leadership.Token)
+	return ret0
+}
+
+// LeadershipCheck indicates an expected call of LeadershipCheck.
+func (mr *MockCheckerMockRecorder) LeadershipCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeadershipCheck", reflect.TypeOf((*MockChecker)(nil).LeadershipCheck), arg0, arg1)
+}
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Leaders mocks base method.
+func (m *MockReader) Leaders() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leaders")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leaders indicates an expected call of Leaders.
+func (mr *MockReaderMockRecorder) Leaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leaders", reflect.TypeOf((*MockReader)(nil).Leaders))
+}
+
+// MockToken is a mock of Token interface.
+type MockToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenMockRecorder
+}
+
+// MockTokenMockRecorder is the mock recorder for MockToken.
+type MockTokenMockRecorder struct {
+	mock *MockToken
+}
+
+// NewMockToken creates a new mock instance.
+func NewMockToken(ctrl *gomock.Controller) *MockToken {
+	mock := &MockToken{ctrl: ctrl}
+	mock.recorder = &MockTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockToken) EXPECT() *MockTokenMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockToken) Check() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockTokenMockRecorder) Check() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockToken)(nil).Check))
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+)
+
+// Register is called to expose a package of facades onto a given registry.
+func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("SecretsMigration", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacade(ctx)
+	}, reflect.TypeOf((*Facade)(nil)))
+}
+
+// newFacade provides the required signature for facade registration.
+func newFacade(ctx facade.Context) (*Facade, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	leadershipChecker, err := ctx.LeadershipChecker()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	leadershipReader, err := ctx.LeadershipReader(model.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return commonsecrets.AdminBackendConfigInfo(commonsecrets.SecretsModel(model))
+	}
+	return NewFacade(
+		backendShim{SecretsStore: state.NewSecrets(st), st: st},
+		ctx.Resources(),
+		ctx.Auth(),
+		model.ControllerUUID(),
+		st,
+		leadershipChecker,
+		leadershipReader,
+		adminConfigGetter,
+	)
+}
@@ -0,0 +1,29 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// backendShim wraps a *State and its secrets to implement Backend.
+type backendShim struct {
+	state.SecretsStore
+	st *state.State
+}
+
+// SecretsMigration is part of the Backend interface.
+func (shim backendShim) SecretsMigration() (*state.SecretsMigration, error) {
+	return shim.st.SecretsMigration()
+}
+
+// SetSecretsMigrationProgress is part of the Backend interface.
+func (shim backendShim) SetSecretsMigrationProgress(p state.SecretsMigrationProgress) error {
+	return shim.st.SetSecretsMigrationProgress(p)
+}
+
+// WatchSecretsMigration is part of the Backend interface.
+func (shim backendShim) WatchSecretsMigration() state.NotifyWatcher {
+	return shim.st.WatchSecretsMigration()
+}
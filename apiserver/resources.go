@@ -45,6 +45,10 @@ type ResourcesBackend interface {
 type ResourcesHandler struct {
 	StateAuthFunc     func(*http.Request, ...string) (ResourcesBackend, state.PoolHelper, names.Tag, error)
 	ChangeAllowedFunc func(*http.Request) error
+
+	// SigningPolicyFunc returns an error if the model's signing policy
+	// doesn't allow the described resource to be uploaded.
+	SigningPolicyFunc func(req *http.Request, what string) error
 }
 
 // ServeHTTP implements http.Handler.
@@ -117,6 +121,9 @@ func (h *ResourcesHandler) upload(backend ResourcesBackend, req *http.Request, u
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := h.SigningPolicyFunc(req, fmt.Sprintf("resource %q", uploaded.Resource.Name)); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// UpdatePendingResource does the same as SetResource (just calls setResource) except SetResouce just blanks PendingID.
 	var stored resources.Resource
@@ -61,6 +61,7 @@ func (s *ResourcesHandlerSuite) SetUpTest(c *gc.C) {
 	s.handler = &apiserver.ResourcesHandler{
 		StateAuthFunc:     s.authState,
 		ChangeAllowedFunc: func(*http.Request) error { return nil },
+		SigningPolicyFunc: func(*http.Request, string) error { return nil },
 	}
 }
 
@@ -153,6 +154,28 @@ func (s *ResourcesHandlerSuite) TestPutChangeBlocked(c *gc.C) {
 	s.checkResp(c, http.StatusBadRequest, "application/json", string(expected))
 }
 
+func (s *ResourcesHandlerSuite) TestPutSigningPolicyRejected(c *gc.C) {
+	uploadContent := "<some data>"
+	res, _ := newResource(c, "spam", "a-user", content)
+	stored, _ := newResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	s.backend.SetResourceErr = errors.New("unexpected SetResource call")
+
+	var checked string
+	s.handler.SigningPolicyFunc = func(_ *http.Request, what string) error {
+		checked = what
+		return errors.Unauthorizedf("%s is not signed", what)
+	}
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Check(checked, gc.Equals, `resource "spam"`)
+	_, expected := apiFailure(`resource "spam" is not signed`, params.CodeUnauthorized)
+	s.checkResp(c, http.StatusUnauthorized, "application/json", expected)
+}
+
 func (s *ResourcesHandlerSuite) TestPutSuccessDockerResource(c *gc.C) {
 	uploadContent := "<some data>"
 	res := newDockerResource(c, "spam", "a-user", content)
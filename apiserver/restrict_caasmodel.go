@@ -15,6 +15,8 @@ import (
 var commonModelFacadeNames = set.NewStrings(
 	"Action",
 	"ActionPruner",
+	"ActionRollout",
+	"ActionScheduler",
 	"AllWatcher",
 	"Agent",
 	"AgentLifeFlag",
@@ -63,6 +65,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"Secrets",
 	"SecretsManager",
 	"SecretsDrain",
+	"SecretsMigration",
 	"UserSecretsDrain",
 	"SecretBackendsManager",
 	"SecretBackendsRotateWatcher",
@@ -98,6 +101,7 @@ var caasModelFacadeNames = set.NewStrings(
 	// For sidecar applications.
 	"CAASApplication",
 	"CAASApplicationProvisioner",
+	"CAASAutoscaler",
 	"CAASFirewallerSidecar",
 )
 
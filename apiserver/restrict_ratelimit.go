@@ -0,0 +1,46 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/collections/set"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	coreratelimit "github.com/juju/juju/core/ratelimit"
+)
+
+// rateLimitExemptFacadeNames are the facades which are never rate
+// limited. Clients ping to keep their connections alive, so limiting
+// pings would drop connections rather than slow them down.
+var rateLimitExemptFacadeNames = set.NewStrings(
+	"Pinger",
+)
+
+// rateLimitCalls returns a check function, for use with restrictRoot,
+// which rejects calls by the user in the model that exceed the API
+// rate limits. The limiter is fetched for each call so that changes to
+// the controller config apply to existing connections; a nil limiter
+// means calls are not limited.
+func rateLimitCalls(
+	limiter func() *coreratelimit.Limiter,
+	collector *Collector,
+	user, modelUUID string,
+) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		if rateLimitExemptFacadeNames.Contains(facadeName) {
+			return nil
+		}
+		l := limiter()
+		if l == nil {
+			return nil
+		}
+		retryAfter, ok := l.Allow(user, modelUUID, facadeName, methodName)
+		if ok {
+			return nil
+		}
+		collector.RateLimitedRequests.WithLabelValues(modelUUID, facadeName, methodName).Inc()
+		logger.Debugf("rate limiting %s.%s for %q in model %s", facadeName, methodName, user, modelUUID)
+		return apiservererrors.NewRateLimitExceededError(retryAfter)
+	}
+}
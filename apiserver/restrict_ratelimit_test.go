@@ -0,0 +1,64 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/ratelimit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/testing"
+)
+
+type rateLimitSuite struct {
+	testing.BaseSuite
+	clock     *testclock.Clock
+	collector *apiserver.Collector
+	root      rpc.Root
+}
+
+var _ = gc.Suite(&rateLimitSuite{})
+
+func (s *rateLimitSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.collector = apiserver.NewMetricsCollector()
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "*", Method: "*", Count: 1, Period: time.Minute,
+	}}, s.clock)
+	s.root = apiserver.TestingRateLimitedRoot(limiter, s.collector, "bob", "deadbeef")
+}
+
+func (s *rateLimitSuite) TestRateLimited(c *gc.C) {
+	_, err := s.root.FindMethod("Client", 6, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.root.FindMethod("Client", 6, "FullStatus")
+	c.Assert(err, gc.ErrorMatches, `rate limit exceeded, retry after 1m0s`)
+	var rateErr *apiservererrors.RateLimitExceededError
+	c.Assert(errors.As(err, &rateErr), jc.IsTrue)
+	c.Assert(rateErr.RetryAfter(), gc.Equals, time.Minute)
+
+	counter := s.collector.RateLimitedRequests.WithLabelValues("deadbeef", "Client", "FullStatus")
+	c.Assert(testutil.ToFloat64(counter), gc.Equals, 1.0)
+
+	s.clock.Advance(time.Minute)
+	_, err = s.root.FindMethod("Client", 6, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rateLimitSuite) TestPingerExempt(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		_, err := s.root.FindMethod("Pinger", 1, "Ping")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+)
+
+// errRestoreInProgress is returned for calls made while the controller
+// is being restored from a backup.
+const errRestoreInProgress = errors.ConstError("restore in progress")
+
+// rejectDuringRestore returns a check function, for use with
+// restrictRoot, which rejects calls made while the controller is being
+// restored from a backup. Connections made before the restore started
+// are otherwise left open, and are dropped when the controller agent
+// restarts to pick up the restored state. Pings are allowed so that
+// the connection which requested the restore stays up until it is
+// complete.
+func rejectDuringRestore(restoring func() bool) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		if facadeName == "Pinger" || !restoring() {
+			return nil
+		}
+		return errRestoreInProgress
+	}
+}
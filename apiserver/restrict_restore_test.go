@@ -0,0 +1,37 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/testing"
+)
+
+type restrictRestoreSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictRestoreSuite{})
+
+func (s *restrictRestoreSuite) TestRejectedWhileRestoring(c *gc.C) {
+	restoring := true
+	root := apiserver.TestingRestoringRoot(func() bool { return restoring })
+
+	caller, err := root.FindMethod("Client", clientFacadeVersion, "FullStatus")
+	c.Assert(err, gc.ErrorMatches, "restore in progress")
+	c.Assert(caller, gc.IsNil)
+
+	caller, err = root.FindMethod("Pinger", pingerFacadeVersion, "Ping")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+
+	// Calls are allowed again if the restore fails.
+	restoring = false
+	caller, err = root.FindMethod("Client", clientFacadeVersion, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+}
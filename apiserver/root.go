@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/cache"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -292,7 +293,7 @@ func restrictAPIRoot(
 }
 
 // restrictAPIRootDuringMaintenance restricts the API root during
-// maintenance events (upgrade or migration), depending
+// maintenance events (upgrade, restore or migration), depending
 // on the authenticated client.
 func restrictAPIRootDuringMaintenance(
 	srv *Server,
@@ -317,6 +318,12 @@ func restrictAPIRootDuringMaintenance(
 		return nil, errors.Errorf("%s blocked because upgrade is in progress", describeLogin())
 	}
 
+	// All logins but those of controller agents are blocked while the
+	// controller is being restored from a backup.
+	if srv.restoreInProgress() {
+		return nil, errors.Errorf("%s blocked because restore is in progress", describeLogin())
+	}
+
 	// For user logins, we limit access during migrations.
 	if _, ok := authTag.(names.UserTag); ok {
 		switch model.MigrationMode() {
@@ -619,6 +626,15 @@ func (ctx *facadeContext) ControllerDB() (coredatabase.TrackedDB, error) {
 	return db, errors.Trace(err)
 }
 
+// CharmCache is part of the facade.Context interface.
+func (ctx *facadeContext) CharmCache() charmdownloader.CharmCache {
+	// Avoid returning a nil *Cache as a non-nil interface.
+	if ctx.r.shared.charmCache == nil {
+		return nil
+	}
+	return ctx.r.shared.charmCache
+}
+
 // adminRoot dispatches API calls to those available to an anonymous connection
 // which has not logged in, which here is the admin facade.
 type adminRoot struct {
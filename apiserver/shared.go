@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	charmdownloader "github.com/juju/juju/core/charm/downloader"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
@@ -47,6 +48,7 @@ type sharedServerContext struct {
 	logger              loggo.Logger
 	cancel              <-chan struct{}
 	charmhubHTTPClient  facade.HTTPClient
+	charmCache          *charmdownloader.Cache
 	dbGetter            coredatabase.DBGetter
 
 	configMutex      sync.RWMutex
@@ -66,6 +68,7 @@ type sharedServerConfig struct {
 	controllerConfig    jujucontroller.Config
 	logger              loggo.Logger
 	charmhubHTTPClient  facade.HTTPClient
+	charmCache          *charmdownloader.Cache
 	dbGetter            coredatabase.DBGetter
 }
 
@@ -111,6 +114,7 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		logger:              config.logger,
 		controllerConfig:    config.controllerConfig,
 		charmhubHTTPClient:  config.charmhubHTTPClient,
+		charmCache:          config.charmCache,
 		dbGetter:            config.dbGetter,
 	}
 	ctx.features = config.controllerConfig.Features()
@@ -150,6 +154,10 @@ func (c *sharedServerContext) onConfigChanged(topic string, data controller.Conf
 	if removed.Size() != 0 || added.Size() != 0 {
 		c.logger.Infof("updating features to %v", values)
 	}
+
+	if c.charmCache != nil {
+		c.charmCache.SetMaxSize(charmCacheSize(data.Config))
+	}
 }
 
 func (c *sharedServerContext) featureEnabled(flag string) bool {
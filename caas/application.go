@@ -9,8 +9,10 @@ import (
 	"github.com/juju/version/v2"
 	core "k8s.io/api/core/v1"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
@@ -48,9 +50,34 @@ type Application interface {
 	// Service returns the service associated with the application.
 	Service() (*Service, error)
 
+	// Utilization returns the average resource usage of the
+	// application's units, as a percentage of what they request.
+	Utilization() (application.Utilization, error)
+
+	// EnsureNetworkPolicy restricts ingress to the application's pods
+	// to that allowed by the rules.
+	EnsureNetworkPolicy(rules []NetworkPolicyRule) error
+
+	// DeleteNetworkPolicy removes any restriction on ingress to the
+	// application's pods.
+	DeleteNetworkPolicy() error
+
 	ServiceInterface
 }
 
+// NetworkPolicyRule allows the pods of an application, or the given
+// networks, to connect to an application's pods on the given ports.
+type NetworkPolicyRule struct {
+	// FromApplication is the application whose pods may connect.
+	FromApplication string
+
+	// FromCIDRs are the networks that may connect.
+	FromCIDRs []string
+
+	// PortRanges are the ports that may be connected to.
+	PortRanges []network.PortRange
+}
+
 // ServicePort represents service ports mapping from service to units.
 type ServicePort struct {
 	Name       string `json:"name"`
@@ -141,6 +168,16 @@ type ApplicationConfig struct {
 
 	// Rootless is true if the application should be run without root priviledges.
 	Rootless bool
+
+	// MaxUnavailable is the number or percentage of units that may be
+	// unavailable during voluntary disruptions, such as node drains.
+	// If empty, the application has no disruption budget.
+	MaxUnavailable string
+
+	// SpreadBy is the topology domain (node, zone or region) the
+	// application's units are spread across. If empty, units are not
+	// spread.
+	SpreadBy string
 }
 
 // ContainerConfig describes a container that is deployed alonside the uniter/charm container.
@@ -87,6 +87,11 @@ type app struct {
 	randomPrefix utils.RandomPrefixFunc
 
 	newApplier func() resources.Applier
+
+	// getPodMetrics returns the resource metrics API's list of the
+	// application's pod metrics. If nil, the metrics are fetched from
+	// the cluster.
+	getPodMetrics func(context.Context) ([]byte, error)
 }
 
 // NewApplication returns an application.
@@ -391,6 +396,10 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		return errors.NotSupportedf("unknown deployment type")
 	}
 
+	if err := a.applyDisruptionBudget(applier, config); err != nil {
+		return errors.Annotate(err, "configuring pod disruption budget")
+	}
+
 	return applier.Run(context.Background(), a.client, false)
 }
 
@@ -989,6 +998,8 @@ func (a *app) Delete() error {
 	default:
 		return errors.NotSupportedf("unknown deployment type")
 	}
+	applier.Delete(resources.NewPodDisruptionBudget(a.name, a.namespace, nil))
+	applier.Delete(resources.NewNetworkPolicy(a.name, a.namespace, nil))
 	applier.Delete(resources.NewService(a.name, a.namespace, nil))
 	applier.Delete(resources.NewSecret(a.secretName(), a.namespace, nil))
 	applier.Delete(resources.NewRoleBinding(a.serviceAccountName(), a.namespace, nil))
@@ -1657,6 +1668,10 @@ func (a *app) ApplicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 	if err != nil {
 		return nil, errors.Annotate(err, "processing constraints")
 	}
+	spec.TopologySpreadConstraints, err = a.topologySpreadConstraints(config.SpreadBy)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	if config.Rootless {
 		spec.SecurityContext = &corev1.PodSecurityContext{
 			FSGroup:            pointer.Int64(constants.JujuFSGroupID),
@@ -1233,6 +1233,8 @@ func (s *applicationSuite) TestDeleteStateful(c *gc.C) {
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewStatefulSet("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab-endpoints", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
@@ -1251,6 +1253,8 @@ func (s *applicationSuite) TestDeleteStateless(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDeployment("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
@@ -1269,6 +1273,8 @@ func (s *applicationSuite) TestDeleteDaemon(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDaemonSet("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
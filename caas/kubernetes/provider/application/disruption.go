@@ -0,0 +1,92 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+// topologyKeys maps the topology domains an application's units can be
+// spread across to the well known node labels identifying them.
+var topologyKeys = map[string]string{
+	"node":   corev1.LabelHostname,
+	"zone":   corev1.LabelTopologyZone,
+	"region": corev1.LabelTopologyRegion,
+}
+
+// ParseMaxUnavailable parses the number ("1") or percentage ("25%") of
+// units that may be unavailable during voluntary disruptions.
+func ParseMaxUnavailable(value string) (intstr.IntOrString, error) {
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		n, err := strconv.Atoi(percent)
+		if err != nil || n < 1 || n > 100 {
+			return intstr.IntOrString{}, errors.NotValidf("max unavailable %q, expected a percentage between 1%% and 100%%", value)
+		}
+		return intstr.FromString(value), nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return intstr.IntOrString{}, errors.NotValidf("max unavailable %q, expected a positive number or percentage", value)
+	}
+	return intstr.FromInt32(int32(n)), nil
+}
+
+// applyDisruptionBudget ensures the application has a pod disruption
+// budget limiting how many of its units voluntary disruptions, such as
+// node drains, can take offline at once. The budget is removed if
+// config has no max unavailable.
+func (a *app) applyDisruptionBudget(applier resources.Applier, config caas.ApplicationConfig) error {
+	if config.MaxUnavailable == "" {
+		applier.Delete(resources.NewPodDisruptionBudget(a.name, a.namespace, nil))
+		return nil
+	}
+	maxUnavailable, err := ParseMaxUnavailable(config.MaxUnavailable)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applier.Apply(resources.NewPodDisruptionBudget(a.name, a.namespace, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      a.labels(),
+			Annotations: a.annotations(config),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+		},
+	}))
+	return nil
+}
+
+// topologySpreadConstraints returns the constraints spreading the
+// application's units across the given topology domain. Units are
+// scheduled even when they can't be spread evenly.
+func (a *app) topologySpreadConstraints(spreadBy string) ([]corev1.TopologySpreadConstraint, error) {
+	if spreadBy == "" {
+		return nil, nil
+	}
+	key, ok := topologyKeys[spreadBy]
+	if !ok {
+		return nil, errors.NotValidf("spread by %q, expected one of node, zone or region", spreadBy)
+	}
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       key,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: a.selectorLabels(),
+		},
+	}}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *applicationSuite) disruptionConfig(maxUnavailable, spreadBy string) caas.ApplicationConfig {
+	return caas.ApplicationConfig{
+		AgentVersion:       version.MustParse(defaultAgentVersion),
+		AgentImagePath:     "operator/image-path:1.1.1",
+		CharmBaseImagePath: "ubuntu@22.04",
+		InitialScale:       3,
+		MaxUnavailable:     maxUnavailable,
+		SpreadBy:           spreadBy,
+	}
+}
+
+func (s *applicationSuite) TestEnsureDisruptionBudget(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(s.disruptionConfig("25%", "")), jc.ErrorIsNil)
+
+	pdb, err := s.client.PolicyV1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pdb.Labels, jc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	maxUnavailable := intstr.FromString("25%")
+	c.Assert(pdb.Spec.MaxUnavailable, jc.DeepEquals, &maxUnavailable)
+	c.Assert(pdb.Spec.Selector, jc.DeepEquals, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+	})
+
+	// Changing the budget updates it.
+	c.Assert(app.Ensure(s.disruptionConfig("1", "")), jc.ErrorIsNil)
+	pdb, err = s.client.PolicyV1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	maxUnavailable = intstr.FromInt32(1)
+	c.Assert(pdb.Spec.MaxUnavailable, jc.DeepEquals, &maxUnavailable)
+
+	// Unsetting the budget removes it.
+	c.Assert(app.Ensure(s.disruptionConfig("", "")), jc.ErrorIsNil)
+	_, err = s.client.PolicyV1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+}
+
+func (s *applicationSuite) TestEnsureDisruptionBudgetInvalid(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+	for _, value := range []string{"0", "-1", "foo", "0%", "101%", "1.5"} {
+		err := app.Ensure(s.disruptionConfig(value, ""))
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("max unavailable %q", value))
+	}
+}
+
+func (s *applicationSuite) TestEnsureSpreadBy(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	c.Assert(app.Ensure(s.disruptionConfig("", "zone")), jc.ErrorIsNil)
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ss.Spec.Template.Spec.TopologySpreadConstraints, jc.DeepEquals, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+	}})
+}
+
+func (s *applicationSuite) TestEnsureSpreadByInvalid(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.Ensure(s.disruptionConfig("", "rack"))
+	c.Assert(err, gc.ErrorMatches, `generating application podspec: spread by "rack", expected one of node, zone or region not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
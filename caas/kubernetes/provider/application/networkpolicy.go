@@ -0,0 +1,109 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/network"
+)
+
+// EnsureNetworkPolicy restricts ingress to the application's pods to
+// that allowed by the rules.
+func (a *app) EnsureNetworkPolicy(rules []caas.NetworkPolicyRule) error {
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	for _, rule := range rules {
+		ports := networkPolicyPorts(rule.PortRanges)
+		if len(ports) == 0 {
+			// A rule without ports would allow ingress on any port.
+			continue
+		}
+		var from []networkingv1.NetworkPolicyPeer
+		if rule.FromApplication != "" {
+			from = append(from, networkingv1.NetworkPolicyPeer{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: utils.SelectorLabelsForApp(rule.FromApplication, a.legacyLabels),
+				},
+			})
+		}
+		for _, cidr := range rule.FromCIDRs {
+			from = append(from, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+		if len(from) == 0 {
+			continue
+		}
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  from,
+			Ports: ports,
+		})
+	}
+
+	applier := a.newApplier()
+	applier.Apply(resources.NewNetworkPolicy(a.name, a.namespace, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: a.labels(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}))
+	err := applier.Run(context.Background(), a.client, false)
+	return errors.Annotatef(err, "ensuring network policy for %q", a.name)
+}
+
+// DeleteNetworkPolicy removes any restriction on ingress to the
+// application's pods.
+func (a *app) DeleteNetworkPolicy() error {
+	applier := a.newApplier()
+	applier.Delete(resources.NewNetworkPolicy(a.name, a.namespace, nil))
+	err := applier.Run(context.Background(), a.client, false)
+	return errors.Annotatef(err, "deleting network policy for %q", a.name)
+}
+
+// networkPolicyPorts converts port ranges to network policy ports. Port
+// ranges of protocols network policies don't support, such as ICMP, are
+// skipped.
+func networkPolicyPorts(portRanges []network.PortRange) []networkingv1.NetworkPolicyPort {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, pr := range portRanges {
+		var protocol corev1.Protocol
+		switch strings.ToLower(pr.Protocol) {
+		case "tcp":
+			protocol = corev1.ProtocolTCP
+		case "udp":
+			protocol = corev1.ProtocolUDP
+		case "sctp":
+			protocol = corev1.ProtocolSCTP
+		default:
+			continue
+		}
+		port := intstr.FromInt32(int32(pr.FromPort))
+		policyPort := networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		}
+		if pr.ToPort > pr.FromPort {
+			endPort := int32(pr.ToPort)
+			policyPort.EndPort = &endPort
+		}
+		ports = append(ports, policyPort)
+	}
+	return ports
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"context"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/network"
+)
+
+func (s *applicationSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.EnsureNetworkPolicy([]caas.NetworkPolicyRule{{
+		FromApplication: "postgresql",
+		PortRanges: []network.PortRange{
+			network.MustParsePortRange("8080/tcp"),
+			network.MustParsePortRange("9000-9010/udp"),
+			// Network policies don't support ICMP.
+			network.MustParsePortRange("icmp"),
+		},
+	}, {
+		FromCIDRs:  []string{"0.0.0.0/0", "::/0"},
+		PortRanges: []network.PortRange{network.MustParsePortRange("443/tcp")},
+	}, {
+		// Rules without ports are ignored.
+		FromApplication: "redis",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	port8080, port9000, port443 := intstr.FromInt32(8080), intstr.FromInt32(9000), intstr.FromInt32(443)
+	np, err := s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(np.Labels, jc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":       "gitlab",
+		"app.kubernetes.io/managed-by": "juju",
+	})
+	c.Assert(np.Spec, jc.DeepEquals, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "postgresql"},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{{
+				Protocol: &tcp,
+				Port:     &port8080,
+			}, {
+				Protocol: &udp,
+				Port:     &port9000,
+				EndPort:  pointer.Int32(9010),
+			}},
+		}, {
+			From: []networkingv1.NetworkPolicyPeer{{
+				IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0"},
+			}, {
+				IPBlock: &networkingv1.IPBlock{CIDR: "::/0"},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{{
+				Protocol: &tcp,
+				Port:     &port443,
+			}},
+		}},
+	})
+
+	// Removed rules are removed from the policy, denying all ingress.
+	err = app.EnsureNetworkPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	np, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(np.Spec.Ingress, gc.HasLen, 0)
+	c.Assert(np.Spec.PolicyTypes, jc.DeepEquals, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress})
+}
+
+func (s *applicationSuite) TestDeleteNetworkPolicy(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	err := app.EnsureNetworkPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.DeleteNetworkPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.NetworkingV1().NetworkPolicies("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+
+	// Deleting a missing policy is not an error.
+	err = app.DeleteNetworkPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -4,6 +4,7 @@
 package application
 
 import (
+	"context"
 	"testing"
 
 	"github.com/juju/clock"
@@ -52,6 +53,22 @@ func NewApplicationForTest(
 	)
 }
 
+// NewApplicationWithMetricsForTest returns an application whose pod
+// metrics are returned by getPodMetrics.
+func NewApplicationWithMetricsForTest(
+	name string,
+	namespace string,
+	client kubernetes.Interface,
+	getPodMetrics func(context.Context) ([]byte, error),
+) caas.Application {
+	a := newApplication(
+		name, namespace, "deadbeef", namespace, false, caas.DeploymentStateful,
+		client, nil, clock.WallClock, nil, nil,
+	)
+	a.getPodMetrics = getPodMetrics
+	return a
+}
+
 func PVCNames(client kubernetes.Interface, namespace, appName, storagePrefix string) (map[string]string, error) {
 	a := &app{
 		name:      appName,
@@ -0,0 +1,153 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	coreapplication "github.com/juju/juju/core/application"
+)
+
+// metricsAPIPath is the path of the Kubernetes resource metrics API,
+// served by the metrics server.
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// podMetricsList is a list of pod resource usage, as reported by the
+// resource metrics API.
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+// podMetrics is the resource usage of a pod's containers.
+type podMetrics struct {
+	Metadata   metav1.ObjectMeta  `json:"metadata"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// Utilization returns the average resource usage of the application's
+// units, as a percentage of the resources they request. Units request
+// resources with the cpu-power and mem constraints.
+func (a *app) Utilization() (coreapplication.Utilization, error) {
+	ctx := context.Background()
+	var result coreapplication.Utilization
+
+	pods, err := resources.ListPods(ctx, a.client, a.namespace, metav1.ListOptions{
+		LabelSelector: a.labelSelector(),
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	metrics, err := a.podMetrics(ctx)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	usageByPod := make(map[string]corev1.ResourceList)
+	for _, m := range metrics {
+		usage := make(corev1.ResourceList)
+		for _, c := range m.Containers {
+			addResources(usage, c.Usage)
+		}
+		usageByPod[m.Metadata.Name] = usage
+	}
+
+	var (
+		cpuUsage, cpuRequested resource.Quantity
+		memUsage, memRequested resource.Quantity
+	)
+	for _, p := range pods {
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+		usage, ok := usageByPod[p.Name]
+		if !ok {
+			// The pod hasn't been measured yet.
+			continue
+		}
+		result.Units++
+		requested := podRequests(p.Spec)
+		if q, ok := requested[corev1.ResourceCPU]; ok && !q.IsZero() {
+			cpuRequested.Add(q)
+			cpuUsage.Add(usage[corev1.ResourceCPU])
+		}
+		if q, ok := requested[corev1.ResourceMemory]; ok && !q.IsZero() {
+			memRequested.Add(q)
+			memUsage.Add(usage[corev1.ResourceMemory])
+		}
+	}
+	if !cpuRequested.IsZero() {
+		cpu := 100 * float64(cpuUsage.MilliValue()) / float64(cpuRequested.MilliValue())
+		result.CPU = &cpu
+	}
+	if !memRequested.IsZero() {
+		mem := 100 * float64(memUsage.Value()) / float64(memRequested.Value())
+		result.Memory = &mem
+	}
+	return result, nil
+}
+
+// podMetrics returns the resource usage of the application's pods.
+func (a *app) podMetrics(ctx context.Context) ([]podMetrics, error) {
+	getMetrics := a.getPodMetrics
+	if getMetrics == nil {
+		getMetrics = a.fetchPodMetrics
+	}
+	data, err := getMetrics(ctx)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("resource metrics API (is metrics-server installed?)")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "fetching pod metrics")
+	}
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Annotate(err, "parsing pod metrics")
+	}
+	return list.Items, nil
+}
+
+// fetchPodMetrics fetches the resource usage of the application's pods
+// from the resource metrics API.
+func (a *app) fetchPodMetrics(ctx context.Context) ([]byte, error) {
+	return a.client.CoreV1().RESTClient().Get().
+		AbsPath(path.Join(metricsAPIPath, "namespaces", a.namespace, "pods")).
+		Param("labelSelector", a.labelSelector()).
+		DoRaw(ctx)
+}
+
+// podRequests returns the resources requested by the pod's containers.
+// Where a container limits a resource without requesting it, the limit
+// is what Kubernetes requests.
+func podRequests(spec corev1.PodSpec) corev1.ResourceList {
+	requests := make(corev1.ResourceList)
+	for _, c := range spec.Containers {
+		for name, q := range c.Resources.Limits {
+			if _, ok := c.Resources.Requests[name]; !ok {
+				addResources(requests, corev1.ResourceList{name: q})
+			}
+		}
+		addResources(requests, c.Resources.Requests)
+	}
+	return requests
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, q := range add {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
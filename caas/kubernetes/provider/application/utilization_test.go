@@ -0,0 +1,118 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/juju/juju/caas/kubernetes/provider/application"
+)
+
+type utilizationSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&utilizationSuite{})
+
+const podMetricsJSON = `{
+	"kind": "PodMetricsList",
+	"apiVersion": "metrics.k8s.io/v1beta1",
+	"items": [{
+		"metadata": {"name": "gitlab-0", "namespace": "test"},
+		"containers": [
+			{"name": "charm", "usage": {"cpu": "50m", "memory": "64Mi"}},
+			{"name": "gitlab", "usage": {"cpu": "250m", "memory": "192Mi"}}
+		]
+	}, {
+		"metadata": {"name": "gitlab-1", "namespace": "test"},
+		"containers": [
+			{"name": "charm", "usage": {"cpu": "10m", "memory": "32Mi"}},
+			{"name": "gitlab", "usage": {"cpu": "90m", "memory": "96Mi"}}
+		]
+	}]
+}`
+
+func (s *utilizationSuite) addPod(c *gc.C, client *fake.Clientset, name string, resources corev1.ResourceRequirements) {
+	_, err := client.CoreV1().Pods("test").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "charm",
+			}, {
+				Name:      "gitlab",
+				Resources: resources,
+			}},
+		},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *utilizationSuite) TestUtilization(c *gc.C) {
+	client := fake.NewSimpleClientset()
+	// Pods request resources with the cpu-power and mem constraints.
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	s.addPod(c, client, "gitlab-0", resources)
+	s.addPod(c, client, "gitlab-1", resources)
+	// Not yet measured by the metrics server.
+	s.addPod(c, client, "gitlab-2", resources)
+
+	app := application.NewApplicationWithMetricsForTest("gitlab", "test", client, func(context.Context) ([]byte, error) {
+		return []byte(podMetricsJSON), nil
+	})
+	usage, err := app.Utilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.Equals, 2)
+	c.Assert(usage.CPU, gc.NotNil)
+	c.Assert(*usage.CPU, gc.Equals, 40.0)
+	c.Assert(usage.Memory, gc.NotNil)
+	c.Assert(*usage.Memory, gc.Equals, 37.5)
+}
+
+func (s *utilizationSuite) TestUtilizationLimitsOnly(c *gc.C) {
+	client := fake.NewSimpleClientset()
+	s.addPod(c, client, "gitlab-0", corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	})
+
+	app := application.NewApplicationWithMetricsForTest("gitlab", "test", client, func(context.Context) ([]byte, error) {
+		return []byte(podMetricsJSON), nil
+	})
+	usage, err := app.Utilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.Equals, 1)
+	c.Assert(usage.CPU, gc.IsNil)
+	c.Assert(usage.Memory, gc.NotNil)
+	c.Assert(*usage.Memory, gc.Equals, 25.0)
+}
+
+func (s *utilizationSuite) TestUtilizationNoMetricsServer(c *gc.C) {
+	client := fake.NewSimpleClientset()
+	app := application.NewApplicationWithMetricsForTest("gitlab", "test", client, func(context.Context) ([]byte, error) {
+		return nil, k8serrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, "")
+	})
+	_, err := app.Utilization()
+	c.Assert(err, gc.ErrorMatches, `resource metrics API \(is metrics-server installed\?\) not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/application"
 )
 
 const (
@@ -28,6 +31,9 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	MaxUnavailableConfigKey = "kubernetes-max-unavailable"
+	SpreadByConfigKey       = "kubernetes-spread-by"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +92,17 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	MaxUnavailableConfigKey: {
+		Description: "number or percentage of units that may be unavailable during voluntary disruptions such as node drains",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	SpreadByConfigKey: {
+		Description: "topology domain (node, zone or region) to spread units across",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+		Values:      []interface{}{"node", "zone", "region"},
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +112,8 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	MaxUnavailableConfigKey:  schema.Omit,
+	SpreadByConfigKey:        schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
@@ -108,3 +127,14 @@ func ConfigSchema() environschema.Fields {
 func ConfigDefaults() schema.Defaults {
 	return schemaDefaults
 }
+
+// ValidateConfig returns an error if the kubernetes specific attributes
+// of an application config aren't valid.
+func ValidateConfig(attrs map[string]interface{}) error {
+	if v, _ := attrs[MaxUnavailableConfigKey].(string); v != "" {
+		if _, err := application.ParseMaxUnavailable(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", MaxUnavailableConfigKey)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestValidateConfig(c *gc.C) {
+	for _, value := range []string{"", "1", "3", "25%", "100%"} {
+		err := provider.ValidateConfig(map[string]interface{}{
+			provider.MaxUnavailableConfigKey: value,
+		})
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("max unavailable %q", value))
+	}
+	c.Assert(provider.ValidateConfig(map[string]interface{}{}), jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateConfigInvalidMaxUnavailable(c *gc.C) {
+	for _, value := range []string{"0", "-1", "foo", "0%", "101%", "1.5"} {
+		err := provider.ValidateConfig(map[string]interface{}{
+			provider.MaxUnavailableConfigKey: value,
+		})
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("max unavailable %q", value))
+		c.Check(err, gc.ErrorMatches, `invalid kubernetes-max-unavailable: .*`)
+	}
+}
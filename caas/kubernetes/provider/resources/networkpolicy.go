@@ -0,0 +1,132 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// NetworkPolicy extends the k8s network policy.
+type NetworkPolicy struct {
+	networkingv1.NetworkPolicy
+}
+
+// NewNetworkPolicy creates a new network policy resource.
+func NewNetworkPolicy(name string, namespace string, in *networkingv1.NetworkPolicy) *NetworkPolicy {
+	if in == nil {
+		in = &networkingv1.NetworkPolicy{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &NetworkPolicy{*in}
+}
+
+// ListNetworkPolicies returns a list of network policies.
+func ListNetworkPolicies(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.ListOptions) ([]NetworkPolicy, error) {
+	api := client.NetworkingV1().NetworkPolicies(namespace)
+	var items []NetworkPolicy
+	for {
+		res, err := api.List(ctx, opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, v := range res.Items {
+			items = append(items, NetworkPolicy{NetworkPolicy: v})
+		}
+		if res.RemainingItemCount == nil || *res.RemainingItemCount == 0 {
+			break
+		}
+		opts.Continue = res.Continue
+	}
+	return items, nil
+}
+
+// Clone returns a copy of the resource.
+func (n *NetworkPolicy) Clone() Resource {
+	clone := *n
+	return &clone
+}
+
+// ID returns a comparable ID for the Resource
+func (n *NetworkPolicy) ID() ID {
+	return ID{"NetworkPolicy", n.Name, n.Namespace}
+}
+
+// Apply creates or replaces the resource. The policy is replaced rather
+// than patched, so that ingress rules no longer wanted are removed.
+func (n *NetworkPolicy) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(n.Namespace)
+	existing, err := api.Get(ctx, n.Name, metav1.GetOptions{})
+	var res *networkingv1.NetworkPolicy
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &n.NetworkPolicy, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	} else if err == nil {
+		policy := n.NetworkPolicy
+		policy.ResourceVersion = existing.ResourceVersion
+		res, err = api.Update(ctx, &policy, metav1.UpdateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if k8serrors.IsConflict(err) {
+		return errors.Annotatef(errConflict, "network policy %q", n.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	n.NetworkPolicy = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (n *NetworkPolicy) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(n.Namespace)
+	res, err := api.Get(ctx, n.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	n.NetworkPolicy = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (n *NetworkPolicy) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.NetworkingV1().NetworkPolicies(n.Namespace)
+	err := api.Delete(ctx, n.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (n *NetworkPolicy) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, n.Namespace, n.Name, "NetworkPolicy")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (n *NetworkPolicy) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if n.DeletionTimestamp != nil {
+		return "", status.Terminated, n.DeletionTimestamp.Time, nil
+	}
+	return "", status.Active, n.CreationTimestamp.Time, nil
+}
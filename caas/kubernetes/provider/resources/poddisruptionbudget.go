@@ -0,0 +1,133 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// PodDisruptionBudget extends the k8s pod disruption budget.
+type PodDisruptionBudget struct {
+	policyv1.PodDisruptionBudget
+}
+
+// NewPodDisruptionBudget creates a new pod disruption budget resource.
+func NewPodDisruptionBudget(name string, namespace string, in *policyv1.PodDisruptionBudget) *PodDisruptionBudget {
+	if in == nil {
+		in = &policyv1.PodDisruptionBudget{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &PodDisruptionBudget{*in}
+}
+
+// ListPodDisruptionBudgets returns a list of pod disruption budgets.
+func ListPodDisruptionBudgets(ctx context.Context, client kubernetes.Interface, namespace string, opts metav1.ListOptions) ([]PodDisruptionBudget, error) {
+	api := client.PolicyV1().PodDisruptionBudgets(namespace)
+	var items []PodDisruptionBudget
+	for {
+		res, err := api.List(ctx, opts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, v := range res.Items {
+			items = append(items, PodDisruptionBudget{PodDisruptionBudget: v})
+		}
+		if res.RemainingItemCount == nil || *res.RemainingItemCount == 0 {
+			break
+		}
+		opts.Continue = res.Continue
+	}
+	return items, nil
+}
+
+// Clone returns a copy of the resource.
+func (p *PodDisruptionBudget) Clone() Resource {
+	clone := *p
+	return &clone
+}
+
+// ID returns a comparable ID for the Resource
+func (p *PodDisruptionBudget) ID() ID {
+	return ID{"PodDisruptionBudget", p.Name, p.Namespace}
+}
+
+// Apply patches the resource change.
+func (p *PodDisruptionBudget) Apply(ctx context.Context, client kubernetes.Interface) error {
+	api := client.PolicyV1().PodDisruptionBudgets(p.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &p.PodDisruptionBudget)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, p.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &p.PodDisruptionBudget, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if k8serrors.IsConflict(err) {
+		return errors.Annotatef(errConflict, "pod disruption budget %q", p.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.PodDisruptionBudget = *res
+	return nil
+}
+
+// Get refreshes the resource.
+func (p *PodDisruptionBudget) Get(ctx context.Context, client kubernetes.Interface) error {
+	api := client.PolicyV1().PodDisruptionBudgets(p.Namespace)
+	res, err := api.Get(ctx, p.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	p.PodDisruptionBudget = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (p *PodDisruptionBudget) Delete(ctx context.Context, client kubernetes.Interface) error {
+	api := client.PolicyV1().PodDisruptionBudgets(p.Namespace)
+	err := api.Delete(ctx, p.Name, metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (p *PodDisruptionBudget) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, p.Namespace, p.Name, "PodDisruptionBudget")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (p *PodDisruptionBudget) ComputeStatus(ctx context.Context, client kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if p.DeletionTimestamp != nil {
+		return "", status.Terminated, p.DeletionTimestamp.Time, nil
+	}
+	return "", status.Active, p.CreationTimestamp.Time, nil
+}
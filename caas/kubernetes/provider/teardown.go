@@ -26,6 +26,8 @@ func (k *kubernetesClient) deleteClusterScopeResourcesModelTeardown(ctx context.
 	)
 
 	// TODO(caas): Fix to only delete cluster wide resources created by this controller.
+	// Namespaced resources, such as the applications' pod disruption budgets,
+	// are deleted along with the model's namespace.
 	tasks := []teardownResources{
 		k.deleteClusterRoleBindingsModelTeardown,
 		k.deleteClusterRolesModelTeardown,
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	caas "github.com/juju/juju/caas"
+	application "github.com/juju/juju/core/application"
 	watcher "github.com/juju/juju/core/watcher"
 	gomock "go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
@@ -71,6 +72,20 @@ func (mr *MockApplicationMockRecorder) Delete() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApplication)(nil).Delete))
 }
 
+// DeleteNetworkPolicy mocks base method.
+func (m *MockApplication) DeleteNetworkPolicy() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkPolicy")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkPolicy indicates an expected call of DeleteNetworkPolicy.
+func (mr *MockApplicationMockRecorder) DeleteNetworkPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).DeleteNetworkPolicy))
+}
+
 // Ensure mocks base method.
 func (m *MockApplication) Ensure(arg0 caas.ApplicationConfig) error {
 	m.ctrl.T.Helper()
@@ -85,6 +100,20 @@ func (mr *MockApplicationMockRecorder) Ensure(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ensure", reflect.TypeOf((*MockApplication)(nil).Ensure), arg0)
 }
 
+// EnsureNetworkPolicy mocks base method.
+func (m *MockApplication) EnsureNetworkPolicy(arg0 []caas.NetworkPolicyRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetworkPolicy indicates an expected call of EnsureNetworkPolicy.
+func (mr *MockApplicationMockRecorder) EnsureNetworkPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).EnsureNetworkPolicy), arg0)
+}
+
 // Exists mocks base method.
 func (m *MockApplication) Exists() (caas.DeploymentState, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +245,21 @@ func (mr *MockApplicationMockRecorder) UpdateService(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockApplication)(nil).UpdateService), arg0)
 }
 
+// Utilization mocks base method.
+func (m *MockApplication) Utilization() (application.Utilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Utilization")
+	ret0, _ := ret[0].(application.Utilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Utilization indicates an expected call of Utilization.
+func (mr *MockApplicationMockRecorder) Utilization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Utilization", reflect.TypeOf((*MockApplication)(nil).Utilization))
+}
+
 // Watch mocks base method.
 func (m *MockApplication) Watch() (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
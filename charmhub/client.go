@@ -118,6 +118,11 @@ func NewClient(config Config) (*Client, error) {
 	if httpClient == nil {
 		httpClient = DefaultHTTPClient(logger)
 	}
+	// A file URL refers to a local mirror directory, which is served
+	// without a Charmhub server.
+	if dir, ok := mirrorDir(url); ok {
+		httpClient = mirrorHTTPClient{mirror: NewMirror(dir)}
+	}
 
 	fs := config.FileSystem
 	if fs == nil {
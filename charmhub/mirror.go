@@ -0,0 +1,782 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// MirrorScheme is the URL scheme of a Charmhub URL which refers to a
+// local mirror directory, rather than a Charmhub server. For example,
+// "file:///var/lib/juju/charmhub-mirror".
+const MirrorScheme = "file"
+
+// A mirror directory holds the following, with download URLs stored
+// relative to the directory, so that it can be moved:
+//
+//	charms/<name>/info.json                              - transport.InfoResponse
+//	charms/<name>/revisions/<revision>.json              - transport.RefreshEntity
+//	charms/<name>/revisions/<revision>.<type>            - charm or bundle archive
+//	charms/<name>/resources/<resource>/<revision>.json   - transport.ResourceRevision
+//	charms/<name>/resources/<resource>/<revision>        - resource content
+const (
+	mirrorCharmsDir    = "charms"
+	mirrorInfoFile     = "info.json"
+	mirrorRevisionsDir = "revisions"
+	mirrorResourcesDir = "resources"
+)
+
+// Mirror serves the Charmhub API from a local directory of mirrored
+// charms, bundles and resources, so that they can be deployed without
+// access to Charmhub.
+//
+// Only the charms and revisions which have been added to the mirror
+// are known to it, and the channels of each are fixed at the time they
+// were added.
+type Mirror struct {
+	dir string
+}
+
+// NewMirror returns a Mirror of the charms in the given directory.
+func NewMirror(dir string) *Mirror {
+	return &Mirror{dir: dir}
+}
+
+// mirrorDir returns the mirror directory referred to by a Charmhub URL,
+// if it is a file URL.
+func mirrorDir(charmhubURL string) (string, bool) {
+	u, err := url.Parse(charmhubURL)
+	if err != nil || u.Scheme != MirrorScheme || u.Path == "" {
+		return "", false
+	}
+	return filepath.FromSlash(u.Path), true
+}
+
+// Dir returns the directory holding the mirror.
+func (m *Mirror) Dir() string {
+	return m.dir
+}
+
+// AddInfo records the information about a charm or bundle. The channel
+// map should only hold the revisions which have been, or are going to
+// be, added to the mirror. Releases already in the mirror, for channels
+// and bases not in the channel map, are kept.
+func (m *Mirror) AddInfo(info transport.InfoResponse) error {
+	if err := validMirrorName(info.Name); err != nil {
+		return errors.Trace(err)
+	}
+	info.ChannelMap = append([]transport.InfoChannelMap(nil), info.ChannelMap...)
+	existing, err := m.info(info.Name)
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		return errors.Trace(err)
+	}
+	released := make(map[string]bool)
+	for _, ch := range info.ChannelMap {
+		released[releaseKey(ch.Channel)] = true
+	}
+	for _, ch := range existing.ChannelMap {
+		if !released[releaseKey(ch.Channel)] {
+			info.ChannelMap = append(info.ChannelMap, ch)
+		}
+	}
+	if info.DefaultRelease.Revision.Revision == 0 {
+		info.DefaultRelease = existing.DefaultRelease
+	}
+	for i, ch := range info.ChannelMap {
+		info.ChannelMap[i].Revision.Download.URL = m.revisionURL(info.Name, ch.Revision.Revision, info.Type)
+	}
+	if info.DefaultRelease.Revision.Revision > 0 {
+		info.DefaultRelease.Revision.Download.URL = m.revisionURL(info.Name, info.DefaultRelease.Revision.Revision, info.Type)
+	}
+	return errors.Trace(m.writeJSON(path.Join(mirrorCharmsDir, info.Name, mirrorInfoFile), info))
+}
+
+// releaseKey identifies the release of a channel for a base.
+func releaseKey(ch transport.Channel) string {
+	return fmt.Sprintf("%s|%s|%s|%s", ch.Name, ch.Base.Architecture, ch.Base.Name, ch.Base.Channel)
+}
+
+// HasRevision returns true if the given revision of a charm or bundle
+// is in the mirror.
+func (m *Mirror) HasRevision(name string, revision int) bool {
+	var entity transport.RefreshEntity
+	if err := m.readJSON(m.revisionMetadataPath(name, revision), &entity); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(m.dir, filepath.FromSlash(entity.Download.URL)))
+	return err == nil
+}
+
+// AddRevision adds a revision of a charm or bundle to the mirror,
+// writing the content of the archive. The archive must match the
+// SHA256 hash in the entity.
+func (m *Mirror) AddRevision(entity transport.RefreshEntity, archive io.Reader) error {
+	if err := validMirrorName(entity.Name); err != nil {
+		return errors.Trace(err)
+	}
+	archivePath := m.revisionURL(entity.Name, entity.Revision, entity.Type)
+	if err := m.writeFile(archivePath, archive, entity.Download.HashSHA256); err != nil {
+		return errors.Annotatef(err, "writing %s %q revision %d", entity.Type, entity.Name, entity.Revision)
+	}
+	entity.Download.URL = archivePath
+	entity.Resources = append([]transport.ResourceRevision(nil), entity.Resources...)
+	for i, res := range entity.Resources {
+		entity.Resources[i].Download.URL = m.resourceURL(entity.Name, res.Name, res.Revision)
+	}
+	return errors.Trace(m.writeJSON(m.revisionMetadataPath(entity.Name, entity.Revision), entity))
+}
+
+// HasResource returns true if the given revision of a charm's
+// resource is in the mirror.
+func (m *Mirror) HasResource(charmName, resourceName string, revision int) bool {
+	_, err := os.Stat(filepath.Join(m.dir, filepath.FromSlash(m.resourceURL(charmName, resourceName, revision))))
+	return err == nil
+}
+
+// AddResource adds a revision of a charm's resource to the mirror,
+// writing its content. The content must match the SHA256 hash in the
+// resource revision.
+func (m *Mirror) AddResource(charmName string, res transport.ResourceRevision, content io.Reader) error {
+	if err := validMirrorName(charmName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validMirrorName(res.Name); err != nil {
+		return errors.Trace(err)
+	}
+	contentPath := m.resourceURL(charmName, res.Name, res.Revision)
+	if err := m.writeFile(contentPath, content, res.Download.HashSHA256); err != nil {
+		return errors.Annotatef(err, "writing resource %q revision %d", res.Name, res.Revision)
+	}
+	res.Download.URL = contentPath
+	return errors.Trace(m.writeJSON(contentPath+".json", res))
+}
+
+func (m *Mirror) revisionMetadataPath(name string, revision int) string {
+	return path.Join(mirrorCharmsDir, name, mirrorRevisionsDir, fmt.Sprintf("%d.json", revision))
+}
+
+func (m *Mirror) revisionURL(name string, revision int, entityType transport.Type) string {
+	return path.Join(mirrorCharmsDir, name, mirrorRevisionsDir, fmt.Sprintf("%d.%s", revision, entityType))
+}
+
+func (m *Mirror) resourceURL(charmName, resourceName string, revision int) string {
+	return path.Join(mirrorCharmsDir, charmName, mirrorResourcesDir, resourceName, strconv.Itoa(revision))
+}
+
+// writeFile writes the content to the path in the mirror, checking
+// that it has the expected SHA256 hash. The file is only replaced once
+// the content has been verified.
+func (m *Mirror) writeFile(name string, content io.Reader, sha256Hash string) error {
+	target := filepath.Join(m.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(target), ".mirror-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, hash), content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if got := fmt.Sprintf("%x", hash.Sum(nil)); sha256Hash != "" && got != sha256Hash {
+		return errors.Errorf("checksum mismatch, expected %q, got %q", sha256Hash, got)
+	}
+	return errors.Trace(os.Rename(f.Name(), target))
+}
+
+func (m *Mirror) writeJSON(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.writeFile(name, bytes.NewReader(data), ""))
+}
+
+func (m *Mirror) readJSON(name string, value interface{}) error {
+	data, err := os.ReadFile(filepath.Join(m.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q in mirror", name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.Unmarshal(data, value))
+}
+
+// validMirrorName ensures that a name can safely be used as part of a
+// path in the mirror.
+func validMirrorName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return errors.NotValidf("name %q", name)
+	}
+	return nil
+}
+
+// ServeHTTP serves the Charmhub API from the mirror, as if the mirror
+// directory were the root of a Charmhub server. The download URLs in
+// responses are made absolute, relative to the request's URL.
+func (m *Mirror) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	base := m.baseURL(req)
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == serverVersion && parts[1] == serverEntity {
+		switch {
+		case parts[2] == "info" && len(parts) == 4 && req.Method == http.MethodGet:
+			m.serveInfo(w, req, base, parts[3])
+			return
+		case parts[2] == "find" && len(parts) == 3 && req.Method == http.MethodGet:
+			m.serveFind(w, req, base)
+			return
+		case parts[2] == "refresh" && len(parts) == 3 && req.Method == http.MethodPost:
+			m.serveRefresh(w, req, base)
+			return
+		case parts[2] == "resources" && len(parts) == 6 && parts[5] == "revisions" && req.Method == http.MethodGet:
+			m.serveResources(w, base, parts[3], parts[4])
+			return
+		}
+	}
+	if len(parts) > 1 && parts[0] == mirrorCharmsDir && req.Method == http.MethodGet {
+		m.serveFile(w, req, path.Join(parts...))
+		return
+	}
+	writeMirrorError(w, http.StatusNotFound, transport.ErrorCodeNotFound, fmt.Sprintf("%s not found", req.URL.Path))
+}
+
+// baseURL returns the URL that relative download URLs are resolved
+// against.
+func (m *Mirror) baseURL(req *http.Request) string {
+	if req.URL.Scheme == MirrorScheme {
+		return (&url.URL{Scheme: MirrorScheme, Path: filepath.ToSlash(m.dir)}).String()
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, req.Host)
+}
+
+func absoluteURL(base, rel string) string {
+	if rel == "" || strings.Contains(rel, "://") {
+		return rel
+	}
+	return strings.TrimRight(base, "/") + "/" + rel
+}
+
+// info returns the information about the named charm or bundle.
+func (m *Mirror) info(name string) (transport.InfoResponse, error) {
+	var info transport.InfoResponse
+	if err := validMirrorName(name); err != nil {
+		return info, errors.NotFoundf("%q", name)
+	}
+	err := m.readJSON(path.Join(mirrorCharmsDir, name, mirrorInfoFile), &info)
+	return info, errors.Trace(err)
+}
+
+// infoByID returns the information about the charm or bundle with the
+// given ID.
+func (m *Mirror) infoByID(id string) (transport.InfoResponse, error) {
+	all, err := m.allInfo()
+	if err != nil {
+		return transport.InfoResponse{}, errors.Trace(err)
+	}
+	for _, info := range all {
+		if info.ID == id {
+			return info, nil
+		}
+	}
+	return transport.InfoResponse{}, errors.NotFoundf("id %q", id)
+}
+
+// allInfo returns the information about each charm and bundle in the
+// mirror, sorted by name.
+func (m *Mirror) allInfo() ([]transport.InfoResponse, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, mirrorCharmsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []transport.InfoResponse
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := m.info(entry.Name())
+		if errors.Is(err, errors.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (m *Mirror) serveInfo(w http.ResponseWriter, req *http.Request, base, name string) {
+	info, err := m.info(name)
+	if errors.Is(err, errors.NotFound) {
+		writeMirrorError(w, http.StatusNotFound, transport.ErrorCodeNotFound, fmt.Sprintf("%s not found", name))
+		return
+	} else if err != nil {
+		writeMirrorError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	if channel := req.URL.Query().Get("channel"); channel != "" {
+		key, err := channelKey(channel)
+		if err != nil {
+			writeMirrorError(w, http.StatusBadRequest, transport.ErrorCodeInvalidChannel, err.Error())
+			return
+		}
+		var channelMap []transport.InfoChannelMap
+		for _, ch := range info.ChannelMap {
+			if k, _ := channelKey(ch.Channel.Name); k == key {
+				channelMap = append(channelMap, ch)
+			}
+		}
+		info.ChannelMap = channelMap
+	}
+	for i := range info.ChannelMap {
+		dl := &info.ChannelMap[i].Revision.Download
+		dl.URL = absoluteURL(base, dl.URL)
+	}
+	info.DefaultRelease.Revision.Download.URL = absoluteURL(base, info.DefaultRelease.Revision.Download.URL)
+	writeMirrorJSON(w, http.StatusOK, info)
+}
+
+func (m *Mirror) serveFind(w http.ResponseWriter, req *http.Request, base string) {
+	query := strings.ToLower(req.URL.Query().Get("q"))
+	all, err := m.allInfo()
+	if err != nil {
+		writeMirrorError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	var resp transport.FindResponses
+	for _, info := range all {
+		if query != "" && !strings.Contains(info.Name, query) && !strings.Contains(strings.ToLower(info.Entity.Summary), query) {
+			continue
+		}
+		release := info.DefaultRelease
+		resp.Results = append(resp.Results, transport.FindResponse{
+			Type:   info.Type,
+			ID:     info.ID,
+			Name:   info.Name,
+			Entity: info.Entity,
+			DefaultRelease: transport.FindChannelMap{
+				Channel: release.Channel,
+				Revision: transport.FindRevision{
+					CreatedAt: release.Revision.CreatedAt,
+					Download:  release.Revision.Download,
+					Bases:     release.Revision.Bases,
+					Revision:  release.Revision.Revision,
+					Version:   release.Revision.Version,
+				},
+			},
+		})
+	}
+	for i := range resp.Results {
+		dl := &resp.Results[i].DefaultRelease.Revision.Download
+		dl.URL = absoluteURL(base, dl.URL)
+	}
+	writeMirrorJSON(w, http.StatusOK, resp)
+}
+
+func (m *Mirror) serveResources(w http.ResponseWriter, base, charmName, resourceName string) {
+	if validMirrorName(charmName) != nil || validMirrorName(resourceName) != nil {
+		writeMirrorError(w, http.StatusNotFound, transport.ErrorCodeNotFound, fmt.Sprintf("resource %q not found", resourceName))
+		return
+	}
+	dir := path.Join(mirrorCharmsDir, charmName, mirrorResourcesDir, resourceName)
+	entries, err := os.ReadDir(filepath.Join(m.dir, filepath.FromSlash(dir)))
+	if os.IsNotExist(err) {
+		writeMirrorError(w, http.StatusNotFound, transport.ErrorCodeNotFound, fmt.Sprintf("resource %q not found", resourceName))
+		return
+	} else if err != nil {
+		writeMirrorError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	var resp transport.ResourcesResponse
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var res transport.ResourceRevision
+		if err := m.readJSON(path.Join(dir, entry.Name()), &res); err != nil {
+			writeMirrorError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+			return
+		}
+		res.Download.URL = absoluteURL(base, res.Download.URL)
+		resp.Revisions = append(resp.Revisions, res)
+	}
+	sort.Slice(resp.Revisions, func(i, j int) bool {
+		return resp.Revisions[i].Revision > resp.Revisions[j].Revision
+	})
+	writeMirrorJSON(w, http.StatusOK, resp)
+}
+
+func (m *Mirror) serveFile(w http.ResponseWriter, req *http.Request, name string) {
+	f, info, err := m.openFile(name)
+	if errors.Is(err, errors.NotFound) {
+		writeMirrorError(w, http.StatusNotFound, transport.ErrorCodeNotFound, err.Error())
+		return
+	} else if err != nil {
+		writeMirrorError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	defer func() { _ = f.Close() }()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, req, info.Name(), info.ModTime(), f)
+}
+
+// openFile opens an archive or resource in the mirror for download.
+func (m *Mirror) openFile(name string) (*os.File, os.FileInfo, error) {
+	clean := path.Clean("/" + name)[1:]
+	if clean != name || !strings.HasPrefix(clean, mirrorCharmsDir+"/") || strings.HasSuffix(clean, ".json") {
+		return nil, nil, errors.NotFoundf("%s", name)
+	}
+	f, err := os.Open(filepath.Join(m.dir, filepath.FromSlash(clean)))
+	if os.IsNotExist(err) {
+		return nil, nil, errors.NotFoundf("%s", name)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		_ = f.Close()
+		return nil, nil, errors.NotFoundf("%s", name)
+	}
+	return f, info, nil
+}
+
+func (m *Mirror) serveRefresh(w http.ResponseWriter, req *http.Request, base string) {
+	var refreshReq transport.RefreshRequest
+	if err := json.NewDecoder(req.Body).Decode(&refreshReq); err != nil {
+		writeMirrorError(w, http.StatusBadRequest, transport.ErrorCodeBadArgument, err.Error())
+		return
+	}
+	contexts := make(map[string]transport.RefreshRequestContext)
+	for _, ctx := range refreshReq.Context {
+		contexts[ctx.InstanceKey] = ctx
+	}
+	resp := transport.RefreshResponses{
+		Results: []transport.RefreshResponse{},
+	}
+	for _, action := range refreshReq.Actions {
+		result := m.refreshAction(action, contexts)
+		if result.Error == nil {
+			result.Entity.Download.URL = absoluteURL(base, result.Entity.Download.URL)
+			for i := range result.Entity.Resources {
+				dl := &result.Entity.Resources[i].Download
+				dl.URL = absoluteURL(base, dl.URL)
+			}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	writeMirrorJSON(w, http.StatusOK, resp)
+}
+
+// refreshAction resolves a single refresh, install or download action
+// against the mirror.
+func (m *Mirror) refreshAction(action transport.RefreshRequestAction, contexts map[string]transport.RefreshRequestContext) transport.RefreshResponse {
+	result := transport.RefreshResponse{
+		InstanceKey: action.InstanceKey,
+		Result:      "error",
+	}
+	failed := func(code transport.APIErrorCode, format string, args ...interface{}) transport.RefreshResponse {
+		result.Error = &transport.APIError{
+			Code:    code,
+			Message: fmt.Sprintf(format, args...),
+		}
+		return result
+	}
+
+	var (
+		id, name, channel string
+		revision          *int
+		base              transport.Base
+	)
+	switch action.Action {
+	case string(refreshAction):
+		ctx, ok := contexts[action.InstanceKey]
+		if !ok {
+			return failed(transport.ErrorCodeMissingContext, "no context for instance key %q", action.InstanceKey)
+		}
+		id, base, channel = ctx.ID, ctx.Base, ctx.TrackingChannel
+		if action.Channel != nil {
+			channel = *action.Channel
+		}
+	case string(installAction), string(downloadAction):
+		if action.ID != nil {
+			id = *action.ID
+		}
+		if action.Name != nil {
+			name = *action.Name
+		}
+		if action.Channel != nil {
+			channel = *action.Channel
+		}
+		if action.Base != nil {
+			base = *action.Base
+		}
+		revision = action.Revision
+	default:
+		return failed(transport.ErrorCodeBadArgument, "unknown action %q", action.Action)
+	}
+
+	var (
+		info transport.InfoResponse
+		err  error
+	)
+	if name != "" {
+		info, err = m.info(name)
+	} else {
+		info, err = m.infoByID(id)
+	}
+	if errors.Is(err, errors.NotFound) {
+		if name != "" {
+			return failed(transport.ErrorCodeNameNotFound, "%s not found in mirror", name)
+		}
+		return failed(transport.ErrorCodeIDNotFound, "%s not found in mirror", id)
+	} else if err != nil {
+		return failed(transport.ErrorCodeAPIError, "%v", err)
+	}
+	result.ID, result.Name = info.ID, info.Name
+
+	if revision == nil {
+		release, effective, err := resolveChannel(info, channel, base)
+		if err != nil {
+			result = failed(transport.ErrorCodeRevisionNotFound, "%v", err)
+			result.ID, result.Name = info.ID, info.Name
+			for _, ch := range info.ChannelMap {
+				result.Error.Extra.Releases = append(result.Error.Extra.Releases, transport.Release{
+					Base:    ch.Channel.Base,
+					Channel: ch.Channel.Name,
+				})
+			}
+			return result
+		}
+		revision = &release.Revision.Revision
+		result.EffectiveChannel = effective
+		result.ReleasedAt, _ = time.Parse(time.RFC3339, release.Channel.ReleasedAt)
+	}
+
+	var entity transport.RefreshEntity
+	if err := m.readJSON(m.revisionMetadataPath(info.Name, *revision), &entity); errors.Is(err, errors.NotFound) {
+		result = failed(transport.ErrorCodeRevisionNotFound, "%s revision %d not found in mirror", info.Name, *revision)
+		result.ID, result.Name = info.ID, info.Name
+		return result
+	} else if err != nil {
+		return failed(transport.ErrorCodeAPIError, "%v", err)
+	}
+	for _, requested := range action.ResourceRevisions {
+		for i, res := range entity.Resources {
+			if res.Name != requested.Name || res.Revision == requested.Revision {
+				continue
+			}
+			var mirrored transport.ResourceRevision
+			resPath := m.resourceURL(info.Name, requested.Name, requested.Revision) + ".json"
+			if err := m.readJSON(resPath, &mirrored); err != nil {
+				return failed(transport.ErrorCodeResourceNotFound, "resource %q revision %d not found in mirror", requested.Name, requested.Revision)
+			}
+			entity.Resources[i] = mirrored
+		}
+	}
+	result.Result = action.Action
+	result.Entity = entity
+	return result
+}
+
+// channelKey returns the normalised form of a channel, including the
+// default "latest" track, for comparison.
+func channelKey(channel string) (string, error) {
+	ch, err := charm.ParseChannelNormalize(channel)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if ch.Track == "" {
+		ch.Track = "latest"
+	}
+	return ch.String(), nil
+}
+
+// riskFallback lists the risks whose releases are used, in order, when
+// there is no release at a risk. As with Charmhub, a channel with no
+// release follows the next more stable risk.
+var riskFallback = []charm.Risk{charm.Edge, charm.Beta, charm.Candidate, charm.Stable}
+
+// resolveChannel returns the release in the channel for the base, and
+// the channel it was found in.
+func resolveChannel(info transport.InfoResponse, channel string, base transport.Base) (transport.InfoChannelMap, string, error) {
+	if channel == "" {
+		channel = "stable"
+		if track := info.DefaultRelease.Channel.Track; track != "" {
+			channel = track + "/stable"
+		}
+	}
+	requested, err := charm.ParseChannelNormalize(channel)
+	if err != nil {
+		return transport.InfoChannelMap{}, "", errors.Trace(err)
+	}
+	start := 0
+	for i, risk := range riskFallback {
+		if risk == requested.Risk {
+			start = i
+		}
+	}
+	for _, risk := range riskFallback[start:] {
+		candidate := requested
+		candidate.Risk = risk
+		key, _ := channelKey(candidate.String())
+		for _, ch := range info.ChannelMap {
+			if k, _ := channelKey(ch.Channel.Name); k != key {
+				continue
+			}
+			if !baseMatches(ch.Channel.Base, base) {
+				continue
+			}
+			return ch, ch.Channel.Name, nil
+		}
+	}
+	return transport.InfoChannelMap{}, "", errors.NotFoundf("%s release in channel %q for base %s/%s/%s",
+		info.Name, channel, base.Architecture, base.Name, base.Channel)
+}
+
+// baseMatches returns true if a release for the given base can be used
+// for the requested base. An empty or "NA" name or channel, or an "all"
+// architecture, matches any.
+func baseMatches(release, requested transport.Base) bool {
+	wildcard := func(s string) bool {
+		return s == "" || s == notAvailable || s == "all"
+	}
+	if !wildcard(release.Architecture) && !wildcard(requested.Architecture) && release.Architecture != requested.Architecture {
+		return false
+	}
+	if !wildcard(release.Name) && !wildcard(requested.Name) && release.Name != requested.Name {
+		return false
+	}
+	baseChannel := func(s string) string {
+		return strings.SplitN(s, "/", 2)[0]
+	}
+	if !wildcard(release.Channel) && !wildcard(requested.Channel) && baseChannel(release.Channel) != baseChannel(requested.Channel) {
+		return false
+	}
+	return true
+}
+
+func writeMirrorJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeMirrorError(w http.ResponseWriter, status int, code transport.APIErrorCode, message string) {
+	writeMirrorJSON(w, status, struct {
+		ErrorList transport.APIErrors `json:"error-list"`
+	}{
+		ErrorList: transport.APIErrors{{Code: code, Message: message}},
+	})
+}
+
+// mirrorHTTPClient is an HTTPClient which serves requests to "file"
+// URLs in the mirror directory from the mirror, without a server.
+type mirrorHTTPClient struct {
+	mirror *Mirror
+}
+
+// Do is part of HTTPClient.
+func (c mirrorHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != MirrorScheme {
+		return nil, errors.NotSupportedf("URL %q outside charm mirror", req.URL)
+	}
+	dir := strings.TrimRight(filepath.ToSlash(c.mirror.dir), "/")
+	rel := strings.TrimPrefix(req.URL.Path, dir)
+	if rel == req.URL.Path && dir != "" {
+		return nil, errors.NotSupportedf("URL %q outside charm mirror", req.URL)
+	}
+	rel = strings.TrimLeft(rel, "/")
+
+	// Downloads are streamed from the file, rather than buffered.
+	if req.Method == http.MethodGet && strings.HasPrefix(rel, mirrorCharmsDir+"/") {
+		f, info, err := c.mirror.openFile(rel)
+		if err == nil {
+			return &http.Response{
+				Status:        "200 OK",
+				StatusCode:    http.StatusOK,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": []string{"application/octet-stream"}},
+				Body:          f,
+				ContentLength: info.Size(),
+				Request:       req,
+			}, nil
+		}
+	}
+
+	mirrorReq := req.Clone(req.Context())
+	mirrorReq.URL.Path = "/" + rel
+
+	rec := &mirrorResponseWriter{header: make(http.Header), status: http.StatusOK}
+	c.mirror.ServeHTTP(rec, mirrorReq)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.status, http.StatusText(rec.status)),
+		StatusCode:    rec.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.header,
+		Body:          io.NopCloser(&rec.body),
+		ContentLength: int64(rec.body.Len()),
+		Request:       req,
+	}, nil
+}
+
+// mirrorResponseWriter buffers a response from the mirror.
+type mirrorResponseWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *mirrorResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *mirrorResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+}
+
+func (w *mirrorResponseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(data)
+}
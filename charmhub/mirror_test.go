@@ -0,0 +1,328 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+type MirrorSuite struct {
+	testing.IsolationSuite
+
+	dir    string
+	mirror *Mirror
+}
+
+var _ = gc.Suite(&MirrorSuite{})
+
+func (s *MirrorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.mirror = NewMirror(s.dir)
+
+	jammy := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	focal := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "20.04"}
+	err := s.mirror.AddInfo(transport.InfoResponse{
+		Type: transport.CharmType,
+		ID:   "mysql-id",
+		Name: "mysql",
+		Entity: transport.Entity{
+			Summary: "MySQL database",
+		},
+		ChannelMap: []transport.InfoChannelMap{{
+			Channel:  transport.Channel{Name: "8.0/stable", Track: "8.0", Risk: "stable", Base: jammy, ReleasedAt: "2023-06-01T12:00:00Z"},
+			Revision: transport.InfoRevision{Revision: 2, Bases: []transport.Base{jammy}, Download: transport.Download{URL: "https://example.com/2"}},
+		}, {
+			Channel:  transport.Channel{Name: "8.0/edge", Track: "8.0", Risk: "edge", Base: jammy},
+			Revision: transport.InfoRevision{Revision: 3, Bases: []transport.Base{jammy}},
+		}, {
+			Channel:  transport.Channel{Name: "8.0/stable", Track: "8.0", Risk: "stable", Base: focal},
+			Revision: transport.InfoRevision{Revision: 1, Bases: []transport.Base{focal}},
+		}},
+		DefaultRelease: transport.InfoChannelMap{
+			Channel:  transport.Channel{Name: "8.0/stable", Track: "8.0", Risk: "stable", Base: jammy},
+			Revision: transport.InfoRevision{Revision: 2, Bases: []transport.Base{jammy}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resource := s.addResource(c, "mysql", "backup-tool", 4, "tool-4")
+	for rev, base := range map[int]transport.Base{1: focal, 2: jammy, 3: jammy} {
+		content := fmt.Sprintf("charm-%d", rev)
+		err := s.mirror.AddRevision(transport.RefreshEntity{
+			Type:      transport.CharmType,
+			ID:        "mysql-id",
+			Name:      "mysql",
+			Revision:  rev,
+			Bases:     []transport.Base{base},
+			Download:  transport.Download{HashSHA256: sha256Hex(content), URL: "https://example.com/charm"},
+			Resources: []transport.ResourceRevision{resource},
+		}, strings.NewReader(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.addResource(c, "mysql", "backup-tool", 5, "tool-5")
+}
+
+func (s *MirrorSuite) addResource(c *gc.C, charmName, name string, revision int, content string) transport.ResourceRevision {
+	res := transport.ResourceRevision{
+		Name:     name,
+		Filename: name + ".tar",
+		Type:     "file",
+		Revision: revision,
+		Download: transport.Download{HashSHA256: sha256Hex(content), URL: "https://example.com/resource"},
+	}
+	err := s.mirror.AddResource(charmName, res, strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return res
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
+
+func (s *MirrorSuite) newClient(c *gc.C) *Client {
+	client, err := NewClient(Config{
+		URL:    "file://" + filepath.ToSlash(s.dir),
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *MirrorSuite) TestAddRevisionChecksumMismatch(c *gc.C) {
+	err := s.mirror.AddRevision(transport.RefreshEntity{
+		Type:     transport.CharmType,
+		Name:     "mysql",
+		Revision: 9,
+		Download: transport.Download{HashSHA256: sha256Hex("other")},
+	}, strings.NewReader("content"))
+	c.Assert(err, gc.ErrorMatches, `writing charm "mysql" revision 9: checksum mismatch, .*`)
+	c.Assert(s.mirror.HasRevision("mysql", 9), jc.IsFalse)
+	c.Assert(s.mirror.HasRevision("mysql", 2), jc.IsTrue)
+}
+
+func (s *MirrorSuite) TestAddInvalidName(c *gc.C) {
+	err := s.mirror.AddInfo(transport.InfoResponse{Name: "../etc"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *MirrorSuite) TestInfo(c *gc.C) {
+	info, err := s.newClient(c).Info(context.Background(), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ID, gc.Equals, "mysql-id")
+	c.Assert(info.ChannelMap, gc.HasLen, 3)
+	c.Assert(info.ChannelMap[0].Revision.Download.URL, gc.Equals, "file://"+filepath.ToSlash(s.dir)+"/charms/mysql/revisions/2.charm")
+
+	info, err = s.newClient(c).Info(context.Background(), "mysql", WithInfoChannel("8.0/edge"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ChannelMap, gc.HasLen, 1)
+	c.Assert(info.ChannelMap[0].Revision.Revision, gc.Equals, 3)
+}
+
+func (s *MirrorSuite) TestAddInfoKeepsReleases(c *gc.C) {
+	jammy := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	err := s.mirror.AddInfo(transport.InfoResponse{
+		Type: transport.CharmType,
+		ID:   "mysql-id",
+		Name: "mysql",
+		ChannelMap: []transport.InfoChannelMap{{
+			Channel:  transport.Channel{Name: "8.0/edge", Track: "8.0", Risk: "edge", Base: jammy},
+			Revision: transport.InfoRevision{Revision: 4, Bases: []transport.Base{jammy}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.newClient(c).Info(context.Background(), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	revisions := make(map[string]int)
+	for _, ch := range info.ChannelMap {
+		revisions[ch.Channel.Name+" "+ch.Channel.Base.Channel] = ch.Revision.Revision
+	}
+	c.Assert(revisions, jc.DeepEquals, map[string]int{
+		"8.0/edge 22.04":   4,
+		"8.0/stable 22.04": 2,
+		"8.0/stable 20.04": 1,
+	})
+	c.Assert(info.DefaultRelease.Revision.Revision, gc.Equals, 2)
+}
+
+func (s *MirrorSuite) TestInfoNotFound(c *gc.C) {
+	_, err := s.newClient(c).Info(context.Background(), "postgresql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestFind(c *gc.C) {
+	results, err := s.newClient(c).Find(context.Background(), "database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Name, gc.Equals, "mysql")
+	c.Assert(results[0].DefaultRelease.Revision.Revision, gc.Equals, 2)
+
+	results, err = s.newClient(c).Find(context.Background(), "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *MirrorSuite) TestInstallFromChannel(c *gc.C) {
+	for i, t := range []struct {
+		channel   string
+		base      RefreshBase
+		revision  int
+		effective string
+	}{{
+		channel:   "8.0/stable",
+		base:      RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"},
+		revision:  2,
+		effective: "8.0/stable",
+	}, {
+		channel:   "8.0/stable",
+		base:      RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "20.04"},
+		revision:  1,
+		effective: "8.0/stable",
+	}, {
+		channel:   "8.0/edge",
+		base:      RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"},
+		revision:  3,
+		effective: "8.0/edge",
+	}, {
+		// There's no candidate release, so stable is used.
+		channel:   "8.0/candidate",
+		base:      RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"},
+		revision:  2,
+		effective: "8.0/stable",
+	}} {
+		c.Logf("test %d: %s %s", i, t.channel, t.base)
+		cfg, err := InstallOneFromChannel("mysql", t.channel, t.base)
+		c.Assert(err, jc.ErrorIsNil)
+		results, err := s.newClient(c).Refresh(context.Background(), cfg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results, gc.HasLen, 1)
+		c.Assert(results[0].Error, gc.IsNil)
+		c.Check(results[0].Entity.Revision, gc.Equals, t.revision)
+		c.Check(results[0].EffectiveChannel, gc.Equals, t.effective)
+		c.Check(results[0].Entity.Download.URL, gc.Equals, fmt.Sprintf("file://%s/charms/mysql/revisions/%d.charm", filepath.ToSlash(s.dir), t.revision))
+	}
+}
+
+func (s *MirrorSuite) TestInstallFromChannelNoRelease(c *gc.C) {
+	cfg, err := InstallOneFromChannel("mysql", "8.0/stable", RefreshBase{Architecture: "arm64", Name: "ubuntu", Channel: "22.04"})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.newClient(c).Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Error.Code, gc.Equals, transport.ErrorCodeRevisionNotFound)
+	c.Assert(results[0].Error.Extra.Releases, gc.HasLen, 3)
+}
+
+func (s *MirrorSuite) TestInstallFromRevision(c *gc.C) {
+	cfg, err := InstallOneFromRevision("mysql", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, ok := AddResource(cfg, "backup-tool", 5)
+	c.Assert(ok, jc.IsTrue)
+	results, err := s.newClient(c).Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Entity.Revision, gc.Equals, 1)
+	c.Assert(results[0].Entity.Resources, gc.HasLen, 1)
+	c.Assert(results[0].Entity.Resources[0].Revision, gc.Equals, 5)
+
+	cfg, err = InstallOneFromRevision("mysql", 7)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.newClient(c).Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Error.Code, gc.Equals, transport.ErrorCodeRevisionNotFound)
+}
+
+func (s *MirrorSuite) TestRefresh(c *gc.C) {
+	cfg, err := RefreshOne("instance-key", "mysql-id", 1, "8.0/stable", RefreshBase{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.newClient(c).Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Name, gc.Equals, "mysql")
+	c.Assert(results[0].Entity.Revision, gc.Equals, 2)
+}
+
+func (s *MirrorSuite) TestDownload(c *gc.C) {
+	client := s.newClient(c)
+	cfg, err := InstallOneFromRevision("mysql", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := client.Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := filepath.Join(c.MkDir(), "mysql.charm")
+	err = client.Download(context.Background(), MustParseURL(c, results[0].Entity.Download.URL), target)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "charm-3")
+
+	r, err := client.DownloadResource(context.Background(), MustParseURL(c, results[0].Entity.Resources[0].Download.URL))
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err = io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "tool-4")
+}
+
+func (s *MirrorSuite) TestDownloadOutsideMirror(c *gc.C) {
+	err := s.newClient(c).Download(context.Background(), MustParseURL(c, "file:///etc/passwd"), filepath.Join(c.MkDir(), "x"))
+	c.Assert(err, gc.ErrorMatches, `.*URL "file:///etc/passwd" outside charm mirror not supported`)
+
+	err = s.newClient(c).Download(context.Background(), MustParseURL(c, "file://"+filepath.ToSlash(s.dir)+"/charms/mysql/info.json"), filepath.Join(c.MkDir(), "x"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestListResourceRevisions(c *gc.C) {
+	revisions, err := s.newClient(c).ListResourceRevisions(context.Background(), "mysql", "backup-tool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Assert(revisions[0].Revision, gc.Equals, 5)
+	c.Assert(revisions[1].Revision, gc.Equals, 4)
+
+	_, err = s.newClient(c).ListResourceRevisions(context.Background(), "mysql", "other")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestServeHTTP(c *gc.C) {
+	srv := httptest.NewServer(s.mirror)
+	defer srv.Close()
+
+	client, err := NewClient(Config{
+		URL:    srv.URL,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := InstallOneFromRevision("mysql", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := client.Refresh(context.Background(), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Entity.Download.URL, gc.Equals, srv.URL+"/charms/mysql/revisions/2.charm")
+
+	target := filepath.Join(c.MkDir(), "mysql.charm")
+	err = client.Download(context.Background(), MustParseURL(c, results[0].Entity.Download.URL), target)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "charm-2")
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// A signature mirror serves the detached SSH signatures of charm
+// archives, resources and container images, addressed by the digest of
+// the content signed:
+//
+//	sha256/<hex digest>.sig   - charm or bundle archive, container image
+//	sha384/<hex digest>.sig   - file resource
+//
+// Addressing signatures by content means they can be looked up with the
+// digests Charmhub already returns, and that a signature can't be served
+// for content other than that which was signed.
+
+// maxSignatureSize is the largest signature read from a signature
+// mirror. SSH signatures are much smaller than this.
+const maxSignatureSize = 64 * 1024
+
+// signatureDigestSizes holds the length, in bytes, of the digests of
+// each algorithm which a signature mirror serves.
+var signatureDigestSizes = map[string]int{
+	"sha256": 32,
+	"sha384": 48,
+}
+
+// SignatureMirror fetches signatures from a signature mirror, which is
+// either an HTTP(S) server or a local directory.
+type SignatureMirror struct {
+	url        *url.URL
+	httpClient HTTPClient
+	logger     Logger
+}
+
+// NewSignatureMirror returns a SignatureMirror for the mirror at the
+// given URL. If httpClient is nil, the default client is used.
+func NewSignatureMirror(mirrorURL string, httpClient HTTPClient, logger Logger) (*SignatureMirror, error) {
+	u, err := url.Parse(mirrorURL)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing signature mirror URL %q", mirrorURL)
+	}
+	switch u.Scheme {
+	case "http", "https", MirrorScheme:
+	default:
+		return nil, errors.NotValidf("signature mirror URL %q", mirrorURL)
+	}
+	if httpClient == nil {
+		httpClient = DefaultHTTPClient(logger)
+	}
+	return &SignatureMirror{
+		url:        u,
+		httpClient: httpClient,
+		logger:     logger,
+	}, nil
+}
+
+// Signature returns the signature of the content with the given digest,
+// in the form "<algorithm>:<hex digest>". A NotFound error is returned
+// if the mirror has no signature for the content.
+func (m *SignatureMirror) Signature(ctx context.Context, digest string) ([]byte, error) {
+	algorithm, hexDigest, err := parseSignatureDigest(digest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := path.Join(algorithm, hexDigest+".sig")
+
+	if m.url.Scheme == MirrorScheme {
+		return m.readFile(name)
+	}
+
+	sigURL := *m.url
+	sigURL.Path = path.Join(sigURL.Path, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sigURL.String(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m.logger.Tracef("fetching signature from %s", sigURL.String())
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "fetching signature from %s", sigURL.String())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errors.NotFoundf("signature of %q", digest)
+	default:
+		return nil, errors.Errorf("fetching signature from %s: %s", sigURL.String(), resp.Status)
+	}
+	return readSignature(resp.Body)
+}
+
+func (m *SignatureMirror) readFile(name string) ([]byte, error) {
+	f, err := os.Open(filepath.Join(filepath.FromSlash(m.url.Path), filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("signature %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	return readSignature(f)
+}
+
+func readSignature(r io.Reader) ([]byte, error) {
+	sig, err := io.ReadAll(io.LimitReader(r, maxSignatureSize+1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sig) > maxSignatureSize {
+		return nil, errors.Errorf("signature larger than %d bytes", maxSignatureSize)
+	}
+	return sig, nil
+}
+
+// parseSignatureDigest splits a digest into its algorithm and lower case
+// hex digest.
+func parseSignatureDigest(digest string) (string, string, error) {
+	algorithm, hexDigest, ok := strings.Cut(digest, ":")
+	size, known := signatureDigestSizes[algorithm]
+	if !ok || !known {
+		return "", "", errors.NotValidf("digest %q", digest)
+	}
+	hexDigest = strings.ToLower(hexDigest)
+	if b, err := hex.DecodeString(hexDigest); err != nil || len(b) != size {
+		return "", "", errors.NotValidf("digest %q", digest)
+	}
+	return algorithm, hexDigest, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+)
+
+type SignatureMirrorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SignatureMirrorSuite{})
+
+var testSignatureDigest = "sha256:" + strings.Repeat("ab", 32)
+
+func (s *SignatureMirrorSuite) TestSignature(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+		c.Check(r.URL.String(), gc.Equals, "https://signatures.example.com/charms/sha256/"+strings.Repeat("ab", 32)+".sig")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("signature")),
+		}, nil
+	})
+
+	mirror, err := NewSignatureMirror("https://signatures.example.com/charms", httpClient, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := mirror.Signature(context.Background(), testSignatureDigest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(sig), gc.Equals, "signature")
+}
+
+func (s *SignatureMirrorSuite) TestSignatureNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}, nil)
+
+	mirror, err := NewSignatureMirror("https://signatures.example.com", httpClient, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mirror.Signature(context.Background(), testSignatureDigest)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SignatureMirrorSuite) TestSignatureFailedStatusCode(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		Status:     "500 Internal Server Error",
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}, nil)
+
+	mirror, err := NewSignatureMirror("https://signatures.example.com", httpClient, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mirror.Signature(context.Background(), testSignatureDigest)
+	c.Assert(err, gc.ErrorMatches, `fetching signature from https://signatures.example.com/sha256/.*\.sig: 500 Internal Server Error`)
+	c.Assert(err, gc.Not(jc.Satisfies), errors.IsNotFound)
+}
+
+func (s *SignatureMirrorSuite) TestSignatureTooLarge(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	httpClient := NewMockHTTPClient(ctrl)
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBuffer(make([]byte, maxSignatureSize+1))),
+	}, nil)
+
+	mirror, err := NewSignatureMirror("https://signatures.example.com", httpClient, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mirror.Signature(context.Background(), testSignatureDigest)
+	c.Assert(err, gc.ErrorMatches, `signature larger than 65536 bytes`)
+}
+
+func (s *SignatureMirrorSuite) TestSignatureDirectory(c *gc.C) {
+	dir := c.MkDir()
+	err := os.Mkdir(filepath.Join(dir, "sha384"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	hexDigest := strings.Repeat("cd", 48)
+	err = os.WriteFile(filepath.Join(dir, "sha384", hexDigest+".sig"), []byte("signature"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mirror, err := NewSignatureMirror("file://"+filepath.ToSlash(dir), nil, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := mirror.Signature(context.Background(), "sha384:"+strings.ToUpper(hexDigest))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(sig), gc.Equals, "signature")
+
+	_, err = mirror.Signature(context.Background(), testSignatureDigest)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SignatureMirrorSuite) TestSignatureInvalidDigest(c *gc.C) {
+	mirror, err := NewSignatureMirror("https://signatures.example.com", nil, &FakeLogger{})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, digest := range []string{
+		"",
+		strings.Repeat("ab", 32),
+		"md5:" + strings.Repeat("ab", 16),
+		"sha256:" + strings.Repeat("ab", 31),
+		"sha256:../../" + strings.Repeat("ab", 29),
+	} {
+		_, err = mirror.Signature(context.Background(), digest)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("digest %q", digest))
+	}
+}
+
+func (s *SignatureMirrorSuite) TestInvalidURL(c *gc.C) {
+	_, err := NewSignatureMirror("ftp://signatures.example.com", nil, &FakeLogger{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
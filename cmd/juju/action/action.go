@@ -22,6 +22,10 @@ type APIClient interface {
 	// timeout.
 	RunOnAllMachines(commands string, timeout time.Duration) (action.EnqueuedActions, error)
 
+	// RunOnAllMachinesInBatches runs the command on all the machines with
+	// the specified timeout, a batch of machines at a time.
+	RunOnAllMachinesInBatches(commands string, timeout time.Duration, rollout action.Rollout) (action.EnqueuedActions, error)
+
 	// Run the Commands specified on the machines identified through the ids
 	// provided in the machines, applications and units slices.
 	Run(action.RunParams) (action.EnqueuedActions, error)
@@ -31,6 +35,10 @@ type APIClient interface {
 	// We return the ID of the overall operation and each individual task.
 	EnqueueOperation([]action.Action) (action.EnqueuedActions, error)
 
+	// EnqueueOperationInBatches queues up the actions as an operation whose
+	// tasks are enqueued in batches, with any on application leaders last.
+	EnqueueOperationInBatches([]action.Action, action.Rollout) (action.EnqueuedActions, error)
+
 	// Cancel attempts to cancel a queued up Action from running.
 	Cancel([]string) ([]action.ActionResult, error)
 
@@ -50,6 +58,15 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// AddSchedule adds a schedule on which to run an action.
+	AddSchedule(action.Schedule) error
+
+	// ListSchedules returns the action schedules of the model.
+	ListSchedules() ([]action.Schedule, error)
+
+	// RemoveSchedule removes the action schedule with the given name.
+	RemoveSchedule(name string) error
 }
 
 // ActionCommandBase is the base type for action sub-commands.
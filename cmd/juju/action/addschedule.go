@@ -0,0 +1,192 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	actionapi "github.com/juju/juju/api/client/action"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+// NewAddScheduleCommand returns a command which adds a schedule on
+// which to run an action.
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+type addScheduleCommand struct {
+	ActionCommandBase
+	name         string
+	actionName   string
+	units        []string
+	applications []string
+	cron         string
+	every        time.Duration
+	concurrency  string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+}
+
+const addScheduleDoc = `
+Add a schedule on which the controller runs an action on the given units and
+applications, with a given set of params. Each run starts an operation which
+can be seen with 'juju operations' and 'juju show-operation <ID>', and the
+most recent runs of each schedule are shown by 'juju schedules'.
+
+The schedule is either a standard cron expression with five fields, given
+with the --cron option and evaluated in UTC, or an interval of at least a
+minute, given with the --every option.
+
+Targets are given with the --unit and --application options. Units may use
+the leader syntax of the form <application>/leader, which is resolved on each
+run, as are the units of an application.
+
+If a run is due while the operation of the previous run is still pending or
+running, the --concurrency option determines what happens:
+  forbid   skip the run (the default)
+  allow    run the action again regardless
+  replace  cancel the unfinished tasks of the previous run, then run
+
+Runs missed while no controller was available are not made up for.
+
+Params are given in the same way as for 'juju run', in a yaml file passed with
+the --params option or in a key.key.key...=value format, and are not validated
+until the action runs.
+`
+
+const addScheduleExamples = `
+    juju add-schedule nightly-backup backup --unit mysql/leader --cron "0 3 * * *"
+    juju add-schedule refresh-cache refresh --app memcached --every 15m
+    juju add-schedule rotate rotate-logs -u web/0,web/1 --every 1h --concurrency allow
+    juju add-schedule snapshot snapshot --app postgresql --every 6h --params p.yml full=true
+`
+
+// SetFlags implements Command.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.units), "u", "One or more unit ids")
+	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "a", "One or more application names")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "app", "")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "")
+	f.StringVar(&c.cron, "cron", "", "Cron expression on which to run the action")
+	f.DurationVar(&c.every, "every", 0, "Interval on which to run the action")
+	f.StringVar(&c.concurrency, "concurrency", string(actions.ConcurrencyForbid),
+		"What to do when a run is due while the previous one is unfinished: forbid, allow or replace")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements Command.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "add-schedule",
+		Args:     "<schedule-name> <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Purpose:  "Run an action on a schedule.",
+		Doc:      addScheduleDoc,
+		Examples: addScheduleExamples,
+		SeeAlso: []string{
+			"schedules",
+			"remove-schedule",
+			"run",
+			"operations",
+		},
+	})
+}
+
+// Init implements Command.
+func (c *addScheduleCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.name = args[0]
+	if !actions.IsValidScheduleName(c.name) {
+		return errors.NotValidf("schedule name %q", c.name)
+	}
+	if len(args) == 1 {
+		return errors.New("no action specified")
+	}
+	c.actionName = args[1]
+	if !nameRule.MatchString(c.actionName) {
+		return errors.NotValidf("action name %q", c.actionName)
+	}
+
+	if len(c.units) == 0 && len(c.applications) == 0 {
+		return errors.New("no unit or application specified")
+	}
+	var nameErrors []string
+	for _, unit := range c.units {
+		if !names.IsValidUnit(unit) && !validLeader.MatchString(unit) {
+			nameErrors = append(nameErrors, fmt.Sprintf("invalid unit name %q", unit))
+		}
+	}
+	for _, application := range c.applications {
+		if !names.IsValidApplication(application) {
+			nameErrors = append(nameErrors, fmt.Sprintf("invalid application name %q", application))
+		}
+	}
+	if len(nameErrors) > 0 {
+		return errors.New(strings.Join(nameErrors, "\n"))
+	}
+
+	if c.cron == "" && c.every == 0 {
+		return errors.New("one of --cron or --every is required")
+	}
+	if c.cron != "" && c.every != 0 {
+		return errors.New("only one of --cron or --every may be given")
+	}
+	if err := c.schedule().Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := actions.ConcurrencyPolicy(c.concurrency).Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.args, err = parseActionArgs(args[2:])
+	return errors.Trace(err)
+}
+
+func (c *addScheduleCommand) schedule() actions.Schedule {
+	return actions.Schedule{Cron: c.cron, Interval: c.every}
+}
+
+// Run implements Command.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	actionParams, err := actionParameters(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.AddSchedule(actionapi.Schedule{
+		Name:              c.name,
+		Cron:              c.cron,
+		Interval:          c.every,
+		Targets:           append(c.units, c.applications...),
+		Action:            c.actionName,
+		Parameters:        actionParams,
+		ConcurrencyPolicy: c.concurrency,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added schedule %q to run %s %s.", c.name, c.actionName, c.schedule())
+	return nil
+}
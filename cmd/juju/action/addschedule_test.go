@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	actionapi "github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type AddScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&AddScheduleSuite{})
+
+func (s *AddScheduleSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no schedule name specified",
+	}, {
+		args: []string{"Nightly", "backup"},
+		err:  `schedule name "Nightly" not valid`,
+	}, {
+		args: []string{"nightly"},
+		err:  "no action specified",
+	}, {
+		args: []string{"nightly", "Backup"},
+		err:  `action name "Backup" not valid`,
+	}, {
+		args: []string{"nightly", "backup", "--every", "1h"},
+		err:  "no unit or application specified",
+	}, {
+		args: []string{"nightly", "backup", "--unit", "mysql/0," + invalidUnitId, "--every", "1h"},
+		err:  `invalid unit name "` + invalidUnitId + `"`,
+	}, {
+		args: []string{"nightly", "backup", "--app", invalidApplicationId, "--every", "1h"},
+		err:  `invalid application name "` + invalidApplicationId + `"`,
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql"},
+		err:  "one of --cron or --every is required",
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql", "--every", "1h", "--cron", "@daily"},
+		err:  "only one of --cron or --every may be given",
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql", "--cron", "0 3 * *"},
+		err:  `cron expression 0 3 \* \*: .*`,
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql", "--every", "10s"},
+		err:  "interval 10s shorter than 1m0s not valid",
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql", "--every", "1h", "--concurrency", "queue"},
+		err:  `concurrency policy "queue" not valid`,
+	}, {
+		args: []string{"nightly", "backup", "--app", "mysql", "--every", "1h", "full"},
+		err:  `argument "full" must be of the form key.key.key...=value`,
+	}, {
+		args: []string{"nightly", "backup", "-u", "mysql/leader", "-a", "redis", "--cron", "0 3 * * *", "full=true"},
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		cmd := action.NewAddScheduleCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, append([]string{"-m", "admin"}, t.args...))
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *AddScheduleSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	dir := c.MkDir()
+	path := filepath.Join(dir, "params.yaml")
+	err := os.WriteFile(path, []byte("out: backup.tar\nfile:\n  kind: gz\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"-m", "admin", "nightly", "backup", "-u", "mysql/leader", "--app", "redis",
+		"--every", "6h", "--concurrency", "replace", "--params", path, "file.kind=xz", "full=true",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added schedule \"nightly\" to run backup every 6h0m0s.\n")
+	c.Assert(fakeClient.schedules, jc.DeepEquals, []actionapi.Schedule{{
+		Name:     "nightly",
+		Interval: 6 * time.Hour,
+		Targets:  []string{"mysql/leader", "redis"},
+		Action:   "backup",
+		Parameters: map[string]interface{}{
+			"out":  "backup.tar",
+			"file": map[string]interface{}{"kind": "xz"},
+			"full": true,
+		},
+		ConcurrencyPolicy: "replace",
+	}})
+}
+
+func (s *AddScheduleSuite) TestRunDefaults(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"-m", "admin", "nightly", "backup", "--app", "mysql", "--cron", "0 3 * * *", "--string-args", "level=1",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.schedules, jc.DeepEquals, []actionapi.Schedule{{
+		Name:              "nightly",
+		Cron:              "0 3 * * *",
+		Targets:           []string{"mysql"},
+		Action:            "backup",
+		Parameters:        map[string]interface{}{"level": "1"},
+		ConcurrencyPolicy: "forbid",
+	}})
+}
+
+func (s *AddScheduleSuite) TestRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiErr: errors.New(`schedule "nightly" already exists`)}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"-m", "admin", "nightly", "backup", "--app", "mysql", "--every", "1h",
+	)
+	c.Assert(err, gc.ErrorMatches, `schedule "nightly" already exists`)
+}
@@ -28,6 +28,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	actionapi "github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/watcher"
@@ -66,6 +67,10 @@ type runCommandBase struct {
 	defaultWait       time.Duration
 	logMessageHandler func(*cmd.Context, string)
 
+	batchSize     int
+	batchDelay    time.Duration
+	stopOnFailure bool
+
 	hideProgress bool // whether to hide progress info by default
 }
 
@@ -88,6 +93,9 @@ func (c *runCommandBase) setNonFormatFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.noColor, "no-color", false, "Disable ANSI color codes in output")
 	f.BoolVar(&c.color, "color", false, "Use ANSI color codes in output")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run on at most this many targets at a time, with application leaders last")
+	f.DurationVar(&c.batchDelay, "batch-delay", 0, "Time to wait after a batch finishes before starting the next")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "Do not start further batches once a task has failed")
 }
 
 func (c *runCommandBase) Init(_ []string) error {
@@ -100,10 +108,32 @@ func (c *runCommandBase) Init(_ []string) error {
 			c.wait = 60 * time.Second
 		}
 	}
+	if c.batchSize < 0 {
+		return errors.NotValidf("batch size %d", c.batchSize)
+	}
+	if c.batchDelay < 0 {
+		return errors.NotValidf("negative batch delay")
+	}
+	if c.batchSize == 0 && (c.batchDelay > 0 || c.stopOnFailure) {
+		return errors.New("--batch-delay and --stop-on-failure require --batch-size")
+	}
 
 	return nil
 }
 
+// rollout returns how the tasks should be enqueued in batches, or nil
+// if they should all be enqueued at once.
+func (c *runCommandBase) rollout() *actionapi.Rollout {
+	if c.batchSize == 0 {
+		return nil
+	}
+	return &actionapi.Rollout{
+		BatchSize:     c.batchSize,
+		BatchDelay:    c.batchDelay,
+		StopOnFailure: c.stopOnFailure,
+	}
+}
+
 func (c *runCommandBase) ensureAPI() (err error) {
 	if c.api != nil {
 		return nil
@@ -136,11 +166,18 @@ func (c *runCommandBase) operationResults(ctx *cmd.Context, results *actionapi.E
 func (c *runCommandBase) processOperationResults(ctx *cmd.Context, forceColor bool, results *actionapi.EnqueuedActions) error {
 	var runningTasks []enqueuedAction
 	var enqueueErrs []string
+	var numPending int
 	for _, a := range results.Actions {
 		if a.Error != nil {
 			enqueueErrs = append(enqueueErrs, a.Error.Error())
 			continue
 		}
+		if a.Action == nil {
+			// The task belongs to a later batch of a rollout and
+			// will be enqueued by the controller.
+			numPending++
+			continue
+		}
 		runningTasks = append(runningTasks, enqueuedAction{
 			task:     a.Action.ID,
 			receiver: a.Action.Receiver,
@@ -178,10 +215,17 @@ func (c *runCommandBase) processOperationResults(ctx *cmd.Context, forceColor bo
 			"id": result.task,
 		}
 	}
+	if numPending > 0 {
+		var plural string
+		if numPending > 1 {
+			plural = "s"
+		}
+		c.progressf(ctx, "  - %d more task%s to run in later batches", numPending, plural)
+	}
 	if !c.background {
 		c.progressf(ctx, "")
 	}
-	if numTasks == 0 {
+	if numTasks == 0 && numPending == 0 {
 		if forceColor {
 			ctx.Infof("Operation %s failed to schedule any tasks:\n%s", opIDColored, colorVal(output.ErrorHighlight, strings.Join(enqueueErrs, "\n")))
 		} else {
@@ -222,6 +266,18 @@ func (c *runCommandBase) processOperationResults(ctx *cmd.Context, forceColor bo
 		}
 		return nil
 	}
+	if numPending > 0 {
+		var err error
+		runningTasks, err = c.waitForRollout(ctx, operationID, runningTasks)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, result := range runningTasks {
+			info[result.receiverId()] = map[string]string{
+				"id": result.task,
+			}
+		}
+	}
 	failed, err := c.waitForTasks(ctx, runningTasks, info)
 	if err != nil {
 		return errors.Trace(err)
@@ -338,6 +394,65 @@ func (c *runCommandBase) waitForTasks(ctx *cmd.Context, runningTasks []enqueuedA
 	return failed, c.out.Write(ctx, info)
 }
 
+// waitForRollout waits for the controller to enqueue the remaining
+// batches of a rolling operation, returning the tasks it has enqueued
+// once the operation has finished. The maximum wait applies to each
+// batch in turn.
+func (c *runCommandBase) waitForRollout(ctx *cmd.Context, operationID string, tasks []enqueuedAction) ([]enqueuedAction, error) {
+	var wait clock.Timer
+	if c.wait < 0 {
+		// Indefinite wait. Discard the tick.
+		wait = c.clock.NewTimer(0 * time.Second)
+		<-wait.Chan()
+	} else {
+		wait = c.clock.NewTimer(c.wait)
+	}
+
+	known := set.NewStrings()
+	for _, t := range tasks {
+		known.Add(t.task)
+	}
+	batch := 1
+	tick := c.clock.NewTimer(resultPollTime)
+	for {
+		op, err := c.api.Operation(operationID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if op.Rollout != nil && op.Rollout.BatchesEnqueued > batch {
+			batch = op.Rollout.BatchesEnqueued
+			c.progressf(ctx, "Started batch %d of %d", batch, op.Rollout.Batches)
+		}
+		for _, a := range op.Actions {
+			if a.Action == nil || known.Contains(a.Action.ID) {
+				continue
+			}
+			known.Add(a.Action.ID)
+			task := enqueuedAction{task: a.Action.ID, receiver: a.Action.Receiver}
+			tasks = append(tasks, task)
+			c.progressf(ctx, "  - task %s on %s", task.task, task.receiver)
+			if c.wait >= 0 {
+				wait.Reset(c.wait)
+			}
+		}
+		switch op.Status {
+		case params.ActionRunning, params.ActionPending:
+		default:
+			if op.Rollout != nil && op.Rollout.Stopped != "" {
+				ctx.Infof("Operation %s: %s", operationID, op.Rollout.Stopped)
+			}
+			return tasks, nil
+		}
+
+		select {
+		case <-wait.Chan():
+			return nil, errors.Errorf("timed out waiting for batch %d of operation %s", batch, operationID)
+		case <-tick.Chan():
+			tick.Reset(resultPollTime)
+		}
+	}
+}
+
 func (c *runCommandBase) handleTimeout(tasks []enqueuedAction, got set.Strings) error {
 	want := set.NewStrings()
 	for _, t := range tasks {
@@ -733,6 +848,85 @@ func convertActionOutput(output map[string]interface{}) (map[string]interface{},
 	return values, code
 }
 
+// parseActionArgs parses key.key.key...=value arguments into their keys
+// followed by the value.
+func parseActionArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// actionParameters returns the parameters of an action read from the
+// params file, if any, overridden by the parsed key-value arguments.
+// The argument values are parsed as YAML unless parseStrings is set.
+func actionParameters(
+	ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool,
+) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return typedConformantParams, nil
+}
+
 // addValueToMap adds the given value to the map on which the method is run.
 // This allows us to merge maps such as {foo: {bar: baz}} and {foo: {baz: faz}}
 // into {foo: {bar: baz, baz: faz}}.
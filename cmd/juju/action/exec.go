@@ -111,6 +111,12 @@ sequentially, but in parallel with other groups. This is done using
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+To run the commands on a few targets at a time rather than on all of them at
+once, use --batch-size. The controller runs each batch once the previous one
+has finished, waiting --batch-delay in between, and runs the commands on
+application leaders last. With --stop-on-failure, no further batches are run
+once a task has failed. The rollout carries on if the client disconnects.
+
 Since juju exec creates tasks, you can query for the status of commands
 started with juju run by calling 
 "juju operations --machines <id>,... --actions juju-exec".
@@ -121,6 +127,8 @@ those arguments. For example:
 
     juju exec --all -- hostname -f
 
+    juju exec --application mysql --batch-size 1 --stop-on-failure -- ./restart.sh
+
 `
 
 // Info implements Command.Info.
@@ -241,7 +249,10 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 	}
 
 	var runResults actionapi.EnqueuedActions
-	if c.all {
+	rollout := c.rollout()
+	if c.all && rollout != nil {
+		runResults, err = c.api.RunOnAllMachinesInBatches(c.commands, c.wait, *rollout)
+	} else if c.all {
 		runResults, err = c.api.RunOnAllMachines(c.commands, c.wait)
 	} else {
 		runParams := actionapi.RunParams{
@@ -252,6 +263,7 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 			Units:          c.units,
 			Parallel:       &c.parallel,
 			ExecutionGroup: &c.executionGroup,
+			Rollout:        rollout,
 		}
 		if c.operator {
 			if modelType != model.CAAS {
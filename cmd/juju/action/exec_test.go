@@ -351,6 +351,62 @@ func (s *ExecSuite) TestAllMachines(c *gc.C) {
 	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *ExecSuite) TestAllMachinesInBatches(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	fakeClient.actionResults = []actionapi.ActionResult{{
+		Action: &actionapi.Action{
+			ID:       validActionId,
+			Receiver: "machine-0",
+		},
+		Output: map[string]interface{}{
+			"stdout": "megatron",
+		},
+		Status: "completed",
+	}}
+	fakeClient.machines = set.NewStrings("0")
+
+	runCmd, _ := newTestExecCommand(testClock(), model.IAAS)
+	context, err := cmdtesting.RunCommand(c, runCmd,
+		"--all", "--batch-size=2", "--batch-delay=10s", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "megatron\n")
+	c.Check(fakeClient.rollout, jc.DeepEquals, &actionapi.Rollout{
+		BatchSize:  2,
+		BatchDelay: 10 * time.Second,
+	})
+}
+
+func (s *ExecSuite) TestExecForUnitsInBatches(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	fakeClient.actionResults = []actionapi.ActionResult{{
+		Action: &actionapi.Action{
+			ID:       validActionId,
+			Receiver: "unit-mysql-0",
+		},
+		Output: map[string]interface{}{
+			"stdout": "bumblebee",
+		},
+		Status: "completed",
+	}}
+
+	runCmd, _ := newTestExecCommand(testClock(), model.IAAS)
+	context, err := cmdtesting.RunCommand(c, runCmd,
+		"--unit=mysql/0", "--batch-size=1", "--stop-on-failure", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "bumblebee\n")
+	c.Assert(fakeClient.execParams, gc.NotNil)
+	c.Check(fakeClient.execParams.Rollout, jc.DeepEquals, &actionapi.Rollout{
+		BatchSize:     1,
+		StopOnFailure: true,
+	})
+}
+
 func (s *ExecSuite) TestAllMachinesWithError(c *gc.C) {
 	fakeClient := &fakeAPIClient{}
 	restore := s.patchAPIClient(fakeClient)
@@ -306,9 +306,19 @@ type operationInfo struct {
 	Error   string              `yaml:"error,omitempty" json:"error,omitempty"`
 	Action  *actionSummary      `yaml:"action,omitempty" json:"action,omitempty"`
 	Timing  timingInfo          `yaml:"timing,omitempty" json:"timing,omitempty"`
+	Rollout *rolloutInfo        `yaml:"rollout,omitempty" json:"rollout,omitempty"`
 	Tasks   map[string]taskInfo `yaml:"tasks,omitempty" json:"tasks,omitempty"`
 }
 
+type rolloutInfo struct {
+	BatchSize      int    `yaml:"batch-size" json:"batch-size"`
+	BatchDelay     string `yaml:"batch-delay,omitempty" json:"batch-delay,omitempty"`
+	StopOnFailure  bool   `yaml:"stop-on-failure,omitempty" json:"stop-on-failure,omitempty"`
+	Batches        int    `yaml:"batches" json:"batches"`
+	BatchesStarted int    `yaml:"batches-started" json:"batches-started"`
+	Stopped        string `yaml:"stopped,omitempty" json:"stopped,omitempty"`
+}
+
 type timingInfo struct {
 	Enqueued  string `yaml:"enqueued,omitempty" json:"enqueued,omitempty"`
 	Started   string `yaml:"started,omitempty" json:"started,omitempty"`
@@ -347,6 +357,18 @@ func formatOperationResult(operation actionapi.Operation, utc bool) operationInf
 	if err := operation.Error; err != nil {
 		result.Error = err.Error()
 	}
+	if rollout := operation.Rollout; rollout != nil {
+		result.Rollout = &rolloutInfo{
+			BatchSize:      rollout.BatchSize,
+			StopOnFailure:  rollout.StopOnFailure,
+			Batches:        rollout.Batches,
+			BatchesStarted: rollout.BatchesEnqueued,
+			Stopped:        rollout.Stopped,
+		}
+		if rollout.BatchDelay > 0 {
+			result.Rollout.BatchDelay = rollout.BatchDelay.String()
+		}
+	}
 	var singleAction actionSummary
 	haveSingleAction := true
 	for i, task := range operation.Actions {
@@ -0,0 +1,172 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	actionapi "github.com/juju/juju/api/client/action"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/actions"
+)
+
+// NewListSchedulesCommand returns a command which lists the action
+// schedules of a model.
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+	utc bool
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are run, along with when each is next due
+and the outcome of its most recent run. Each run which was not skipped started
+an operation, which can be seen with 'juju show-operation <ID>'.
+
+The yaml and json formats include the most recent runs of each schedule.
+`
+
+const listSchedulesExamples = `
+    juju schedules
+    juju schedules --format yaml
+    juju schedules --utc
+`
+
+// SetFlags implements Command.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "plain", map[string]cmd.Formatter{
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+		"plain": c.formatTabular,
+	})
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+}
+
+// Info implements Command.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "schedules",
+		Purpose:  "Lists the schedules on which actions are run.",
+		Doc:      listSchedulesDoc,
+		Aliases:  []string{"list-schedules"},
+		Examples: listSchedulesExamples,
+		SeeAlso: []string{
+			"add-schedule",
+			"remove-schedule",
+			"operations",
+			"show-operation",
+		},
+	})
+}
+
+// Init implements Command.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 {
+		ctx.Infof("no schedules")
+		return nil
+	}
+	if c.out.Name() == "plain" {
+		return c.out.Write(ctx, schedules)
+	}
+	out := make(map[string]scheduleInfo)
+	for _, schedule := range schedules {
+		out[schedule.Name] = formatSchedule(schedule, c.utc)
+	}
+	return c.out.Write(ctx, out)
+}
+
+func (c *listSchedulesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]actionapi.Schedule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Name", "Action", "Targets", "Schedule", "Next run", "Last run", "Result")
+	for _, schedule := range schedules {
+		w.Print(schedule.Name, schedule.Action, strings.Join(schedule.Targets, ","))
+		w.Print(scheduleString(schedule))
+		w.Print(formatTimestamp(schedule.NextRun, false, c.utc, true))
+		if n := len(schedule.Runs); n > 0 {
+			last := schedule.Runs[n-1]
+			w.Print(formatTimestamp(last.Time, false, c.utc, true))
+			if last.Skipped != "" {
+				w.Println("skipped: " + last.Skipped)
+			} else {
+				w.Println("operation " + last.OperationID)
+			}
+		} else {
+			w.Println("", "")
+		}
+	}
+	return tw.Flush()
+}
+
+func scheduleString(schedule actionapi.Schedule) string {
+	return actions.Schedule{Cron: schedule.Cron, Interval: schedule.Interval}.String()
+}
+
+type scheduleInfo struct {
+	Action      string                 `yaml:"action" json:"action"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Targets     []string               `yaml:"targets" json:"targets"`
+	Schedule    string                 `yaml:"schedule" json:"schedule"`
+	Concurrency string                 `yaml:"concurrency" json:"concurrency"`
+	Created     string                 `yaml:"created,omitempty" json:"created,omitempty"`
+	NextRun     string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	Runs        []scheduledRunInfo     `yaml:"runs,omitempty" json:"runs,omitempty"`
+}
+
+type scheduledRunInfo struct {
+	Time      string `yaml:"time" json:"time"`
+	Operation string `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Skipped   string `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+}
+
+func formatSchedule(schedule actionapi.Schedule, utc bool) scheduleInfo {
+	info := scheduleInfo{
+		Action:      schedule.Action,
+		Parameters:  schedule.Parameters,
+		Targets:     schedule.Targets,
+		Schedule:    scheduleString(schedule),
+		Concurrency: schedule.ConcurrencyPolicy,
+		Created:     formatTimestamp(schedule.Created, false, utc, false),
+		NextRun:     formatTimestamp(schedule.NextRun, false, utc, false),
+	}
+	for _, run := range schedule.Runs {
+		info.Runs = append(info.Runs, scheduledRunInfo{
+			Time:      formatTimestamp(run.Time, false, utc, false),
+			Operation: run.OperationID,
+			Skipped:   run.Skipped,
+		})
+	}
+	return info
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	actionapi "github.com/juju/juju/api/client/action"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ListSchedulesSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ListSchedulesSuite{})
+
+var created = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func testSchedules() []actionapi.Schedule {
+	return []actionapi.Schedule{{
+		Name:              "hourly",
+		Interval:          time.Hour,
+		Targets:           []string{"mysql/leader", "redis"},
+		Action:            "backup",
+		ConcurrencyPolicy: "forbid",
+		Created:           created,
+		NextRun:           created.Add(3 * time.Hour),
+		Runs: []actionapi.ScheduledRun{
+			{Time: created.Add(time.Hour), OperationID: "1"},
+			{Time: created.Add(2 * time.Hour), Skipped: "operation 1 of the previous run has not finished"},
+		},
+	}, {
+		Name:              "nightly",
+		Cron:              "0 3 * * *",
+		Targets:           []string{"mysql"},
+		Action:            "vacuum",
+		Parameters:        map[string]interface{}{"full": true},
+		ConcurrencyPolicy: "allow",
+		Created:           created,
+		NextRun:           created.Add(3 * time.Hour),
+	}}
+}
+
+func (s *ListSchedulesSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewListSchedulesCommandForTest(s.store), []string{"-m", "admin", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ListSchedulesSuite) TestRunPlain(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: testSchedules()})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name     Action  Targets             Schedule      Next run             Last run             Result\n"+
+		"hourly   backup  mysql/leader,redis  every 1h0m0s  2024-05-01T03:00:00  2024-05-01T02:00:00  skipped: operation 1 of the previous run has not finished\n"+
+		"nightly  vacuum  mysql               0 3 * * *     2024-05-01T03:00:00                       \n")
+}
+
+func (s *ListSchedulesSuite) TestRunYaml(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: testSchedules()})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+hourly:
+  action: backup
+  targets:
+  - mysql/leader
+  - redis
+  schedule: every 1h0m0s
+  concurrency: forbid
+  created: 2024-05-01 00:00:00 +0000 UTC
+  next-run: 2024-05-01 03:00:00 +0000 UTC
+  runs:
+  - time: 2024-05-01 01:00:00 +0000 UTC
+    operation: "1"
+  - time: 2024-05-01 02:00:00 +0000 UTC
+    skipped: operation 1 of the previous run has not finished
+nightly:
+  action: vacuum
+  parameters:
+    full: true
+  targets:
+  - mysql
+  schedule: 0 3 * * *
+  concurrency: allow
+  created: 2024-05-01 00:00:00 +0000 UTC
+  next-run: 2024-05-01 03:00:00 +0000 UTC
+`[1:])
+}
+
+func (s *ListSchedulesSuite) TestRunNone(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no schedules\n")
+}
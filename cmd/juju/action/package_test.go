@@ -148,6 +148,9 @@ type fakeAPIClient struct {
 	charmActions       map[string]actionapi.ActionSpec
 	machines           set.Strings
 	execParams         *actionapi.RunParams
+	rollout            *actionapi.Rollout
+	schedules          []actionapi.Schedule
+	removedSchedule    string
 	apiErr             error
 	logMessageCh       chan []string
 	waitForResults     chan bool
@@ -173,6 +176,16 @@ func (c *fakeAPIClient) EnqueueOperation(args []actionapi.Action) (actionapi.Enq
 		Actions:     actions}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueOperationInBatches(args []actionapi.Action, rollout actionapi.Rollout) (actionapi.EnqueuedActions, error) {
+	c.rollout = &rollout
+	result, err := c.EnqueueOperation(args)
+	// Only the first batch is enqueued straight away.
+	for i := rollout.BatchSize; i < len(result.Actions); i++ {
+		result.Actions[i] = actionapi.ActionResult{Status: "pending"}
+	}
+	return result, err
+}
+
 func (c *fakeAPIClient) Cancel(_ []string) ([]actionapi.ActionResult, error) {
 	return c.actionResults, c.apiErr
 }
@@ -336,6 +349,11 @@ func (c *fakeAPIClient) RunOnAllMachines(_ string, _ time.Duration) (actionapi.E
 	return result, nil
 }
 
+func (c *fakeAPIClient) RunOnAllMachinesInBatches(commands string, timeout time.Duration, rollout actionapi.Rollout) (actionapi.EnqueuedActions, error) {
+	c.rollout = &rollout
+	return c.RunOnAllMachines(commands, timeout)
+}
+
 func (c *fakeAPIClient) Run(runParams actionapi.RunParams) (actionapi.EnqueuedActions, error) {
 	var result actionapi.EnqueuedActions
 
@@ -362,3 +380,17 @@ func (c *fakeAPIClient) Run(runParams actionapi.RunParams) (actionapi.EnqueuedAc
 
 	return result, nil
 }
+
+func (c *fakeAPIClient) AddSchedule(schedule actionapi.Schedule) error {
+	c.schedules = append(c.schedules, schedule)
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() ([]actionapi.Schedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedule(name string) error {
+	c.removedSchedule = name
+	return c.apiErr
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+// NewRemoveScheduleCommand returns a command which removes an action
+// schedule.
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+type removeScheduleCommand struct {
+	ActionCommandBase
+	name string
+}
+
+const removeScheduleDoc = `
+Remove a schedule on which an action is run. Operations already started by the
+schedule are not affected; use 'juju cancel-task' to cancel their tasks.
+`
+
+// Info implements Command.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-schedule",
+		Args:     "<schedule-name>",
+		Purpose:  "Remove an action schedule.",
+		Doc:      removeScheduleDoc,
+		Examples: "    juju remove-schedule nightly-backup\n",
+		SeeAlso: []string{
+			"add-schedule",
+			"schedules",
+		},
+	})
+}
+
+// Init implements Command.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.name = args[0]
+	if !actions.IsValidScheduleName(c.name) {
+		return errors.NotValidf("schedule name %q", c.name)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveSchedule(c.name); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Removed schedule %q.", c.name)
+	return nil
+}
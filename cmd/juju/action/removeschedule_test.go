@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type RemoveScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&RemoveScheduleSuite{})
+
+func (s *RemoveScheduleSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no schedule name specified",
+	}, {
+		args: []string{"Nightly"},
+		err:  `schedule name "Nightly" not valid`,
+	}, {
+		args: []string{"nightly", "hourly"},
+		err:  `unrecognized args: \["hourly"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := cmdtesting.InitCommand(action.NewRemoveScheduleCommandForTest(s.store), append([]string{"-m", "admin"}, t.args...))
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *RemoveScheduleSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.removedSchedule, gc.Equals, "nightly")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed schedule \"nightly\".\n")
+}
+
+func (s *RemoveScheduleSuite) TestRunError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{apiErr: errors.New(`schedule "nightly" not found`)})
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "nightly")
+	c.Assert(err, gc.ErrorMatches, `schedule "nightly" not found`)
+}
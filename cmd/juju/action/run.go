@@ -11,11 +11,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
-	"gopkg.in/yaml.v2"
 
 	actionapi "github.com/juju/juju/api/client/action"
 	jujucmd "github.com/juju/juju/cmd"
-	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -56,6 +54,14 @@ use the --background option.
 
 To set the maximum time to wait for a action to complete, use the --wait option.
 
+To run the action on a few units at a time rather than on all of them at once,
+use the --batch-size option. The controller runs each batch once the previous
+one has finished, waiting --batch-delay in between, and runs the action on
+application leaders last. With --stop-on-failure, no further batches are run
+once a task has failed. The rollout carries on if the client disconnects, and
+its progress can be seen with 'juju show-operation <ID>'. When waiting for the
+results, --wait applies to each batch in turn.
+
 By default, a single action will output its failure message if the action fails,
 followed by any results set by the action. For multiple actions, each action's
 results will be printed with the action id and action status. To see more detailed
@@ -88,6 +94,7 @@ const runExamples = `
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
     juju run mysql/leader backup
+    juju run mysql/0 mysql/1 mysql/2 backup --batch-size 1 --batch-delay 30s --stop-on-failure
     juju show-operation <ID>
     juju run mysql/3 backup --params parameters.yml
     juju run mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
@@ -142,23 +149,8 @@ func (c *runCommand) Init(args []string) (err error) {
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(c.unitReceivers)+1:] {
-		thisArg := strings.SplitN(arg, "=", 2)
-		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key.key.key...=value", arg)
-		}
-		keySlice := strings.Split(thisArg[0], ".")
-		// check each key for validity
-		for _, key := range keySlice {
-			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
-					"and contain only lowercase alphanumeric and hyphens", key)
-			}
-		}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
-	}
-	return nil
+	c.args, err = parseActionArgs(args[len(c.unitReceivers)+1:])
+	return errors.Trace(err)
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -175,54 +167,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *runCommand) enqueueActions(ctx *cmd.Context) (*actionapi.EnqueuedActions, error) {
-	actionParams := map[string]interface{}{}
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return nil, errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := actionParameters(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
-	}
 	actions := make([]actionapi.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -233,7 +181,12 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (*actionapi.EnqueuedAction
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
 	}
-	results, err := c.api.EnqueueOperation(actions)
+	var results actionapi.EnqueuedActions
+	if rollout := c.rollout(); rollout != nil {
+		results, err = c.api.EnqueueOperationInBatches(actions, *rollout)
+	} else {
+		results, err = c.api.EnqueueOperation(actions)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
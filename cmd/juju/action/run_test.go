@@ -210,6 +210,14 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			{"foo", "baz", "bo", "y"},
 			{"bar", "foo", "hello"},
 		},
+	}, {
+		should:      "fail with negative batch size",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "-1"},
+		expectError: "batch size -1 not valid",
+	}, {
+		should:      "fail with --stop-on-failure and no batch size",
+		args:        []string{validUnitId, "valid-action-name", "--stop-on-failure"},
+		expectError: "--batch-delay and --stop-on-failure require --batch-size",
 	}, {
 		should:       "work with leader identifier",
 		args:         []string{"mysql/leader", "valid-action-name"},
@@ -950,6 +958,114 @@ mysql/1:
 	}
 }
 
+func (s *RunSuite) TestRunInBatches(c *gc.C) {
+	results := []actionapi.ActionResult{{
+		Action: &actionapi.Action{
+			ID:       validActionId,
+			Receiver: names.NewUnitTag(validUnitId).String(),
+			Name:     "some-action",
+		},
+		Status: "completed",
+		Output: map[string]interface{}{"outcome": "success"},
+	}, {
+		Action: &actionapi.Action{
+			ID:       validActionId2,
+			Receiver: names.NewUnitTag(validUnitId2).String(),
+			Name:     "some-action",
+		},
+		Status: "completed",
+		Output: map[string]interface{}{"outcome": "success"},
+	}}
+	fakeClient := &fakeAPIClient{
+		actionResults: results,
+		operationResults: actionapi.Operations{
+			Operations: []actionapi.Operation{{
+				ID:      "1",
+				Status:  "completed",
+				Actions: results,
+				Rollout: &actionapi.RolloutInfo{
+					Rollout:         actionapi.Rollout{BatchSize: 1, BatchDelay: time.Second, StopOnFailure: true},
+					Batches:         2,
+					BatchesEnqueued: 2,
+				},
+			}},
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	runCmd, _ := action.NewRunCommandForTest(s.store, s.clock, nil)
+	ctx, err := cmdtesting.RunCommand(c, runCmd,
+		"-m", "admin", validUnitId, validUnitId2, "some-action", "--format", "yaml",
+		"--batch-size", "1", "--batch-delay", "1s", "--stop-on-failure",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.rollout, jc.DeepEquals, &actionapi.Rollout{
+		BatchSize:     1,
+		BatchDelay:    time.Second,
+		StopOnFailure: true,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Running operation 1 with 1 task
+  - task 1 on unit-mysql-0
+  - 1 more task to run in later batches
+
+Started batch 2 of 2
+  - task 2 on unit-mysql-1
+Waiting for task 1...
+Waiting for task 2...
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  id: "1"
+  results:
+    outcome: success
+  status: completed
+  unit: mysql/0
+mysql/1:
+  id: "2"
+  results:
+    outcome: success
+  status: completed
+  unit: mysql/1
+`[1:])
+}
+
+func (s *RunSuite) TestRunInBatchesTimeout(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []actionapi.ActionResult{{
+			Action: &actionapi.Action{
+				ID:       validActionId,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}, {
+			Action: &actionapi.Action{
+				ID:       validActionId2,
+				Receiver: names.NewUnitTag(validUnitId2).String(),
+			},
+		}},
+		operationResults: actionapi.Operations{
+			Operations: []actionapi.Operation{{
+				ID:     "1",
+				Status: "running",
+				Rollout: &actionapi.RolloutInfo{
+					Rollout:         actionapi.Rollout{BatchSize: 1},
+					Batches:         2,
+					BatchesEnqueued: 1,
+				},
+			}},
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	runCmd, _ := action.NewRunCommandForTest(s.store, s.clock, nil)
+	_, err := cmdtesting.RunCommand(c, runCmd,
+		"-m", "admin", validUnitId, validUnitId2, "some-action", "--batch-size", "1", "--wait", "3s",
+	)
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for batch 1 of operation 1")
+}
+
 func (s *RunSuite) TestVerbosity(c *gc.C) {
 	tests := []struct {
 		about   string
@@ -268,6 +268,59 @@ tasks:
     results:
       foo:
         bar: baz
+`[1:],
+	}, {
+		should:            "show the batches of a stopped rollout",
+		withClientQueryID: operationId,
+		withAPIResponse: actionapi.Operations{
+			Operations: []actionapi.Operation{{
+				ID:      operationId,
+				Summary: "an operation",
+				Status:  "failed",
+				Fail:    "rollout stopped after batch 1 of 3 failed",
+				Actions: []actionapi.ActionResult{{
+					Action: &actionapi.Action{
+						ID:       "69",
+						Name:     "backup",
+						Receiver: "foo/0",
+					},
+					Status: "failed",
+				}},
+				Rollout: &actionapi.RolloutInfo{
+					Rollout: actionapi.Rollout{
+						BatchSize:     1,
+						BatchDelay:    30 * time.Second,
+						StopOnFailure: true,
+					},
+					Batches:         3,
+					BatchesEnqueued: 1,
+					Stopped:         "rollout stopped after batch 1 of 3 failed",
+				},
+				Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+				Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+			}},
+		},
+		expectedOutput: `
+summary: an operation
+status: failed
+fail: rollout stopped after batch 1 of 3 failed
+action:
+  name: backup
+  parameters: {}
+timing:
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  completed: 2015-02-14 08:15:30 +0000 UTC
+rollout:
+  batch-size: 1
+  batch-delay: 30s
+  stop-on-failure: true
+  batches: 3
+  batches-started: 1
+  stopped: rollout stopped after batch 1 of 3 failed
+tasks:
+  "69":
+    host: foo/0
+    status: failed
 `[1:],
 	}, {
 		should:            "watch, wait, get a result",
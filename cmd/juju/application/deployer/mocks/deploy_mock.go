@@ -69,6 +69,20 @@ func (mr *MockDeployerAPIMockRecorder) APICall(arg0, arg1, arg2, arg3, arg4, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APICall", reflect.TypeOf((*MockDeployerAPI)(nil).APICall), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// APICallContext mocks base method.
+func (m *MockDeployerAPI) APICallContext(arg0 context.Context, arg1 string, arg2 int, arg3, arg4 string, arg5, arg6 any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APICallContext", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// APICallContext indicates an expected call of APICallContext.
+func (mr *MockDeployerAPIMockRecorder) APICallContext(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APICallContext", reflect.TypeOf((*MockDeployerAPI)(nil).APICallContext), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // AddCharm mocks base method.
 func (m *MockDeployerAPI) AddCharm(arg0 *charm.URL, arg1 charm0.Origin, arg2 bool) (charm0.Origin, error) {
 	m.ctrl.T.Helper()
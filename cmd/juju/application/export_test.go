@@ -230,3 +230,11 @@ func NewConfigCommandForTest(api ApplicationAPI, store jujuclient.ClientStore) m
 	c.SetClientStore(store)
 	return c
 }
+
+func NewSetAutoscaleCommandForTest(api setAutoscaleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setAutoscaleCommand{newAPIFunc: func() (setAutoscaleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/rpc/params"
+)
+
+// defaultAutoscaleCooldown is how long to wait between scaling an
+// application, unless otherwise specified.
+const defaultAutoscaleCooldown = 5 * time.Minute
+
+// NewSetAutoscaleCommand returns a command which sets an application's
+// autoscale policy.
+func NewSetAutoscaleCommand() modelcmd.ModelCommand {
+	cmd := &setAutoscaleCommand{}
+	cmd.newAPIFunc = func() (setAutoscaleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// setAutoscaleCommand is responsible for setting an application's
+// autoscale policy.
+type setAutoscaleCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (setAutoscaleAPI, error)
+	applicationName string
+
+	minUnits     int
+	maxUnits     int
+	targetCPU    int
+	targetMemory int
+	cooldown     time.Duration
+	remove       bool
+}
+
+const setAutoscaleDoc = `
+Scale a k8s application automatically, keeping the average CPU or memory
+usage of its units close to a target.
+
+The usage of each unit is measured by the Kubernetes metrics server, which
+must be installed in the cluster, as a percentage of the resources the unit
+requests. Units request resources with the cpu-power and mem constraints,
+which must be set for the usage to be measured.
+
+As with the Kubernetes HorizontalPodAutoscaler, the number of units is
+changed in proportion to how far the usage is from the target. When both
+CPU and memory targets are given, the larger number of units is used. The
+number of units is always between the --min and --max values, and is not
+changed again until the --cooldown period has passed.
+
+While an application is autoscaled, its current and target number of units
+are shown by "juju status --format yaml". Scaling an autoscaled application
+with "juju scale-application" only lasts until it is next autoscaled.
+
+Use --remove to stop autoscaling the application, leaving it at its current
+scale.
+
+Autoscale policies are not migrated with the model, so must be set again
+once the model has been migrated to another controller.
+`
+
+const setAutoscaleExamples = `
+    juju set-autoscale mariadb --min 1 --max 5 --cpu 60
+    juju set-autoscale mariadb --min 2 --max 10 --cpu 60 --memory 80 --cooldown 10m
+    juju set-autoscale mariadb --remove
+`
+
+// Info implements cmd.Command.
+func (c *setAutoscaleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "set-autoscale",
+		Args:     "<application>",
+		Purpose:  "Scale a k8s application automatically with its resource usage.",
+		Doc:      setAutoscaleDoc,
+		Examples: setAutoscaleExamples,
+		SeeAlso: []string{
+			"scale-application",
+			"status",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *setAutoscaleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.minUnits, "min", 1, "The minimum number of units")
+	f.IntVar(&c.maxUnits, "max", 0, "The maximum number of units")
+	f.IntVar(&c.targetCPU, "cpu", 0, "The target CPU usage of the units, as a percentage of the CPU they request")
+	f.IntVar(&c.targetMemory, "memory", 0, "The target memory usage of the units, as a percentage of the memory they request")
+	f.DurationVar(&c.cooldown, "cooldown", defaultAutoscaleCooldown, "How long to wait after scaling the application before scaling it again")
+	f.BoolVar(&c.remove, "remove", false, "Stop autoscaling the application")
+}
+
+// Init implements cmd.Command.
+func (c *setAutoscaleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if c.remove {
+		if c.maxUnits != 0 || c.targetCPU != 0 || c.targetMemory != 0 {
+			return errors.New("--remove cannot be used with --max, --cpu or --memory")
+		}
+		return cmd.CheckEmpty(args[1:])
+	}
+	if c.maxUnits == 0 {
+		return errors.New("--max must be specified")
+	}
+	if c.targetCPU == 0 && c.targetMemory == 0 {
+		return errors.New("at least one of --cpu or --memory must be specified")
+	}
+	if err := c.policy().Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setAutoscaleCommand) policy() coreapplication.AutoscalePolicy {
+	return coreapplication.AutoscalePolicy{
+		MinUnits:     c.minUnits,
+		MaxUnits:     c.maxUnits,
+		TargetCPU:    c.targetCPU,
+		TargetMemory: c.targetMemory,
+		Cooldown:     c.cooldown,
+	}
+}
+
+type setAutoscaleAPI interface {
+	Close() error
+	SetAutoscalePolicy(string, *params.AutoscalePolicy) error
+}
+
+// Run implements cmd.Command.
+func (c *setAutoscaleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var policy *params.AutoscalePolicy
+	if !c.remove {
+		policy = &params.AutoscalePolicy{
+			MinUnits:     c.minUnits,
+			MaxUnits:     c.maxUnits,
+			TargetCPU:    c.targetCPU,
+			TargetMemory: c.targetMemory,
+			Cooldown:     c.cooldown,
+		}
+	}
+	if err := client.SetAutoscalePolicy(c.applicationName, policy); err != nil {
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not set autoscale policy for application %q", c.applicationName), block.BlockChange)
+	}
+	if c.remove {
+		ctx.Infof("%v is no longer autoscaled", c.applicationName)
+	} else {
+		ctx.Infof("%v autoscaled between %d and %d units", c.applicationName, c.minUnits, c.maxUnits)
+	}
+	return nil
+}
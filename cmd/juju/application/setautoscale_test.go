@@ -0,0 +1,142 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/rpc/params"
+)
+
+type SetAutoscaleSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockSetAutoscaleAPI
+}
+
+var _ = gc.Suite(&SetAutoscaleSuite{})
+
+type mockSetAutoscaleAPI struct {
+	*testing.Stub
+}
+
+func (s mockSetAutoscaleAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockSetAutoscaleAPI) SetAutoscalePolicy(appName string, policy *params.AutoscalePolicy) error {
+	s.MethodCall(s, "SetAutoscalePolicy", appName, policy)
+	return s.NextErr()
+}
+
+func (s *SetAutoscaleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetAutoscaleAPI{Stub: &testing.Stub{}}
+}
+
+func (s *SetAutoscaleSuite) runSetAutoscale(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewSetAutoscaleCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscale(c *gc.C) {
+	ctx, err := s.runSetAutoscale(c, "foo", "--max", "5", "--cpu", "60")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stderr := cmdtesting.Stderr(ctx)
+	out := strings.Replace(stderr, "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo autoscaled between 1 and 5 units`)
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", &params.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  5 * time.Minute,
+	})
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscaleAllOptions(c *gc.C) {
+	_, err := s.runSetAutoscale(c, "foo", "--min", "2", "--max", "10", "--cpu", "60", "--memory", "80", "--cooldown", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", &params.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     10,
+		TargetCPU:    60,
+		TargetMemory: 80,
+		Cooldown:     10 * time.Minute,
+	})
+}
+
+func (s *SetAutoscaleSuite) TestRemoveAutoscale(c *gc.C) {
+	ctx, err := s.runSetAutoscale(c, "foo", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stderr := cmdtesting.Stderr(ctx)
+	out := strings.Replace(stderr, "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo is no longer autoscaled`)
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", (*params.AutoscalePolicy)(nil))
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscaleBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runSetAutoscale(c, "foo", "--max", "5", "--cpu", "60")
+	c.Assert(err.Error(), jc.Contains, `could not set autoscale policy for application "foo": nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscaleWrongModel(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, NewSetAutoscaleCommandForTest(s.mockAPI, store), "foo", "--max", "5", "--cpu", "60")
+	c.Assert(err, gc.ErrorMatches, `Juju command "set-autoscale" only supported on k8s container models`)
+}
+
+func (s *SetAutoscaleSuite) TestInvalidArgs(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: `no application specified`,
+	}, {
+		args: []string{"invalid:name", "--max", "5", "--cpu", "60"},
+		err:  `invalid application name "invalid:name"`,
+	}, {
+		args: []string{"foo", "--cpu", "60"},
+		err:  `--max must be specified`,
+	}, {
+		args: []string{"foo", "--max", "5"},
+		err:  `at least one of --cpu or --memory must be specified`,
+	}, {
+		args: []string{"foo", "--min", "6", "--max", "5", "--cpu", "60"},
+		err:  `max units 5 less than min units 6 not valid`,
+	}, {
+		args: []string{"foo", "--max", "5", "--cpu", "60", "--cooldown", "-1m"},
+		err:  `negative cooldown -1m0s not valid`,
+	}, {
+		args: []string{"foo", "--remove", "--max", "5"},
+		err:  `--remove cannot be used with --max, --cpu or --memory`,
+	}, {
+		args: []string{"foo", "bar", "--max", "5", "--cpu", "60"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		_, err := s.runSetAutoscale(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
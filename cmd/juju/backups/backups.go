@@ -27,6 +27,12 @@ type APIClient interface {
 	Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error)
 	// Download pulls the backup archive file.
 	Download(filename string) (io.ReadCloser, error)
+	// List returns the metadata of the scheduled backups.
+	List() ([]params.BackupsMetadataResult, error)
+	// Prune removes all but the newest keep full scheduled backups.
+	Prune(keep int) ([]params.BackupsMetadataResult, error)
+	// Restore restores the controller from a backup.
+	Restore(backupID, fileName string) (params.BackupsRestoreResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
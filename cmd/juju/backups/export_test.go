@@ -27,6 +27,14 @@ type DownloadCommand struct {
 	*downloadCommand
 }
 
+type ListCommand struct {
+	*listCommand
+}
+
+type RestoreCommand struct {
+	*restoreCommand
+}
+
 func NewCreateCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CreateCommand) {
 	c := &createCommand{}
 	c.SetClientStore(store)
@@ -38,3 +46,15 @@ func NewDownloadCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Down
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c), &DownloadCommand{c}
 }
+
+func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListCommand) {
+	c := &listCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ListCommand{c}
+}
+
+func NewRestoreCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RestoreCommand) {
+	c := &restoreCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RestoreCommand{c}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listDoc = `
+backups lists the backups taken by the controller on the schedule set by
+the backup-interval and backup-incremental-interval controller config.
+They are stored in the object store given by the backup-s3-endpoint
+controller config, or on the controller machine if there is none.
+
+Incremental backups only hold the database changes since the previous
+backup, and are restored on top of the full backup they build upon.
+
+With --prune, all but the newest full backups are removed first, along
+with the incremental backups building upon them. The number of full
+backups kept is given by --keep, or the backup-retention-count
+controller config if it is not specified.
+`
+
+const listExamples = `
+    juju backups
+    juju backups --format yaml
+    juju backups --prune
+    juju backups --prune --keep 3
+`
+
+// NewListCommand returns a command used to list the scheduled backups.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand is the sub-command for listing scheduled backups.
+type listCommand struct {
+	CommandBase
+	out cmd.Output
+
+	// Prune indicates that the oldest backups are to be removed.
+	Prune bool
+	// Keep is the number of full backups to keep when pruning.
+	Keep int
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "backups",
+		Aliases:  []string{"list-backups"},
+		Purpose:  "List or prune the scheduled backups of the controller.",
+		Doc:      listDoc,
+		Examples: listExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"controller-config",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.Prune, "prune", false, "Remove the oldest backups before listing")
+	f.IntVar(&c.Keep, "keep", 0, "The number of full backups to keep when pruning")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatBackupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Keep < 0 {
+		return errors.NotValidf("--keep %d", c.Keep)
+	}
+	if c.Keep != 0 && !c.Prune {
+		return errors.New("--keep can only be used with --prune")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// backupDetails is the representation of a backup written by the
+// command.
+type backupDetails struct {
+	ID       string    `json:"id" yaml:"id"`
+	Type     string    `json:"type" yaml:"type"`
+	Parent   string    `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Started  time.Time `json:"started" yaml:"started"`
+	Finished time.Time `json:"finished" yaml:"finished"`
+	Size     int64     `json:"size" yaml:"size"`
+	Checksum string    `json:"checksum" yaml:"checksum"`
+	Notes    string    `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.Prune {
+		removed, err := client.Prune(c.Keep)
+		for _, result := range removed {
+			ctx.Infof("removed backup %s", result.ID)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	results, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No backups to display.")
+		return nil
+	}
+	details := make([]backupDetails, len(results))
+	for i, result := range results {
+		details[i] = backupDetails{
+			ID:       result.ID,
+			Type:     "full",
+			Parent:   result.Parent,
+			Started:  result.Started,
+			Finished: result.Finished,
+			Size:     result.Size,
+			Checksum: result.Checksum,
+			Notes:    result.Notes,
+		}
+		if result.Parent != "" {
+			details[i].Type = "incremental"
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+// formatBackupsTabular writes a tabular summary of the backups.
+func formatBackupsTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.([]backupDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.SetColumnAlignRight(3)
+
+	w.Println("ID", "Type", "Started", "Size", "Parent", "Notes")
+	for _, d := range details {
+		w.Println(d.ID, d.Type, d.Started.Local().Format(time.RFC3339), d.Size, d.Parent, d.Notes)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+type listSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+	command        *backups.ListCommand
+	started        time.Time
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, s.command = backups.NewListCommandForTest(s.store)
+	s.started = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *listSuite) setList() *fakeAPIClient {
+	client := s.setSuccess()
+	client.list = []params.BackupsMetadataResult{{
+		ID:       "juju-backup-20230601-120000.tar.gz",
+		Started:  s.started,
+		Size:     1024,
+		Checksum: "abc",
+		Notes:    "scheduled backup",
+	}, {
+		ID:       "juju-backup-20230601-130000-incremental.tar.gz",
+		Started:  s.started.Add(time.Hour),
+		Size:     64,
+		Checksum: "def",
+		Parent:   "juju-backup-20230601-120000.tar.gz",
+	}}
+	return client
+}
+
+func (s *listSuite) TestInitKeepWithoutPrune(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--keep", "2")
+	c.Assert(err, gc.ErrorMatches, "--keep can only be used with --prune")
+}
+
+func (s *listSuite) TestInitNegativeKeep(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--prune", "--keep", "-1")
+	c.Assert(err, gc.ErrorMatches, "--keep -1 not valid")
+}
+
+func (s *listSuite) TestInitExtraArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *listSuite) TestListYAML(c *gc.C) {
+	client := s.setList()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "List")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: juju-backup-20230601-120000.tar.gz
+  type: full
+  started: 2023-06-01T12:00:00Z
+  finished: 0001-01-01T00:00:00Z
+  size: 1024
+  checksum: abc
+  notes: scheduled backup
+- id: juju-backup-20230601-130000-incremental.tar.gz
+  type: incremental
+  parent: juju-backup-20230601-120000.tar.gz
+  started: 2023-06-01T13:00:00Z
+  finished: 0001-01-01T00:00:00Z
+  size: 64
+  checksum: def
+`[1:])
+}
+
+func (s *listSuite) TestListTabular(c *gc.C) {
+	s.PatchValue(&time.Local, time.UTC)
+	s.setList()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                                              Type         Started               Size  Parent                              Notes
+juju-backup-20230601-120000.tar.gz              full         2023-06-01T12:00:00Z  1024                                      scheduled backup
+juju-backup-20230601-130000-incremental.tar.gz  incremental  2023-06-01T13:00:00Z    64  juju-backup-20230601-120000.tar.gz  
+`[1:])
+}
+
+func (s *listSuite) TestListEmpty(c *gc.C) {
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No backups to display.\n")
+}
+
+func (s *listSuite) TestPrune(c *gc.C) {
+	client := s.setList()
+	client.pruned = []params.BackupsMetadataResult{{ID: "juju-backup-20230531-120000.tar.gz"}}
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--prune", "--keep", "3", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Prune", "List")
+	client.CheckArgs(c, "3")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removed backup juju-backup-20230531-120000.tar.gz\n")
+}
+
+func (s *listSuite) TestPruneDefaultKeep(c *gc.C) {
+	client := s.setList()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--prune")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Prune", "List")
+	client.CheckArgs(c, "0")
+}
+
+func (s *listSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
@@ -163,6 +163,9 @@ func (s *BaseBackupsSuite) checkArchive(c *gc.C) {
 type fakeAPIClient struct {
 	metaresult *params.BackupsMetadataResult
 	archive    io.ReadCloser
+	list       []params.BackupsMetadataResult
+	pruned     []params.BackupsMetadataResult
+	restored   params.BackupsRestoreResult
 	err        error
 
 	calls []string
@@ -206,6 +209,32 @@ func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	return c.archive, nil
 }
 
+func (c *fakeAPIClient) List() ([]params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "List")
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.list, nil
+}
+
+func (c *fakeAPIClient) Prune(keep int) ([]params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "Prune")
+	c.args = append(c.args, fmt.Sprint(keep))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.pruned, nil
+}
+
+func (c *fakeAPIClient) Restore(backupID, fileName string) (params.BackupsRestoreResult, error) {
+	c.calls = append(c.calls, "Restore")
+	c.args = append(c.args, backupID, fileName)
+	if c.err != nil {
+		return params.BackupsRestoreResult{}, c.err
+	}
+	return c.restored, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
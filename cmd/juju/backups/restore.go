@@ -0,0 +1,142 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const restoreDoc = `
+restore-backup restores the controller databases, both mongo and the
+controller's Dqlite database, from a backup.
+
+The backup is either one of the scheduled backups listed by 'juju backups',
+given by its ID, or a backup archive already on the controller machine,
+given by its path with --file. An incremental backup is restored on top of
+the full backup it builds upon, along with the incremental backups taken
+between them.
+
+Only backups taken of the same controller, by the same major and minor
+version of juju, can be restored. The controller must have a single
+controller machine; use 'juju remove-machine' to remove the others first,
+and 'juju enable-ha' to restore them afterwards.
+
+If the controller addresses recorded in the backup differ from the current
+ones, the agents on each machine are updated over SSH to connect to the
+current addresses. Once the restore is complete, the controller agent
+restarts to pick up the restored state.
+
+All changes made to the controller since the backup was taken are lost,
+so you are asked for confirmation unless --no-prompt is given.
+`
+
+const restoreExamples = `
+    juju restore-backup juju-backup-20230601-120000.tar.gz
+    juju restore-backup --file /var/lib/juju/backups/juju-backup-20230601-120000.tar.gz
+`
+
+// NewRestoreCommand returns a command used to restore the controller
+// from a backup.
+func NewRestoreCommand() cmd.Command {
+	return modelcmd.Wrap(&restoreCommand{})
+}
+
+// restoreCommand is the sub-command for restoring a backup.
+type restoreCommand struct {
+	CommandBase
+	modelcmd.DestroyConfirmationCommandBase
+
+	// BackupID is the ID of the stored backup to restore.
+	BackupID string
+	// Filename is the path to the backup archive on the controller.
+	Filename string
+}
+
+// Info implements Command.Info.
+func (c *restoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "restore-backup",
+		Args:     "[<backup-id>]",
+		Purpose:  "Restore the controller from a backup.",
+		Doc:      restoreDoc,
+		Examples: restoreExamples,
+		SeeAlso: []string{
+			"backups",
+			"create-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.DestroyConfirmationCommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "file", "", "Path to a backup archive on the controller machine")
+}
+
+// Init implements Command.Init.
+func (c *restoreCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	id, err := cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.BackupID = id
+	if (c.BackupID == "") == (c.Filename == "") {
+		return errors.New("specify either a backup ID or --file")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.NeedsConfirmation() {
+		source := c.BackupID
+		if source == "" {
+			source = c.Filename
+		}
+		fmt.Fprintf(ctx.Stderr, "WARNING! This will replace the state of the controller with backup %s.\n", source)
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "restore")
+		}
+	}
+
+	result, err := client.Restore(c.BackupID, c.Filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, restored := range result.Restored {
+		ctx.Infof("restored backup %s", restored.ID)
+	}
+	var failed int
+	for _, machine := range result.Machines {
+		if machine.Error != nil {
+			failed++
+			ctx.Warningf("cannot update agents on machine %s in model %s: %v", machine.Machine, machine.Model, machine.Error)
+		}
+	}
+	if failed > 0 {
+		ctx.Warningf("update the API addresses in the agent config on the above machines by hand")
+	}
+	ctx.Infof("The controller agent is restarting; it may take a few minutes before it is available.")
+	return nil
+}
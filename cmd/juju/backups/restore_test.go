@@ -0,0 +1,117 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+type restoreSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+	command        *backups.RestoreCommand
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, s.command = backups.NewRestoreCommandForTest(s.store)
+}
+
+func (s *restoreSuite) setRestored() *fakeAPIClient {
+	client := s.setSuccess()
+	client.restored = params.BackupsRestoreResult{
+		Restored: []params.BackupsMetadataResult{
+			{ID: "juju-backup-20230601-120000.tar.gz"},
+			{ID: "juju-backup-20230601-130000-incremental.tar.gz"},
+		},
+	}
+	return client
+}
+
+func (s *restoreSuite) TestInitNoBackup(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, gc.ErrorMatches, "specify either a backup ID or --file")
+}
+
+func (s *restoreSuite) TestInitBackupIDAndFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "juju-backup-a.tar.gz", "--file", "/tmp/juju-backup-a.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "specify either a backup ID or --file")
+}
+
+func (s *restoreSuite) TestInitExtraArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "juju-backup-a.tar.gz", "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *restoreSuite) TestRestoreBackupID(c *gc.C) {
+	client := s.setRestored()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "juju-backup-20230601-130000-incremental.tar.gz", "--no-prompt")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Restore")
+	client.CheckArgs(c, "juju-backup-20230601-130000-incremental.tar.gz", "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+restored backup juju-backup-20230601-120000.tar.gz
+restored backup juju-backup-20230601-130000-incremental.tar.gz
+The controller agent is restarting; it may take a few minutes before it is available.
+`[1:])
+}
+
+func (s *restoreSuite) TestRestoreFile(c *gc.C) {
+	client := s.setRestored()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--file", "/tmp/juju-backup-a.tar.gz", "--no-prompt")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckArgs(c, "", "/tmp/juju-backup-a.tar.gz")
+}
+
+func (s *restoreSuite) TestRestoreMachineErrors(c *gc.C) {
+	client := s.setRestored()
+	client.restored.Machines = []params.BackupsRestoreMachineResult{
+		{Model: "foo", Machine: "1"},
+		{Model: "foo", Machine: "2", Error: &params.Error{Message: "boom"}},
+	}
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "juju-backup-a.tar.gz", "--no-prompt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), jc.Contains, "cannot update agents on machine 2 in model foo: boom")
+	c.Check(c.GetTestLog(), gc.Not(jc.Contains), "machine 1")
+}
+
+func (s *restoreSuite) TestRestoreConfirmed(c *gc.C) {
+	client := s.setRestored()
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("y\n")
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"juju-backup-a.tar.gz"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wrappedCommand.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Restore")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "WARNING! This will replace the state of the controller with backup juju-backup-a.tar.gz.\n")
+}
+
+func (s *restoreSuite) TestRestoreAborted(c *gc.C) {
+	client := s.setRestored()
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("n\n")
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"juju-backup-a.tar.gz"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wrappedCommand.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "restore: aborted")
+	client.CheckCalls(c)
+}
+
+func (s *restoreSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "juju-backup-a.tar.gz", "--no-prompt")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
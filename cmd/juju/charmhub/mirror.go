@@ -0,0 +1,345 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
+	jujucmd "github.com/juju/juju/cmd"
+	corebase "github.com/juju/juju/core/base"
+)
+
+const (
+	mirrorSummary = "Mirrors CharmHub charms and their resources to a local directory."
+	mirrorDoc     = `
+Download charms and bundles, and the resources they use, from CharmHub
+into a local mirror directory, so that they can be deployed and
+refreshed without access to CharmHub.
+
+By default, the revisions released to every channel, for every
+architecture and base, are mirrored. Use --channel, --arch and --base
+to only mirror some of them. Running the command again adds newly
+released revisions to the mirror; revisions already in the mirror are
+not downloaded again.
+
+To use the mirror, copy the directory to the same path on each
+controller machine, and set the charmhub-url model config to the
+file URL of the directory, before deploying:
+
+    juju model-config charmhub-url=file:///var/lib/juju/charmhub-mirror
+
+The mirror can also be served over HTTP, for clients which can't
+access the directory, and the same commands can query it by setting
+--charmhub-url or CHARMHUB_URL to its file URL.
+
+Charms and resources can be signed, for models with the
+charm-signing-keys model config, by serving detached signatures from
+the directory or URL in the charm-signatures-url model config. Each
+signature is named after the digest of the content it signs:
+
+    sha256/<hex digest>.sig   - charm archive, or container image
+    sha384/<hex digest>.sig   - file resource
+
+    ssh-keygen -Y sign -f signing-key -n juju-charm <charm file>
+    ssh-keygen -Y sign -f signing-key -n juju-resource <resource file>
+
+Container image resources are signed by their image digest, such as
+"sha256:<hex>", rather than by the downloaded file. Charms and
+resources without a signature are refused if the signed-charms-only
+model config is set.
+`
+
+	mirrorExamples = `
+    juju mirror-charms --dir /srv/charmhub-mirror postgresql
+    juju mirror-charms --dir /srv/charmhub-mirror --channel 14/stable --base ubuntu@22.04 postgresql
+    juju info --charmhub-url file:///srv/charmhub-mirror postgresql
+`
+)
+
+// NewMirrorCommand wraps mirrorCommand with sane model settings.
+func NewMirrorCommand() cmd.Command {
+	return &mirrorCommand{
+		charmHubCommand: newCharmHubCommand(),
+	}
+}
+
+// mirrorCommand supplies the "mirror-charms" CLI command used for
+// mirroring charms and bundles to a local directory.
+type mirrorCommand struct {
+	*charmHubCommand
+
+	dir           string
+	channel       string
+	charmOrBundle []string
+}
+
+// Info returns help related info about the command, it implements
+// part of the cmd.Command interface.
+func (c *mirrorCommand) Info() *cmd.Info {
+	mirror := &cmd.Info{
+		Name:     "mirror-charms",
+		Args:     "[options] <charm> [<charm> ...]",
+		Purpose:  mirrorSummary,
+		Doc:      mirrorDoc,
+		Examples: mirrorExamples,
+		SeeAlso: []string{
+			"download",
+			"info",
+		},
+	}
+	return jujucmd.Info(mirror)
+}
+
+// SetFlags defines flags which can be used with the mirror command.
+// It implements part of the cmd.Command interface.
+func (c *mirrorCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.charmHubCommand.SetFlags(f)
+
+	f.StringVar(&c.dir, "dir", "", "the directory holding the mirror")
+	f.StringVar(&c.arch, "arch", ArchAll, "only mirror releases for an arch <"+c.archArgumentList()+">")
+	f.StringVar(&c.base, "base", "", "only mirror releases for a base")
+	f.StringVar(&c.channel, "channel", "", "only mirror releases to a channel")
+}
+
+// Init initializes the mirror command, including validating the provided
+// flags. It implements part of the cmd.Command interface.
+func (c *mirrorCommand) Init(args []string) error {
+	if err := c.charmHubCommand.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.dir == "" {
+		return errors.New("--dir is required")
+	}
+	if len(args) == 0 {
+		return errors.Errorf("expected one or more charm or bundle names")
+	}
+	if c.base != "" {
+		if _, err := corebase.ParseBaseFromString(c.base); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.channel != "" {
+		if _, err := charm.ParseChannelNormalize(c.channel); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, arg := range args {
+		curl, err := charm.ParseURL(arg)
+		if err != nil {
+			return errors.NotValidf("charm or bundle name, %q, is", arg)
+		}
+		if !charm.CharmHub.Matches(curl.Schema) {
+			return errors.Errorf("%q is not a Charmhub charm", arg)
+		}
+		c.charmOrBundle = append(c.charmOrBundle, curl.Name)
+	}
+	return nil
+}
+
+// Run is the business logic of the mirror command. It implements the meaty
+// part of the cmd.Command interface.
+func (c *mirrorCommand) Run(cmdContext *cmd.Context) error {
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.CharmHubClientFunc(charmhub.Config{
+		URL:    c.charmHubURL,
+		Logger: downloadLogger{Context: cmdContext},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "charmhub-mirror")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mirror := charmhub.NewMirror(dir)
+	for _, name := range c.charmOrBundle {
+		if err := c.mirrorOne(ctx, cmdContext, client, mirror, tmpDir, name); err != nil {
+			return errors.Annotatef(err, "mirroring %q", name)
+		}
+	}
+
+	cmdContext.Infof(`
+Use the mirror by setting the charmhub-url model config to:
+    %s`[1:], (&url.URL{Scheme: charmhub.MirrorScheme, Path: filepath.ToSlash(dir)}).String())
+	return nil
+}
+
+// mirrorOne adds the matching releases of a charm or bundle to the
+// mirror.
+func (c *mirrorCommand) mirrorOne(
+	ctx context.Context, cmdContext *cmd.Context,
+	client CharmHubClient, mirror *charmhub.Mirror,
+	tmpDir, name string,
+) error {
+	info, err := client.Info(ctx, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var releases []transport.InfoChannelMap
+	for _, release := range info.ChannelMap {
+		ok, err := c.matches(release.Channel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok {
+			releases = append(releases, release)
+		}
+	}
+	if len(releases) == 0 {
+		return errors.NotFoundf("releases matching the channel, arch and base")
+	}
+
+	mirrored := make(map[int]bool)
+	for _, release := range releases {
+		revision := release.Revision.Revision
+		if mirrored[revision] {
+			continue
+		}
+		mirrored[revision] = true
+		if mirror.HasRevision(info.Name, revision) {
+			cmdContext.Verbosef("%s %q revision %d is already mirrored", info.Type, info.Name, revision)
+			continue
+		}
+		if err := c.mirrorRevision(ctx, cmdContext, client, mirror, tmpDir, info.Name, revision); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// Only the releases which were mirrored are recorded, so that the
+	// mirror doesn't offer revisions it doesn't have.
+	info.ChannelMap = releases
+	if !mirrored[info.DefaultRelease.Revision.Revision] {
+		info.DefaultRelease = transport.InfoChannelMap{}
+	}
+	return errors.Trace(mirror.AddInfo(info))
+}
+
+// matches returns true if a release's channel matches the channel, arch
+// and base being mirrored.
+func (c *mirrorCommand) matches(ch transport.Channel) (bool, error) {
+	if c.channel != "" {
+		want, err := charm.ParseChannelNormalize(c.channel)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		got, err := charm.ParseChannelNormalize(ch.Name)
+		if err != nil {
+			return false, nil
+		}
+		if !sameChannel(want, got) {
+			return false, nil
+		}
+	}
+	if c.arch != ArchAll && c.arch != ch.Base.Architecture {
+		return false, nil
+	}
+	if c.base != "" {
+		want, err := corebase.ParseBaseFromString(c.base)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		got, err := corebase.ParseBase(ch.Base.Name, ch.Base.Channel)
+		if err != nil || !want.IsCompatible(got) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sameChannel returns true if both channels are the same, treating an
+// empty track as the latest track.
+func sameChannel(a, b charm.Channel) bool {
+	if a.Track == "" {
+		a.Track = "latest"
+	}
+	if b.Track == "" {
+		b.Track = "latest"
+	}
+	return a.Track == b.Track && a.Risk == b.Risk && a.Branch == b.Branch
+}
+
+// mirrorRevision downloads a revision of a charm or bundle, and the
+// resources it uses, into the mirror.
+func (c *mirrorCommand) mirrorRevision(
+	ctx context.Context, cmdContext *cmd.Context,
+	client CharmHubClient, mirror *charmhub.Mirror,
+	tmpDir, name string, revision int,
+) error {
+	cfg, err := charmhub.InstallOneFromRevision(name, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := client.Refresh(ctx, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		return errors.NotFoundf("revision %d", revision)
+	}
+	if results[0].Error != nil {
+		return errors.Errorf("unable to locate revision %d: %s", revision, results[0].Error.Message)
+	}
+	entity := results[0].Entity
+
+	for _, res := range entity.Resources {
+		if mirror.HasResource(entity.Name, res.Name, res.Revision) {
+			continue
+		}
+		cmdContext.Infof("Fetching resource %q revision %d", res.Name, res.Revision)
+		err := c.download(ctx, client, tmpDir, res.Download.URL, func(f *os.File) error {
+			return mirror.AddResource(entity.Name, res, f)
+		})
+		if err != nil {
+			return errors.Annotatef(err, "resource %q revision %d", res.Name, res.Revision)
+		}
+	}
+
+	cmdContext.Infof("Fetching %s %q revision %d", entity.Type, entity.Name, entity.Revision)
+	// The revision is added last, so that a revision is only in the
+	// mirror once all of its resources are.
+	err = c.download(ctx, client, tmpDir, entity.Download.URL, func(f *os.File) error {
+		return mirror.AddRevision(entity, f)
+	})
+	return errors.Annotatef(err, "revision %d", revision)
+}
+
+// download downloads the content at a URL to a temporary file, and
+// passes the file to add.
+func (c *mirrorCommand) download(ctx context.Context, client CharmHubClient, tmpDir, rawURL string, add func(*os.File) error) error {
+	downloadURL, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := filepath.Join(tmpDir, "download")
+	defer func() { _ = os.Remove(path) }()
+	if err := client.Download(ctx, downloadURL, path); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	return errors.Trace(add(f))
+}
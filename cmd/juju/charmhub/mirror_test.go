@@ -0,0 +1,197 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/cmd/juju/charmhub/mocks"
+	"github.com/juju/juju/core/arch"
+	"github.com/juju/juju/testing"
+)
+
+type mirrorSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	charmHubAPI *mocks.MockCharmHubClient
+	dir         string
+}
+
+var _ = gc.Suite(&mirrorSuite{})
+
+func (s *mirrorSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+func (s *mirrorSuite) newCommand() *mirrorCommand {
+	return &mirrorCommand{
+		charmHubCommand: &charmHubCommand{
+			arches: arch.AllArches(),
+			CharmHubClientFunc: func(charmhub.Config) (CharmHubClient, error) {
+				return s.charmHubAPI, nil
+			},
+		},
+	}
+}
+
+func (s *mirrorSuite) setUpMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.charmHubAPI = mocks.NewMockCharmHubClient(ctrl)
+	return ctrl
+}
+
+func (s *mirrorSuite) TestInitNoDir(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"test"})
+	c.Assert(err, gc.ErrorMatches, "--dir is required")
+}
+
+func (s *mirrorSuite) TestInitNoArgs(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"--dir", s.dir})
+	c.Assert(err, gc.ErrorMatches, "expected one or more charm or bundle names")
+}
+
+func (s *mirrorSuite) TestInitInvalidBase(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"--dir", s.dir, "--base", "ubuntu", "test"})
+	c.Assert(err, gc.ErrorMatches, `.*expected base string to contain os and channel separated by '@'`)
+}
+
+func (s *mirrorSuite) TestInitCSSchema(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"--dir", s.dir, "cs:test"})
+	c.Assert(err, gc.ErrorMatches, `charm or bundle name, "cs:test", is not valid`)
+}
+
+func (s *mirrorSuite) TestRun(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectInfo()
+	s.expectRevision(c, 2)
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--dir", s.dir, "--channel", "stable", "--base", "ubuntu@22.04", "test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `(?s).*file://`+s.dir+`\n$`)
+
+	s.assertMirrored(c, 2)
+	mirror := charmhub.NewMirror(s.dir)
+	c.Assert(mirror.HasRevision("test", 1), jc.IsFalse)
+
+	client, err := charmhub.NewClient(charmhub.Config{
+		URL:    "file://" + s.dir,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := client.Info(context.Background(), "test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ChannelMap, gc.HasLen, 1)
+	c.Assert(info.ChannelMap[0].Channel.Name, gc.Equals, "latest/stable")
+	c.Assert(info.DefaultRelease.Revision.Revision, gc.Equals, 2)
+}
+
+func (s *mirrorSuite) TestRunSkipsMirroredRevisions(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectInfo()
+	s.expectRevision(c, 2)
+	s.expectRevision(c, 1)
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--dir", s.dir, "test")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertMirrored(c, 1)
+	s.assertMirrored(c, 2)
+
+	// Only the charm information is fetched again.
+	s.expectInfo()
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "--dir", s.dir, "test")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *mirrorSuite) TestRunNoMatchingReleases(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectInfo()
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--dir", s.dir, "--arch", "s390x", "test")
+	c.Assert(err, gc.ErrorMatches, `mirroring "test": releases matching the channel, arch and base not found`)
+}
+
+func (s *mirrorSuite) expectInfo() {
+	jammy := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	focal := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "20.04"}
+	stable := transport.InfoChannelMap{
+		Channel:  transport.Channel{Name: "latest/stable", Track: "latest", Risk: "stable", Base: jammy},
+		Revision: transport.InfoRevision{Revision: 2, Bases: []transport.Base{jammy}},
+	}
+	s.charmHubAPI.EXPECT().Info(gomock.Any(), "test").Return(transport.InfoResponse{
+		Type: transport.CharmType,
+		ID:   "test-id",
+		Name: "test",
+		ChannelMap: []transport.InfoChannelMap{stable, {
+			Channel:  transport.Channel{Name: "latest/edge", Track: "latest", Risk: "edge", Base: jammy},
+			Revision: transport.InfoRevision{Revision: 2, Bases: []transport.Base{jammy}},
+		}, {
+			Channel:  transport.Channel{Name: "latest/stable", Track: "latest", Risk: "stable", Base: focal},
+			Revision: transport.InfoRevision{Revision: 1, Bases: []transport.Base{focal}},
+		}},
+		DefaultRelease: stable,
+	}, nil)
+}
+
+func (s *mirrorSuite) expectRevision(c *gc.C, revision int) {
+	charmURL := fmt.Sprintf("https://example.com/charm/%d", revision)
+	resourceURL := fmt.Sprintf("https://example.com/resource/%d", revision)
+	charmContent := fmt.Sprintf("charm-%d", revision)
+	resourceContent := fmt.Sprintf("resource-%d", revision)
+
+	s.charmHubAPI.EXPECT().Refresh(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, cfg charmhub.RefreshConfig) ([]transport.RefreshResponse, error) {
+		req, err := cfg.Build()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(*req.Actions[0].Revision, gc.Equals, revision)
+		return []transport.RefreshResponse{{
+			InstanceKey: charmhub.ExtractConfigInstanceKey(cfg),
+			Entity: transport.RefreshEntity{
+				Type:     transport.CharmType,
+				ID:       "test-id",
+				Name:     "test",
+				Revision: revision,
+				Download: transport.Download{
+					HashSHA256: fmt.Sprintf("%x", sha256.Sum256([]byte(charmContent))),
+					URL:        charmURL,
+				},
+				Resources: []transport.ResourceRevision{{
+					Name:     "tool",
+					Type:     "file",
+					Revision: revision,
+					Download: transport.Download{
+						HashSHA256: fmt.Sprintf("%x", sha256.Sum256([]byte(resourceContent))),
+						URL:        resourceURL,
+					},
+				}},
+			},
+		}}, nil
+	})
+	for rawURL, content := range map[string]string{charmURL: charmContent, resourceURL: resourceContent} {
+		downloadURL, err := url.Parse(rawURL)
+		c.Assert(err, jc.ErrorIsNil)
+		content := content
+		s.charmHubAPI.EXPECT().Download(gomock.Any(), downloadURL, gomock.Any()).DoAndReturn(func(_ context.Context, _ *url.URL, path string, _ ...charmhub.DownloadOption) error {
+			return os.WriteFile(path, []byte(content), 0644)
+		})
+	}
+}
+
+func (s *mirrorSuite) assertMirrored(c *gc.C, revision int) {
+	mirror := charmhub.NewMirror(s.dir)
+	c.Assert(mirror.HasRevision("test", revision), jc.IsTrue)
+	c.Assert(mirror.HasResource("test", "tool", revision), jc.IsTrue)
+}
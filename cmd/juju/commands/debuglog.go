@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/api/common"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -68,6 +70,28 @@ The filtering options combine as follows:
   --include-label and --exclude-label selections are logically ANDed to form
   the complete filter.
 
+The '--since' and '--until' options limit the messages shown to those logged
+within a time window. Each takes either an RFC3339 timestamp, or a duration
+(such as 90m or 2h) which is relative to the current time. Setting '--since'
+implies '--replay'. When '--until' is in the past, existing messages are shown
+and then the command exits.
+
+The '--message' option only shows messages matching a regular expression.
+
+The '--filter' option takes a boolean expression which is evaluated by the
+controller against each message. Comparisons are made up of a field, an
+operator and a value, and may be combined using "and", "or", "not" and
+parentheses. The fields are:
+
+  message, module, entity, location  compared with =, !=, ~ (regex), !~
+  label                              matches any of a message's labels
+  label.<key>                        matches labels of the form key=value
+  level                              also supports <, <=, > and >=
+
+Values containing spaces, parentheses or operators must be quoted. All of the
+options above are applied by the controller, in addition to the --include and
+--exclude style options, so only matching messages are sent to the client.
+
 `
 
 const usageDebugLogExamples = `
@@ -105,6 +129,17 @@ To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
+
+Show the messages from the last two hours which mention a refused or reset
+connection:
+
+    juju debug-log --since 2h --message 'connection (refused|reset)'
+
+Show the messages logged during an incident window by the mysql units, or by
+the uniter on any unit, which are at least a WARNING:
+
+    juju debug-log --since 2023-06-01T10:00:00Z --until 2023-06-01T11:00:00Z \
+        --filter '(entity = unit-mysql-* or module = juju.worker.uniter) and level >= WARNING'
 `
 
 func (c *debugLogCommand) Info() *cmd.Info {
@@ -148,6 +183,9 @@ type debugLogCommand struct {
 	retry      bool
 	retryDelay time.Duration
 
+	since string
+	until string
+
 	format string
 	tz     *time.Location
 }
@@ -171,6 +209,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time (RFC3339 timestamp, or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time (RFC3339 timestamp, or duration ago)")
+	f.StringVar(&c.params.MessageRegex, "message", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.params.Filter, "filter", "", "Only show log messages matching this filter expression")
+
 	f.BoolVar(&c.noTail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
@@ -202,6 +245,9 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.retryDelay < 0 {
 		return errors.NotValidf("negative retry delay")
 	}
+	if err := c.initFilters(); err != nil {
+		return errors.Trace(err)
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -218,6 +264,54 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// initFilters parses and checks the time window and message filters, so
+// mistakes are reported before connecting to the controller.
+func (c *debugLogCommand) initFilters() error {
+	now := time.Now()
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.NotValidf("--until before --since")
+		}
+		c.params.EndTime = until
+	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --message")
+		}
+	}
+	if c.params.Filter != "" {
+		if _, err := corelogger.ParseFilter(c.params.Filter); err != nil {
+			return errors.Annotate(err, "invalid --filter")
+		}
+	}
+	return nil
+}
+
+// parseLogTime parses either an RFC3339 timestamp, or a duration which
+// is subtracted from now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is not an RFC3339 timestamp or a positive duration", value)
+	}
+	return now.Add(-d), nil
+}
+
 func (c *debugLogCommand) parseEntity(entity string) string {
 	tag, err := names.ParseTag(entity)
 	switch {
@@ -262,6 +356,10 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) error {
 		c.params.NoTail = false
 	} else if c.noTail {
 		c.params.NoTail = true
+	} else if !c.params.EndTime.IsZero() && c.params.EndTime.Before(time.Now()) {
+		// No new messages can be shown, so there's no point waiting
+		// for them.
+		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
 		// using a terminal.
@@ -122,6 +122,36 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		}, {
 			args:     []string{"--retry-delay", "-1s"},
 			errMatch: `negative retry delay not valid`,
+		}, {
+			args: []string{"--since", "2016-10-09T08:15:23Z", "--until", "2016-10-09T09:15:23Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2016, 10, 9, 8, 15, 23, 0, time.UTC),
+				EndTime:   time.Date(2016, 10, 9, 9, 15, 23, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since: "yesterday" is not an RFC3339 timestamp or a positive duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `invalid --until: "-1h" is not an RFC3339 timestamp or a positive duration`,
+		}, {
+			args:     []string{"--since", "2016-10-09T08:15:23Z", "--until", "2016-10-09T07:15:23Z"},
+			errMatch: `--until before --since not valid`,
+		}, {
+			args: []string{"--message", "refused|reset", "--filter", "label.app = mysql or level >= ERROR"},
+			expected: common.DebugLogParams{
+				Backlog:      10,
+				MessageRegex: "refused|reset",
+				Filter:       "label.app = mysql or level >= ERROR",
+			},
+		}, {
+			args:     []string{"--message", "("},
+			errMatch: `invalid --message: error parsing regexp: .*`,
+		}, {
+			args:     []string{"--filter", "colour = red"},
+			errMatch: `invalid --filter: parsing filter "colour = red": unknown field "colour"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -160,6 +190,41 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestSinceDuration(c *gc.C) {
+	command := &debugLogCommand{}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	before := time.Now()
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "1h"})
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+
+	c.Check(command.params.Replay, jc.IsTrue)
+	c.Check(command.params.StartTime.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(command.params.StartTime.After(after.Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(command.params.EndTime.Sub(command.params.StartTime), gc.Equals, time.Hour)
+}
+
+func (s *DebugLogSuite) TestUntilInPastStopsTailing(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newDebugLogCommand(jujuclienttesting.MinimalStore()),
+		"--since", "2016-10-09T08:15:23Z",
+		"--until", "2016-10-09T09:15:23Z",
+		"--filter", "module = juju.worker",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params, jc.DeepEquals, common.DebugLogParams{
+		Backlog:   10,
+		Replay:    true,
+		NoTail:    true,
+		StartTime: time.Date(2016, 10, 9, 8, 15, 23, 0, time.UTC),
+		EndTime:   time.Date(2016, 10, 9, 9, 15, 23, 0, time.UTC),
+		Filter:    "module = juju.worker",
+	})
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
@@ -411,6 +411,8 @@ func registerCommands(r commandRegistry) {
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
+	r.Register(backups.NewListCommand())
+	r.Register(backups.NewRestoreCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -458,6 +460,7 @@ func registerCommands(r commandRegistry) {
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(newMigrateAllCommand())
 	r.Register(model.NewExportBundleCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -473,6 +476,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(action.NewListOperationsCommand())
 	r.Register(action.NewShowOperationCommand())
 	r.Register(action.NewShowTaskCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -560,6 +566,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewSetAutoscaleCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
@@ -576,6 +583,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(charmhub.NewInfoCommand())
 	r.Register(charmhub.NewFindCommand())
 	r.Register(charmhub.NewDownloadCommand())
+	r.Register(charmhub.NewMirrorCommand())
 
 	// Secrets.
 	r.Register(secrets.NewListSecretsCommand())
@@ -592,6 +600,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(secretbackends.NewUpdateSecretBackendCommand())
 	r.Register(secretbackends.NewRemoveSecretBackendCommand())
 	r.Register(secretbackends.NewShowSecretBackendCommand())
+	r.Register(secretbackends.NewMigrateSecretsCommand())
 
 	// Payload commands.
 	r.Register(payload.NewListCommand())
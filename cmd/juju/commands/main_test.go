@@ -311,6 +311,7 @@ var commandNames = []string{
 	"add-k8s",
 	"add-machine",
 	"add-model",
+	"add-schedule",
 	"add-secret-backend",
 	"add-space",
 	"add-ssh-key",
@@ -323,6 +324,7 @@ var commandNames = []string{
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"backups",
 	"bind",
 	"bootstrap",
 	"cancel-task",
@@ -379,6 +381,7 @@ var commandNames = []string{
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
+	"list-backups",
 	"list-charm-resources",
 	"list-clouds",
 	"list-controllers",
@@ -389,6 +392,7 @@ var commandNames = []string{
 	"list-models",
 	"list-offers",
 	"list-operations",
+	"list-schedules",
 	"list-payloads",
 	"list-regions",
 	"list-resources",
@@ -405,6 +409,9 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-all",
+	"migrate-secret-backend",
+	"mirror-charms",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -427,6 +434,7 @@ var commandNames = []string{
 	"remove-offer",
 	"remove-relation",
 	"remove-saas",
+	"remove-schedule",
 	"remove-secret-backend",
 	"remove-secret",
 	"remove-space",
@@ -439,6 +447,7 @@ var commandNames = []string{
 	"resolved",
 	"resolve",
 	"resources",
+	"restore-backup",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -446,10 +455,12 @@ var commandNames = []string{
 	"revoke-secret",
 	"run",
 	"scale-application",
+	"schedules",
 	"scp",
 	"secrets",
 	"secret-backends",
 	"set-application-base",
+	"set-autoscale",
 	"set-credential",
 	"set-constraints",
 	"set-default-credentials",
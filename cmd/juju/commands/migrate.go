@@ -10,10 +10,12 @@ import (
 	"github.com/juju/cmd/v3"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/client/action"
 	"github.com/juju/juju/api/client/modelmanager"
 	"github.com/juju/juju/api/client/usermanager"
 	"github.com/juju/juju/api/controller/controller"
@@ -35,16 +37,24 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	resume           bool
+	abort            bool
+	dryRun           bool
+	out              cmd.Output
 
 	// Overridden by tests
-	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
-	migAPI     map[string]migrateAPI
-	modelAPI   modelInfoAPI
-	userAPI    userListAPI
+	newAPIRoot  func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	migAPI      map[string]migrateAPI
+	modelAPI    modelInfoAPI
+	userAPI     userListAPI
+	scheduleAPI scheduleListAPI
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	DryRunMigration(spec controller.MigrationSpec) (params.MigrationReport, error)
+	ResumeMigration(modelUUID string) error
+	AbortMigration(modelUUID string) error
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -59,6 +69,11 @@ type userListAPI interface {
 	Close() error
 }
 
+type scheduleListAPI interface {
+	ListSchedules() ([]action.Schedule, error)
+	Close() error
+}
+
 const migrateDoc = `
 migrate begins the migration of a model from its current controller to
 a new controller. This is useful for load balancing when a controller
@@ -74,6 +89,30 @@ If the migration fails for some reason, the model be returned to its
 original state with the model being managed by the original
 controller.
 
+If the migration fails while importing the model into the target
+controller or while processing its relations, the migration waits
+rather than being aborted straight away. Once the problem has been
+resolved, the --resume option retries the failed phase, reusing the
+progress described below. Alternatively, the --abort option gives up
+on the migration, returning the model to the original controller. The
+model's agents remain locked down while the migration waits, so a
+migration which isn't resumed within an hour is aborted.
+
+Only the import of the model and the processing of its relations can
+be resumed. When the import is resumed, the model is not imported again
+if that had already been done, and charms, agent binaries and resources
+already uploaded to the target controller are skipped. Progress isn't
+kept within a binary: one whose upload was interrupted is uploaded
+again from the start. The transfer of the model's logs, which follows a
+successful migration, can't be resumed; if it fails it is retried
+automatically, from the latest log record held by the target
+controller.
+
+Autoscale policies, set by "set-autoscale", are not migrated, so must
+be set again once the migration has completed. Neither are action
+schedules, added by "add-schedule"; a warning is given for each of the
+model's schedules, which must be added again on the target controller.
+
 In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
@@ -82,15 +121,33 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+The --dry-run option checks whether the model could be migrated to the
+target controller without starting a migration. All of the source and
+target controller prechecks are run, and the model is imported into the
+target controller in a simulation, without the model being affected.
+Every issue which would block the migration is reported, along with any
+warnings, the cloud and credential the target controller will use for
+the model, and the sizes of the charms, agent binaries and resources
+which would be transferred. The command fails if any blocking issues
+are found.
+
+`
+
+const migrateExamples = `
+    juju migrate mymodel target-controller
+    juju migrate mymodel target-controller --dry-run
+    juju migrate mymodel --resume
+    juju migrate mymodel --abort
 `
 
 // Info implements cmd.Command.
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
-		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
-		Purpose: "Migrate a workload model to another controller.",
-		Doc:     migrateDoc,
+		Name:     "migrate",
+		Args:     "<model-name> <target-controller-name>",
+		Purpose:  "Migrate a workload model to another controller.",
+		Doc:      migrateDoc,
+		Examples: migrateExamples,
 		SeeAlso: []string{
 			"login",
 			"controllers",
@@ -99,11 +156,36 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.resume, "resume", false, "Retry the failed phase of a migration")
+	f.BoolVar(&c.abort, "abort", false, "Abort a failed migration")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model could be migrated, without migrating it")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationReportTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
+	if c.resume || c.abort {
+		if c.resume && c.abort {
+			return errors.New("cannot specify both --resume and --abort")
+		}
+		if c.dryRun {
+			return errors.New("--dry-run cannot be specified with --resume or --abort")
+		}
+		if len(args) > 1 {
+			return errors.New("target controller cannot be specified with --resume or --abort")
+		}
+		return errors.Trace(c.SetModelIdentifier(args[0], false))
+	}
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
@@ -121,6 +203,9 @@ func (c *migrateCommand) Init(args []string) error {
 
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	if c.resume || c.abort {
+		return c.resumeOrAbort(ctx)
+	}
 	spec, err := c.getMigrationSpec()
 	if err != nil {
 		return err
@@ -134,6 +219,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		return c.dryRunMigration(ctx, modelName, *spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -141,6 +229,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := c.warnActionSchedules(ctx, controllerName, modelName); err != nil {
+		return errors.Trace(err)
+	}
 	api, err := c.getMigrationAPI(controllerName)
 	if err != nil {
 		return err
@@ -154,15 +245,84 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// dryRunMigration reports whether the model could be migrated, without
+// migrating it. The checks of the users known to each controller are
+// done by the source controller as part of the dry run.
+func (c *migrateCommand) dryRunMigration(ctx *cmd.Context, modelName string, spec controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+
+	report, err := api.DryRunMigration(spec)
+	if errors.Is(err, errors.NotSupported) {
+		return errors.New("controller does not support migration dry runs, upgrade the controller first")
+	} else if err != nil {
+		return errors.Annotate(err, "checking migration")
+	}
+	if err := c.out.Write(ctx, newMigrationReport(modelName, c.targetController, report)); err != nil {
+		return errors.Trace(err)
+	}
+	if len(report.Blockers) > 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *migrateCommand) resumeOrAbort(ctx *cmd.Context) error {
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+
+	if c.abort {
+		if err := api.AbortMigration(uuids[0]); err != nil {
+			return errors.Annotate(err, "aborting migration")
+		}
+		ctx.Infof("Migration of %q aborted", modelName)
+		return nil
+	}
+	if err := api.ResumeMigration(uuids[0]); err != nil {
+		return errors.Annotate(err, "resuming migration")
+	}
+	ctx.Infof("Migration of %q resumed", modelName)
+	return nil
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
-	store := c.ClientStore()
+	return migrationTargetSpec(c.ClientStore(), c.targetController, c.getTargetControllerMacaroons)
+}
 
-	controllerInfo, err := store.ControllerByName(c.targetController)
+// migrationTargetSpec returns a migration spec holding the details of
+// the target controller, as recorded in the client store. The UUID of
+// the model to be migrated is left for the caller to fill in.
+func migrationTargetSpec(
+	store jujuclient.ClientStore, targetController string, getMacaroons func() ([]macaroon.Slice, error),
+) (*controller.MigrationSpec, error) {
+	controllerInfo, err := store.ControllerByName(targetController)
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := store.AccountDetails(c.targetController)
+	accountInfo, err := store.AccountDetails(targetController)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +330,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	var macs []macaroon.Slice
 	if accountInfo.Password == "" {
 		var err error
-		macs, err = c.getTargetControllerMacaroons()
+		macs, err = getMacaroons()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -178,7 +338,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 
 	return &controller.MigrationSpec{
 		TargetControllerUUID:  controllerInfo.ControllerUUID,
-		TargetControllerAlias: c.targetController,
+		TargetControllerAlias: targetController,
 		TargetAddrs:           controllerInfo.APIEndpoints,
 		TargetCACert:          controllerInfo.CACert,
 		TargetUser:            accountInfo.User,
@@ -217,6 +377,18 @@ func (c *migrateCommand) getModelAPI() (modelInfoAPI, error) {
 	return modelmanager.NewClient(apiRoot), nil
 }
 
+func (c *migrateCommand) getScheduleAPI(controllerName, modelName string) (scheduleListAPI, error) {
+	if c.scheduleAPI != nil {
+		return c.scheduleAPI, nil
+	}
+
+	apiRoot, err := c.newAPIRoot(c.ClientStore(), controllerName, modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return action.NewClient(apiRoot), nil
+}
+
 func (c *migrateCommand) getTargetControllerUserAPI() (userListAPI, error) {
 	if c.userAPI != nil {
 		return c.userAPI, nil
@@ -230,7 +402,16 @@ func (c *migrateCommand) getTargetControllerUserAPI() (userListAPI, error) {
 }
 
 func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
-	jar, err := c.CommandBase.CookieJar(c.ClientStore(), c.targetController)
+	return targetControllerMacaroons(&c.CommandBase, c.ClientStore(), c.targetController, c.newAPIRoot)
+}
+
+func targetControllerMacaroons(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	targetController string,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+) ([]macaroon.Slice, error) {
+	jar, err := base.CookieJar(store, targetController)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -240,7 +421,7 @@ func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error
 	//
 	// TODO(axw,mjs) add a controller API that returns a macaroon that
 	// may be used for the sole purpose of migration.
-	api, err := c.newAPIRoot(c.ClientStore(), c.targetController, "")
+	api, err := newAPIRoot(store, targetController, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "connecting to target controller")
 	}
@@ -326,6 +507,28 @@ users to the destination controller or remove them from the current model:
 	return nil
 }
 
+// warnActionSchedules warns about each of the model's action schedules,
+// as they aren't migrated.
+func (c *migrateCommand) warnActionSchedules(ctx *cmd.Context, controllerName, modelName string) error {
+	api, err := c.getScheduleAPI(controllerName, modelName)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if errors.Is(err, errors.NotSupported) {
+		// The controller predates action schedules.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "listing action schedules")
+	}
+	for _, schedule := range schedules {
+		ctx.Warningf("action schedule %q will not be migrated, add it again once the migration has completed", schedule.Name)
+	}
+	return nil
+}
+
 func (c *migrateCommand) getIdentityProviderURL(controllerName string) (string, error) {
 	api, err := c.getMigrationAPI(controllerName)
 	if err != nil {
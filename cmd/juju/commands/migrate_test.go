@@ -11,6 +11,7 @@ import (
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/client/action"
 	"github.com/juju/juju/api/client/usermanager"
 	"github.com/juju/juju/api/controller/controller"
 	apitesting "github.com/juju/juju/api/testing"
@@ -34,6 +36,7 @@ type MigrateSuite struct {
 	targetControllerAPI *fakeTargetControllerAPI
 	modelAPI            *fakeModelAPI
 	userAPI             *fakeUserAPI
+	scheduleAPI         *fakeScheduleAPI
 	store               *jujuclient.MemStore
 }
 
@@ -198,6 +201,8 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 		},
 	}
 
+	s.scheduleAPI = &fakeScheduleAPI{}
+
 	mac0, err := macaroon.New([]byte("secret0"), []byte("id0"), "location0", macaroon.LatestVersion)
 	c.Assert(err, jc.ErrorIsNil)
 	mac1, err := macaroon.New([]byte("secret1"), []byte("id1"), "location1", macaroon.LatestVersion)
@@ -245,6 +250,116 @@ func (s *MigrateSuite) TestTooManyArgs(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
 }
 
+func (s *MigrateSuite) TestResume(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of \"model\" resumed\n")
+	c.Check(s.api.resumed, jc.DeepEquals, []string{modelUUID})
+	c.Check(s.api.aborted, gc.HasLen, 0)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestAbort(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "--abort")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of \"model\" aborted\n")
+	c.Check(s.api.aborted, jc.DeepEquals, []string{modelUUID})
+	c.Check(s.api.resumed, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestResumeError(c *gc.C) {
+	s.api.failedErr = errors.New("migration is not waiting to be resumed")
+	_, err := s.makeAndRun(c, "model", "--resume")
+	c.Assert(err, gc.ErrorMatches, "resuming migration: migration is not waiting to be resumed")
+}
+
+func (s *MigrateSuite) TestResumeWithTargetController(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--resume")
+	c.Assert(err, gc.ErrorMatches, "target controller cannot be specified with --resume or --abort")
+}
+
+func (s *MigrateSuite) TestResumeAndAbort(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "--resume", "--abort")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both --resume and --abort")
+}
+
+func (s *MigrateSuite) TestDryRunWithResume(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "--resume", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run cannot be specified with --resume or --abort")
+}
+
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	s.api.report = params.MigrationReport{
+		Warnings: []params.MigrationIssue{{
+			Controller: "target",
+			Message:    `credential "aws/fred/default" will be created on target controller`,
+		}},
+		Cloud:           "aws",
+		CloudRegion:     "us-east-1",
+		CloudCredential: "aws/fred/default",
+		Charms:          []params.MigrationBinary{{Name: "ch:mysql-1", Size: 2048}},
+		Tools:           []params.MigrationBinary{{Name: "3.1.0-ubuntu-amd64", Size: 1024}},
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model  Target controller  Cloud/Region   Credential
+model  target             aws/us-east-1  aws/fred/default
+
+Controller  Warning
+target      credential "aws/fred/default" will be created on target controller
+
+Transfer            Type   Size
+ch:mysql-1          charm  2.0 KiB
+3.1.0-ubuntu-amd64  agent  1.0 KiB
+Total                      3.0 KiB
+
+Model "model" can be migrated to "target".
+`[1:])
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlocked(c *gc.C) {
+	s.api.report = params.MigrationReport{
+		Blockers: []params.MigrationIssue{
+			{Controller: "source", Message: "machine 0 is dying"},
+			{Controller: "target", Message: "upgrade in progress"},
+		},
+		Cloud: "aws",
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+model: model
+target-controller: target
+cloud: aws
+blockers:
+- controller: source
+  message: machine 0 is dying
+- controller: target
+  message: upgrade in progress
+transfer-size: 0
+`[1:])
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.dryRunErr = errors.NotSupportedf("DryRunMigration")
+	_, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "controller does not support migration dry runs, upgrade the controller first")
+}
+
 func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	ctx, err := s.makeAndRun(c, "model", "target")
 	c.Assert(err, jc.ErrorIsNil)
@@ -261,6 +376,35 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestActionSchedulesWarning(c *gc.C) {
+	s.scheduleAPI.schedules = []action.Schedule{{Name: "hourly"}, {Name: "nightly"}}
+	ctx, err := s.makeAndRun(c, "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration started with ID \"uuid:0\"\n")
+	c.Check(c.GetTestLog(), jc.Contains,
+		`WARNING cmd action schedule "hourly" will not be migrated, add it again once the migration has completed`)
+	c.Check(c.GetTestLog(), jc.Contains,
+		`WARNING cmd action schedule "nightly" will not be migrated, add it again once the migration has completed`)
+	c.Check(s.api.specSeen, gc.NotNil)
+}
+
+func (s *MigrateSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	s.scheduleAPI.err = errors.NotSupportedf("scheduling actions on this version of Juju")
+	ctx, err := s.makeAndRun(c, "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration started with ID \"uuid:0\"\n")
+	c.Check(c.GetTestLog(), gc.Not(jc.Contains), "action schedule")
+}
+
+func (s *MigrateSuite) TestActionSchedulesError(c *gc.C) {
+	s.scheduleAPI.err = errors.New("boom")
+	_, err := s.makeAndRun(c, "model", "target")
+	c.Assert(err, gc.ErrorMatches, "listing action schedules: boom")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "targetuser",
@@ -432,6 +576,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	}
 	inner.modelAPI = s.modelAPI
 	inner.userAPI = s.userAPI
+	inner.scheduleAPI = s.scheduleAPI
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
@@ -441,6 +586,21 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 type fakeMigrateAPI struct {
 	specSeen    *controller.MigrationSpec
 	identityURL string
+	resumed     []string
+	aborted     []string
+	failedErr   error
+	report      params.MigrationReport
+	dryRunErr   error
+}
+
+func (a *fakeMigrateAPI) ResumeMigration(modelUUID string) error {
+	a.resumed = append(a.resumed, modelUUID)
+	return a.failedErr
+}
+
+func (a *fakeMigrateAPI) AbortMigration(modelUUID string) error {
+	a.aborted = append(a.aborted, modelUUID)
+	return a.failedErr
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -448,6 +608,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) DryRunMigration(spec controller.MigrationSpec) (params.MigrationReport, error) {
+	a.specSeen = &spec
+	return a.report, a.dryRunErr
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
@@ -510,6 +675,19 @@ func (a *fakeUserAPI) UserInfo(_ []string, _ usermanager.IncludeDisabled) ([]par
 	return a.users, nil
 }
 
+type fakeScheduleAPI struct {
+	schedules []action.Schedule
+	err       error
+}
+
+func (*fakeScheduleAPI) Close() error {
+	return nil
+}
+
+func (a *fakeScheduleAPI) ListSchedules() ([]action.Schedule, error) {
+	return a.schedules, a.err
+}
+
 type fakeTargetControllerAPI struct {
 	api.Connection
 	cookieURL *url.URL
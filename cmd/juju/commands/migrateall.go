@@ -0,0 +1,525 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/macaroon.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/client/applicationoffers"
+	"github.com/juju/juju/api/controller/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/crossmodel"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+)
+
+const (
+	defaultMigrateAllConcurrency  = 4
+	defaultMigrateAllPollInterval = 5 * time.Second
+)
+
+// The states a model can be in once migrate-all has finished with it.
+const (
+	migrateAllNotStarted = "not started"
+	migrateAllMigrating  = "migrating"
+	migrateAllMigrated   = "migrated"
+	migrateAllWaiting    = "waiting"
+	migrateAllFailed     = "failed"
+)
+
+func newMigrateAllCommand() cmd.Command {
+	var cmd migrateAllCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
+	cmd.pollInterval = defaultMigrateAllPollInterval
+	return modelcmd.WrapController(&cmd)
+}
+
+// migrateAllCommand migrates many models to another controller,
+// tracking the migrations until they finish.
+type migrateAllCommand struct {
+	modelcmd.ControllerCommandBase
+	out              cmd.Output
+	targetController string
+	modelNames       []string
+	concurrency      int
+
+	// Overridden by tests
+	newAPIRoot   func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	migAPI       migrateAllAPI
+	offersAPI    offerListAPI
+	clock        clock.Clock
+	pollInterval time.Duration
+}
+
+type migrateAllAPI interface {
+	AllModels() ([]base.UserModel, error)
+	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationProgress(modelUUIDs ...string) ([]params.MigrationProgressResult, error)
+}
+
+type offerListAPI interface {
+	ListOffers(filters ...crossmodel.ApplicationOfferFilter) ([]*crossmodel.ApplicationOfferDetails, error)
+}
+
+const migrateAllDoc = `
+migrate-all migrates many workload models from the current controller
+to another controller, and waits for the migrations to finish. If no
+models are specified, every workload model on the controller is
+migrated. The controller model is never migrated.
+
+At most --concurrency migrations are run at once. Models related to
+each other through cross model relations are migrated together, so
+that an offering model and the models consuming its offers spend as
+little time as possible on different controllers. A group of related
+models larger than the concurrency limit is started once the other
+migrations have finished.
+
+Progress is reported as each migration moves through its phases. Once
+all of the migrations have finished, a summary of the outcome for each
+model is shown. The command fails if any model wasn't migrated.
+
+A migration which fails while importing the model into the target
+controller, or while processing its relations, waits to be resumed or
+aborted with the "migrate" command's --resume and --abort options.
+Such models are reported as waiting in the summary.
+
+Interrupting the command stops any further migrations from being
+started. Migrations which have already started continue, and can be
+tracked using the "status" command.
+
+In order to start a migration, the target controller must be in the
+juju client's local configuration cache. See the juju "login" command
+for details of how to do this.
+`
+
+const migrateAllExamples = `
+    juju migrate-all target-controller
+    juju migrate-all target-controller mymodel othermodel
+    juju migrate-all target-controller --concurrency 10 --format yaml
+`
+
+// Info implements cmd.Command.
+func (c *migrateAllCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "migrate-all",
+		Args:     "<target-controller-name> [<model-name> ...]",
+		Purpose:  "Migrate many workload models to another controller.",
+		Doc:      migrateAllDoc,
+		Examples: migrateAllExamples,
+		SeeAlso: []string{
+			"migrate",
+			"login",
+			"controllers",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateAllCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.concurrency, "concurrency", defaultMigrateAllConcurrency, "Maximum number of migrations to run at once")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrateAllTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *migrateAllCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target controller not specified")
+	}
+	if c.concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	c.targetController = args[0]
+	c.modelNames = args[1:]
+	return nil
+}
+
+// migratingModel tracks the migration of a single model.
+type migratingModel struct {
+	name        string
+	uuid        string
+	status      string
+	migrationId string
+	phase       string
+	message     string
+}
+
+// Run implements cmd.Command.
+func (c *migrateAllCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if controllerName == c.targetController {
+		return errors.New("target controller must be different to the current controller")
+	}
+	spec, err := migrationTargetSpec(c.ClientStore(), c.targetController, c.getTargetControllerMacaroons)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	migAPI, offersAPI, closer, err := c.getAPIs(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	models, err := c.selectModels(controllerName, migAPI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(models) == 0 {
+		ctx.Infof("No models to migrate")
+		return nil
+	}
+	groups, err := groupRelatedModels(models, offersAPI)
+	if err != nil {
+		ctx.Warningf("cross model relations will not be taken into account: %v", err)
+		groups = make([][]*migratingModel, len(models))
+		for i, m := range models {
+			groups[i] = []*migratingModel{m}
+		}
+	}
+
+	c.migrate(ctx, migAPI, *spec, groups)
+
+	summary := newMigrateAllSummary(c.targetController, models)
+	if err := c.out.Write(ctx, summary); err != nil {
+		return errors.Trace(err)
+	}
+	if summary.Migrated != len(models) {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *migrateAllCommand) getAPIs(controllerName string) (migrateAllAPI, offerListAPI, func(), error) {
+	if c.migAPI != nil && c.offersAPI != nil {
+		return c.migAPI, c.offersAPI, func() {}, nil
+	}
+	apiRoot, err := c.newAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	closer := func() { _ = apiRoot.Close() }
+	return controller.NewClient(apiRoot), applicationoffers.NewClient(apiRoot), closer, nil
+}
+
+func (c *migrateAllCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
+	return targetControllerMacaroons(&c.CommandBase, c.ClientStore(), c.targetController, c.newAPIRoot)
+}
+
+// selectModels returns the models to be migrated, sorted by name. If
+// no models were specified, all of the workload models are returned.
+func (c *migrateAllCommand) selectModels(controllerName string, migAPI migrateAllAPI) ([]*migratingModel, error) {
+	allModels, err := migAPI.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing models")
+	}
+	var controllerModel string
+	byName := make(map[string]base.UserModel)
+	for _, m := range allModels {
+		name := jujuclient.JoinOwnerModelName(names.NewUserTag(m.Owner), m.Name)
+		if m.Name == bootstrap.ControllerModelName {
+			controllerModel = name
+			continue
+		}
+		byName[name] = m
+	}
+
+	var models []*migratingModel
+	if len(c.modelNames) == 0 {
+		for name, m := range byName {
+			models = append(models, &migratingModel{name: name, uuid: m.UUID, status: migrateAllNotStarted})
+		}
+	} else {
+		account, err := c.ClientStore().AccountDetails(controllerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		seen := make(map[string]bool)
+		for _, name := range c.modelNames {
+			if !jujuclient.IsQualifiedModelName(name) {
+				name = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), name)
+			}
+			if name == controllerModel {
+				return nil, errors.New("cannot migrate the controller model")
+			}
+			m, ok := byName[name]
+			if !ok {
+				return nil, errors.NotFoundf("model %q", name)
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			models = append(models, &migratingModel{name: name, uuid: m.UUID, status: migrateAllNotStarted})
+		}
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].name < models[j].name
+	})
+	return models, nil
+}
+
+// groupRelatedModels groups together the models which are related to
+// each other, directly or indirectly, through cross model relations.
+// The groups are ordered by the first model in each.
+func groupRelatedModels(models []*migratingModel, offersAPI offerListAPI) ([][]*migratingModel, error) {
+	byName := make(map[string]*migratingModel)
+	byUUID := make(map[string]*migratingModel)
+	filters := make([]crossmodel.ApplicationOfferFilter, len(models))
+	for i, m := range models {
+		byName[m.name] = m
+		byUUID[m.uuid] = m
+		modelName, owner, err := jujuclient.SplitModelName(m.name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		filters[i] = crossmodel.ApplicationOfferFilter{
+			OwnerName: owner.Id(),
+			ModelName: modelName,
+		}
+	}
+	offers, err := offersAPI.ListOffers(filters...)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing offers")
+	}
+
+	parent := make(map[*migratingModel]*migratingModel)
+	var find func(*migratingModel) *migratingModel
+	find = func(m *migratingModel) *migratingModel {
+		p, ok := parent[m]
+		if !ok || p == m {
+			return m
+		}
+		root := find(p)
+		parent[m] = root
+		return root
+	}
+	for _, offer := range offers {
+		url, err := crossmodel.ParseOfferURL(offer.OfferURL)
+		if err != nil {
+			return nil, errors.Annotatef(err, "offer %q", offer.OfferName)
+		}
+		offerer, ok := byName[jujuclient.JoinOwnerModelName(names.NewUserTag(url.User), url.ModelName)]
+		if !ok {
+			continue
+		}
+		for _, conn := range offer.Connections {
+			if consumer, ok := byUUID[conn.SourceModelUUID]; ok {
+				parent[find(consumer)] = find(offerer)
+			}
+		}
+	}
+
+	var groups [][]*migratingModel
+	index := make(map[*migratingModel]int)
+	for _, m := range models {
+		root := find(m)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], m)
+	}
+	return groups, nil
+}
+
+// migrate starts the migrations of the groups of models, in order,
+// and waits for them to finish. Related models are started together.
+// A group larger than the concurrency limit is only started once no
+// other migrations are running.
+func (c *migrateAllCommand) migrate(
+	ctx *cmd.Context, migAPI migrateAllAPI, spec controller.MigrationSpec, groups [][]*migratingModel,
+) {
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var running []*migratingModel
+	for {
+		for len(groups) > 0 && (len(running) == 0 || len(running)+len(groups[0]) <= c.concurrency) {
+			for _, m := range groups[0] {
+				if c.startMigration(ctx, migAPI, spec, m) {
+					running = append(running, m)
+				}
+			}
+			groups = groups[1:]
+		}
+		if len(running) == 0 {
+			return
+		}
+
+		select {
+		case <-interrupted:
+			ctx.Infof("Interrupted, no further migrations will be started")
+			return
+		case <-c.clock.After(c.pollInterval):
+		}
+		running = c.pollMigrations(ctx, migAPI, running)
+	}
+}
+
+func (c *migrateAllCommand) startMigration(
+	ctx *cmd.Context, migAPI migrateAllAPI, spec controller.MigrationSpec, m *migratingModel,
+) bool {
+	spec.ModelUUID = m.uuid
+	id, err := migAPI.InitiateMigration(spec)
+	if err != nil {
+		m.status = migrateAllFailed
+		m.message = fmt.Sprintf("cannot start migration: %v", err)
+		ctx.Infof("%s: %s", m.name, m.message)
+		return false
+	}
+	m.status = migrateAllMigrating
+	m.migrationId = id
+	ctx.Infof("%s: migration started with ID %q", m.name, id)
+	return true
+}
+
+// pollMigrations updates the progress of the running migrations,
+// returning those which are still running.
+func (c *migrateAllCommand) pollMigrations(
+	ctx *cmd.Context, migAPI migrateAllAPI, running []*migratingModel,
+) []*migratingModel {
+	uuids := make([]string, len(running))
+	for i, m := range running {
+		uuids[i] = m.uuid
+	}
+	results, err := migAPI.MigrationProgress(uuids...)
+	if err != nil {
+		ctx.Warningf("cannot get migration progress: %v", err)
+		return running
+	}
+
+	var stillRunning []*migratingModel
+	for i, m := range running {
+		if updateMigratingModel(ctx, m, results[i]) {
+			stillRunning = append(stillRunning, m)
+		}
+	}
+	return stillRunning
+}
+
+// updateMigratingModel records the progress of a model's migration,
+// reporting any change. It returns whether the migration is still
+// running.
+func updateMigratingModel(ctx *cmd.Context, m *migratingModel, result params.MigrationProgressResult) bool {
+	if result.Error != nil {
+		m.status = migrateAllFailed
+		m.message = fmt.Sprintf("cannot get migration progress: %v", result.Error)
+		ctx.Infof("%s: %s", m.name, m.message)
+		return false
+	}
+	if result.Phase != m.phase || result.Message != m.message {
+		m.phase, m.message = result.Phase, result.Message
+		if m.message != "" {
+			ctx.Infof("%s: %s, %s", m.name, m.phase, m.message)
+		} else {
+			ctx.Infof("%s: %s", m.name, m.phase)
+		}
+	}
+
+	if result.FailedPhase != "" {
+		m.status = migrateAllWaiting
+		return false
+	}
+	switch phase, _ := coremigration.ParsePhase(result.Phase); phase {
+	case coremigration.DONE, coremigration.REAPFAILED:
+		// A failure to remove the model from this controller doesn't
+		// stop the model being managed by the target controller.
+		m.status = migrateAllMigrated
+		return false
+	case coremigration.ABORTDONE:
+		m.status = migrateAllFailed
+		return false
+	}
+	return true
+}
+
+// migrateAllSummary holds the outcome of a migrate-all run, as it is
+// displayed to the user.
+type migrateAllSummary struct {
+	TargetController string              `yaml:"target-controller" json:"target-controller"`
+	Migrated         int                 `yaml:"migrated" json:"migrated"`
+	Models           []migrateAllOutcome `yaml:"models" json:"models"`
+}
+
+type migrateAllOutcome struct {
+	Model       string `yaml:"model" json:"model"`
+	Status      string `yaml:"status" json:"status"`
+	MigrationId string `yaml:"migration-id,omitempty" json:"migration-id,omitempty"`
+	Phase       string `yaml:"phase,omitempty" json:"phase,omitempty"`
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func newMigrateAllSummary(targetController string, models []*migratingModel) migrateAllSummary {
+	out := migrateAllSummary{TargetController: targetController}
+	for _, m := range models {
+		if m.status == migrateAllMigrated {
+			out.Migrated++
+		}
+		out.Models = append(out.Models, migrateAllOutcome{
+			Model:       m.name,
+			Status:      m.status,
+			MigrationId: m.migrationId,
+			Phase:       m.phase,
+			Message:     m.message,
+		})
+	}
+	return out
+}
+
+func formatMigrateAllTabular(writer io.Writer, value interface{}) error {
+	summary, ok := value.(migrateAllSummary)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", summary, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Model", "Status", "Phase", "Message")
+	waiting := false
+	for _, outcome := range summary.Models {
+		w.Println(outcome.Model, outcome.Status, valueOrNone(outcome.Phase), outcome.Message)
+		waiting = waiting || outcome.Status == migrateAllWaiting
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	_, err := fmt.Fprintf(writer, "\n%d of %d models migrated to %q.\n",
+		summary.Migrated, len(summary.Models), summary.TargetController)
+	if err != nil || !waiting {
+		return errors.Trace(err)
+	}
+	_, err = fmt.Fprintln(writer,
+		`Waiting migrations can be resumed or aborted using "juju migrate <model> --resume" or "--abort".`)
+	return errors.Trace(err)
+}
@@ -0,0 +1,341 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller/controller"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+)
+
+type MigrateAllSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api       *fakeMigrateAllAPI
+	offersAPI *fakeOfferListAPI
+	store     *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&MigrateAllSuite{})
+
+func (s *MigrateAllSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.AddController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", jujuclient.AccountDetails{
+		User: "sourceuser",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.AddController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", jujuclient.AccountDetails{
+		User:     "targetuser",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeMigrateAllAPI{
+		models: []base.UserModel{
+			{Name: "controller", UUID: "controller-uuid", Type: model.IAAS, Owner: "admin"},
+			{Name: "gamma", UUID: "gamma-uuid", Type: model.IAAS, Owner: "sourceuser"},
+			{Name: "alpha", UUID: "alpha-uuid", Type: model.IAAS, Owner: "sourceuser"},
+			{Name: "beta", UUID: "beta-uuid", Type: model.CAAS, Owner: "sourceuser"},
+			{Name: "delta", UUID: "delta-uuid", Type: model.IAAS, Owner: "fred"},
+		},
+		progress: map[string][]params.MigrationProgressResult{
+			"alpha-uuid": {
+				{Phase: "QUIESCE", Message: "quiescing"},
+				{Phase: "DONE", Message: "successful"},
+			},
+			"beta-uuid": {
+				{Phase: "DONE", Message: "successful"},
+			},
+			"gamma-uuid": {
+				{Phase: "IMPORT", Message: "import failed: boom", FailedPhase: "IMPORT"},
+			},
+			"delta-uuid": {
+				{Phase: "ABORTDONE", Message: "aborted, removed model from target controller"},
+			},
+		},
+		running: make(map[string]bool),
+	}
+	s.offersAPI = &fakeOfferListAPI{}
+}
+
+func (s *MigrateAllSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := newMigrateAllCommand()
+	command.(modelcmd.ControllerCommand).SetClientStore(s.store)
+	inner := modelcmd.InnerCommand(command).(*migrateAllCommand)
+	inner.migAPI = s.api
+	inner.offersAPI = s.offersAPI
+	inner.pollInterval = 0
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *MigrateAllSuite) TestMissingTargetController(c *gc.C) {
+	_, err := s.makeAndRun(c)
+	c.Assert(err, gc.ErrorMatches, "target controller not specified")
+}
+
+func (s *MigrateAllSuite) TestInvalidConcurrency(c *gc.C) {
+	_, err := s.makeAndRun(c, "target", "--concurrency", "0")
+	c.Assert(err, gc.ErrorMatches, "--concurrency must be at least 1")
+}
+
+func (s *MigrateAllSuite) TestSameController(c *gc.C) {
+	_, err := s.makeAndRun(c, "source")
+	c.Assert(err, gc.ErrorMatches, "target controller must be different to the current controller")
+}
+
+func (s *MigrateAllSuite) TestTargetControllerDoesNotExist(c *gc.C) {
+	_, err := s.makeAndRun(c, "wat")
+	c.Assert(err, gc.ErrorMatches, "controller wat not found")
+	c.Check(s.api.started, gc.HasLen, 0)
+}
+
+func (s *MigrateAllSuite) TestMigrateAll(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"fred/delta", "sourceuser/alpha", "sourceuser/beta", "sourceuser/gamma"})
+	c.Check(s.api.specs[0], jc.DeepEquals, controller.MigrationSpec{
+		ModelUUID:             "delta-uuid",
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model             Status    Phase      Message
+fred/delta        failed    ABORTDONE  aborted, removed model from target controller
+sourceuser/alpha  migrated  DONE       successful
+sourceuser/beta   migrated  DONE       successful
+sourceuser/gamma  waiting   IMPORT     import failed: boom
+
+2 of 4 models migrated to "target".
+Waiting migrations can be resumed or aborted using "juju migrate <model> --resume" or "--abort".
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+fred/delta: migration started with ID "delta-uuid:0"
+sourceuser/alpha: migration started with ID "alpha-uuid:0"
+sourceuser/beta: migration started with ID "beta-uuid:0"
+sourceuser/gamma: migration started with ID "gamma-uuid:0"
+fred/delta: ABORTDONE, aborted, removed model from target controller
+sourceuser/alpha: QUIESCE, quiescing
+sourceuser/beta: DONE, successful
+sourceuser/gamma: IMPORT, import failed: boom
+sourceuser/alpha: DONE, successful
+`[1:])
+}
+
+func (s *MigrateAllSuite) TestConcurrencyLimit(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "target", "--concurrency", "2", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"fred/delta", "sourceuser/alpha", "sourceuser/beta", "sourceuser/gamma"})
+	c.Check(s.api.maxRunning, gc.Equals, 2)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+target-controller: target
+migrated: 2
+models:
+- model: fred/delta
+  status: failed
+  migration-id: delta-uuid:0
+  phase: ABORTDONE
+  message: aborted, removed model from target controller
+- model: sourceuser/alpha
+  status: migrated
+  migration-id: alpha-uuid:0
+  phase: DONE
+  message: successful
+- model: sourceuser/beta
+  status: migrated
+  migration-id: beta-uuid:0
+  phase: DONE
+  message: successful
+- model: sourceuser/gamma
+  status: waiting
+  migration-id: gamma-uuid:0
+  phase: IMPORT
+  message: 'import failed: boom'
+`[1:])
+}
+
+func (s *MigrateAllSuite) TestRelatedModelsMigratedTogether(c *gc.C) {
+	// gamma consumes an offer from alpha, so they're started together
+	// ahead of beta, even though beta sorts before gamma.
+	s.offersAPI.offers = []*crossmodel.ApplicationOfferDetails{{
+		OfferName: "db",
+		OfferURL:  "sourceuser/alpha.db",
+		Connections: []crossmodel.OfferConnection{
+			{SourceModelUUID: "gamma-uuid"},
+			{SourceModelUUID: "not-migrating-uuid"},
+		},
+	}}
+	s.api.progress["gamma-uuid"] = []params.MigrationProgressResult{
+		{Phase: "DONE", Message: "successful"},
+	}
+	_, err := s.makeAndRun(c, "target", "--concurrency", "2", "alpha", "beta", "gamma")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.offersAPI.filters, jc.DeepEquals, []crossmodel.ApplicationOfferFilter{
+		{OwnerName: "sourceuser", ModelName: "alpha"},
+		{OwnerName: "sourceuser", ModelName: "beta"},
+		{OwnerName: "sourceuser", ModelName: "gamma"},
+	})
+	c.Check(s.api.started, jc.DeepEquals, []string{"sourceuser/alpha", "sourceuser/gamma", "sourceuser/beta"})
+	c.Check(s.api.maxRunning, gc.Equals, 2)
+}
+
+func (s *MigrateAllSuite) TestGroupLargerThanConcurrencyLimit(c *gc.C) {
+	s.offersAPI.offers = []*crossmodel.ApplicationOfferDetails{{
+		OfferName:   "db",
+		OfferURL:    "sourceuser/beta.db",
+		Connections: []crossmodel.OfferConnection{{SourceModelUUID: "alpha-uuid"}},
+	}}
+	_, err := s.makeAndRun(c, "target", "--concurrency", "1", "alpha", "beta")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"sourceuser/alpha", "sourceuser/beta"})
+	c.Check(s.api.maxRunning, gc.Equals, 2)
+}
+
+func (s *MigrateAllSuite) TestListOffersFailure(c *gc.C) {
+	s.offersAPI.err = errors.New("boom")
+	_, err := s.makeAndRun(c, "target", "alpha")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), jc.Contains,
+		"cross model relations will not be taken into account: listing offers: boom")
+	c.Check(s.api.started, jc.DeepEquals, []string{"sourceuser/alpha"})
+}
+
+func (s *MigrateAllSuite) TestSpecifiedModels(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "target", "beta", "fred/delta", "beta")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(s.api.started, jc.DeepEquals, []string{"fred/delta", "sourceuser/beta"})
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `1 of 2 models migrated to "target".`)
+}
+
+func (s *MigrateAllSuite) TestModelDoesNotExist(c *gc.C) {
+	_, err := s.makeAndRun(c, "target", "wat")
+	c.Assert(err, gc.ErrorMatches, `model "sourceuser/wat" not found`)
+	c.Check(s.api.started, gc.HasLen, 0)
+}
+
+func (s *MigrateAllSuite) TestControllerModel(c *gc.C) {
+	_, err := s.makeAndRun(c, "target", "admin/controller")
+	c.Assert(err, gc.ErrorMatches, "cannot migrate the controller model")
+}
+
+func (s *MigrateAllSuite) TestInitiateFailure(c *gc.C) {
+	s.api.initiateErrs = map[string]error{"beta-uuid": errors.New("model has errors")}
+	ctx, err := s.makeAndRun(c, "target", "alpha", "beta")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model             Status    Phase  Message
+sourceuser/alpha  migrated  DONE   successful
+sourceuser/beta   failed    -      cannot start migration: model has errors
+
+1 of 2 models migrated to "target".
+`[1:])
+}
+
+func (s *MigrateAllSuite) TestProgressError(c *gc.C) {
+	s.api.progress["alpha-uuid"] = []params.MigrationProgressResult{{
+		Error: apiservererrors.ServerError(errors.NotFoundf("migration")),
+	}}
+	ctx, err := s.makeAndRun(c, "target", "alpha")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "cannot get migration progress: migration not found")
+}
+
+func (s *MigrateAllSuite) TestNoModels(c *gc.C) {
+	s.api.models = s.api.models[:1]
+	ctx, err := s.makeAndRun(c, "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No models to migrate\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+type fakeMigrateAllAPI struct {
+	models       []base.UserModel
+	progress     map[string][]params.MigrationProgressResult
+	initiateErrs map[string]error
+
+	specs      []controller.MigrationSpec
+	started    []string
+	running    map[string]bool
+	maxRunning int
+}
+
+func (a *fakeMigrateAllAPI) AllModels() ([]base.UserModel, error) {
+	return a.models, nil
+}
+
+func (a *fakeMigrateAllAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
+	if err := a.initiateErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
+	a.specs = append(a.specs, spec)
+	for _, m := range a.models {
+		if m.UUID == spec.ModelUUID {
+			a.started = append(a.started, m.Owner+"/"+m.Name)
+		}
+	}
+	a.running[spec.ModelUUID] = true
+	if len(a.running) > a.maxRunning {
+		a.maxRunning = len(a.running)
+	}
+	return spec.ModelUUID + ":0", nil
+}
+
+func (a *fakeMigrateAllAPI) MigrationProgress(modelUUIDs ...string) ([]params.MigrationProgressResult, error) {
+	results := make([]params.MigrationProgressResult, len(modelUUIDs))
+	for i, modelUUID := range modelUUIDs {
+		script := a.progress[modelUUID]
+		results[i] = script[0]
+		// The last result in each script ends the migration.
+		if len(script) > 1 {
+			a.progress[modelUUID] = script[1:]
+		} else {
+			delete(a.running, modelUUID)
+		}
+	}
+	return results, nil
+}
+
+type fakeOfferListAPI struct {
+	offers  []*crossmodel.ApplicationOfferDetails
+	filters []crossmodel.ApplicationOfferFilter
+	err     error
+}
+
+func (a *fakeOfferListAPI) ListOffers(filters ...crossmodel.ApplicationOfferFilter) ([]*crossmodel.ApplicationOfferDetails, error) {
+	a.filters = filters
+	return a.offers, a.err
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+// migrationReport holds the result of a migration dry run, as it is
+// displayed to the user.
+type migrationReport struct {
+	Model            string            `yaml:"model" json:"model"`
+	TargetController string            `yaml:"target-controller" json:"target-controller"`
+	Cloud            string            `yaml:"cloud" json:"cloud"`
+	CloudRegion      string            `yaml:"cloud-region,omitempty" json:"cloud-region,omitempty"`
+	CloudCredential  string            `yaml:"cloud-credential,omitempty" json:"cloud-credential,omitempty"`
+	Blockers         []migrationIssue  `yaml:"blockers,omitempty" json:"blockers,omitempty"`
+	Warnings         []migrationIssue  `yaml:"warnings,omitempty" json:"warnings,omitempty"`
+	Charms           []migrationBinary `yaml:"charms,omitempty" json:"charms,omitempty"`
+	AgentBinaries    []migrationBinary `yaml:"agent-binaries,omitempty" json:"agent-binaries,omitempty"`
+	Resources        []migrationBinary `yaml:"resources,omitempty" json:"resources,omitempty"`
+	TransferSize     int64             `yaml:"transfer-size" json:"transfer-size"`
+}
+
+type migrationIssue struct {
+	Controller string `yaml:"controller" json:"controller"`
+	Message    string `yaml:"message" json:"message"`
+}
+
+type migrationBinary struct {
+	Name string `yaml:"name" json:"name"`
+	Size int64  `yaml:"size" json:"size"`
+}
+
+func newMigrationReport(modelName, targetController string, in params.MigrationReport) migrationReport {
+	out := migrationReport{
+		Model:            modelName,
+		TargetController: targetController,
+		Cloud:            in.Cloud,
+		CloudRegion:      in.CloudRegion,
+		CloudCredential:  in.CloudCredential,
+		Blockers:         migrationIssues(in.Blockers),
+		Warnings:         migrationIssues(in.Warnings),
+	}
+	out.Charms, out.TransferSize = migrationBinaries(in.Charms, out.TransferSize)
+	out.AgentBinaries, out.TransferSize = migrationBinaries(in.Tools, out.TransferSize)
+	out.Resources, out.TransferSize = migrationBinaries(in.Resources, out.TransferSize)
+	return out
+}
+
+func migrationIssues(in []params.MigrationIssue) []migrationIssue {
+	var out []migrationIssue
+	for _, issue := range in {
+		out = append(out, migrationIssue{Controller: issue.Controller, Message: issue.Message})
+	}
+	return out
+}
+
+func migrationBinaries(in []params.MigrationBinary, total int64) ([]migrationBinary, int64) {
+	var out []migrationBinary
+	for _, binary := range in {
+		out = append(out, migrationBinary{Name: binary.Name, Size: binary.Size})
+		total += binary.Size
+	}
+	return out, total
+}
+
+func formatMigrationReportTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(migrationReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Model", "Target controller", "Cloud/Region", "Credential")
+	cloud := report.Cloud
+	if report.CloudRegion != "" {
+		cloud += "/" + report.CloudRegion
+	}
+	w.Println(report.Model, report.TargetController, cloud, valueOrNone(report.CloudCredential))
+
+	if len(report.Blockers) > 0 {
+		w.Println()
+		w.Println("Controller", "Blocker")
+		for _, issue := range report.Blockers {
+			w.Println(issue.Controller, issue.Message)
+		}
+	}
+	if len(report.Warnings) > 0 {
+		w.Println()
+		w.Println("Controller", "Warning")
+		for _, issue := range report.Warnings {
+			w.Println(issue.Controller, issue.Message)
+		}
+	}
+
+	if len(report.Charms)+len(report.AgentBinaries)+len(report.Resources) > 0 {
+		w.Println()
+		w.Println("Transfer", "Type", "Size")
+		printBinaries := func(kind string, binaries []migrationBinary) {
+			for _, binary := range binaries {
+				w.Println(binary.Name, kind, humanizeSize(binary.Size))
+			}
+		}
+		printBinaries("charm", report.Charms)
+		printBinaries("agent", report.AgentBinaries)
+		printBinaries("resource", report.Resources)
+		w.Println("Total", "", humanizeSize(report.TransferSize))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if n := len(report.Blockers); n > 0 {
+		_, err := fmt.Fprintf(writer, "\nMigration of %q to %q is blocked by %d issue(s).\n",
+			report.Model, report.TargetController, n)
+		return errors.Trace(err)
+	}
+	_, err := fmt.Fprintf(writer, "\nModel %q can be migrated to %q.\n", report.Model, report.TargetController)
+	return errors.Trace(err)
+}
+
+func humanizeSize(size int64) string {
+	if size < 0 {
+		size = 0
+	}
+	return humanize.IBytes(uint64(size))
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
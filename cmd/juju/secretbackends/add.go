@@ -55,6 +55,7 @@ const addSecretBackendsExamples = `
     juju add-secret-backend myvault vault --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault token-rotate=10m --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault endpoint=https://vault.io:8200 token=s.1wshwhw
+    juju add-secret-backend myencrypted encrypted key-dir=/var/lib/juju/secret-keys master-key-id=2023-10
 `
 
 // AddSecretBackendsAPI is the secrets client API.
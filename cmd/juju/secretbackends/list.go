@@ -72,6 +72,7 @@ func (c *listSecretBackendsCommand) Info() *cmd.Info {
 		Examples: listSecretBackendsExamples,
 		SeeAlso: []string{
 			"add-secret-backend",
+			"migrate-secret-backend",
 			"remove-secret-backend",
 			"show-secret-backend",
 			"update-secret-backend",
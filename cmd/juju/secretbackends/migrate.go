@@ -0,0 +1,307 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/secretbackends"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+)
+
+// migrateStatusInterval is how often the progress of a migration
+// is checked.
+const migrateStatusInterval = 2 * time.Second
+
+type migrateSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	MigrateSecretsAPIFunc func() (MigrateSecretsAPI, error)
+	clock                 clock.Clock
+
+	BackendName  string
+	URIs         []string
+	Applications []string
+	DryRun       bool
+}
+
+var migrateSecretsDoc = `
+Moves the content of secret revisions in the model to the specified
+secret backend. Unlike changing the model's secret-backend config, which
+drains every secret in the background, it moves just the secrets or
+applications asked for.
+
+By default all secret revisions in the model not already stored in the
+target backend are moved. Use --secret to move specific secrets, and
+--app to move the secrets owned by an application or its units.
+
+The revisions are moved by the controller, and the command reports its
+progress until the migration has finished. Interrupting the command does
+not stop the migration. Only one migration of a model's secrets can be
+in progress at a time.
+
+The content of each revision is read from its current backend, written
+to the target backend, then read back and compared against a checksum
+of the original. Only when the checksums match is the revision changed
+to use the target backend, in the same way as the secret's owner would
+change it, and the content removed from the original backend. The
+revisions of a secret owned by an application are only changed while
+that application has a leader. If a revision cannot be written,
+verified or updated, it is left where it was and any partial copy is
+removed, then the migration stops after the current batch and the
+failures are reported. To roll back a migration, run the command again
+naming the original backend.
+
+With --dry-run, nothing is moved; the revisions that would be moved are
+listed together with the checksum of their current content.
+`
+
+const migrateSecretsExamples = `
+    juju migrate-secret-backend myvault
+    juju migrate-secret-backend myvault --app mariadb --dry-run
+    juju migrate-secret-backend internal --secret secret:9m4e2mr0ui3e8a215n4g
+    juju migrate-secret-backend myvault -m mymodel --dry-run --format yaml
+`
+
+// MigrateSecretsAPI is the secrets client API.
+type MigrateSecretsAPI interface {
+	MigrateSecrets(params.MigrateSecretsArgs) (params.MigrateSecretsResult, error)
+	SecretsMigrationStatus(modelUUID string) (params.SecretsMigrationResult, error)
+	ListSecretBackends([]string, bool) ([]secretbackends.SecretBackend, error)
+	Close() error
+}
+
+// NewMigrateSecretsCommand returns a command to move secrets between
+// backends.
+func NewMigrateSecretsCommand() cmd.Command {
+	c := &migrateSecretsCommand{clock: clock.WallClock}
+	c.MigrateSecretsAPIFunc = c.secretBackendsAPI
+
+	return modelcmd.Wrap(c)
+}
+
+func (c *migrateSecretsCommand) secretBackendsAPI() (MigrateSecretsAPI, error) {
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secretbackends.NewClient(root), nil
+}
+
+// Info implements cmd.Info.
+func (c *migrateSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "migrate-secret-backend",
+		Purpose:  "Moves secret content in the model to a different secret backend.",
+		Doc:      migrateSecretsDoc,
+		Args:     "<backend-name>",
+		Examples: migrateSecretsExamples,
+		SeeAlso: []string{
+			"secret-backends",
+			"secrets",
+			"show-secret",
+		},
+	})
+}
+
+// SetFlags implements cmd.SetFlags.
+func (c *migrateSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.URIs), "secret", "Only move the specified secret")
+	f.Var(cmd.NewAppendStringsValue(&c.Applications), "app", "Only move secrets owned by the specified application or its units")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the revisions that would be moved without moving them")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		"tabular": func(writer io.Writer, value interface{}) error {
+			return formatMigratedRevisionsTabular(writer, value)
+		},
+	})
+}
+
+// Init implements cmd.Init.
+func (c *migrateSecretsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("must specify backend name")
+	}
+	c.BackendName = args[0]
+	for _, uri := range c.URIs {
+		if _, err := secrets.ParseURI(uri); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, app := range c.Applications {
+		if !names.IsValidApplication(app) {
+			return errors.NotValidf("application name %q", app)
+		}
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+type migratedRevision struct {
+	URI      string `json:"uri" yaml:"uri"`
+	Revision int    `json:"revision" yaml:"revision"`
+	From     string `json:"from" yaml:"from"`
+	To       string `json:"to" yaml:"to"`
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Run implements cmd.Run.
+func (c *migrateSecretsCommand) Run(ctxt *cmd.Context) error {
+	_, details, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.MigrateSecretsAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:    details.ModelUUID,
+		BackendName:  c.BackendName,
+		URIs:         c.URIs,
+		Applications: c.Applications,
+		DryRun:       c.DryRun,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Total == 0 {
+		ctxt.Infof("no secret revisions to move to %q", c.BackendName)
+		return nil
+	}
+	if c.DryRun {
+		return c.writeRevisions(ctxt, api, result.Revisions)
+	}
+	ctxt.Infof("moving %d secret revisions to %q", result.Total, c.BackendName)
+	return c.waitForMigration(ctxt, api, details.ModelUUID)
+}
+
+// writeRevisions writes the revisions which would be moved.
+func (c *migrateSecretsCommand) writeRevisions(
+	ctxt *cmd.Context, api MigrateSecretsAPI, results []params.MigrateSecretRevisionResult,
+) error {
+	backendNames, err := c.backendNames(api)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var (
+		revisions []migratedRevision
+		failed    bool
+	)
+	for _, r := range results {
+		rev := migratedRevision{
+			URI:      r.URI,
+			Revision: r.Revision,
+			From:     backendName(backendNames, r.FromBackendID),
+			To:       backendName(backendNames, r.ToBackendID),
+			Checksum: r.Checksum,
+		}
+		if r.Error != nil {
+			failed = true
+			rev.Error = r.Error.Error()
+			cmd.WriteError(ctxt.Stderr, errors.Annotatef(r.Error, "secret %s revision %d", r.URI, r.Revision))
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := c.out.Write(ctxt, revisions); err != nil {
+		return errors.Trace(err)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// waitForMigration reports the progress of the model's secrets
+// migration until it has finished.
+func (c *migrateSecretsCommand) waitForMigration(ctxt *cmd.Context, api MigrateSecretsAPI, modelUUID string) error {
+	interrupted := make(chan os.Signal, 1)
+	ctxt.InterruptNotify(interrupted)
+	defer ctxt.StopInterruptNotify(interrupted)
+
+	moved := 0
+	for {
+		select {
+		case <-interrupted:
+			ctxt.Infof("stopped waiting; the migration continues on the controller")
+			return nil
+		case <-c.clock.After(migrateStatusInterval):
+		}
+		status, err := api.SecretsMigrationStatus(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if status.Moved != moved {
+			moved = status.Moved
+			ctxt.Infof("moved %d of %d secret revisions to %q", moved, status.Total, c.BackendName)
+		}
+		switch status.Status {
+		case "completed":
+			return nil
+		case "failed":
+			for _, f := range status.Failures {
+				cmd.WriteError(ctxt.Stderr, errors.Errorf("secret %s revision %d: %s", f.URI, f.Revision, f.Message))
+			}
+			return cmd.ErrSilent
+		}
+	}
+}
+
+// backendNames returns the names of the secret backends keyed on ID.
+func (c *migrateSecretsCommand) backendNames(api MigrateSecretsAPI) (map[string]string, error) {
+	backends, err := api.ListSecretBackends(nil, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for _, b := range backends {
+		if b.ID != "" {
+			result[b.ID] = b.Name
+		}
+	}
+	return result, nil
+}
+
+func backendName(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
+
+// formatMigratedRevisionsTabular writes a tabular summary of migrated secret revisions.
+func formatMigratedRevisionsTabular(writer io.Writer, value interface{}) error {
+	revisions, ok := value.([]migratedRevision)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", revisions, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.SetColumnAlignRight(1)
+
+	w.Println("Secret", "Revision", "From", "To", "Checksum", "Message")
+	for _, r := range revisions {
+		w.Print(r.URI, r.Revision, r.From, r.To, r.Checksum, truncateMessage(r.Error))
+		w.Println()
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackends_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apisecretbackends "github.com/juju/juju/api/client/secretbackends"
+	"github.com/juju/juju/cmd/juju/secretbackends"
+	"github.com/juju/juju/cmd/juju/secretbackends/mocks"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MigrateSuite struct {
+	jujutesting.IsolationSuite
+	store             *jujuclient.MemStore
+	migrateSecretsAPI *mocks.MockMigrateSecretsAPI
+}
+
+var _ = gc.Suite(&MigrateSuite{})
+
+func (s *MigrateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "mycontroller"
+	store.Models["mycontroller"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/fred": {ModelUUID: coretesting.ModelTag.Id(), ModelType: "iaas"},
+		},
+		CurrentModel: "admin/fred",
+	}
+	store.Accounts["mycontroller"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.store = store
+}
+
+func (s *MigrateSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.migrateSecretsAPI = mocks.NewMockMigrateSecretsAPI(ctrl)
+	s.migrateSecretsAPI.EXPECT().Close().Return(nil)
+
+	return ctrl
+}
+
+func (s *MigrateSuite) expectBackends() {
+	s.migrateSecretsAPI.EXPECT().ListSecretBackends(nil, false).Return([]apisecretbackends.SecretBackend{{
+		ID:   coretesting.ControllerTag.Id(),
+		Name: "internal",
+	}, {
+		ID:   "vault-id",
+		Name: "myvault",
+	}}, nil)
+}
+
+func (s *MigrateSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	// The status of the migration is checked every few milliseconds.
+	clock := testclock.NewDilatedWallClock(time.Millisecond)
+	command := secretbackends.NewMigrateCommandForTest(s.store, s.migrateSecretsAPI, clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *MigrateSuite) TestMigrateInitError(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "must specify backend name",
+	}, {
+		args: []string{"myvault", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"myvault", "--secret", "foo:bar"},
+		err:  `secret URI scheme "foo" not valid`,
+	}, {
+		args: []string{"myvault", "--app", "-"},
+		err:  `application name "-" not valid`,
+	}, {
+		args: []string{"myvault", "--batch-size", "10"},
+		err:  `option provided but not defined: --batch-size`,
+	}} {
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *MigrateSuite) revision(uri string, rev int) params.MigrateSecretRevisionResult {
+	return params.MigrateSecretRevisionResult{
+		URI:           uri,
+		Revision:      rev,
+		FromBackendID: coretesting.ControllerTag.Id(),
+		ToBackendID:   "vault-id",
+		Checksum:      "deadbeef",
+	}
+}
+
+func (s *MigrateSuite) TestMigrate(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	gomock.InOrder(
+		s.migrateSecretsAPI.EXPECT().MigrateSecrets(params.MigrateSecretsArgs{
+			ModelUUID:    coretesting.ModelTag.Id(),
+			BackendName:  "myvault",
+			Applications: []string{"mariadb"},
+		}).Return(params.MigrateSecretsResult{Total: 3}, nil),
+		s.migrateSecretsAPI.EXPECT().SecretsMigrationStatus(coretesting.ModelTag.Id()).Return(params.SecretsMigrationResult{
+			Status: "pending",
+			Total:  3,
+		}, nil),
+		s.migrateSecretsAPI.EXPECT().SecretsMigrationStatus(coretesting.ModelTag.Id()).Return(params.SecretsMigrationResult{
+			Status: "running",
+			Total:  3,
+			Moved:  2,
+		}, nil),
+		s.migrateSecretsAPI.EXPECT().SecretsMigrationStatus(coretesting.ModelTag.Id()).Return(params.SecretsMigrationResult{
+			Status: "completed",
+			Total:  3,
+			Moved:  3,
+		}, nil),
+	)
+
+	ctx, err := s.run(c, "myvault", "--app", "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+moving 3 secret revisions to "myvault"
+moved 2 of 3 secret revisions to "myvault"
+moved 3 of 3 secret revisions to "myvault"
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *MigrateSuite) TestMigrateFailed(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	gomock.InOrder(
+		s.migrateSecretsAPI.EXPECT().MigrateSecrets(params.MigrateSecretsArgs{
+			ModelUUID:   coretesting.ModelTag.Id(),
+			BackendName: "myvault",
+		}).Return(params.MigrateSecretsResult{Total: 6}, nil),
+		s.migrateSecretsAPI.EXPECT().SecretsMigrationStatus(coretesting.ModelTag.Id()).Return(params.SecretsMigrationResult{
+			Status: "failed",
+			Total:  6,
+			Moved:  1,
+			Failures: []params.SecretsMigrationFailure{{
+				URI:      "secret:9m4e2mr0ui3e8a215n4g",
+				Revision: 2,
+				Message:  "checksum mismatch",
+			}},
+		}, nil),
+	)
+
+	ctx, err := s.run(c, "myvault")
+	c.Assert(err, gc.ErrorMatches, "cmd: error out silently")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+moving 6 secret revisions to "myvault"
+moved 1 of 6 secret revisions to "myvault"
+ERROR secret secret:9m4e2mr0ui3e8a215n4g revision 2: checksum mismatch
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateInProgress(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(gomock.Any()).Return(
+		params.MigrateSecretsResult{}, errors.AlreadyExistsf(`secrets migration to backend "internal"`))
+
+	_, err := s.run(c, "myvault")
+	c.Assert(err, gc.ErrorMatches, `secrets migration to backend "internal" already exists`)
+}
+
+func (s *MigrateSuite) TestMigrateDryRun(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectBackends()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(params.MigrateSecretsArgs{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		BackendName: "myvault",
+		URIs:        []string{"secret:9m4e2mr0ui3e8a215n4g"},
+		DryRun:      true,
+	}).Return(params.MigrateSecretsResult{
+		Revisions: []params.MigrateSecretRevisionResult{
+			s.revision("secret:9m4e2mr0ui3e8a215n4g", 1),
+		},
+		Total: 1,
+	}, nil)
+
+	ctx, err := s.run(c, "myvault", "--secret", "secret:9m4e2mr0ui3e8a215n4g", "--dry-run", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `[{"uri":"secret:9m4e2mr0ui3e8a215n4g","revision":1,"from":"internal","to":"myvault","checksum":"deadbeef"}]`+"\n")
+}
+
+func (s *MigrateSuite) TestMigrateDryRunTabular(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectBackends()
+
+	failed := s.revision("secret:9m4e2mr0ui3e8a215n4h", 1)
+	failed.Checksum = ""
+	failed.Error = &params.Error{Message: "permission denied"}
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(gomock.Any()).Return(params.MigrateSecretsResult{
+		Revisions: []params.MigrateSecretRevisionResult{
+			s.revision("secret:9m4e2mr0ui3e8a215n4g", 1),
+			failed,
+		},
+		Total: 2,
+	}, nil)
+
+	ctx, err := s.run(c, "myvault", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "cmd: error out silently")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+ERROR secret secret:9m4e2mr0ui3e8a215n4h revision 1: permission denied
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Secret                       Revision  From      To       Checksum  Message
+secret:9m4e2mr0ui3e8a215n4g         1  internal  myvault  deadbeef                     
+secret:9m4e2mr0ui3e8a215n4h         1  internal  myvault            permission denied  
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateNothingToDo(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.migrateSecretsAPI.EXPECT().MigrateSecrets(gomock.Any()).Return(params.MigrateSecretsResult{}, nil)
+
+	ctx, err := s.run(c, "internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+no secret revisions to move to "internal"
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/juju/secretbackends (interfaces: ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI)
+// Source: github.com/juju/juju/cmd/juju/secretbackends (interfaces: ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI,MigrateSecretsAPI)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/secretbackendsapi.go github.com/juju/juju/cmd/juju/secretbackends ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI
+//	mockgen -package mocks -destination mocks/secretbackendsapi.go github.com/juju/juju/cmd/juju/secretbackends ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI,MigrateSecretsAPI
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	secretbackends "github.com/juju/juju/api/client/secretbackends"
+	params "github.com/juju/juju/rpc/params"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -220,3 +221,85 @@ func (mr *MockUpdateSecretBackendsAPIMockRecorder) UpdateSecretBackend(arg0, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretBackend", reflect.TypeOf((*MockUpdateSecretBackendsAPI)(nil).UpdateSecretBackend), arg0, arg1)
 }
+
+// MockMigrateSecretsAPI is a mock of MigrateSecretsAPI interface.
+type MockMigrateSecretsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMigrateSecretsAPIMockRecorder
+}
+
+// MockMigrateSecretsAPIMockRecorder is the mock recorder for MockMigrateSecretsAPI.
+type MockMigrateSecretsAPIMockRecorder struct {
+	mock *MockMigrateSecretsAPI
+}
+
+// NewMockMigrateSecretsAPI creates a new mock instance.
+func NewMockMigrateSecretsAPI(ctrl *gomock.Controller) *MockMigrateSecretsAPI {
+	mock := &MockMigrateSecretsAPI{ctrl: ctrl}
+	mock.recorder = &MockMigrateSecretsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMigrateSecretsAPI) EXPECT() *MockMigrateSecretsAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMigrateSecretsAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMigrateSecretsAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMigrateSecretsAPI)(nil).Close))
+}
+
+// ListSecretBackends mocks base method.
+func (m *MockMigrateSecretsAPI) ListSecretBackends(arg0 []string, arg1 bool) ([]secretbackends.SecretBackend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretBackends", arg0, arg1)
+	ret0, _ := ret[0].([]secretbackends.SecretBackend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretBackends indicates an expected call of ListSecretBackends.
+func (mr *MockMigrateSecretsAPIMockRecorder) ListSecretBackends(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretBackends", reflect.TypeOf((*MockMigrateSecretsAPI)(nil).ListSecretBackends), arg0, arg1)
+}
+
+// MigrateSecrets mocks base method.
+func (m *MockMigrateSecretsAPI) MigrateSecrets(arg0 params.MigrateSecretsArgs) (params.MigrateSecretsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateSecrets", arg0)
+	ret0, _ := ret[0].(params.MigrateSecretsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateSecrets indicates an expected call of MigrateSecrets.
+func (mr *MockMigrateSecretsAPIMockRecorder) MigrateSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateSecrets", reflect.TypeOf((*MockMigrateSecretsAPI)(nil).MigrateSecrets), arg0)
+}
+
+// SecretsMigrationStatus mocks base method.
+func (m *MockMigrateSecretsAPI) SecretsMigrationStatus(arg0 string) (params.SecretsMigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretsMigrationStatus", arg0)
+	ret0, _ := ret[0].(params.SecretsMigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretsMigrationStatus indicates an expected call of SecretsMigrationStatus.
+func (mr *MockMigrateSecretsAPIMockRecorder) SecretsMigrationStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretsMigrationStatus", reflect.TypeOf((*MockMigrateSecretsAPI)(nil).SecretsMigrationStatus), arg0)
+}
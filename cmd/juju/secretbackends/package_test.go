@@ -6,12 +6,15 @@ package secretbackends
 import (
 	stdtesting "testing"
 
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 )
 
-//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretbackendsapi.go github.com/juju/juju/cmd/juju/secretbackends ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretbackendsapi.go github.com/juju/juju/cmd/juju/secretbackends ListSecretBackendsAPI,AddSecretBackendsAPI,RemoveSecretBackendsAPI,UpdateSecretBackendsAPI,MigrateSecretsAPI
 
 func TestPackage(t *stdtesting.T) {
 	gc.TestingT(t)
@@ -61,3 +64,13 @@ func NewUpdateCommandForTest(store jujuclient.ClientStore, updateSecretBackendsA
 	c.SetClientStore(store)
 	return c
 }
+
+// NewMigrateCommandForTest returns a migrate secret backend command for testing.
+func NewMigrateCommandForTest(store jujuclient.ClientStore, migrateSecretsAPI MigrateSecretsAPI, clock clock.Clock) cmd.Command {
+	c := &migrateSecretsCommand{
+		MigrateSecretsAPIFunc: func() (MigrateSecretsAPI, error) { return migrateSecretsAPI, nil },
+		clock:                 clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -45,6 +45,11 @@ To rotate the backend access credential/token (if specified), use
 the "token-rotate" config and supply a duration. To reset any existing
 token rotation period, supply a value of 0.
 
+To rotate the master key of an encrypted backend, first install the
+new key on every controller machine, then supply its "master-key-id".
+The key of each model is re-wrapped with the new master key, after
+which the old master key is no longer needed.
+
 `
 
 const updateSecretBackendsExamples = `
@@ -54,6 +59,7 @@ const updateSecretBackendsExamples = `
     juju update-secret-backend myvault endpoint=https://vault.io:8200 token=s.1wshwhw
     juju update-secret-backend myvault token-rotate=0
     juju update-secret-backend myvault --reset namespace,ca-cert
+    juju update-secret-backend myencrypted master-key-id=2024-01
 `
 
 // UpdateSecretBackendsAPI is the secrets client API.
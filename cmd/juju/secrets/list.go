@@ -96,6 +96,11 @@ type secretRevisionDetails struct {
 	CreateTime time.Time  `json:"created" yaml:"created"`
 	UpdateTime time.Time  `json:"updated" yaml:"updated"`
 	ExpireTime *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+
+	// The following are only shown with the revision history.
+	Trigger   string   `json:"trigger,omitempty" yaml:"trigger,omitempty"`
+	Author    string   `json:"author,omitempty" yaml:"author,omitempty"`
+	Consumers []string `json:"consumers,omitempty" yaml:"consumers,omitempty"`
 }
 
 type secretDetailsByID map[string]secretDisplayDetails
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/juju/secrets (interfaces: ListSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI)
+// Source: github.com/juju/juju/cmd/juju/secrets (interfaces: ListSecretsAPI,ShowSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI
+//	mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,ShowSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI
 //
 
 // Package mocks is a generated GoMock package.
@@ -69,6 +69,73 @@ func (mr *MockListSecretsAPIMockRecorder) ListSecrets(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockListSecretsAPI)(nil).ListSecrets), arg0, arg1)
 }
 
+// MockShowSecretsAPI is a mock of ShowSecretsAPI interface.
+type MockShowSecretsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockShowSecretsAPIMockRecorder
+}
+
+// MockShowSecretsAPIMockRecorder is the mock recorder for MockShowSecretsAPI.
+type MockShowSecretsAPIMockRecorder struct {
+	mock *MockShowSecretsAPI
+}
+
+// NewMockShowSecretsAPI creates a new mock instance.
+func NewMockShowSecretsAPI(ctrl *gomock.Controller) *MockShowSecretsAPI {
+	mock := &MockShowSecretsAPI{ctrl: ctrl}
+	mock.recorder = &MockShowSecretsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShowSecretsAPI) EXPECT() *MockShowSecretsAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockShowSecretsAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockShowSecretsAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockShowSecretsAPI)(nil).Close))
+}
+
+// ListSecretHistory mocks base method.
+func (m *MockShowSecretsAPI) ListSecretHistory(arg0 secrets0.Filter) ([]secrets.SecretDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecretHistory", arg0)
+	ret0, _ := ret[0].([]secrets.SecretDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretHistory indicates an expected call of ListSecretHistory.
+func (mr *MockShowSecretsAPIMockRecorder) ListSecretHistory(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretHistory", reflect.TypeOf((*MockShowSecretsAPI)(nil).ListSecretHistory), arg0)
+}
+
+// ListSecrets mocks base method.
+func (m *MockShowSecretsAPI) ListSecrets(arg0 bool, arg1 secrets0.Filter) ([]secrets.SecretDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", arg0, arg1)
+	ret0, _ := ret[0].([]secrets.SecretDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockShowSecretsAPIMockRecorder) ListSecrets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockShowSecretsAPI)(nil).ListSecrets), arg0, arg1)
+}
+
 // MockAddSecretsAPI is a mock of AddSecretsAPI interface.
 type MockAddSecretsAPI struct {
 	ctrl     *gomock.Controller
@@ -11,7 +11,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 )
 
-//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,ShowSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI
 
 func TestPackage(t *stdtesting.T) {
 	gc.TestingT(t)
@@ -72,9 +72,9 @@ func NewListCommandForTest(store jujuclient.ClientStore, listSecretsAPI ListSecr
 }
 
 // NewShowCommandForTest returns a list-secrets command for testing.
-func NewShowCommandForTest(store jujuclient.ClientStore, listSecretsAPI ListSecretsAPI) *showSecretsCommand {
+func NewShowCommandForTest(store jujuclient.ClientStore, listSecretsAPI ShowSecretsAPI) *showSecretsCommand {
 	c := &showSecretsCommand{
-		listSecretsAPIFunc: func() (ListSecretsAPI, error) { return listSecretsAPI, nil },
+		listSecretsAPIFunc: func() (ShowSecretsAPI, error) { return listSecretsAPI, nil },
 	}
 	c.SetClientStore(store)
 	return c
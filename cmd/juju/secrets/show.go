@@ -4,9 +4,14 @@
 package secrets
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
 
 	apisecrets "github.com/juju/juju/api/client/secrets"
 	jujucmd "github.com/juju/juju/cmd"
@@ -18,12 +23,16 @@ type showSecretsCommand struct {
 	modelcmd.ModelCommandBase
 	out cmd.Output
 
-	listSecretsAPIFunc func() (ListSecretsAPI, error)
+	listSecretsAPIFunc func() (ShowSecretsAPI, error)
 	uri                *coresecrets.URI
 	name               string
 	revealSecrets      bool
 	revisions          bool
 	revision           int
+	history            bool
+	diff               string
+	diffFrom           int
+	diffTo             int
 }
 
 var showSecretsDoc = `
@@ -34,6 +43,17 @@ with the '--reveal' option in json or yaml formats.
 
 Use --revision to inspect a particular revision, else latest is used.
 Use --revisions to see the metadata for each revision.
+
+Use --history to see the metadata for each revision together with
+what created it and the consumers still tracking it. The trigger of
+a revision is "create" for the first revision, "rotate" if it was
+created while the secret was due to be rotated, else "update".
+
+Use --diff to compare the content of two revisions, either given as
+<from>:<to> or as a single revision to compare with its predecessor.
+Only the keys which were added, removed or changed are shown, never
+the values. As the content is read to compare it, --diff requires the
+same access as --reveal.
 `
 
 const showSecretsExamples = `
@@ -43,8 +63,18 @@ const showSecretsExamples = `
     juju show-secret 9m4e2mr0ui3e8a215n4g --revision 2 --reveal
     juju show-secret 9m4e2mr0ui3e8a215n4g --revisions
     juju show-secret 9m4e2mr0ui3e8a215n4g --reveal
+    juju show-secret 9m4e2mr0ui3e8a215n4g --history
+    juju show-secret 9m4e2mr0ui3e8a215n4g --diff 3
+    juju show-secret 9m4e2mr0ui3e8a215n4g --diff 1:3
 `
 
+// ShowSecretsAPI is the secrets client API.
+type ShowSecretsAPI interface {
+	ListSecrets(bool, coresecrets.Filter) ([]apisecrets.SecretDetails, error)
+	ListSecretHistory(coresecrets.Filter) ([]apisecrets.SecretDetails, error)
+	Close() error
+}
+
 // NewShowSecretsCommand returns a command to list secrets metadata.
 func NewShowSecretsCommand() cmd.Command {
 	c := &showSecretsCommand{}
@@ -53,7 +83,7 @@ func NewShowSecretsCommand() cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
-func (c *showSecretsCommand) secretsAPI() (ListSecretsAPI, error) {
+func (c *showSecretsCommand) secretsAPI() (ShowSecretsAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -79,6 +109,8 @@ func (c *showSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.revisions, "revisions", false, "Show the secret revisions metadata")
 	f.IntVar(&c.revision, "revision", 0, "Show a specific revision (defaults to latest)")
 	f.IntVar(&c.revision, "r", 0, "")
+	f.BoolVar(&c.history, "history", false, "Show the secret revision history")
+	f.StringVar(&c.diff, "diff", "", "Show the keys changed between two revisions, as <from>:<to> or <to>")
 	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
@@ -93,6 +125,7 @@ func (c *showSecretsCommand) Init(args []string) error {
 	uri, err := coresecrets.ParseURI(args[0])
 	if err != nil {
 		c.name = args[0]
+		err = nil
 	}
 	c.uri = uri
 	if c.revisions {
@@ -106,9 +139,45 @@ func (c *showSecretsCommand) Init(args []string) error {
 	if c.revision < 0 {
 		return errors.New("revision must be a positive integer")
 	}
+	if c.history {
+		if c.revealSecrets || c.revisions || c.revision > 0 {
+			return errors.New("--history cannot be used with --reveal, --revisions or --revision")
+		}
+	}
+	if c.diff != "" {
+		if c.history || c.revealSecrets || c.revisions || c.revision > 0 {
+			return errors.New("--diff cannot be used with --history, --reveal, --revisions or --revision")
+		}
+		if c.diffFrom, c.diffTo, err = parseRevisionRange(c.diff); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// parseRevisionRange parses "<from>:<to>", or "<to>" which is
+// compared with the revision before it.
+func parseRevisionRange(s string) (int, int, error) {
+	fromStr, toStr, ok := strings.Cut(s, ":")
+	if !ok {
+		fromStr, toStr = "", s
+	}
+	to, err := strconv.Atoi(toStr)
+	if err != nil || to < 1 {
+		return 0, 0, errors.NotValidf("revision range %q", s)
+	}
+	from := to - 1
+	if ok {
+		if from, err = strconv.Atoi(fromStr); err != nil || from < 1 {
+			return 0, 0, errors.NotValidf("revision range %q", s)
+		}
+	}
+	if from < 1 || from == to {
+		return 0, 0, errors.NotValidf("revision range %q", s)
+	}
+	return from, to, nil
+}
+
 // Run implements cmd.Run.
 func (c *showSecretsCommand) Run(ctxt *cmd.Context) error {
 	if c.revealSecrets && c.out.Name() == "tabular" {
@@ -122,26 +191,154 @@ func (c *showSecretsCommand) Run(ctxt *cmd.Context) error {
 	}
 	defer api.Close()
 
-	filter := coresecrets.Filter{
-		URI: c.uri,
+	if c.diff != "" {
+		return c.showDiff(ctxt, api)
 	}
+
+	filter := c.filter()
 	if c.revision > 0 {
 		filter.Revision = &c.revision
 	}
-	if c.name != "" {
-		filter.Label = &c.name
+	var result []apisecrets.SecretDetails
+	if c.history {
+		result, err = api.ListSecretHistory(filter)
+	} else {
+		result, err = api.ListSecrets(c.revealSecrets, filter)
 	}
-	result, err := api.ListSecrets(c.revealSecrets, filter)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	details := gatherSecretInfo(result, c.revealSecrets, c.revisions, true)
+	details := gatherSecretInfo(result, c.revealSecrets, c.revisions || c.history, true)
 	if len(details) == 0 {
-		if c.uri != nil {
-			return errors.NotFoundf("secret %q", c.uri.ID)
-		}
-		return errors.NotFoundf("secret %q", c.name)
+		return c.notFound()
+	}
+	if c.history {
+		addRevisionHistory(details, result)
 	}
 
 	return c.out.Write(ctxt, details)
 }
+
+func (c *showSecretsCommand) filter() coresecrets.Filter {
+	filter := coresecrets.Filter{
+		URI: c.uri,
+	}
+	if c.name != "" {
+		filter.Label = &c.name
+	}
+	return filter
+}
+
+func (c *showSecretsCommand) notFound() error {
+	if c.uri != nil {
+		return errors.NotFoundf("secret %q", c.uri.ID)
+	}
+	return errors.NotFoundf("secret %q", c.name)
+}
+
+// addRevisionHistory adds the trigger, author and consumers
+// of each revision to the secret details.
+func addRevisionHistory(details map[string]secretDisplayDetails, secrets []apisecrets.SecretDetails) {
+	for _, m := range secrets {
+		if m.Metadata.URI == nil {
+			continue
+		}
+		info := details[m.Metadata.URI.ID]
+		for i, r := range m.Revisions {
+			rev := &info.Revisions[i]
+			switch {
+			case r.Rotated:
+				rev.Trigger = "rotate"
+			case r.Revision == 1:
+				rev.Trigger = "create"
+			default:
+				rev.Trigger = "update"
+			}
+			rev.Author = tagID(r.Author)
+			for _, consumer := range m.RevisionConsumers[r.Revision] {
+				rev.Consumers = append(rev.Consumers, tagID(consumer))
+			}
+		}
+		details[m.Metadata.URI.ID] = info
+	}
+}
+
+// tagID returns the ID of the entity with the given tag,
+// or the tag itself if it cannot be parsed.
+func tagID(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Id()
+}
+
+type secretDiffDetails struct {
+	FromRevision int               `json:"from-revision" yaml:"from-revision"`
+	ToRevision   int               `json:"to-revision" yaml:"to-revision"`
+	Changes      map[string]string `json:"changes" yaml:"changes"`
+}
+
+const (
+	keyAdded   = "added"
+	keyRemoved = "removed"
+	keyChanged = "changed"
+)
+
+// showDiff writes the keys which differ between two revisions of the
+// secret. The secret values themselves are never written.
+func (c *showSecretsCommand) showDiff(ctxt *cmd.Context, api ShowSecretsAPI) error {
+	var (
+		id     string
+		values [2]map[string]string
+	)
+	for i, rev := range []int{c.diffFrom, c.diffTo} {
+		rev := rev
+		filter := c.filter()
+		filter.Revision = &rev
+		result, err := api.ListSecrets(true, filter)
+		if err != nil {
+			return errors.Annotatef(err, "reading revision %d", rev)
+		}
+		if len(result) == 0 {
+			return c.notFound()
+		}
+		if result[0].Error != "" {
+			return errors.Errorf("reading revision %d: %s", rev, result[0].Error)
+		}
+		id = result[0].Metadata.URI.ID
+		values[i] = make(map[string]string)
+		if result[0].Value != nil {
+			values[i] = result[0].Value.EncodedValues()
+		}
+	}
+	changes := make(map[string]string)
+	for k, v := range values[0] {
+		newV, ok := values[1][k]
+		if !ok {
+			changes[k] = keyRemoved
+		} else if newV != v {
+			changes[k] = keyChanged
+		}
+	}
+	for k := range values[1] {
+		if _, ok := values[0][k]; !ok {
+			changes[k] = keyAdded
+		}
+	}
+	if len(changes) == 0 {
+		keys := make([]string, 0, len(values[1]))
+		for k := range values[1] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		ctxt.Infof("revisions %d and %d have the same content (keys: %s)", c.diffFrom, c.diffTo, strings.Join(keys, ", "))
+	}
+	return c.out.Write(ctxt, map[string]secretDiffDetails{
+		id: {
+			FromRevision: c.diffFrom,
+			ToRevision:   c.diffTo,
+			Changes:      changes,
+		},
+	})
+}
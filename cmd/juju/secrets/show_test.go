@@ -23,7 +23,7 @@ import (
 type ShowSuite struct {
 	jujutesting.IsolationSuite
 	store      *jujuclient.MemStore
-	secretsAPI *mocks.MockListSecretsAPI
+	secretsAPI *mocks.MockShowSecretsAPI
 }
 
 var _ = gc.Suite(&ShowSuite{})
@@ -39,7 +39,7 @@ func (s *ShowSuite) SetUpTest(c *gc.C) {
 func (s *ShowSuite) setup(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
-	s.secretsAPI = mocks.NewMockListSecretsAPI(ctrl)
+	s.secretsAPI = mocks.NewMockShowSecretsAPI(ctrl)
 
 	return ctrl
 }
@@ -221,3 +221,148 @@ func (s *ShowSuite) TestShowRevisions(c *gc.C) {
     updated: 0001-01-01T00:00:00Z
 `[1:], uri.ID))
 }
+
+func (s *ShowSuite) TestInitHistoryAndDiff(c *gc.C) {
+	uri := coresecrets.NewURI()
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--history", "--reveal"},
+		err:  "--history cannot be used with --reveal, --revisions or --revision",
+	}, {
+		args: []string{"--history", "--revision", "2"},
+		err:  "--history cannot be used with --reveal, --revisions or --revision",
+	}, {
+		args: []string{"--diff", "2", "--history"},
+		err:  "--diff cannot be used with --history, --reveal, --revisions or --revision",
+	}, {
+		args: []string{"--diff", "2", "--reveal"},
+		err:  "--diff cannot be used with --history, --reveal, --revisions or --revision",
+	}, {
+		args: []string{"--diff", "1"},
+		err:  `revision range "1" not valid`,
+	}, {
+		args: []string{"--diff", "2:2"},
+		err:  `revision range "2:2" not valid`,
+	}, {
+		args: []string{"--diff", "0:2"},
+		err:  `revision range "0:2" not valid`,
+	}, {
+		args: []string{"--diff", "a:b"},
+		err:  `revision range "a:b" not valid`,
+	}} {
+		args := append([]string{uri.ID}, t.args...)
+		_, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.store, s.secretsAPI), args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ShowSuite) TestShowHistory(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	uri := coresecrets.NewURI()
+	s.secretsAPI.EXPECT().ListSecretHistory(coresecrets.Filter{
+		URI: uri,
+	}).Return(
+		[]apisecrets.SecretDetails{{
+			Metadata: coresecrets.SecretMetadata{
+				URI: uri, RotatePolicy: coresecrets.RotateHourly,
+				Version: 1, LatestRevision: 2,
+				OwnerTag: "application-mysql",
+			},
+			Revisions: []coresecrets.SecretRevisionMetadata{{
+				Revision:    1,
+				BackendName: ptr("internal"),
+				Author:      "application-mysql",
+			}, {
+				Revision:    2,
+				BackendName: ptr("internal"),
+				Author:      "unit-mysql-0",
+				Rotated:     true,
+			}},
+			RevisionConsumers: map[int][]string{
+				1: {"unit-mariadb-0", "unit-mariadb-1"},
+				2: {"unit-wordpress-0"},
+			},
+		}}, nil)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.store, s.secretsAPI), uri.ID, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, gc.Equals, fmt.Sprintf(`
+%s:
+  revision: 2
+  rotation: hourly
+  owner: mysql
+  created: 0001-01-01T00:00:00Z
+  updated: 0001-01-01T00:00:00Z
+  revisions:
+  - revision: 1
+    backend: internal
+    created: 0001-01-01T00:00:00Z
+    updated: 0001-01-01T00:00:00Z
+    trigger: create
+    author: mysql
+    consumers:
+    - mariadb/0
+    - mariadb/1
+  - revision: 2
+    backend: internal
+    created: 0001-01-01T00:00:00Z
+    updated: 0001-01-01T00:00:00Z
+    trigger: rotate
+    author: mysql/0
+    consumers:
+    - wordpress/0
+`[1:], uri.ID))
+}
+
+func (s *ShowSuite) TestShowDiff(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	uri := coresecrets.NewURI()
+	for rev, value := range map[int]map[string]string{
+		1: {"foo": "YmFy", "baz": "cXV4", "old": "eA=="},
+		3: {"foo": "YmFy", "baz": "cXV1", "new": "eQ=="},
+	} {
+		rev := rev
+		s.secretsAPI.EXPECT().ListSecrets(true, coresecrets.Filter{
+			URI:      uri,
+			Revision: &rev,
+		}).Return([]apisecrets.SecretDetails{{
+			Metadata: coresecrets.SecretMetadata{URI: uri, LatestRevision: 3},
+			Value:    coresecrets.NewSecretValue(value),
+		}}, nil)
+	}
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.store, s.secretsAPI), uri.ID, "--diff", "1:3")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, gc.Equals, fmt.Sprintf(`
+%s:
+  from-revision: 1
+  to-revision: 3
+  changes:
+    baz: changed
+    new: added
+    old: removed
+`[1:], uri.ID))
+	c.Assert(out, gc.Not(jc.Contains), "YmFy")
+}
+
+func (s *ShowSuite) TestShowDiffError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	uri := coresecrets.NewURI()
+	s.secretsAPI.EXPECT().ListSecrets(true, gomock.Any()).Return([]apisecrets.SecretDetails{{
+		Metadata: coresecrets.SecretMetadata{URI: uri},
+		Error:    "permission denied",
+	}}, nil)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	_, err := cmdtesting.RunCommand(c, secrets.NewShowCommandForTest(s.store, s.secretsAPI), uri.ID, "--diff", "2")
+	c.Assert(err, gc.ErrorMatches, "reading revision 1: permission denied")
+}
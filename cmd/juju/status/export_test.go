@@ -6,6 +6,7 @@ package status
 import (
 	"github.com/juju/cmd/v3"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -17,3 +18,8 @@ func NewTestStatusCommand(statusapi statusAPI, clock Clock) cmd.Command {
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, clock: clock})
 }
+
+func NewTestStreamingStatusCommand(statusapi statusAPI, watcher api.AllWatch) cmd.Command {
+	return modelcmd.Wrap(
+		&statusCommand{statusAPI: statusapi, allWatcher: watcher})
+}
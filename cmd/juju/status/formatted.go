@@ -128,6 +128,7 @@ type applicationStatus struct {
 	CharmProfile     string                                 `json:"charm-profile,omitempty" yaml:"charm-profile,omitempty"`
 	CanUpgradeTo     string                                 `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
 	Scale            int                                    `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Autoscale        *applicationAutoscaleStatus            `json:"autoscale,omitempty" yaml:"autoscale,omitempty"`
 	ProviderId       string                                 `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                                 `json:"address,omitempty" yaml:"address,omitempty"`
 	Exposed          bool                                   `json:"exposed" yaml:"exposed"`
@@ -140,6 +141,21 @@ type applicationStatus struct {
 	EndpointBindings map[string]string                      `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
 }
 
+// applicationAutoscaleStatus holds an application's autoscale policy,
+// and what the autoscaler last observed of the application.
+type applicationAutoscaleStatus struct {
+	MinUnits     int    `json:"min-units" yaml:"min-units"`
+	MaxUnits     int    `json:"max-units" yaml:"max-units"`
+	TargetCPU    string `json:"target-cpu,omitempty" yaml:"target-cpu,omitempty"`
+	TargetMemory string `json:"target-memory,omitempty" yaml:"target-memory,omitempty"`
+	Cooldown     string `json:"cooldown" yaml:"cooldown"`
+	CPU          string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory       string `json:"memory,omitempty" yaml:"memory,omitempty"`
+	TargetScale  int    `json:"target-scale,omitempty" yaml:"target-scale,omitempty"`
+	LastScaled   string `json:"last-scaled,omitempty" yaml:"last-scaled,omitempty"`
+	Message      string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
 type applicationStatusRelation struct {
 	RelatedApplicationName string `json:"related-application,omitempty" yaml:"related-application,omitempty"`
 	Interface              string `json:"interface,omitempty" yaml:"interface,omitempty"`
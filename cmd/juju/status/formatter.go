@@ -5,6 +5,8 @@ package status
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/juju/charm/v12"
@@ -275,6 +277,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Exposed:          application.Exposed,
 		Life:             string(application.Life),
 		Scale:            application.Scale,
+		Autoscale:        sf.formatAutoscale(application.Autoscale),
 		ProviderId:       application.ProviderId,
 		Address:          application.PublicAddress,
 		Relations:        sf.processApplicationRelations(name, application.Relations),
@@ -299,6 +302,39 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+func (sf *statusFormatter) formatAutoscale(autoscale *params.ApplicationAutoscaleStatus) *applicationAutoscaleStatus {
+	if autoscale == nil {
+		return nil
+	}
+	percent := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64) + "%"
+	}
+	policy, status := autoscale.Policy, autoscale.Status
+	out := &applicationAutoscaleStatus{
+		MinUnits:    policy.MinUnits,
+		MaxUnits:    policy.MaxUnits,
+		Cooldown:    policy.Cooldown.String(),
+		TargetScale: status.TargetScale,
+		Message:     status.Message,
+	}
+	if policy.TargetCPU > 0 {
+		out.TargetCPU = percent(float64(policy.TargetCPU))
+	}
+	if policy.TargetMemory > 0 {
+		out.TargetMemory = percent(float64(policy.TargetMemory))
+	}
+	if status.CPU != nil {
+		out.CPU = percent(math.Round(*status.CPU*10) / 10)
+	}
+	if status.Memory != nil {
+		out.Memory = percent(math.Round(*status.Memory*10) / 10)
+	}
+	if status.LastScaled != nil {
+		out.LastScaled = common.FormatTime(status.LastScaled, sf.isoTime)
+	}
+	return out
+}
+
 func (sf *statusFormatter) processApplicationRelations(appName string, rels map[string][]string) map[string][]applicationStatusRelation {
 	out := make(map[string][]applicationStatusRelation)
 	for relName, theOtherSideAppNames := range rels {
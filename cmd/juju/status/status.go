@@ -19,6 +19,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/viddy"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/client/client"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/storage"
@@ -74,6 +75,11 @@ type statusCommand struct {
 
 	// watch indicates the time to wait between consecutive status queries
 	watch time.Duration
+
+	// stream indicates that changes to the model are streamed as JSON
+	// lines, rather than reporting the status once.
+	stream     bool
+	allWatcher api.AllWatch
 }
 
 var usageSummary = `
@@ -149,6 +155,10 @@ Watch the status every five seconds:
 
     juju status --watch 5s
 
+Stream changes to the model as JSON lines, one per changed entity:
+
+    juju status --format=json --stream
+
 Show only applications/units in active status:
 
     juju status active
@@ -188,6 +198,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
 	f.DurationVar(&c.watch, "watch", 0, "Watch the status every period of time")
+	f.BoolVar(&c.stream, "stream", false, "Stream changes to the model as JSON lines (requires --format=json)")
 
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
@@ -239,6 +250,18 @@ func (c *statusCommand) Init(args []string) error {
 		return errors.Errorf("cannot mix --no-color and --color")
 	}
 
+	if c.stream {
+		if c.out.Name() != "json" {
+			return errors.Errorf("--stream requires --format=json")
+		}
+		if c.watch != 0 {
+			return errors.Errorf("cannot mix --stream and --watch")
+		}
+		if len(c.patterns) > 0 {
+			return errors.Errorf("selectors are not supported with --stream")
+		}
+	}
+
 	return nil
 }
 
@@ -256,6 +279,9 @@ var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 func (c *statusCommand) close() {
 	// We really don't care what the errors are if there are some.
 	// The user can't do anything about it.  Just try.
+	if c.allWatcher != nil {
+		c.allWatcher.Stop()
+	}
 	if c.statusAPI != nil {
 		c.statusAPI.Close()
 	}
@@ -414,6 +440,10 @@ func (c *statusCommand) statusCommandForViddy(args []string) []string {
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.stream {
+		return errors.Trace(c.streamStatus(ctx))
+	}
+
 	if c.watch != 0 {
 		jujuStatusArgs := c.statusCommandForViddy(os.Args)
 
@@ -6454,6 +6454,41 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatAutoscale(c *gc.C) {
+	lastScaled := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	cpu := 72.46
+	formatter := NewStatusFormatter(NewStatusFormatterParams{
+		Status:  &params.FullStatus{},
+		ISOTime: true,
+	})
+	formatted := formatter.formatAutoscale(&params.ApplicationAutoscaleStatus{
+		Policy: params.AutoscalePolicy{
+			MinUnits:  1,
+			MaxUnits:  5,
+			TargetCPU: 60,
+			Cooldown:  3 * time.Minute,
+		},
+		Status: params.AutoscaleStatus{
+			UnitsMeasured: 2,
+			CPU:           &cpu,
+			TargetScale:   3,
+			LastScaled:    &lastScaled,
+			Message:       "waiting for cooldown before scaling to 3",
+		},
+	})
+	c.Check(formatted, jc.DeepEquals, &applicationAutoscaleStatus{
+		MinUnits:    1,
+		MaxUnits:    5,
+		TargetCPU:   "60%",
+		Cooldown:    "3m0s",
+		CPU:         "72.5%",
+		TargetScale: 3,
+		LastScaled:  "2023-10-17 12:00:00Z",
+		Message:     "waiting for cooldown before scaling to 3",
+	})
+	c.Check(formatter.formatAutoscale(nil), gc.IsNil)
+}
+
 func (s *StatusSuite) TestMissingControllerTimestampInFullStatus(c *gc.C) {
 	status := &params.FullStatus{
 		Model: params.ModelStatusInfo{
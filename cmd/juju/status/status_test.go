@@ -4,7 +4,12 @@
 package status_test
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd/v3"
@@ -134,6 +139,151 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) runStream(c *gc.C, watcher *fakeAllWatcher, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStreamingStatusCommand(s.statusapi, watcher)
+	return cmdtesting.RunCommand(c, statusCmd, args...)
+}
+
+func (s *MinimalStatusSuite) TestStream(c *gc.C) {
+	watcher := &fakeAllWatcher{
+		deltas: [][]params.Delta{{{
+			Entity: &params.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql", MachineId: "0"},
+		}, {
+			Entity: &params.MachineInfo{ModelUUID: "uuid", Id: "0", Base: "ubuntu@22.04"},
+		}}, {{
+			Entity: &params.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql", MachineId: "1"},
+		}, {
+			// Unchanged entities aren't reported.
+			Entity: &params.MachineInfo{ModelUUID: "uuid", Id: "0", Base: "ubuntu@22.04"},
+		}, {
+			Removed: true,
+			Entity:  &params.MachineInfo{ModelUUID: "uuid", Id: "0"},
+		}}},
+	}
+
+	ctx, err := s.runStream(c, watcher, "--format=json", "--stream")
+	c.Assert(err, gc.ErrorMatches, "watcher stopped")
+	c.Assert(watcher.isStopped(), jc.IsTrue)
+
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n")
+	c.Assert(lines, gc.HasLen, 4)
+	var deltas []status.StreamDelta
+	for _, line := range lines {
+		var delta status.StreamDelta
+		c.Assert(json.Unmarshal([]byte(line), &delta), jc.ErrorIsNil)
+		deltas = append(deltas, delta)
+	}
+	c.Check(deltas[0].ModelUUID, gc.Equals, "uuid")
+	c.Check(deltas[0].Entity, gc.Equals, "unit")
+	c.Check(deltas[0].Id, gc.Equals, "mysql/0")
+	c.Check(deltas[0].Change, gc.Equals, "add")
+	c.Check(string(deltas[0].Fields["machine-id"]), gc.Equals, `"0"`)
+	c.Check(string(deltas[0].Fields["application"]), gc.Equals, `"mysql"`)
+
+	c.Check(deltas[1].Entity, gc.Equals, "machine")
+	c.Check(deltas[1].Change, gc.Equals, "add")
+
+	c.Check(deltas[2].Entity, gc.Equals, "unit")
+	c.Check(deltas[2].Change, gc.Equals, "change")
+	c.Check(deltas[2].Fields, jc.DeepEquals, map[string]json.RawMessage{
+		"machine-id": json.RawMessage(`"1"`),
+	})
+
+	c.Check(deltas[3], jc.DeepEquals, status.StreamDelta{
+		ModelUUID: "uuid", Entity: "machine", Id: "0", Change: "remove",
+	})
+}
+
+func (s *MinimalStatusSuite) TestStreamInterrupted(c *gc.C) {
+	// The model is quiet, so Next blocks until the watcher is stopped.
+	watcher := &fakeAllWatcher{block: make(chan struct{})}
+	statusCmd := status.NewTestStreamingStatusCommand(s.statusapi, watcher)
+	err := cmdtesting.InitCommand(statusCmd, []string{"--format=json", "--stream"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Catch the interrupts here too, so that one sent before the
+	// command is ready for it doesn't kill the test.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	proc, err := os.FindProcess(os.Getpid())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := cmdtesting.Context(c)
+	done := make(chan error, 1)
+	go func() {
+		done <- statusCmd.Run(ctx)
+	}()
+
+	timeout := time.After(testing.LongWait)
+	for {
+		c.Assert(proc.Signal(os.Interrupt), jc.ErrorIsNil)
+		select {
+		case err := <-done:
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(watcher.isStopped(), jc.IsTrue)
+			return
+		case <-time.After(testing.ShortWait):
+		case <-timeout:
+			c.Fatalf("interrupted stream didn't finish")
+		}
+	}
+}
+
+func (s *MinimalStatusSuite) TestStreamRequiresJSON(c *gc.C) {
+	_, err := s.runStream(c, &fakeAllWatcher{}, "--stream")
+	c.Assert(err, gc.ErrorMatches, "--stream requires --format=json")
+}
+
+func (s *MinimalStatusSuite) TestStreamWithWatch(c *gc.C) {
+	_, err := s.runStream(c, &fakeAllWatcher{}, "--format=json", "--stream", "--watch", "5s")
+	c.Assert(err, gc.ErrorMatches, "cannot mix --stream and --watch")
+}
+
+func (s *MinimalStatusSuite) TestStreamWithSelectors(c *gc.C) {
+	_, err := s.runStream(c, &fakeAllWatcher{}, "--format=json", "--stream", "mysql")
+	c.Assert(err, gc.ErrorMatches, "selectors are not supported with --stream")
+}
+
+type fakeAllWatcher struct {
+	deltas [][]params.Delta
+
+	mu      sync.Mutex
+	stopped bool
+
+	// block, if set, makes Next wait until the watcher is stopped
+	// once there are no more deltas.
+	block chan struct{}
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if len(w.deltas) == 0 {
+		if w.block != nil {
+			<-w.block
+		}
+		return nil, errors.New("watcher stopped")
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.block != nil && !w.stopped {
+		close(w.block)
+	}
+	w.stopped = true
+	return nil
+}
+
+func (w *fakeAllWatcher) isStopped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stopped
+}
+
 type fakeStatusAPI struct {
 	expectIncludeStorage bool
 	result               *params.FullStatus
@@ -0,0 +1,197 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/rpc/params"
+)
+
+const (
+	// streamChangeAdd is the change type of the first delta seen for an
+	// entity.
+	streamChangeAdd = "add"
+
+	// streamChangeChange is the change type of subsequent deltas for an
+	// entity.
+	streamChangeChange = "change"
+
+	// streamChangeRemove is the change type of the delta for an entity
+	// that has been removed.
+	streamChangeRemove = "remove"
+)
+
+// StreamDelta is a single line of streamed status output, describing a
+// change to one entity in the model.
+type StreamDelta struct {
+	// ModelUUID is the UUID of the model holding the entity.
+	ModelUUID string `json:"model-uuid"`
+
+	// Entity is the kind of entity, e.g. "unit" or "machine".
+	Entity string `json:"entity"`
+
+	// Id identifies the entity within its kind, e.g. "mysql/0".
+	Id string `json:"id"`
+
+	// Change is one of "add", "change" or "remove".
+	Change string `json:"change"`
+
+	// Fields holds the entity's fields for "add" deltas, and the fields
+	// whose values have changed for "change" deltas.
+	Fields map[string]json.RawMessage `json:"fields,omitempty"`
+}
+
+var newAllWatcherForStatus = func(c *statusCommand) (api.AllWatch, error) {
+	if c.allWatcher != nil {
+		return c.allWatcher, nil
+	}
+	apiclient, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The client is closed, along with the watcher, when the command
+	// finishes.
+	c.statusAPI = apiclient
+	w, err := apiclient.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.allWatcher = w
+	return w, nil
+}
+
+// streamer converts deltas from the AllWatcher into StreamDeltas. It
+// remembers the fields of each entity, so that only the fields which
+// have changed are emitted.
+type streamer struct {
+	encoder  *json.Encoder
+	entities map[params.EntityId]map[string]json.RawMessage
+}
+
+func newStreamer(w io.Writer) *streamer {
+	return &streamer{
+		encoder:  json.NewEncoder(w),
+		entities: make(map[params.EntityId]map[string]json.RawMessage),
+	}
+}
+
+// write emits a JSON line for each delta that changes an entity.
+func (s *streamer) write(deltas []params.Delta) error {
+	for _, delta := range deltas {
+		line, err := s.streamDelta(delta)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if line == nil {
+			continue
+		}
+		if err := s.encoder.Encode(line); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (s *streamer) streamDelta(delta params.Delta) (*StreamDelta, error) {
+	id := delta.Entity.EntityId()
+	line := &StreamDelta{
+		ModelUUID: id.ModelUUID,
+		Entity:    id.Kind,
+		Id:        id.Id,
+	}
+	if delta.Removed {
+		delete(s.entities, id)
+		line.Change = streamChangeRemove
+		return line, nil
+	}
+
+	data, err := json.Marshal(delta.Entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Trace(err)
+	}
+	previous, ok := s.entities[id]
+	s.entities[id] = fields
+	if !ok {
+		line.Change = streamChangeAdd
+		line.Fields = fields
+		return line, nil
+	}
+
+	changed := make(map[string]json.RawMessage)
+	for name, value := range fields {
+		if old, ok := previous[name]; !ok || string(old) != string(value) {
+			changed[name] = value
+		}
+	}
+	for name := range previous {
+		if _, ok := fields[name]; !ok {
+			changed[name] = json.RawMessage("null")
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	line.Change = streamChangeChange
+	line.Fields = changed
+	return line, nil
+}
+
+// streamStatus writes a JSON line for each change to the entities in
+// the model, until the watcher fails or the command is interrupted.
+// The first lines describe the current state of every entity.
+func (c *statusCommand) streamStatus(ctx *cmd.Context) error {
+	// The watcher is stopped when the command is closed.
+	w, err := newAllWatcherForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Next blocks until there are changes, so stop the watcher when
+	// the command is interrupted to unblock it. The stream is marked
+	// as interrupted first, so that the error Next then returns is
+	// expected.
+	interrupt := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupt)
+	defer ctx.StopInterruptNotify(interrupt)
+	finished := make(chan struct{})
+	interrupted := make(chan struct{})
+	go func() {
+		select {
+		case <-interrupt:
+			close(interrupted)
+			_ = w.Stop()
+		case <-finished:
+		}
+	}()
+	defer close(finished)
+
+	s := newStreamer(ctx.Stdout)
+	for {
+		deltas, err := w.Next()
+		if err != nil {
+			select {
+			case <-interrupted:
+				// The watcher has already been stopped.
+				c.allWatcher = nil
+				return nil
+			default:
+			}
+			return errors.Trace(err)
+		}
+		if err := s.write(deltas); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
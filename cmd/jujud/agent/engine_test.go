@@ -32,6 +32,8 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-rollout",         // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-downloader",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
@@ -58,6 +60,8 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-rollout",
+		"action-scheduler",
 		"application-scaler",
 		"charm-downloader",
 		"charm-revision-updater",
@@ -58,6 +58,7 @@ import (
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
+	corelease "github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/life"
 	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/core/machinelock"
@@ -81,6 +82,7 @@ import (
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/introspection"
+	"github.com/juju/juju/worker/lease"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
 	"github.com/juju/juju/worker/migrationmaster"
@@ -587,6 +589,10 @@ func (a *MachineAgent) makeEngineCreator(
 		// which is set to the current StatePool managed by the state
 		// tracker in controller agents.
 		var statePoolReporter statePoolIntrospectionReporter
+		// leaseStoreReporter is an introspection.LeaseStore, which is
+		// set to the current lease store managed by the lease manager
+		// in controller agents.
+		var leaseStoreReporter leaseStoreIntrospectionReporter
 		registerIntrospectionHandlers := func(handle func(path string, h http.Handler)) {
 			handle("/metrics/", promhttp.HandlerFor(a.prometheusRegistry, promhttp.HandlerOpts{}))
 		}
@@ -627,6 +633,7 @@ func (a *MachineAgent) makeEngineCreator(
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.Set,
+			SetLeaseStore:                     leaseStoreReporter.Set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			MuxShutdownWait:                   1 * time.Minute,
@@ -663,6 +670,7 @@ func (a *MachineAgent) makeEngineCreator(
 			Clock:              clock.WallClock,
 			LocalHub:           localHub,
 			CentralHub:         a.centralHub,
+			LeaseStore:         &leaseStoreReporter,
 		}); err != nil {
 			// If the introspection worker failed to start, we just log error
 			// but continue. It is very unlikely to happen in the real world
@@ -1319,3 +1327,41 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// leaseStoreIntrospectionReporter wraps a (possibly nil) lease.Store,
+// delegating to it or returning an error if it is nil.
+type leaseStoreIntrospectionReporter struct {
+	mu    sync.Mutex
+	store *lease.Store
+}
+
+func (h *leaseStoreIntrospectionReporter) Set(store *lease.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.store = store
+}
+
+func (h *leaseStoreIntrospectionReporter) get() (*lease.Store, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.store == nil {
+		return nil, errors.New("agent has no lease store set")
+	}
+	return h.store, nil
+}
+
+func (h *leaseStoreIntrospectionReporter) Leases(ctx stdcontext.Context, keys ...corelease.Key) (map[corelease.Key]corelease.Info, error) {
+	store, err := h.get()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store.Leases(ctx, keys...)
+}
+
+func (h *leaseStoreIntrospectionReporter) Pinned(ctx stdcontext.Context) (map[corelease.Key][]string, error) {
+	store, err := h.get()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store.Pinned(ctx)
+}
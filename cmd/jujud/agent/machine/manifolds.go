@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/upgrades"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
@@ -46,6 +47,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasunitsmanager"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
@@ -69,6 +71,7 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/lease"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/leaseexpiry"
 	"github.com/juju/juju/worker/logger"
@@ -88,6 +91,7 @@ import (
 	"github.com/juju/juju/worker/querylogger"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/secretbackendrotate"
+	"github.com/juju/juju/worker/secretnotifier"
 	"github.com/juju/juju/worker/singular"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
@@ -96,6 +100,7 @@ import (
 	"github.com/juju/juju/worker/syslogger"
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
+	"github.com/juju/juju/worker/tracer"
 	"github.com/juju/juju/worker/upgradedatabase"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradeseries"
@@ -219,6 +224,11 @@ type ManifoldsConfig struct {
 	// worker running outside of the dependency engine.
 	SetStatePool func(*state.StatePool)
 
+	// SetLeaseStore is used by the lease manager for informing the agent
+	// of the lease store that it creates, so we can pass it to the
+	// introspection worker running outside of the dependency engine.
+	SetLeaseStore func(*lease.Store)
+
 	// RegisterIntrospectionHTTPHandlers is a function that calls the
 	// supplied function to register introspection HTTP handlers. The
 	// function will be passed a path and a handler; the function may
@@ -579,6 +589,17 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpdateAgentFunc: config.UpdateLoggerConfig,
 		})),
 
+		// The tracer installs an OpenTelemetry tracer provider for the
+		// agent when tracing is enabled in the controller config, so
+		// that spans created by other workers (and, on controllers, by
+		// the API server) are exported to the configured collector.
+		tracerName: ifNotMigrating(tracer.Manifold(tracer.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Logger:        loggo.GetLogger("juju.worker.tracer"),
+			NewExporter:   tracer.NewExporter,
+		})),
+
 		// The log sender is a leaf worker that sends log messages to some
 		// API server, when configured so to do. We should only need one of
 		// these in a consolidated agent.
@@ -754,6 +775,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PrometheusRegisterer: config.PrometheusRegisterer,
 			NewWorker:            leasemanager.NewWorker,
 			NewStore:             leasemanager.NewStore,
+			SetLeaseStore:        config.SetLeaseStore,
 		})),
 
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
@@ -788,6 +810,17 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		// The secret notifier posts secret expiry and rotation events
+		// to the webhook configured in the controller config. It only
+		// runs on the primary controller, so that each event is only
+		// posted once.
+		secretNotifierName: ifNotMigrating(ifPrimaryController(secretnotifier.Manifold(secretnotifier.ManifoldConfig{
+			StateName: stateName,
+			Clock:     config.Clock,
+			Logger:    loggo.GetLogger("juju.worker.secretnotifier"),
+			NewWorker: secretnotifier.NewWorker,
+		}))),
+
 		// The controlsocket worker runs on the controller machine.
 		controlSocketName: ifController(controlsocket.Manifold(controlsocket.ManifoldConfig{
 			StateName:  stateName,
@@ -981,6 +1014,18 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Logger:        loggo.GetLogger("juju.worker.stateconverter"),
 		}))),
+
+		// The backup scheduler takes the backups of the controller
+		// configured in the controller config. It only runs on the
+		// primary controller, so that each backup is only taken once.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(backupscheduler.ManifoldConfig{
+			AgentName:      agentName,
+			StateName:      stateName,
+			Clock:          config.Clock,
+			Logger:         loggo.GetLogger("juju.worker.backupscheduler"),
+			NewObjectStore: backups.NewS3ObjectStore,
+			NewWorker:      backupscheduler.NewWorker,
+		}))),
 	}
 
 	return mergeManifolds(config, manifolds)
@@ -1115,6 +1160,7 @@ const (
 	machineSetupName              = "machine-setup"
 	rebootName                    = "reboot-executor"
 	loggingConfigUpdaterName      = "logging-config-updater"
+	tracerName                    = "tracer"
 	diskManagerName               = "disk-manager"
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
@@ -1151,8 +1197,10 @@ const (
 	stateConverterName            = "state-converter"
 	lxdContainerProvisioner       = "lxd-container-provisioner"
 	kvmContainerProvisioner       = "kvm-container-provisioner"
+	backupSchedulerName           = "backup-scheduler"
 
 	secretBackendRotateName = "secret-backend-rotate"
+	secretNotifierName      = "secret-notifier"
 
 	upgradeSeriesWorkerName = "upgrade-series"
 
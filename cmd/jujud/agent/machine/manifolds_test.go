@@ -65,6 +65,7 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -111,6 +112,7 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"query-logger",
 			"reboot-executor",
 			"secret-backend-rotate",
+			"secret-notifier",
 			"ssh-authkeys-updater",
 			"ssh-identity-writer",
 			"state",
@@ -120,6 +122,7 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"syslog",
 			"termination-signal-handler",
 			"tools-version-checker",
+			"tracer",
 			"upgrade-check-flag",
 			"upgrade-check-gate",
 			"upgrade-database-flag",
@@ -180,11 +183,13 @@ func (s *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"pubsub-forwarder",
 			"query-logger",
 			"secret-backend-rotate",
+			"secret-notifier",
 			"ssh-identity-writer",
 			"state",
 			"state-config-watcher",
 			"syslog",
 			"termination-signal-handler",
+			"tracer",
 			"upgrade-check-flag",
 			"upgrade-check-gate",
 			"upgrade-database-flag",
@@ -313,8 +318,10 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 
 	// Explicitly guarded by ifPrimaryController.
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"secret-backend-rotate",
+		"secret-notifier",
 	)
 
 	// Guarded by ifDatabaseUpgradeComplete,
@@ -499,6 +506,22 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
@@ -936,6 +959,22 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"secret-notifier": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"ssh-authkeys-updater": {
 		"agent",
 		"api-caller",
@@ -1007,6 +1046,18 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"tracer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"upgrade-check-flag": {"upgrade-check-gate"},
 
 	"upgrade-check-gate": {},
@@ -1401,6 +1452,22 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"secret-notifier": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"ssh-identity-writer": {
 		"agent",
 		"api-caller",
@@ -1421,6 +1488,18 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 
 	"termination-signal-handler": {},
 
+	"tracer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"upgrade-check-flag": {"upgrade-check-gate"},
 
 	"upgrade-check-gate": {},
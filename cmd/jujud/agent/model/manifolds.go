@@ -28,11 +28,14 @@ import (
 	"github.com/juju/juju/pki"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionrollout"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/caasapplicationprovisioner"
+	"github.com/juju/juju/worker/caasautoscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -65,6 +68,7 @@ import (
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/secretsdrainworker"
+	"github.com/juju/juju/worker/secretsmigration"
 	"github.com/juju/juju/worker/secretspruner"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
@@ -310,11 +314,30 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.ActionPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.action"),
 		})),
+		actionRolloutName: ifNotMigrating(actionrollout.Manifold(actionrollout.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     actionrollout.NewFacade,
+			NewWorker:     actionrollout.NewWorker,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.actionrollout"),
+			Clock:         config.Clock,
+		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.actionscheduler"),
+			Clock:         config.Clock,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Config: logforwarder.SyslogConfig,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-http",
+				Config: logforwarder.HTTPConfig,
+				OpenFn: sinks.OpenHTTP,
 			}},
 			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
@@ -337,6 +360,15 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewUserSecretsFacade: secretspruner.NewUserSecretsFacade,
 			NewWorker:            secretspruner.NewWorker,
 		})),
+		// The secretsMigrationName worker moves the model's secret revisions
+		// to another backend when asked to by `juju migrate-secret-backend`.
+		secretsMigrationName: ifNotMigrating(secretsmigration.Manifold(secretsmigration.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     secretsmigration.NewFacade,
+			NewWorker:     secretsmigration.NewWorker,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.secretsmigration"),
+		})),
+
 		// The userSecretsDrainWorker is the worker that drains the user secrets from the inactive backend to the current active backend.
 		userSecretsDrainWorker: ifNotMigrating(secretsdrainworker.Manifold(secretsdrainworker.ManifoldConfig{
 			APICallerName:         apiCallerName,
@@ -581,6 +613,17 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		)),
 
+		caasAutoscalerName: ifNotMigrating(caasautoscaler.Manifold(
+			caasautoscaler.ManifoldConfig{
+				APICallerName: apiCallerName,
+				BrokerName:    caasBrokerTrackerName,
+				NewFacade:     caasautoscaler.NewFacade,
+				NewWorker:     caasautoscaler.NewWorker,
+				Logger:        config.LoggingContext.GetLogger("juju.worker.caasautoscaler"),
+				Clock:         config.Clock,
+			},
+		)),
+
 		caasUnitProvisionerName: ifNotMigrating(caasunitprovisioner.Manifold(
 			caasunitprovisioner.ManifoldConfig{
 				APICallerName: apiCallerName,
@@ -731,6 +774,8 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionRolloutName        = "action-rollout"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
@@ -743,12 +788,14 @@ const (
 	caasmodelconfigmanagerName     = "caas-model-config-manager"
 	caasOperatorProvisionerName    = "caas-operator-provisioner"
 	caasApplicationProvisionerName = "caas-application-provisioner"
+	caasAutoscalerName             = "caas-autoscaler"
 	caasUnitProvisionerName        = "caas-unit-provisioner"
 	caasStorageProvisionerName     = "caas-storage-provisioner"
 	caasBrokerTrackerName          = "caas-broker-tracker"
 
 	secretsPrunerName      = "secrets-pruner"
 	userSecretsDrainWorker = "user-secrets-drain-worker"
+	secretsMigrationName   = "secrets-migration"
 
 	validCredentialFlagName = "valid-credential-flag"
 )
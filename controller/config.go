@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/core/ratelimit"
 	"github.com/juju/juju/pki"
 )
 
@@ -26,6 +28,15 @@ const (
 	MongoProfLow = "low"
 	// MongoProfDefault represents the mongo memory profile shipped by default.
 	MongoProfDefault = "default"
+
+	// AuditLogSinkFile writes audit records to a rotating log file on
+	// each controller machine.
+	AuditLogSinkFile = "file"
+	// AuditLogSinkSyslog forwards audit records to a remote syslog host.
+	AuditLogSinkSyslog = "syslog"
+	// AuditLogSinkWebhook posts batches of audit records to an HTTP
+	// endpoint.
+	AuditLogSinkWebhook = "webhook"
 )
 
 // docs:controller-config-keys
@@ -92,6 +103,43 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSink determines where audit records are written. Valid
+	// values are "file" (the default), "syslog" and "webhook".
+	AuditLogSink = "audit-log-sink"
+
+	// AuditLogSyslogHost is the host-port of the syslog host that
+	// audit records are forwarded to when using the syslog sink.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the CA certificate (x.509, PEM-encoded)
+	// used to validate the syslog host's certificate.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate (x.509,
+	// PEM-encoded) used when connecting to the syslog host.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client private key (PEM-encoded)
+	// used when connecting to the syslog host.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// AuditLogWebhookURL is the URL that batches of audit records are
+	// posted to when using the webhook sink.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogWebhookBatchSize is the maximum number of audit records
+	// posted to the webhook in a single request.
+	AuditLogWebhookBatchSize = "audit-log-webhook-batch-size"
+
+	// AuditLogWebhookFlushInterval is the maximum time that audit
+	// records are held before being posted to the webhook.
+	AuditLogWebhookFlushInterval = "audit-log-webhook-flush-interval"
+
+	// AuditLogWebhookSpoolSize is the maximum size of the on-disk spool
+	// that holds audit records which couldn't be delivered to the
+	// webhook, eg "100M".
+	AuditLogWebhookSpoolSize = "audit-log-webhook-spool-size"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -266,6 +314,69 @@ const (
 	// is enabled). The lower the threshold, the more queries will be output. A
 	// value of 0 means all queries will be output.
 	QueryTracingThreshold = "query-tracing-threshold"
+
+	// OpenTelemetryEnabled returns whether OpenTelemetry tracing is
+	// enabled. If so, spans are exported to OpenTelemetryEndpoint.
+	OpenTelemetryEnabled = "open-telemetry-enabled"
+
+	// OpenTelemetryEndpoint returns the endpoint of the OTLP/HTTP
+	// collector that spans are exported to, as host:port.
+	OpenTelemetryEndpoint = "open-telemetry-endpoint"
+
+	// OpenTelemetryInsecure returns whether spans are exported without
+	// TLS.
+	OpenTelemetryInsecure = "open-telemetry-insecure"
+
+	// OpenTelemetrySampleRatio returns the fraction, between 0 and 1, of
+	// traces which are sampled. Spans whose parent is sampled are always
+	// sampled.
+	OpenTelemetrySampleRatio = "open-telemetry-sample-ratio"
+
+	// APIRateLimitRules is a list of token bucket limits applied to
+	// facade method calls made by users and agents, other than
+	// controller agents, in the form
+	// "<scope>:<Facade>.<Method>=<count>/<period>". See
+	// core/ratelimit.Rule for details.
+	APIRateLimitRules = "api-ratelimit-rules"
+
+	// BackupInterval is how often the controller takes a scheduled full
+	// backup. It is a duration, or one of "@hourly", "@daily" or
+	// "@weekly". Scheduled backups are disabled when it is empty.
+	BackupInterval = "backup-interval"
+
+	// BackupIncrementalInterval is how often the controller takes an
+	// incremental backup, holding the database changes since the
+	// previous backup, between scheduled full backups. Incremental
+	// backups are disabled when it is zero.
+	BackupIncrementalInterval = "backup-incremental-interval"
+
+	// BackupRetentionCount is the number of scheduled full backups
+	// kept, along with their incremental backups.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupS3Endpoint is the URL of an S3 compatible object store that
+	// scheduled backups are uploaded to. Backups are kept on the
+	// controller machine when it is empty.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Bucket is the bucket that scheduled backups are uploaded
+	// to, when an S3 endpoint is configured.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// SecretNotifyWebhookURL is the URL that secret expiry and rotation
+	// events, for secrets in all models, are posted to. Notifications
+	// are disabled when it is empty.
+	SecretNotifyWebhookURL = "secret-notify-webhook-url"
+
+	// SecretNotifyExpiryWarning is how long before a secret revision
+	// expires that an expiry-approaching event is posted to the secret
+	// notify webhook.
+	SecretNotifyExpiryWarning = "secret-notify-expiry-warning"
+
+	// CharmCacheSize is the maximum size of the cache of downloaded
+	// charm archives shared by all models on a controller, eg "2G".
+	// A size of 0 disables the cache.
+	CharmCacheSize = "charm-cache-size"
 )
 
 // Attribute Defaults
@@ -302,6 +413,21 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogSink is the default destination for audit records.
+	DefaultAuditLogSink = AuditLogSinkFile
+
+	// DefaultAuditLogWebhookBatchSize is the default number of audit
+	// records posted to the webhook in a single request.
+	DefaultAuditLogWebhookBatchSize = 100
+
+	// DefaultAuditLogWebhookFlushInterval is the default maximum time
+	// that audit records are held before being posted to the webhook.
+	DefaultAuditLogWebhookFlushInterval = 5 * time.Second
+
+	// DefaultAuditLogWebhookSpoolSizeMB is the default maximum size in
+	// MB of the webhook's on-disk spool.
+	DefaultAuditLogWebhookSpoolSizeMB = 100
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -390,6 +516,35 @@ const (
 	// for query tracing. If a query takes longer than this to complete
 	// it will be logged if query tracing is enabled.
 	DefaultQueryTracingThreshold = time.Second
+
+	// DefaultOpenTelemetryEnabled is the default value for if OpenTelemetry
+	// tracing is enabled.
+	DefaultOpenTelemetryEnabled = false
+
+	// DefaultOpenTelemetryInsecure is the default value for if spans are
+	// exported without TLS.
+	DefaultOpenTelemetryInsecure = false
+
+	// DefaultOpenTelemetrySampleRatio is the default fraction of traces
+	// which are sampled.
+	DefaultOpenTelemetrySampleRatio = 0.1
+
+	// DefaultBackupRetentionCount is the default number of scheduled
+	// full backups kept.
+	DefaultBackupRetentionCount = 7
+
+	// DefaultBackupS3Bucket is the default bucket that scheduled
+	// backups are uploaded to.
+	DefaultBackupS3Bucket = "juju-backups"
+
+	// DefaultSecretNotifyExpiryWarning is the default time before a
+	// secret revision expires that an expiry-approaching event is
+	// posted.
+	DefaultSecretNotifyExpiryWarning = 24 * time.Hour
+
+	// DefaultCharmCacheSizeMB is the default size in MB of the cache
+	// of downloaded charm archives.
+	DefaultCharmCacheSizeMB = 2048
 )
 
 var (
@@ -399,10 +554,16 @@ var (
 		AllowModelAccessKey,
 		AgentRateLimitMax,
 		AgentRateLimitRate,
+		APIRateLimitRules,
 		APIPort,
 		APIPortOpenDelay,
 		AutocertDNSNameKey,
 		AutocertURLKey,
+		BackupIncrementalInterval,
+		BackupInterval,
+		BackupRetentionCount,
+		BackupS3Bucket,
+		BackupS3Endpoint,
 		CACertKey,
 		ControllerAPIPort,
 		ControllerName,
@@ -433,6 +594,15 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSink,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
+		AuditLogWebhookSpoolSize,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -444,6 +614,13 @@ var (
 		ControllerResourceDownloadLimit,
 		QueryTracingEnabled,
 		QueryTracingThreshold,
+		OpenTelemetryEnabled,
+		OpenTelemetryEndpoint,
+		OpenTelemetryInsecure,
+		OpenTelemetrySampleRatio,
+		SecretNotifyExpiryWarning,
+		SecretNotifyWebhookURL,
+		CharmCacheSize,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -466,6 +643,7 @@ var (
 		AgentLogfileMaxSize,
 		AgentRateLimitMax,
 		AgentRateLimitRate,
+		APIRateLimitRules,
 		APIPortOpenDelay,
 		ApplicationResourceDownloadLimit,
 		AuditingEnabled,
@@ -473,6 +651,20 @@ var (
 		AuditLogExcludeMethods,
 		AuditLogMaxBackups,
 		AuditLogMaxSize,
+		AuditLogSink,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogSyslogHost,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
+		AuditLogWebhookSpoolSize,
+		AuditLogWebhookURL,
+		BackupIncrementalInterval,
+		BackupInterval,
+		BackupRetentionCount,
+		BackupS3Bucket,
+		BackupS3Endpoint,
 		CAASImageRepo,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
@@ -492,11 +684,18 @@ var (
 		ModelLogfileMaxSize,
 		ModelLogsSize,
 		MongoMemoryProfile,
+		OpenTelemetryEnabled,
+		OpenTelemetryEndpoint,
+		OpenTelemetryInsecure,
+		OpenTelemetrySampleRatio,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
 		PublicDNSAddress,
 		QueryTracingEnabled,
 		QueryTracingThreshold,
+		SecretNotifyExpiryWarning,
+		SecretNotifyWebhookURL,
+		CharmCacheSize,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -762,6 +961,62 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSink returns where audit records should be written.
+func (c Config) AuditLogSink() string {
+	if v := c.asString(AuditLogSink); v != "" {
+		return v
+	}
+	return DefaultAuditLogSink
+}
+
+// AuditLogSyslogHost returns the host-port of the syslog host used
+// by the syslog audit log sink.
+func (c Config) AuditLogSyslogHost() string {
+	return c.asString(AuditLogSyslogHost)
+}
+
+// AuditLogSyslogCACert returns the CA certificate used to validate
+// the syslog host used by the syslog audit log sink.
+func (c Config) AuditLogSyslogCACert() string {
+	return c.asString(AuditLogSyslogCACert)
+}
+
+// AuditLogSyslogClientCert returns the client certificate used when
+// connecting to the syslog host used by the syslog audit log sink.
+func (c Config) AuditLogSyslogClientCert() string {
+	return c.asString(AuditLogSyslogClientCert)
+}
+
+// AuditLogSyslogClientKey returns the client private key used when
+// connecting to the syslog host used by the syslog audit log sink.
+func (c Config) AuditLogSyslogClientKey() string {
+	return c.asString(AuditLogSyslogClientKey)
+}
+
+// AuditLogWebhookURL returns the URL used by the webhook audit log
+// sink.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogWebhookBatchSize returns the maximum number of audit records
+// posted to the webhook in a single request.
+func (c Config) AuditLogWebhookBatchSize() int {
+	return c.intOrDefault(AuditLogWebhookBatchSize, DefaultAuditLogWebhookBatchSize)
+}
+
+// AuditLogWebhookFlushInterval returns the maximum time that audit
+// records are held before being posted to the webhook.
+func (c Config) AuditLogWebhookFlushInterval() time.Duration {
+	return c.durationOrDefault(AuditLogWebhookFlushInterval, DefaultAuditLogWebhookFlushInterval)
+}
+
+// AuditLogWebhookSpoolSizeMB returns the maximum size in MB of the
+// webhook's on-disk spool.
+func (c Config) AuditLogWebhookSpoolSizeMB() int {
+	return c.sizeMBOrDefault(AuditLogWebhookSpoolSize, DefaultAuditLogWebhookSpoolSizeMB)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -995,6 +1250,124 @@ func (c Config) QueryTracingThreshold() time.Duration {
 	return c.durationOrDefault(QueryTracingThreshold, DefaultQueryTracingThreshold)
 }
 
+// OpenTelemetryEnabled returns whether OpenTelemetry tracing is enabled.
+func (c Config) OpenTelemetryEnabled() bool {
+	return c.boolOrDefault(OpenTelemetryEnabled, DefaultOpenTelemetryEnabled)
+}
+
+// OpenTelemetryEndpoint returns the endpoint of the OTLP/HTTP collector
+// that spans are exported to.
+func (c Config) OpenTelemetryEndpoint() string {
+	return c.asString(OpenTelemetryEndpoint)
+}
+
+// OpenTelemetryInsecure returns whether spans are exported without TLS.
+func (c Config) OpenTelemetryInsecure() bool {
+	return c.boolOrDefault(OpenTelemetryInsecure, DefaultOpenTelemetryInsecure)
+}
+
+// OpenTelemetrySampleRatio returns the fraction of traces which are
+// sampled.
+func (c Config) OpenTelemetrySampleRatio() float64 {
+	if v, ok := c[OpenTelemetrySampleRatio].(float64); ok {
+		return v
+	}
+	return DefaultOpenTelemetrySampleRatio
+}
+
+// APIRateLimitRules returns the rate limits applied to facade method
+// calls made by users and non-controller agents.
+func (c Config) APIRateLimitRules() []ratelimit.Rule {
+	var rules []ratelimit.Rule
+	value, _ := c[APIRateLimitRules].([]interface{})
+	for _, item := range value {
+		// Invalid rules are rejected by Validate.
+		if rule, err := ratelimit.ParseRule(item.(string)); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// BackupInterval returns how often scheduled full backups are taken,
+// or zero if scheduled backups are disabled.
+func (c Config) BackupInterval() time.Duration {
+	// Invalid intervals are rejected by Validate.
+	d, _ := parseBackupInterval(c.asString(BackupInterval))
+	return d
+}
+
+// BackupIncrementalInterval returns how often incremental backups are
+// taken between scheduled full backups, or zero if they are disabled.
+func (c Config) BackupIncrementalInterval() time.Duration {
+	return c.durationOrDefault(BackupIncrementalInterval, 0)
+}
+
+// BackupRetentionCount returns the number of scheduled full backups
+// kept.
+func (c Config) BackupRetentionCount() int {
+	return c.intOrDefault(BackupRetentionCount, DefaultBackupRetentionCount)
+}
+
+// BackupS3Endpoint returns the URL of the S3 compatible object store
+// that scheduled backups are uploaded to, if any.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Bucket returns the bucket that scheduled backups are uploaded
+// to.
+func (c Config) BackupS3Bucket() string {
+	if v := c.asString(BackupS3Bucket); v != "" {
+		return v
+	}
+	return DefaultBackupS3Bucket
+}
+
+// SecretNotifyWebhookURL returns the URL that secret expiry and
+// rotation events are posted to, if any.
+func (c Config) SecretNotifyWebhookURL() string {
+	return c.asString(SecretNotifyWebhookURL)
+}
+
+// SecretNotifyExpiryWarning returns how long before a secret revision
+// expires that an expiry-approaching event is posted.
+func (c Config) SecretNotifyExpiryWarning() time.Duration {
+	return c.durationOrDefault(SecretNotifyExpiryWarning, DefaultSecretNotifyExpiryWarning)
+}
+
+// CharmCacheSizeMB is the maximum size of the cache of downloaded charm
+// archives shared by all models on the controller. Zero means that the
+// cache is disabled.
+func (c Config) CharmCacheSizeMB() int {
+	return c.sizeMBOrDefault(CharmCacheSize, DefaultCharmCacheSizeMB)
+}
+
+// backupIntervalAliases are the cron-like names accepted as backup
+// intervals.
+var backupIntervalAliases = map[string]time.Duration{
+	"@hourly": time.Hour,
+	"@daily":  24 * time.Hour,
+	"@weekly": 7 * 24 * time.Hour,
+}
+
+func parseBackupInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if d, ok := backupIntervalAliases[value]; ok {
+		return d, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NotValidf("%s %q", BackupInterval, value)
+	}
+	if d < time.Minute {
+		return 0, errors.NotValidf("%s %q less than 1m", BackupInterval, value)
+	}
+	return d, nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -1175,6 +1548,54 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[APIRateLimitRules].([]interface{}); ok {
+		for _, rule := range v {
+			if _, err := ratelimit.ParseRule(rule.(string)); err != nil {
+				return errors.Annotatef(err, "invalid %s in configuration", APIRateLimitRules)
+			}
+		}
+	}
+
+	if v, ok := c[BackupInterval].(string); ok {
+		if _, err := parseBackupInterval(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[BackupIncrementalInterval].(time.Duration); ok && v < 0 {
+		return errors.NotValidf("negative %s", BackupIncrementalInterval)
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 1 {
+		return errors.NotValidf("%s %d less than 1", BackupRetentionCount, v)
+	}
+
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.NotValidf("%s %q", BackupS3Endpoint, v)
+		}
+	}
+
+	if v, ok := c[SecretNotifyWebhookURL].(string); ok && v != "" {
+		if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.NotValidf("%s %q", SecretNotifyWebhookURL, v)
+		}
+	}
+
+	if v, ok := c[SecretNotifyExpiryWarning].(time.Duration); ok && v <= 0 {
+		return errors.NotValidf("%s %v", SecretNotifyExpiryWarning, v)
+	}
+
+	if v, ok := c[CharmCacheSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", CharmCacheSize)
+		}
+	}
+
+	if err := c.validateAuditLogSink(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -1234,6 +1655,67 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateOpenTelemetry(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (c Config) validateAuditLogSink() error {
+	switch sink := c.AuditLogSink(); sink {
+	case AuditLogSinkFile:
+	case AuditLogSinkSyslog:
+		if c.AuditLogSyslogHost() == "" {
+			return errors.Errorf("%s must be set when using the %q audit log sink", AuditLogSyslogHost, sink)
+		}
+	case AuditLogSinkWebhook:
+		v := c.AuditLogWebhookURL()
+		if v == "" {
+			return errors.Errorf("%s must be set when using the %q audit log sink", AuditLogWebhookURL, sink)
+		}
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", AuditLogWebhookURL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("%s scheme %q", AuditLogWebhookURL, u.Scheme)
+		}
+	default:
+		return errors.Errorf("%s: expected one of %q, %q or %q got %q",
+			AuditLogSink, AuditLogSinkFile, AuditLogSinkSyslog, AuditLogSinkWebhook, sink)
+	}
+
+	if v, ok := c[AuditLogWebhookBatchSize].(int); ok {
+		if v < 1 {
+			return errors.NotValidf("%s less than 1", AuditLogWebhookBatchSize)
+		}
+	}
+	if v, ok := c[AuditLogWebhookFlushInterval].(time.Duration); ok {
+		if v <= 0 {
+			return errors.Errorf("%s value %q must be a positive duration", AuditLogWebhookFlushInterval, v)
+		}
+	}
+	if v, ok := c[AuditLogWebhookSpoolSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", AuditLogWebhookSpoolSize)
+		}
+	}
+	return nil
+}
+
+func (c Config) validateOpenTelemetry() error {
+	if c.OpenTelemetryEnabled() && c.OpenTelemetryEndpoint() == "" {
+		return errors.Errorf("%s must be set when %s is true", OpenTelemetryEndpoint, OpenTelemetryEnabled)
+	}
+	if v := c.OpenTelemetryEndpoint(); v != "" {
+		if _, _, err := net.SplitHostPort(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", OpenTelemetryEndpoint)
+		}
+	}
+	if v := c.OpenTelemetrySampleRatio(); v < 0 || v > 1 {
+		return errors.Errorf("%s value %v must be between 0 and 1", OpenTelemetrySampleRatio, v)
+	}
 	return nil
 }
 
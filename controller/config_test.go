@@ -18,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/ratelimit"
 	"github.com/juju/juju/docker"
 	"github.com/juju/juju/docker/registry"
 	"github.com/juju/juju/docker/registry/mocks"
@@ -166,6 +167,37 @@ var newConfigTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log sink",
+	config: controller.Config{
+		controller.AuditLogSink: "carrier-pigeon",
+	},
+	expectError: `audit-log-sink: expected one of "file", "syslog" or "webhook" got "carrier-pigeon"`,
+}, {
+	about: "syslog audit log sink without host",
+	config: controller.Config{
+		controller.AuditLogSink: "syslog",
+	},
+	expectError: `audit-log-syslog-host must be set when using the "syslog" audit log sink`,
+}, {
+	about: "webhook audit log sink without url",
+	config: controller.Config{
+		controller.AuditLogSink: "webhook",
+	},
+	expectError: `audit-log-webhook-url must be set when using the "webhook" audit log sink`,
+}, {
+	about: "webhook audit log sink with invalid url scheme",
+	config: controller.Config{
+		controller.AuditLogSink:       "webhook",
+		controller.AuditLogWebhookURL: "ftp://siem.example.com",
+	},
+	expectError: `audit-log-webhook-url scheme "ftp" not valid`,
+}, {
+	about: "zero audit log webhook batch size",
+	config: controller.Config{
+		controller.AuditLogWebhookBatchSize: 0,
+	},
+	expectError: `audit-log-webhook-batch-size less than 1 not valid`,
 }, {
 	about: "invalid model log max size",
 	config: controller.Config{
@@ -373,6 +405,78 @@ var newConfigTests = []struct {
 		controller.QueryTracingThreshold: "-1s",
 	},
 	expectError: `query-tracing-threshold value "-1s" must be a positive duration`,
+}, {
+	about: "open telemetry enabled without endpoint",
+	config: controller.Config{
+		controller.OpenTelemetryEnabled: true,
+	},
+	expectError: `open-telemetry-endpoint must be set when open-telemetry-enabled is true`,
+}, {
+	about: "invalid open telemetry endpoint",
+	config: controller.Config{
+		controller.OpenTelemetryEndpoint: "collector.example.com",
+	},
+	expectError: `invalid open-telemetry-endpoint in configuration: .*`,
+}, {
+	about: "open telemetry sample ratio out of range",
+	config: controller.Config{
+		controller.OpenTelemetrySampleRatio: 1.5,
+	},
+	expectError: `open-telemetry-sample-ratio value 1.5 must be between 0 and 1`,
+}, {
+	about: "invalid api rate limit rule",
+	config: controller.Config{
+		controller.APIRateLimitRules: []interface{}{"user:Client.FullStatus=10/1m", "Client.Status=1/s"},
+	},
+	expectError: `invalid api-ratelimit-rules in configuration: rate limit rule "Client.Status=1/s": missing scope not valid`,
+}, {
+	about: "invalid backup interval",
+	config: controller.Config{
+		controller.BackupInterval: "@monthly",
+	},
+	expectError: `backup-interval "@monthly" not valid`,
+}, {
+	about: "backup interval too short",
+	config: controller.Config{
+		controller.BackupInterval: "10s",
+	},
+	expectError: `backup-interval "10s" less than 1m not valid`,
+}, {
+	about: "negative incremental backup interval",
+	config: controller.Config{
+		controller.BackupIncrementalInterval: "-1h",
+	},
+	expectError: `negative backup-incremental-interval not valid`,
+}, {
+	about: "invalid backup retention count",
+	config: controller.Config{
+		controller.BackupRetentionCount: 0,
+	},
+	expectError: `backup-retention-count 0 less than 1 not valid`,
+}, {
+	about: "invalid backup s3 endpoint",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "minio:9000",
+	},
+	expectError: `backup-s3-endpoint "minio:9000" not valid`,
+}, {
+	about: "invalid secret notify webhook url",
+	config: controller.Config{
+		controller.SecretNotifyWebhookURL: "/notify",
+	},
+	expectError: `secret-notify-webhook-url "/notify" not valid`,
+}, {
+	about: "invalid secret notify expiry warning",
+	config: controller.Config{
+		controller.SecretNotifyExpiryWarning: "0s",
+	},
+	expectError: `secret-notify-expiry-warning 0s not valid`,
+}, {
+	about: "invalid charm cache size",
+	config: controller.Config{
+		controller.CharmCacheSize: "abcd",
+	},
+	expectError: `invalid charm-cache-size in configuration: expected a non-negative number, got "abcd"`,
 }}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -557,6 +661,164 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	))
 }
 
+func (s *ConfigSuite) TestAuditLogSinkDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSink(), gc.Equals, controller.AuditLogSinkFile)
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 100)
+	c.Assert(cfg.AuditLogWebhookFlushInterval(), gc.Equals, 5*time.Second)
+	c.Assert(cfg.AuditLogWebhookSpoolSizeMB(), gc.Equals, 100)
+}
+
+func (s *ConfigSuite) TestAuditLogSinkValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-sink":                   "webhook",
+			"audit-log-webhook-url":            "https://siem.example.com/ingest",
+			"audit-log-webhook-batch-size":     50,
+			"audit-log-webhook-flush-interval": "30s",
+			"audit-log-webhook-spool-size":     "1G",
+			"audit-log-syslog-host":            "syslog.example.com:6514",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSink(), gc.Equals, controller.AuditLogSinkWebhook)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://siem.example.com/ingest")
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 50)
+	c.Assert(cfg.AuditLogWebhookFlushInterval(), gc.Equals, 30*time.Second)
+	c.Assert(cfg.AuditLogWebhookSpoolSizeMB(), gc.Equals, 1024)
+	c.Assert(cfg.AuditLogSyslogHost(), gc.Equals, "syslog.example.com:6514")
+}
+
+func (s *ConfigSuite) TestOpenTelemetryDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OpenTelemetryEnabled(), jc.IsFalse)
+	c.Assert(cfg.OpenTelemetryEndpoint(), gc.Equals, "")
+	c.Assert(cfg.OpenTelemetryInsecure(), jc.IsFalse)
+	c.Assert(cfg.OpenTelemetrySampleRatio(), gc.Equals, 0.1)
+}
+
+func (s *ConfigSuite) TestOpenTelemetryValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"open-telemetry-enabled":      true,
+			"open-telemetry-endpoint":     "collector.example.com:4318",
+			"open-telemetry-insecure":     true,
+			"open-telemetry-sample-ratio": 1,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OpenTelemetryEnabled(), jc.IsTrue)
+	c.Assert(cfg.OpenTelemetryEndpoint(), gc.Equals, "collector.example.com:4318")
+	c.Assert(cfg.OpenTelemetryInsecure(), jc.IsTrue)
+	c.Assert(cfg.OpenTelemetrySampleRatio(), gc.Equals, 1.0)
+}
+
+func (s *ConfigSuite) TestAPIRateLimitRules(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-ratelimit-rules": []interface{}{"user:Client.FullStatus=10/1m", "model:Client.*=100/s"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIRateLimitRules(), jc.DeepEquals, []ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "Client", Method: "FullStatus", Count: 10, Period: time.Minute,
+	}, {
+		Scope: ratelimit.ScopeModel, Facade: "Client", Method: "*", Count: 100, Period: time.Second,
+	}})
+}
+
+func (s *ConfigSuite) TestAPIRateLimitRulesDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIRateLimitRules(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestBackupConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-interval":             "@daily",
+			"backup-incremental-interval": "1h",
+			"backup-retention-count":      3,
+			"backup-s3-endpoint":          "https://s3.example.com",
+			"backup-s3-bucket":            "controller-backups",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupInterval(), gc.Equals, 24*time.Hour)
+	c.Assert(cfg.BackupIncrementalInterval(), gc.Equals, time.Hour)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 3)
+	c.Assert(cfg.BackupS3Endpoint(), gc.Equals, "https://s3.example.com")
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, "controller-backups")
+
+	cfg[controller.BackupInterval] = "6h"
+	c.Assert(cfg.BackupInterval(), gc.Equals, 6*time.Hour)
+}
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupIncrementalInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Assert(cfg.BackupS3Endpoint(), gc.Equals, "")
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, controller.DefaultBackupS3Bucket)
+}
+
+func (s *ConfigSuite) TestSecretNotifyConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"secret-notify-webhook-url":    "https://example.com/secrets",
+			"secret-notify-expiry-warning": "2h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretNotifyWebhookURL(), gc.Equals, "https://example.com/secrets")
+	c.Assert(cfg.SecretNotifyExpiryWarning(), gc.Equals, 2*time.Hour)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretNotifyWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.SecretNotifyExpiryWarning(), gc.Equals, controller.DefaultSecretNotifyExpiryWarning)
+}
+
+func (s *ConfigSuite) TestCharmCacheSize(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CharmCacheSizeMB(), gc.Equals, controller.DefaultCharmCacheSizeMB)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"charm-cache-size": "10G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CharmCacheSizeMB(), gc.Equals, 10240)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"charm-cache-size": "0",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CharmCacheSizeMB(), gc.Equals, 0)
+}
+
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
 	_, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
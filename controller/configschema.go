@@ -19,6 +19,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  schema.String(),
 	AuditLogMaxBackups:               schema.ForceInt(),
 	AuditLogExcludeMethods:           schema.List(schema.String()),
+	AuditLogSink:                     schema.String(),
+	AuditLogSyslogHost:               schema.String(),
+	AuditLogSyslogCACert:             schema.String(),
+	AuditLogSyslogClientCert:         schema.String(),
+	AuditLogSyslogClientKey:          schema.String(),
+	AuditLogWebhookURL:               schema.String(),
+	AuditLogWebhookBatchSize:         schema.ForceInt(),
+	AuditLogWebhookFlushInterval:     schema.TimeDuration(),
+	AuditLogWebhookSpoolSize:         schema.String(),
 	APIPort:                          schema.ForceInt(),
 	APIPortOpenDelay:                 schema.TimeDuration(),
 	ControllerAPIPort:                schema.ForceInt(),
@@ -58,6 +67,19 @@ var configChecker = schema.FieldMap(schema.Fields{
 	ControllerResourceDownloadLimit:  schema.ForceInt(),
 	QueryTracingEnabled:              schema.Bool(),
 	QueryTracingThreshold:            schema.TimeDuration(),
+	OpenTelemetryEnabled:             schema.Bool(),
+	OpenTelemetryEndpoint:            schema.String(),
+	OpenTelemetryInsecure:            schema.Bool(),
+	OpenTelemetrySampleRatio:         schema.Float(),
+	APIRateLimitRules:                schema.List(schema.String()),
+	BackupInterval:                   schema.String(),
+	BackupIncrementalInterval:        schema.TimeDuration(),
+	BackupRetentionCount:             schema.ForceInt(),
+	BackupS3Endpoint:                 schema.String(),
+	BackupS3Bucket:                   schema.String(),
+	SecretNotifyWebhookURL:           schema.String(),
+	SecretNotifyExpiryWarning:        schema.TimeDuration(),
+	CharmCacheSize:                   schema.String(),
 }, schema.Defaults{
 	AgentRateLimitMax:                schema.Omit,
 	AgentRateLimitRate:               schema.Omit,
@@ -70,6 +92,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:               DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:           DefaultAuditLogExcludeMethods,
+	AuditLogSink:                     schema.Omit,
+	AuditLogSyslogHost:               schema.Omit,
+	AuditLogSyslogCACert:             schema.Omit,
+	AuditLogSyslogClientCert:         schema.Omit,
+	AuditLogSyslogClientKey:          schema.Omit,
+	AuditLogWebhookURL:               schema.Omit,
+	AuditLogWebhookBatchSize:         schema.Omit,
+	AuditLogWebhookFlushInterval:     schema.Omit,
+	AuditLogWebhookSpoolSize:         schema.Omit,
 	StatePort:                        DefaultStatePort,
 	LoginTokenRefreshURL:             schema.Omit,
 	IdentityURL:                      schema.Omit,
@@ -105,6 +136,19 @@ var configChecker = schema.FieldMap(schema.Fields{
 	ControllerResourceDownloadLimit:  schema.Omit,
 	QueryTracingEnabled:              DefaultQueryTracingEnabled,
 	QueryTracingThreshold:            DefaultQueryTracingThreshold,
+	OpenTelemetryEnabled:             schema.Omit,
+	OpenTelemetryEndpoint:            schema.Omit,
+	OpenTelemetryInsecure:            schema.Omit,
+	OpenTelemetrySampleRatio:         schema.Omit,
+	APIRateLimitRules:                schema.Omit,
+	BackupInterval:                   schema.Omit,
+	BackupIncrementalInterval:        schema.Omit,
+	BackupRetentionCount:             DefaultBackupRetentionCount,
+	BackupS3Endpoint:                 schema.Omit,
+	BackupS3Bucket:                   schema.Omit,
+	SecretNotifyWebhookURL:           schema.Omit,
+	SecretNotifyExpiryWarning:        schema.Omit,
+	CharmCacheSize:                   fmt.Sprintf("%vM", DefaultCharmCacheSizeMB),
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -146,6 +190,42 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tlist,
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogSink: {
+		Type:        environschema.Tstring,
+		Description: `Where audit records are written: "file" (the default), "syslog" or "webhook"`,
+	},
+	AuditLogSyslogHost: {
+		Type:        environschema.Tstring,
+		Description: `The host-port of the syslog host used by the "syslog" audit log sink`,
+	},
+	AuditLogSyslogCACert: {
+		Type:        environschema.Tstring,
+		Description: `The CA certificate used to validate the syslog host used by the "syslog" audit log sink`,
+	},
+	AuditLogSyslogClientCert: {
+		Type:        environschema.Tstring,
+		Description: `The client certificate used to connect to the syslog host used by the "syslog" audit log sink`,
+	},
+	AuditLogSyslogClientKey: {
+		Type:        environschema.Tstring,
+		Description: `The client private key used to connect to the syslog host used by the "syslog" audit log sink`,
+	},
+	AuditLogWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The URL that audit records are posted to by the "webhook" audit log sink`,
+	},
+	AuditLogWebhookBatchSize: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of audit records posted to the webhook in a single request`,
+	},
+	AuditLogWebhookFlushInterval: {
+		Type:        environschema.Tstring,
+		Description: `The maximum time that audit records are held before being posted to the webhook`,
+	},
+	AuditLogWebhookSpoolSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of the on-disk spool of audit records that couldn't be delivered to the webhook`,
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -302,4 +382,65 @@ Use "caas-image-repo" instead.`,
 threshold, the more queries will be output. A value of 0 means all queries 
 will be output if tracing is enabled.`,
 	},
+	OpenTelemetryEnabled: {
+		Type:        environschema.Tbool,
+		Description: `Enable exporting of OpenTelemetry traces from agents`,
+	},
+	OpenTelemetryEndpoint: {
+		Type:        environschema.Tstring,
+		Description: `The host:port of the OTLP/HTTP collector that traces are exported to`,
+	},
+	OpenTelemetryInsecure: {
+		Type:        environschema.Tbool,
+		Description: `Export OpenTelemetry traces without TLS`,
+	},
+	OpenTelemetrySampleRatio: {
+		Type:        environschema.Tstring,
+		Description: `The fraction, between 0 and 1, of traces which are sampled`,
+	},
+	APIRateLimitRules: {
+		Type: environschema.Tlist,
+		Description: `A list of rate limits applied to API calls made by users and
+non-controller agents, each of the form
+"<user|model>:<Facade>.<Method>=<count>/<period>", for example
+"user:Client.FullStatus=10/1m". User scoped limits apply to agents by tag,
+for example "unit-mysql-0". The facade or method may be "*"`,
+	},
+	BackupInterval: {
+		Type: environschema.Tstring,
+		Description: `How often the controller takes a scheduled full backup, as a duration
+or one of "@hourly", "@daily" or "@weekly". Empty disables scheduled backups`,
+	},
+	BackupIncrementalInterval: {
+		Type:        environschema.Tstring,
+		Description: `How often an incremental backup is taken between scheduled full backups. Zero disables incremental backups.
+Incremental backups hold the MongoDB oplog entries since the last backup and a copy of the
+Dqlite database; a full backup is taken instead when the oplog no longer covers the period`,
+	},
+	BackupRetentionCount: {
+		Type:        environschema.Tint,
+		Description: `The number of scheduled full backups kept, along with their incremental backups`,
+	},
+	BackupS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of an S3 compatible object store that scheduled backups are uploaded to.
+Without it backups are kept on the controller machine, which is only supported
+for controllers without HA`,
+	},
+	BackupS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket that scheduled backups are uploaded to`,
+	},
+	SecretNotifyWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The URL that secret expiry and rotation events are posted to. Empty disables the notifications`,
+	},
+	SecretNotifyExpiryWarning: {
+		Type:        environschema.Tstring,
+		Description: `How long before a secret revision expires that an expiry-approaching event is posted`,
+	},
+	CharmCacheSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of the cache of downloaded charms shared by all models on the controller (eg "2G"). A size of 0 disables the cache`,
+	},
 }
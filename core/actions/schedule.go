@@ -0,0 +1,107 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// MinScheduleInterval is the shortest interval at which a scheduled
+// action may run.
+const MinScheduleInterval = time.Minute
+
+var validScheduleName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// IsValidScheduleName returns true if name is a valid name for an
+// action schedule.
+func IsValidScheduleName(name string) bool {
+	return validScheduleName.MatchString(name)
+}
+
+// ConcurrencyPolicy determines what happens when a scheduled action is
+// due to run while the operation started by its previous run has not
+// yet finished.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow starts the new run regardless.
+	ConcurrencyAllow ConcurrencyPolicy = "allow"
+
+	// ConcurrencyForbid skips the new run.
+	ConcurrencyForbid ConcurrencyPolicy = "forbid"
+
+	// ConcurrencyReplace cancels the unfinished tasks of the
+	// previous run before starting the new one.
+	ConcurrencyReplace ConcurrencyPolicy = "replace"
+)
+
+// Validate returns an error if the policy is not known.
+func (p ConcurrencyPolicy) Validate() error {
+	switch p {
+	case ConcurrencyAllow, ConcurrencyForbid, ConcurrencyReplace:
+		return nil
+	}
+	return errors.NotValidf("concurrency policy %q", p)
+}
+
+// Schedule describes when a scheduled action runs: either when a cron
+// expression matches or at a fixed interval. Cron expressions have
+// the standard five fields, or are one of the descriptors such as
+// @daily, and are evaluated in UTC unless prefixed by CRON_TZ=<zone>.
+type Schedule struct {
+	Cron     string
+	Interval time.Duration
+}
+
+// Validate returns an error if the schedule does not have exactly one
+// of a valid cron expression or an interval of at least
+// MinScheduleInterval.
+func (s Schedule) Validate() error {
+	switch {
+	case s.Cron == "" && s.Interval == 0:
+		return errors.NotValidf("schedule without cron expression or interval")
+	case s.Cron != "" && s.Interval != 0:
+		return errors.NotValidf("schedule with both cron expression and interval")
+	case s.Cron != "":
+		if _, err := cron.ParseStandard(s.Cron); err != nil {
+			return errors.NewNotValid(err, "cron expression "+s.Cron)
+		}
+	case s.Interval < MinScheduleInterval:
+		return errors.NotValidf("interval %v shorter than %v", s.Interval, MinScheduleInterval)
+	}
+	return nil
+}
+
+// Next returns the first time after the given one at which the
+// schedule is due.
+func (s Schedule) Next(after time.Time) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if s.Interval != 0 {
+		return after.Add(s.Interval), nil
+	}
+	schedule, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	next := schedule.Next(after.UTC())
+	if next.IsZero() {
+		return time.Time{}, errors.NotFoundf("next time for cron expression %q", s.Cron)
+	}
+	return next, nil
+}
+
+// String returns the cron expression, or the interval as in
+// "every 1h0m0s".
+func (s Schedule) String() string {
+	if s.Cron != "" {
+		return s.Cron
+	}
+	return "every " + s.Interval.String()
+}
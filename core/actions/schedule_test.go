@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+var now = time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+
+func (s *scheduleSuite) TestValidate(c *gc.C) {
+	for i, t := range []struct {
+		schedule actions.Schedule
+		err      string
+	}{{
+		schedule: actions.Schedule{Cron: "0 3 * * *"},
+	}, {
+		schedule: actions.Schedule{Cron: "@daily"},
+	}, {
+		schedule: actions.Schedule{Interval: time.Hour},
+	}, {
+		schedule: actions.Schedule{},
+		err:      "schedule without cron expression or interval not valid",
+	}, {
+		schedule: actions.Schedule{Cron: "@daily", Interval: time.Hour},
+		err:      "schedule with both cron expression and interval not valid",
+	}, {
+		schedule: actions.Schedule{Cron: "0 3 * *"},
+		err:      "cron expression 0 3 \\* \\*: expected exactly 5 fields, found 4: .*",
+	}, {
+		schedule: actions.Schedule{Interval: 30 * time.Second},
+		err:      "interval 30s shorter than 1m0s not valid",
+	}} {
+		c.Logf("test %d: %+v", i, t.schedule)
+		err := t.schedule.Validate()
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *scheduleSuite) TestNextCron(c *gc.C) {
+	next, err := actions.Schedule{Cron: "0 3 * * *"}.Next(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC))
+
+	next, err = actions.Schedule{Cron: "*/15 * * * *"}.Next(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2024, 5, 1, 12, 45, 0, 0, time.UTC))
+}
+
+func (s *scheduleSuite) TestNextCronIsUTC(c *gc.C) {
+	local := now.In(time.FixedZone("UTC+2", 2*60*60))
+	next, err := actions.Schedule{Cron: "0 3 * * *"}.Next(local)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC))
+}
+
+func (s *scheduleSuite) TestNextInterval(c *gc.C) {
+	next, err := actions.Schedule{Interval: 90 * time.Minute}.Next(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, now.Add(90*time.Minute))
+}
+
+func (s *scheduleSuite) TestNextInvalid(c *gc.C) {
+	_, err := actions.Schedule{}.Next(now)
+	c.Assert(err, gc.ErrorMatches, "schedule without cron expression or interval not valid")
+}
+
+func (s *scheduleSuite) TestString(c *gc.C) {
+	c.Assert(actions.Schedule{Cron: "@daily"}.String(), gc.Equals, "@daily")
+	c.Assert(actions.Schedule{Interval: time.Hour}.String(), gc.Equals, "every 1h0m0s")
+}
+
+func (s *scheduleSuite) TestConcurrencyPolicyValidate(c *gc.C) {
+	for _, p := range []actions.ConcurrencyPolicy{
+		actions.ConcurrencyAllow, actions.ConcurrencyForbid, actions.ConcurrencyReplace,
+	} {
+		c.Check(p.Validate(), jc.ErrorIsNil)
+	}
+	c.Check(actions.ConcurrencyPolicy("queue").Validate(), gc.ErrorMatches, `concurrency policy "queue" not valid`)
+}
+
+func (s *scheduleSuite) TestIsValidScheduleName(c *gc.C) {
+	c.Check(actions.IsValidScheduleName("nightly-backup"), jc.IsTrue)
+	c.Check(actions.IsValidScheduleName("backup2"), jc.IsTrue)
+	c.Check(actions.IsValidScheduleName("Nightly"), jc.IsFalse)
+	c.Check(actions.IsValidScheduleName("-backup"), jc.IsFalse)
+	c.Check(actions.IsValidScheduleName("backup-"), jc.IsFalse)
+	c.Check(actions.IsValidScheduleName(""), jc.IsFalse)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"math"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// AutoscaleTolerance is how far, as a fraction of the target, resource
+// usage may stray from the target before an application is rescaled.
+// It stops applications being rescaled over small changes in usage.
+const AutoscaleTolerance = 0.1
+
+// AutoscalePolicy defines how an application is scaled automatically
+// to keep the resource usage of its units near a target.
+type AutoscalePolicy struct {
+	// MinUnits is the fewest units the application is scaled to.
+	MinUnits int
+
+	// MaxUnits is the most units the application is scaled to.
+	MaxUnits int
+
+	// TargetCPU is the target average CPU usage of the units, as a
+	// percentage of the CPU they request. Zero means CPU usage isn't
+	// used for scaling.
+	TargetCPU int
+
+	// TargetMemory is the target average memory usage of the units, as
+	// a percentage of the memory they request. Zero means memory usage
+	// isn't used for scaling.
+	TargetMemory int
+
+	// Cooldown is the least time between changes of scale.
+	Cooldown time.Duration
+}
+
+// Validate returns an error if the policy isn't valid.
+func (p AutoscalePolicy) Validate() error {
+	if p.MinUnits < 1 {
+		return errors.NotValidf("min units %d less than 1", p.MinUnits)
+	}
+	if p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("max units %d less than min units %d", p.MaxUnits, p.MinUnits)
+	}
+	if p.TargetCPU < 0 || p.TargetMemory < 0 {
+		return errors.NotValidf("negative target usage")
+	}
+	if p.TargetCPU == 0 && p.TargetMemory == 0 {
+		return errors.NotValidf("policy without a cpu or memory target")
+	}
+	if p.Cooldown < 0 {
+		return errors.NotValidf("negative cooldown %v", p.Cooldown)
+	}
+	return nil
+}
+
+// Utilization holds the average resource usage of an application's
+// units.
+type Utilization struct {
+	// Units is the number of units the usage was measured from.
+	Units int
+
+	// CPU is the average CPU usage of the units, as a percentage of the
+	// CPU they request, or nil if it isn't known.
+	CPU *float64
+
+	// Memory is the average memory usage of the units, as a percentage
+	// of the memory they request, or nil if it isn't known.
+	Memory *float64
+}
+
+// DesiredScale returns the scale which would bring the average resource
+// usage of the units to the policy's targets, given the current scale.
+// As with the Kubernetes HorizontalPodAutoscaler, the scale needed for
+// each target is calculated, and the largest is used. The scale is
+// always within the policy's bounds.
+func (p AutoscalePolicy) DesiredScale(current int, usage Utilization) int {
+	desired := current
+	if current > 0 && usage.Units > 0 {
+		desired = 0
+		if s, ok := scaleForTarget(current, p.TargetCPU, usage.CPU); ok && s > desired {
+			desired = s
+		}
+		if s, ok := scaleForTarget(current, p.TargetMemory, usage.Memory); ok && s > desired {
+			desired = s
+		}
+		if desired == 0 {
+			// No target could be measured.
+			desired = current
+		}
+	}
+	if desired < p.MinUnits {
+		return p.MinUnits
+	}
+	if desired > p.MaxUnits {
+		return p.MaxUnits
+	}
+	return desired
+}
+
+// scaleForTarget returns the scale needed to bring the usage to the
+// target, and whether the target could be measured.
+func scaleForTarget(current, target int, usage *float64) (int, bool) {
+	if target == 0 || usage == nil {
+		return 0, false
+	}
+	ratio := *usage / float64(target)
+	if math.Abs(ratio-1) <= AutoscaleTolerance {
+		return current, true
+	}
+	return int(math.Ceil(float64(current) * ratio)), true
+}
+
+// AutoscaleStatus records what the autoscaler last observed of, and
+// did to, an application.
+type AutoscaleStatus struct {
+	// Utilization is the last measured resource usage of the units.
+	Utilization Utilization
+
+	// TargetScale is the scale the autoscaler last wanted.
+	TargetScale int
+
+	// LastScaled is when the autoscaler last changed the scale.
+	LastScaled time.Time
+
+	// Message describes why the application couldn't be autoscaled,
+	// if it couldn't.
+	Message string
+}
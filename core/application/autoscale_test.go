@@ -0,0 +1,114 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+type autoscaleSuite struct{}
+
+var _ = gc.Suite(&autoscaleSuite{})
+
+func percent(v float64) *float64 {
+	return &v
+}
+
+func (s *autoscaleSuite) TestValidate(c *gc.C) {
+	valid := application.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  time.Minute,
+	}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		mutate func(*application.AutoscalePolicy)
+		err    string
+	}{{
+		mutate: func(p *application.AutoscalePolicy) { p.MinUnits = 0 },
+		err:    "min units 0 less than 1 not valid",
+	}, {
+		mutate: func(p *application.AutoscalePolicy) { p.MaxUnits = 0 },
+		err:    "max units 0 less than min units 1 not valid",
+	}, {
+		mutate: func(p *application.AutoscalePolicy) { p.TargetMemory = -1 },
+		err:    "negative target usage not valid",
+	}, {
+		mutate: func(p *application.AutoscalePolicy) { p.TargetCPU = 0 },
+		err:    "policy without a cpu or memory target not valid",
+	}, {
+		mutate: func(p *application.AutoscalePolicy) { p.Cooldown = -time.Second },
+		err:    "negative cooldown -1s not valid",
+	}} {
+		c.Logf("test %d", i)
+		policy := valid
+		test.mutate(&policy)
+		c.Check(policy.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *autoscaleSuite) TestDesiredScale(c *gc.C) {
+	policy := application.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     10,
+		TargetCPU:    50,
+		TargetMemory: 80,
+	}
+	for i, test := range []struct {
+		about   string
+		current int
+		usage   application.Utilization
+		desired int
+	}{{
+		about:   "cpu over target",
+		current: 4,
+		usage:   application.Utilization{Units: 4, CPU: percent(75)},
+		desired: 6,
+	}, {
+		about:   "cpu under target",
+		current: 4,
+		usage:   application.Utilization{Units: 4, CPU: percent(25)},
+		desired: 2,
+	}, {
+		about:   "within tolerance",
+		current: 4,
+		usage:   application.Utilization{Units: 4, CPU: percent(54)},
+		desired: 4,
+	}, {
+		about:   "largest scale wins",
+		current: 4,
+		usage:   application.Utilization{Units: 4, CPU: percent(25), Memory: percent(120)},
+		desired: 6,
+	}, {
+		about:   "bounded by max units",
+		current: 8,
+		usage:   application.Utilization{Units: 8, CPU: percent(100)},
+		desired: 10,
+	}, {
+		about:   "bounded by min units",
+		current: 3,
+		usage:   application.Utilization{Units: 3, CPU: percent(1)},
+		desired: 2,
+	}, {
+		about:   "no usage measured",
+		current: 4,
+		usage:   application.Utilization{},
+		desired: 4,
+	}, {
+		about:   "no units",
+		current: 0,
+		usage:   application.Utilization{},
+		desired: 2,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(policy.DesiredScale(test.current, test.usage), gc.Equals, test.desired)
+	}
+}
@@ -6,6 +6,19 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+const (
+	// FileSink writes audit records to a rotating log file.
+	FileSink = "file"
+
+	// SyslogSink forwards audit records to a remote syslog host.
+	SyslogSink = "syslog"
+
+	// WebhookSink posts batches of audit records to an HTTP endpoint.
+	WebhookSink = "webhook"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +42,17 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Sink determines where audit records are written: one of
+	// FileSink, SyslogSink or WebhookSink. An empty value is treated
+	// as FileSink.
+	Sink string
+
+	// Syslog holds the connection details used by the syslog sink.
+	Syslog syslog.RawConfig
+
+	// Webhook holds the configuration used by the webhook sink.
+	Webhook WebhookConfig
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -38,5 +62,34 @@ func (cfg Config) Validate() error {
 	if cfg.Enabled && cfg.Target == nil {
 		return errors.NewNotValid(nil, "logging enabled but no target provided")
 	}
+	// The settings for each sink are validated when it is created.
+	switch cfg.sink() {
+	case FileSink, SyslogSink, WebhookSink:
+	default:
+		return errors.NotValidf("audit log sink %q", cfg.Sink)
+	}
 	return nil
 }
+
+// SameSink returns whether the other config writes audit records to
+// the same sink, with the same settings, as this one.
+func (cfg Config) SameSink(other Config) bool {
+	sink := cfg.sink()
+	if sink != other.sink() {
+		return false
+	}
+	switch sink {
+	case SyslogSink:
+		return cfg.Syslog == other.Syslog
+	case WebhookSink:
+		return cfg.Webhook == other.Webhook
+	}
+	return true
+}
+
+func (cfg Config) sink() string {
+	if cfg.Sink == "" {
+		return FileSink
+	}
+	return cfg.Sink
+}
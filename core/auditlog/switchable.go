@@ -0,0 +1,70 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// SwitchableLog is an AuditLog which forwards records to another
+// AuditLog that can be replaced while the log is in use. API connections
+// hold on to the SwitchableLog, so the sink can be changed without
+// interrupting them.
+type SwitchableLog struct {
+	mu     sync.RWMutex
+	target AuditLog
+}
+
+// NewSwitchableLog returns a SwitchableLog forwarding records to target.
+func NewSwitchableLog(target AuditLog) *SwitchableLog {
+	return &SwitchableLog{target: target}
+}
+
+// Target returns the AuditLog that records are currently forwarded to.
+func (s *SwitchableLog) Target() AuditLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.target
+}
+
+// Switch forwards subsequent records to target, returning the previous
+// target. Once Switch returns no further records will be sent to the
+// previous target, so it can be safely closed by the caller.
+func (s *SwitchableLog) Switch(target AuditLog) AuditLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := s.target
+	s.target = target
+	return old
+}
+
+// AddConversation implements AuditLog.
+func (s *SwitchableLog) AddConversation(c Conversation) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return errors.Trace(s.target.AddConversation(c))
+}
+
+// AddRequest implements AuditLog.
+func (s *SwitchableLog) AddRequest(r Request) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return errors.Trace(s.target.AddRequest(r))
+}
+
+// AddResponse implements AuditLog.
+func (s *SwitchableLog) AddResponse(r ResponseErrors) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return errors.Trace(s.target.AddResponse(r))
+}
+
+// Close implements AuditLog, closing the current target.
+func (s *SwitchableLog) Close() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return errors.Trace(s.target.Close())
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type SwitchableSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SwitchableSuite{})
+
+func (s *SwitchableSuite) TestForwardsToCurrentTarget(c *gc.C) {
+	first := &stubLog{}
+	log := auditlog.NewSwitchableLog(first)
+	c.Assert(log.AddConversation(auditlog.Conversation{}), jc.ErrorIsNil)
+
+	second := &stubLog{}
+	previous := log.Switch(second)
+	c.Assert(previous, gc.Equals, auditlog.AuditLog(first))
+	c.Assert(log.Target(), gc.Equals, auditlog.AuditLog(second))
+
+	c.Assert(log.AddRequest(auditlog.Request{}), jc.ErrorIsNil)
+	c.Assert(log.AddResponse(auditlog.ResponseErrors{}), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	first.CheckCallNames(c, "AddConversation")
+	second.CheckCallNames(c, "AddRequest", "AddResponse", "Close")
+}
+
+type stubLog struct {
+	testing.Stub
+}
+
+func (l *stubLog) AddConversation(c auditlog.Conversation) error {
+	l.AddCall("AddConversation", c)
+	return l.NextErr()
+}
+
+func (l *stubLog) AddRequest(r auditlog.Request) error {
+	l.AddCall("AddRequest", r)
+	return l.NextErr()
+}
+
+func (l *stubLog) AddResponse(r auditlog.ResponseErrors) error {
+	l.AddCall("AddResponse", r)
+	return l.NextErr()
+}
+
+func (l *stubLog) Close() error {
+	l.AddCall("Close")
+	return l.NextErr()
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"os"
+	"sync/atomic"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/v2/rfc5424"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+const (
+	// syslogAppName is the RFC 5424 APP-NAME used for audit records.
+	syslogAppName = "juju-audit"
+)
+
+type auditLogSyslog struct {
+	tomb     tomb.Tomb
+	sender   syslog.Sender
+	clock    clock.Clock
+	hostname string
+	messages chan rfc5424.Message
+
+	// dropped counts the records discarded because the buffer was
+	// full, since the count was last reported.
+	dropped int64
+}
+
+// NewSyslog returns an audit entry sink which forwards each record,
+// encoded as JSON, to a remote syslog host as an RFC 5424 message.
+// Up to bufferSize records are held waiting to be sent.
+func NewSyslog(cfg syslog.RawConfig, bufferSize int, clock clock.Clock) (AuditLog, error) {
+	client, err := syslog.Open(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSyslogForSender(client.Sender, bufferSize, clock)
+}
+
+// NewSyslogForSender returns an audit entry sink which forwards each
+// record to the given syslog sender. Records are buffered and sent by
+// a separate goroutine, so adding a record never waits on the syslog
+// host; if the buffer is full the record is dropped and counted.
+func NewSyslogForSender(sender syslog.Sender, bufferSize int, clock clock.Clock) (AuditLog, error) {
+	if bufferSize < 1 {
+		return nil, errors.NotValidf("buffer size %d", bufferSize)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warningf("unable to determine hostname for syslog audit records: %v", err)
+	}
+	a := &auditLogSyslog{
+		sender:   sender,
+		clock:    clock,
+		hostname: hostname,
+		messages: make(chan rfc5424.Message, bufferSize),
+	}
+	a.tomb.Go(a.loop)
+	return a, nil
+}
+
+// AddConversation implements AuditLog.
+func (a *auditLogSyslog) AddConversation(c Conversation) error {
+	return errors.Trace(a.addRecord("conversation", Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (a *auditLogSyslog) AddRequest(m Request) error {
+	return errors.Trace(a.addRecord("request", Record{Request: &m}))
+}
+
+// AddResponse implements AuditLog.
+func (a *auditLogSyslog) AddResponse(m ResponseErrors) error {
+	return errors.Trace(a.addRecord("errors", Record{Errors: &m}))
+}
+
+// Close implements AuditLog. Any records which haven't been sent are
+// sent before the connection to the syslog host is closed.
+func (a *auditLogSyslog) Close() error {
+	a.tomb.Kill(nil)
+	err := a.tomb.Wait()
+	if closeErr := a.sender.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
+
+func (a *auditLogSyslog) addRecord(msgID string, r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityNotice,
+				Facility: rfc5424.FacilityAuthpriv,
+			},
+			Timestamp: rfc5424.Timestamp{Time: a.clock.Now()},
+			Hostname: rfc5424.Hostname{
+				Hostname: a.hostname,
+			},
+			AppName: syslogAppName,
+			MsgID:   rfc5424.MsgID(msgID),
+		},
+		Msg: string(bytes),
+	}
+	if err := msg.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case <-a.tomb.Dying():
+		return errors.New("audit log syslog closed")
+	default:
+	}
+	select {
+	case a.messages <- msg:
+	default:
+		atomic.AddInt64(&a.dropped, 1)
+	}
+	return nil
+}
+
+func (a *auditLogSyslog) loop() error {
+	for {
+		select {
+		case <-a.tomb.Dying():
+			// Send whatever is still buffered before exiting.
+			for len(a.messages) > 0 {
+				a.send(<-a.messages)
+			}
+			a.reportDropped()
+			return nil
+		case msg := <-a.messages:
+			a.send(msg)
+		}
+	}
+}
+
+func (a *auditLogSyslog) send(msg rfc5424.Message) {
+	a.reportDropped()
+	if err := a.sender.Send(msg); err != nil {
+		logger.Warningf("unable to send audit record to syslog: %v", err)
+	}
+}
+
+// reportDropped logs the number of records dropped because the buffer
+// was full since it was last called.
+func (a *auditLogSyslog) reportDropped() {
+	if n := atomic.SwapInt64(&a.dropped, 0); n > 0 {
+		logger.Errorf("audit syslog buffer is full, dropped %d audit records", n)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SyslogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SyslogSuite{})
+
+func (s *SyslogSuite) TestSendsRecords(c *gc.C) {
+	now := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	sender := &fakeSender{}
+	log, err := auditlog.NewSyslogForSender(sender, 10, testclock.NewClock(now))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = log.AddConversation(auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "gojira",
+		When:           "2017-11-27T13:21:24Z",
+		ModelName:      "admin/default",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:35:11Z",
+		Errors: []*auditlog.Error{
+			{Message: "oops", Code: "unauthorized access"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	sender.stub.CheckCallNames(c, "Send", "Send", "Close")
+	calls := sender.stub.Calls()
+
+	msg := calls[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Priority, gc.Equals, rfc5424.Priority{
+		Severity: rfc5424.SeverityNotice,
+		Facility: rfc5424.FacilityAuthpriv,
+	})
+	c.Check(msg.Timestamp.Time, gc.Equals, now)
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju-audit"))
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("conversation"))
+	c.Check(msg.Msg, gc.Equals, `{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":"AC1"}}`)
+
+	msg = calls[1].Args[0].(rfc5424.Message)
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("errors"))
+	c.Check(msg.Msg, gc.Equals, `{"errors":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:35:11Z","errors":[{"message":"oops","code":"unauthorized access"}]}}`)
+}
+
+func (s *SyslogSuite) TestInvalidBufferSize(c *gc.C) {
+	_, err := auditlog.NewSyslogForSender(&fakeSender{}, 0, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SyslogSuite) TestSendErrorNotReturned(c *gc.C) {
+	sender := &fakeSender{}
+	sender.stub.SetErrors(errors.New("connection reset"))
+	log, err := auditlog.NewSyslogForSender(sender, 10, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The record is sent by a separate goroutine, so the error is
+	// logged rather than failing the audited API call.
+	err = log.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	sender.stub.CheckCallNames(c, "Send", "Close")
+}
+
+func (s *SyslogSuite) TestDropsWhenBufferFull(c *gc.C) {
+	// The syslog host hangs until released, so nothing in the buffer
+	// can be sent.
+	release := make(chan struct{})
+	sender := &fakeSender{release: release}
+	log, err := auditlog.NewSyslogForSender(sender, 2, testclock.NewClock(time.Now()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Adding records never waits for the syslog host.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := uint64(1); i <= 10; i++ {
+			err := log.AddRequest(auditlog.Request{RequestID: i})
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("adding audit records blocked")
+	}
+
+	close(release)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	var sent int
+	for _, call := range sender.stub.Calls() {
+		if call.FuncName == "Send" {
+			sent++
+		}
+	}
+	// At most one record being sent plus a full buffer.
+	c.Assert(sent > 0, jc.IsTrue)
+	c.Assert(sent <= 3, jc.IsTrue, gc.Commentf("%d records sent", sent))
+}
+
+type fakeSender struct {
+	stub    testing.Stub
+	release chan struct{}
+}
+
+func (s *fakeSender) Send(msg rfc5424.Message) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.stub.AddCall("Send", msg)
+	return s.stub.NextErr()
+}
+
+func (s *fakeSender) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
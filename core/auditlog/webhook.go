@@ -0,0 +1,337 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"gopkg.in/tomb.v2"
+)
+
+const (
+	// webhookSpoolFilename is the name of the file, in the spool
+	// directory, that holds records which couldn't be delivered.
+	webhookSpoolFilename = "audit-webhook.spool"
+
+	// webhookContentType is the content type of the requests sent to
+	// the webhook: one JSON encoded record per line.
+	webhookContentType = "application/x-ndjson"
+)
+
+// HTTPClient is the subset of *http.Client used by the webhook sink.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// WebhookConfig holds the configuration for an audit entry sink that
+// posts batches of records to an HTTP endpoint.
+type WebhookConfig struct {
+	// URL is the endpoint that records are posted to.
+	URL string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request.
+	BatchSize int
+
+	// BufferSize is the maximum number of records held waiting to be
+	// sent. Once the buffer is full, new records are dropped rather
+	// than blocking the API call being audited.
+	BufferSize int
+
+	// FlushInterval is the maximum time a record is held before it is
+	// sent.
+	FlushInterval time.Duration
+
+	// RetryAttempts is the number of times a batch is sent before it
+	// is written to the spool.
+	RetryAttempts int
+
+	// RetryDelay is the initial delay between attempts to send a batch.
+	// The delay doubles on each subsequent attempt.
+	RetryDelay time.Duration
+
+	// SpoolDir is the directory holding records which couldn't be
+	// delivered, so they can be resent when the endpoint recovers.
+	SpoolDir string
+
+	// MaxSpoolSizeMB is the maximum size of the spool. Once the spool
+	// is full, undeliverable records are dropped.
+	MaxSpoolSizeMB int
+}
+
+// Validate checks the webhook configuration.
+func (cfg WebhookConfig) Validate() error {
+	if cfg.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if cfg.BatchSize < 1 {
+		return errors.NotValidf("BatchSize %d", cfg.BatchSize)
+	}
+	if cfg.BufferSize < 1 {
+		return errors.NotValidf("BufferSize %d", cfg.BufferSize)
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.NotValidf("FlushInterval %v", cfg.FlushInterval)
+	}
+	if cfg.RetryAttempts < 1 {
+		return errors.NotValidf("RetryAttempts %d", cfg.RetryAttempts)
+	}
+	if cfg.RetryDelay <= 0 {
+		return errors.NotValidf("RetryDelay %v", cfg.RetryDelay)
+	}
+	if cfg.SpoolDir == "" {
+		return errors.NotValidf("empty SpoolDir")
+	}
+	if cfg.MaxSpoolSizeMB < 1 {
+		return errors.NotValidf("MaxSpoolSizeMB %d", cfg.MaxSpoolSizeMB)
+	}
+	return nil
+}
+
+type auditLogWebhook struct {
+	tomb    tomb.Tomb
+	config  WebhookConfig
+	client  HTTPClient
+	clock   clock.Clock
+	records chan []byte
+
+	// dropped counts the records discarded because the buffer was
+	// full, since the count was last reported.
+	dropped int64
+}
+
+// NewWebhook returns an audit entry sink which posts records, as JSON
+// lines, to an HTTP endpoint. Records are sent in batches of up to
+// BatchSize records, or after FlushInterval has passed, whichever comes
+// first. Records are buffered and sent by a separate goroutine, so
+// adding a record never waits on the endpoint; if the buffer is full the
+// record is dropped and counted. Batches which can't be delivered after
+// retrying are written to a bounded on-disk spool, and resent ahead of
+// new records once the endpoint is reachable again.
+func NewWebhook(config WebhookConfig, client HTTPClient, clock clock.Clock) (AuditLog, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &auditLogWebhook{
+		config:  config,
+		client:  client,
+		clock:   clock,
+		records: make(chan []byte, config.BufferSize),
+	}
+	a.tomb.Go(a.loop)
+	return a, nil
+}
+
+// AddConversation implements AuditLog.
+func (a *auditLogWebhook) AddConversation(c Conversation) error {
+	return errors.Trace(a.addRecord(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (a *auditLogWebhook) AddRequest(m Request) error {
+	return errors.Trace(a.addRecord(Record{Request: &m}))
+}
+
+// AddResponse implements AuditLog.
+func (a *auditLogWebhook) AddResponse(m ResponseErrors) error {
+	return errors.Trace(a.addRecord(Record{Errors: &m}))
+}
+
+// Close implements AuditLog. Any records which haven't been sent are
+// flushed before returning.
+func (a *auditLogWebhook) Close() error {
+	a.tomb.Kill(nil)
+	return errors.Trace(a.tomb.Wait())
+}
+
+func (a *auditLogWebhook) addRecord(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case <-a.tomb.Dying():
+		return errors.New("audit log webhook closed")
+	default:
+	}
+	select {
+	case a.records <- line:
+	default:
+		atomic.AddInt64(&a.dropped, 1)
+	}
+	return nil
+}
+
+func (a *auditLogWebhook) loop() error {
+	var (
+		batch [][]byte
+		flush <-chan time.Time
+	)
+	for {
+		select {
+		case <-a.tomb.Dying():
+			// Send whatever is still buffered before exiting.
+			for len(a.records) > 0 {
+				batch = append(batch, <-a.records)
+				if len(batch) >= a.config.BatchSize {
+					a.flush(batch)
+					batch = nil
+				}
+			}
+			a.flush(batch)
+			a.reportDropped()
+			return nil
+		case line := <-a.records:
+			batch = append(batch, line)
+			if len(batch) >= a.config.BatchSize {
+				a.flush(batch)
+				batch, flush = nil, nil
+			} else if flush == nil {
+				flush = a.clock.After(a.config.FlushInterval)
+			}
+		case <-flush:
+			a.flush(batch)
+			batch, flush = nil, nil
+		}
+	}
+}
+
+// flush sends any spooled records followed by the batch, spooling the
+// batch if it can't be sent.
+func (a *auditLogWebhook) flush(batch [][]byte) {
+	a.reportDropped()
+	if len(batch) == 0 {
+		return
+	}
+	if err := a.sendSpool(); err != nil {
+		logger.Warningf("unable to send spooled audit records: %v", err)
+		a.spool(batch)
+		return
+	}
+	if err := a.sendWithRetry(batch); err != nil {
+		logger.Warningf("unable to send %d audit records: %v", len(batch), err)
+		a.spool(batch)
+	}
+}
+
+// reportDropped logs the number of records dropped because the buffer
+// was full since it was last called.
+func (a *auditLogWebhook) reportDropped() {
+	if n := atomic.SwapInt64(&a.dropped, 0); n > 0 {
+		logger.Errorf("audit webhook buffer is full, dropped %d audit records", n)
+	}
+}
+
+func (a *auditLogWebhook) sendWithRetry(batch [][]byte) error {
+	return retry.Call(retry.CallArgs{
+		Func: func() error {
+			return a.send(batch)
+		},
+		Attempts:    a.config.RetryAttempts,
+		Delay:       a.config.RetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       a.clock,
+		// Once the sink is closing we make a single attempt, then
+		// fall back to the spool.
+		Stop: a.tomb.Dying(),
+	})
+}
+
+func (a *auditLogWebhook) send(batch [][]byte) error {
+	body := bytes.Join(batch, []byte("\n"))
+	body = append(body, '\n')
+	req, err := http.NewRequest(http.MethodPost, a.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", webhookContentType)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (a *auditLogWebhook) spoolPath() string {
+	return filepath.Join(a.config.SpoolDir, webhookSpoolFilename)
+}
+
+// spool appends the batch to the spool file, unless doing so would
+// exceed the maximum spool size, in which case the batch is dropped.
+func (a *auditLogWebhook) spool(batch [][]byte) {
+	var size int
+	for _, line := range batch {
+		size += len(line) + 1
+	}
+	path := a.spoolPath()
+	if info, err := os.Stat(path); err == nil {
+		limit := int64(a.config.MaxSpoolSizeMB) * 1024 * 1024
+		if info.Size()+int64(size) > limit {
+			logger.Errorf("audit webhook spool is full, dropping %d audit records", len(batch))
+			return
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		logger.Errorf("unable to open audit webhook spool, dropping %d audit records: %v", len(batch), err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	w := bufio.NewWriter(f)
+	for _, line := range batch {
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		logger.Errorf("unable to write audit webhook spool: %v", err)
+	}
+}
+
+// sendSpool sends any spooled records, in batches, removing the spool
+// once they have all been delivered. If a batch can't be sent, the
+// remaining records are kept in the spool.
+func (a *auditLogWebhook) sendSpool() error {
+	path := a.spoolPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || len(data) == 0 {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n"))
+	for len(lines) > 0 {
+		n := a.config.BatchSize
+		if n > len(lines) {
+			n = len(lines)
+		}
+		if err := a.send(lines[:n]); err != nil {
+			remaining := append(bytes.Join(lines, []byte("\n")), '\n')
+			if writeErr := os.WriteFile(path, remaining, 0600); writeErr != nil {
+				logger.Errorf("unable to rewrite audit webhook spool: %v", writeErr)
+			}
+			return errors.Trace(err)
+		}
+		lines = lines[n:]
+	}
+	return errors.Trace(os.Remove(path))
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WebhookSuite struct {
+	testing.IsolationSuite
+
+	mu       sync.Mutex
+	status   int
+	requests chan string
+	server   *httptest.Server
+	clock    *testclock.Clock
+	config   auditlog.WebhookConfig
+}
+
+var _ = gc.Suite(&WebhookSuite{})
+
+func (s *WebhookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.status = http.StatusOK
+	s.requests = make(chan string, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+		body, err := io.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.mu.Lock()
+		status := s.status
+		s.mu.Unlock()
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			s.requests <- string(body)
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.clock = testclock.NewClock(time.Now())
+	s.config = auditlog.WebhookConfig{
+		URL:            s.server.URL,
+		BatchSize:      2,
+		BufferSize:     10,
+		FlushInterval:  time.Minute,
+		RetryAttempts:  1,
+		RetryDelay:     time.Second,
+		SpoolDir:       c.MkDir(),
+		MaxSpoolSizeMB: 1,
+	}
+}
+
+func (s *WebhookSuite) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *WebhookSuite) newWebhook(c *gc.C) auditlog.AuditLog {
+	log, err := auditlog.NewWebhook(s.config, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return log
+}
+
+func (s *WebhookSuite) addRequest(c *gc.C, log auditlog.AuditLog, id uint64) {
+	err := log.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      id,
+		When:           "2017-12-12T11:34:56Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WebhookSuite) nextRequest(c *gc.C) []string {
+	select {
+	case body := <-s.requests:
+		return strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for webhook request")
+	}
+	return nil
+}
+
+func requestLine(id string) string {
+	return `{"request":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":` + id +
+		`,"when":"2017-12-12T11:34:56Z","facade":"Application","method":"Deploy","version":4}}`
+}
+
+func (s *WebhookSuite) TestValidate(c *gc.C) {
+	s.config.URL = ""
+	_, err := auditlog.NewWebhook(s.config, http.DefaultClient, s.clock)
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+func (s *WebhookSuite) TestSendsFullBatch(c *gc.C) {
+	log := s.newWebhook(c)
+	defer func() { c.Assert(log.Close(), jc.ErrorIsNil) }()
+
+	s.addRequest(c, log, 1)
+	s.addRequest(c, log, 2)
+	c.Assert(s.nextRequest(c), jc.DeepEquals, []string{requestLine("1"), requestLine("2")})
+}
+
+func (s *WebhookSuite) TestSendsAfterFlushInterval(c *gc.C) {
+	log := s.newWebhook(c)
+	defer func() { c.Assert(log.Close(), jc.ErrorIsNil) }()
+
+	s.addRequest(c, log, 1)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextRequest(c), jc.DeepEquals, []string{requestLine("1")})
+}
+
+func (s *WebhookSuite) TestCloseFlushes(c *gc.C) {
+	log := s.newWebhook(c)
+	s.addRequest(c, log, 1)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Assert(s.nextRequest(c), jc.DeepEquals, []string{requestLine("1")})
+
+	err := log.AddConversation(auditlog.Conversation{})
+	c.Assert(err, gc.ErrorMatches, "audit log webhook closed")
+}
+
+func (s *WebhookSuite) TestSpoolsAndResends(c *gc.C) {
+	s.setStatus(http.StatusServiceUnavailable)
+	log := s.newWebhook(c)
+	defer func() { c.Assert(log.Close(), jc.ErrorIsNil) }()
+
+	s.addRequest(c, log, 1)
+	s.addRequest(c, log, 2)
+	spool := filepath.Join(s.config.SpoolDir, "audit-webhook.spool")
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if _, err := os.Stat(spool); err == nil {
+			break
+		}
+	}
+	data, err := os.ReadFile(spool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, requestLine("1")+"\n"+requestLine("2")+"\n")
+
+	s.setStatus(http.StatusOK)
+	s.addRequest(c, log, 3)
+	s.addRequest(c, log, 4)
+	c.Assert(s.nextRequest(c), jc.DeepEquals, []string{requestLine("1"), requestLine("2")})
+	c.Assert(s.nextRequest(c), jc.DeepEquals, []string{requestLine("3"), requestLine("4")})
+
+	_, err = os.Stat(spool)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *WebhookSuite) TestDropsWhenSpoolFull(c *gc.C) {
+	spool := filepath.Join(s.config.SpoolDir, "audit-webhook.spool")
+	spooled := strings.Repeat("{}\n", 1024*1024/3)
+	err := os.WriteFile(spool, []byte(spooled), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setStatus(http.StatusServiceUnavailable)
+	log := s.newWebhook(c)
+	s.addRequest(c, log, 1)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	data, err := os.ReadFile(spool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, spooled)
+}
+
+func (s *WebhookSuite) TestDropsWhenBufferFull(c *gc.C) {
+	// The endpoint hangs until released, so nothing in the buffer can
+	// be sent.
+	release := make(chan struct{})
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		<-release
+		received <- string(body)
+	}))
+	defer server.Close()
+	s.config.URL = server.URL
+	s.config.BatchSize = 1
+	s.config.BufferSize = 2
+	log := s.newWebhook(c)
+
+	// Adding records never waits for the endpoint.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := uint64(1); i <= 10; i++ {
+			s.addRequest(c, log, i)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("adding audit records blocked")
+	}
+
+	close(release)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	close(received)
+	var lines int
+	for body := range received {
+		lines += strings.Count(body, "\n")
+	}
+	// At most one record being sent plus a full buffer.
+	c.Assert(lines > 0, jc.IsTrue)
+	c.Assert(lines <= 3, jc.IsTrue, gc.Commentf("%d records sent", lines))
+}
@@ -0,0 +1,351 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package downloader
+
+import (
+	"container/list"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3"
+)
+
+// CharmCache provides access to previously downloaded charm archives by
+// their SHA256 hash.
+type CharmCache interface {
+	// Fetch places the charm archive with the given SHA256 hash at
+	// path. If the archive isn't cached, download is called to
+	// download it to path, and the archive is then added to the cache.
+	// Concurrent fetches of the same archive only download it once.
+	// The returned bool is true if the archive wasn't downloaded.
+	Fetch(sha256, path string, download func(path string) error) (bool, error)
+}
+
+// CacheMetrics records the activity of a Cache.
+type CacheMetrics interface {
+	// Hit records that an archive was found in the cache.
+	Hit()
+
+	// Miss records that an archive wasn't in the cache.
+	Miss()
+
+	// Evicted records that an archive was removed from the cache to
+	// make space for others.
+	Evicted()
+
+	// Usage records the size and number of archives in the cache.
+	Usage(size int64, entries int)
+}
+
+// CacheConfig holds the configuration for a Cache.
+type CacheConfig struct {
+	// Dir is the directory that cached archives are stored in.
+	Dir string
+
+	// MaxSize is the maximum total size in bytes of the cached
+	// archives.
+	MaxSize int64
+
+	Logger  Logger
+	Metrics CacheMetrics
+}
+
+// Validate returns an error if the config is not valid.
+func (cfg CacheConfig) Validate() error {
+	if cfg.Dir == "" {
+		return errors.NotValidf("empty Dir")
+	}
+	if cfg.MaxSize < 0 {
+		return errors.NotValidf("negative MaxSize")
+	}
+	if cfg.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if cfg.Metrics == nil {
+		return errors.NotValidf("nil Metrics")
+	}
+	return nil
+}
+
+// Cache is a content-addressed cache of charm archives, keyed by their
+// SHA256 hash, which is shared by all the models on a controller. When
+// the cache is full, the least recently used archives are evicted.
+type Cache struct {
+	dir     string
+	logger  Logger
+	metrics CacheMetrics
+
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+
+	// lru holds the cached archives, most recently used first.
+	lru     *list.List
+	entries map[string]*list.Element
+
+	// inflight holds the archives being downloaded.
+	inflight map[string]*inflightDownload
+}
+
+// inflightDownload records the result of downloading an archive, once
+// done is closed.
+type inflightDownload struct {
+	done chan struct{}
+	err  error
+}
+
+type cacheEntry struct {
+	sha256 string
+	size   int64
+}
+
+// NewCache returns a Cache of the archives in the configured directory,
+// which is created if it doesn't exist. Archives which are already in
+// the directory, from previous runs, are kept.
+func NewCache(cfg CacheConfig) (*Cache, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &Cache{
+		dir:      cfg.Dir,
+		logger:   cfg.Logger,
+		metrics:  cfg.Metrics,
+		maxSize:  cfg.MaxSize,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+		inflight: make(map[string]*inflightDownload),
+	}
+	if err := c.load(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+// load adds the archives already in the directory to the cache. Their
+// modification times, which are updated when they're used, give the
+// order in which they were last used.
+func (c *Cache) load() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var infos []os.FileInfo
+	for _, dirEntry := range dirEntries {
+		if !validSHA256(dirEntry.Name()) || !dirEntry.Type().IsRegular() {
+			// Remove partial copies left by a previous run.
+			_ = os.Remove(filepath.Join(c.dir, dirEntry.Name()))
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().After(infos[j].ModTime())
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, info := range infos {
+		c.entries[info.Name()] = c.lru.PushBack(&cacheEntry{sha256: info.Name(), size: info.Size()})
+		c.size += info.Size()
+	}
+	c.evict()
+	return nil
+}
+
+// SetMaxSize changes the maximum total size of the cached archives,
+// evicting archives if the cache is now too big. A size of 0 removes
+// all the archives from the cache.
+func (c *Cache) SetMaxSize(maxSize int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxSize = maxSize
+	c.evict()
+}
+
+// Fetch is part of the CharmCache interface.
+func (c *Cache) Fetch(sha256, path string, download func(path string) error) (bool, error) {
+	if !validSHA256(sha256) {
+		return false, errors.NotValidf("SHA256 hash %q", sha256)
+	}
+	for {
+		c.mu.Lock()
+		if elem, ok := c.entries[sha256]; ok {
+			c.lru.MoveToFront(elem)
+			c.mu.Unlock()
+			if err := c.copyOut(sha256, path); errors.Is(err, errors.NotFound) {
+				// The file has been removed from the directory, so
+				// download the archive again.
+				c.logger.Warningf("cached charm archive %q has been removed", sha256)
+				c.remove(sha256)
+				continue
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			c.metrics.Hit()
+			return true, nil
+		}
+		if inflight, ok := c.inflight[sha256]; ok {
+			// Wait for the other download to complete, and then use
+			// the cached archive. If it couldn't be cached, such as
+			// when it is too big, download it here.
+			c.mu.Unlock()
+			<-inflight.done
+			if inflight.err != nil {
+				return false, errors.Trace(inflight.err)
+			}
+			continue
+		}
+		inflight := &inflightDownload{done: make(chan struct{})}
+		c.inflight[sha256] = inflight
+		c.mu.Unlock()
+
+		c.metrics.Miss()
+		err := download(path)
+		if err == nil {
+			c.add(sha256, path)
+		}
+		c.mu.Lock()
+		delete(c.inflight, sha256)
+		c.mu.Unlock()
+		inflight.err = err
+		close(inflight.done)
+		return false, errors.Trace(err)
+	}
+}
+
+// add adds a downloaded archive to the cache, if it has the expected
+// hash. Failing to add the archive isn't an error, as the archive has
+// still been downloaded.
+func (c *Cache) add(sha256, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		c.logger.Warningf("unable to cache charm archive %q: %v", sha256, err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	tmp, err := os.CreateTemp(c.dir, ".download-")
+	if err != nil {
+		c.logger.Warningf("unable to cache charm archive %q: %v", sha256, err)
+		return
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	hash, size, err := utils.ReadSHA256(io.TeeReader(f, tmp))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		c.logger.Warningf("unable to cache charm archive %q: %v", sha256, err)
+		return
+	}
+	if hash != sha256 {
+		// The archive is verified by the downloader, so just don't
+		// cache it.
+		c.logger.Debugf("not caching charm archive %q with SHA256 hash %q", sha256, hash)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if size > c.maxSize {
+		c.logger.Debugf("charm archive %q of %d bytes is too big to cache", sha256, size)
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path(sha256)); err != nil {
+		c.logger.Warningf("unable to cache charm archive %q: %v", sha256, err)
+		return
+	}
+	c.entries[sha256] = c.lru.PushFront(&cacheEntry{sha256: sha256, size: size})
+	c.size += size
+	c.evict()
+}
+
+// copyOut copies a cached archive to path, and records that it was
+// used.
+func (c *Cache) copyOut(sha256, path string) error {
+	cachedPath := c.path(sha256)
+	src, err := os.Open(cachedPath)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("cached charm archive %q", sha256)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return errors.Trace(err)
+	}
+	if err := dst.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	_ = os.Chtimes(cachedPath, now, now)
+	return nil
+}
+
+// remove removes an archive from the cache.
+func (c *Cache) remove(sha256 string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[sha256]; ok {
+		c.removeElement(elem)
+	}
+	c.metrics.Usage(c.size, len(c.entries))
+}
+
+// evict removes the least recently used archives until the cache is no
+// bigger than its maximum size. It must be called with the mutex held.
+func (c *Cache) evict() {
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		if elem == nil {
+			break
+		}
+		entry := c.removeElement(elem)
+		c.logger.Debugf("evicted charm archive %q from cache", entry.sha256)
+		c.metrics.Evicted()
+	}
+	c.metrics.Usage(c.size, len(c.entries))
+}
+
+func (c *Cache) removeElement(elem *list.Element) *cacheEntry {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.sha256)
+	c.size -= entry.size
+	if err := os.Remove(c.path(entry.sha256)); err != nil && !os.IsNotExist(err) {
+		c.logger.Warningf("unable to remove cached charm archive %q: %v", entry.sha256, err)
+	}
+	return entry
+}
+
+func (c *Cache) path(sha256 string) string {
+	return filepath.Join(c.dir, sha256)
+}
+
+// validSHA256 returns true if s is a hex encoded SHA256 hash, and so
+// can safely be used as a file name.
+func validSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
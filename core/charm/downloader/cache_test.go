@@ -0,0 +1,250 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package downloader_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charm/downloader"
+)
+
+var _ = gc.Suite(&cacheSuite{})
+
+type cacheSuite struct {
+	testing.IsolationSuite
+
+	dir     string
+	metrics *fakeCacheMetrics
+}
+
+func (s *cacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.metrics = &fakeCacheMetrics{}
+}
+
+func (s *cacheSuite) newCache(c *gc.C, maxSize int64) *downloader.Cache {
+	cache, err := downloader.NewCache(downloader.CacheConfig{
+		Dir:     s.dir,
+		MaxSize: maxSize,
+		Logger:  loggo.GetLogger("test"),
+		Metrics: s.metrics,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return cache
+}
+
+func hashOf(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
+
+// fetch fetches content through the cache, returning whether it was
+// cached.
+func (s *cacheSuite) fetch(c *gc.C, cache *downloader.Cache, content string) bool {
+	path := filepath.Join(c.MkDir(), "charm")
+	cached, err := cache.Fetch(hashOf(content), path, func(path string) error {
+		return os.WriteFile(path, []byte(content), 0644)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, content)
+	return cached
+}
+
+func (s *cacheSuite) TestValidate(c *gc.C) {
+	_, err := downloader.NewCache(downloader.CacheConfig{})
+	c.Assert(err, gc.ErrorMatches, "empty Dir not valid")
+}
+
+func (s *cacheSuite) TestFetch(c *gc.C) {
+	cache := s.newCache(c, 100)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsFalse)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsTrue)
+	c.Assert(s.fetch(c, cache, "charm-2"), jc.IsFalse)
+
+	c.Assert(s.metrics.hits, gc.Equals, 1)
+	c.Assert(s.metrics.misses, gc.Equals, 2)
+	c.Assert(s.metrics.size, gc.Equals, int64(14))
+	c.Assert(s.metrics.entries, gc.Equals, 2)
+}
+
+func (s *cacheSuite) TestFetchError(c *gc.C) {
+	cache := s.newCache(c, 100)
+	_, err := cache.Fetch(hashOf("charm-1"), filepath.Join(c.MkDir(), "charm"), func(string) error {
+		return errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsFalse)
+}
+
+func (s *cacheSuite) TestFetchHashMismatch(c *gc.C) {
+	cache := s.newCache(c, 100)
+	path := filepath.Join(c.MkDir(), "charm")
+	cached, err := cache.Fetch(hashOf("charm-1"), path, func(path string) error {
+		return os.WriteFile(path, []byte("other"), 0644)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cached, jc.IsFalse)
+	c.Assert(s.metrics.entries, gc.Equals, 0)
+}
+
+func (s *cacheSuite) TestFetchInvalidHash(c *gc.C) {
+	cache := s.newCache(c, 100)
+	_, err := cache.Fetch("../etc/passwd", filepath.Join(c.MkDir(), "charm"), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *cacheSuite) TestEvictLeastRecentlyUsed(c *gc.C) {
+	cache := s.newCache(c, 20)
+	s.fetch(c, cache, "charm-1")
+	s.fetch(c, cache, "charm-2")
+	// Use charm-1, so that charm-2 is the least recently used.
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsTrue)
+	s.fetch(c, cache, "charm-3")
+
+	c.Assert(s.metrics.evictions, gc.Equals, 1)
+	c.Assert(s.metrics.entries, gc.Equals, 2)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsTrue)
+	c.Assert(s.fetch(c, cache, "charm-3"), jc.IsTrue)
+	_, err := os.Stat(filepath.Join(s.dir, hashOf("charm-2")))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *cacheSuite) TestTooBigToCache(c *gc.C) {
+	cache := s.newCache(c, 5)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsFalse)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsFalse)
+	c.Assert(s.metrics.entries, gc.Equals, 0)
+}
+
+func (s *cacheSuite) TestSetMaxSize(c *gc.C) {
+	cache := s.newCache(c, 100)
+	s.fetch(c, cache, "charm-1")
+	s.fetch(c, cache, "charm-2")
+
+	cache.SetMaxSize(10)
+	c.Assert(s.metrics.entries, gc.Equals, 1)
+	c.Assert(s.fetch(c, cache, "charm-2"), jc.IsTrue)
+
+	cache.SetMaxSize(0)
+	c.Assert(s.metrics.entries, gc.Equals, 0)
+	c.Assert(s.fetch(c, cache, "charm-2"), jc.IsFalse)
+}
+
+func (s *cacheSuite) TestLoadExisting(c *gc.C) {
+	cache := s.newCache(c, 100)
+	s.fetch(c, cache, "charm-1")
+	s.fetch(c, cache, "charm-2")
+	// Make charm-1 the least recently used.
+	old := time.Now().Add(-time.Hour)
+	err := os.Chtimes(filepath.Join(s.dir, hashOf("charm-1")), old, old)
+	c.Assert(err, jc.ErrorIsNil)
+	// Partial copies are removed.
+	err = os.WriteFile(filepath.Join(s.dir, ".download-123"), []byte("partial"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cache = s.newCache(c, 10)
+	c.Assert(s.metrics.entries, gc.Equals, 1)
+	c.Assert(s.fetch(c, cache, "charm-2"), jc.IsTrue)
+	entries, err := os.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Name(), gc.Equals, hashOf("charm-2"))
+}
+
+func (s *cacheSuite) TestCachedFileRemoved(c *gc.C) {
+	cache := s.newCache(c, 100)
+	s.fetch(c, cache, "charm-1")
+	err := os.Remove(filepath.Join(s.dir, hashOf("charm-1")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsFalse)
+	c.Assert(s.fetch(c, cache, "charm-1"), jc.IsTrue)
+}
+
+func (s *cacheSuite) TestConcurrentFetchDownloadsOnce(c *gc.C) {
+	cache := s.newCache(c, 100)
+	content := "charm-1"
+
+	var (
+		mu        sync.Mutex
+		downloads int
+	)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	download := func(path string) error {
+		mu.Lock()
+		downloads++
+		mu.Unlock()
+		close(started)
+		<-release
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]bool, 5)
+	fetch := func(i int) {
+		defer wg.Done()
+		cached, err := cache.Fetch(hashOf(content), filepath.Join(c.MkDir(), "charm"), download)
+		c.Check(err, jc.ErrorIsNil)
+		results[i] = cached
+	}
+	wg.Add(1)
+	go fetch(0)
+	<-started
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go fetch(i)
+	}
+	close(release)
+	wg.Wait()
+
+	c.Assert(downloads, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []bool{false, true, true, true, true})
+}
+
+type fakeCacheMetrics struct {
+	mu        sync.Mutex
+	hits      int
+	misses    int
+	evictions int
+	size      int64
+	entries   int
+}
+
+func (m *fakeCacheMetrics) Hit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits++
+}
+
+func (m *fakeCacheMetrics) Miss() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses++
+}
+
+func (m *fakeCacheMetrics) Evicted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictions++
+}
+
+func (m *fakeCacheMetrics) Usage(size int64, entries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.size = size
+	m.entries = entries
+}
@@ -4,6 +4,8 @@
 package downloader
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
@@ -92,17 +94,24 @@ func (dc DownloadedCharm) verify(downloadOrigin corecharm.Origin, force bool) er
 
 // Downloader implements store-agnostic download and pesistence of charm blobs.
 type Downloader struct {
-	logger     Logger
-	repoGetter RepositoryGetter
-	storage    Storage
+	logger        Logger
+	repoGetter    RepositoryGetter
+	storage       Storage
+	cache         CharmCache
+	signingPolicy corecharm.SigningPolicy
 }
 
-// NewDownloader returns a new charm downloader instance.
-func NewDownloader(logger Logger, storage Storage, repoGetter RepositoryGetter) *Downloader {
+// NewDownloader returns a new charm downloader instance. If cache is not
+// nil, charms with a known SHA256 hash are fetched through it, so that
+// each is only downloaded once. Downloaded charms are checked against
+// their signatures according to the signing policy.
+func NewDownloader(logger Logger, storage Storage, repoGetter RepositoryGetter, cache CharmCache, signingPolicy corecharm.SigningPolicy) *Downloader {
 	return &Downloader{
-		repoGetter: repoGetter,
-		storage:    storage,
-		logger:     logger,
+		repoGetter:    repoGetter,
+		storage:       storage,
+		logger:        logger,
+		cache:         cache,
+		signingPolicy: signingPolicy,
 	}
 }
 
@@ -166,6 +175,9 @@ func (d *Downloader) DownloadAndStore(charmURL *charm.URL, requestedOrigin corec
 	if err := downloadedCharm.verify(actualOrigin, force); err != nil {
 		return corecharm.Origin{}, errors.Annotatef(err, "verifying downloaded charm %q from origin %v", charmURL.Name, requestedOrigin)
 	}
+	if err := d.verifySignature(charmURL.Name, downloadedCharm.SHA256, tmpFile.Name()); err != nil {
+		return corecharm.Origin{}, errors.Annotatef(err, "verifying downloaded charm %q from origin %v", charmURL.Name, requestedOrigin)
+	}
 
 	// Store Charm
 	if err := d.storeCharm(charmURL.String(), downloadedCharm, tmpFile.Name()); err != nil {
@@ -177,7 +189,7 @@ func (d *Downloader) DownloadAndStore(charmURL *charm.URL, requestedOrigin corec
 
 func (d *Downloader) downloadAndHash(charmName string, requestedOrigin corecharm.Origin, repo CharmRepository, dstPath string) (DownloadedCharm, corecharm.Origin, error) {
 	d.logger.Debugf("downloading charm %q from requested origin %v", charmName, requestedOrigin)
-	chArchive, actualOrigin, err := repo.DownloadCharm(charmName, requestedOrigin, dstPath)
+	chArchive, actualOrigin, err := d.download(charmName, requestedOrigin, repo, dstPath)
 	if err != nil {
 		return DownloadedCharm{}, corecharm.Origin{}, errors.Trace(err)
 	}
@@ -205,6 +217,65 @@ func (d *Downloader) downloadAndHash(charmName string, requestedOrigin corecharm
 	}, actualOrigin, nil
 }
 
+// download downloads a charm to dstPath. If there is a cache, and the
+// repository provides the charm's hash, the charm is only downloaded
+// if it isn't already cached.
+func (d *Downloader) download(charmName string, requestedOrigin corecharm.Origin, repo CharmRepository, dstPath string) (corecharm.CharmArchive, corecharm.Origin, error) {
+	if d.cache == nil {
+		return repo.DownloadCharm(charmName, requestedOrigin, dstPath)
+	}
+	_, resolvedOrigin, err := repo.GetDownloadURL(charmName, requestedOrigin)
+	if err != nil {
+		return nil, corecharm.Origin{}, errors.Trace(err)
+	}
+	if resolvedOrigin.Hash == "" {
+		return repo.DownloadCharm(charmName, requestedOrigin, dstPath)
+	}
+
+	var (
+		chArchive    corecharm.CharmArchive
+		actualOrigin = resolvedOrigin
+	)
+	cached, err := d.cache.Fetch(resolvedOrigin.Hash, dstPath, func(path string) error {
+		var err error
+		chArchive, actualOrigin, err = repo.DownloadCharm(charmName, resolvedOrigin, path)
+		return errors.Trace(err)
+	})
+	if err != nil {
+		return nil, corecharm.Origin{}, errors.Trace(err)
+	}
+	if cached {
+		d.logger.Debugf("using cached charm %q with SHA256 hash %q", charmName, resolvedOrigin.Hash)
+		if chArchive, err = charm.ReadCharmArchive(dstPath); err != nil {
+			return nil, corecharm.Origin{}, errors.Annotate(err, "reading cached charm")
+		}
+	}
+	return chArchive, actualOrigin, nil
+}
+
+// verifySignature verifies a downloaded charm against the signature of
+// its SHA256 hash, if it is signed, according to the signing policy.
+func (d *Downloader) verifySignature(charmName, sha256 string, archivePath string) error {
+	if !d.signingPolicy.Enabled() {
+		return nil
+	}
+	what := fmt.Sprintf("charm %q", charmName)
+	sig, err := d.signingPolicy.Signature(context.TODO(), what, "sha256:"+sha256)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if sig == nil {
+		d.logger.Debugf("%s is not signed", what)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	return errors.Trace(d.signingPolicy.Verify(what, corecharm.CharmSignatureNamespace, sig, f))
+}
+
 func (d *Downloader) storeCharm(charmURL string, dc DownloadedCharm, archivePath string) error {
 	charmArchive, err := os.Open(archivePath)
 	if err != nil {
@@ -4,13 +4,17 @@
 package downloader_test
 
 import (
-	"errors"
+	"context"
+	"crypto/sha256"
+	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version/v2"
@@ -20,6 +24,9 @@ import (
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/charm/downloader"
 	"github.com/juju/juju/core/charm/downloader/mocks"
+	"github.com/juju/juju/pki/ssh"
+	pkitest "github.com/juju/juju/pki/test"
+	"github.com/juju/juju/testcharms"
 )
 
 var _ = gc.Suite(&downloaderSuite{})
@@ -246,6 +253,140 @@ func (s downloaderSuite) TestDownloadAndStore(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s downloaderSuite) TestDownloadAndStoreCached(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	archivePath := testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	data, err := os.ReadFile(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	cache, err := downloader.NewCache(downloader.CacheConfig{
+		Dir:     c.MkDir(),
+		MaxSize: 1 << 20,
+		Logger:  s.logger,
+		Metrics: &fakeCacheMetrics{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cache.Fetch(hash, filepath.Join(c.MkDir(), "dummy"), func(path string) error {
+		return os.WriteFile(path, data, 0644)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:dummy")
+	requestedOrigin := corecharm.Origin{
+		Source: corecharm.CharmHub,
+		Platform: corecharm.Platform{
+			Architecture: "amd64",
+		},
+	}
+	resolvedOrigin := requestedOrigin
+	resolvedOrigin.ID = "dummy-id"
+	resolvedOrigin.Hash = hash
+
+	s.storage.EXPECT().PrepareToStoreCharm(curl.String()).Return(nil)
+	s.repoGetter.EXPECT().GetCharmRepository(corecharm.CharmHub).Return(repoAdapter{s.repo}, nil)
+	// The charm is resolved, but not downloaded.
+	s.repo.EXPECT().GetDownloadURL(curl.Name, requestedOrigin).Return(&url.URL{}, resolvedOrigin, nil)
+	s.storage.EXPECT().Store(curl.String(), gomock.AssignableToTypeOf(downloader.DownloadedCharm{})).DoAndReturn(
+		func(_ string, dc downloader.DownloadedCharm) error {
+			c.Assert(dc.Charm.Meta().Name, gc.Equals, "dummy")
+			c.Assert(dc.SHA256, gc.Equals, hash)
+			contents, err := io.ReadAll(dc.CharmData)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(contents, jc.DeepEquals, data)
+			return nil
+		},
+	)
+
+	dl := downloader.NewDownloader(s.logger, s.storage, s.repoGetter, cache, corecharm.SigningPolicy{})
+	gotOrigin, err := dl.DownloadAndStore(curl, requestedOrigin, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotOrigin, gc.DeepEquals, resolvedOrigin)
+}
+
+func (s downloaderSuite) TestDownloadAndStoreSigned(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	signer, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.Sign(signer, corecharm.CharmSignatureNamespace, strings.NewReader("meshuggah\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := corecharm.NewSigningPolicy(keys, true, signatureSource{archiveDigest: sig})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:ubuntu-lite")
+	resolvedOrigin := s.expectDownload(c, curl)
+	s.storage.EXPECT().Store(curl.String(), gomock.AssignableToTypeOf(downloader.DownloadedCharm{})).Return(nil)
+
+	dl := downloader.NewDownloader(s.logger, s.storage, s.repoGetter, nil, policy)
+	gotOrigin, err := dl.DownloadAndStore(curl, corecharm.Origin{Source: corecharm.CharmHub}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotOrigin, gc.DeepEquals, resolvedOrigin)
+}
+
+func (s downloaderSuite) TestDownloadAndStoreSignatureMismatch(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	signer, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.Sign(signer, corecharm.CharmSignatureNamespace, strings.NewReader("tampered"))
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := corecharm.NewSigningPolicy(keys, false, signatureSource{archiveDigest: sig})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:ubuntu-lite")
+	s.expectDownload(c, curl)
+
+	dl := downloader.NewDownloader(s.logger, s.storage, s.repoGetter, nil, policy)
+	_, err = dl.DownloadAndStore(curl, corecharm.Origin{Source: corecharm.CharmHub}, false)
+	c.Assert(err, gc.ErrorMatches, `verifying downloaded charm "ubuntu-lite" from origin .*: signature of charm "ubuntu-lite" does not match: .*`)
+}
+
+func (s downloaderSuite) TestDownloadAndStoreUnsigned(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	_, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := corecharm.NewSigningPolicy(keys, true, signatureSource{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:ubuntu-lite")
+	s.expectDownload(c, curl)
+
+	dl := downloader.NewDownloader(s.logger, s.storage, s.repoGetter, nil, policy)
+	_, err = dl.DownloadAndStore(curl, corecharm.Origin{Source: corecharm.CharmHub}, false)
+	c.Assert(err, gc.ErrorMatches, `verifying downloaded charm "ubuntu-lite" from origin .*: charm "ubuntu-lite" is not signed, and the model only allows signed charms and resources`)
+}
+
+// expectDownload sets up the expectations for downloading a charm, with
+// the content "meshuggah\n", from the repository.
+func (s downloaderSuite) expectDownload(c *gc.C, curl *charm.URL) corecharm.Origin {
+	requestedOrigin := corecharm.Origin{
+		Source: corecharm.CharmHub,
+		Platform: corecharm.Platform{
+			Architecture: "amd64",
+		},
+	}
+	resolvedOrigin := requestedOrigin
+	resolvedOrigin.Hash = archiveDigest[len("sha256:"):]
+
+	s.storage.EXPECT().PrepareToStoreCharm(curl.String()).Return(nil)
+	s.repoGetter.EXPECT().GetCharmRepository(corecharm.CharmHub).Return(repoAdapter{s.repo}, nil)
+	s.repo.EXPECT().DownloadCharm(curl.Name, requestedOrigin, gomock.Any()).DoAndReturn(
+		func(_ string, requestedOrigin corecharm.Origin, archivePath string) (downloader.CharmArchive, corecharm.Origin, error) {
+			c.Assert(os.WriteFile(archivePath, []byte("meshuggah\n"), 0644), jc.ErrorIsNil)
+			return s.charmArchive, resolvedOrigin, nil
+		},
+	)
+	s.charmArchive.EXPECT().Meta().Return(&charm.Meta{
+		MinJujuVersion: version.MustParse("0.0.42"),
+	})
+	s.charmArchive.EXPECT().Version().Return("the-version")
+	s.charmArchive.EXPECT().LXDProfile().Return(nil)
+	return resolvedOrigin
+}
+
 func (s *downloaderSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.charmArchive = mocks.NewMockCharmArchive(ctrl)
@@ -260,7 +401,7 @@ func (s *downloaderSuite) setupMocks(c *gc.C) *gomock.Controller {
 }
 
 func (s *downloaderSuite) newDownloader() *downloader.Downloader {
-	return downloader.NewDownloader(s.logger, s.storage, s.repoGetter)
+	return downloader.NewDownloader(s.logger, s.storage, s.repoGetter, nil, corecharm.SigningPolicy{})
 }
 
 func mustParseChannel(c *gc.C, channel string) *charm.Channel {
@@ -289,3 +430,18 @@ func (r repoAdapter) ResolveWithPreferredChannel(charmName string, requestedOrig
 func (r repoAdapter) GetDownloadURL(charmName string, requestedOrigin corecharm.Origin) (*url.URL, corecharm.Origin, error) {
 	return r.repo.GetDownloadURL(charmName, requestedOrigin)
 }
+
+// archiveDigest is the digest of the charm archive downloaded by
+// expectDownload.
+const archiveDigest = "sha256:4e97ed7423be2ea12939e8fdd592cfb3dcd4d0097d7d193ef998ab6b4db70461"
+
+// signatureSource serves signatures keyed on the digest of the content
+// signed.
+type signatureSource map[string][]byte
+
+func (s signatureSource) Signature(_ context.Context, digest string) ([]byte, error) {
+	if sig, ok := s[digest]; ok {
+		return sig, nil
+	}
+	return nil, errors.NotFoundf("signature of %q", digest)
+}
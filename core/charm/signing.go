@@ -0,0 +1,174 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"context"
+	"io"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/pki/ssh"
+)
+
+const (
+	// CharmSignatureNamespace is the namespace charm archives are signed
+	// in, with "ssh-keygen -Y sign -n juju-charm".
+	CharmSignatureNamespace = "juju-charm"
+
+	// ResourceSignatureNamespace is the namespace resources are signed
+	// in, with "ssh-keygen -Y sign -n juju-resource". File resources are
+	// signed by their content, and OCI image resources by their image
+	// digest, such as "sha256:<hex>".
+	ResourceSignatureNamespace = "juju-resource"
+)
+
+// SignatureSource serves the detached signatures of charms and
+// resources, addressed by the digest of the content signed.
+type SignatureSource interface {
+	// Signature returns the armored SSH signature of the content with
+	// the given digest, in the form "<algorithm>:<hex digest>". A
+	// NotFound error is returned if the content isn't signed.
+	Signature(ctx context.Context, digest string) ([]byte, error)
+}
+
+// SigningPolicy determines which signatures of charms and resources
+// are trusted, and whether they must be signed.
+type SigningPolicy struct {
+	// Signers are the keys trusted to sign charms and resources.
+	Signers []ssh.AllowedSigner
+
+	// Required is true if charms and resources must be signed by one of
+	// the signers.
+	Required bool
+
+	// Source serves the signatures. Without a source, nothing is
+	// signed.
+	Source SignatureSource
+}
+
+// NewSigningPolicy returns a SigningPolicy trusting the signing keys,
+// which are in the OpenSSH allowed_signers format, and fetching the
+// signatures from source.
+func NewSigningPolicy(signingKeys string, required bool, source SignatureSource) (SigningPolicy, error) {
+	signers, err := ssh.ParseAllowedSigners(signingKeys)
+	if err != nil {
+		return SigningPolicy{}, errors.Trace(err)
+	}
+	if required && len(signers) == 0 {
+		return SigningPolicy{}, errors.NotValidf("requiring signatures without signing keys")
+	}
+	return SigningPolicy{
+		Signers:  signers,
+		Required: required,
+		Source:   source,
+	}, nil
+}
+
+// Enabled returns true if signatures should be checked.
+func (p SigningPolicy) Enabled() bool {
+	return len(p.Signers) > 0
+}
+
+// Signature returns the signature of the content with the given digest,
+// or nil if the policy isn't enabled or the content isn't signed. If the
+// signature can't be fetched, the content is treated as unsigned unless
+// signatures are required.
+func (p SigningPolicy) Signature(ctx context.Context, what, digest string) ([]byte, error) {
+	if !p.Enabled() || p.Source == nil || digest == "" {
+		return nil, nil
+	}
+	sig, err := p.Source.Signature(ctx, digest)
+	if err == nil {
+		return sig, nil
+	}
+	if !p.Required || errors.Is(err, errors.NotFound) {
+		return nil, nil
+	}
+	return nil, errors.Annotatef(err, "fetching signature of %s", what)
+}
+
+// CheckSigner checks that the signature, which may be nil if there is no
+// signature, is acceptable to the policy. It doesn't verify the signed
+// content, so is used to fail early, before the content is downloaded.
+func (p SigningPolicy) CheckSigner(what, namespace string, signature []byte) error {
+	_, err := p.signer(what, namespace, signature)
+	return errors.Trace(err)
+}
+
+// Verify verifies that the message is signed by the signature, which
+// may be nil if there is no signature. Unsigned messages, and messages
+// signed by untrusted keys, are only accepted when a signature isn't
+// required, but messages which don't match a trusted signature are
+// never accepted.
+func (p SigningPolicy) Verify(what, namespace string, signature []byte, message io.Reader) error {
+	v, err := p.NewVerifier(what, namespace, signature)
+	if err != nil || v == nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(v, message); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(v.Verify())
+}
+
+// NewVerifier returns a SignatureVerifier for verifying a streamed
+// message against the signature, which may be nil if there is no
+// signature. If the message doesn't need to be verified, nil is
+// returned.
+func (p SigningPolicy) NewVerifier(what, namespace string, signature []byte) (*SignatureVerifier, error) {
+	sig, err := p.signer(what, namespace, signature)
+	if err != nil || sig == nil {
+		return nil, errors.Trace(err)
+	}
+	v, err := sig.NewVerifier(p.Signers, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SignatureVerifier{what: what, verifier: v}, nil
+}
+
+// signer returns the parsed signature, if it was made by a trusted key,
+// or nil if the policy allows the signature to be ignored.
+func (p SigningPolicy) signer(what, namespace string, signature []byte) (*ssh.Signature, error) {
+	if !p.Enabled() {
+		return nil, nil
+	}
+	if signature == nil {
+		if p.Required {
+			return nil, errors.Unauthorizedf("%s is not signed, and the model only allows signed charms and resources", what)
+		}
+		return nil, nil
+	}
+	sig, err := ssh.ParseSignature(signature)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot verify signature of %s", what)
+	}
+	if _, err := sig.Signer(p.Signers, namespace); errors.Is(err, errors.Unauthorized) && !p.Required {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot verify signature of %s", what)
+	}
+	return sig, nil
+}
+
+// SignatureVerifier verifies the signature of a message written to it.
+type SignatureVerifier struct {
+	what     string
+	verifier *ssh.Verifier
+}
+
+// Write is part of the io.Writer interface.
+func (v *SignatureVerifier) Write(p []byte) (int, error) {
+	return v.verifier.Write(p)
+}
+
+// Verify returns an error if the signature isn't a signature of the
+// message written so far.
+func (v *SignatureVerifier) Verify() error {
+	if _, err := v.verifier.Verify(); err != nil {
+		return errors.Annotatef(err, "signature of %s does not match", v.what)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	cryptossh "golang.org/x/crypto/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charm"
+	"github.com/juju/juju/pki/ssh"
+	pkitest "github.com/juju/juju/pki/test"
+)
+
+type signingSuite struct {
+	testing.IsolationSuite
+
+	signer    cryptossh.Signer
+	untrusted cryptossh.Signer
+	keys      string
+}
+
+var _ = gc.Suite(&signingSuite{})
+
+func (s *signingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	var err error
+	s.signer, s.keys, err = pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	s.untrusted, _, err = pkitest.NewSSHSigner("mallory@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *signingSuite) sign(c *gc.C, signer cryptossh.Signer, message string) []byte {
+	sig, err := ssh.Sign(signer, charm.CharmSignatureNamespace, strings.NewReader(message))
+	c.Assert(err, jc.ErrorIsNil)
+	return sig
+}
+
+func (s *signingSuite) policy(c *gc.C, required bool) charm.SigningPolicy {
+	policy, err := charm.NewSigningPolicy(s.keys, required, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return policy
+}
+
+func (s *signingSuite) verify(policy charm.SigningPolicy, signature []byte, message string) error {
+	return policy.Verify(`charm "foo"`, charm.CharmSignatureNamespace, signature, strings.NewReader(message))
+}
+
+func (s *signingSuite) TestNewSigningPolicy(c *gc.C) {
+	policy, err := charm.NewSigningPolicy("", false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Enabled(), jc.IsFalse)
+
+	_, err = charm.NewSigningPolicy("", true, nil)
+	c.Assert(err, gc.ErrorMatches, "requiring signatures without signing keys not valid")
+
+	_, err = charm.NewSigningPolicy("bad", false, nil)
+	c.Assert(err, gc.ErrorMatches, `allowed signers line 1: .*`)
+
+	c.Assert(s.policy(c, false).Enabled(), jc.IsTrue)
+}
+
+type signatureSource struct {
+	sig []byte
+	err error
+}
+
+func (s signatureSource) Signature(context.Context, string) ([]byte, error) {
+	return s.sig, s.err
+}
+
+func (s *signingSuite) TestSignature(c *gc.C) {
+	policy := s.policy(c, false)
+	policy.Source = signatureSource{sig: []byte("signature")}
+	sig, err := policy.Signature(context.Background(), `charm "foo"`, "sha256:digest")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(sig), gc.Equals, "signature")
+
+	// Without a digest there's nothing to look up.
+	sig, err = policy.Signature(context.Background(), `charm "foo"`, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sig, gc.IsNil)
+}
+
+func (s *signingSuite) TestSignatureDisabled(c *gc.C) {
+	policy := charm.SigningPolicy{Source: signatureSource{sig: []byte("signature")}}
+	sig, err := policy.Signature(context.Background(), `charm "foo"`, "sha256:digest")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sig, gc.IsNil)
+}
+
+func (s *signingSuite) TestSignatureNoSource(c *gc.C) {
+	sig, err := s.policy(c, true).Signature(context.Background(), `charm "foo"`, "sha256:digest")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sig, gc.IsNil)
+}
+
+func (s *signingSuite) TestSignatureNotFound(c *gc.C) {
+	for _, required := range []bool{false, true} {
+		policy := s.policy(c, required)
+		policy.Source = signatureSource{err: errors.NotFoundf("signature")}
+		sig, err := policy.Signature(context.Background(), `charm "foo"`, "sha256:digest")
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(sig, gc.IsNil)
+	}
+}
+
+func (s *signingSuite) TestSignatureFailed(c *gc.C) {
+	policy := s.policy(c, false)
+	policy.Source = signatureSource{err: errors.New("boom")}
+	sig, err := policy.Signature(context.Background(), `charm "foo"`, "sha256:digest")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sig, gc.IsNil)
+
+	policy = s.policy(c, true)
+	policy.Source = signatureSource{err: errors.New("boom")}
+	_, err = policy.Signature(context.Background(), `charm "foo"`, "sha256:digest")
+	c.Assert(err, gc.ErrorMatches, `fetching signature of charm "foo": boom`)
+}
+
+func (s *signingSuite) TestVerifyDisabled(c *gc.C) {
+	var policy charm.SigningPolicy
+	c.Assert(s.verify(policy, nil, "archive"), jc.ErrorIsNil)
+	c.Assert(s.verify(policy, []byte("not a signature"), "archive"), jc.ErrorIsNil)
+}
+
+func (s *signingSuite) TestVerifySigned(c *gc.C) {
+	sig := s.sign(c, s.signer, "archive")
+	for _, required := range []bool{false, true} {
+		policy := s.policy(c, required)
+		c.Check(s.verify(policy, sig, "archive"), jc.ErrorIsNil)
+		err := s.verify(policy, sig, "tampered")
+		c.Check(err, gc.ErrorMatches, `signature of charm "foo" does not match: signature: ssh: signature did not verify`)
+	}
+}
+
+func (s *signingSuite) TestVerifyUnsigned(c *gc.C) {
+	c.Assert(s.verify(s.policy(c, false), nil, "archive"), jc.ErrorIsNil)
+
+	err := s.verify(s.policy(c, true), nil, "archive")
+	c.Assert(err, gc.ErrorMatches, `charm "foo" is not signed, and the model only allows signed charms and resources`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *signingSuite) TestVerifyUntrustedSigner(c *gc.C) {
+	sig := s.sign(c, s.untrusted, "archive")
+	c.Assert(s.verify(s.policy(c, false), sig, "tampered"), jc.ErrorIsNil)
+
+	err := s.verify(s.policy(c, true), sig, "archive")
+	c.Assert(err, gc.ErrorMatches, `cannot verify signature of charm "foo": signing key SHA256:.* not trusted`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *signingSuite) TestVerifyInvalidSignature(c *gc.C) {
+	err := s.verify(s.policy(c, false), []byte("not a signature"), "archive")
+	c.Assert(err, gc.ErrorMatches, `cannot verify signature of charm "foo": SSH signature not valid`)
+}
+
+func (s *signingSuite) TestCheckSigner(c *gc.C) {
+	policy := s.policy(c, true)
+	err := policy.CheckSigner(`charm "foo"`, charm.CharmSignatureNamespace, s.sign(c, s.signer, "archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = policy.CheckSigner(`charm "foo"`, charm.CharmSignatureNamespace, s.sign(c, s.untrusted, "archive"))
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	err = policy.CheckSigner(`charm "foo"`, charm.ResourceSignatureNamespace, s.sign(c, s.signer, "archive"))
+	c.Assert(err, gc.ErrorMatches, `cannot verify signature of charm "foo": signature namespace "juju-charm", expected "juju-resource", not valid`)
+}
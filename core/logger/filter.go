@@ -0,0 +1,440 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// Filter is a boolean expression which is evaluated against log records.
+//
+// Filters are written as comparisons, combined with "and", "or", "not"
+// and parentheses. A comparison is a field, an operator and a value:
+//
+//	message ~ "connection (refused|reset)"
+//	module = juju.worker.uniter
+//	entity = unit-mysql-*
+//	label = http
+//	label.app = mysql
+//	level >= WARNING
+//
+// The supported operators are "=", "!=", "~" (matches the regular
+// expression) and "!~" (does not match the regular expression). The
+// level field also supports "<", "<=", ">" and ">=". Values containing
+// spaces, parentheses or operator characters must be quoted, using
+// either double quotes (with Go escapes) or single quotes (verbatim).
+//
+// Module comparisons with "=" also match submodules, and entity
+// comparisons with "=" support a trailing '*' wildcard, consistent with
+// the include and exclude filters. The label field matches any of the
+// record's labels; "label.<key>" matches labels of the form key=value.
+type Filter interface {
+	// Match returns true if the record satisfies the filter.
+	Match(rec *LogRecord) bool
+
+	// String returns the canonical form of the filter.
+	String() string
+}
+
+// ParseFilter parses a filter expression.
+func ParseFilter(expr string) (Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing filter %q", expr)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errors.Errorf("parsing filter %q: unexpected %s", expr, tok)
+	}
+	return f, nil
+}
+
+type andFilter struct {
+	left, right Filter
+}
+
+func (f andFilter) Match(rec *LogRecord) bool {
+	return f.left.Match(rec) && f.right.Match(rec)
+}
+
+func (f andFilter) String() string {
+	return fmt.Sprintf("(%s and %s)", f.left, f.right)
+}
+
+type orFilter struct {
+	left, right Filter
+}
+
+func (f orFilter) Match(rec *LogRecord) bool {
+	return f.left.Match(rec) || f.right.Match(rec)
+}
+
+func (f orFilter) String() string {
+	return fmt.Sprintf("(%s or %s)", f.left, f.right)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f notFilter) Match(rec *LogRecord) bool {
+	return !f.filter.Match(rec)
+}
+
+func (f notFilter) String() string {
+	return fmt.Sprintf("not %s", f.filter)
+}
+
+// comparison compares a single field of a record with a value.
+type comparison struct {
+	field string
+	key   string
+	op    string
+	value string
+	re    *regexp.Regexp
+	level loggo.Level
+}
+
+func (f comparison) String() string {
+	field := f.field
+	if f.key != "" {
+		field += "." + f.key
+	}
+	return fmt.Sprintf("%s %s %s", field, f.op, strconv.Quote(f.value))
+}
+
+func (f comparison) Match(rec *LogRecord) bool {
+	if f.field == "level" {
+		return f.matchLevel(rec.Level)
+	}
+	candidates := f.candidates(rec)
+	switch f.op {
+	case "=":
+		return f.anyEqual(candidates)
+	case "!=":
+		return !f.anyEqual(candidates)
+	case "~":
+		return f.anyMatch(candidates)
+	case "!~":
+		return !f.anyMatch(candidates)
+	}
+	return false
+}
+
+func (f comparison) matchLevel(level loggo.Level) bool {
+	switch f.op {
+	case "=":
+		return level == f.level
+	case "!=":
+		return level != f.level
+	case "<":
+		return level < f.level
+	case "<=":
+		return level <= f.level
+	case ">":
+		return level > f.level
+	case ">=":
+		return level >= f.level
+	}
+	return false
+}
+
+// candidates returns the values of the record's field which are
+// compared with the filter value.
+func (f comparison) candidates(rec *LogRecord) []string {
+	switch f.field {
+	case "message":
+		return []string{rec.Message}
+	case "module":
+		return []string{rec.Module}
+	case "entity":
+		return []string{rec.Entity}
+	case "location":
+		return []string{rec.Location}
+	case "label":
+		if f.key == "" {
+			return rec.Labels
+		}
+		var values []string
+		prefix := f.key + "="
+		for _, label := range rec.Labels {
+			if strings.HasPrefix(label, prefix) {
+				values = append(values, strings.TrimPrefix(label, prefix))
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func (f comparison) anyEqual(candidates []string) bool {
+	for _, candidate := range candidates {
+		if f.equal(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f comparison) equal(candidate string) bool {
+	switch f.field {
+	case "module":
+		return candidate == f.value || strings.HasPrefix(candidate, f.value+".")
+	case "entity":
+		if strings.HasSuffix(f.value, "*") {
+			return strings.HasPrefix(candidate, strings.TrimSuffix(f.value, "*"))
+		}
+	}
+	return candidate == f.value
+}
+
+func (f comparison) anyMatch(candidates []string) bool {
+	for _, candidate := range candidates {
+		if f.re.MatchString(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		left = orFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		left = andFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	tok := p.peek()
+	switch {
+	case tok.isKeyword("not"):
+		p.next()
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return notFilter{filter: f}, nil
+	case tok.kind == tokenOpen:
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if tok := p.next(); tok.kind != tokenClose {
+			return nil, errors.Errorf("expected \")\", got %s", tok)
+		}
+		return f, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (Filter, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, errors.Errorf("expected field, got %s", fieldTok)
+	}
+	opTok := p.next()
+	if opTok.kind != tokenOp {
+		return nil, errors.Errorf("expected operator after %q, got %s", fieldTok.text, opTok)
+	}
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, errors.Errorf("expected value after %q, got %s", opTok.text, valueTok)
+	}
+
+	f := comparison{
+		field: strings.ToLower(fieldTok.text),
+		op:    opTok.text,
+		value: valueTok.text,
+	}
+	if strings.HasPrefix(f.field, "label.") {
+		f.field, f.key = "label", strings.TrimPrefix(fieldTok.text, "label.")
+		if f.key == "" {
+			return nil, errors.Errorf("empty label key")
+		}
+	}
+
+	switch f.field {
+	case "level":
+		level, ok := loggo.ParseLevel(f.value)
+		if !ok || level < loggo.TRACE || level > loggo.ERROR {
+			return nil, errors.Errorf("level value %q is not one of %q, %q, %q, %q, %q",
+				f.value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		}
+		f.level = level
+		return f, nil
+	case "message", "module", "entity", "location", "label":
+	default:
+		return nil, errors.Errorf("unknown field %q", fieldTok.text)
+	}
+
+	switch f.op {
+	case "=", "!=":
+	case "~", "!~":
+		re, err := regexp.Compile(f.value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid regular expression for %q", fieldTok.text)
+		}
+		f.re = re
+	default:
+		return nil, errors.Errorf("operator %q not supported for %q", f.op, fieldTok.text)
+	}
+	return f, nil
+}
+
+type filterTokenKind int
+
+const (
+	tokenEOF filterTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenOpen
+	tokenClose
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (t filterToken) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+const filterOpChars = "=!~<>"
+
+func isFilterWordChar(r rune) bool {
+	return !unicode.IsSpace(r) && r != '(' && r != ')' && r != '"' && r != '\'' &&
+		!strings.ContainsRune(filterOpChars, r)
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenOpen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenClose, text: ")"})
+			i++
+		case r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.Errorf("unterminated quoted string in filter %q", expr)
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: string(runes[i+1 : end])})
+			i = end + 1
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, errors.Errorf("unterminated quoted string in filter %q", expr)
+			}
+			text, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid quoted string in filter %q", expr)
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: text})
+			i = end + 1
+		case strings.ContainsRune(filterOpChars, r):
+			end := i + 1
+			for end < len(runes) && strings.ContainsRune(filterOpChars, runes[end]) {
+				end++
+			}
+			op := string(runes[i:end])
+			switch op {
+			case "=", "!=", "~", "!~", "<", "<=", ">", ">=":
+			default:
+				return nil, errors.Errorf("unknown operator %q in filter %q", op, expr)
+			}
+			tokens = append(tokens, filterToken{kind: tokenOp, text: op})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && isFilterWordChar(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF}), nil
+}
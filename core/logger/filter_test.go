@@ -0,0 +1,104 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logger_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logger"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+var filterRecord = &logger.LogRecord{
+	Entity:   "unit-mysql-0",
+	Level:    loggo.WARNING,
+	Module:   "juju.worker.uniter.operation",
+	Location: "executor.go:42",
+	Message:  "connection refused (retrying)",
+	Labels:   []string{"http", "app=mysql"},
+}
+
+func (s *FilterSuite) TestMatch(c *gc.C) {
+	for i, test := range []struct {
+		expr  string
+		match bool
+	}{
+		{`message = "connection refused (retrying)"`, true},
+		{`message != "connection refused (retrying)"`, false},
+		{`message ~ "refused|reset"`, true},
+		{`message ~ '^connection'`, true},
+		{`message !~ refused`, false},
+		{`module = juju.worker.uniter`, true},
+		{`module = juju.worker.unit`, false},
+		{`module != juju.worker`, false},
+		{`entity = unit-mysql-*`, true},
+		{`entity = unit-mysql-1`, false},
+		{`entity ~ "^machine-"`, false},
+		{`location ~ "\\.go:42$"`, true},
+		{`label = http`, true},
+		{`label = metrics`, false},
+		{`label != metrics`, true},
+		{`label.app = mysql`, true},
+		{`label.app = wordpress`, false},
+		{`label.app ~ "^my"`, true},
+		{`label.unit = mysql/0`, false},
+		{`level = WARNING`, true},
+		{`level >= INFO`, true},
+		{`level > WARNING`, false},
+		{`level <= DEBUG`, false},
+		{`level < error`, true},
+		{`message ~ refused and level >= ERROR`, false},
+		{`message ~ refused or level >= ERROR`, true},
+		{`not label = http`, false},
+		{`NOT (label = metrics or module = juju.apiserver)`, true},
+		{`(entity = machine-0 or entity = unit-mysql-*) and not message ~ timeout`, true},
+		{`entity = machine-0 or entity = unit-wordpress-* and level >= INFO`, false},
+		{`label = metrics and level >= INFO or module = juju.worker`, true},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		f, err := logger.ParseFilter(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(f.Match(filterRecord), gc.Equals, test.match)
+	}
+}
+
+func (s *FilterSuite) TestString(c *gc.C) {
+	f, err := logger.ParseFilter(`message ~ 'a b' and not (label.app = mysql or level >= warning)`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.String(), gc.Equals, `(message ~ "a b" and not (label.app = "mysql" or level >= "warning"))`)
+}
+
+func (s *FilterSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{
+		{``, `parsing filter "": expected field, got end of filter`},
+		{`message`, `parsing filter "message": expected operator after "message", got end of filter`},
+		{`message =`, `parsing filter "message =": expected value after "=", got end of filter`},
+		{`colour = red`, `parsing filter "colour = red": unknown field "colour"`},
+		{`message == x`, `unknown operator "==" in filter "message == x"`},
+		{`message > x`, `parsing filter "message > x": operator ">" not supported for "message"`},
+		{`message ~ "("`, `parsing filter .*: invalid regular expression for "message": .*`},
+		{`level = LOUD`, `parsing filter "level = LOUD": level value "LOUD" is not one of .*`},
+		{`label. = x`, `parsing filter "label. = x": empty label key`},
+		{`(module = a`, `parsing filter "\(module = a": expected "\)", got end of filter`},
+		{`module = a)`, `parsing filter "module = a\)": unexpected "\)"`},
+		{`module = a b = c`, `parsing filter "module = a b = c": unexpected "b"`},
+		{`message = "abc`, `unterminated quoted string in filter "message = \\"abc"`},
+		{`message = 'abc`, `unterminated quoted string in filter "message = 'abc"`},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := logger.ParseFilter(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -43,4 +43,18 @@ type LogTailerParams struct {
 	ExcludeModule []string
 	IncludeLabel  []string
 	ExcludeLabel  []string
+
+	// EndTime, if set, limits the records to those logged at or
+	// before this time. Tailing stops at the first record logged
+	// after it.
+	EndTime time.Time
+
+	// MessageRegex, if set, limits the records to those whose message
+	// matches this regular expression, in Go's syntax. Like Filter, it
+	// is applied before InitialLines are counted.
+	MessageRegex string
+
+	// Filter, if set, limits the records to those it matches. It is
+	// applied before InitialLines are counted.
+	Filter Filter
 }
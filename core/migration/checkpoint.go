@@ -0,0 +1,42 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+// Checkpoint records the progress of a model migration so that a
+// migration which fails in a resumable phase can be resumed, rather
+// than aborted and started again. Resumed phases skip the steps
+// recorded as done; a step which was interrupted is started again.
+type Checkpoint struct {
+	// FailedPhase holds the phase which failed, if the migration is
+	// waiting to be resumed. It is UNKNOWN otherwise.
+	FailedPhase Phase
+
+	// Imported is true once the model has been imported into the
+	// target controller.
+	Imported bool
+
+	// Charms holds the URLs of the charms uploaded to the target
+	// controller.
+	Charms []string
+
+	// Tools holds the versions of the agent binaries uploaded to the
+	// target controller.
+	Tools []string
+
+	// Resources holds the application resources, as
+	// "application/resource", uploaded to the target controller.
+	Resources []string
+
+	// LogsSent holds the number of log records transferred to the
+	// target controller, for reporting progress. It doesn't determine
+	// where log transfer carries on from, which is the latest record
+	// held by the target controller.
+	LogsSent int
+}
+
+// IsFailed returns true if the migration failed in a resumable phase
+// and is waiting to be resumed or aborted.
+func (c Checkpoint) IsFailed() bool {
+	return c.FailedPhase != UNKNOWN
+}
@@ -32,6 +32,9 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Checkpoint holds the progress made by the migration.
+	Checkpoint Checkpoint
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -103,6 +103,19 @@ func (p Phase) IsRunning() bool {
 	}
 }
 
+// IsResumable returns true if a failure in the phase leaves the
+// migration waiting to be resumed or aborted, rather than aborting it.
+// The work done by these phases can be retried without first removing
+// the model from the target controller.
+func (p Phase) IsResumable() bool {
+	switch p {
+	case IMPORT, PROCESSRELATIONS:
+		return true
+	default:
+		return false
+	}
+}
+
 // Define all possible phase transitions.
 //
 // The keys are the "from" states and the values enumerate the
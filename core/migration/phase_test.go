@@ -72,6 +72,17 @@ func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 	c.Check(migration.ABORTDONE.IsRunning(), jc.IsFalse)
 }
 
+func (s *PhaseSuite) TestIsResumable(c *gc.C) {
+	c.Check(migration.IMPORT.IsResumable(), jc.IsTrue)
+	c.Check(migration.PROCESSRELATIONS.IsResumable(), jc.IsTrue)
+
+	c.Check(migration.QUIESCE.IsResumable(), jc.IsFalse)
+	c.Check(migration.VALIDATION.IsResumable(), jc.IsFalse)
+	c.Check(migration.SUCCESS.IsResumable(), jc.IsFalse)
+	c.Check(migration.LOGTRANSFER.IsResumable(), jc.IsFalse)
+	c.Check(migration.ABORT.IsResumable(), jc.IsFalse)
+}
+
 func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.SUCCESS), jc.IsFalse)
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.ABORT), jc.IsTrue)
@@ -0,0 +1,236 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// Scope identifies the entity that a rate limit rule is applied to.
+type Scope string
+
+const (
+	// ScopeUser applies a rule to each user separately.
+	ScopeUser Scope = "user"
+
+	// ScopeModel applies a rule to each model separately, regardless
+	// of which user is making the calls.
+	ScopeModel Scope = "model"
+)
+
+// Wildcard matches any facade or method name in a rule.
+const Wildcard = "*"
+
+// maxBuckets is the number of token buckets held by a limiter before
+// it discards those which are full. A full bucket is equivalent to a
+// new one, so discarding them doesn't change the limits applied.
+const maxBuckets = 10000
+
+// Rule is a token bucket limit on calls to facade methods.
+//
+// Rules are written as:
+//
+//	<scope>:<facade>.<method>=<count>/<period>
+//
+// For example "user:Client.FullStatus=10/1m" allows each user to make
+// up to 10 FullStatus calls in a burst, refilling at 10 calls per
+// minute. Either the facade or method may be "*" to match any name; a
+// rule matching more than one method limits each method separately.
+// The period is a Go duration, or a unit without a number ("s", "m" or
+// "h") meaning one of that unit.
+type Rule struct {
+	Scope  Scope
+	Facade string
+	Method string
+	Count  int
+	Period time.Duration
+}
+
+// ParseRule parses a rate limit rule.
+func ParseRule(s string) (Rule, error) {
+	scope, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		return Rule{}, errors.NotValidf("rate limit rule %q: missing scope", s)
+	}
+	target, limit, ok := strings.Cut(rest, "=")
+	if !ok {
+		return Rule{}, errors.NotValidf("rate limit rule %q: missing limit", s)
+	}
+	facade, method, ok := strings.Cut(target, ".")
+	if !ok || facade == "" || method == "" {
+		return Rule{}, errors.NotValidf("rate limit rule %q: expected Facade.Method", s)
+	}
+	count, period, ok := strings.Cut(limit, "/")
+	if !ok {
+		return Rule{}, errors.NotValidf("rate limit rule %q: expected count/period", s)
+	}
+
+	r := Rule{
+		Scope:  Scope(scope),
+		Facade: facade,
+		Method: method,
+	}
+	switch r.Scope {
+	case ScopeUser, ScopeModel:
+	default:
+		return Rule{}, errors.NotValidf("rate limit rule %q: scope %q", s, scope)
+	}
+	var err error
+	if r.Count, err = strconv.Atoi(count); err != nil || r.Count < 1 {
+		return Rule{}, errors.NotValidf("rate limit rule %q: count %q", s, count)
+	}
+	if period != "" && unicode.IsLetter(rune(period[0])) {
+		period = "1" + period
+	}
+	if r.Period, err = time.ParseDuration(period); err != nil || r.Period <= 0 {
+		return Rule{}, errors.NotValidf("rate limit rule %q: period %q", s, period)
+	}
+	return r, nil
+}
+
+// String returns the rule in the form accepted by ParseRule.
+func (r Rule) String() string {
+	return fmt.Sprintf("%s:%s.%s=%d/%s", r.Scope, r.Facade, r.Method, r.Count, r.Period)
+}
+
+// Matches returns true if the rule applies to the facade method.
+func (r Rule) Matches(facade, method string) bool {
+	return (r.Facade == Wildcard || r.Facade == facade) &&
+		(r.Method == Wildcard || r.Method == method)
+}
+
+// Limiter applies a set of rules to facade method calls.
+type Limiter struct {
+	rules []Rule
+	clock clock.Clock
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter returns a limiter that applies the given rules.
+func NewLimiter(rules []Rule, clock clock.Clock) *Limiter {
+	return &Limiter{
+		rules:   rules,
+		clock:   clock,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Rules returns the rules applied by the limiter.
+func (l *Limiter) Rules() []Rule {
+	return l.rules
+}
+
+// Allow takes a token from each bucket that applies to a call, by the
+// user in the model, to the facade method. If any bucket is empty the
+// call is not allowed, no tokens are taken, and the returned duration
+// is how long the caller should wait before every empty bucket has a
+// token again.
+func (l *Limiter) Allow(user, model, facade, method string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if len(l.buckets) > maxBuckets {
+		l.prune(now)
+	}
+
+	// Check all the buckets before taking from any of them, so that a
+	// rejected call doesn't use up the limits of the others.
+	var (
+		buckets    []*bucket
+		retryAfter time.Duration
+	)
+	for i, rule := range l.rules {
+		if !rule.Matches(facade, method) {
+			continue
+		}
+		id := user
+		if rule.Scope == ScopeModel {
+			id = model
+		}
+		key := fmt.Sprintf("%d:%s:%s.%s", i, id, facade, method)
+		b, ok := l.buckets[key]
+		if !ok {
+			b = newBucket(rule, now)
+			l.buckets[key] = b
+		}
+		if wait := b.wait(now); wait > retryAfter {
+			retryAfter = wait
+		}
+		buckets = append(buckets, b)
+	}
+	if retryAfter > 0 {
+		return retryAfter, false
+	}
+	for _, b := range buckets {
+		b.take(now)
+	}
+	return 0, true
+}
+
+// prune discards buckets which are full.
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// bucket is a token bucket which, unlike those in juju/ratelimit,
+// can report how long it will be until a token is available without
+// taking one. Rather than counting tokens it records when the bucket
+// will next be full, so all the arithmetic is in whole durations.
+type bucket struct {
+	// interval is the time taken to add one token.
+	interval time.Duration
+	// period is the time taken to fill an empty bucket.
+	period time.Duration
+	// fullAt is the time at which the bucket will be full.
+	fullAt time.Time
+}
+
+func newBucket(rule Rule, now time.Time) *bucket {
+	interval := rule.Period / time.Duration(rule.Count)
+	return &bucket{
+		interval: interval,
+		period:   interval * time.Duration(rule.Count),
+		fullAt:   now,
+	}
+}
+
+// wait returns how long it will be until the bucket has a token, or
+// zero if it has one now.
+func (b *bucket) wait(now time.Time) time.Duration {
+	// The bucket has a token once it's no more than one token short
+	// of being full.
+	if wait := b.fullAt.Sub(now) - (b.period - b.interval); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// take removes a token from the bucket, which must have one.
+func (b *bucket) take(now time.Time) {
+	if b.fullAt.Before(now) {
+		b.fullAt = now
+	}
+	b.fullAt = b.fullAt.Add(b.interval)
+}
+
+// full returns true if the bucket is at capacity, in which case it's
+// equivalent to a new bucket.
+func (b *bucket) full(now time.Time) bool {
+	return !b.fullAt.After(now)
+}
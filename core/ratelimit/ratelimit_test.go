@@ -0,0 +1,197 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ratelimit_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/ratelimit"
+)
+
+type RateLimitSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RateLimitSuite{})
+
+func (s *RateLimitSuite) TestParseRule(c *gc.C) {
+	for i, test := range []struct {
+		rule     string
+		expected ratelimit.Rule
+		str      string
+	}{{
+		rule: "user:Client.FullStatus=10/1m",
+		expected: ratelimit.Rule{
+			Scope: ratelimit.ScopeUser, Facade: "Client", Method: "FullStatus", Count: 10, Period: time.Minute,
+		},
+		str: "user:Client.FullStatus=10/1m0s",
+	}, {
+		rule: "model:Client.*=100/s",
+		expected: ratelimit.Rule{
+			Scope: ratelimit.ScopeModel, Facade: "Client", Method: "*", Count: 100, Period: time.Second,
+		},
+		str: "model:Client.*=100/1s",
+	}, {
+		rule: "user:*.*=5/500ms",
+		expected: ratelimit.Rule{
+			Scope: ratelimit.ScopeUser, Facade: "*", Method: "*", Count: 5, Period: 500 * time.Millisecond,
+		},
+		str: "user:*.*=5/500ms",
+	}} {
+		c.Logf("test %d: %s", i, test.rule)
+		r, err := ratelimit.ParseRule(test.rule)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(r, jc.DeepEquals, test.expected)
+		c.Check(r.String(), gc.Equals, test.str)
+	}
+}
+
+func (s *RateLimitSuite) TestParseRuleErrors(c *gc.C) {
+	for i, test := range []struct {
+		rule string
+		err  string
+	}{
+		{"Client.FullStatus=10/1m", `rate limit rule "Client.FullStatus=10/1m": missing scope not valid`},
+		{"user:Client.FullStatus", `rate limit rule "user:Client.FullStatus": missing limit not valid`},
+		{"user:FullStatus=10/1m", `rate limit rule "user:FullStatus=10/1m": expected Facade.Method not valid`},
+		{"user:Client.FullStatus=10", `rate limit rule "user:Client.FullStatus=10": expected count/period not valid`},
+		{"app:Client.FullStatus=10/1m", `rate limit rule "app:Client.FullStatus=10/1m": scope "app" not valid`},
+		{"user:Client.FullStatus=0/1m", `rate limit rule "user:Client.FullStatus=0/1m": count "0" not valid`},
+		{"user:Client.FullStatus=10/fortnight", `rate limit rule "user:Client.FullStatus=10/fortnight": period "1fortnight" not valid`},
+		{"user:Client.FullStatus=10/-1s", `rate limit rule "user:Client.FullStatus=10/-1s": period "-1s" not valid`},
+	} {
+		c.Logf("test %d: %s", i, test.rule)
+		_, err := ratelimit.ParseRule(test.rule)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RateLimitSuite) TestMatches(c *gc.C) {
+	r := ratelimit.Rule{Facade: "Client", Method: "*"}
+	c.Check(r.Matches("Client", "FullStatus"), jc.IsTrue)
+	c.Check(r.Matches("Application", "Deploy"), jc.IsFalse)
+
+	r = ratelimit.Rule{Facade: "*", Method: "FullStatus"}
+	c.Check(r.Matches("Client", "FullStatus"), jc.IsTrue)
+	c.Check(r.Matches("Client", "Status"), jc.IsFalse)
+}
+
+func (s *RateLimitSuite) TestAllowPerUser(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	l := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "Client", Method: "FullStatus", Count: 2, Period: time.Minute,
+	}}, clock)
+
+	for i := 0; i < 2; i++ {
+		_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+		c.Assert(ok, jc.IsTrue)
+	}
+	retryAfter, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(retryAfter, gc.Equals, 30*time.Second)
+
+	// Other users, and other methods, are not affected.
+	_, ok = l.Allow("mary", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+	_, ok = l.Allow("bob", "model-1", "Client", "Status")
+	c.Assert(ok, jc.IsTrue)
+
+	clock.Advance(retryAfter)
+	_, ok = l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *RateLimitSuite) TestAllowPerModel(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	l := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeModel, Facade: "Client", Method: "*", Count: 1, Period: time.Second,
+	}}, clock)
+
+	_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+	_, ok = l.Allow("mary", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsFalse)
+
+	// Wildcard rules limit each method separately.
+	_, ok = l.Allow("mary", "model-1", "Client", "Status")
+	c.Assert(ok, jc.IsTrue)
+	_, ok = l.Allow("mary", "model-2", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *RateLimitSuite) TestAllowMostRestrictiveRule(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	l := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "*", Method: "*", Count: 10, Period: time.Second,
+	}, {
+		Scope: ratelimit.ScopeModel, Facade: "Client", Method: "FullStatus", Count: 1, Period: time.Minute,
+	}}, clock)
+
+	_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+	retryAfter, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(retryAfter, gc.Equals, time.Minute)
+}
+
+func (s *RateLimitSuite) TestRetryAfterIsTimeUntilRefill(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	l := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "Client", Method: "FullStatus", Count: 2, Period: time.Minute,
+	}, {
+		Scope: ratelimit.ScopeModel, Facade: "Client", Method: "FullStatus", Count: 1, Period: 10 * time.Second,
+	}}, clock)
+
+	_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+	clock.Advance(10 * time.Second)
+	_, ok = l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+
+	// Both buckets are now empty. The user bucket has been refilling
+	// for 10s, so is 20s from its next token, which is later than the
+	// model bucket's.
+	clock.Advance(4 * time.Second)
+	retryAfter, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(retryAfter, gc.Equals, 16*time.Second)
+
+	// Retrying after that long succeeds.
+	clock.Advance(retryAfter)
+	_, ok = l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *RateLimitSuite) TestAllowRejectedTakesNoTokens(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	l := ratelimit.NewLimiter([]ratelimit.Rule{{
+		Scope: ratelimit.ScopeUser, Facade: "Client", Method: "FullStatus", Count: 2, Period: time.Minute,
+	}, {
+		Scope: ratelimit.ScopeModel, Facade: "Client", Method: "FullStatus", Count: 1, Period: time.Minute,
+	}}, clock)
+
+	_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+
+	// Calls rejected by the model limit don't use bob's user limit.
+	for i := 0; i < 3; i++ {
+		_, ok = l.Allow("bob", "model-1", "Client", "FullStatus")
+		c.Assert(ok, jc.IsFalse)
+	}
+	_, ok = l.Allow("bob", "model-2", "Client", "FullStatus")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *RateLimitSuite) TestAllowNoRules(c *gc.C) {
+	l := ratelimit.NewLimiter(nil, testclock.NewClock(time.Now()))
+	for i := 0; i < 100; i++ {
+		_, ok := l.Allow("bob", "model-1", "Client", "FullStatus")
+		c.Assert(ok, jc.IsTrue)
+	}
+}
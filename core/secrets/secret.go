@@ -197,6 +197,11 @@ type SecretRevisionMetadata struct {
 	CreateTime  time.Time
 	UpdateTime  time.Time
 	ExpireTime  *time.Time
+	// Author is the tag of the entity which created the revision.
+	Author string
+	// Rotated is true if the revision was created in response to
+	// the secret being due for rotation.
+	Rotated bool
 }
 
 // SecretOwnerMetadata holds a secret metadata and any backend references of revisions.
@@ -36,6 +36,14 @@ func (r *ValueRef) String() string {
 	return fmt.Sprintf("%s:%s", r.BackendID, r.RevisionID)
 }
 
+// WrappedKey is a data key with which a secret backend encrypts
+// secret content, itself encrypted by one of the backend's master
+// keys.
+type WrappedKey struct {
+	MasterKeyID string
+	Key         []byte
+}
+
 // NextBackendRotateTime returns the next time a token rotate is due,
 // given the supplied rotate interval.
 func NextBackendRotateTime(now time.Time, rotateInterval time.Duration) (*time.Time, error) {
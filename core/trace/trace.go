@@ -0,0 +1,67 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package trace provides helpers for instrumenting Juju with
+// OpenTelemetry spans.
+//
+// Spans are created using the global tracer provider, which is a no-op
+// until an agent's tracer worker installs an exporting provider. Code
+// can therefore create spans unconditionally; when tracing is disabled
+// the cost is negligible.
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer used for Juju spans.
+const InstrumentationName = "github.com/juju/juju"
+
+// traceParentKey is the W3C trace context header holding the
+// propagated span.
+const traceParentKey = "traceparent"
+
+var propagator = propagation.TraceContext{}
+
+// Span is a single operation within a trace.
+type Span = trace.Span
+
+// Start starts a span with the given name and attributes, as a child
+// of any span held in ctx. The returned context holds the new span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, first recording err against it if it is not nil.
+func End(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent value describing the span
+// held in ctx, or the empty string if ctx holds no valid span.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(traceParentKey)
+}
+
+// WithTraceParent returns a copy of ctx holding the remote span
+// described by the W3C traceparent value. If the value is empty or
+// invalid, ctx is returned unchanged.
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{traceParentKey: traceParent}
+	return propagator.Extract(ctx, carrier)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+)
+
+type TraceSuite struct {
+	testing.IsolationSuite
+
+	recorder *tracetest.SpanRecorder
+}
+
+var _ = gc.Suite(&TraceSuite{})
+
+func (s *TraceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.recorder = tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder))
+	otel.SetTracerProvider(provider)
+	s.AddCleanup(func(*gc.C) {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		_ = provider.Shutdown(context.Background())
+	})
+}
+
+func (s *TraceSuite) TestStartEnd(c *gc.C) {
+	ctx, parent := trace.Start(context.Background(), "parent")
+	_, child := trace.Start(ctx, "child")
+	trace.End(child, errors.New("boom"))
+	trace.End(parent, nil)
+
+	spans := s.recorder.Ended()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[0].Name(), gc.Equals, "child")
+	c.Check(spans[0].Parent().SpanID(), gc.Equals, spans[1].SpanContext().SpanID())
+	c.Check(spans[0].Status().Code, gc.Equals, codes.Error)
+	c.Check(spans[0].Status().Description, gc.Equals, "boom")
+	c.Check(spans[1].Name(), gc.Equals, "parent")
+	c.Check(spans[1].Status().Code, gc.Equals, codes.Unset)
+}
+
+func (s *TraceSuite) TestTraceParentRoundTrip(c *gc.C) {
+	ctx, span := trace.Start(context.Background(), "client")
+	traceParent := trace.TraceParent(ctx)
+	c.Assert(traceParent, gc.Matches, `00-[0-9a-f]{32}-[0-9a-f]{16}-01`)
+
+	remote := trace.WithTraceParent(context.Background(), traceParent)
+	_, child := trace.Start(remote, "server")
+	child.End()
+	span.End()
+
+	spans := s.recorder.Ended()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[0].Parent().IsRemote(), jc.IsTrue)
+	c.Check(spans[0].SpanContext().TraceID(), gc.Equals, spans[1].SpanContext().TraceID())
+	c.Check(spans[0].Parent().SpanID(), gc.Equals, spans[1].SpanContext().SpanID())
+}
+
+func (s *TraceSuite) TestTraceParentNoSpan(c *gc.C) {
+	c.Assert(trace.TraceParent(context.Background()), gc.Equals, "")
+}
+
+func (s *TraceSuite) TestWithTraceParentInvalid(c *gc.C) {
+	for _, value := range []string{"", "rubbish"} {
+		ctx := trace.WithTraceParent(context.Background(), value)
+		c.Check(oteltrace.SpanContextFromContext(ctx).IsValid(), jc.IsFalse)
+	}
+}
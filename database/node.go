@@ -36,8 +36,18 @@ const (
 	dqliteDataDir         = "dqlite"
 	dqlitePort            = 17666
 	dqliteClusterFileName = "cluster.yaml"
+	dqliteRestoreDir      = "dqlite-restore"
+	dqlitePreRestoreDir   = "dqlite.pre-restore"
 )
 
+// StagedRestoreDir returns the directory under the input agent data
+// directory, into which the Dqlite data restored from a backup is
+// staged. The staged data is applied by NodeManager.ApplyStagedRestore
+// before the local Dqlite node is next started.
+func StagedRestoreDir(dataDir string) string {
+	return filepath.Join(dataDir, dqliteRestoreDir)
+}
+
 // NodeManager is responsible for interrogating a single Dqlite node,
 // and emitting configuration for starting its Dqlite `App` based on
 // operational requirements and controller agent config.
@@ -140,6 +150,61 @@ func (m *NodeManager) SetClusterToLocalNode(ctx context.Context) error {
 	return errors.Trace(m.SetClusterServers(ctx, []dqlite.NodeInfo{node}))
 }
 
+// ApplyStagedRestore replaces the Dqlite data directory with the data
+// staged by a backup restore, if there is any, and reports whether it
+// did so. The restored node keeps the ID recorded in the backup, but
+// takes the address of the local node and becomes the only member of
+// the cluster. The replaced data is kept alongside, for recovery.
+// This should only be called on a stopped Dqlite node.
+func (m *NodeManager) ApplyStagedRestore(ctx context.Context) (bool, error) {
+	staged := StagedRestoreDir(m.cfg.DataDir())
+	if _, err := os.Stat(staged); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "checking for staged Dqlite restore")
+	}
+
+	dataDir, err := m.EnsureDataDir()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	// A node that has never been started has no address of its own,
+	// in which case the address recorded in the backup is kept.
+	local, err := m.NodeInfo()
+	hasLocal := err == nil
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return false, errors.Trace(err)
+	}
+
+	previous := filepath.Join(m.cfg.DataDir(), dqlitePreRestoreDir)
+	if err := os.RemoveAll(previous); err != nil {
+		return false, errors.Annotate(err, "removing previous Dqlite data")
+	}
+	if err := os.Rename(dataDir, previous); err != nil {
+		return false, errors.Annotate(err, "moving aside current Dqlite data")
+	}
+	if err := os.Rename(staged, dataDir); err != nil {
+		return false, errors.Annotate(err, "moving staged Dqlite data into place")
+	}
+
+	node, err := m.NodeInfo()
+	if err != nil {
+		return false, errors.Annotate(err, "reading restored node info")
+	}
+	if hasLocal {
+		node.Address = local.Address
+	}
+	if err := m.SetNodeInfo(node); err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := m.SetClusterServers(ctx, []dqlite.NodeInfo{node}); err != nil {
+		return false, errors.Trace(err)
+	}
+	m.logger.Warningf("applied restored Dqlite data as node %d at %s", node.ID, node.Address)
+	return true, nil
+}
+
 // ClusterServers returns the node information for
 // Dqlite nodes configured to be in the cluster.
 func (m *NodeManager) ClusterServers(ctx context.Context) ([]dqlite.NodeInfo, error) {
@@ -282,6 +282,56 @@ func (s *nodeManagerSuite) TestSetClusterToLocalNodeSuccess(c *gc.C) {
 	c.Check(newServers, gc.DeepEquals, []dqlite.NodeInfo{servers[0]})
 }
 
+func (s *nodeManagerSuite) TestApplyStagedRestoreNothingStaged(c *gc.C) {
+	cfg := fakeAgentConfig{dataDir: c.MkDir()}
+	m := NewNodeManager(cfg, stubLogger{}, coredatabase.NoopSlowQueryLogger{})
+
+	applied, err := m.ApplyStagedRestore(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(applied, jc.IsFalse)
+}
+
+func (s *nodeManagerSuite) TestApplyStagedRestoreSuccess(c *gc.C) {
+	cfg := fakeAgentConfig{dataDir: c.MkDir()}
+	m := NewNodeManager(cfg, stubLogger{}, coredatabase.NoopSlowQueryLogger{})
+	ctx := context.Background()
+
+	_, err := m.EnsureDataDir()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetNodeInfo(dqlite.NodeInfo{ID: 1, Address: "10.6.6.6:17666"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Stage the data of a node from another controller.
+	staged := StagedRestoreDir(cfg.DataDir())
+	err = os.MkdirAll(staged, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	data := []byte(`
+Address: 10.9.9.9:17666
+ID: 3297041220608546238
+Role: 0
+`[1:])
+	err = os.WriteFile(path.Join(staged, "info.yaml"), data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	applied, err := m.ApplyStagedRestore(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(applied, jc.IsTrue)
+
+	expected := dqlite.NodeInfo{ID: 3297041220608546238, Address: "10.6.6.6:17666"}
+	node, err := m.NodeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(node, jc.DeepEquals, expected)
+
+	servers, err := m.ClusterServers(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(servers, jc.DeepEquals, []dqlite.NodeInfo{expected})
+
+	_, err = os.Stat(staged)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+	_, err = os.Stat(path.Join(cfg.DataDir(), dqlitePreRestoreDir, "info.yaml"))
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func (s *nodeManagerSuite) TestWithAddressOptionIPv4Success(c *gc.C) {
 	m := NewNodeManager(nil, stubLogger{}, coredatabase.NoopSlowQueryLogger{})
 	m.port = dqlitetesting.FindTCPPort(c)
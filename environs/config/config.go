@@ -32,7 +32,9 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/pki/ssh"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -207,6 +209,26 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPEndpoint sets the URL of the HTTP endpoint to which log
+	// records are posted as JSON lines.
+	LogFwdHTTPEndpoint = "logforward-http-endpoint"
+
+	// LogFwdHTTPHeaders sets additional headers, as comma separated
+	// name=value pairs, sent with each HTTP log forwarding request.
+	LogFwdHTTPHeaders = "logforward-http-headers"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records sent
+	// in a single HTTP log forwarding request.
+	LogFwdHTTPBatchSize = "logforward-http-batch-size"
+
+	// LogFwdHTTPFlushInterval sets the maximum time a log record is
+	// held before it is forwarded over HTTP.
+	LogFwdHTTPFlushInterval = "logforward-http-flush-interval"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log forwarding endpoint's certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -268,6 +290,23 @@ const (
 	// CharmHubURLKey is the key for the url to use for CharmHub API calls
 	CharmHubURLKey = "charmhub-url"
 
+	// CharmSigningKeysKey is the key for the public keys trusted to sign
+	// charms and resources, in the OpenSSH allowed_signers format.
+	CharmSigningKeysKey = "charm-signing-keys"
+
+	// SignedCharmsOnlyKey is the key for whether only charms and resources
+	// signed by one of the charm signing keys may be deployed.
+	SignedCharmsOnlyKey = "signed-charms-only"
+
+	// CharmSignaturesURLKey is the key for the URL of the mirror serving
+	// the signatures of charms and resources.
+	CharmSignaturesURLKey = "charm-signatures-url"
+
+	// NetworkPoliciesKey is the key for whether ingress to the pods of
+	// a Kubernetes model's applications is restricted to their related
+	// applications' pods and exposed ports.
+	NetworkPoliciesKey = "network-policies"
+
 	// ModeKey is the key for defining the mode that a given model should be
 	// using.
 	// It is expected that when in a different mode, Juju will perform in a
@@ -733,6 +772,14 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if err := cfg.validateLogFwdHTTP(); err != nil {
+		return errors.Annotate(err, "invalid HTTP log forwarding config")
+	}
+
+	if err := cfg.validateCharmSigning(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -1278,7 +1325,9 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 
 	if s, ok := c.defined[LogForwardEnabled]; ok {
 		partial = true
-		lfCfg.Enabled = s.(bool)
+		// When only the HTTP sink is configured, forwarding is
+		// enabled for it alone.
+		lfCfg.Enabled = s.(bool) && (c.asString(LogFwdSyslogHost) != "" || c.asString(LogFwdHTTPEndpoint) == "")
 	}
 
 	if s, ok := c.defined[LogFwdSyslogHost]; ok && s != "" {
@@ -1307,6 +1356,61 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the HTTP log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	cfg, err := c.logFwdHTTP()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return cfg, cfg != nil
+}
+
+func (c *Config) logFwdHTTP() (*httpjson.RawConfig, error) {
+	endpoint := c.asString(LogFwdHTTPEndpoint)
+	if endpoint == "" {
+		return nil, nil
+	}
+	enabled, _ := c.defined[LogForwardEnabled].(bool)
+	lfCfg := &httpjson.RawConfig{
+		Enabled:  enabled,
+		Endpoint: endpoint,
+		CACert:   c.asString(LogFwdHTTPCACert),
+	}
+	if v, ok := c.defined[LogFwdHTTPBatchSize].(int); ok {
+		lfCfg.BatchSize = v
+	}
+	if v := c.asString(LogFwdHTTPFlushInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, errors.Annotatef(err, "%s", LogFwdHTTPFlushInterval)
+		}
+		lfCfg.FlushInterval = interval
+	}
+	if v := c.asString(LogFwdHTTPHeaders); v != "" {
+		lfCfg.Headers = make(map[string]string)
+		for _, header := range strings.Split(v, ",") {
+			name, value, ok := strings.Cut(header, "=")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, errors.NotValidf("%s entry %q, expected name=value", LogFwdHTTPHeaders, header)
+			}
+			lfCfg.Headers[name] = strings.TrimSpace(value)
+		}
+	}
+	return lfCfg, nil
+}
+
+func (c *Config) validateLogFwdHTTP() error {
+	lfCfg, err := c.logFwdHTTP()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if lfCfg == nil {
+		return nil
+	}
+	return errors.Trace(lfCfg.Validate())
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1499,6 +1603,50 @@ func (c *Config) validateCharmHubURL() error {
 	return nil
 }
 
+// CharmSigningKeys returns the public keys trusted to sign charms and
+// resources, in the OpenSSH allowed_signers format.
+func (c *Config) CharmSigningKeys() string {
+	return c.asString(CharmSigningKeysKey)
+}
+
+// SignedCharmsOnly returns whether only charms and resources signed by
+// one of the charm signing keys may be deployed.
+func (c *Config) SignedCharmsOnly() bool {
+	v, _ := c.defined[SignedCharmsOnlyKey].(bool)
+	return v
+}
+
+// CharmSignaturesURL returns the URL of the mirror serving the
+// signatures of charms and resources, or "" if there is none.
+func (c *Config) CharmSignaturesURL() string {
+	return c.asString(CharmSignaturesURLKey)
+}
+
+// NetworkPolicies returns whether ingress to the pods of a Kubernetes
+// model's applications is restricted to their related applications' pods
+// and exposed ports.
+func (c *Config) NetworkPolicies() bool {
+	v, _ := c.defined[NetworkPoliciesKey].(bool)
+	return v
+}
+
+func (c *Config) validateCharmSigning() error {
+	signers, err := ssh.ParseAllowedSigners(c.CharmSigningKeys())
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", CharmSigningKeysKey)
+	}
+	if c.SignedCharmsOnly() && len(signers) == 0 {
+		return errors.NotValidf("%s without %s", SignedCharmsOnlyKey, CharmSigningKeysKey)
+	}
+	if v := c.CharmSignaturesURL(); v != "" {
+		u, err := url.ParseRequestURI(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "file") {
+			return errors.NotValidf("%s %q", CharmSignaturesURLKey, v)
+		}
+	}
+	return nil
+}
+
 const (
 	// RequiresPromptsMode is used to tell clients interacting with
 	// model that confirmation prompts are required when removing
@@ -1821,12 +1969,21 @@ var alwaysOptional = schema.Defaults{
 	AuthorizedKeysKey: schema.Omit,
 	ExtraInfoKey:      schema.Omit,
 
-	LogForwardEnabled:      schema.Omit,
-	LogFwdSyslogHost:       schema.Omit,
-	LogFwdSyslogCACert:     schema.Omit,
-	LogFwdSyslogClientCert: schema.Omit,
-	LogFwdSyslogClientKey:  schema.Omit,
-	LoggingOutputKey:       schema.Omit,
+	LogForwardEnabled:       schema.Omit,
+	LogFwdSyslogHost:        schema.Omit,
+	LogFwdSyslogCACert:      schema.Omit,
+	LogFwdSyslogClientCert:  schema.Omit,
+	LogFwdSyslogClientKey:   schema.Omit,
+	LogFwdHTTPEndpoint:      schema.Omit,
+	LogFwdHTTPHeaders:       schema.Omit,
+	LogFwdHTTPBatchSize:     schema.Omit,
+	LogFwdHTTPFlushInterval: schema.Omit,
+	LogFwdHTTPCACert:        schema.Omit,
+	LoggingOutputKey:        schema.Omit,
+	CharmSigningKeysKey:     schema.Omit,
+	SignedCharmsOnlyKey:     schema.Omit,
+	CharmSignaturesURLKey:   schema.Omit,
+	NetworkPoliciesKey:      schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -2296,7 +2453,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -2320,6 +2477,31 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPEndpoint: {
+		Description: `The URL of the HTTP endpoint to which log records are posted as JSON lines.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPHeaders: {
+		Description: `Comma separated name=value headers sent with each HTTP log forwarding request.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of log records sent in a single HTTP log forwarding request. (default 100)`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPFlushInterval: {
+		Description: `The maximum time a log record is held before it is forwarded over HTTP. (default 5s)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding endpoint certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -2467,6 +2649,26 @@ CIDRs specifying what ingress can be applied to offers in this model.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	CharmSigningKeysKey: {
+		Description: `The public keys trusted to sign charms and resources, one per line in the OpenSSH allowed_signers format`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SignedCharmsOnlyKey: {
+		Description: `Whether only charms and resources signed by one of the charm-signing-keys may be deployed. Local charms and uploaded resources are unsigned, so are refused (default false)`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	CharmSignaturesURLKey: {
+		Description: `The http, https or file URL of the mirror serving the signatures of charms and resources, as <algorithm>/<hex digest>.sig: sha256 digests of charm archives and container image digests, and sha384 digests of file resources. Charms and resources without a signature, or whose signature can't be fetched, are unsigned, and are refused if signed-charms-only is set`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	NetworkPoliciesKey: {
+		Description: `Whether ingress to the pods of a Kubernetes model's applications is restricted to their related applications' pods and exposed ports (default false)`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	LoggingOutputKey: {
 		Description: `The logging output destination: database and/or syslog. (default "")`,
 		Type:        environschema.Tstring,
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -615,6 +616,75 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":             true,
+			"logforward-http-endpoint":       "https://logs.example.com/ingest",
+			"logforward-http-headers":        "Authorization=Bearer token",
+			"logforward-http-batch-size":     50,
+			"logforward-http-flush-interval": "10s",
+			"logforward-http-ca-cert":        testing.CACert,
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":       true,
+			"logforward-http-endpoint": "logs.example.com:3100",
+		}),
+		err: `invalid HTTP log forwarding config: Endpoint scheme "logs.example.com" not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding headers",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-endpoint": "https://logs.example.com/ingest",
+			"logforward-http-headers":  "Authorization",
+		}),
+		err: `invalid HTTP log forwarding config: logforward-http-headers entry "Authorization", expected name=value not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding flush interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-endpoint":       "https://logs.example.com/ingest",
+			"logforward-http-flush-interval": "soon",
+		}),
+		err: `invalid HTTP log forwarding config: logforward-http-flush-interval: time: invalid duration "soon"`,
+	}, {
+		about:       "Valid charm signing config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"charm-signing-keys": charmSigningKeys,
+			"signed-charms-only": true,
+		}),
+	}, {
+		about:       "Invalid charm signing keys",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"charm-signing-keys": "charmers@example.com",
+		}),
+		err: `invalid charm-signing-keys: allowed signers line 1: signer "charmers@example.com" not valid`,
+	}, {
+		about:       "Signed charms only without charm signing keys",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"signed-charms-only": true,
+		}),
+		err: `signed-charms-only without charm-signing-keys not valid`,
+	}, {
+		about:       "Valid charm signatures URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"charm-signatures-url": "https://signatures.example.com/charms",
+		}),
+	}, {
+		about:       "Invalid charm signatures URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"charm-signatures-url": "ftp://signatures.example.com",
+		}),
+		err: `charm-signatures-url "ftp://signatures.example.com" not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -754,7 +824,16 @@ func (test configTest) check(c *gc.C) {
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
-		c.Assert(lfCfg.Enabled, gc.Equals, v)
+		// Syslog forwarding isn't enabled when only the HTTP sink
+		// is configured.
+		_, hasHTTP := test.attrs["logforward-http-endpoint"]
+		_, hasSyslog := test.attrs["syslog-host"]
+		c.Assert(lfCfg.Enabled, gc.Equals, v && (hasSyslog || !hasHTTP))
+	}
+	if v, ok := test.attrs["logforward-http-endpoint"].(string); ok {
+		httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.Endpoint, gc.Equals, v)
 	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
@@ -1321,6 +1400,41 @@ func (s *ConfigSuite) TestApplicationOfferAllowList(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "empty cidrs not valid")
 }
 
+func (s *ConfigSuite) TestLogFwdHTTP(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":             true,
+		"logforward-http-endpoint":       "https://logs.example.com/ingest",
+		"logforward-http-headers":        "Authorization=Bearer token, X-Scope-OrgID=juju",
+		"logforward-http-batch-size":     50,
+		"logforward-http-flush-interval": "10s",
+		"logforward-http-ca-cert":        testing.CACert,
+	})
+	lfCfg, ok := cfg.LogFwdHTTP()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg, jc.DeepEquals, &httpjson.RawConfig{
+		Enabled:  true,
+		Endpoint: "https://logs.example.com/ingest",
+		Headers: map[string]string{
+			"Authorization": "Bearer token",
+			"X-Scope-OrgID": "juju",
+		},
+		BatchSize:     50,
+		FlushInterval: 10 * time.Second,
+		CACert:        testing.CACert,
+	})
+
+	// Syslog forwarding isn't enabled when only the HTTP sink is configured.
+	syslogCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg.Enabled, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogFwdHTTPNotSet(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.LogFwdHTTP()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *ConfigSuite) TestLoggingOutput(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	loggingOutput, ok := config.LoggingOutput()
@@ -1666,6 +1780,35 @@ func (s *ConfigSuite) TestLXDSnapChannelConfig(c *gc.C) {
 	c.Assert(config.LXDSnapChannel(), gc.Equals, "latest/candidate")
 }
 
+const charmSigningKeys = "charmers@example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILA6vuMI2W5IZ/PJI3P3a0M8kTUi0hnGF+qRot0Y5h1q"
+
+func (s *ConfigSuite) TestCharmSigningConfig(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.CharmSigningKeys(), gc.Equals, "")
+	c.Assert(cfg.SignedCharmsOnly(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.CharmSigningKeysKey: charmSigningKeys,
+		config.SignedCharmsOnlyKey: true,
+	})
+	c.Assert(cfg.CharmSigningKeys(), gc.Equals, charmSigningKeys)
+	c.Assert(cfg.SignedCharmsOnly(), jc.IsTrue)
+	c.Assert(cfg.CharmSignaturesURL(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.CharmSignaturesURLKey: "file:///srv/signatures",
+	})
+	c.Assert(cfg.CharmSignaturesURL(), gc.Equals, "file:///srv/signatures")
+}
+
+func (s *ConfigSuite) TestNetworkPoliciesConfig(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.NetworkPolicies(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{config.NetworkPoliciesKey: true})
+	c.Assert(cfg.NetworkPolicies(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestTelemetryConfig(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.Telemetry(), jc.IsTrue)
@@ -31,11 +31,17 @@ type Logger interface {
 // S3Client represents the S3 client methods required by objectClient
 type S3Client interface {
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
 }
 
 // Session represents the interface objectClient exports to interact with S3
 type Session interface {
 	GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error)
+	PutObject(ctx context.Context, bucketName, objectName string, body io.Reader, size int64) error
+	ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, bucketName, objectName string) error
 }
 
 // objectsClient is a Juju shim around the AWS S3 client,
@@ -61,6 +67,67 @@ func (c *objectsClient) GetObject(ctx context.Context, bucketName, objectName st
 	return obj.Body, nil
 }
 
+// PutObject stores an object of the given size in an S3 object store.
+func (c *objectsClient) PutObject(ctx context.Context, bucketName, objectName string, body io.Reader, size int64) error {
+	c.logger.Tracef("storing bucket %s object %s in s3 storage", bucketName, objectName)
+
+	_, err := c.client.PutObject(ctx,
+		&s3.PutObjectInput{
+			Bucket:        aws.String(bucketName),
+			Key:           aws.String(objectName),
+			Body:          body,
+			ContentLength: aws.Int64(size),
+		})
+	if err != nil {
+		return errors.Annotatef(err, "unable to put object %s on bucket %s using S3 client", objectName, bucketName)
+	}
+	return nil
+}
+
+// ListObjects returns the names of the objects in a bucket of an S3
+// object store which start with the prefix.
+func (c *objectsClient) ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error) {
+	c.logger.Tracef("listing bucket %s objects with prefix %q in s3 storage", bucketName, prefix)
+
+	var (
+		names []string
+		token *string
+	)
+	for {
+		out, err := c.client.ListObjectsV2(ctx,
+			&s3.ListObjectsV2Input{
+				Bucket:            aws.String(bucketName),
+				Prefix:            aws.String(prefix),
+				ContinuationToken: token,
+			})
+		if err != nil {
+			return nil, errors.Annotatef(err, "unable to list objects on bucket %s using S3 client", bucketName)
+		}
+		for _, obj := range out.Contents {
+			names = append(names, aws.ToString(obj.Key))
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			return names, nil
+		}
+		token = out.NextContinuationToken
+	}
+}
+
+// DeleteObject removes an object from an S3 object store.
+func (c *objectsClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	c.logger.Tracef("deleting bucket %s object %s from s3 storage", bucketName, objectName)
+
+	_, err := c.client.DeleteObject(ctx,
+		&s3.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(objectName),
+		})
+	if err != nil {
+		return errors.Annotatef(err, "unable to delete object %s on bucket %s using S3 client", objectName, bucketName)
+	}
+	return nil
+}
+
 type awsEndpointResolver struct {
 	endpoint string
 }
@@ -149,3 +216,29 @@ func NewS3Client(apiConn api.Connection, logger Logger) (Session, error) {
 		logger: logger,
 	}, nil
 }
+
+// NewS3ClientForEndpoint creates a generic S3 client for an external S3
+// compatible object store. Credentials are found using the standard AWS
+// SDK credential chain, for example the AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY environment variables.
+func NewS3ClientForEndpoint(endpoint string, logger Logger) (Session, error) {
+	awsLogger := &awsLogger{
+		logger: logger,
+	}
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithLogger(awsLogger),
+		config.WithEndpointResolver(&awsEndpointResolver{endpoint: endpoint}),
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot load default config for s3 client")
+	}
+
+	return &objectsClient{
+		client: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			o.UsePathStyle = true
+		}),
+		logger: logger,
+	}, nil
+}
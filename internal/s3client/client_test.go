@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -50,3 +51,66 @@ func (s *s3ClientSuite) TestGetObject(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(blob), gc.Equals, "blob")
 }
+
+func (s *s3ClientSuite) TestPutObject(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	body := strings.NewReader("blob")
+	s.s3Client.EXPECT().PutObject(gomock.Any(), &s3.PutObjectInput{
+		Bucket:        aws.String("bucket"),
+		Key:           aws.String("object"),
+		Body:          body,
+		ContentLength: aws.Int64(4),
+	}, gomock.Any()).Return(&s3.PutObjectOutput{}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	err := cli.PutObject(context.Background(), "bucket", "object", body, 4)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *s3ClientSuite) TestListObjects(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.s3Client.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+		Bucket: aws.String("bucket"),
+		Prefix: aws.String("backups/"),
+	}, gomock.Any()).Return(&s3.ListObjectsV2Output{
+		Contents:              []types.Object{{Key: aws.String("backups/a")}},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("next"),
+	}, nil)
+	s.s3Client.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+		Bucket:            aws.String("bucket"),
+		Prefix:            aws.String("backups/"),
+		ContinuationToken: aws.String("next"),
+	}, gomock.Any()).Return(&s3.ListObjectsV2Output{
+		Contents: []types.Object{{Key: aws.String("backups/b")}},
+	}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	names, err := cli.ListObjects(context.Background(), "bucket", "backups/")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"backups/a", "backups/b"})
+}
+
+func (s *s3ClientSuite) TestDeleteObject(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.s3Client.EXPECT().DeleteObject(gomock.Any(), &s3.DeleteObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("object"),
+	}, gomock.Any()).Return(&s3.DeleteObjectOutput{}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	err := cli.DeleteObject(context.Background(), "bucket", "object")
+	c.Assert(err, jc.ErrorIsNil)
+}
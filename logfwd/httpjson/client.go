@@ -0,0 +1,241 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// contentType is the content type of the requests sent to the
+	// endpoint: one JSON encoded record per line.
+	contentType = "application/x-ndjson"
+
+	// requestTimeout is the maximum time allowed for a single request.
+	requestTimeout = 30 * time.Second
+)
+
+// HTTPClient is the subset of *http.Client used by Client.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client forwards log records, as JSON lines, to an HTTP endpoint.
+// Records are posted in batches of up to BatchSize records, or once
+// FlushInterval has passed since the oldest unsent record was received,
+// whichever comes first.
+type Client struct {
+	tomb    tomb.Tomb
+	config  RawConfig
+	client  HTTPClient
+	clock   clock.Clock
+	records chan []logfwd.Record
+
+	mu     sync.Mutex
+	onSent func([]logfwd.Record) error
+}
+
+// Open returns a client which posts records to the configured endpoint.
+func Open(cfg RawConfig, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	return OpenForClient(cfg, client, clock)
+}
+
+// OpenForClient returns a client which posts records to the configured
+// endpoint using the given HTTP client.
+func OpenForClient(cfg RawConfig, client HTTPClient, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &Client{
+		config:  cfg,
+		client:  client,
+		clock:   clock,
+		records: make(chan []logfwd.Record),
+	}
+	c.tomb.Go(c.loop)
+	return c, nil
+}
+
+// OnSent registers a function which is called with each batch of
+// records once it has been accepted by the endpoint. Records are only
+// held by the client until they are sent, so callers use this to track
+// which records have actually been forwarded.
+func (c *Client) OnSent(f func([]logfwd.Record) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onSent = f
+}
+
+// Send queues the records to be posted to the endpoint. An error is
+// returned if the client has stopped, e.g. because a batch could not
+// be posted.
+func (c *Client) Send(records []logfwd.Record) error {
+	select {
+	case c.records <- records:
+		return nil
+	case <-c.tomb.Dying():
+		if err := c.tomb.Err(); err != tomb.ErrStillAlive && err != nil {
+			return errors.Trace(err)
+		}
+		return errors.New("log forwarding client closed")
+	}
+}
+
+// Close flushes any queued records, then stops the client.
+func (c *Client) Close() error {
+	c.tomb.Kill(nil)
+	return errors.Trace(c.tomb.Wait())
+}
+
+func (c *Client) loop() error {
+	var (
+		pending []logfwd.Record
+		flush   <-chan time.Time
+	)
+	batchSize := c.config.batchSize()
+	for {
+		select {
+		case <-c.tomb.Dying():
+			return errors.Trace(c.flush(pending))
+		case records := <-c.records:
+			pending = append(pending, records...)
+			if len(pending) >= batchSize {
+				// Send all the complete batches, holding the
+				// remainder until the next flush.
+				n := len(pending) - len(pending)%batchSize
+				if err := c.flush(pending[:n]); err != nil {
+					return errors.Trace(err)
+				}
+				pending, flush = append([]logfwd.Record(nil), pending[n:]...), nil
+			}
+			if flush == nil && len(pending) > 0 {
+				flush = c.clock.After(c.config.flushInterval())
+			}
+		case <-flush:
+			if err := c.flush(pending); err != nil {
+				return errors.Trace(err)
+			}
+			pending, flush = nil, nil
+		}
+	}
+}
+
+// flush posts the records in batches of at most BatchSize records,
+// notifying the OnSent function after each batch is accepted.
+func (c *Client) flush(records []logfwd.Record) error {
+	batchSize := c.config.batchSize()
+	for len(records) > 0 {
+		n := batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		batch := records[:n]
+		if err := c.post(batch); err != nil {
+			return errors.Annotatef(err, "sending %d log records", len(batch))
+		}
+		c.mu.Lock()
+		onSent := c.onSent
+		c.mu.Unlock()
+		if onSent != nil {
+			if err := onSent(batch); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (c *Client) post(records []logfwd.Record) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, rec := range records {
+		if err := enc.Encode(recordFromLogfwd(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(http.MethodPost, c.config.Endpoint, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range c.config.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Record is the JSON representation of a log record sent to the
+// endpoint.
+type Record struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	Message         string    `json:"message"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name,omitempty"`
+	Software        string    `json:"software,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+}
+
+func recordFromLogfwd(rec logfwd.Record) Record {
+	r := Record{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		Message:        rec.Message,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+	}
+	if rec.Origin.Software.Name != "" {
+		r.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return r
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	client *stubHTTPClient
+	config httpjson.RawConfig
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.client = &stubHTTPClient{requests: make(chan *http.Request, 10)}
+	s.config = httpjson.RawConfig{
+		Enabled:       true,
+		Endpoint:      "https://logs.example.com/ingest",
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+		BatchSize:     2,
+		FlushInterval: time.Minute,
+	}
+}
+
+func (s *ClientSuite) newRecord(id int64) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.9.42"),
+			},
+		},
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.test",
+			Filename: "test.go",
+			Line:     42,
+		},
+		Message: "hello",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C) *httpjson.Client {
+	client, err := httpjson.OpenForClient(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) nextRequest(c *gc.C) *http.Request {
+	select {
+	case req := <-s.client.requests:
+		return req
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request")
+	}
+	return nil
+}
+
+func (s *ClientSuite) assertNoRequest(c *gc.C) {
+	select {
+	case req := <-s.client.requests:
+		c.Fatalf("unexpected request %v", req)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	s.config.Endpoint = ""
+	_, err := httpjson.OpenForClient(s.config, s.client, s.clock)
+	c.Assert(err, gc.ErrorMatches, `empty Endpoint not valid`)
+}
+
+func (s *ClientSuite) TestSendBatch(c *gc.C) {
+	client := s.open(c)
+	defer func() { _ = client.Close() }()
+
+	var sent [][]logfwd.Record
+	client.OnSent(func(records []logfwd.Record) error {
+		sent = append(sent, records)
+		return nil
+	})
+
+	err := client.Send([]logfwd.Record{s.newRecord(1), s.newRecord(2), s.newRecord(3)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.nextRequest(c)
+	c.Check(req.Method, gc.Equals, http.MethodPost)
+	c.Check(req.URL.String(), gc.Equals, "https://logs.example.com/ingest")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+	c.Check(req.Header.Get("Authorization"), gc.Equals, "Bearer token")
+
+	lines := s.client.bodies[0]
+	c.Assert(lines, gc.HasLen, 2)
+	var rec httpjson.Record
+	err = json.Unmarshal([]byte(lines[0]), &rec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rec, jc.DeepEquals, httpjson.Record{
+		ID:              1,
+		Timestamp:       time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:           "INFO",
+		Module:          "juju.worker.test",
+		Location:        "test.go:42",
+		Message:         "hello",
+		ControllerUUID:  "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:        "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType:      "machine",
+		OriginName:      "99",
+		Software:        "jujud-machine-agent",
+		SoftwareVersion: "2.9.42",
+	})
+
+	// The third record is held until the flush interval passes.
+	s.assertNoRequest(c)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.nextRequest(c)
+
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	c.Assert(sent, gc.HasLen, 2)
+	c.Check(sent[0], gc.HasLen, 2)
+	c.Check(sent[1], jc.DeepEquals, []logfwd.Record{s.newRecord(3)})
+}
+
+func (s *ClientSuite) TestCloseFlushes(c *gc.C) {
+	client := s.open(c)
+
+	err := client.Send([]logfwd.Record{s.newRecord(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRequest(c)
+
+	c.Assert(client.Close(), jc.ErrorIsNil)
+	s.nextRequest(c)
+	c.Check(s.client.bodies, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendFailureStopsClient(c *gc.C) {
+	s.client.status = http.StatusServiceUnavailable
+	client := s.open(c)
+
+	var sent []logfwd.Record
+	client.OnSent(func(records []logfwd.Record) error {
+		sent = append(sent, records...)
+		return nil
+	})
+
+	err := client.Send([]logfwd.Record{s.newRecord(1), s.newRecord(2)})
+	c.Assert(err, jc.ErrorIsNil)
+	s.nextRequest(c)
+
+	err = client.Send([]logfwd.Record{s.newRecord(3)})
+	c.Assert(err, gc.ErrorMatches, `sending 2 log records: endpoint returned 503 Service Unavailable`)
+	c.Assert(client.Close(), gc.NotNil)
+	c.Check(sent, gc.HasLen, 0)
+}
+
+type stubHTTPClient struct {
+	status   int
+	err      error
+	bodies   [][]string
+	requests chan *http.Request
+}
+
+func (c *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	c.bodies = append(c.bodies, lines)
+	c.requests <- req
+	if c.err != nil {
+		return nil, c.err
+	}
+	status := c.status
+	if status == 0 {
+		status = http.StatusNoContent
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3/cert"
+)
+
+const (
+	// DefaultBatchSize is the default maximum number of records sent
+	// in a single request.
+	DefaultBatchSize = 100
+
+	// DefaultFlushInterval is the default maximum time a record is
+	// held before it is sent.
+	DefaultFlushInterval = 5 * time.Second
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Endpoint is the URL that records are posted to.
+	Endpoint string
+
+	// Headers holds additional HTTP headers, such as authorization
+	// or tenant headers, sent with each request.
+	Headers map[string]string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request. If zero, DefaultBatchSize is used.
+	BatchSize int
+
+	// FlushInterval is the maximum time a record is held before it
+	// is sent. If zero, DefaultFlushInterval is used.
+	FlushInterval time.Duration
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If not
+	// set, the system roots are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateEndpoint(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("BatchSize %d", cfg.BatchSize)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.NotValidf("FlushInterval %v", cfg.FlushInterval)
+	}
+	for name := range cfg.Headers {
+		if name == "" {
+			return errors.NotValidf("empty header name")
+		}
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateEndpoint() error {
+	if cfg.Endpoint == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty Endpoint")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return errors.NotValidf("Endpoint %q", cfg.Endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("Endpoint scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("Endpoint %q without host", cfg.Endpoint)
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+func (cfg RawConfig) flushInterval() time.Duration {
+	if cfg.FlushInterval == 0 {
+		return DefaultFlushInterval
+	}
+	return cfg.FlushInterval
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
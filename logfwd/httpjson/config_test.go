@@ -0,0 +1,107 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled:       true,
+		Endpoint:      "https://logs.example.com/loki/api/v1/push",
+		Headers:       map[string]string{"X-Scope-OrgID": "juju"},
+		BatchSize:     50,
+		FlushInterval: time.Second,
+		CACert:        coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg httpjson.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingEndpoint(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errorsIsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty Endpoint not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadEndpoint(c *gc.C) {
+	for _, endpoint := range []string{
+		"logs.example.com:3100",
+		"ftp://logs.example.com",
+		"http://",
+	} {
+		c.Logf("endpoint %q", endpoint)
+		cfg := httpjson.RawConfig{
+			Enabled:  true,
+			Endpoint: endpoint,
+		}
+		err := cfg.Validate()
+		c.Check(err, jc.Satisfies, errorsIsNotValid)
+	}
+}
+
+func (s *ConfigSuite) TestRawValidateBadBatchSize(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Endpoint:  "http://logs.example.com",
+		BatchSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `BatchSize -1 not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFlushInterval(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Endpoint:      "http://logs.example.com",
+		FlushInterval: -time.Second,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `FlushInterval -1s not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Endpoint: "https://logs.example.com",
+		CACert:   "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: .*`)
+}
+
+func errorsIsNotValid(err error) bool {
+	return errors.Is(err, errors.NotValid)
+}
@@ -0,0 +1,7 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package httpjson holds the tools needed to perform log forwarding
+// from Juju to an HTTP endpoint which ingests JSON lines, such as
+// Loki or Elasticsearch.
+package httpjson
@@ -147,6 +147,45 @@ type UploadBinariesConfig struct {
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// Progress, if set, records the binaries uploaded so that an
+	// interrupted upload can be resumed without sending them again.
+	Progress UploadProgress
+}
+
+// BinaryKind identifies the kind of a binary uploaded during a
+// migration.
+type BinaryKind string
+
+const (
+	CharmBinary    BinaryKind = "charm"
+	ToolsBinary    BinaryKind = "tools"
+	ResourceBinary BinaryKind = "resource"
+)
+
+// UploadProgress records which binaries have been uploaded to the
+// target controller in a migration.
+type UploadProgress interface {
+	// Uploaded returns true if the binary has already been uploaded.
+	Uploaded(kind BinaryKind, id string) bool
+
+	// SetUploaded records that the binary has been uploaded.
+	SetUploaded(kind BinaryKind, id string) error
+}
+
+func (c *UploadBinariesConfig) uploaded(kind BinaryKind, id string) bool {
+	if c.Progress != nil && c.Progress.Uploaded(kind, id) {
+		logger.Debugf("%s %s already sent to target", kind, id)
+		return true
+	}
+	return false
+}
+
+func (c *UploadBinariesConfig) setUploaded(kind BinaryKind, id string) error {
+	if c.Progress == nil {
+		return nil
+	}
+	return errors.Annotatef(c.Progress.SetUploaded(kind, id), "recording %s %s uploaded", kind, id)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -225,6 +264,9 @@ func uploadCharms(config UploadBinariesConfig) error {
 	naturalsort.Sort(config.Charms)
 
 	for _, charmURL := range config.Charms {
+		if config.uploaded(CharmBinary, charmURL) {
+			continue
+		}
 		logger.Debugf("sending charm %s to target", charmURL)
 		reader, err := config.CharmDownloader.OpenCharm(charmURL)
 		if err != nil {
@@ -248,12 +290,18 @@ func uploadCharms(config UploadBinariesConfig) error {
 			// The target controller shouldn't assign a different charm URL.
 			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
 		}
+		if err := config.setUploaded(CharmBinary, charmURL); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
 
 func uploadTools(config UploadBinariesConfig) error {
 	for v, uri := range config.Tools {
+		if config.uploaded(ToolsBinary, v.String()) {
+			continue
+		}
 		logger.Debugf("sending agent binaries to target: %s", v)
 
 		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
@@ -271,18 +319,25 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload agent binaries")
 		}
+		if err := config.setUploaded(ToolsBinary, v.String()); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
 
 func uploadResources(config UploadBinariesConfig) error {
 	for _, res := range config.Resources {
-		if res.ApplicationRevision.IsPlaceholder() {
+		rev := res.ApplicationRevision
+		id := rev.ApplicationID + "/" + rev.Name
+		if rev.IsPlaceholder() {
 			// Resource placeholders created in the migration import rather
 			// than attempting to post empty resources.
-		} else {
-			err := uploadAppResource(config, res.ApplicationRevision)
-			if err != nil {
+		} else if !config.uploaded(ResourceBinary, id) {
+			if err := uploadAppResource(config, rev); err != nil {
+				return errors.Trace(err)
+			}
+			if err := config.setUploaded(ResourceBinary, id); err != nil {
 				return errors.Trace(err)
 			}
 		}
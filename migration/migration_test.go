@@ -220,6 +220,73 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
 }
 
+type fakeUploadProgress struct {
+	uploaded map[migration.BinaryKind][]string
+}
+
+func (p *fakeUploadProgress) Uploaded(kind migration.BinaryKind, id string) bool {
+	for _, uploaded := range p.uploaded[kind] {
+		if uploaded == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *fakeUploadProgress) SetUploaded(kind migration.BinaryKind, id string) error {
+	p.uploaded[kind] = append(p.uploaded[kind], id)
+	return nil
+}
+
+func (s *ImportSuite) TestBinariesMigrationResumed(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+
+	toolsMap := map[version.Binary]string{
+		version.MustParseBinary("2.1.0-ubuntu-amd64"): "/tools/0",
+		version.MustParseBinary("2.0.0-ubuntu-amd64"): "/tools/1",
+	}
+	app0Res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	app1Res := resourcetesting.NewResource(c, nil, "blob1", "app1", "blob1").Resource
+
+	// Some binaries were uploaded before the upload was interrupted.
+	progress := &fakeUploadProgress{uploaded: map[migration.BinaryKind][]string{
+		migration.CharmBinary:    {"local:trusty/magic-2"},
+		migration.ToolsBinary:    {"2.0.0-ubuntu-amd64"},
+		migration.ResourceBinary: {"app0/blob0"},
+	}}
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-10", "local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              toolsMap,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		Resources:          []coremigration.SerializedModelResource{{ApplicationRevision: app0Res}, {ApplicationRevision: app1Res}},
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Progress:           progress,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(uploader.charms, jc.DeepEquals, []string{"local:trusty/magic-10"})
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.1.0-ubuntu-amd64"): "/tools/0",
+	})
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{"app1/blob1": "blob1"})
+
+	// The newly uploaded binaries are recorded.
+	c.Assert(progress.uploaded, jc.DeepEquals, map[migration.BinaryKind][]string{
+		migration.CharmBinary:    {"local:trusty/magic-2", "local:trusty/magic-10"},
+		migration.ToolsBinary:    {"2.0.0-ubuntu-amd64", "2.1.0-ubuntu-amd64"},
+		migration.ResourceBinary: {"app0/blob0", "app1/blob1"},
+	})
+}
+
 func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 	downloader := &fakeDownloader{}
 	uploader := &fakeUploader{
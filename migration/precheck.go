@@ -25,8 +25,32 @@ func SourcePrecheck(
 	backend PrecheckBackend,
 	modelPresence ModelPresence, controllerPresence ModelPresence,
 	environscloudspecGetter environsCloudSpecGetter,
+) error {
+	return errors.Trace(sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, nil))
+}
+
+// SourcePrecheckIssues runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem found it returns all of
+// the problems which would prevent the model from being migrated. An
+// error is only returned if the checks couldn't be run.
+func SourcePrecheckIssues(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+) ([]string, error) {
+	issues := []string{}
+	err := sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, &issues)
+	return issues, errors.Trace(err)
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+	issues *[]string,
 ) error {
 	ctx := newPrecheckSource(backend, modelPresence, environscloudspecGetter)
+	ctx.issues = issues
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -47,7 +71,9 @@ func SourcePrecheck(
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.blocked(errors.New("cleanup needed")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Check the source controller.
@@ -56,9 +82,16 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 	controllerCtx := newPrecheckTarget(controllerBackend, controllerPresence, environscloudspecGetter)
+	var controllerIssues []string
+	if issues != nil {
+		controllerCtx.issues = &controllerIssues
+	}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
+	for _, issue := range controllerIssues {
+		*issues = append(*issues, "controller: "+issue)
+	}
 	return nil
 }
 
@@ -66,9 +99,29 @@ func SourcePrecheck(
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	return errors.Trace(targetPrecheck(backend, pool, modelInfo, presence, nil))
+}
+
+// TargetPrecheckIssues runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem found it returns all of
+// the problems which would prevent the model from being migrated to
+// the target controller. An error is only returned if the checks
+// couldn't be run.
+func TargetPrecheckIssues(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) ([]string, error) {
+	issues := []string{}
+	err := targetPrecheck(backend, pool, modelInfo, presence, &issues)
+	return issues, errors.Trace(err)
+}
+
+func targetPrecheck(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence,
+	issues *[]string,
+) error {
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	controllerCtx := newPrecheckTarget(backend, presence, nil)
+	controllerCtx.issues = issues
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -80,7 +133,9 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := controllerCtx.blocked(errors.New("model is being migrated out of target controller")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
@@ -89,13 +144,19 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		err := controllerCtx.blocked(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		err := controllerCtx.blocked(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// The MigrateToAllowed check is the same as validating if a model can be
@@ -105,10 +166,12 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		return errors.Maskf(err, "unknown target controller version %v", controllerVersion)
 	}
 	if !allowed {
-		return errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)
+		err := controllerCtx.blocked(errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
-	controllerCtx := newPrecheckTarget(backend, presence, nil)
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
@@ -129,10 +192,15 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			err := controllerCtx.blocked(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := controllerCtx.blocked(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 
@@ -157,6 +225,21 @@ type precheckContext struct {
 	backend                 PrecheckBackend
 	presence                ModelPresence
 	environscloudspecGetter environsCloudSpecGetter
+
+	// issues collects the problems found when all of them are being
+	// reported, rather than just the first.
+	issues *[]string
+}
+
+// blocked handles a problem which prevents the migration. The problem
+// is returned as an error unless all problems are being collected, in
+// which case it is recorded and checking continues.
+func (ctx *precheckContext) blocked(err error) error {
+	if err == nil || ctx.issues == nil {
+		return err
+	}
+	*ctx.issues = append(*ctx.issues, err.Error())
+	return nil
 }
 
 func (ctx *precheckContext) checkController() error {
@@ -165,13 +248,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocked(errors.Errorf("model is %s", model.Life())); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.blocked(errors.New("upgrade in progress")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -187,36 +274,43 @@ func (ctx *precheckContext) checkMachines() error {
 	if err != nil {
 		return errors.Annotate(err, "retrieving machines")
 	}
-	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if err := ctx.checkMachine(machine, modelVersion); err != nil {
+			return errors.Trace(err)
 		}
+	}
+	return nil
+}
 
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
-		}
+// checkMachine checks whether the machine blocks the migration. Only
+// the first problem found with the machine is reported.
+func (ctx *precheckContext) checkMachine(machine PrecheckMachine, modelVersion version.Number) error {
+	if machine.Life() != state.Alive {
+		return ctx.blocked(errors.Errorf("machine %s is %s", machine.Id(), machine.Life()))
+	}
 
-		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return ctx.blocked(newStatusError("machine %s not running", machine.Id(), statusInfo.Status))
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
+	if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return ctx.blocked(newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status))
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return ctx.blocked(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction))
 	}
-	return nil
+
+	_, err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id())
+	return errors.Trace(err)
 }
 
 func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
@@ -236,14 +330,16 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.blocked(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
 		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
 		}
-		err = ctx.checkUnits(app, units, modelVersion, model.Type())
-		if err != nil {
+		if err := ctx.checkUnits(app, units, modelVersion, model.Type()); err != nil {
 			return nil, errors.Trace(err)
 		}
 		appUnits[app.Name()] = units
@@ -253,7 +349,10 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		err := ctx.blocked(errors.Errorf("application %s is below its minimum units threshold", app.Name()))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
@@ -262,42 +361,53 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 	}
 
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
-		}
-
-		if err := ctx.checkUnitAgentStatus(unit); err != nil {
+		if err := ctx.checkUnit(unit, appCharmURL, modelVersion, modelType); err != nil {
 			return errors.Trace(err)
 		}
+	}
+	return nil
+}
 
-		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-				return errors.Trace(err)
-			}
-		}
+// checkUnit checks whether the unit blocks the migration. Only the
+// first problem found with the unit is reported.
+func (ctx *precheckContext) checkUnit(unit PrecheckUnit, appCharmURL *string, modelVersion version.Number, modelType state.ModelType) error {
+	if unit.Life() != state.Alive {
+		return ctx.blocked(errors.Errorf("unit %s is %s", unit.Name(), unit.Life()))
+	}
+
+	if ok, err := ctx.checkUnitAgentStatus(unit); err != nil || !ok {
+		return errors.Trace(err)
+	}
 
-		unitCharmURL := unit.CharmURL()
-		if unitCharmURL == nil || *appCharmURL != *unitCharmURL {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+	if modelType == state.ModelTypeIAAS {
+		if ok, err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil || !ok {
+			return errors.Trace(err)
 		}
 	}
+
+	unitCharmURL := unit.CharmURL()
+	if unitCharmURL == nil || *appCharmURL != *unitCharmURL {
+		return ctx.blocked(errors.Errorf("unit %s is upgrading", unit.Name()))
+	}
 	return nil
 }
 
-func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
+// checkUnitAgentStatus returns false if the unit's agent status
+// blocks the migration.
+func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) (bool, error) {
 	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	statusData, _ := modelPresenceContext.UnitStatus(unit)
 	if statusData.Err != nil {
-		return errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
+		return false, errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
 	}
 	agentStatus := statusData.Status.Status
 	switch agentStatus {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return false, ctx.blocked(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
-	return nil
+	return true, nil
 }
 
 func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) error {
@@ -324,7 +434,7 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 				return errors.Trace(err)
 			}
 			if !inScope {
-				return errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel)
+				return ctx.blocked(errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel))
 			}
 			return nil
 		}
@@ -378,10 +488,14 @@ func (ctx *precheckSource) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocked(errors.Errorf("model is %s", model.Life())); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.blocked(errors.New("model is being imported as part of another migration")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -389,7 +503,9 @@ func (ctx *precheckSource) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			if err := ctx.blocked(errors.New("model has revoked credentials")); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 
@@ -406,24 +522,26 @@ func (ctx *precheckSource) checkModel() error {
 	if blockers == nil {
 		return nil
 	}
-	return errors.NewNotSupported(nil, fmt.Sprintf("cannot migrate to controller due to issues:\n%s", blockers))
+	return ctx.blocked(errors.NewNotSupported(nil, fmt.Sprintf("cannot migrate to controller due to issues:\n%s", blockers)))
 }
 
 type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+// checkAgentTools returns false if the agent's binaries don't match
+// the model's version, which blocks the migration.
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) (bool, error) {
 	tools, err := agent.AgentTools()
 	if err != nil {
-		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
+		return false, errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return false, ctx.blocked(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
-	return nil
+	return true, nil
 }
 
 func newStatusError(format, id string, s status.Status) error {
@@ -473,6 +473,99 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unit remote-mysql/0 hasn't joined relation "foo:db remote-mysql:db" yet`)
 }
 
+func (*SourcePrecheckSuite) TestIssuesSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	issues, err := migration.SourcePrecheckIssues(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestIssuesCollectsAll(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.machines = append(backend.machines, &fakeMachine{id: "2", status: status.Down})
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{name: "foo", life: state.Dying},
+		&fakeApp{
+			name:     "bar",
+			charmURL: "ch:bar-3",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "bar/0", charmURL: "ch:bar-2"},
+				&fakeUnit{name: "bar/1", life: state.Dead},
+			},
+		},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+
+	issues, err := migration.SourcePrecheckIssues(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"machine 2 agent not functioning at this time (down)",
+		"application foo is dying",
+		"unit bar/0 is upgrading",
+		"unit bar/1 is dead",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (*SourcePrecheckSuite) TestIssuesRetrievalError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckIssues(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func (*SourcePrecheckSuite) TestIssuesMachineRetrievalError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	backend.machines = append(backend.machines, &fakeMachine{id: "2", instanceStatusErr: errors.New("boom")})
+	_, err := migration.SourcePrecheckIssues(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "retrieving machine 2 instance status: boom")
+}
+
+func (*SourcePrecheckSuite) TestIssuesUnitRetrievalError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	backend.model.modelType = state.ModelTypeIAAS
+	backend.apps = append(backend.apps, &fakeApp{
+		name:     "bar",
+		charmURL: "ch:bar-3",
+		units: []migration.PrecheckUnit{
+			&fakeUnit{name: "bar/0", charmURL: "ch:bar-3", noTools: true},
+		},
+	})
+	_, err := migration.SourcePrecheckIssues(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "retrieving agent binaries for unit bar/0: tools not found")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -722,6 +815,41 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestIssuesCollectsAll(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{
+				uuid:      modelUUID,
+				name:      modelName,
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithDyingMachine()
+	backend.models = pool.uuids()
+	backend.migrationActive = true
+	backend.isUpgrading = true
+	s.modelInfo.AgentVersion.Patch++
+
+	issues, err := migration.TargetPrecheckIssues(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 is dying",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
+func (s *TargetPrecheckSuite) TestIssuesRetrievalError(c *gc.C) {
+	backend := &fakeBackend{migrationActiveErr: errors.New("boom")}
+	_, err := migration.TargetPrecheckIssues(backend, nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking for active migration: boom")
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -1008,12 +1136,13 @@ func (m *fakeModel) CloudCredentialTag() (names.CloudCredentialTag, bool) {
 }
 
 type fakeMachine struct {
-	id             string
-	version        version.Binary
-	life           state.Life
-	status         status.Status
-	instanceStatus status.Status
-	rebootAction   state.RebootAction
+	id                string
+	version           version.Binary
+	life              state.Life
+	status            status.Status
+	instanceStatus    status.Status
+	instanceStatusErr error
+	rebootAction      state.RebootAction
 }
 
 func (m *fakeMachine) Id() string {
@@ -1034,6 +1163,9 @@ func (m *fakeMachine) Status() (status.StatusInfo, error) {
 }
 
 func (m *fakeMachine) InstanceStatus() (status.StatusInfo, error) {
+	if m.instanceStatusErr != nil {
+		return status.StatusInfo{}, m.instanceStatusErr
+	}
 	s := m.instanceStatus
 	if s == "" {
 		// Avoid the need to specify this everywhere.
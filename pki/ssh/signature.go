@@ -0,0 +1,326 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	cryptossh "golang.org/x/crypto/ssh"
+)
+
+// The SSH signature format is that produced by "ssh-keygen -Y sign", as
+// described in PROTOCOL.sshsig in the OpenSSH source.
+const (
+	signatureMagic      = "SSHSIG"
+	signatureVersion    = 1
+	signaturePEMType    = "SSH SIGNATURE"
+	signatureHashSHA256 = "sha256"
+	signatureHashSHA512 = "sha512"
+)
+
+// AllowedSigner is a key trusted to make signatures, as held in an
+// OpenSSH allowed_signers file.
+type AllowedSigner struct {
+	// Principals are the identities the key belongs to.
+	Principals []string
+
+	// Namespaces are the namespaces the key may make signatures in. If
+	// empty, the key may make signatures in any namespace.
+	Namespaces []string
+
+	// Key is the trusted public key.
+	Key cryptossh.PublicKey
+}
+
+// allowsNamespace returns true if the signer may make signatures in the
+// namespace.
+func (s AllowedSigner) allowsNamespace(namespace string) bool {
+	if len(s.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range s.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAllowedSigners parses signers in the OpenSSH allowed_signers
+// format, with one signer per line of the form:
+//
+//	principals [options] keytype base64-key [comment]
+//
+// Only the namespaces option is supported. Empty lines and lines
+// starting with '#' are ignored.
+func ParseAllowedSigners(data string) ([]AllowedSigner, error) {
+	var signers []AllowedSigner
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		signer, err := parseAllowedSigner(line)
+		if err != nil {
+			return nil, errors.Annotatef(err, "allowed signers line %d", lineNum)
+		}
+		signers = append(signers, signer)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return signers, nil
+}
+
+func parseAllowedSigner(line string) (AllowedSigner, error) {
+	principals, rest := splitField(line)
+	principals = strings.ReplaceAll(principals, `"`, "")
+	if principals == "" || rest == "" {
+		return AllowedSigner{}, errors.NotValidf("signer %q", line)
+	}
+	key, _, options, _, err := cryptossh.ParseAuthorizedKey([]byte(rest))
+	if err != nil {
+		return AllowedSigner{}, errors.NewNotValid(err, "signer public key")
+	}
+	signer := AllowedSigner{
+		Principals: strings.Split(principals, ","),
+		Key:        key,
+	}
+	for _, option := range options {
+		name, value, _ := strings.Cut(option, "=")
+		switch strings.ToLower(name) {
+		case "namespaces":
+			for _, ns := range strings.Split(strings.Trim(value, `"`), ",") {
+				if ns = strings.TrimSpace(ns); ns != "" {
+					signer.Namespaces = append(signer.Namespaces, ns)
+				}
+			}
+		default:
+			return AllowedSigner{}, errors.NotSupportedf("signer option %q", name)
+		}
+	}
+	return signer, nil
+}
+
+// splitField splits the first, possibly quoted, whitespace separated
+// field from the line.
+func splitField(line string) (string, string) {
+	inQuotes := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			return line[:i], strings.TrimSpace(line[i:])
+		}
+	}
+	return line, ""
+}
+
+// signatureBlob is the content of an armored SSH signature, after the
+// magic preamble.
+type signatureBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// signedData is the data that is signed to make an SSH signature, after
+// the magic preamble.
+type signedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+func (d signedData) marshal() []byte {
+	return append([]byte(signatureMagic), cryptossh.Marshal(d)...)
+}
+
+func newSignatureHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case signatureHashSHA256:
+		return sha256.New(), nil
+	case signatureHashSHA512:
+		return sha512.New(), nil
+	}
+	return nil, errors.NotSupportedf("signature hash algorithm %q", algorithm)
+}
+
+// Sign returns an armored SSH signature of the message in the
+// namespace, as would be made by "ssh-keygen -Y sign -n <namespace>".
+func Sign(signer cryptossh.Signer, namespace string, message io.Reader) ([]byte, error) {
+	if namespace == "" {
+		return nil, errors.NotValidf("empty namespace")
+	}
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := signedData{
+		Namespace:     namespace,
+		HashAlgorithm: signatureHashSHA512,
+		Hash:          h.Sum(nil),
+	}.marshal()
+
+	var (
+		sig *cryptossh.Signature
+		err error
+	)
+	if algSigner, ok := signer.(cryptossh.AlgorithmSigner); ok && signer.PublicKey().Type() == cryptossh.KeyAlgoRSA {
+		// SHA1 RSA signatures aren't allowed in SSH signatures.
+		sig, err = algSigner.SignWithAlgorithm(rand.Reader, data, cryptossh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = signer.Sign(rand.Reader, data)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	blob := append([]byte(signatureMagic), cryptossh.Marshal(signatureBlob{
+		Version:       signatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: signatureHashSHA512,
+		Signature:     cryptossh.Marshal(sig),
+	})...)
+	return pem.EncodeToMemory(&pem.Block{Type: signaturePEMType, Bytes: blob}), nil
+}
+
+// Signature is a parsed SSH signature.
+type Signature struct {
+	// Key is the public key which made the signature.
+	Key cryptossh.PublicKey
+
+	// Namespace is the namespace the signature was made in.
+	Namespace string
+
+	hashAlgorithm string
+	signature     *cryptossh.Signature
+}
+
+// ParseSignature parses an armored SSH signature, as made by
+// "ssh-keygen -Y sign".
+func ParseSignature(armored []byte) (*Signature, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(armored))
+	if block == nil || block.Type != signaturePEMType {
+		return nil, errors.NotValidf("SSH signature")
+	}
+	if !bytes.HasPrefix(block.Bytes, []byte(signatureMagic)) {
+		return nil, errors.NotValidf("SSH signature preamble")
+	}
+	var blob signatureBlob
+	if err := cryptossh.Unmarshal(block.Bytes[len(signatureMagic):], &blob); err != nil {
+		return nil, errors.NewNotValid(err, "SSH signature")
+	}
+	if blob.Version != signatureVersion {
+		return nil, errors.NotSupportedf("SSH signature version %d", blob.Version)
+	}
+	key, err := cryptossh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "SSH signature public key")
+	}
+	var sig cryptossh.Signature
+	if err := cryptossh.Unmarshal(blob.Signature, &sig); err != nil {
+		return nil, errors.NewNotValid(err, "SSH signature")
+	}
+	if sig.Format == cryptossh.KeyAlgoRSA {
+		return nil, errors.NotSupportedf("SSH signature with SHA1 RSA")
+	}
+	if _, err := newSignatureHash(blob.HashAlgorithm); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Signature{
+		Key:           key,
+		Namespace:     blob.Namespace,
+		hashAlgorithm: blob.HashAlgorithm,
+		signature:     &sig,
+	}, nil
+}
+
+// Signer returns the allowed signer which made the signature, checking
+// that it made the signature in the expected namespace. The message
+// isn't verified; use Verify for that.
+func (s *Signature) Signer(allowed []AllowedSigner, namespace string) (AllowedSigner, error) {
+	if s.Namespace != namespace {
+		return AllowedSigner{}, errors.NotValidf("signature namespace %q, expected %q,", s.Namespace, namespace)
+	}
+	keyBytes := s.Key.Marshal()
+	for _, signer := range allowed {
+		if bytes.Equal(signer.Key.Marshal(), keyBytes) && signer.allowsNamespace(namespace) {
+			return signer, nil
+		}
+	}
+	return AllowedSigner{}, errors.Unauthorizedf("signing key %s not trusted", cryptossh.FingerprintSHA256(s.Key))
+}
+
+// Verify verifies that the signature is a signature of the message,
+// made in the expected namespace by one of the allowed signers, which
+// is returned.
+func (s *Signature) Verify(allowed []AllowedSigner, namespace string, message io.Reader) (AllowedSigner, error) {
+	v, err := s.NewVerifier(allowed, namespace)
+	if err != nil {
+		return AllowedSigner{}, errors.Trace(err)
+	}
+	if _, err := io.Copy(v, message); err != nil {
+		return AllowedSigner{}, errors.Trace(err)
+	}
+	return v.Verify()
+}
+
+// NewVerifier returns a Verifier of the signature, for verifying a
+// message as it is streamed. The signature must have been made in the
+// expected namespace by one of the allowed signers.
+func (s *Signature) NewVerifier(allowed []AllowedSigner, namespace string) (*Verifier, error) {
+	signer, err := s.Signer(allowed, namespace)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	h, _ := newSignatureHash(s.hashAlgorithm)
+	return &Verifier{
+		sig:    s,
+		signer: signer,
+		hash:   h,
+	}, nil
+}
+
+// Verifier verifies a signature of the message written to it.
+type Verifier struct {
+	sig    *Signature
+	signer AllowedSigner
+	hash   hash.Hash
+}
+
+// Write is part of the io.Writer interface.
+func (v *Verifier) Write(p []byte) (int, error) {
+	return v.hash.Write(p)
+}
+
+// Verify verifies that the signature is a signature of the message
+// written so far, returning the allowed signer which made it.
+func (v *Verifier) Verify() (AllowedSigner, error) {
+	data := signedData{
+		Namespace:     v.sig.Namespace,
+		HashAlgorithm: v.sig.hashAlgorithm,
+		Hash:          v.hash.Sum(nil),
+	}.marshal()
+	if err := v.sig.Key.Verify(data, v.sig.signature); err != nil {
+		return AllowedSigner{}, errors.NewNotValid(err, "signature")
+	}
+	return v.signer, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ssh_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	cryptossh "golang.org/x/crypto/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/pki/ssh"
+)
+
+type SignatureSuite struct {
+}
+
+var _ = gc.Suite(&SignatureSuite{})
+
+// keygenSignature is a signature of keygenMessage made with
+// "ssh-keygen -Y sign -n juju-charm" using keygenPublicKey.
+const (
+	keygenPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILA6vuMI2W5IZ/PJI3P3a0M8kTUi0hnGF+qRot0Y5h1q"
+	keygenMessage   = "hello charm\n"
+	keygenSignature = `
+-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgsDq+4wjZbkhn88kjc/drQzyRNS
+LSGcYX6pGi3RjmHWoAAAAKanVqdS1jaGFybQAAAAAAAAAGc2hhNTEyAAAAUwAAAAtzc2gt
+ZWQyNTUxOQAAAEAPzazIHgDRA0KkMBiWOcNbwEH//oEB2H4kUuQwnRTanEGPAnHaRMaG5M
+LhK7KmrXSfGdN1qlUQ75kQEaiJzVAD
+-----END SSH SIGNATURE-----
+`
+)
+
+func newSigner(c *gc.C, profile ssh.KeyProfile) cryptossh.Signer {
+	pk, err := profile()
+	c.Assert(err, jc.ErrorIsNil)
+	signer, err := cryptossh.NewSignerFromKey(pk)
+	c.Assert(err, jc.ErrorIsNil)
+	return signer
+}
+
+func allowedSigner(signer cryptossh.Signer, options string) string {
+	return "charmers@example.com " + options + strings.TrimSpace(string(cryptossh.MarshalAuthorizedKey(signer.PublicKey())))
+}
+
+func (s *SignatureSuite) TestParseAllowedSigners(c *gc.C) {
+	signers, err := ssh.ParseAllowedSigners(`
+# Trusted charm publishers.
+charmers@example.com,"*@example.org" ` + keygenPublicKey + ` a comment
+
+"ops team" namespaces="juju-charm,juju-resource" ` + keygenPublicKey + `
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(signers, gc.HasLen, 2)
+	c.Check(signers[0].Principals, jc.DeepEquals, []string{"charmers@example.com", "*@example.org"})
+	c.Check(signers[0].Namespaces, gc.HasLen, 0)
+	c.Check(signers[0].Key.Type(), gc.Equals, cryptossh.KeyAlgoED25519)
+	c.Check(signers[1].Principals, jc.DeepEquals, []string{"ops team"})
+	c.Check(signers[1].Namespaces, jc.DeepEquals, []string{"juju-charm", "juju-resource"})
+}
+
+func (s *SignatureSuite) TestParseAllowedSignersErrors(c *gc.C) {
+	_, err := ssh.ParseAllowedSigners("charmers@example.com")
+	c.Check(err, gc.ErrorMatches, `allowed signers line 1: signer "charmers@example.com" not valid`)
+	_, err = ssh.ParseAllowedSigners("\ncharmers@example.com ssh-ed25519 bad")
+	c.Check(err, gc.ErrorMatches, `allowed signers line 2: signer public key: .*`)
+	_, err = ssh.ParseAllowedSigners("charmers@example.com cert-authority " + keygenPublicKey)
+	c.Check(err, gc.ErrorMatches, `allowed signers line 1: signer option "cert-authority" not supported`)
+}
+
+func (s *SignatureSuite) TestVerifyKeygenSignature(c *gc.C) {
+	signers, err := ssh.ParseAllowedSigners("charmers@example.com " + keygenPublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sig, err := ssh.ParseSignature([]byte(keygenSignature))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sig.Namespace, gc.Equals, "juju-charm")
+	signer, err := sig.Verify(signers, "juju-charm", strings.NewReader(keygenMessage))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(signer.Principals, jc.DeepEquals, []string{"charmers@example.com"})
+
+	_, err = sig.Verify(signers, "juju-charm", strings.NewReader("tampered"))
+	c.Assert(err, gc.ErrorMatches, "signature: ssh: signature did not verify")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SignatureSuite) TestSignAndVerify(c *gc.C) {
+	for _, profile := range []ssh.KeyProfile{ssh.ED25519, ssh.ECDSAP256, ssh.RSA2048} {
+		signer := newSigner(c, profile)
+		allowed, err := ssh.ParseAllowedSigners(allowedSigner(signer, ""))
+		c.Assert(err, jc.ErrorIsNil)
+
+		armored, err := ssh.Sign(signer, "juju-charm", strings.NewReader("charm archive"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(armored), gc.Matches, "(?s)-----BEGIN SSH SIGNATURE-----\n.*-----END SSH SIGNATURE-----\n")
+
+		sig, err := ssh.ParseSignature(armored)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = sig.Verify(allowed, "juju-charm", strings.NewReader("charm archive"))
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("key type %s", signer.PublicKey().Type()))
+	}
+}
+
+func (s *SignatureSuite) TestVerifyWrongNamespace(c *gc.C) {
+	signer := newSigner(c, ssh.ED25519)
+	allowed, err := ssh.ParseAllowedSigners(allowedSigner(signer, ""))
+	c.Assert(err, jc.ErrorIsNil)
+
+	armored, err := ssh.Sign(signer, "file", strings.NewReader("charm archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.ParseSignature(armored)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sig.Verify(allowed, "juju-charm", strings.NewReader("charm archive"))
+	c.Assert(err, gc.ErrorMatches, `signature namespace "file", expected "juju-charm", not valid`)
+}
+
+func (s *SignatureSuite) TestVerifyUntrustedKey(c *gc.C) {
+	trusted := newSigner(c, ssh.ED25519)
+	untrusted := newSigner(c, ssh.ED25519)
+	allowed, err := ssh.ParseAllowedSigners(allowedSigner(trusted, ""))
+	c.Assert(err, jc.ErrorIsNil)
+
+	armored, err := ssh.Sign(untrusted, "juju-charm", strings.NewReader("charm archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.ParseSignature(armored)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sig.Signer(allowed, "juju-charm")
+	c.Assert(err, gc.ErrorMatches, `signing key SHA256:.* not trusted`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *SignatureSuite) TestVerifyNamespaceNotAllowed(c *gc.C) {
+	signer := newSigner(c, ssh.ED25519)
+	allowed, err := ssh.ParseAllowedSigners(allowedSigner(signer, `namespaces="juju-resource" `))
+	c.Assert(err, jc.ErrorIsNil)
+
+	armored, err := ssh.Sign(signer, "juju-charm", strings.NewReader("charm archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.ParseSignature(armored)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sig.Signer(allowed, "juju-charm")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *SignatureSuite) TestParseSignatureInvalid(c *gc.C) {
+	_, err := ssh.ParseSignature([]byte("not a signature"))
+	c.Assert(err, gc.ErrorMatches, "SSH signature not valid")
+	_, err = ssh.ParseSignature([]byte("-----BEGIN SSH SIGNATURE-----\nYm9ndXM=\n-----END SSH SIGNATURE-----\n"))
+	c.Assert(err, gc.ErrorMatches, "SSH signature preamble not valid")
+}
+
+func (s *SignatureSuite) TestVerifierStreamed(c *gc.C) {
+	signers, err := ssh.ParseAllowedSigners("charmers@example.com " + keygenPublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := ssh.ParseSignature([]byte(keygenSignature))
+	c.Assert(err, jc.ErrorIsNil)
+
+	v, err := sig.NewVerifier(signers, "juju-charm")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = v.Write([]byte(keygenMessage[:5]))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = v.Verify()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	_, err = v.Write([]byte(keygenMessage[5:]))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = v.Verify()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/juju/juju/pki/ssh"
+)
+
+// NewSSHSigner returns a new SSH signer for making SSH signatures in
+// tests, and an allowed_signers line which trusts it.
+func NewSSHSigner(principal string) (cryptossh.Signer, string, error) {
+	pk, err := ssh.ED25519()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	signer, err := cryptossh.NewSignerFromKey(pk)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	authorizedKey := strings.TrimSpace(string(cryptossh.MarshalAuthorizedKey(signer.PublicKey())))
+	return signer, principal + " " + authorizedKey, nil
+}
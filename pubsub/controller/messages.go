@@ -22,3 +22,28 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// Restored messages are published by the apiserver backups facade once
+// the controller databases have been restored from a backup. Controller
+// agents restart on receipt, so that they pick up the restored state.
+// data: `RestoredMessage`
+const Restored = "controller.restored"
+
+// RestoredMessage identifies the backups that have been restored, in
+// the order in which they were restored.
+type RestoredMessage struct {
+	BackupIDs []string
+}
+
+// Restoring messages are published by the apiserver backups facade when
+// it starts restoring the controller databases from a backup, and again
+// if the restore fails. While a restore is in progress the API server
+// refuses calls from anything but the controller agents, and the Dqlite
+// node is stopped so that its data can be replaced.
+// data: `RestoringMessage`
+const Restoring = "controller.restoring"
+
+// RestoringMessage reports whether a restore is in progress.
+type RestoringMessage struct {
+	InProgress bool
+}
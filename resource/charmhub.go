@@ -4,8 +4,12 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"net/url"
+	"strings"
 
 	charmresource "github.com/juju/charm/v12/resource"
 	"github.com/juju/errors"
@@ -13,7 +17,9 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/charmhub/transport"
+	corecharm "github.com/juju/juju/core/charm"
 	corelogger "github.com/juju/juju/core/logger"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/state"
 )
 
@@ -49,6 +55,17 @@ func newCharmHubClient(st chClientState) (ResourceGetter, error) {
 		return &CharmHubClient{}, errors.Trace(err)
 	}
 
+	var source corecharm.SignatureSource
+	if mirrorURL := modelCfg.CharmSignaturesURL(); mirrorURL != "" {
+		if source, err = charmhub.NewSignatureMirror(mirrorURL, nil, logger); err != nil {
+			return nil, errors.Annotate(err, "invalid charm signing config")
+		}
+	}
+	policy, err := corecharm.NewSigningPolicy(modelCfg.CharmSigningKeys(), modelCfg.SignedCharmsOnly(), source)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid charm signing config")
+	}
+
 	chURL, _ := modelCfg.CharmHubURL()
 	chClient, err := charmhub.NewClient(charmhub.Config{
 		URL:    chURL,
@@ -57,12 +74,17 @@ func newCharmHubClient(st chClientState) (ResourceGetter, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &CharmHubClient{client: chClient, logger: logger.ChildWithLabels("charmhub", corelogger.CHARMHUB)}, nil
+	return &CharmHubClient{
+		client: chClient,
+		logger: logger.ChildWithLabels("charmhub", corelogger.CHARMHUB),
+		policy: policy,
+	}, nil
 }
 
 type CharmHubClient struct {
 	client CharmHub
 	logger Logger
+	policy corecharm.SigningPolicy
 }
 
 // GetResource returns data about the resource including an io.ReadCloser
@@ -111,9 +133,85 @@ func (ch *CharmHubClient) GetResource(req ResourceRequest) (ResourceData, error)
 	if err != nil {
 		return data, errors.Trace(err)
 	}
+	data.ReadCloser, err = ch.verifyResource(r, data.ReadCloser)
+	if err != nil {
+		return data, errors.Trace(err)
+	}
 	return data, nil
 }
 
+// verifyResource returns a reader of the resource's content which fails
+// if the content doesn't match the resource's signature, as required by
+// the model's signing policy. File resources are verified as they are
+// read, and container image resources by their image digest. Signatures
+// are looked up by the resource's fingerprint or image digest.
+func (ch *CharmHubClient) verifyResource(r charmresource.Resource, rc io.ReadCloser) (io.ReadCloser, error) {
+	if !ch.policy.Enabled() {
+		return rc, nil
+	}
+	what := fmt.Sprintf("resource %q", r.Name)
+
+	if r.Type == charmresource.TypeContainerImage {
+		defer func() { _ = rc.Close() }()
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details, err := resources.UnmarshalDockerResource(content)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Images without a digest can't be looked up, so are
+		// treated as unsigned.
+		_, digest, _ := strings.Cut(details.RegistryPath, "@")
+		sig, err := ch.policy.Signature(context.TODO(), what, digest)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := ch.policy.Verify(what, corecharm.ResourceSignatureNamespace, sig, strings.NewReader(digest)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+
+	var digest string
+	if !r.Fingerprint.IsZero() {
+		digest = "sha384:" + r.Fingerprint.Hex()
+	}
+	sig, err := ch.policy.Signature(context.TODO(), what, digest)
+	if err != nil {
+		_ = rc.Close()
+		return nil, errors.Trace(err)
+	}
+	verifier, err := ch.policy.NewVerifier(what, corecharm.ResourceSignatureNamespace, sig)
+	if err != nil {
+		_ = rc.Close()
+		return nil, errors.Trace(err)
+	} else if verifier == nil {
+		return rc, nil
+	}
+	return &verifyingReadCloser{ReadCloser: rc, verifier: verifier}, nil
+}
+
+// verifyingReadCloser verifies the signature of the content read from
+// it, returning an error instead of io.EOF if the content doesn't match.
+type verifyingReadCloser struct {
+	io.ReadCloser
+	verifier *corecharm.SignatureVerifier
+}
+
+// Read is part of the io.Reader interface.
+func (r *verifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	_, _ = r.verifier.Write(p[:n])
+	if err == io.EOF {
+		if verr := r.verifier.Verify(); verr != nil {
+			return n, errors.Trace(verr)
+		}
+	}
+	return n, err
+}
+
 func resourceFromRevision(name string, revs []transport.ResourceRevision) (charmresource.Resource, *url.URL, error) {
 	var rev transport.ResourceRevision
 	for _, v := range revs {
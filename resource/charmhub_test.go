@@ -5,15 +5,22 @@ package resource_test
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"strings"
 
 	"github.com/juju/charm/v12"
 	charmresource "github.com/juju/charm/v12/resource"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
+	cryptossh "golang.org/x/crypto/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/charmhub/transport"
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/pki/ssh"
+	pkitest "github.com/juju/juju/pki/test"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/mocks"
 	"github.com/juju/juju/state"
@@ -67,6 +74,143 @@ func (s *CharmHubSuite) TestGetResource(c *gc.C) {
 	})
 }
 
+func (s *CharmHubSuite) TestGetResourceSigned(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefresh()
+	signer, policy := s.signingPolicy(c, true)
+	policy.Source = signatureSource{resourceDigest: s.sign(c, signer, "resource content")}
+	s.expectDownload("resource content")
+
+	result, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := io.ReadAll(result.ReadCloser)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "resource content")
+}
+
+func (s *CharmHubSuite) TestGetResourceSignatureMismatch(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefresh()
+	signer, policy := s.signingPolicy(c, false)
+	policy.Source = signatureSource{resourceDigest: s.sign(c, signer, "resource content")}
+	s.expectDownload("tampered content")
+
+	result, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = io.ReadAll(result.ReadCloser)
+	c.Assert(err, gc.ErrorMatches, `signature of resource "wal-e" does not match: .*`)
+}
+
+func (s *CharmHubSuite) TestGetResourceUnsignedRequired(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefresh()
+	_, policy := s.signingPolicy(c, true)
+	policy.Source = signatureSource{}
+	s.expectDownload("resource content")
+
+	_, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, gc.ErrorMatches, `resource "wal-e" is not signed, and the model only allows signed charms and resources`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *CharmHubSuite) TestGetResourceUnsignedAllowed(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefresh()
+	_, policy := s.signingPolicy(c, false)
+	policy.Source = signatureSource{}
+	s.expectDownload("resource content")
+
+	result, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := io.ReadAll(result.ReadCloser)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "resource content")
+}
+
+func (s *CharmHubSuite) TestGetResourceImageSigned(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefreshType("oci-image")
+	signer, policy := s.signingPolicy(c, true)
+	image := `{"ImageName":"registry.example.com/wal-e@sha256:deadbeef"}`
+	policy.Source = signatureSource{"sha256:deadbeef": s.sign(c, signer, "sha256:deadbeef")}
+	s.expectDownload(image)
+
+	result, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := io.ReadAll(result.ReadCloser)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, image)
+}
+
+func (s *CharmHubSuite) TestGetResourceImageSignatureMismatch(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefreshType("oci-image")
+	signer, policy := s.signingPolicy(c, false)
+	image := `{"ImageName":"registry.example.com/wal-e@sha256:cafebabe"}`
+	// The mirror serves a signature of another image.
+	policy.Source = signatureSource{"sha256:cafebabe": s.sign(c, signer, "sha256:deadbeef")}
+	s.expectDownload(image)
+
+	_, err := s.newSigningCharmHubClient(policy).GetResource(s.resourceRequest())
+	c.Assert(err, gc.ErrorMatches, `signature of resource "wal-e" does not match: .*`)
+}
+
+func (s *CharmHubSuite) resourceRequest() resource.ResourceRequest {
+	curl, _ := charm.ParseURL("ch:postgresql")
+	rev := 42
+	return resource.ResourceRequest{
+		CharmID: resource.CharmID{
+			URL: curl,
+			Origin: state.CharmOrigin{
+				ID:       "mycharmhubid",
+				Channel:  &state.Channel{Risk: "stable"},
+				Revision: &rev,
+				Platform: &state.Platform{
+					Architecture: "amd64",
+					OS:           "ubuntu",
+					Channel:      "20.04/stable",
+				},
+			},
+		},
+		Name:     "wal-e",
+		Revision: 8,
+	}
+}
+
+func (s *CharmHubSuite) signingPolicy(c *gc.C, required bool) (cryptossh.Signer, corecharm.SigningPolicy) {
+	signer, keys, err := pkitest.NewSSHSigner("charmers@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := corecharm.NewSigningPolicy(keys, required, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return signer, policy
+}
+
+func (s *CharmHubSuite) sign(c *gc.C, signer cryptossh.Signer, message string) []byte {
+	sig, err := ssh.Sign(signer, corecharm.ResourceSignatureNamespace, strings.NewReader(message))
+	c.Assert(err, jc.ErrorIsNil)
+	return sig
+}
+
+func (s *CharmHubSuite) newSigningCharmHubClient(policy corecharm.SigningPolicy) *resource.CharmHubClient {
+	return resource.NewSigningCharmHubClientForTest(s.client, &noopLogger{}, policy)
+}
+
+func (s *CharmHubSuite) expectDownload(content string) {
+	s.client.EXPECT().DownloadResource(gomock.Any(), gomock.Any()).Return(io.NopCloser(strings.NewReader(content)), nil)
+}
+
 func (s *CharmHubSuite) newCharmHubClient() *resource.CharmHubClient {
 	return resource.NewCharmHubClientForTest(s.client, &noopLogger{})
 }
@@ -76,6 +220,10 @@ func (s *CharmHubSuite) expectDownloadResource() {
 }
 
 func (s *CharmHubSuite) expectRefresh() {
+	s.expectRefreshType("file")
+}
+
+func (s *CharmHubSuite) expectRefreshType(resType string) {
 	resp := []transport.RefreshResponse{
 		{
 			Entity: transport.RefreshEntity{
@@ -93,7 +241,7 @@ func (s *CharmHubSuite) expectRefresh() {
 							URL:        "https://api.staging.charmhub.io/api/v1/resources/download/charm_jmeJLrjWpJX9OglKSeUHCwgyaCNuoQjD.wal-e_0"},
 						Name:     "wal-e",
 						Revision: 8,
-						Type:     "file",
+						Type:     resType,
 					},
 				},
 				Summary: "PostgreSQL object-relational SQL database (supported version)",
@@ -108,6 +256,21 @@ func (s *CharmHubSuite) expectRefresh() {
 	s.client.EXPECT().Refresh(gomock.Any(), gomock.Any()).Return(resp, nil)
 }
 
+// resourceDigest is the digest of the resource fingerprint returned by
+// expectRefresh.
+const resourceDigest = "sha384:38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b"
+
+// signatureSource serves signatures keyed on the digest of the content
+// signed.
+type signatureSource map[string][]byte
+
+func (s signatureSource) Signature(_ context.Context, digest string) ([]byte, error) {
+	if sig, ok := s[digest]; ok {
+		return sig, nil
+	}
+	return nil, errors.NotFoundf("signature of %q", digest)
+}
+
 type noopLogger struct{}
 
 func (noopLogger) Tracef(string, ...interface{}) {}
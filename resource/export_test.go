@@ -9,6 +9,7 @@ import (
 	"github.com/juju/charm/v12"
 	"github.com/juju/names/v5"
 
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/state"
 )
 
@@ -26,6 +27,14 @@ func NewCharmHubClientForTest(cl CharmHub, logger Logger) *CharmHubClient {
 	}
 }
 
+func NewSigningCharmHubClientForTest(cl CharmHub, logger Logger, policy corecharm.SigningPolicy) *CharmHubClient {
+	return &CharmHubClient{
+		client: cl,
+		logger: logger,
+		policy: policy,
+	}
+}
+
 func NewResourceRetryClientForTest(cl ResourceGetter) *ResourceRetryClient {
 	client := newRetryClient(cl)
 	client.retryArgs.Delay = time.Millisecond
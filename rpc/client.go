@@ -4,11 +4,14 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/trace"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -24,6 +27,10 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// traceParent holds the W3C traceparent value of the span
+	// making the call, if any.
+	traceParent string
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -91,9 +98,10 @@ func (conn *Conn) send(call *Call) {
 
 	// Encode and send the request.
 	hdr := &Header{
-		RequestId: reqId,
-		Request:   call.Request,
-		Version:   1,
+		RequestId:   reqId,
+		Request:     call.Request,
+		Version:     1,
+		TraceParent: call.traceParent,
 	}
 	params := call.Params
 	if params == nil {
@@ -167,11 +175,18 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	return conn.CallContext(context.Background(), req, params, response)
+}
+
+// CallContext is like Call, but if ctx holds a trace span, the span is
+// propagated to the server so the request is traced as part of it.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
 	call := &Call{
-		Request:  req,
-		Params:   params,
-		Response: response,
-		Done:     make(chan *Call, 1),
+		Request:     req,
+		Params:      params,
+		Response:    response,
+		Done:        make(chan *Call, 1),
+		traceParent: trace.TraceParent(ctx),
 	}
 	conn.send(call)
 	result := <-call.Done
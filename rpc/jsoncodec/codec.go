@@ -71,6 +71,8 @@ type inMsgV1 struct {
 	ErrorCode string                 `json:"error-code"`
 	ErrorInfo map[string]interface{} `json:"error-info"`
 	Response  json.RawMessage        `json:"response"`
+
+	TraceParent string `json:"traceparent"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +99,8 @@ type outMsgV1 struct {
 	ErrorCode string                 `json:"error-code,omitempty"`
 	ErrorInfo map[string]interface{} `json:"error-info,omitempty"`
 	Response  interface{}            `json:"response,omitempty"`
+
+	TraceParent string `json:"traceparent,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +144,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.ErrorInfo = c.msg.ErrorInfo
+	hdr.TraceParent = c.msg.TraceParent
 	hdr.Version = version
 	return nil
 }
@@ -274,6 +279,8 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
 		ErrorInfo: hdr.ErrorInfo,
+
+		TraceParent: hdr.TraceParent,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -42,6 +42,46 @@ const (
 // Actions is a slice of Action for bulk requests.
 type Actions struct {
 	Actions []Action `json:"actions,omitempty"`
+
+	// Rollout, if set, enqueues the actions in batches rather
+	// than all at once.
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+}
+
+// RolloutSpec describes how the tasks of an operation are enqueued in
+// batches, each batch being enqueued once the previous one has finished.
+type RolloutSpec struct {
+	BatchSize     int           `json:"batch-size"`
+	BatchDelay    time.Duration `json:"batch-delay,omitempty"`
+	StopOnFailure bool          `json:"stop-on-failure,omitempty"`
+}
+
+// RolloutInfo describes the progress of an operation whose tasks are
+// enqueued in batches.
+type RolloutInfo struct {
+	BatchSize       int           `json:"batch-size"`
+	BatchDelay      time.Duration `json:"batch-delay,omitempty"`
+	StopOnFailure   bool          `json:"stop-on-failure,omitempty"`
+	Batches         int           `json:"batches"`
+	BatchesEnqueued int           `json:"batches-enqueued"`
+	Stopped         string        `json:"stopped,omitempty"`
+}
+
+// RolloutResults holds the results of advancing the rollouts of a model.
+type RolloutResults struct {
+	Results []RolloutResult `json:"results"`
+}
+
+// RolloutResult describes what was done to advance a rollout.
+type RolloutResult struct {
+	OperationTag string `json:"operation"`
+	// BatchEnqueued is the number (from 1) of the batch enqueued, if any.
+	BatchEnqueued int `json:"batch-enqueued,omitempty"`
+	// NextBatchIn is how long is left of the delay before the
+	// next batch is enqueued, if the rollout is waiting on it.
+	NextBatchIn time.Duration `json:"next-batch-in,omitempty"`
+	Finished    bool          `json:"finished,omitempty"`
+	Error       *Error        `json:"error,omitempty"`
 }
 
 // Action describes an Action that will be or has been queued up.
@@ -145,6 +185,7 @@ type OperationResult struct {
 	Completed    time.Time      `json:"completed,omitempty"`
 	Status       string         `json:"status,omitempty"`
 	Actions      []ActionResult `json:"actions,omitempty"`
+	Rollout      *RolloutInfo   `json:"rollout,omitempty"`
 	Error        *Error         `json:"error,omitempty"`
 }
 
@@ -196,3 +237,57 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// AddActionSchedules holds the schedules on which to run actions.
+type AddActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionSchedule describes an action which is run on a schedule.
+type ActionSchedule struct {
+	Name              string                 `json:"name"`
+	Cron              string                 `json:"cron,omitempty"`
+	Interval          time.Duration          `json:"interval,omitempty"`
+	Targets           []string               `json:"targets"`
+	Action            string                 `json:"action"`
+	Parameters        map[string]interface{} `json:"parameters,omitempty"`
+	ConcurrencyPolicy string                 `json:"concurrency-policy,omitempty"`
+	Created           time.Time              `json:"created,omitempty"`
+	NextRun           time.Time              `json:"next-run,omitempty"`
+	Runs              []ScheduledRun         `json:"runs,omitempty"`
+}
+
+// ActionSchedules holds the action schedules of a model.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ScheduledRun records a run of an action schedule.
+type ScheduledRun struct {
+	Time         time.Time `json:"time"`
+	OperationTag string    `json:"operation,omitempty"`
+	Skipped      string    `json:"skipped,omitempty"`
+}
+
+// ScheduledRunResults holds the results of running the action
+// schedules which were due.
+type ScheduledRunResults struct {
+	Results []ScheduledRunResult `json:"results"`
+	// NextDue is when the first of the model's schedules is next
+	// due to run, if the model has any.
+	NextDue *time.Time `json:"next-due,omitempty"`
+}
+
+// ScheduledRunResult describes a run of an action schedule.
+type ScheduledRunResult struct {
+	Schedule     string    `json:"schedule"`
+	OperationTag string    `json:"operation,omitempty"`
+	Skipped      string    `json:"skipped,omitempty"`
+	NextRun      time.Time `json:"next-run"`
+	Error        *Error    `json:"error,omitempty"`
+}
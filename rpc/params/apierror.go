@@ -124,6 +124,19 @@ func (e RedirectErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// RateLimitExceededErrorInfo provides additional information for
+// RateLimitExceeded errors.
+type RateLimitExceededErrorInfo struct {
+	// RetryAfter is the number of seconds the client should wait
+	// before making the call again.
+	RetryAfter float64 `json:"retry-after"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e RateLimitExceededErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
 // serializeToMap is a convenience function for marshaling v into a
 // map[string]interface{}. It works by marshalling v into json and then
 // unmarshaling back to a map.
@@ -193,6 +206,7 @@ const (
 	CodeNotValid                  = "not valid"
 	CodeAccessRequired            = "access required"
 	CodeAppShouldNotHaveUnits     = "application should not have units"
+	CodeRateLimitExceeded         = "rate limit exceeded"
 )
 
 // TranslateWellKnownError translates well known wire error codes into a github.com/juju/errors error
@@ -420,3 +434,9 @@ func IsCodeDeadlineExceeded(err error) bool {
 func IsCodeAppShouldNotHaveUnits(err error) bool {
 	return ErrCode(err) == CodeAppShouldNotHaveUnits
 }
+
+// IsCodeRateLimitExceeded returns true if err includes a RateLimitExceeded
+// error code.
+func IsCodeRateLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeRateLimitExceeded
+}
@@ -457,6 +457,110 @@ type ScaleApplicationInfo struct {
 	Scale int `json:"num-units"`
 }
 
+// AutoscalePolicy holds an application's autoscale policy.
+type AutoscalePolicy struct {
+	// MinUnits is the fewest units the application is scaled to.
+	MinUnits int `json:"min-units"`
+
+	// MaxUnits is the most units the application is scaled to.
+	MaxUnits int `json:"max-units"`
+
+	// TargetCPU is the target average CPU usage of the units, as a
+	// percentage of the CPU they request.
+	TargetCPU int `json:"target-cpu,omitempty"`
+
+	// TargetMemory is the target average memory usage of the units, as
+	// a percentage of the memory they request.
+	TargetMemory int `json:"target-memory,omitempty"`
+
+	// Cooldown is the least time between changes of scale.
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// AutoscaleStatus holds what the autoscaler last observed of, and did
+// to, an application.
+type AutoscaleStatus struct {
+	// UnitsMeasured is the number of units resource usage was
+	// measured from.
+	UnitsMeasured int `json:"units-measured"`
+
+	// CPU is the average CPU usage of the units, as a percentage of
+	// the CPU they request.
+	CPU *float64 `json:"cpu,omitempty"`
+
+	// Memory is the average memory usage of the units, as a
+	// percentage of the memory they request.
+	Memory *float64 `json:"memory,omitempty"`
+
+	// TargetScale is the scale the autoscaler last wanted.
+	TargetScale int `json:"target-scale"`
+
+	// LastScaled is when the autoscaler last changed the scale.
+	LastScaled *time.Time `json:"last-scaled,omitempty"`
+
+	// Message describes why the application couldn't be autoscaled.
+	Message string `json:"message,omitempty"`
+}
+
+// SetAutoscalePoliciesArgs holds parameters for the
+// Application.SetAutoscalePolicies call.
+type SetAutoscalePoliciesArgs struct {
+	Args []SetAutoscalePolicyArg `json:"args"`
+}
+
+// SetAutoscalePolicyArg sets the autoscale policy of an application.
+type SetAutoscalePolicyArg struct {
+	// ApplicationTag holds the tag of the application to autoscale.
+	ApplicationTag string `json:"application-tag"`
+
+	// Policy is the autoscale policy, or nil to stop autoscaling the
+	// application.
+	Policy *AutoscalePolicy `json:"policy,omitempty"`
+}
+
+// AutoscaledApplicationsResult holds the results of a
+// CAASAutoscaler.AutoscaledApplications call.
+type AutoscaledApplicationsResult struct {
+	Applications []AutoscaledApplication `json:"applications"`
+	Error        *Error                  `json:"error,omitempty"`
+}
+
+// AutoscaledApplication holds an autoscaled application.
+type AutoscaledApplication struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Policy is the application's autoscale policy.
+	Policy AutoscalePolicy `json:"policy"`
+
+	// Status is what the autoscaler last observed of, and did to, the
+	// application.
+	Status AutoscaleStatus `json:"status"`
+
+	// Scale is the application's desired scale.
+	Scale int `json:"scale"`
+}
+
+// SetAutoscaleStatusArgs holds parameters for the
+// CAASAutoscaler.SetAutoscaleStatus call.
+type SetAutoscaleStatusArgs struct {
+	Args []SetAutoscaleStatusArg `json:"args"`
+}
+
+// SetAutoscaleStatusArg records what the autoscaler observed of an
+// application, and optionally rescales it.
+type SetAutoscaleStatusArg struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Status is what the autoscaler observed of, and did to, the
+	// application.
+	Status AutoscaleStatus `json:"status"`
+
+	// Scale, if set, is the scale to change the application to.
+	Scale *int `json:"scale,omitempty"`
+}
+
 // ApplicationResult holds an application info.
 // NOTE: we should look to combine ApplicationResult and ApplicationInfo.
 type ApplicationResult struct {
@@ -20,6 +20,51 @@ type BackupsDownloadArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsListArgs holds the args for the API List method.
+type BackupsListArgs struct{}
+
+// BackupsPruneArgs holds the args for the API Prune method.
+type BackupsPruneArgs struct {
+	// Keep is the number of full backups to keep. If zero, the
+	// backup-retention-count controller config is used.
+	Keep int `json:"keep,omitempty"`
+}
+
+// BackupsListResult holds the list of all stored backups.
+type BackupsListResult struct {
+	List []BackupsMetadataResult `json:"list"`
+}
+
+// BackupsRestoreArgs holds the args for the API Restore method. Exactly
+// one of BackupID and FileName is set.
+type BackupsRestoreArgs struct {
+	// BackupID is the ID of a stored scheduled backup to restore,
+	// along with the backups it builds upon.
+	BackupID string `json:"backup-id,omitempty"`
+
+	// FileName is the path to a backup archive on the controller.
+	FileName string `json:"file-name,omitempty"`
+}
+
+// BackupsRestoreResult holds the result of the API Restore method.
+type BackupsRestoreResult struct {
+	// Restored holds the metadata of the restored backups, in the order
+	// in which they were restored.
+	Restored []BackupsMetadataResult `json:"restored"`
+
+	// Machines holds the outcome of re-pointing the agents on each
+	// machine at the controller, when its addresses changed.
+	Machines []BackupsRestoreMachineResult `json:"machines,omitempty"`
+}
+
+// BackupsRestoreMachineResult holds the outcome of re-pointing the
+// agents on a machine at the controller after a restore.
+type BackupsRestoreMachineResult struct {
+	Model   string `json:"model"`
+	Machine string `json:"machine"`
+	Error   *Error `json:"error,omitempty"`
+}
+
 // BackupsMetadataResult holds the metadata for a backup as returned by
 // an API backups method (such as Create).
 type BackupsMetadataResult struct {
@@ -57,4 +102,8 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// Parent is the ID of the full backup that an incremental backup
+	// builds upon. It is empty for full backups.
+	Parent string `json:"parent,omitempty"`
 }
@@ -59,6 +59,8 @@ type CAASApplicationProvisioningInfo struct {
 	CharmURL             string                       `json:"charm-url,omitempty"`
 	Trust                bool                         `json:"trust,omitempty"`
 	Scale                int                          `json:"scale,omitempty"`
+	MaxUnavailable       string                       `json:"max-unavailable,omitempty"`
+	SpreadBy             string                       `json:"spread-by,omitempty"`
 	Error                *Error                       `json:"error,omitempty"`
 }
 
@@ -753,6 +753,10 @@ type RunParams struct {
 	// WorkloadContext for CAAS is true when the Commands should be run on
 	// the workload not the operator.
 	WorkloadContext bool `json:"workload-context,omitempty"`
+
+	// Rollout, if set, runs the commands in batches rather than on
+	// all the targets at once.
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
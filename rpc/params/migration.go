@@ -63,6 +63,80 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the results of one or more
+// migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult holds the report produced by a migration dry
+// run for a single model.
+type MigrationDryRunResult struct {
+	ModelTag string           `json:"model-tag"`
+	Report   *MigrationReport `json:"report,omitempty"`
+	Error    *Error           `json:"error,omitempty"`
+}
+
+// MigrationReport describes what would happen if a model were migrated
+// to a target controller. The migration can only proceed if there are
+// no blockers.
+type MigrationReport struct {
+	Blockers        []MigrationIssue  `json:"blockers,omitempty"`
+	Warnings        []MigrationIssue  `json:"warnings,omitempty"`
+	Cloud           string            `json:"cloud"`
+	CloudRegion     string            `json:"cloud-region,omitempty"`
+	CloudCredential string            `json:"cloud-credential,omitempty"`
+	Charms          []MigrationBinary `json:"charms,omitempty"`
+	Tools           []MigrationBinary `json:"tools,omitempty"`
+	Resources       []MigrationBinary `json:"resources,omitempty"`
+}
+
+// MigrationIssue describes a problem found by a migration dry run, and
+// whether it was found on the source or target controller.
+type MigrationIssue struct {
+	Controller string `json:"controller"`
+	Message    string `json:"message"`
+}
+
+// MigrationBinary holds the name and size of a binary which would be
+// transferred to the target controller during a migration.
+type MigrationBinary struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// MigrationProgressResults is used to return the progress of the
+// latest migrations of one or more models.
+type MigrationProgressResults struct {
+	Results []MigrationProgressResult `json:"results"`
+}
+
+// MigrationProgressResult holds the progress of the latest migration
+// of a single model. FailedPhase is set when the migration failed in
+// a resumable phase and is waiting to be resumed or aborted.
+type MigrationProgressResult struct {
+	ModelTag    string `json:"model-tag"`
+	MigrationId string `json:"migration-id,omitempty"`
+	Phase       string `json:"phase,omitempty"`
+	Message     string `json:"message,omitempty"`
+	FailedPhase string `json:"failed-phase,omitempty"`
+	Error       *Error `json:"error,omitempty"`
+}
+
+// DryRunImportArgs holds the details of a model for the
+// migrationtarget.DryRunImport API method.
+type DryRunImportArgs struct {
+	Model MigrationModelInfo `json:"model"`
+	Bytes []byte             `json:"bytes"`
+}
+
+// MigrationTargetReport holds the problems found by the target
+// controller when checking whether a model could be imported.
+type MigrationTargetReport struct {
+	Blockers []string `json:"blockers,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -75,6 +149,23 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// SetMigrationCheckpointArgs provides the progress made by a migration
+// to the migrationmaster.SetCheckpoint API method.
+type SetMigrationCheckpointArgs struct {
+	Checkpoint MigrationCheckpoint `json:"checkpoint"`
+}
+
+// MigrationCheckpoint holds the progress made by a model migration, so
+// that a migration which failed in a resumable phase can be resumed.
+type MigrationCheckpoint struct {
+	FailedPhase string   `json:"failed-phase,omitempty"`
+	Imported    bool     `json:"imported,omitempty"`
+	Charms      []string `json:"charms,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
+	LogsSent    int      `json:"logs-sent,omitempty"`
+}
+
 // PrechecksArgs provides the target controller version
 // to the migrationmaster.Prechecks API method.
 type PrechecksArgs struct {
@@ -158,6 +249,8 @@ type MasterMigrationStatus struct {
 	MigrationId      string        `json:"migration-id"`
 	Phase            string        `json:"phase"`
 	PhaseChangedTime time.Time     `json:"phase-changed-time"`
+
+	Checkpoint *MigrationCheckpoint `json:"checkpoint,omitempty"`
 }
 
 // MigrationModelInfo is used to report basic model information to the
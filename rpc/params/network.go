@@ -854,6 +854,28 @@ type ApplicationOpenedPortsResults struct {
 	Results []ApplicationOpenedPortsResult `json:"results"`
 }
 
+// ApplicationIngressRule describes the pods of an application, or the
+// networks, allowed to connect to an application's pods on the given
+// port ranges.
+type ApplicationIngressRule struct {
+	SourceApplication string      `json:"source-application,omitempty"`
+	SourceCIDRs       []string    `json:"source-cidrs,omitempty"`
+	PortRanges        []PortRange `json:"port-ranges"`
+}
+
+// ApplicationIngressRulesResult holds a single result of the
+// CAASFirewallerSidecar.ApplicationIngressRules() API call.
+type ApplicationIngressRulesResult struct {
+	Rules []ApplicationIngressRule `json:"rules,omitempty"`
+	Error *Error                   `json:"error,omitempty"`
+}
+
+// ApplicationIngressRulesResults holds all the results of the
+// CAASFirewallerSidecar.ApplicationIngressRules() API call.
+type ApplicationIngressRulesResults struct {
+	Results []ApplicationIngressRulesResult `json:"results"`
+}
+
 // OpenPortRangesByEndpointResults holds the results of a request to the
 // uniter's OpenedMachinePortRangesByEndpoint and OpenedPortRangesByEndpoint API.
 type OpenPortRangesByEndpointResults struct {
@@ -244,6 +244,10 @@ type SecretsFilter struct {
 type ListSecretsArgs struct {
 	ShowSecrets bool          `json:"show-secrets"`
 	Filter      SecretsFilter `json:"filter"`
+
+	// ShowConsumers is true if the consumers tracking
+	// each secret revision are to be included.
+	ShowConsumers bool `json:"show-consumers,omitempty"`
 }
 
 // ListSecretResults holds secret metadata results.
@@ -266,6 +270,9 @@ type SecretRevision struct {
 	CreateTime  time.Time       `json:"create-time,omitempty"`
 	UpdateTime  time.Time       `json:"update-time,omitempty"`
 	ExpireTime  *time.Time      `json:"expire-time,omitempty"`
+	Author      string          `json:"author,omitempty"`
+	Rotated     bool            `json:"rotated,omitempty"`
+	Consumers   []string        `json:"consumers,omitempty"`
 }
 
 // ListSecretResult is the result of getting secret metadata.
@@ -441,6 +448,113 @@ type RemoveSecretBackendArg struct {
 	Force bool   `json:"force,omitempty"`
 }
 
+// MigrateSecretsArgs holds the args for moving secret revisions
+// of a model to another secret backend.
+type MigrateSecretsArgs struct {
+	// ModelUUID is the model whose secrets are moved.
+	ModelUUID string `json:"model-uuid"`
+
+	// BackendName is the name of the backend to move the secrets to.
+	BackendName string `json:"backend-name"`
+
+	// URIs, if set, restricts the secrets moved to those given.
+	URIs []string `json:"uris,omitempty"`
+
+	// Applications, if set, restricts the secrets moved to those
+	// owned by the given applications or their units.
+	Applications []string `json:"applications,omitempty"`
+
+	// DryRun means to only report, and check, the revisions to move.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// MigrateSecretsResult holds the result of starting to move secret
+// revisions to another secret backend.
+type MigrateSecretsResult struct {
+	// Revisions holds, for a dry run, the revisions which would be moved.
+	Revisions []MigrateSecretRevisionResult `json:"revisions,omitempty"`
+
+	// Total is the number of revisions to move.
+	Total int `json:"total"`
+}
+
+// MigrateSecretRevisionResult holds the result of moving a
+// secret revision to another secret backend.
+type MigrateSecretRevisionResult struct {
+	URI           string `json:"uri"`
+	Revision      int    `json:"revision"`
+	FromBackendID string `json:"from-backend-id"`
+	ToBackendID   string `json:"to-backend-id"`
+
+	// Checksum is the checksum of the revision content,
+	// verified after the content was moved.
+	Checksum string `json:"checksum,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
+
+// MigrateSecretRevisionResults holds the results of moving
+// secret revisions to another secret backend.
+type MigrateSecretRevisionResults struct {
+	Results []MigrateSecretRevisionResult `json:"results"`
+}
+
+// SecretsMigrationRevision identifies a secret revision
+// to move to another secret backend.
+type SecretsMigrationRevision struct {
+	URI      string `json:"uri"`
+	Revision int    `json:"revision"`
+}
+
+// MigrateSecretRevisionsArgs holds the args for moving
+// secret revisions to another secret backend.
+type MigrateSecretRevisionsArgs struct {
+	BackendID string                     `json:"backend-id"`
+	Revisions []SecretsMigrationRevision `json:"revisions"`
+}
+
+// SecretsMigrationFailure describes a secret revision
+// which could not be moved to another secret backend.
+type SecretsMigrationFailure struct {
+	URI      string `json:"uri"`
+	Revision int    `json:"revision"`
+	Message  string `json:"message"`
+}
+
+// SecretsMigrationResult holds the progress of moving
+// a model's secret revisions to another secret backend.
+type SecretsMigrationResult struct {
+	BackendID    string                    `json:"backend-id"`
+	BackendName  string                    `json:"backend-name"`
+	URIs         []string                  `json:"uris,omitempty"`
+	Applications []string                  `json:"applications,omitempty"`
+	Status       string                    `json:"status"`
+	Total        int                       `json:"total"`
+	Moved        int                       `json:"moved"`
+	Failures     []SecretsMigrationFailure `json:"failures,omitempty"`
+	Started      time.Time                 `json:"started"`
+	Updated      time.Time                 `json:"updated"`
+
+	// Revisions holds, for the worker carrying out the
+	// migration, the revisions still to move.
+	Revisions []SecretsMigrationRevision `json:"revisions,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// SecretsMigrationResults holds the progress of moving
+// secret revisions of several models.
+type SecretsMigrationResults struct {
+	Results []SecretsMigrationResult `json:"results"`
+}
+
+// SecretsMigrationProgressArg holds the progress of moving
+// a model's secret revisions to another secret backend.
+type SecretsMigrationProgressArg struct {
+	Status   string                    `json:"status"`
+	Moved    int                       `json:"moved"`
+	Failures []SecretsMigrationFailure `json:"failures,omitempty"`
+}
+
 // RotateSecretBackendArgs holds the args for updating rotated secret backend info.
 type RotateSecretBackendArgs struct {
 	BackendIDs []string `json:"backend-ids"`
@@ -161,9 +161,17 @@ type ApplicationStatus struct {
 	EndpointBindings map[string]string          `json:"endpoint-bindings"`
 
 	// The following are for CAAS models.
-	Scale         int    `json:"int,omitempty"`
-	ProviderId    string `json:"provider-id,omitempty"`
-	PublicAddress string `json:"public-address"`
+	Scale         int                         `json:"int,omitempty"`
+	ProviderId    string                      `json:"provider-id,omitempty"`
+	PublicAddress string                      `json:"public-address"`
+	Autoscale     *ApplicationAutoscaleStatus `json:"autoscale,omitempty"`
+}
+
+// ApplicationAutoscaleStatus holds the autoscale policy of an
+// application, and what the autoscaler last observed of it.
+type ApplicationAutoscaleStatus struct {
+	Policy AutoscalePolicy `json:"policy"`
+	Status AutoscaleStatus `json:"status"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
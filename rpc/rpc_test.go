@@ -17,6 +17,11 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/rpcreflect"
 	jc "github.com/juju/testing/checkers"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/rpc"
@@ -1144,6 +1149,38 @@ func (*rpcSuite) TestCodeNotImplementedMatchesAPIserverParams(c *gc.C) {
 	c.Assert(rpc.CodeNotImplemented, gc.Equals, params.CodeNotImplemented)
 }
 
+func (s *rpcSuite) TestCallContextPropagatesTrace(c *gc.C) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	s.AddCleanup(func(*gc.C) {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		_ = provider.Shutdown(context.Background())
+	})
+
+	root := &Root{}
+	root.contextInst = &ContextMethods{root: root}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "client")
+	err := client.CallContext(ctx, rpc.Request{"ContextMethods", 0, "", "Call0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	span.End()
+
+	// The server span ends before the reply is written.
+	spans := recorder.Ended()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[0].Name(), gc.Equals, "ContextMethods.Call0")
+	c.Check(spans[0].SpanContext().TraceID(), gc.Equals, span.SpanContext().TraceID())
+	c.Check(spans[0].Parent().SpanID(), gc.Equals, span.SpanContext().SpanID())
+	c.Check(spans[0].Parent().IsRemote(), jc.IsTrue)
+
+	// The span is available to the method being called.
+	callSpan := oteltrace.SpanFromContext(root.contextInst.callContext)
+	c.Check(callSpan.SpanContext().SpanID(), gc.Equals, spans[0].SpanContext().SpanID())
+}
+
 func (*rpcSuite) TestRequestContext(c *gc.C) {
 	root := &Root{}
 	root.contextInst = &ContextMethods{root: root}
@@ -14,6 +14,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/rpcreflect"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/juju/juju/core/trace"
 )
 
 const codeNotImplemented = "not implemented"
@@ -70,6 +73,11 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceParent holds the W3C traceparent value of the span which
+	// made a request, if any, so that the span serving the request is
+	// recorded as its child.
+	TraceParent string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -568,7 +576,20 @@ func (conn *Conn) runRequest(
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
 
+	ctx, span := trace.Start(
+		trace.WithTraceParent(ctx, req.hdr.TraceParent),
+		req.hdr.Request.Type+"."+req.hdr.Request.Action,
+		attribute.String("rpc.system", "juju"),
+		attribute.String("rpc.service", req.hdr.Request.Type),
+		attribute.String("rpc.method", req.hdr.Request.Action),
+		attribute.Int("rpc.version", req.hdr.Request.Version),
+	)
+	// The span is ended in a deferred func so that it's still ended
+	// if the facade method panics.
+	var callErr error
+	defer func() { trace.End(span, callErr) }()
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
+	callErr = err
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), recorder)
 	} else {
@@ -93,7 +93,7 @@ func (c *secretsClient) GetContent(uri *secrets.URI, label string, refresh, peek
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		val, err := backend.GetContent(context.TODO(), content.ValueRef.RevisionID)
+		val, err := provider.GetContent(context.TODO(), backend, content.ValueRef.RevisionID, content.SecretValue)
 		if err == nil || !errors.Is(err, errors.NotFound) || lastBackendID == backendID {
 			return val, errors.Trace(err)
 		}
@@ -124,25 +124,30 @@ func (c *secretsClient) GetRevisionContent(uri *secrets.URI, revision int) (secr
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return backend.GetContent(context.TODO(), content.ValueRef.RevisionID)
+	return provider.GetContent(context.TODO(), backend, content.ValueRef.RevisionID, content.SecretValue)
 }
 
 // SaveContent implements Client.
-func (c *secretsClient) SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (secrets.ValueRef, error) {
+// The returned content holds the reference to the content in the backend,
+// and for backends which seal content, the sealed value to be stored by Juju.
+func (c *secretsClient) SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (ContentParams, error) {
 	activeBackend, activeBackendID, err := c.GetBackend(nil, false)
 	if err != nil {
 		if errors.Is(err, errors.NotFound) {
-			return secrets.ValueRef{}, errors.NotSupportedf("saving secret content to external backend")
+			return ContentParams{}, errors.NotSupportedf("saving secret content to external backend")
 		}
-		return secrets.ValueRef{}, errors.Trace(err)
+		return ContentParams{}, errors.Trace(err)
 	}
-	revId, err := activeBackend.SaveContent(context.TODO(), uri, revision, value)
+	revId, sealed, err := provider.SaveContent(context.TODO(), activeBackend, uri, revision, value)
 	if err != nil {
-		return secrets.ValueRef{}, errors.Trace(err)
-	}
-	return secrets.ValueRef{
-		BackendID:  activeBackendID,
-		RevisionID: revId,
+		return ContentParams{}, errors.Trace(err)
+	}
+	return ContentParams{
+		SecretValue: sealed,
+		ValueRef: &secrets.ValueRef{
+			BackendID:  activeBackendID,
+			RevisionID: revId,
+		},
 	}, nil
 }
 
@@ -63,9 +63,11 @@ func (s *backendSuite) TestSaveContent(c *gc.C) {
 
 	val, err := client.SaveContent(uri, 666, secretValue)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(val, jc.DeepEquals, coresecrets.ValueRef{
-		BackendID:  "backend-id2",
-		RevisionID: "rev-id",
+	c.Assert(val, jc.DeepEquals, secrets.ContentParams{
+		ValueRef: &coresecrets.ValueRef{
+			BackendID:  "backend-id2",
+			RevisionID: "rev-id",
+		},
 	})
 }
 
@@ -100,8 +100,9 @@ type BackendsClient interface {
 	// one is configured, or from Juju.
 	GetRevisionContent(uri *secrets.URI, revision int) (secrets.SecretValue, error)
 
-	// SaveContent saves the content of a secret to an external backend returning the backend id.
-	SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (secrets.ValueRef, error)
+	// SaveContent saves the content of a secret to an external backend returning
+	// the backend reference, and any sealed content to be stored by Juju.
+	SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (ContentParams, error)
 
 	// DeleteContent deletes a secret from an external backend
 	// if it exists there.
@@ -5,6 +5,7 @@ package all
 
 import (
 	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/encrypted"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
@@ -14,4 +15,5 @@ func init() {
 	provider.Register(juju.NewProvider())
 	provider.Register(kubernetes.NewProvider())
 	provider.Register(vault.NewProvider())
+	provider.Register(encrypted.NewProvider())
 }
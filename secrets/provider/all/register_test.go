@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/secrets/provider"
 	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/encrypted"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
@@ -26,6 +27,7 @@ func (s *allSuite) TestInit(c *gc.C) {
 		juju.BackendType,
 		kubernetes.BackendType,
 		vault.BackendType,
+		encrypted.BackendType,
 	} {
 		p, err := provider.Provider(name)
 		c.Check(err, jc.ErrorIsNil)
@@ -22,6 +22,45 @@ type SecretsBackend interface {
 	DeleteContent(_ context.Context, revisionId string) error
 }
 
+// SealedContentBackend is implemented by secrets backends which hold
+// no content of their own. The content of each revision is sealed by
+// the backend, and the sealed content is stored by Juju along with
+// the revision ID, which is an opaque reference to it.
+type SealedContentBackend interface {
+	// SealContent returns the ID of a new secret revision, and its
+	// sealed content to be stored by Juju.
+	SealContent(_ context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (string, secrets.SecretValue, error)
+
+	// OpenContent returns the content of the secret revision from
+	// its sealed content.
+	OpenContent(_ context.Context, revisionId string, sealed secrets.SecretValue) (secrets.SecretValue, error)
+}
+
+// SaveContent saves the content of a secret revision to the backend,
+// returning the revision ID. If the backend seals content, the sealed
+// content, which Juju must store with the revision, is returned too.
+func SaveContent(
+	ctx context.Context, b SecretsBackend, uri *secrets.URI, revision int, value secrets.SecretValue,
+) (string, secrets.SecretValue, error) {
+	if sb, ok := b.(SealedContentBackend); ok {
+		return sb.SealContent(ctx, uri, revision, value)
+	}
+	revisionId, err := b.SaveContent(ctx, uri, revision, value)
+	return revisionId, nil, err
+}
+
+// GetContent returns the content of a secret revision from the
+// backend. stored is the content Juju holds for the revision, which is
+// opened by backends that seal content.
+func GetContent(
+	ctx context.Context, b SecretsBackend, revisionId string, stored secrets.SecretValue,
+) (secrets.SecretValue, error) {
+	if sb, ok := b.(SealedContentBackend); ok {
+		return sb.OpenContent(ctx, revisionId, stored)
+	}
+	return b.GetContent(ctx, revisionId)
+}
+
 // BackendConfig is used when constructing a secrets backend.
 type BackendConfig struct {
 	BackendType string
@@ -35,6 +74,11 @@ type ModelBackendConfig struct {
 	ModelUUID      string
 	ModelName      string
 	BackendConfig
+
+	// ModelKey is the data key of the model, wrapped by a master key,
+	// for backends which support model keys. It is only set in the
+	// admin config used on the controller.
+	ModelKey *secrets.WrappedKey
 }
 
 // ModelBackendConfigInfo holds secret backends, one of which
@@ -0,0 +1,173 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+const (
+	// revisionIDPrefix identifies the encryption scheme of a
+	// revision.
+	revisionIDPrefix = "x25519-aes-gcm:"
+
+	// sealedContentKey holds the encrypted content of a revision in
+	// the sealed content stored by Juju.
+	sealedContentKey = "sealed"
+)
+
+// parseRevisionID returns the ephemeral public key of the revision
+// with the given ID. The revision ID is the encoded public key, which
+// identifies the revision key without revealing anything about it or
+// the content:
+//
+//	x25519-aes-gcm:<ephemeral public key>
+func parseRevisionID(revisionId string) (*ecdh.PublicKey, error) {
+	notFound := errors.NotFoundf("secret revision %q", revisionId)
+	encoded, ok := strings.CutPrefix(revisionId, revisionIDPrefix)
+	if !ok {
+		return nil, notFound
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, notFound
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, notFound
+	}
+	return ephemeral, nil
+}
+
+func revisionID(ephemeral *ecdh.PublicKey) string {
+	return revisionIDPrefix + base64.RawURLEncoding.EncodeToString(ephemeral.Bytes())
+}
+
+type encryptedBackend struct {
+	modelUUID string
+	// publicKey is the model public key, used to encrypt new content.
+	publicKey *ecdh.PublicKey
+
+	// modelKey is only set on the controller, which unwraps it
+	// with a master key, and can derive the key of any revision.
+	modelKey    *ecdh.PrivateKey
+	store       *keyStore
+	masterKeyID string
+
+	// revisionKeys are handed to agents, keyed on the ID of the
+	// revisions they may read.
+	revisionKeys map[string][]byte
+}
+
+// revisionKey returns the key which encrypts the content of the
+// given revision.
+func (k *encryptedBackend) revisionKey(revisionId string) ([]byte, error) {
+	ephemeral, err := parseRevisionID(revisionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if k.modelKey == nil {
+		key, ok := k.revisionKeys[revisionId]
+		if !ok {
+			return nil, errors.Unauthorizedf("reading secret revision not shared with this agent")
+		}
+		return key, nil
+	}
+	return openRevisionKey(k.modelKey, ephemeral, k.modelUUID)
+}
+
+// OpenContent implements SealedContentBackend.
+func (k *encryptedBackend) OpenContent(ctx context.Context, revisionId string, sealed secrets.SecretValue) (secrets.SecretValue, error) {
+	key, err := k.revisionKey(revisionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var encoded string
+	if sealed != nil {
+		encoded = sealed.EncodedValues()[sealedContentKey]
+	}
+	if encoded == "" {
+		return nil, errors.NotFoundf("content of secret revision %q", revisionId)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.NotValidf("sealed content of secret revision %q", revisionId)
+	}
+	plaintext, err := open(key, data, []byte(k.modelUUID))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading secret content")
+	}
+	var val map[string]string
+	if err := json.Unmarshal(plaintext, &val); err != nil {
+		return nil, errors.Annotate(err, "reading secret content")
+	}
+	return secrets.NewSecretValue(val), nil
+}
+
+// SealContent implements SealedContentBackend.
+// The content is encrypted with a new revision key, agreed with the
+// model public key, so that only the controller can derive the key to
+// read it again.
+func (k *encryptedBackend) SealContent(
+	ctx context.Context, uri *secrets.URI, revision int, value secrets.SecretValue,
+) (string, secrets.SecretValue, error) {
+	if k.publicKey == nil {
+		return "", nil, errors.NotSupportedf("saving content without a model public key")
+	}
+	plaintext, err := json.Marshal(value.EncodedValues())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	ephemeral, key, err := newRevisionKey(k.publicKey, k.modelUUID)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	data, err := seal(key, plaintext, []byte(k.modelUUID))
+	if err != nil {
+		return "", nil, errors.Annotatef(err, "saving secret content for %q", uri.Name(revision))
+	}
+	sealed := secrets.NewSecretValue(map[string]string{
+		sealedContentKey: base64.StdEncoding.EncodeToString(data),
+	})
+	return revisionID(ephemeral), sealed, nil
+}
+
+// GetContent implements SecretsBackend.
+// The content is held by Juju, sealed; see OpenContent.
+func (k *encryptedBackend) GetContent(ctx context.Context, revisionId string) (secrets.SecretValue, error) {
+	return nil, errors.NotImplementedf("reading secret content without its sealed content")
+}
+
+// SaveContent implements SecretsBackend.
+// The content must be stored by Juju, sealed; see SealContent.
+func (k *encryptedBackend) SaveContent(ctx context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (string, error) {
+	return "", errors.NotImplementedf("saving secret content without sealing it")
+}
+
+// DeleteContent implements SecretsBackend.
+// The sealed content is removed along with the revision, so there is
+// nothing else to delete.
+func (k *encryptedBackend) DeleteContent(ctx context.Context, revisionId string) error {
+	_, err := parseRevisionID(revisionId)
+	return errors.Trace(err)
+}
+
+// Ping implements SecretsBackend.
+func (k *encryptedBackend) Ping() error {
+	if k.store == nil {
+		return nil
+	}
+	if _, err := k.store.masterKey(k.masterKeyID); err != nil {
+		return errors.Annotate(err, "backend not usable")
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	coreconfig "github.com/juju/juju/core/config"
+	"github.com/juju/juju/core/paths"
+	"github.com/juju/juju/secrets/provider"
+)
+
+const (
+	KeyDirKey      = "key-dir"
+	MasterKeyIDKey = "master-key-id"
+
+	// PublicKeyKey holds the model public key in the config handed to
+	// agents, with which they encrypt new content.
+	PublicKeyKey = "public-key"
+
+	// RevisionKeysKey holds, in the config handed to agents, the keys
+	// of the revisions they may read. Neither it nor PublicKeyKey is
+	// ever part of the stored backend config.
+	RevisionKeysKey = "revision-keys"
+)
+
+// agentKeys are the config keys only found in the config handed to
+// agents.
+var agentKeys = []string{PublicKeyKey, RevisionKeysKey}
+
+var configSchema = environschema.Fields{
+	KeyDirKey: {
+		Description: "The directory on the controller machines holding the master keys.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	MasterKeyIDKey: {
+		Description: "The ID of the master key which wraps the model keys. " +
+			"The key must be installed on every controller machine. Setting a new ID rotates the master key.",
+		Type:      environschema.Tstring,
+		Mandatory: true,
+	},
+}
+
+var configDefaults = schema.Defaults{
+	KeyDirKey: filepath.Join(paths.DataDir(paths.CurrentOS()), "secret-keys"),
+}
+
+type backendConfig struct {
+	validAttrs map[string]interface{}
+	// The agent keys are not part of the schema so are held separately.
+	encodedPublicKey    string
+	encodedRevisionKeys string
+}
+
+func (c *backendConfig) keyDir() string {
+	return c.validAttrs[KeyDirKey].(string)
+}
+
+func (c *backendConfig) masterKeyID() string {
+	v, _ := c.validAttrs[MasterKeyIDKey].(string)
+	return v
+}
+
+// isAgent reports whether the config is one handed to an agent.
+func (c *backendConfig) isAgent() bool {
+	return c.encodedPublicKey != ""
+}
+
+// publicKey returns the model public key, if the config is one handed
+// to an agent.
+func (c *backendConfig) publicKey() (*ecdh.PublicKey, error) {
+	data, err := base64.RawURLEncoding.DecodeString(c.encodedPublicKey)
+	if err != nil {
+		return nil, errors.NotValidf("public key")
+	}
+	key, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, errors.NotValidf("public key")
+	}
+	return key, nil
+}
+
+// revisionKeys returns the keys of the revisions an agent may read,
+// keyed on revision ID.
+func (c *backendConfig) revisionKeys() (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	if c.encodedRevisionKeys == "" {
+		return keys, nil
+	}
+	for _, entry := range strings.Split(c.encodedRevisionKeys, ",") {
+		// Revision IDs hold a colon, but encoded keys do not.
+		i := strings.LastIndex(entry, ":")
+		if i < 0 {
+			return nil, errors.NotValidf("revision keys")
+		}
+		id, encoded := entry[:i], entry[i+1:]
+		key, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.NotValidf("revision keys")
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// encodeRevisionKeys encodes the revision keys for the config handed
+// to an agent.
+func encodeRevisionKeys(keys map[string][]byte) string {
+	entries := make([]string, 0, len(keys))
+	for id, key := range keys {
+		entries = append(entries, id+":"+base64.RawURLEncoding.EncodeToString(key))
+	}
+	return strings.Join(entries, ",")
+}
+
+// ConfigSchema implements SecretBackendProvider.
+func (p encryptedProvider) ConfigSchema() environschema.Fields {
+	return configSchema
+}
+
+// ConfigDefaults implements SecretBackendProvider.
+func (p encryptedProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+// ValidateConfig implements SecretBackendProvider.
+// The master key is not read here, as the config may be validated on
+// a controller other than those which use it. Ping checks the key is
+// installed.
+func (p encryptedProvider) ValidateConfig(oldCfg, newCfg provider.ConfigAttrs) error {
+	for _, key := range agentKeys {
+		if _, ok := newCfg[key]; ok {
+			return errors.NotValidf("setting %q", key)
+		}
+	}
+	newValidCfg, err := newConfig(newCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !filepath.IsAbs(newValidCfg.keyDir()) {
+		return errors.NotValidf("relative key directory %q", newValidCfg.keyDir())
+	}
+	masterKeyID := newValidCfg.masterKeyID()
+	if !validKeyID(masterKeyID) {
+		return errors.NotValidf("master key ID %q", masterKeyID)
+	}
+
+	if oldCfg == nil {
+		return nil
+	}
+	oldValidCfg, err := newConfig(oldCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for n, field := range configSchema {
+		if !field.Immutable {
+			continue
+		}
+		if oldValidCfg.validAttrs[n] != newValidCfg.validAttrs[n] {
+			return errors.Errorf("cannot change immutable field %q", n)
+		}
+	}
+	if oldValidCfg.masterKeyID() != masterKeyID {
+		logger.Infof("rotating secrets master key from %q to %q; model keys will be re-wrapped",
+			oldValidCfg.masterKeyID(), masterKeyID)
+	}
+	return nil
+}
+
+// validKeyID reports whether the master key ID can name a key file.
+func validKeyID(id string) bool {
+	return id != "" && id != "." && id != ".." && filepath.Base(id) == id
+}
+
+func newConfig(attrs map[string]interface{}) (*backendConfig, error) {
+	schemaAttrs := make(map[string]interface{})
+	for k, v := range attrs {
+		if k != PublicKeyKey && k != RevisionKeysKey {
+			schemaAttrs[k] = v
+		}
+	}
+	cfg, err := coreconfig.NewConfig(schemaAttrs, configSchema, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	publicKey, _ := attrs[PublicKeyKey].(string)
+	revisionKeys, _ := attrs[RevisionKeysKey].(string)
+	return &backendConfig{
+		validAttrs:          cfg.Attributes(),
+		encodedPublicKey:    publicKey,
+		encodedRevisionKeys: revisionKeys,
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted_test
+
+import (
+	"os"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/encrypted"
+)
+
+type configSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&configSuite{})
+
+func configValidator(c *gc.C) provider.ProviderConfig {
+	p, err := provider.Provider(encrypted.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator, ok := p.(provider.ProviderConfig)
+	c.Assert(ok, jc.IsTrue)
+	return configValidator
+}
+
+func (s *configSuite) TestValidateConfigErrors(c *gc.C) {
+	keyDir := c.MkDir()
+	for _, t := range []struct {
+		cfg    map[string]interface{}
+		oldCfg map[string]interface{}
+		err    string
+	}{{
+		cfg: map[string]interface{}{"key-dir": "keys", "master-key-id": "1"},
+		err: `relative key directory "keys" not valid`,
+	}, {
+		cfg:    map[string]interface{}{"key-dir": "/new", "master-key-id": "1"},
+		oldCfg: map[string]interface{}{"key-dir": "/old", "master-key-id": "1"},
+		err:    `cannot change immutable field "key-dir"`,
+	}, {
+		cfg: map[string]interface{}{"key-dir": keyDir},
+		err: `master-key-id: expected string, got nothing`,
+	}, {
+		cfg: map[string]interface{}{"key-dir": keyDir, "master-key-id": "../1"},
+		err: `master key ID "../1" not valid`,
+	}, {
+		cfg: map[string]interface{}{"key-dir": keyDir, "master-key-id": "1", "public-key": "a2V5"},
+		err: `setting "public-key" not valid`,
+	}, {
+		cfg: map[string]interface{}{"key-dir": keyDir, "master-key-id": "1", "revision-keys": "a:a2V5"},
+		err: `setting "revision-keys" not valid`,
+	}} {
+		err := configValidator(c).ValidateConfig(t.oldCfg, t.cfg)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *configSuite) TestValidateConfigDoesNotWriteKeys(c *gc.C) {
+	keyDir := c.MkDir()
+	cfg := map[string]interface{}{"key-dir": keyDir, "master-key-id": "first"}
+	err := configValidator(c).ValidateConfig(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The master key is installed by the operator on every controller
+	// machine, not by whichever controller validates the config.
+	entries, err := os.ReadDir(keyDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+
+	newCfg := map[string]interface{}{"key-dir": keyDir, "master-key-id": "second"}
+	err = configValidator(c).ValidateConfig(cfg, newCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err = os.ReadDir(keyDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+// keySize is the size of the master, model and revision keys; AES-256
+// and X25519 are used.
+const keySize = 32
+
+// newModelKey returns a new random model key pair.
+func newModelKey() (*ecdh.PrivateKey, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	return key, errors.Annotate(err, "generating model key")
+}
+
+// wrapModelKey encrypts the private model key with the master key. The
+// wrapped key can only be unwrapped for the same model.
+func wrapModelKey(masterKey []byte, key *ecdh.PrivateKey, modelUUID string) ([]byte, error) {
+	return seal(masterKey, key.Bytes(), []byte(modelUUID))
+}
+
+// unwrapModelKey decrypts a private model key wrapped by wrapModelKey.
+func unwrapModelKey(masterKey, wrapped []byte, modelUUID string) (*ecdh.PrivateKey, error) {
+	data, err := open(masterKey, wrapped, []byte(modelUUID))
+	if err != nil {
+		return nil, errors.Annotate(err, "unwrapping model key")
+	}
+	return ecdh.X25519().NewPrivateKey(data)
+}
+
+// revisionKey derives the key which encrypts the content of a single
+// revision from the shared secret of the revision's ephemeral key pair
+// and the model key pair.
+func revisionKey(shared []byte, ephemeral, model *ecdh.PublicKey, modelUUID string) ([]byte, error) {
+	salt := append(ephemeral.Bytes(), model.Bytes()...)
+	key := make([]byte, keySize)
+	kdf := hkdf.New(sha256.New, shared, salt, []byte("juju-secrets-revision:"+modelUUID))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, errors.Annotate(err, "deriving revision key")
+	}
+	return key, nil
+}
+
+// newRevisionKey returns a new ephemeral public key, and the revision
+// key agreed between it and the model public key. Only the holder of
+// the model private key can derive the revision key again.
+func newRevisionKey(model *ecdh.PublicKey, modelUUID string) (*ecdh.PublicKey, []byte, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "generating ephemeral key")
+	}
+	shared, err := ephemeral.ECDH(model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	key, err := revisionKey(shared, ephemeral.PublicKey(), model, modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return ephemeral.PublicKey(), key, nil
+}
+
+// openRevisionKey derives the revision key for the given ephemeral
+// public key using the model private key.
+func openRevisionKey(model *ecdh.PrivateKey, ephemeral *ecdh.PublicKey, modelUUID string) ([]byte, error) {
+	shared, err := model.ECDH(ephemeral)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return revisionKey(shared, ephemeral, model.PublicKey(), modelUUID)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, errors.NotValidf("key of %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts and authenticates the plaintext, along with the
+// additional data, and returns the nonce followed by the ciphertext.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Annotate(err, "generating nonce")
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the output of seal, failing if either the ciphertext
+// or the additional data have been tampered with.
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.NotValidf("encrypted data of %d bytes", len(sealed))
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.Annotate(err, "decrypting")
+	}
+	return plaintext, nil
+}
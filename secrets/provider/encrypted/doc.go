@@ -0,0 +1,31 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package encrypted provides a secrets backend which stores secret
+// content in the Juju database, encrypted at rest.
+//
+// The backend is configured with the ID of a master key, held in a key
+// directory on the controller machines, outside of the database, so a
+// copy of the database alone does not reveal any secret content. The
+// master keys are installed by the operator: each is 32 random bytes in
+// <key-dir>/master/<id>.key, and must be the same on every controller
+// machine. Juju never writes them.
+//
+// Each model has a random X25519 key pair, its data key. The private
+// key is wrapped (encrypted) by the master key, and the wrapped key,
+// along with the ID of the master key, is stored by Juju. The content of
+// every secret revision is encrypted using AES-GCM with its own key,
+// agreed between a new ephemeral key pair and the model public key.
+// The encrypted content is stored by Juju as the content of the
+// revision. The revision ID is the ephemeral public key, an opaque
+// reference from which the controller can derive the revision key
+// again.
+//
+// Agents are handed the model public key, with which they encrypt new
+// content, and the keys of just those revisions they may read.
+//
+// Rotating the master key, by installing a new key and setting its ID
+// in the backend config, re-wraps the data key of each model with the
+// new master key. The content is unchanged, and the old master key is
+// no longer needed once the update has completed.
+package encrypted
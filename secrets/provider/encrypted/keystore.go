@@ -0,0 +1,68 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted
+
+import (
+	"crypto/ecdh"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+const (
+	masterKeysDir = "master"
+	keyFileSuffix = ".key"
+)
+
+// keyStore reads the master keys from a directory on the controller.
+// The keys are installed by the operator, and must be the same on
+// every controller machine; Juju never writes them.
+type keyStore struct {
+	dir string
+}
+
+func (s keyStore) masterKeyPath(id string) string {
+	return filepath.Join(s.dir, masterKeysDir, id+keyFileSuffix)
+}
+
+// masterKey returns the master key with the given ID.
+func (s keyStore) masterKey(id string) ([]byte, error) {
+	key, err := os.ReadFile(s.masterKeyPath(id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("master key %q in %q", id, s.dir)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading master key %q", id)
+	}
+	if len(key) != keySize {
+		return nil, errors.NotValidf("master key %q of %d bytes", id, len(key))
+	}
+	return key, nil
+}
+
+// wrap returns the model key wrapped by the master key with the
+// given ID.
+func (s keyStore) wrap(masterKeyID string, key *ecdh.PrivateKey, modelUUID string) (*secrets.WrappedKey, error) {
+	masterKey, err := s.masterKey(masterKeyID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wrapped, err := wrapModelKey(masterKey, key, modelUUID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "wrapping model key with master key %q", masterKeyID)
+	}
+	return &secrets.WrappedKey{MasterKeyID: masterKeyID, Key: wrapped}, nil
+}
+
+// unwrap returns the model key wrapped by one of the master keys.
+func (s keyStore) unwrap(wrapped secrets.WrappedKey, modelUUID string) (*ecdh.PrivateKey, error) {
+	masterKey, err := s.masterKey(wrapped.MasterKeyID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unwrapModelKey(masterKey, wrapped.Key, modelUUID)
+}
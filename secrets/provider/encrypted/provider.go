@@ -0,0 +1,175 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted
+
+import (
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+var logger = loggo.GetLogger("juju.secrets.encrypted")
+
+const (
+	// BackendType is the type of the encrypted secrets backend.
+	BackendType = "encrypted"
+)
+
+// NewProvider returns an encrypted secrets provider.
+func NewProvider() provider.SecretBackendProvider {
+	return encryptedProvider{}
+}
+
+type encryptedProvider struct {
+}
+
+func (p encryptedProvider) Type() string {
+	return BackendType
+}
+
+// Initialise is not used; Juju creates the data key of each model
+// with NewModelKey and stores it wrapped.
+func (p encryptedProvider) Initialise(*provider.ModelBackendConfig) error {
+	return nil
+}
+
+// CleanupModel is not used. The sealed content and the wrapped model
+// key are stored by Juju, which removes them along with the model.
+func (p encryptedProvider) CleanupModel(*provider.ModelBackendConfig) error {
+	return nil
+}
+
+// CleanupSecrets is not used; the sealed content of each revision is
+// removed along with the revision.
+func (p encryptedProvider) CleanupSecrets(cfg *provider.ModelBackendConfig, tag names.Tag, removed provider.SecretRevisions) error {
+	return nil
+}
+
+// NewModelKey implements SupportModelKeys.
+// The model key is a random X25519 key pair. The private key is
+// wrapped by the master key named in the config.
+func (p encryptedProvider) NewModelKey(cfg *provider.ModelBackendConfig) (*secrets.WrappedKey, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid encrypted backend config")
+	}
+	key, err := newModelKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store := keyStore{dir: validCfg.keyDir()}
+	return store.wrap(validCfg.masterKeyID(), key, cfg.ModelUUID)
+}
+
+// RewrapModelKey implements SupportModelKeys.
+// Only the model key is re-wrapped when the master key is rotated; the
+// content it protects is unchanged.
+func (p encryptedProvider) RewrapModelKey(cfg *provider.ModelBackendConfig, wrapped secrets.WrappedKey) (*secrets.WrappedKey, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid encrypted backend config")
+	}
+	if wrapped.MasterKeyID == validCfg.masterKeyID() {
+		return &wrapped, nil
+	}
+	store := keyStore{dir: validCfg.keyDir()}
+	key, err := store.unwrap(wrapped, cfg.ModelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store.wrap(validCfg.masterKeyID(), key, cfg.ModelUUID)
+}
+
+// RestrictedConfig returns the config needed to create a
+// secrets backend client for the given entity tag. The config
+// holds the model public key, with which the agent can encrypt
+// new content, and the keys of just those revisions the entity
+// owns or may read. Agents are never handed a master key or the
+// model private key.
+func (p encryptedProvider) RestrictedConfig(
+	adminCfg *provider.ModelBackendConfig, forDrain bool, tag names.Tag, owned provider.SecretRevisions, read provider.SecretRevisions,
+) (*provider.BackendConfig, error) {
+	backend, err := newControllerBackend(adminCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backend.modelKey == nil {
+		return nil, errors.NotValidf("restricted config without a model key")
+	}
+	revisionKeys := make(map[string][]byte)
+	for _, revisions := range []provider.SecretRevisions{owned, read} {
+		for _, revisionID := range revisions.RevisionIDs() {
+			if revisionKeys[revisionID], err = backend.revisionKey(revisionID); err != nil {
+				return nil, errors.Annotatef(err, "deriving key of secret revision")
+			}
+		}
+	}
+	cfg := provider.BackendConfig{
+		BackendType: BackendType,
+		Config: provider.ConfigAttrs{
+			MasterKeyIDKey:  backend.masterKeyID,
+			PublicKeyKey:    base64.RawURLEncoding.EncodeToString(backend.publicKey.Bytes()),
+			RevisionKeysKey: encodeRevisionKeys(revisionKeys),
+		},
+	}
+	return &cfg, nil
+}
+
+// NewBackend returns an encrypted secrets backend client. On the
+// controller, the model key is unwrapped with a master key from the
+// key directory; agents are handed the keys they need in their
+// restricted config.
+func (p encryptedProvider) NewBackend(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid encrypted backend config")
+	}
+	if !validCfg.isAgent() {
+		return newControllerBackend(cfg)
+	}
+	publicKey, err := validCfg.publicKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisionKeys, err := validCfg.revisionKeys()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptedBackend{
+		modelUUID:    cfg.ModelUUID,
+		publicKey:    publicKey,
+		revisionKeys: revisionKeys,
+	}, nil
+}
+
+// newControllerBackend returns a backend client which unwraps the
+// model key in the config with a master key from the key directory.
+func newControllerBackend(cfg *provider.ModelBackendConfig) (*encryptedBackend, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid encrypted backend config")
+	}
+	backend := &encryptedBackend{
+		modelUUID:   cfg.ModelUUID,
+		store:       &keyStore{dir: validCfg.keyDir()},
+		masterKeyID: validCfg.masterKeyID(),
+	}
+	// A backend without a model key is only used to check the
+	// master key.
+	if cfg.ModelKey == nil {
+		return backend, nil
+	}
+	modelKey, err := backend.store.unwrap(*cfg.ModelKey, cfg.ModelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend.modelKey = modelKey
+	backend.publicKey = modelKey.PublicKey()
+	return backend, nil
+}
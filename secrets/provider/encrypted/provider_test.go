@@ -0,0 +1,314 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package encrypted_test
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/encrypted"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	testing.IsolationSuite
+
+	keyDir   string
+	config   provider.ConfigAttrs
+	modelKey *coresecrets.WrappedKey
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.keyDir = c.MkDir()
+	s.modelKey = nil
+	writeMasterKey(c, s.keyDir, "first")
+	s.config = provider.ConfigAttrs{"key-dir": s.keyDir, "master-key-id": "first"}
+	err := configValidator(c).ValidateConfig(nil, s.config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// writeMasterKey installs a new master key, as an operator would.
+func writeMasterKey(c *gc.C, keyDir, id string) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.MkdirAll(filepath.Join(keyDir, "master"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(keyDir, "master", id+".key"), key, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) modelConfig() *provider.ModelBackendConfig {
+	return &provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: encrypted.BackendType,
+			Config:      s.config,
+		},
+	}
+}
+
+// adminConfig returns the admin config, holding the model key, as
+// Juju hands it to the provider on the controller.
+func (s *providerSuite) adminConfig() *provider.ModelBackendConfig {
+	cfg := s.modelConfig()
+	cfg.ModelKey = s.modelKey
+	return cfg
+}
+
+func (s *providerSuite) provider(c *gc.C) provider.SecretBackendProvider {
+	p, err := provider.Provider(encrypted.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.HasModelKeys(p), jc.IsTrue)
+	err = p.Initialise(s.modelConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	if s.modelKey == nil {
+		s.modelKey, err = p.(provider.SupportModelKeys).NewModelKey(s.modelConfig())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return p
+}
+
+type savedRevision struct {
+	uri        *coresecrets.URI
+	revisionID string
+	sealed     coresecrets.SecretValue
+}
+
+func (s *providerSuite) save(c *gc.C, b provider.SecretsBackend, value string) savedRevision {
+	uri := coresecrets.NewURI()
+	revisionID, sealed, err := provider.SaveContent(
+		context.Background(), b, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": value}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sealed, gc.NotNil)
+	return savedRevision{uri: uri, revisionID: revisionID, sealed: sealed}
+}
+
+func (s *providerSuite) get(b provider.SecretsBackend, rev savedRevision) (coresecrets.SecretValue, error) {
+	return provider.GetContent(context.Background(), b, rev.revisionID, rev.sealed)
+}
+
+func (s *providerSuite) agentBackend(
+	c *gc.C, p provider.SecretBackendProvider, owned, read provider.SecretRevisions,
+) provider.SecretsBackend {
+	adminCfg := s.adminConfig()
+	cfg, err := p.RestrictedConfig(adminCfg, false, names.NewUnitTag("ubuntu/0"), owned, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["public-key"], gc.Not(gc.Equals), "")
+	c.Assert(cfg.Config["key-dir"], gc.IsNil)
+
+	b, err := p.NewBackend(&provider.ModelBackendConfig{
+		ControllerUUID: adminCfg.ControllerUUID,
+		ModelUUID:      adminCfg.ModelUUID,
+		ModelName:      adminCfg.ModelName,
+		BackendConfig:  *cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return b
+}
+
+func (s *providerSuite) TestNewModelKey(c *gc.C) {
+	p := s.provider(c)
+	c.Check(s.modelKey.MasterKeyID, gc.Equals, "first")
+	c.Check(s.modelKey.Key, gc.Not(gc.HasLen), 0)
+
+	other, err := p.(provider.SupportModelKeys).NewModelKey(s.modelConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(other.Key, gc.Not(jc.DeepEquals), s.modelKey.Key)
+}
+
+func (s *providerSuite) TestSaveAndGetContent(c *gc.C) {
+	p := s.provider(c)
+	b, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+
+	rev := s.save(c, b, "YmFy")
+	c.Check(strings.HasPrefix(rev.revisionID, "x25519-aes-gcm:"), jc.IsTrue)
+	c.Check(rev.sealed.EncodedValues()["sealed"], gc.Not(gc.Equals), "")
+	c.Check(rev.sealed.EncodedValues()["foo"], gc.Equals, "")
+
+	val, err := s.get(b, rev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	err = b.DeleteContent(context.Background(), rev.revisionID)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestContentMustBeSealed(c *gc.C) {
+	p := s.provider(c)
+	b, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = b.SaveContent(context.Background(), coresecrets.NewURI(), 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	rev := s.save(c, b, "YmFy")
+	_, err = b.GetContent(context.Background(), rev.revisionID)
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *providerSuite) TestAgentBackend(c *gc.C) {
+	p := s.provider(c)
+	admin, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	readable := s.save(c, admin, "YmFy")
+	other := s.save(c, admin, "YmF6")
+
+	read := provider.SecretRevisions{}
+	read.Add(readable.uri, readable.revisionID)
+	b := s.agentBackend(c, p, nil, read)
+
+	// Agents do not need the key directory.
+	err = os.RemoveAll(s.keyDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+
+	val, err := s.get(b, readable)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	// Only the revisions the agent may read can be decrypted.
+	_, err = s.get(b, other)
+	c.Check(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *providerSuite) TestAgentSavesContent(c *gc.C) {
+	p := s.provider(c)
+	b := s.agentBackend(c, p, nil, nil)
+	rev := s.save(c, b, "YmFy")
+
+	// The agent cannot read the content back until it is handed
+	// the key of the revision it owns.
+	_, err := s.get(b, rev)
+	c.Check(err, jc.Satisfies, errors.IsUnauthorized)
+
+	owned := provider.SecretRevisions{}
+	owned.Add(rev.uri, rev.revisionID)
+	b = s.agentBackend(c, p, owned, nil)
+	val, err := s.get(b, rev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	// The controller can read it.
+	admin, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	val, err = s.get(admin, rev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
+
+func (s *providerSuite) TestGetContentOtherModel(c *gc.C) {
+	p := s.provider(c)
+	b, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	rev := s.save(c, b, "YmFy")
+
+	// The model key can only be unwrapped for its own model.
+	other := s.adminConfig()
+	other.ModelUUID = coretesting.ModelTag.Id()[:35] + "0"
+	_, err = p.NewBackend(other)
+	c.Check(err, gc.ErrorMatches, "unwrapping model key: decrypting: .*")
+
+	// Nor can the content of one model be read with the key of
+	// another.
+	other.ModelKey, err = p.(provider.SupportModelKeys).NewModelKey(other)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err = p.NewBackend(other)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.get(b, rev)
+	c.Check(err, gc.ErrorMatches, "reading secret content: decrypting: .*")
+}
+
+func (s *providerSuite) TestGetContentNotFound(c *gc.C) {
+	p := s.provider(c)
+	b, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = provider.GetContent(context.Background(), b, "some-id", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = b.DeleteContent(context.Background(), "some-id")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	// The revision ID alone does not hold the content.
+	rev := s.save(c, b, "YmFy")
+	_, err = provider.GetContent(context.Background(), b, rev.revisionID, nil)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *providerSuite) TestRotateMasterKey(c *gc.C) {
+	p := s.provider(c)
+	b, err := p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	rev := s.save(c, b, "YmFy")
+
+	writeMasterKey(c, s.keyDir, "second")
+	newCfg := provider.ConfigAttrs{"key-dir": s.keyDir, "master-key-id": "second"}
+	err = configValidator(c).ValidateConfig(s.config, newCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config = newCfg
+
+	// Only the model key is re-wrapped.
+	rewrapped, err := p.(provider.SupportModelKeys).RewrapModelKey(s.modelConfig(), *s.modelKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rewrapped.MasterKeyID, gc.Equals, "second")
+	s.modelKey = rewrapped
+	again, err := p.(provider.SupportModelKeys).RewrapModelKey(s.modelConfig(), *s.modelKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(again, jc.DeepEquals, rewrapped)
+
+	// The old master key is no longer needed to read the content
+	// saved before the rotation.
+	err = os.Remove(filepath.Join(s.keyDir, "master", "first.key"))
+	c.Assert(err, jc.ErrorIsNil)
+	b, err = p.NewBackend(s.adminConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+	val, err := s.get(b, rev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	read := provider.SecretRevisions{}
+	read.Add(rev.uri, rev.revisionID)
+	agent := s.agentBackend(c, p, nil, read)
+	val, err = s.get(agent, rev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
+
+func (s *providerSuite) TestRewrapMissingMasterKey(c *gc.C) {
+	p := s.provider(c)
+	s.config = provider.ConfigAttrs{"key-dir": s.keyDir, "master-key-id": "missing"}
+	_, err := p.(provider.SupportModelKeys).RewrapModelKey(s.modelConfig(), *s.modelKey)
+	c.Check(err, gc.ErrorMatches, `master key "missing" in ".*" not found`)
+}
+
+func (s *providerSuite) TestPingMissingMasterKey(c *gc.C) {
+	p, err := provider.Provider(encrypted.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := p.NewBackend(&provider.ModelBackendConfig{
+		BackendConfig: provider.BackendConfig{
+			BackendType: encrypted.BackendType,
+			Config:      provider.ConfigAttrs{"key-dir": s.keyDir, "master-key-id": "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = b.Ping()
+	c.Check(err, gc.ErrorMatches, `backend not usable: master key "missing" in ".*" not found`)
+}
@@ -100,3 +100,25 @@ func HasAuthRefresh(p SecretBackendProvider) bool {
 	_, ok := p.(SupportAuthRefresh)
 	return ok
 }
+
+// SupportModelKeys is implemented by providers which encrypt secret
+// content with a data key for each model. Juju stores the data keys,
+// wrapped by a master key of the backend, and passes them to the
+// provider in the admin config.
+type SupportModelKeys interface {
+	// NewModelKey returns a new data key for the model, wrapped by
+	// the master key named in the backend config.
+	NewModelKey(cfg *ModelBackendConfig) (*secrets.WrappedKey, error)
+
+	// RewrapModelKey returns the data key of the model wrapped by the
+	// master key named in the backend config. The key is returned
+	// unchanged if it is already wrapped by that master key.
+	RewrapModelKey(cfg *ModelBackendConfig, key secrets.WrappedKey) (*secrets.WrappedKey, error)
+}
+
+// HasModelKeys returns true if the provider encrypts content with
+// model data keys.
+func HasModelKeys(p SecretBackendProvider) bool {
+	_, ok := p.(SupportModelKeys)
+	return ok
+}
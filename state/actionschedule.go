@@ -0,0 +1,299 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// maxScheduledRuns is the number of most recent runs kept in the
+// history of an action schedule.
+const maxScheduledRuns = 10
+
+// ActionSchedule describes an action which is run on a schedule.
+type ActionSchedule struct {
+	// Name uniquely identifies the schedule within the model.
+	Name string
+
+	// Schedule determines when the action runs.
+	Schedule actions.Schedule
+
+	// Targets are the units, in the form mysql/0 or mysql/leader,
+	// and applications the action runs on. The units of an
+	// application are resolved on each run.
+	Targets []string
+
+	// Action is the name of the action to run.
+	Action string
+
+	// Parameters are passed to the action on each run.
+	Parameters map[string]interface{}
+
+	// ConcurrencyPolicy determines what happens when a run is due
+	// while the operation started by the previous run is active.
+	ConcurrencyPolicy actions.ConcurrencyPolicy
+
+	// Created is when the schedule was added.
+	Created time.Time
+
+	// NextRun is when the action is next due to run.
+	NextRun time.Time
+
+	// Runs holds the most recent runs, oldest first.
+	Runs []ScheduledRun
+}
+
+// ScheduledRun records a run of an action schedule.
+type ScheduledRun struct {
+	// Time is when the run was due.
+	Time time.Time
+
+	// OperationID identifies the operation started by the run.
+	OperationID string
+
+	// Skipped records why no operation was started.
+	Skipped string
+}
+
+type actionScheduleDoc struct {
+	DocId             string                 `bson:"_id"`
+	ModelUUID         string                 `bson:"model-uuid"`
+	Name              string                 `bson:"name"`
+	Cron              string                 `bson:"cron,omitempty"`
+	Interval          time.Duration          `bson:"interval,omitempty"`
+	Targets           []string               `bson:"targets"`
+	Action            string                 `bson:"action"`
+	Parameters        map[string]interface{} `bson:"parameters,omitempty"`
+	ConcurrencyPolicy string                 `bson:"concurrency-policy"`
+	Created           time.Time              `bson:"created"`
+	NextRun           time.Time              `bson:"next-run"`
+	Runs              []scheduledRunDoc      `bson:"runs,omitempty"`
+}
+
+type scheduledRunDoc struct {
+	Time        time.Time `bson:"time"`
+	OperationID string    `bson:"operation,omitempty"`
+	Skipped     string    `bson:"skipped,omitempty"`
+}
+
+func (doc *actionScheduleDoc) schedule() ActionSchedule {
+	result := ActionSchedule{
+		Name: doc.Name,
+		Schedule: actions.Schedule{
+			Cron:     doc.Cron,
+			Interval: doc.Interval,
+		},
+		Targets:           doc.Targets,
+		Action:            doc.Action,
+		Parameters:        doc.Parameters,
+		ConcurrencyPolicy: actions.ConcurrencyPolicy(doc.ConcurrencyPolicy),
+		Created:           doc.Created.UTC(),
+		NextRun:           doc.NextRun.UTC(),
+	}
+	for _, run := range doc.Runs {
+		result.Runs = append(result.Runs, ScheduledRun{
+			Time:        run.Time.UTC(),
+			OperationID: run.OperationID,
+			Skipped:     run.Skipped,
+		})
+	}
+	return result
+}
+
+// AddActionSchedule adds a schedule on which to run an action. The
+// schedule's creation and next run times are set here; any runs are
+// ignored.
+func (m *Model) AddActionSchedule(schedule ActionSchedule) error {
+	if !actions.IsValidScheduleName(schedule.Name) {
+		return errors.NotValidf("schedule name %q", schedule.Name)
+	}
+	if err := schedule.Schedule.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedule.Targets) == 0 {
+		return errors.NotValidf("schedule without targets")
+	}
+	if schedule.Action == "" {
+		return errors.NotValidf("schedule without action")
+	}
+	if schedule.ConcurrencyPolicy == "" {
+		schedule.ConcurrencyPolicy = actions.ConcurrencyForbid
+	}
+	if err := schedule.ConcurrencyPolicy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	next, err := schedule.Schedule.Next(now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := actionScheduleDoc{
+		DocId:             m.st.docID(schedule.Name),
+		ModelUUID:         m.UUID(),
+		Name:              schedule.Name,
+		Cron:              schedule.Schedule.Cron,
+		Interval:          schedule.Schedule.Interval,
+		Targets:           schedule.Targets,
+		Action:            schedule.Action,
+		Parameters:        schedule.Parameters,
+		ConcurrencyPolicy: string(schedule.ConcurrencyPolicy),
+		Created:           now,
+		NextRun:           next,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := m.ActionSchedule(schedule.Name); err == nil {
+				return nil, errors.AlreadyExistsf("schedule %q", schedule.Name)
+			} else if !errors.Is(err, errors.NotFound) {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{
+			assertModelActiveOp(m.UUID()),
+			{
+				C:      actionSchedulesC,
+				Id:     doc.DocId,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			},
+		}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (m *Model) ActionSchedule(name string) (ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ActionSchedule{}, errors.NotFoundf("schedule %q", name)
+	}
+	if err != nil {
+		return ActionSchedule{}, errors.Annotatef(err, "cannot get schedule %q", name)
+	}
+	return doc.schedule(), nil
+}
+
+// AllActionSchedules returns the action schedules of the model, sorted
+// by name.
+func (m *Model) AllActionSchedules() ([]ActionSchedule, error) {
+	return m.findActionSchedules(nil, "name")
+}
+
+// DueActionSchedules returns the action schedules due to run at the
+// given time, earliest first.
+func (m *Model) DueActionSchedules(now time.Time) ([]ActionSchedule, error) {
+	return m.findActionSchedules(bson.D{{"next-run", bson.D{{"$lte", now}}}}, "next-run")
+}
+
+// NextActionScheduleRun returns when the first of the model's action
+// schedules is next due to run. A NotFound error is returned if the
+// model has no action schedules.
+func (m *Model) NextActionScheduleRun() (time.Time, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.Find(nil).Select(bson.D{{"next-run", 1}}).Sort("next-run").One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, errors.NotFoundf("action schedules")
+	}
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot get next scheduled run")
+	}
+	return doc.NextRun.UTC(), nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher which triggers whenever
+// an action schedule of the model is added or removed. Runs of the
+// schedules are not reported.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newActionSchedulesWatcher(m.st, isLocalID(m.st))
+}
+
+func (m *Model) findActionSchedules(query bson.D, sort string) ([]ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).Sort(sort).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get schedules")
+	}
+	result := make([]ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.schedule()
+	}
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given name.
+// Operations started by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := m.ActionSchedule(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     m.st.docID(name),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// RecordScheduledRun adds a run to the history of an action schedule,
+// dropping the oldest runs beyond the most recent few, and moves the
+// schedule's next run from the time the run was due to next. It fails
+// if the run is no longer due, so that it cannot be recorded twice.
+func (m *Model) RecordScheduledRun(name string, next time.Time, run ScheduledRun) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+		defer closer()
+
+		var doc actionScheduleDoc
+		err := schedules.FindId(name).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("schedule %q", name)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get schedule %q", name)
+		}
+		if !doc.NextRun.Equal(run.Time) {
+			return nil, errors.Errorf("run of schedule %q at %v has already been recorded", name, run.Time)
+		}
+		runs := append(doc.Runs, scheduledRunDoc{
+			Time:        run.Time,
+			OperationID: run.OperationID,
+			Skipped:     run.Skipped,
+		})
+		if len(runs) > maxScheduledRuns {
+			runs = runs[len(runs)-maxScheduledRuns:]
+		}
+		return []txn.Op{{
+			C:  actionSchedulesC,
+			Id: doc.DocId,
+			Assert: bson.D{
+				{"next-run", run.Time},
+				{"runs", doc.Runs},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"next-run", next},
+				{"runs", runs},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
@@ -0,0 +1,216 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) schedule(name string) state.ActionSchedule {
+	return state.ActionSchedule{
+		Name:       name,
+		Schedule:   actions.Schedule{Interval: time.Hour},
+		Targets:    []string{"mysql"},
+		Action:     "backup",
+		Parameters: map[string]interface{}{"full": true},
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("nightly"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err := s.Model.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Name, gc.Equals, "nightly")
+	c.Assert(schedule.Schedule, jc.DeepEquals, actions.Schedule{Interval: time.Hour})
+	c.Assert(schedule.Targets, jc.DeepEquals, []string{"mysql"})
+	c.Assert(schedule.Action, gc.Equals, "backup")
+	c.Assert(schedule.Parameters, jc.DeepEquals, map[string]interface{}{"full": true})
+	c.Assert(schedule.ConcurrencyPolicy, gc.Equals, actions.ConcurrencyForbid)
+	c.Assert(schedule.Created.IsZero(), jc.IsFalse)
+	c.Assert(schedule.NextRun, gc.Equals, schedule.Created.Add(time.Hour))
+	c.Assert(schedule.Runs, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleAlreadyExists(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("nightly"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.AddActionSchedule(s.schedule("nightly"))
+	c.Assert(err, jc.ErrorIs, errors.AlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `schedule "nightly" already exists`)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	schedule := s.schedule("Nightly")
+	err := s.Model.AddActionSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, `schedule name "Nightly" not valid`)
+
+	schedule = s.schedule("nightly")
+	schedule.Schedule = actions.Schedule{}
+	err = s.Model.AddActionSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, "schedule without cron expression or interval not valid")
+
+	schedule = s.schedule("nightly")
+	schedule.Targets = nil
+	err = s.Model.AddActionSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, "schedule without targets not valid")
+
+	schedule = s.schedule("nightly")
+	schedule.ConcurrencyPolicy = "queue"
+	err = s.Model.AddActionSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, `concurrency policy "queue" not valid`)
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedules(c *gc.C) {
+	for _, name := range []string{"weekly", "hourly", "nightly"} {
+		err := s.Model.AddActionSchedule(s.schedule(name))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	schedules, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 3)
+	c.Assert(schedules[0].Name, gc.Equals, "hourly")
+	c.Assert(schedules[1].Name, gc.Equals, "nightly")
+	c.Assert(schedules[2].Name, gc.Equals, "weekly")
+}
+
+func (s *ActionScheduleSuite) TestDueActionSchedules(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	due, err := s.Model.DueActionSchedules(schedule.NextRun.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+
+	due, err = s.Model.DueActionSchedules(schedule.NextRun)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 1)
+	c.Assert(due[0].Name, gc.Equals, "hourly")
+}
+
+func (s *ActionScheduleSuite) TestNextActionScheduleRun(c *gc.C) {
+	_, err := s.Model.NextActionScheduleRun()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	err = s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+	schedule := s.schedule("quarterly")
+	schedule.Schedule = actions.Schedule{Interval: 15 * time.Minute}
+	err = s.Model.AddActionSchedule(schedule)
+	c.Assert(err, jc.ErrorIsNil)
+	quarterly, err := s.Model.ActionSchedule("quarterly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	next, err := s.Model.NextActionScheduleRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, quarterly.NextRun)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.Model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	err := s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.RecordScheduledRun("hourly", schedule.NextRun.Add(time.Hour), state.ScheduledRun{
+		Time:        schedule.NextRun,
+		OperationID: "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.Model.RemoveActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *ActionScheduleSuite) TestRecordScheduledRun(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	run := state.ScheduledRun{Time: schedule.NextRun, OperationID: "1"}
+	next := run.Time.Add(time.Hour)
+	err = s.Model.RecordScheduledRun("hourly", next, run)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.RecordScheduledRun("hourly", next, run)
+	c.Assert(err, gc.ErrorMatches, `run of schedule "hourly" at .* has already been recorded`)
+
+	schedule, err = s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun, gc.Equals, next)
+	c.Assert(schedule.Runs, jc.DeepEquals, []state.ScheduledRun{run})
+}
+
+func (s *ActionScheduleSuite) TestRecordScheduledRunHistory(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := schedule.NextRun
+	for i := 0; i < 12; i++ {
+		run := state.ScheduledRun{
+			Time:        start.Add(time.Duration(i) * time.Hour),
+			OperationID: fmt.Sprint(i),
+		}
+		err = s.Model.RecordScheduledRun("hourly", run.Time.Add(time.Hour), run)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.Model.RecordScheduledRun("hourly", start.Add(13*time.Hour), state.ScheduledRun{
+		Time:    start.Add(12 * time.Hour),
+		Skipped: "still running",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Runs, gc.HasLen, 10)
+	c.Assert(schedule.Runs[0], jc.DeepEquals, state.ScheduledRun{
+		Time:        start.Add(3 * time.Hour),
+		OperationID: "3",
+	})
+	c.Assert(schedule.Runs[9], jc.DeepEquals, state.ScheduledRun{
+		Time:    start.Add(12 * time.Hour),
+		Skipped: "still running",
+	})
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	err := s.Model.AddActionSchedule(s.schedule("hourly"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.RemoveActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	err = s.Model.RemoveActionSchedule("hourly")
+	c.Assert(err, gc.ErrorMatches, `schedule "hourly" not found`)
+}
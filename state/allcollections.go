@@ -100,6 +100,11 @@ func allCollections() CollectionSchema {
 		// This collection tracks the progress of model migrations.
 		migrationsStatusC: {global: true},
 
+		// This collection holds the checkpoints of model migrations,
+		// recording what has been transferred so that a failed
+		// migration phase can be resumed.
+		migrationsCheckpointC: {global: true},
+
 		// This collection records the model migrations which
 		// are currently in progress. It is used to ensure that only
 		// one model migration document exists per model.
@@ -241,6 +246,11 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "name"},
 			}},
 		},
+
+		// This collection holds what the autoscaler last observed of
+		// each autoscaled application.
+		autoscaleStatusC: {},
+
 		unitsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "application"},
@@ -444,6 +454,11 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
 
 		// -----
 
@@ -630,6 +645,14 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// secretsMigrationsC holds the progress of moving a model's
+		// secret revisions to another secret backend.
+		secretsMigrationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
+
 		secretBackendsC: {
 			global: true,
 			indexes: []mgo.Index{{
@@ -644,6 +667,15 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		secretBackendModelKeysC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"backend-id"},
+			}, {
+				Key: []string{"model-uuid"},
+			}},
+		},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -662,6 +694,7 @@ func allCollections() CollectionSchema {
 const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
@@ -696,6 +729,7 @@ const (
 	metricsManagerC            = "metricsmanager"
 	minUnitsC                  = "minunits"
 	migrationsActiveC          = "migrations.active"
+	migrationsCheckpointC      = "migrations.checkpoint"
 	migrationsC                = "migrations"
 	migrationsMinionSyncC      = "migrations.minionsync"
 	migrationsStatusC          = "migrations.status"
@@ -714,6 +748,7 @@ const (
 	relationsC                 = "relations"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
+	autoscaleStatusC           = "autoscaleStatus"
 	endpointBindingsC          = "endpointbindings"
 	settingsC                  = "settings"
 	generationsC               = "generations"
@@ -751,14 +786,16 @@ const (
 	relationNetworksC    = "relationNetworks"
 
 	// Secrets
-	secretMetadataC        = "secretMetadata"
-	secretRevisionsC       = "secretRevisions"
-	secretConsumersC       = "secretConsumers"
-	secretRemoteConsumersC = "secretRemoteConsumers"
-	secretPermissionsC     = "secretPermissions"
-	secretRotateC          = "secretRotate"
-	secretBackendsC        = "secretBackends"
-	secretBackendsRotateC  = "secretBackendsRotate"
+	secretMetadataC         = "secretMetadata"
+	secretRevisionsC        = "secretRevisions"
+	secretConsumersC        = "secretConsumers"
+	secretRemoteConsumersC  = "secretRemoteConsumers"
+	secretPermissionsC      = "secretPermissions"
+	secretRotateC           = "secretRotate"
+	secretBackendsC         = "secretBackends"
+	secretBackendsRotateC   = "secretBackendsRotate"
+	secretsMigrationsC      = "secretsMigrations"
+	secretBackendModelKeysC = "secretBackendModelKeys"
 )
 
 // watcherIgnoreList contains all the collections in mongo that should not be watched by the
@@ -768,4 +805,5 @@ var watcherIgnoreList = []string{
 	sequenceC,
 	refcountsC,
 	statusesHistoryC,
+	autoscaleStatusC,
 }
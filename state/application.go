@@ -108,6 +108,7 @@ type applicationDoc struct {
 	DesiredScale      int                           `bson:"scale"`
 	PasswordHash      string                        `bson:"passwordhash"`
 	ProvisioningState *ApplicationProvisioningState `bson:"provisioning-state"`
+	Autoscale         *autoscaleDoc                 `bson:"autoscale,omitempty"`
 
 	// Placement is the placement directive that should be used allocating units/pods.
 	Placement string `bson:"placement,omitempty"`
@@ -747,7 +748,7 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 		Id:     a.doc.DocID,
 		Assert: asserts,
 		Remove: true,
-	}}
+	}, removeAutoscaleStatusOp(a.doc.DocID)}
 
 	// Remove application offers.
 	removeOfferOps, err := removeApplicationOffersOps(a.st, a.doc.Name)
@@ -4716,6 +4716,29 @@ func (s *ApplicationSuite) TestUpdateApplicationConfig(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(config.ConfigAttributes{"outlook": "positive"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.mysql.UpdateApplicationConfig(config.ConfigAttributes{"outlook": "positive"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Charm config changes are not reported.
+	err = s.mysql.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"dataset-size": "80%"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestApplicationConfigNotFoundNoError(c *gc.C) {
 	ch := s.AddTestingCharm(c, "dummy")
 	app := s.AddTestingApplication(c, "dummy-application", ch)
@@ -0,0 +1,241 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+
+	"github.com/juju/juju/core/application"
+	stateerrors "github.com/juju/juju/state/errors"
+)
+
+// autoscaleDoc records an application's autoscale policy, on the
+// application doc.
+type autoscaleDoc struct {
+	MinUnits     int   `bson:"min-units" json:"min-units"`
+	MaxUnits     int   `bson:"max-units" json:"max-units"`
+	TargetCPU    int   `bson:"target-cpu,omitempty" json:"target-cpu,omitempty"`
+	TargetMemory int   `bson:"target-memory,omitempty" json:"target-memory,omitempty"`
+	Cooldown     int64 `bson:"cooldown" json:"cooldown"`
+}
+
+// autoscaleStatusDoc records what the autoscaler last observed of, and
+// did to, an application. It's kept apart from the application doc,
+// and isn't watched, as it's written each time the autoscaler polls.
+type autoscaleStatusDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	UnitsMeasured int      `bson:"units-measured,omitempty"`
+	CPU           *float64 `bson:"cpu,omitempty"`
+	Memory        *float64 `bson:"memory,omitempty"`
+	TargetScale   int      `bson:"target-scale,omitempty"`
+	LastScaled    int64    `bson:"last-scaled,omitempty"`
+	Message       string   `bson:"message,omitempty"`
+}
+
+func newAutoscaleDoc(policy application.AutoscalePolicy) *autoscaleDoc {
+	return &autoscaleDoc{
+		MinUnits:     policy.MinUnits,
+		MaxUnits:     policy.MaxUnits,
+		TargetCPU:    policy.TargetCPU,
+		TargetMemory: policy.TargetMemory,
+		Cooldown:     int64(policy.Cooldown),
+	}
+}
+
+func (doc *autoscaleDoc) policy() application.AutoscalePolicy {
+	return application.AutoscalePolicy{
+		MinUnits:     doc.MinUnits,
+		MaxUnits:     doc.MaxUnits,
+		TargetCPU:    doc.TargetCPU,
+		TargetMemory: doc.TargetMemory,
+		Cooldown:     time.Duration(doc.Cooldown),
+	}
+}
+
+// AutoscalePolicy returns the application's autoscale policy, or a
+// NotFound error if the application isn't autoscaled.
+func (a *Application) AutoscalePolicy() (application.AutoscalePolicy, error) {
+	doc := a.doc.Autoscale
+	if doc == nil {
+		return application.AutoscalePolicy{}, errors.NotFoundf("autoscale policy for application %q", a.Name())
+	}
+	return doc.policy(), nil
+}
+
+// SetAutoscalePolicy sets the application's autoscale policy. A nil
+// policy stops the application being autoscaled, leaving it at its
+// current scale.
+func (a *Application) SetAutoscalePolicy(policy *application.AutoscalePolicy) error {
+	var (
+		update bson.D
+		doc    *autoscaleDoc
+	)
+	if policy == nil {
+		update = bson.D{{"$unset", bson.D{{"autoscale", nil}}}}
+	} else {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = newAutoscaleDoc(*policy)
+		update = bson.D{{"$set", bson.D{{"autoscale", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if policy == nil || a.doc.Autoscale == nil {
+		// Changing the policy keeps what the autoscaler has
+		// observed, but nothing is kept from an earlier policy.
+		ops = append(ops, removeAutoscaleStatusOp(a.doc.DocID))
+	}
+	if err := a.st.db().RunTransaction(ops); errors.Is(err, txn.ErrAborted) {
+		return errors.Annotatef(stateerrors.ErrDead, "cannot set autoscale policy for application %q", a)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set autoscale policy for application %q", a)
+	}
+	a.doc.Autoscale = doc
+	return nil
+}
+
+// AutoscaleStatus returns what the autoscaler last observed of, and did
+// to, the application, or a NotFound error if the application isn't
+// autoscaled.
+func (a *Application) AutoscaleStatus() (application.AutoscaleStatus, error) {
+	if a.doc.Autoscale == nil {
+		return application.AutoscaleStatus{}, errors.NotFoundf("autoscale policy for application %q", a.Name())
+	}
+	autoscaleStatuses, closer := a.st.db().GetCollection(autoscaleStatusC)
+	defer closer()
+
+	var doc autoscaleStatusDoc
+	err := autoscaleStatuses.FindId(a.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		// The autoscaler hasn't checked the application yet.
+		return application.AutoscaleStatus{}, nil
+	} else if err != nil {
+		return application.AutoscaleStatus{}, errors.Annotatef(err, "cannot get autoscale status for application %q", a)
+	}
+	status := application.AutoscaleStatus{
+		Utilization: application.Utilization{
+			Units:  doc.UnitsMeasured,
+			CPU:    doc.CPU,
+			Memory: doc.Memory,
+		},
+		TargetScale: doc.TargetScale,
+		Message:     doc.Message,
+	}
+	if doc.LastScaled != 0 {
+		status.LastScaled = time.Unix(0, doc.LastScaled).UTC()
+	}
+	return status, nil
+}
+
+// SetAutoscaleStatus records what the autoscaler observed of, and did
+// to, the application. The application must be autoscaled.
+func (a *Application) SetAutoscaleStatus(status application.AutoscaleStatus) error {
+	var lastScaled int64
+	if !status.LastScaled.IsZero() {
+		lastScaled = status.LastScaled.UnixNano()
+	}
+	doc := autoscaleStatusDoc{
+		DocID:         a.doc.DocID,
+		ModelUUID:     a.st.ModelUUID(),
+		UnitsMeasured: status.Utilization.Units,
+		CPU:           status.Utilization.CPU,
+		Memory:        status.Utilization.Memory,
+		TargetScale:   status.TargetScale,
+		LastScaled:    lastScaled,
+		Message:       status.Message,
+	}
+
+	autoscaleStatuses, closer := a.st.db().GetCollection(autoscaleStatusC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Autoscale == nil {
+			return nil, errors.NotFoundf("autoscale policy for application %q", a.Name())
+		}
+		// The application doc is only asserted, so recording the
+		// status doesn't trigger the application's watchers.
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"autoscale", bson.D{{"$exists", true}}}},
+		}}
+		n, err := autoscaleStatuses.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			ops = append(ops, txn.Op{
+				C:      autoscaleStatusC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      autoscaleStatusC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"units-measured", doc.UnitsMeasured},
+					{"cpu", doc.CPU},
+					{"memory", doc.Memory},
+					{"target-scale", doc.TargetScale},
+					{"last-scaled", doc.LastScaled},
+					{"message", doc.Message},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		if errors.Is(err, errors.NotFound) {
+			return errors.Trace(err)
+		}
+		return errors.Annotatef(err, "cannot set autoscale status for application %q", a)
+	}
+	return nil
+}
+
+func removeAutoscaleStatusOp(appDocID string) txn.Op {
+	return txn.Op{
+		C:      autoscaleStatusC,
+		Id:     appDocID,
+		Remove: true,
+	}
+}
+
+// AutoscaledApplications returns the applications with an autoscale
+// policy.
+func (st *State) AutoscaledApplications() ([]*Application, error) {
+	applicationsCollection, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []applicationDoc
+	err := applicationsCollection.Find(bson.D{{"autoscale", bson.D{{"$exists", true}}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get autoscaled applications")
+	}
+	applications := make([]*Application, len(docs))
+	for i := range docs {
+		applications[i] = newApplication(st, &docs[i])
+	}
+	return applications, nil
+}
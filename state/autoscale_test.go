@@ -0,0 +1,138 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state/testing"
+)
+
+var testAutoscalePolicy = application.AutoscalePolicy{
+	MinUnits:  1,
+	MaxUnits:  5,
+	TargetCPU: 60,
+	Cooldown:  3 * time.Minute,
+}
+
+func (s *CAASApplicationSuite) TestAutoscalePolicyNotFound(c *gc.C) {
+	_, err := s.app.AutoscalePolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.app.AutoscaleStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicy(c *gc.C) {
+	policy := testAutoscalePolicy
+	err := s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.app.AutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, policy)
+
+	apps, err := s.caasSt.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, gc.HasLen, 1)
+	c.Assert(apps[0].Name(), gc.Equals, "gitlab")
+
+	err = s.app.SetAutoscalePolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.app.AutoscalePolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	apps, err = s.caasSt.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, gc.HasLen, 0)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicyInvalid(c *gc.C) {
+	policy := testAutoscalePolicy
+	policy.MaxUnits = 0
+	err := s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, gc.ErrorMatches, "max units 0 less than min units 1 not valid")
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscaleStatus(c *gc.C) {
+	policy := testAutoscalePolicy
+	err := s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cpu := 72.5
+	status := application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 2, CPU: &cpu},
+		TargetScale: 3,
+		LastScaled:  time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC),
+	}
+	err = s.app.SetAutoscaleStatus(status)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.app.AutoscaleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, status)
+
+	// Changing the policy keeps the status.
+	policy.MaxUnits = 10
+	err = s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = s.app.AutoscaleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, status)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscaleStatusDoesNotChangeApplication(c *gc.C) {
+	policy := testAutoscalePolicy
+	err := s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.app.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	for i := 1; i <= 2; i++ {
+		err = s.app.SetAutoscaleStatus(application.AutoscaleStatus{
+			Utilization: application.Utilization{Units: i},
+			TargetScale: i,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	wc.AssertNoChange()
+
+	got, err := s.app.AutoscaleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.TargetScale, gc.Equals, 2)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicyResetsStatus(c *gc.C) {
+	policy := testAutoscalePolicy
+	err := s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAutoscaleStatus(application.AutoscaleStatus{TargetScale: 3})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Status from an earlier policy isn't kept.
+	err = s.app.SetAutoscalePolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.app.AutoscaleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, application.AutoscaleStatus{})
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscaleStatusNotAutoscaled(c *gc.C) {
+	err := s.app.SetAutoscaleStatus(application.AutoscaleStatus{TargetScale: 3})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -24,6 +24,10 @@ const (
 
 	// FilenameTemplate is used with time.Time.Format to generate a filename.
 	FilenameTemplate = FilenamePrefix + "20060102-150405.tar.gz"
+
+	// IncrementalFilenameTemplate is used with time.Time.Format to
+	// generate a filename for an incremental backup.
+	IncrementalFilenameTemplate = FilenamePrefix + "20060102-150405-incremental.tar.gz"
 )
 
 var logger = loggo.GetLogger("juju.state.backups")
@@ -31,6 +35,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -50,8 +55,18 @@ type Backups interface {
 	// the provided metadata.
 	Create(meta *Metadata, dbInfo *DBInfo) (string, error)
 
+	// CreateIncremental creates a new juju backup archive holding the
+	// mongo changes since meta.OplogSince and a copy of the Dqlite
+	// database. It updates the provided metadata, which must identify
+	// the parent full backup.
+	CreateIncremental(meta *Metadata, dbInfo *DBInfo) (string, error)
+
 	// Get returns the metadata and specified archive file.
 	Get(fileName string) (*Metadata, io.ReadCloser, error)
+
+	// Restore restores the backup archive into the controller with the
+	// given UUID, returning the archive metadata.
+	Restore(archive io.Reader, dbInfo *DBInfo, controllerUUID string) (*Metadata, error)
 }
 
 type backups struct {
@@ -115,14 +130,8 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 		totalFizeSizesMiB, dbInfo.ApproxSizeMB, int(totalFizeSizesMiB)+dbInfo.ApproxSizeMB)
 
 	destinationDir := b.paths.BackupDir
-	if _, err := os.Stat(destinationDir); err != nil {
-		if os.IsNotExist(err) {
-			return "", errors.Errorf("backup destination directory %q does not exist", destinationDir)
-		}
-		return "", errors.NewNotValid(nil, fmt.Sprintf("invalid backup destination directory %q: %v", destinationDir, err))
-	}
-	if !filepath.IsAbs(destinationDir) {
-		return "", errors.Errorf("cannot use relative backup destination directory %q", destinationDir)
+	if err := validateDestinationDir(destinationDir); err != nil {
+		return "", errors.Trace(err)
 	}
 
 	// We require space equal to the larger of:
@@ -173,6 +182,75 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 	return result.filename, nil
 }
 
+// CreateIncremental creates and stores a new incremental backup archive,
+// holding the oplog entries written since meta.OplogSince along with a
+// copy of the Dqlite controller database, and updates the provided
+// metadata. A filename to download the backup is provided. An error
+// satisfying ErrFullBackupRequired is returned if the oplog no longer
+// covers the period since meta.OplogSince.
+func (b *backups) CreateIncremental(meta *Metadata, dbInfo *DBInfo) (string, error) {
+	if !meta.Incremental() || meta.OplogSince == nil {
+		return "", errors.NotValidf("incremental backup metadata without parent or oplog start")
+	}
+	meta.Started = time.Now().UTC()
+
+	metadataFile, err := meta.AsJSONBuffer()
+	if err != nil {
+		return "", errors.Annotate(err, "while preparing the metadata")
+	}
+
+	destinationDir := b.paths.BackupDir
+	if err := validateDestinationDir(destinationDir); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// The Dqlite controller database has no equivalent of the oplog,
+	// so each incremental backup holds a complete copy of it.
+	var filesToBackUp []string
+	dqlite := filepath.Join(b.paths.DataDir, dqliteDir)
+	if _, err := os.Stat(dqlite); err == nil {
+		filesToBackUp = append(filesToBackUp, dqlite)
+	} else if !os.IsNotExist(err) {
+		return "", errors.Trace(err)
+	}
+
+	dumper, err := getOplogDumper(dbInfo, *meta.OplogSince)
+	if err != nil {
+		return "", errors.Annotate(err, "while preparing for oplog dump")
+	}
+
+	args := createArgs{
+		destinationDir: destinationDir,
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		incremental:    true,
+	}
+	result, err := runCreate(&args)
+	if err != nil {
+		return "", errors.Annotate(err, "while creating incremental backup archive")
+	}
+	defer func() { _ = result.archiveFile.Close() }()
+
+	if err := finishMeta(meta, result); err != nil {
+		return "", errors.Annotate(err, "while updating metadata")
+	}
+	return result.filename, nil
+}
+
+func validateDestinationDir(destinationDir string) error {
+	if _, err := os.Stat(destinationDir); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("backup destination directory %q does not exist", destinationDir)
+		}
+		return errors.NewNotValid(nil, fmt.Sprintf("invalid backup destination directory %q: %v", destinationDir, err))
+	}
+	if !filepath.IsAbs(destinationDir) {
+		return errors.Errorf("cannot use relative backup destination directory %q", destinationDir)
+	}
+	return nil
+}
+
 func isValidFilepath(root string, filePath string) (bool, error) {
 	if !filepath.IsAbs(filePath) {
 		return false, nil
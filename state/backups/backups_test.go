@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/juju/collections/set"
@@ -202,6 +203,77 @@ func (s *backupsSuite) TestNotEnoughDiskSpaceSmallDisk(c *gc.C) {
 	s.checkFailure(c, "not enough free space in .*; want 2057MiB, have 10MiB")
 }
 
+func (s *backupsSuite) TestCreateIncrementalOkay(c *gc.C) {
+	archiveFile := io.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(
+		archiveFile,
+		10,
+		"<checksum>",
+		path.Join(s.paths.BackupDir, "test-backup-incremental.tar.gz"))
+	received, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+
+	var receivedSince time.Time
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, since time.Time) (backups.DBDumper, error) {
+		receivedSince = since
+		return &fakeDumper{}, nil
+	})
+
+	since := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "juju-backup-20230501-100000.tar.gz"
+	meta.OplogSince = &since
+	resultFilename, err := s.api.CreateIncremental(meta, &backups.DBInfo{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resultFilename, gc.Equals, path.Join(s.paths.BackupDir, "test-backup-incremental.tar.gz"))
+
+	resultBackupDir, filesToBackUp, _ := backups.ExposeCreateArgs(received)
+	c.Check(resultBackupDir, gc.Equals, s.paths.BackupDir)
+	c.Check(filesToBackUp, gc.HasLen, 0)
+	c.Check(backups.ExposeCreateArgsIncremental(received), jc.IsTrue)
+	c.Check(receivedSince, gc.Equals, since)
+
+	c.Check(meta.Size(), gc.Equals, int64(10))
+	c.Check(meta.Checksum(), gc.Equals, "<checksum>")
+	c.Check(meta.Incremental(), jc.IsTrue)
+}
+
+func (s *backupsSuite) TestCreateIncrementalRequiresParent(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.CreateIncremental(meta, &backups.DBInfo{})
+	c.Assert(err, gc.ErrorMatches, "incremental backup metadata without parent or oplog start not valid")
+}
+
+func (s *backupsSuite) TestCreateIncrementalWithDqlite(c *gc.C) {
+	dqlite := filepath.Join(s.paths.DataDir, "dqlite")
+	err := os.Mkdir(dqlite, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile := io.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(
+		archiveFile,
+		10,
+		"<checksum>",
+		path.Join(s.paths.BackupDir, "test-backup-incremental.tar.gz"))
+	received, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.GetOplogDumper, func(*backups.DBInfo, time.Time) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+
+	since := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "juju-backup-20230501-100000.tar.gz"
+	meta.OplogSince = &since
+	_, err = s.api.CreateIncremental(meta, &backups.DBInfo{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The whole Dqlite database is included alongside the oplog.
+	_, filesToBackUp, _ := backups.ExposeCreateArgs(received)
+	c.Check(filesToBackUp, jc.DeepEquals, []string{dqlite})
+	c.Check(backups.ExposeCreateArgsIncremental(received), jc.IsTrue)
+}
+
 func (s *backupsSuite) TestGetFileName(c *gc.C) {
 	backupSubDir := filepath.Join(s.paths.BackupDir, "a", "b")
 	err := os.MkdirAll(backupSubDir, 0755)
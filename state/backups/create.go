@@ -31,6 +31,9 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	// incremental is true when the archive holds a dump of the oplog,
+	// and only the Dqlite data from the state-related files.
+	incremental bool
 }
 
 type createResult struct {
@@ -44,7 +47,7 @@ type createResult struct {
 // updates the metadata with the file info.
 func create(args *createArgs) (_ *createResult, err error) {
 	// Prepare the backup builder.
-	builder, err := newBuilder(args.destinationDir, args.filesToBackUp, args.db)
+	builder, err := newBuilder(args.destinationDir, args.filesToBackUp, args.db, args.incremental)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -106,13 +109,16 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// incremental is true when the archive holds a dump of the oplog,
+	// and no files need be bundled.
+	incremental bool
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
 // directories which backup uses as its staging area while building the
 // archive.  It also creates the archive
 // (temp root, tarball root, DB dumpdir), along with any error.
-func newBuilder(destinationDir string, filesToBackUp []string, db DBDumper) (b *builder, err error) {
+func newBuilder(destinationDir string, filesToBackUp []string, db DBDumper, incremental bool) (b *builder, err error) {
 	// Create the backups workspace root directory.
 	// The root directory will always be relative to the
 	// specified backup dir - by default we'll write to
@@ -128,6 +134,9 @@ func newBuilder(destinationDir string, filesToBackUp []string, db DBDumper) (b *
 
 	// TODO(hpidcock): lp:1558657
 	finalFilename := time.Now().Format(FilenameTemplate)
+	if incremental {
+		finalFilename = time.Now().Format(IncrementalFilenameTemplate)
+	}
 	// Populate the builder.
 	b = &builder{
 		destinationDir: destinationDir,
@@ -136,6 +145,7 @@ func newBuilder(destinationDir string, filesToBackUp []string, db DBDumper) (b *
 		filename:       filepath.Join(destinationDir, finalFilename),
 		filesToBackUp:  filesToBackUp,
 		db:             db,
+		incremental:    incremental,
 	}
 	defer func() {
 		if err != nil {
@@ -161,6 +171,9 @@ func newBuilder(destinationDir string, filesToBackUp []string, db DBDumper) (b *
 		return nil, errors.Annotate(err, "while creating archive file")
 	}
 
+	if incremental && len(filesToBackUp) == 0 {
+		return b, nil
+	}
 	b.bundleFile, err = os.Create(b.archivePaths.FilesBundle)
 	if err != nil {
 		return nil, errors.Annotate(err, `while creating bundle file`)
@@ -272,6 +285,10 @@ func writeAll(targetname string, source io.Reader) error {
 }
 
 func (b *builder) buildFilesBundle() error {
+	if b.incremental && len(b.filesToBackUp) == 0 {
+		logger.Infof("incremental backup, no juju state-related files to dump")
+		return nil
+	}
 	logger.Infof("dumping juju state-related files")
 	if len(b.filesToBackUp) == 0 {
 		return errors.New("missing list of files to back up")
@@ -4,10 +4,12 @@
 package backups
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/juju/collections/set"
@@ -118,9 +120,12 @@ func getBackupTargetDatabases(session DBSession) (set.Strings, error) {
 const (
 	dumpName       = "mongodump"
 	snapToolPrefix = "juju-db."
-	snapTmpDir     = "/tmp/snap-private-tmp/snap.juju-db"
 )
 
+// snapTmpDir is where the private /tmp of the juju-db snap is found on
+// the host.
+var snapTmpDir = "/tmp/snap-private-tmp/snap.juju-db"
+
 // DBDumper is any type that dumps something to a dump dir.
 type DBDumper interface {
 	// Dump something to dumpDir.
@@ -236,6 +241,229 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+// oplogDumper dumps the oplog entries written since a point in time,
+// for use in incremental backups.
+type oplogDumper struct {
+	*mongoDumper
+	since time.Time
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping the
+// oplog entries written since the given time.
+func NewOplogDumper(info *DBInfo, since time.Time) (DBDumper, error) {
+	dumper, err := NewDBDumper(info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &oplogDumper{
+		mongoDumper: dumper.(*mongoDumper),
+		since:       since,
+	}, nil
+}
+
+// ErrFullBackupRequired is returned when an incremental backup can't
+// capture all the changes since its start time, so a full backup must
+// be taken instead.
+const ErrFullBackupRequired = errors.ConstError("full backup required")
+
+// CheckOplogCovers returns an error satisfying ErrFullBackupRequired
+// if entries written since the given time may have been dropped from
+// the capped oplog collection, so that an incremental backup starting
+// then would be missing changes.
+func CheckOplogCovers(session DBSession, since time.Time) error {
+	var result struct {
+		Cursor struct {
+			FirstBatch []struct {
+				Timestamp bson.MongoTimestamp `bson:"ts"`
+			} `bson:"firstBatch"`
+		} `bson:"cursor"`
+	}
+	err := session.DB("local").Run(bson.D{
+		{"find", "oplog.rs"},
+		{"sort", bson.D{{"$natural", 1}}},
+		{"limit", 1},
+		{"projection", bson.D{{"ts", 1}}},
+	}, &result)
+	if err != nil {
+		return errors.Annotate(err, "reading oldest oplog entry")
+	}
+	if len(result.Cursor.FirstBatch) == 0 {
+		return errors.Annotatef(ErrFullBackupRequired, "oplog is empty")
+	}
+	// The high 32 bits of a timestamp hold the seconds since the epoch.
+	oldest := time.Unix(int64(result.Cursor.FirstBatch[0].Timestamp>>32), 0).UTC()
+	if !since.After(oldest) {
+		return errors.Annotatef(ErrFullBackupRequired,
+			"oldest oplog entry at %v does not cover changes since %v", oldest, since.UTC())
+	}
+	return nil
+}
+
+func (od *oplogDumper) options(dumpDir string) []string {
+	query := fmt.Sprintf(`{"ts":{"$gte":{"$timestamp":{"t":%d,"i":0}}}}`, od.since.Unix())
+	options := []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", od.Address,
+		"--username", od.Username,
+		"--password", od.Password,
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+	}
+	return options
+}
+
+// Dump dumps the oplog entries since the dumper's start time.
+func (od *oplogDumper) Dump(dumpDir string) error {
+	logger.Tracef("dumping Mongo oplog since %v to %q", od.since, dumpDir)
+	dumpDirArg := dumpDir
+	if od.IsSnap() && strings.HasPrefix(dumpDirArg, snapTmpDir) {
+		dumpDirArg = strings.TrimPrefix(dumpDirArg, snapTmpDir)
+	}
+	if err := runCommandFn(od.binPath, od.options(dumpDirArg)...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+	return nil
+}
+
+const restoreName = "mongorestore"
+
+// DBRestorer is any type that restores something from a dump dir.
+type DBRestorer interface {
+	// Restore restores the contents of dumpDir.
+	Restore(dumpDir string) error
+
+	// IsSnap returns true if we are using the juju-db snap.
+	IsSnap() bool
+}
+
+var getMongorestorePath = func() (string, error) {
+	return getMongoToolPath(restoreName, os.Stat, exec.LookPath)
+}
+
+type mongoRestorer struct {
+	*DBInfo
+	// binPath is the path to the restore executable.
+	binPath string
+	// oplogOnly is true when only the oplog of the dump is to be
+	// replayed, for incremental backups.
+	oplogOnly bool
+}
+
+// NewDBRestorer returns a new value with a Restore method for restoring
+// the juju state databases from a full backup dump, replacing their
+// current contents.
+func NewDBRestorer(info *DBInfo) (DBRestorer, error) {
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+	return &mongoRestorer{
+		DBInfo:  info,
+		binPath: mongorestorePath,
+	}, nil
+}
+
+// NewOplogRestorer returns a new value with a Restore method for
+// replaying the oplog entries dumped in an incremental backup.
+func NewOplogRestorer(info *DBInfo) (DBRestorer, error) {
+	restorer, err := NewDBRestorer(info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := restorer.(*mongoRestorer)
+	r.oplogOnly = true
+	return r, nil
+}
+
+func (mr *mongoRestorer) options(dumpDir string) []string {
+	options := []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", mr.Address,
+		"--username", mr.Username,
+		"--password", mr.Password,
+		"--oplogReplay",
+	}
+	if mr.oplogOnly {
+		return append(options,
+			"--oplogFile", filepath.Join(dumpDir, "local", "oplog.rs.bson"),
+		)
+	}
+	return append(options, "--drop")
+}
+
+// IsSnap returns true if we are using the juju-db snap.
+func (mr *mongoRestorer) IsSnap() bool {
+	return filepath.Base(mr.binPath) == snapToolPrefix+restoreName
+}
+
+// tempDir returns a new temporary directory that the restore command
+// can read. The juju-db snap has a private /tmp, found on the host
+// under snapTmpDir.
+func (mr *mongoRestorer) tempDir(prefix string) (string, error) {
+	if !mr.IsSnap() {
+		return os.MkdirTemp("", prefix)
+	}
+	parent := filepath.Join(snapTmpDir, os.TempDir())
+	if err := os.MkdirAll(parent, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	return os.MkdirTemp(parent, prefix)
+}
+
+// commandPath returns the path at which the restore command sees the
+// given path on the host.
+func (mr *mongoRestorer) commandPath(path string) string {
+	if mr.IsSnap() && strings.HasPrefix(path, snapTmpDir) {
+		return strings.TrimPrefix(path, snapTmpDir)
+	}
+	return path
+}
+
+// Restore restores the juju state databases from dumpDir.
+func (mr *mongoRestorer) Restore(dumpDir string) error {
+	logger.Tracef("restoring Mongo database from %q", dumpDir)
+
+	// Works around https://bugs.launchpad.net/snapd/+bug/1999109, as
+	// for dumps: the juju-db snap cannot read the host's /tmp, so the
+	// dump is moved to the snap's private /tmp.
+	if mr.IsSnap() && !strings.HasPrefix(dumpDir, snapTmpDir) {
+		snapDir, err := mr.tempDir("juju-restore-")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = os.RemoveAll(snapDir) }()
+		moved := filepath.Join(snapDir, filepath.Base(dumpDir))
+		if err := os.Rename(dumpDir, moved); err != nil {
+			return errors.Annotate(err, "moving dump into juju-db snap")
+		}
+		dumpDir = moved
+	}
+
+	restoreDir := dumpDir
+	if mr.oplogOnly {
+		// The oplog entries are replayed on their own, so mongorestore
+		// is pointed at an empty directory to avoid restoring the
+		// dumped oplog collection itself.
+		emptyDir, err := mr.tempDir("juju-restore-oplog-")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = os.RemoveAll(emptyDir) }()
+		restoreDir = emptyDir
+	}
+	options := append(mr.options(mr.commandPath(dumpDir)), "--dir", mr.commandPath(restoreDir))
+	if err := runCommandFn(mr.binPath, options...); err != nil {
+		return errors.Annotate(err, "error restoring databases")
+	}
+	return nil
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -143,3 +143,60 @@ func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 
 	s.checkDBs(c, "juju", "admin")
 }
+
+func (s *dumpSuite) patchRestore(c *gc.C) *[]string {
+	var args []string
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) {
+		return "bogusmongorestore", nil
+	})
+	s.PatchValue(backups.RunCommand, func(cmd string, cmdArgs ...string) error {
+		c.Check(cmd, gc.Equals, "bogusmongorestore")
+		args = cmdArgs
+		return nil
+	})
+	return &args
+}
+
+func (s *dumpSuite) TestRestoreRanCommand(c *gc.C) {
+	args := s.patchRestore(c)
+	restorer, err := backups.NewDBRestorer(s.dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = restorer.Restore(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*args, jc.DeepEquals, []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--oplogReplay",
+		"--drop",
+		"--dir", s.dumpDir,
+	})
+}
+
+func (s *dumpSuite) TestOplogRestoreRanCommand(c *gc.C) {
+	args := s.patchRestore(c)
+	restorer, err := backups.NewOplogRestorer(s.dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = restorer.Restore(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*args, gc.HasLen, 15)
+	c.Check((*args)[:11], jc.DeepEquals, []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--oplogReplay",
+	})
+	c.Check((*args)[11], gc.Equals, "--oplogFile")
+	c.Check((*args)[12], gc.Equals, filepath.Join(s.dumpDir, "local", "oplog.rs.bson"))
+	// The oplog is replayed on its own, from outside the dump.
+	c.Check((*args)[13], gc.Equals, "--dir")
+	c.Check((*args)[14], gc.Not(gc.Equals), s.dumpDir)
+}
@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"github.com/juju/mgo/v3/bson"
@@ -25,6 +27,7 @@ type dbInfoSuite struct {
 type fakeSession struct {
 	dbNames []string
 	db      *fakeDatabase
+	oplog   *oplogDatabase
 }
 
 type fakeDatabase struct {
@@ -36,6 +39,9 @@ func (f *fakeSession) DatabaseNames() ([]string, error) {
 }
 
 func (f *fakeSession) DB(name string) backups.Database {
+	if name == "local" && f.oplog != nil {
+		return f.oplog
+	}
 	return f.db
 }
 
@@ -95,3 +101,36 @@ func (s *dbInfoSuite) TestNewDBInfoMissingTag(c *gc.C) {
 	c.Check(dbInfo.Address, gc.Equals, "localhost:8080")
 	c.Check(dbInfo.Password, gc.Equals, "eggs")
 }
+
+type oplogDatabase struct {
+	first []bson.M
+}
+
+func (f *oplogDatabase) Run(cmd interface{}, result interface{}) error {
+	cmdInfo, ok := cmd.(bson.D)
+	if !ok || len(cmdInfo) == 0 || cmdInfo[0].Name != "find" || cmdInfo[0].Value != "oplog.rs" {
+		return errors.Errorf("unexpected cmd %#v", cmd)
+	}
+	data, err := bson.Marshal(bson.M{"cursor": bson.M{"firstBatch": f.first}})
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, result)
+}
+
+func (s *dbInfoSuite) TestCheckOplogCovers(c *gc.C) {
+	oldest := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	session := fakeSession{db: &fakeDatabase{}}
+	session.oplog = &oplogDatabase{first: []bson.M{{"ts": mongo.NewMongoTimestamp(oldest) + 3}}}
+
+	err := backups.CheckOplogCovers(&session, oldest.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = backups.CheckOplogCovers(&session, oldest)
+	c.Assert(err, jc.ErrorIs, backups.ErrFullBackupRequired)
+	c.Assert(err, gc.ErrorMatches, `oldest oplog entry at 2023-05-01 10:00:00 \+0000 UTC does not cover changes since 2023-05-01 10:00:00 \+0000 UTC: full backup required`)
+
+	session.oplog = &oplogDatabase{}
+	err = backups.CheckOplogCovers(&session, oldest)
+	c.Assert(err, jc.ErrorIs, backups.ErrFullBackupRequired)
+}
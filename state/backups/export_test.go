@@ -16,6 +16,11 @@ var (
 
 	TestGetFilesToBackUp = &getFilesToBackUp
 	GetDBDumper          = &getDBDumper
+	GetOplogDumper       = &getOplogDumper
+	GetDBRestorer        = &getDBRestorer
+	GetOplogRestorer     = &getOplogRestorer
+	GetMongorestorePath  = &getMongorestorePath
+	RunSSHScript         = &runSSHScript
 	RunCreate            = &runCreate
 	FinishMeta           = &finishMeta
 	GetMongodumpPath     = &getMongodumpPath
@@ -23,6 +28,7 @@ var (
 	AvailableDisk        = &availableDisk
 	TotalDisk            = &totalDisk
 	DirSize              = &dirSize
+	SnapTmpDir           = &snapTmpDir
 )
 
 // ExposeCreateResult extracts the values in a create() result.
@@ -46,6 +52,12 @@ func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.destinationDir, args.filesToBackUp, args.db
 }
 
+// ExposeCreateArgsIncremental reports whether a create() args value
+// is for an incremental backup.
+func ExposeCreateArgsIncremental(args *createArgs) bool {
+	return args.incremental
+}
+
 // NewTestCreateResult builds a new create() result.
 func NewTestCreateResult(file io.ReadCloser, size int64, checksum, filename string) *createResult {
 	result := createResult{
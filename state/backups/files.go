@@ -22,6 +22,7 @@ const (
 	agentsConfs = "machine-*"
 	toolsDir    = "tools"
 	initDir     = "init"
+	dqliteDir   = "dqlite"
 
 	sshIdentFile = "system-identity"
 	nonceFile    = "nonce.txt"
@@ -104,6 +105,17 @@ func GetFilesToBackUp(rootDir string, paths *Paths) ([]string, error) {
 		backupFiles = append(backupFiles, nonce)
 	}
 
+	// Handle the dqlite controller database (might not exist).
+	dqlite := filepath.Join(rootDir, paths.DataDir, dqliteDir)
+	if _, err := os.Stat(dqlite); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("skipping missing dir %q", dqlite)
+	} else {
+		backupFiles = append(backupFiles, dqlite)
+	}
+
 	// Handle user SSH files (might not exist).
 	SSHDir := filepath.Join(rootDir, sshDir)
 	if _, err := os.Stat(SSHDir); err != nil {
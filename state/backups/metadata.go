@@ -89,6 +89,20 @@ type Metadata struct {
 
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
+
+	// Parent is the ID of the full backup that an incremental backup
+	// builds upon. It is empty for full backups.
+	Parent string
+
+	// OplogSince records the time from which the oplog entries in an
+	// incremental backup were dumped. It is nil for full backups.
+	OplogSince *time.Time
+}
+
+// Incremental returns true if the metadata describes an incremental
+// backup, holding only the oplog entries since an earlier backup.
+func (m *Metadata) Incremental() bool {
+	return m.Parent != ""
 }
 
 // ControllerMetadata contains controller specific metadata.
@@ -257,6 +271,11 @@ type flatMetadata struct {
 	HANodes                     int64
 	ControllerMachineID         string
 	ControllerMachineInstanceID string
+
+	// The incremental backup fields are omitted for full backups, so
+	// that full backups remain readable by older versions.
+	Parent     string     `json:",omitempty"`
+	OplogSince *time.Time `json:",omitempty"`
 }
 
 func (m *Metadata) flat() flatMetadata {
@@ -277,6 +296,8 @@ func (m *Metadata) flat() flatMetadata {
 		ControllerMachineID:         m.Controller.MachineID,
 		ControllerMachineInstanceID: m.Controller.MachineInstanceID,
 		HANodes:                     m.Controller.HANodes,
+		Parent:                      m.Parent,
+		OplogSince:                  m.OplogSince,
 	}
 	stored := m.Stored()
 	if stored != nil {
@@ -322,6 +343,8 @@ func (flat *flatMetadata) inflate() (*Metadata, error) {
 		MachineInstanceID: flat.ControllerMachineInstanceID,
 		HANodes:           flat.HANodes,
 	}
+	meta.Parent = flat.Parent
+	meta.OplogSince = flat.OplogSince
 	return meta, nil
 }
 
@@ -139,6 +139,45 @@ func (s *metadataSuite) TestAsJSONBufferV1HA(c *gc.C) {
 		`}`+"\n")
 }
 
+func (s *metadataSuite) TestAsJSONBufferIncremental(c *gc.C) {
+	meta := s.createTestMetadata(c)
+	meta.FormatVersion = 1
+	since := time.Date(2014, time.Month(9), 9, 10, 0, 0, 0, time.UTC)
+	meta.Parent = "juju-backup-20140909-100000.tar.gz"
+	meta.OplogSince = &since
+
+	s.assertMetadata(c, meta, `{`+
+		`"ID":"20140909-115934.asdf-zxcv-qwe",`+
+		`"FormatVersion":1,`+
+		`"Checksum":"123af2cef",`+
+		`"ChecksumFormat":"SHA-1, base64 encoded",`+
+		`"Size":10,`+
+		`"Stored":"0001-01-01T00:00:00Z",`+
+		`"Started":"2014-09-09T11:59:34Z",`+
+		`"Finished":"2014-09-09T12:00:34Z",`+
+		`"Notes":"",`+
+		`"ModelUUID":"asdf-zxcv-qwe",`+
+		`"Machine":"0",`+
+		`"Hostname":"myhost",`+
+		`"Version":"1.21-alpha3",`+
+		`"Base":"ubuntu@22.04",`+
+		`"ControllerUUID":"",`+
+		`"HANodes":0,`+
+		`"ControllerMachineID":"",`+
+		`"ControllerMachineInstanceID":"",`+
+		`"Parent":"juju-backup-20140909-100000.tar.gz",`+
+		`"OplogSince":"2014-09-09T10:00:00Z"`+
+		`}`+"\n")
+
+	buf, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	read, err := backups.NewMetadataJSONReader(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(read.Incremental(), jc.IsTrue)
+	c.Check(read.Parent, gc.Equals, "juju-backup-20140909-100000.tar.gz")
+	c.Check(*read.OplogSince, gc.Equals, since)
+}
+
 func (s *metadataSuite) TestNewMetadataJSONReaderV0(c *gc.C) {
 	file := bytes.NewBufferString(`{` +
 		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
@@ -0,0 +1,232 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3/ssh"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/database"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var (
+	getDBRestorer    = NewDBRestorer
+	getOplogRestorer = NewOplogRestorer
+	runSSHScript     = runSSHScriptWithIdentity
+)
+
+// CheckCompatibility returns an error if the backup described by the
+// metadata cannot be restored into the controller with the given UUID,
+// running the given version of juju. Only backups of the same
+// controller, taken by the same major and minor version of juju, in the
+// current metadata format, can be restored.
+func CheckCompatibility(meta *Metadata, controllerUUID string, current version.Number) error {
+	if meta.FormatVersion != currentFormatVersion {
+		return errors.NotSupportedf("restoring backup metadata format %d (want %d)",
+			meta.FormatVersion, currentFormatVersion)
+	}
+	if meta.Controller.UUID != controllerUUID {
+		return errors.NotSupportedf("restoring a backup of controller %q into controller %q",
+			meta.Controller.UUID, controllerUUID)
+	}
+	backupVersion := meta.Origin.Version
+	if backupVersion.Major != current.Major || backupVersion.Minor != current.Minor {
+		return errors.NotSupportedf("restoring a backup taken by juju %s into a controller running %s",
+			backupVersion, current)
+	}
+	return nil
+}
+
+// RestoreChain returns the metadata of the backups that must be
+// restored, in order, to restore the backup with the given ID from the
+// storage. That is the full backup, followed by any of its incremental
+// backups up to and including the given one.
+func RestoreChain(ctx context.Context, storage ArchiveStorage, id string) ([]*Metadata, error) {
+	metaList, err := storage.List(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var target *Metadata
+	for _, meta := range metaList {
+		if meta.ID() == id {
+			target = meta
+			break
+		}
+	}
+	if target == nil {
+		return nil, errors.NotFoundf("backup %q", id)
+	}
+	if !target.Incremental() {
+		return []*Metadata{target}, nil
+	}
+
+	var chain []*Metadata
+	for _, meta := range metaList {
+		if meta.ID() == target.Parent {
+			chain = append([]*Metadata{meta}, chain...)
+		} else if meta.Parent == target.Parent && !meta.Started.After(target.Started) {
+			chain = append(chain, meta)
+		}
+	}
+	if len(chain) == 0 || chain[0].ID() != target.Parent {
+		return nil, errors.NotFoundf("full backup %q of incremental backup %q", target.Parent, id)
+	}
+	return chain, nil
+}
+
+// Restore restores the juju state database from the backup archive,
+// replacing its current contents, and returns the archive metadata.
+// For an incremental backup the recorded mongo changes are replayed on
+// top of the state restored from its full backup. Both kinds of backup
+// hold a complete copy of the Dqlite controller database, which is
+// staged to be put in place when the controller agent next starts; the
+// copy from the last backup restored is the one used.
+func (b *backups) Restore(archive io.Reader, dbInfo *DBInfo, controllerUUID string) (*Metadata, error) {
+	ws, err := NewArchiveWorkspaceReader(archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "while unpacking backup archive")
+	}
+	defer func() { _ = ws.Close() }()
+
+	meta, err := ws.Metadata()
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading backup metadata")
+	}
+	if err := CheckCompatibility(meta, controllerUUID, jujuversion.Current); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	newRestorer := getDBRestorer
+	if meta.Incremental() {
+		newRestorer = getOplogRestorer
+	}
+	restorer, err := newRestorer(dbInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "while preparing for DB restore")
+	}
+	if err := restorer.Restore(ws.DBDumpDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := b.stageDqlite(ws, meta.Incremental()); err != nil {
+		return nil, errors.Annotate(err, "while staging Dqlite data")
+	}
+	return meta, nil
+}
+
+// stageDqlite unpacks the Dqlite data directory from the files bundle
+// of the archive into the directory from which it is applied when the
+// Dqlite node is next started. Incremental backups taken on controllers
+// without Dqlite have no files bundle.
+func (b *backups) stageDqlite(ws *ArchiveWorkspace, incremental bool) error {
+	if incremental {
+		if _, err := os.Stat(ws.FilesBundle); os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// The bundle is unpacked next to the staging directory, so that the
+	// data can be moved into place rather than copied.
+	unpackDir, err := os.MkdirTemp(b.paths.DataDir, "juju-restore-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(unpackDir) }()
+
+	if err := ws.UnpackFilesBundle(unpackDir); err != nil {
+		return errors.Trace(err)
+	}
+	unpacked := filepath.Join(unpackDir, b.paths.DataDir, dqliteDir)
+	if _, err := os.Stat(unpacked); os.IsNotExist(err) {
+		logger.Warningf("backup archive holds no Dqlite data; skipping")
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	staged := database.StagedRestoreDir(b.paths.DataDir)
+	if err := os.RemoveAll(staged); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(unpacked, staged))
+}
+
+// RepointAgents updates the API addresses in the config of the juju
+// agents on the machine at the given host, then restarts the machine
+// agent so that it connects to the given addresses. The machine is
+// reached over SSH, using the controller's system identity found in
+// dataDir, and must present one of the given host keys.
+func RepointAgents(dataDir, host string, hostKeys, apiAddresses []string) error {
+	if len(apiAddresses) == 0 {
+		return errors.NotValidf("empty API addresses")
+	}
+	if len(hostKeys) == 0 {
+		return errors.NotFoundf("SSH host keys for %q", host)
+	}
+
+	knownHosts, err := os.CreateTemp("", "juju-known-hosts-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.Remove(knownHosts.Name()) }()
+	for _, key := range hostKeys {
+		if _, err := fmt.Fprintf(knownHosts, "%s %s\n", host, strings.TrimSpace(key)); err != nil {
+			_ = knownHosts.Close()
+			return errors.Trace(err)
+		}
+	}
+	if err := knownHosts.Close(); err != nil {
+		return errors.Trace(err)
+	}
+
+	script := repointAgentsScript(apiAddresses)
+	identity := filepath.Join(dataDir, sshIdentFile)
+	if output, err := runSSHScript(identity, knownHosts.Name(), host, script); err != nil {
+		return errors.Annotatef(err, "updating agents on %q: %s", host, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// repointAgentsScript returns a bash script that replaces the API
+// addresses in each agent config and restarts the machine agent.
+func repointAgentsScript(apiAddresses []string) string {
+	return fmt.Sprintf(`
+set -e
+for conf in /var/lib/juju/agents/*/agent.conf; do
+    sudo awk -v addrs=%q '
+        BEGIN { n = split(addrs, a, " ") }
+        /^apiaddresses:/ { print; for (i = 1; i <= n; i++) print "- " a[i]; skip = 1; next }
+        skip && /^- / { next }
+        { skip = 0; print }
+    ' "$conf" | sudo tee "$conf.new" > /dev/null
+    sudo mv "$conf.new" "$conf"
+done
+for svc in $(systemctl list-units --plain --no-legend 'jujud-machine-*' | awk '{print $1}'); do
+    sudo systemctl restart "$svc"
+done
+`[1:], strings.Join(apiAddresses, " "))
+}
+
+func runSSHScriptWithIdentity(identity, knownHostsFile, host, script string) (string, error) {
+	options := ssh.Options{}
+	options.SetIdentities(identity)
+	options.SetStrictHostKeyChecking(ssh.StrictHostChecksYes)
+	options.SetKnownHostsFile(knownHostsFile)
+
+	command := ssh.DefaultClient.Command("ubuntu@"+host, []string{"/bin/bash"}, &options)
+	command.Stdin = strings.NewReader(script)
+	output, err := command.CombinedOutput()
+	return string(output), errors.Trace(err)
+}
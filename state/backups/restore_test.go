@@ -0,0 +1,403 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/database"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type restoreSuite struct {
+	testing.BaseSuite
+
+	paths    *backups.Paths
+	api      backups.Backups
+	restorer *fakeRestorer
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+type fakeRestorer struct {
+	kind    string
+	dumpDir string
+	files   []string
+}
+
+func (r *fakeRestorer) Restore(dumpDir string) error {
+	r.dumpDir = dumpDir
+	entries, err := os.ReadDir(dumpDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		r.files = append(r.files, entry.Name())
+	}
+	return nil
+}
+
+func (r *fakeRestorer) IsSnap() bool {
+	return false
+}
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	// The archives hold the files of a controller with its data
+	// directory at /var/lib/juju.
+	root := c.MkDir()
+	dataDir := filepath.Join(root, "var", "lib", "juju")
+	err := os.MkdirAll(dataDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	s.paths = &backups.Paths{DataDir: dataDir}
+	s.api = backups.NewBackups(s.paths)
+
+	s.restorer = &fakeRestorer{}
+	s.PatchValue(backups.GetDBRestorer, func(*backups.DBInfo) (backups.DBRestorer, error) {
+		s.restorer.kind = "full"
+		return s.restorer, nil
+	})
+	s.PatchValue(backups.GetOplogRestorer, func(*backups.DBInfo) (backups.DBRestorer, error) {
+		s.restorer.kind = "oplog"
+		return s.restorer, nil
+	})
+}
+
+func (s *restoreSuite) newArchive(c *gc.C, meta *backups.Metadata, dqlite bool) *strings.Reader {
+	dataDir := strings.TrimPrefix(s.paths.DataDir, "/")
+	files := []backupstesting.File{{
+		Name:    filepath.Join(dataDir, "system-identity"),
+		Content: "<an ssh key goes here>",
+	}}
+	if dqlite {
+		files = append(files, backupstesting.File{
+			Name:    filepath.Join(dataDir, "dqlite", "info.yaml"),
+			Content: "ID: 1\n",
+		})
+	}
+	dump := []backupstesting.File{{
+		Name:    "juju/machines.bson",
+		Content: "<BSON data goes here>",
+	}}
+	if meta.Incremental() {
+		dump = append(dump, backupstesting.File{
+			Name:    "local/oplog.rs.bson",
+			Content: "<oplog entries go here>",
+		})
+	}
+	archive, err := backupstesting.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return strings.NewReader(archive.String())
+}
+
+func newRestoreMetadata() *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Controller.UUID = testing.ControllerTag.Id()
+	return meta
+}
+
+func (s *restoreSuite) TestRestoreFull(c *gc.C) {
+	meta := newRestoreMetadata()
+	archive := s.newArchive(c, meta, true)
+
+	restored, err := s.api.Restore(archive, &backups.DBInfo{}, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restored.Controller.UUID, gc.Equals, testing.ControllerTag.Id())
+	c.Check(s.restorer.kind, gc.Equals, "full")
+	c.Check(s.restorer.files, jc.DeepEquals, []string{"juju"})
+
+	data, err := os.ReadFile(filepath.Join(database.StagedRestoreDir(s.paths.DataDir), "info.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "ID: 1\n")
+
+	// Nothing else is left behind in the data directory.
+	entries, err := os.ReadDir(s.paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 1)
+}
+
+func (s *restoreSuite) TestRestoreFullWithoutDqlite(c *gc.C) {
+	meta := newRestoreMetadata()
+	archive := s.newArchive(c, meta, false)
+
+	_, err := s.api.Restore(archive, &backups.DBInfo{}, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.restorer.kind, gc.Equals, "full")
+
+	_, err = os.Stat(database.StagedRestoreDir(s.paths.DataDir))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *restoreSuite) TestRestoreIncremental(c *gc.C) {
+	meta := newRestoreMetadata()
+	since := meta.Started.Add(-time.Hour)
+	meta.Parent = "juju-backup-1.tar.gz"
+	meta.OplogSince = &since
+	archive := s.newArchive(c, meta, true)
+
+	_, err := s.api.Restore(archive, &backups.DBInfo{}, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.restorer.kind, gc.Equals, "oplog")
+
+	// The copy of the Dqlite data in the incremental backup is staged.
+	data, err := os.ReadFile(filepath.Join(database.StagedRestoreDir(s.paths.DataDir), "info.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "ID: 1\n")
+}
+
+func (s *restoreSuite) TestRestoreIncrementalWithoutDqlite(c *gc.C) {
+	meta := newRestoreMetadata()
+	since := meta.Started.Add(-time.Hour)
+	meta.Parent = "juju-backup-1.tar.gz"
+	meta.OplogSince = &since
+	archive := s.newArchive(c, meta, false)
+
+	_, err := s.api.Restore(archive, &backups.DBInfo{}, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.restorer.kind, gc.Equals, "oplog")
+
+	_, err = os.Stat(database.StagedRestoreDir(s.paths.DataDir))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+// mongorestoreRun records a run of mongorestore.
+type mongorestoreRun struct {
+	args map[string]string
+	// files are the names of the files in the restored directory,
+	// and oplog is the content of the replayed oplog file, both read
+	// while mongorestore runs.
+	files []string
+	oplog string
+}
+
+// patchMongorestore puts the real restorers back in place, with the
+// mongorestore at the given path run in their place. Paths are passed
+// to mongorestore as it sees them; hostPath returns the path on the
+// host.
+func (s *restoreSuite) patchMongorestore(c *gc.C, binPath string, hostPath func(string) string) *mongorestoreRun {
+	s.PatchValue(backups.GetDBRestorer, backups.NewDBRestorer)
+	s.PatchValue(backups.GetOplogRestorer, backups.NewOplogRestorer)
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) {
+		return binPath, nil
+	})
+	run := &mongorestoreRun{args: make(map[string]string)}
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		c.Check(cmd, gc.Equals, binPath)
+		for i, arg := range args {
+			if !strings.HasPrefix(arg, "--") {
+				continue
+			}
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				run.args[arg] = args[i+1]
+			} else {
+				run.args[arg] = ""
+			}
+		}
+		entries, err := os.ReadDir(hostPath(run.args["--dir"]))
+		c.Assert(err, jc.ErrorIsNil)
+		for _, entry := range entries {
+			run.files = append(run.files, entry.Name())
+		}
+		if oplogFile, ok := run.args["--oplogFile"]; ok {
+			data, err := os.ReadFile(hostPath(oplogFile))
+			c.Assert(err, jc.ErrorIsNil)
+			run.oplog = string(data)
+		}
+		return nil
+	})
+	return run
+}
+
+func (s *restoreSuite) TestRestoreRunsMongorestore(c *gc.C) {
+	run := s.patchMongorestore(c, "/usr/bin/mongorestore", func(path string) string { return path })
+
+	meta := newRestoreMetadata()
+	archive := s.newArchive(c, meta, true)
+	dbInfo := &backups.DBInfo{Address: "10.0.0.1:37017", Username: "machine-0", Password: "sekrit"}
+	_, err := s.api.Restore(archive, dbInfo, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(run.args, jc.DeepEquals, map[string]string{
+		"--ssl":                    "",
+		"--tlsInsecure":            "",
+		"--authenticationDatabase": "admin",
+		"--host":                   "10.0.0.1:37017",
+		"--username":               "machine-0",
+		"--password":               "sekrit",
+		"--oplogReplay":            "",
+		"--drop":                   "",
+		"--dir":                    run.args["--dir"],
+	})
+	c.Check(run.files, jc.DeepEquals, []string{"juju"})
+
+	// The unpacked archive is removed once restored.
+	_, err = os.Stat(run.args["--dir"])
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+
+	data, err := os.ReadFile(filepath.Join(database.StagedRestoreDir(s.paths.DataDir), "info.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "ID: 1\n")
+}
+
+func (s *restoreSuite) TestRestoreRunsSnapMongorestore(c *gc.C) {
+	// The juju-db snap sees its private /tmp in place of the host's.
+	snapTmpDir := c.MkDir()
+	s.PatchValue(backups.SnapTmpDir, snapTmpDir)
+	run := s.patchMongorestore(c, "/snap/bin/juju-db.mongorestore", func(path string) string {
+		return filepath.Join(snapTmpDir, path)
+	})
+
+	meta := newRestoreMetadata()
+	since := meta.Started.Add(-time.Hour)
+	meta.Parent = "juju-backup-1.tar.gz"
+	meta.OplogSince = &since
+	archive := s.newArchive(c, meta, false)
+	_, err := s.api.Restore(archive, &backups.DBInfo{}, testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The oplog is replayed from the dump, moved to where the snap can
+	// read it, with mongorestore pointed at an empty directory.
+	c.Check(strings.HasPrefix(run.args["--oplogFile"], os.TempDir()), jc.IsTrue)
+	c.Check(run.oplog, gc.Equals, "<oplog entries go here>")
+	c.Check(strings.HasPrefix(run.args["--dir"], os.TempDir()), jc.IsTrue)
+	c.Check(run.files, gc.HasLen, 0)
+	_, hasDrop := run.args["--drop"]
+	c.Check(hasDrop, jc.IsFalse)
+
+	// Nothing is left behind in the snap's /tmp.
+	entries, err := os.ReadDir(filepath.Join(snapTmpDir, os.TempDir()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *restoreSuite) TestRestoreIncompatible(c *gc.C) {
+	meta := newRestoreMetadata()
+	archive := s.newArchive(c, meta, true)
+
+	_, err := s.api.Restore(archive, &backups.DBInfo{}, "another-controller")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(s.restorer.kind, gc.Equals, "")
+}
+
+func (s *restoreSuite) TestCheckCompatibility(c *gc.C) {
+	uuid := testing.ControllerTag.Id()
+	current := jujuversion.Current
+
+	meta := newRestoreMetadata()
+	c.Check(backups.CheckCompatibility(meta, uuid, current), jc.ErrorIsNil)
+
+	meta.Origin.Version = version.Number{Major: current.Major, Minor: current.Minor, Patch: current.Patch + 1}
+	c.Check(backups.CheckCompatibility(meta, uuid, current), jc.ErrorIsNil)
+
+	meta.Origin.Version = version.Number{Major: current.Major, Minor: current.Minor + 1}
+	err := backups.CheckCompatibility(meta, uuid, current)
+	c.Check(err, gc.ErrorMatches, `restoring a backup taken by juju .* into a controller running .* not supported`)
+
+	meta = newRestoreMetadata()
+	meta.FormatVersion = 0
+	err = backups.CheckCompatibility(meta, uuid, current)
+	c.Check(err, gc.ErrorMatches, `restoring backup metadata format 0 \(want 1\) not supported`)
+
+	meta = newRestoreMetadata()
+	err = backups.CheckCompatibility(meta, "another-controller", current)
+	c.Check(err, gc.ErrorMatches, `restoring a backup of controller ".*" into controller "another-controller" not supported`)
+}
+
+func (s *restoreSuite) TestRestoreChain(c *gc.C) {
+	storage := backups.NewDirStorage(c.MkDir())
+	t0 := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, b := range []struct {
+		id     string
+		parent string
+	}{
+		{"juju-backup-1.tar.gz", ""},
+		{"juju-backup-1-a.tar.gz", "juju-backup-1.tar.gz"},
+		{"juju-backup-2.tar.gz", ""},
+		{"juju-backup-1-b.tar.gz", "juju-backup-1.tar.gz"},
+		{"juju-backup-1-c.tar.gz", "juju-backup-1.tar.gz"},
+	} {
+		meta := newStoredMetadata(c, b.id, t0.Add(time.Duration(i)*time.Hour), b.parent)
+		err := storage.Add(context.Background(), strings.NewReader(b.id), meta)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	chain, err := backups.RestoreChain(context.Background(), storage, "juju-backup-2.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadataIDs(chain), jc.DeepEquals, []string{"juju-backup-2.tar.gz"})
+
+	chain, err = backups.RestoreChain(context.Background(), storage, "juju-backup-1-b.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadataIDs(chain), jc.DeepEquals, []string{
+		"juju-backup-1.tar.gz",
+		"juju-backup-1-a.tar.gz",
+		"juju-backup-1-b.tar.gz",
+	})
+
+	_, err = backups.RestoreChain(context.Background(), storage, "juju-backup-3.tar.gz")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *restoreSuite) TestRestoreChainMissingParent(c *gc.C) {
+	storage := backups.NewDirStorage(c.MkDir())
+	meta := newStoredMetadata(c, "juju-backup-1-a.tar.gz", time.Now(), "juju-backup-1.tar.gz")
+	err := storage.Add(context.Background(), strings.NewReader("data"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.RestoreChain(context.Background(), storage, "juju-backup-1-a.tar.gz")
+	c.Check(err, gc.ErrorMatches, `full backup "juju-backup-1.tar.gz" of incremental backup "juju-backup-1-a.tar.gz" not found`)
+}
+
+func (s *restoreSuite) TestRepointAgents(c *gc.C) {
+	var identity, knownHosts, host, script string
+	s.PatchValue(backups.RunSSHScript, func(i, k, h, sc string) (string, error) {
+		identity, host, script = i, h, sc
+		data, err := os.ReadFile(k)
+		c.Assert(err, jc.ErrorIsNil)
+		knownHosts = string(data)
+		return "", nil
+	})
+
+	err := backups.RepointAgents("/var/lib/juju", "10.0.0.5",
+		[]string{"ssh-rsa AAAA1 root@host\n", "ssh-ed25519 AAAA2"},
+		[]string{"10.0.0.1:17070", "10.0.0.2:17070"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(identity, gc.Equals, "/var/lib/juju/system-identity")
+	c.Check(host, gc.Equals, "10.0.0.5")
+	c.Check(knownHosts, gc.Equals, "10.0.0.5 ssh-rsa AAAA1 root@host\n10.0.0.5 ssh-ed25519 AAAA2\n")
+	c.Check(script, jc.Contains, `addrs="10.0.0.1:17070 10.0.0.2:17070"`)
+	c.Check(script, jc.Contains, `systemctl restart`)
+}
+
+func (s *restoreSuite) TestRepointAgentsRequiresHostKeys(c *gc.C) {
+	s.PatchValue(backups.RunSSHScript, func(_, _, _, _ string) (string, error) {
+		c.Fatalf("unexpected SSH connection")
+		return "", nil
+	})
+
+	err := backups.RepointAgents("/var/lib/juju", "10.0.0.5", nil, []string{"10.0.0.1:17070"})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *restoreSuite) TestRepointAgentsFailure(c *gc.C) {
+	s.PatchValue(backups.RunSSHScript, func(_, _, _, _ string) (string, error) {
+		return "permission denied\n", errors.New("exit status 1")
+	})
+
+	err := backups.RepointAgents("/var/lib/juju", "10.0.0.5", []string{"ssh-rsa AAAA1"}, []string{"10.0.0.1:17070"})
+	c.Check(err, gc.ErrorMatches, `updating agents on "10.0.0.5": permission denied: exit status 1`)
+}
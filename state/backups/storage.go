@@ -0,0 +1,325 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/internal/s3client"
+)
+
+const (
+	// metadataSuffix is appended to the name of an archive to give the
+	// name of the file or object holding its metadata.
+	metadataSuffix = ".json"
+
+	// objectPrefix is prepended to the names of the archives held in an
+	// object store bucket.
+	objectPrefix = "backups/"
+)
+
+// ArchiveStorage holds backup archives, along with their metadata, so
+// that they outlive the controller machine that created them.
+type ArchiveStorage interface {
+	// Add stores the archive with the given metadata. The ID of the
+	// metadata is the name of the stored archive.
+	Add(ctx context.Context, archive io.Reader, meta *Metadata) error
+
+	// Get returns the archive with the given ID.
+	Get(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// List returns the metadata of each stored archive, oldest first.
+	List(ctx context.Context) ([]*Metadata, error)
+
+	// Remove removes the archive with the given ID.
+	Remove(ctx context.Context, id string) error
+}
+
+// ObjectStore is the subset of an S3 compatible object store client
+// used to store backup archives.
+type ObjectStore interface {
+	GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error)
+	PutObject(ctx context.Context, bucketName, objectName string, body io.Reader, size int64) error
+	ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, bucketName, objectName string) error
+}
+
+// NewS3ObjectStore returns an ObjectStore for the S3 compatible object
+// store at the given endpoint, using the default AWS credential chain.
+func NewS3ObjectStore(endpoint string) (ObjectStore, error) {
+	store, err := s3client.NewS3ClientForEndpoint(endpoint, logger)
+	return store, errors.Trace(err)
+}
+
+// StorageDir returns the directory on a controller machine in which
+// scheduled backups are kept, when no object store is configured.
+func StorageDir(dataDir string) string {
+	return filepath.Join(dataDir, "backups")
+}
+
+// NewStorage returns the ArchiveStorage used for scheduled backups. The
+// archives are uploaded to a bucket of the S3 compatible object store
+// configured in the controller config, created by newObjectStore, or
+// kept in StorageDir if there is none.
+//
+// StorageDir is local to each controller machine, so without an object
+// store the backups of a controller with more than one node would be
+// scattered across whichever machines took them. NewStorage returns a
+// NotSupported error in that case.
+func NewStorage(
+	cfg controller.Config, dataDir string, controllerNodes int,
+	newObjectStore func(endpoint string) (ObjectStore, error),
+) (ArchiveStorage, error) {
+	endpoint := cfg.BackupS3Endpoint()
+	if endpoint == "" {
+		if controllerNodes > 1 {
+			return nil, errors.NotSupportedf(
+				"storing scheduled backups of a controller with %d nodes without %q",
+				controllerNodes, controller.BackupS3Endpoint)
+		}
+		return NewDirStorage(StorageDir(dataDir)), nil
+	}
+	store, err := newObjectStore(endpoint)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to backup object store %q", endpoint)
+	}
+	return NewObjectStorage(store, cfg.BackupS3Bucket()), nil
+}
+
+// validateArchiveID ensures that an ID names an archive, and not some
+// other file.
+func validateArchiveID(id string) error {
+	if !strings.HasPrefix(id, FilenamePrefix) || id != path.Base(id) || strings.HasSuffix(id, metadataSuffix) {
+		return errors.NotValidf("backup ID %q", id)
+	}
+	return nil
+}
+
+// sortMetadata orders the metadata by the time the backups started.
+func sortMetadata(metaList []*Metadata) {
+	sort.SliceStable(metaList, func(i, j int) bool {
+		return metaList[i].Started.Before(metaList[j].Started)
+	})
+}
+
+type dirStorage struct {
+	dir string
+}
+
+// NewDirStorage returns an ArchiveStorage that holds archives, and
+// their metadata, in a directory.
+func NewDirStorage(dir string) ArchiveStorage {
+	return &dirStorage{dir: dir}
+}
+
+// Add is part of ArchiveStorage.
+func (s *dirStorage) Add(_ context.Context, archive io.Reader, meta *Metadata) error {
+	if err := validateArchiveID(meta.ID()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Annotate(err, "while creating backup storage directory")
+	}
+	metadata, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	filename := filepath.Join(s.dir, meta.ID())
+	if err := writeAll(filename, archive); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(writeAll(filename+metadataSuffix, metadata))
+}
+
+// Get is part of ArchiveStorage.
+func (s *dirStorage) Get(_ context.Context, id string) (io.ReadCloser, error) {
+	if err := validateArchiveID(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(filepath.Join(s.dir, id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup %q", id)
+	}
+	return f, errors.Trace(err)
+}
+
+// List is part of ArchiveStorage.
+func (s *dirStorage) List(_ context.Context) ([]*Metadata, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metaList []*Metadata
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, FilenamePrefix) || !strings.HasSuffix(name, metadataSuffix) {
+			continue
+		}
+		f, err := os.Open(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		meta, err := NewMetadataJSONReader(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading metadata %q", name)
+		}
+		metaList = append(metaList, meta)
+	}
+	sortMetadata(metaList)
+	return metaList, nil
+}
+
+// Remove is part of ArchiveStorage.
+func (s *dirStorage) Remove(_ context.Context, id string) error {
+	if err := validateArchiveID(id); err != nil {
+		return errors.Trace(err)
+	}
+	filename := filepath.Join(s.dir, id)
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(filename + metadataSuffix); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+type objectStorage struct {
+	store  ObjectStore
+	bucket string
+}
+
+// NewObjectStorage returns an ArchiveStorage that holds archives, and
+// their metadata, in a bucket of an S3 compatible object store.
+func NewObjectStorage(store ObjectStore, bucket string) ArchiveStorage {
+	return &objectStorage{
+		store:  store,
+		bucket: bucket,
+	}
+}
+
+// Add is part of ArchiveStorage.
+func (s *objectStorage) Add(ctx context.Context, archive io.Reader, meta *Metadata) error {
+	if err := validateArchiveID(meta.ID()); err != nil {
+		return errors.Trace(err)
+	}
+	metadata, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := objectPrefix + meta.ID()
+	if err := s.store.PutObject(ctx, s.bucket, name, archive, meta.Size()); err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is written last, so that only complete archives
+	// are listed.
+	data, err := io.ReadAll(metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.store.PutObject(ctx, s.bucket, name+metadataSuffix, bytes.NewReader(data), int64(len(data)))
+	return errors.Trace(err)
+}
+
+// Get is part of ArchiveStorage.
+func (s *objectStorage) Get(ctx context.Context, id string) (io.ReadCloser, error) {
+	if err := validateArchiveID(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r, err := s.store.GetObject(ctx, s.bucket, objectPrefix+id)
+	return r, errors.Trace(err)
+}
+
+// List is part of ArchiveStorage.
+func (s *objectStorage) List(ctx context.Context) ([]*Metadata, error) {
+	names, err := s.store.ListObjects(ctx, s.bucket, objectPrefix+FilenamePrefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metaList []*Metadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, metadataSuffix) {
+			continue
+		}
+		r, err := s.store.GetObject(ctx, s.bucket, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		meta, err := NewMetadataJSONReader(r)
+		_ = r.Close()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading metadata %q", name)
+		}
+		metaList = append(metaList, meta)
+	}
+	sortMetadata(metaList)
+	return metaList, nil
+}
+
+// Remove is part of ArchiveStorage.
+func (s *objectStorage) Remove(ctx context.Context, id string) error {
+	if err := validateArchiveID(id); err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is removed first, so that a partially removed
+	// archive is no longer listed.
+	name := objectPrefix + id
+	if err := s.store.DeleteObject(ctx, s.bucket, name+metadataSuffix); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.store.DeleteObject(ctx, s.bucket, name))
+}
+
+// Prune removes all but the newest keep full backups from the storage,
+// along with the incremental backups that build upon the removed ones.
+// Incremental backups whose full backup is missing are also removed, as
+// they cannot be restored. The metadata of the removed backups is
+// returned.
+func Prune(ctx context.Context, storage ArchiveStorage, keep int) ([]*Metadata, error) {
+	if keep < 1 {
+		return nil, errors.NotValidf("keeping %d backups", keep)
+	}
+	metaList, err := storage.List(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var full []*Metadata
+	for _, meta := range metaList {
+		if !meta.Incremental() {
+			full = append(full, meta)
+		}
+	}
+	kept := make(map[string]bool)
+	for i, meta := range full {
+		if i >= len(full)-keep {
+			kept[meta.ID()] = true
+		}
+	}
+
+	var removed []*Metadata
+	for _, meta := range metaList {
+		if kept[meta.ID()] || (meta.Incremental() && kept[meta.Parent]) {
+			continue
+		}
+		if err := storage.Remove(ctx, meta.ID()); err != nil {
+			return removed, errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+		removed = append(removed, meta)
+	}
+	return removed, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type storageSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func newStoredMetadata(c *gc.C, id string, started time.Time, parent string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Parent = parent
+	if parent != "" {
+		meta.OplogSince = &started
+	}
+	err := meta.MarkComplete(int64(len(id)), "checksum")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func metadataIDs(metaList []*backups.Metadata) []string {
+	ids := make([]string, len(metaList))
+	for i, meta := range metaList {
+		ids[i] = meta.ID()
+	}
+	return ids
+}
+
+func (s *storageSuite) addBackups(c *gc.C, storage backups.ArchiveStorage) {
+	t0 := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, b := range []struct {
+		id     string
+		parent string
+	}{
+		{"juju-backup-1.tar.gz", ""},
+		{"juju-backup-1-incremental.tar.gz", "juju-backup-1.tar.gz"},
+		{"juju-backup-2.tar.gz", ""},
+		{"juju-backup-2-incremental.tar.gz", "juju-backup-2.tar.gz"},
+		{"juju-backup-3.tar.gz", ""},
+	} {
+		meta := newStoredMetadata(c, b.id, t0.Add(time.Duration(i)*time.Hour), b.parent)
+		err := storage.Add(context.Background(), strings.NewReader(b.id), meta)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *storageSuite) TestDirStorage(c *gc.C) {
+	dir := c.MkDir()
+	storage := backups.NewDirStorage(dir)
+	s.addBackups(c, storage)
+
+	metaList, err := storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadataIDs(metaList), jc.DeepEquals, []string{
+		"juju-backup-1.tar.gz",
+		"juju-backup-1-incremental.tar.gz",
+		"juju-backup-2.tar.gz",
+		"juju-backup-2-incremental.tar.gz",
+		"juju-backup-3.tar.gz",
+	})
+	c.Assert(metaList[1].Parent, gc.Equals, "juju-backup-1.tar.gz")
+
+	r, err := storage.Get(context.Background(), "juju-backup-2.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "juju-backup-2.tar.gz")
+
+	err = storage.Remove(context.Background(), "juju-backup-2.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(dir, "juju-backup-2.tar.gz"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+	_, err = storage.Get(context.Background(), "juju-backup-2.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestDirStorageMissingDir(c *gc.C) {
+	storage := backups.NewDirStorage(filepath.Join(c.MkDir(), "missing"))
+	metaList, err := storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestInvalidID(c *gc.C) {
+	storage := backups.NewDirStorage(c.MkDir())
+	for _, id := range []string{"agent.conf", "../juju-backup-1.tar.gz", "juju-backup-1.tar.gz.json"} {
+		_, err := storage.Get(context.Background(), id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		err = storage.Remove(context.Background(), id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *storageSuite) TestObjectStorage(c *gc.C) {
+	store := &fakeObjectStore{objects: make(map[string][]byte)}
+	storage := backups.NewObjectStorage(store, "bucket")
+	s.addBackups(c, storage)
+
+	c.Assert(store.objects, gc.HasLen, 10)
+	c.Assert(string(store.objects["bucket/backups/juju-backup-3.tar.gz"]), gc.Equals, "juju-backup-3.tar.gz")
+
+	metaList, err := storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 5)
+	c.Assert(metaList[4].ID(), gc.Equals, "juju-backup-3.tar.gz")
+
+	err = storage.Remove(context.Background(), "juju-backup-3.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.objects, gc.HasLen, 8)
+}
+
+func (s *storageSuite) TestNewStorage(c *gc.C) {
+	dataDir := c.MkDir()
+	cfg := testing.FakeControllerConfig()
+	newObjectStore := func(string) (backups.ObjectStore, error) {
+		c.Fatalf("unexpected object store")
+		return nil, nil
+	}
+	storage, err := backups.NewStorage(cfg, dataDir, 1, newObjectStore)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addBackups(c, storage)
+	_, err = os.Stat(filepath.Join(backups.StorageDir(dataDir), "juju-backup-3.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	store := &fakeObjectStore{objects: make(map[string][]byte)}
+	cfg[controller.BackupS3Endpoint] = "https://s3.example.com"
+	cfg[controller.BackupS3Bucket] = "my-backups"
+	storage, err = backups.NewStorage(cfg, dataDir, 3, func(endpoint string) (backups.ObjectStore, error) {
+		c.Check(endpoint, gc.Equals, "https://s3.example.com")
+		return store, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addBackups(c, storage)
+	c.Assert(store.objects["my-backups/backups/juju-backup-3.tar.gz"], gc.NotNil)
+}
+
+func (s *storageSuite) TestNewStorageHARequiresObjectStore(c *gc.C) {
+	cfg := testing.FakeControllerConfig()
+	_, err := backups.NewStorage(cfg, c.MkDir(), 3, func(string) (backups.ObjectStore, error) {
+		c.Fatalf("unexpected object store")
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+	c.Assert(err, gc.ErrorMatches, `storing scheduled backups of a controller with 3 nodes without "backup-s3-endpoint" not supported`)
+}
+
+func (s *storageSuite) TestNewStorageError(c *gc.C) {
+	cfg := testing.FakeControllerConfig()
+	cfg[controller.BackupS3Endpoint] = "https://s3.example.com"
+	_, err := backups.NewStorage(cfg, c.MkDir(), 1, func(string) (backups.ObjectStore, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, `connecting to backup object store "https://s3.example.com": boom`)
+}
+
+func (s *storageSuite) TestPrune(c *gc.C) {
+	storage := backups.NewDirStorage(c.MkDir())
+	s.addBackups(c, storage)
+
+	removed, err := backups.Prune(context.Background(), storage, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadataIDs(removed), jc.DeepEquals, []string{
+		"juju-backup-1.tar.gz",
+		"juju-backup-1-incremental.tar.gz",
+	})
+
+	metaList, err := storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadataIDs(metaList), jc.DeepEquals, []string{
+		"juju-backup-2.tar.gz",
+		"juju-backup-2-incremental.tar.gz",
+		"juju-backup-3.tar.gz",
+	})
+}
+
+func (s *storageSuite) TestPruneRemovesOrphanedIncrementals(c *gc.C) {
+	storage := backups.NewDirStorage(c.MkDir())
+	s.addBackups(c, storage)
+	err := storage.Remove(context.Background(), "juju-backup-2.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	removed, err := backups.Prune(context.Background(), storage, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadataIDs(removed), jc.DeepEquals, []string{
+		"juju-backup-2-incremental.tar.gz",
+	})
+}
+
+func (s *storageSuite) TestPruneInvalidKeep(c *gc.C) {
+	_, err := backups.Prune(context.Background(), backups.NewDirStorage(c.MkDir()), 0)
+	c.Assert(err, gc.ErrorMatches, "keeping 0 backups not valid")
+}
+
+type fakeObjectStore struct {
+	objects map[string][]byte
+}
+
+func (s *fakeObjectStore) GetObject(_ context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+	data, ok := s.objects[bucketName+"/"+objectName]
+	if !ok {
+		return nil, errors.NotFoundf("object %q", objectName)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeObjectStore) PutObject(_ context.Context, bucketName, objectName string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return errors.Errorf("expected %d bytes, got %d", size, len(data))
+	}
+	s.objects[bucketName+"/"+objectName] = data
+	return nil
+}
+
+func (s *fakeObjectStore) ListObjects(_ context.Context, bucketName, prefix string) ([]string, error) {
+	var names []string
+	for key := range s.objects {
+		name := strings.TrimPrefix(key, bucketName+"/")
+		if name != key && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *fakeObjectStore) DeleteObject(_ context.Context, bucketName, objectName string) error {
+	delete(s.objects, bucketName+"/"+objectName)
+	return nil
+}
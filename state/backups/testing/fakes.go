@@ -41,6 +41,8 @@ type FakeBackups struct {
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// ControllerUUIDArg holds the controller UUID that was passed in.
+	ControllerUUIDArg string
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -63,9 +65,36 @@ func (b *FakeBackups) Create(
 	return b.Filename, b.Error
 }
 
+// CreateIncremental creates and stores a new incremental juju backup
+// archive.
+func (b *FakeBackups) CreateIncremental(
+	meta *backups.Metadata,
+	dbInfo *backups.DBInfo,
+) (string, error) {
+	b.Calls = append(b.Calls, "CreateIncremental")
+
+	b.DBInfoArg = dbInfo
+	b.MetaArg = meta
+
+	if b.Meta != nil {
+		*meta = *b.Meta
+	}
+
+	return b.Filename, b.Error
+}
+
 // Get returns the metadata and archive file associated with the ID.
 func (b *FakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
 	b.Calls = append(b.Calls, "Get")
 	b.IDArg = id
 	return b.Meta, b.Archive, b.Error
 }
+
+// Restore restores the backup archive and returns its metadata.
+func (b *FakeBackups) Restore(archive io.Reader, dbInfo *backups.DBInfo, controllerUUID string) (*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "Restore")
+	b.ArchiveArg = archive
+	b.DBInfoArg = dbInfo
+	b.ControllerUUIDArg = controllerUUID
+	return b.Meta, b.Error
+}
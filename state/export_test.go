@@ -1271,3 +1271,12 @@ func (m *Model) AllActionIDsHasActionNotifications() ([]string, error) {
 	}
 	return actionIDs, nil
 }
+
+// SetMaxFilterScanLines sets the number of log documents examined when
+// looking for initial lines matching a filter, returning a func which
+// restores the original value.
+func SetMaxFilterScanLines(n int) func() {
+	orig := maxFilterScanLines
+	maxFilterScanLines = n
+	return func() { maxFilterScanLines = orig }
+}
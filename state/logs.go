@@ -442,6 +442,11 @@ var maxRecentLogIds = int(oplogOverlap.Minutes() * 150000)
 // so that we can iterate them in the correct order.
 var maxInitialLines = 10000
 
+// maxFilterScanLines limits the number of documents examined when
+// looking for the initial lines that match a filter. Without it, a
+// filter which rarely matches would scan the whole logs collection.
+var maxFilterScanLines = maxInitialLines * 10
+
 // LogTailerState describes the methods on State required for logging to
 // the database.
 type LogTailerState interface {
@@ -456,6 +461,13 @@ type LogTailerState interface {
 func NewLogTailer(
 	st LogTailerState, params corelogger.LogTailerParams, opLog *mgo.Collection,
 ) (corelogger.LogTailer, error) {
+	var messageRegex *regexp.Regexp
+	if params.MessageRegex != "" {
+		var err error
+		if messageRegex, err = regexp.Compile(params.MessageRegex); err != nil {
+			return nil, errors.Annotate(err, "invalid message regular expression")
+		}
+	}
 	session := st.MongoSession().Copy()
 
 	if opLog == nil {
@@ -468,9 +480,11 @@ func NewLogTailer(
 		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
 		opLog:           opLog,
 		params:          params,
+		messageRegex:    messageRegex,
 		logCh:           make(chan *corelogger.LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
 		maxInitialLines: maxInitialLines,
+		maxFilterScan:   maxFilterScanLines,
 	}
 	t.tomb.Go(func() error {
 		defer close(t.logCh)
@@ -488,11 +502,13 @@ type logTailer struct {
 	logsColl        *mgo.Collection
 	opLog           *mgo.Collection
 	params          corelogger.LogTailerParams
+	messageRegex    *regexp.Regexp
 	logCh           chan *corelogger.LogRecord
 	lastID          int64
 	lastTime        time.Time
 	recentIds       *recentIdTracker
 	maxInitialLines int
+	maxFilterScan   int
 }
 
 // Logs implements the LogTailer interface.
@@ -546,49 +562,92 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 		return errors.Errorf("too many lines requested (%d) maximum is %d",
 			t.params.InitialLines, maxInitialLines)
 	}
-	iter := query.Sort("-t", "-_id").
-		Limit(t.params.InitialLines).
-		Iter()
+	// Records rejected by the filter don't count towards the initial
+	// lines, so when there's a filter we can only limit the query to
+	// the number of documents we're prepared to scan.
+	query = query.Sort("-t", "-_id")
+	if !t.filtered() {
+		query = query.Limit(t.params.InitialLines)
+	} else {
+		query = query.Limit(t.maxFilterScan)
+	}
+	iter := query.Iter()
 	defer iter.Close()
-	queue := make([]logDoc, t.params.InitialLines)
+	type queued struct {
+		id  bson.ObjectId
+		rec *corelogger.LogRecord
+	}
+	queue := make([]queued, t.params.InitialLines)
 	cur := t.params.InitialLines
 
-	var doc logDoc
-	for iter.Next(&doc) {
+	var (
+		doc     logDoc
+		scanned int
+		oldest  time.Time
+	)
+	for cur > 0 && iter.Next(&doc) {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
 		default:
 		}
-		cur--
-		queue[cur] = doc
-		if cur == 0 {
-			break
+		scanned++
+		rec, err := logDocToRecord(t.modelUUID, &doc)
+		if err != nil {
+			return errors.Annotate(err, "deserialization failed (possible DB corruption)")
+		}
+		oldest = rec.Time
+		if !t.matches(rec) {
+			continue
 		}
+		cur--
+		queue[cur] = queued{id: doc.Id, rec: rec}
 	}
 	if err := iter.Close(); err != nil {
 		return errors.Trace(err)
 	}
+	if t.filtered() && cur > 0 && scanned >= t.maxFilterScan {
+		// We gave up before finding enough matching records, so let
+		// the client know that older records weren't searched.
+		select {
+		case <-t.tomb.Dying():
+			return errors.Trace(tomb.ErrDying)
+		case t.logCh <- t.truncatedRecord(t.params.InitialLines-cur, oldest):
+		}
+	}
 	// We loaded the queue in reverse order, truncate it to just the actual
 	// contents, and then return them in the correct order.
 	queue = queue[cur:]
-	for _, doc := range queue {
-		rec, err := logDocToRecord(t.modelUUID, &doc)
-		if err != nil {
-			return errors.Annotate(err, "deserialization failed (possible DB corruption)")
-		}
+	for _, q := range queue {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
-		case t.logCh <- rec:
-			t.lastID = rec.ID
-			t.lastTime = rec.Time
-			t.recentIds.Add(doc.Id)
+		case t.logCh <- q.rec:
+			t.lastID = q.rec.ID
+			t.lastTime = q.rec.Time
+			t.recentIds.Add(q.id)
 		}
 	}
 	return nil
 }
 
+// truncatedRecord returns a record reporting that the search for
+// initial lines matching the filter found only the given number of
+// matches before reaching the scan limit at the given time.
+func (t *logTailer) truncatedRecord(matched int, at time.Time) *corelogger.LogRecord {
+	return &corelogger.LogRecord{
+		Time:      at,
+		ModelUUID: t.modelUUID,
+		Entity:    "controller",
+		Level:     loggo.WARNING,
+		Module:    "juju.state.logs",
+		Message: fmt.Sprintf(
+			"filter matched %d of the %d most recent log records, older records were not searched",
+			matched, t.maxFilterScan,
+		),
+	}
+}
+
 func (t *logTailer) processCollection() error {
 	// Create a selector from the params.
 	sel := t.paramsToSelector(t.params, "")
@@ -632,6 +691,9 @@ func (t *logTailer) processCollection() error {
 			}
 			deserialisationFailures = 0
 		}
+		if !t.matches(rec) {
+			continue
+		}
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
@@ -653,6 +715,9 @@ func (t *logTailer) tailOplog() error {
 
 	newParams := t.params
 	newParams.StartID = t.lastID // (t.lastID + 1) once Id is a sequential int.
+	// Records logged after the end time aren't selected; the first one
+	// seen ends the tailing instead.
+	newParams.EndTime = time.Time{}
 	oplogSel := append(t.paramsToSelector(newParams, "o."),
 		bson.DocElem{"ns", logsDB + "." + logCollectionName(t.modelUUID)},
 	)
@@ -709,6 +774,12 @@ func (t *logTailer) tailOplog() error {
 				}
 				deserialisationFailures = 0
 			}
+			if !t.params.EndTime.IsZero() && rec.Time.After(t.params.EndTime) {
+				return nil
+			}
+			if !t.matches(rec) {
+				continue
+			}
 			select {
 			case <-t.tomb.Dying():
 				return tomb.ErrDying
@@ -718,10 +789,35 @@ func (t *logTailer) tailOplog() error {
 	}
 }
 
+// filtered returns true if records are matched by the tailer, as
+// well as by the selector.
+func (t *logTailer) filtered() bool {
+	return t.params.Filter != nil || t.messageRegex != nil
+}
+
+// matches returns true if the record satisfies the params' filter and
+// message regular expression, which can't be expressed as part of the
+// selector. The regular expression is matched here rather than by
+// MongoDB, whose PCRE syntax differs from the Go syntax it is checked
+// against.
+func (t *logTailer) matches(rec *corelogger.LogRecord) bool {
+	if t.messageRegex != nil && !t.messageRegex.MatchString(rec.Message) {
+		return false
+	}
+	return t.params.Filter == nil || t.params.Filter.Match(rec)
+}
+
 func (t *logTailer) paramsToSelector(params corelogger.LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeSel := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeSel["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeSel["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -464,6 +464,88 @@ func (s *LogTailerSuite) TestInitialLines(c *gc.C) {
 	s.assertTailer(c, tailer, 5, expected)
 }
 
+func (s *LogTailerSuite) TestInitialLinesWithFilters(c *gc.C) {
+	expected := logTemplate{Message: "connection refused", Level: loggo.ERROR}
+	s.writeLogs(c, s.otherUUID, 3, expected)
+	// The most recent lines don't match, but mustn't use up the
+	// initial lines.
+	s.writeLogs(c, s.otherUUID, 5, logTemplate{Message: "connection refused"})
+	s.writeLogs(c, s.otherUUID, 5, logTemplate{Message: "all good", Level: loggo.ERROR})
+
+	filter, err := corelogger.ParseFilter("level >= ERROR")
+	c.Assert(err, jc.ErrorIsNil)
+	tailer, err := state.NewLogTailer(s.otherState, corelogger.LogTailerParams{
+		InitialLines: 2,
+		MessageRegex: "^connection",
+		Filter:       filter,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	s.assertTailer(c, tailer, 2, expected)
+}
+
+func (s *LogTailerSuite) TestInitialLinesWithFilterScanLimit(c *gc.C) {
+	restore := state.SetMaxFilterScanLines(5)
+	defer restore()
+
+	// The only matching record is older than the scan limit allows.
+	s.writeLogs(c, s.otherUUID, 1, logTemplate{Message: "too old", Level: loggo.ERROR})
+	expected := logTemplate{Message: "want", Level: loggo.ERROR}
+	s.writeLogs(c, s.otherUUID, 1, expected)
+	s.writeLogs(c, s.otherUUID, 4, logTemplate{Message: "dont want"})
+
+	filter, err := corelogger.ParseFilter("level >= ERROR")
+	c.Assert(err, jc.ErrorIsNil)
+	tailer, err := state.NewLogTailer(s.otherState, corelogger.LogTailerParams{
+		InitialLines: 3,
+		NoTail:       true,
+		Filter:       filter,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The truncation is reported ahead of the records found.
+	var messages []string
+	for log := range tailer.Logs() {
+		messages = append(messages, log.Message)
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+	c.Assert(messages, jc.DeepEquals, []string{
+		"filter matched 1 of the 5 most recent log records, older records were not searched",
+		"want",
+	})
+}
+
+func (s *LogTailerSuite) TestEndTime(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c, s.otherUUID, threshT.Add(time.Second), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, corelogger.LogTailerParams{
+		EndTime: threshT,
+	}, s.oplogColl)
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops once it sees a log after the end time in
+	// the oplog.
+	s.writeLogsT(c, s.otherUUID, threshT.Add(6*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+	select {
+	case log, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse, gc.Commentf("unexpected log %v", log))
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
 func (s *LogTailerSuite) TestRecordsAddedOutOfTimeOrder(c *gc.C) {
 	format := "2006-01-02 03:04"
 	t1, err := time.Parse(format, "2016-11-25 09:10")
@@ -761,6 +843,34 @@ func (s *LogTailerSuite) TestIncludeExcludeLabels(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	// Unlike PCRE, Go's "$" doesn't match before a trailing newline.
+	done := logTemplate{Message: "done"}
+	doneNewline := logTemplate{Message: "done\n"}
+	other := logTemplate{Message: "not done yet"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, done)
+		s.writeLogs(c, s.otherUUID, 1, doneNewline)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, done)
+	}
+	params := corelogger.LogTailerParams{
+		MessageRegex: "^done$",
+	}
+	assert := func(tailer corelogger.LogTailer) {
+		s.assertTailer(c, tailer, 2, done)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessageRegexInvalid(c *gc.C) {
+	// Lookbehinds are valid PCRE but not valid Go.
+	_, err := state.NewLogTailer(s.otherState, corelogger.LogTailerParams{
+		MessageRegex: "(?<=a)b",
+	}, s.oplogColl)
+	c.Assert(err, gc.ErrorMatches, "invalid message regular expression: .*")
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -22,6 +22,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/arch"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
@@ -858,6 +859,43 @@ func (s *MigrationImportSuite) TestCAASApplications(c *gc.C) {
 	c.Assert(state.GetApplicationHasResources(newApp), jc.IsTrue)
 }
 
+func (s *MigrationImportSuite) TestCAASApplicationAutoscalePolicyNotMigrated(c *gc.C) {
+	caasSt := s.Factory.MakeCAASModel(c, nil)
+	s.AddCleanup(func(_ *gc.C) { caasSt.Close() })
+
+	cons := constraints.MustParse("arch=amd64 mem=8G")
+	platform := &state.Platform{Architecture: arch.DefaultArchitecture, OS: "ubuntu", Channel: "20.04/stable"}
+	charm, application, _ := s.setupSourceApplications(c, caasSt, cons, platform, true)
+	policy := coreapplication.AutoscalePolicy{
+		MinUnits:  1,
+		MaxUnits:  5,
+		TargetCPU: 60,
+		Cooldown:  3 * time.Minute,
+	}
+	err := application.SetAutoscalePolicy(&policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c, caasSt)
+	f := factory.NewFactory(newSt, s.StatePool)
+	f.MakeCharm(c, &factory.CharmParams{
+		Name:     "starsay",
+		Series:   "kubernetes",
+		URL:      charm.URL(),
+		Revision: strconv.Itoa(charm.Revision()),
+	})
+
+	// Autoscale policies aren't in the model description, so
+	// aren't migrated.
+	newApp, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = newApp.AutoscalePolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	annotations, err := newModel.Annotations(newApp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, testAnnotations)
+}
+
 func (s *MigrationImportSuite) TestCAASApplicationStatus(c *gc.C) {
 	// Caas application status that is derived from unit statuses must survive migration.
 	caasSt := s.Factory.MakeCAASModel(c, nil)
@@ -125,6 +125,13 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// What the autoscaler observed is observed again by the
+		// target controller's autoscaler.
+		autoscaleStatusC,
+		// Action schedules aren't in the model description yet,
+		// so need to be added again after migration; migrate
+		// warns about each of them.
+		actionSchedulesC,
 		// reference counts are implementation details that should be
 		// reconstructed on the other side.
 		refcountsC,
@@ -147,6 +154,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// We don't import any of the migration collections.
 		migrationsC,
 		migrationsStatusC,
+		migrationsCheckpointC,
 		migrationsActiveC,
 		migrationsMinionSyncC,
 
@@ -214,6 +222,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Secret backends are per controller.
 		secretBackendsC,
 		secretBackendsRotateC,
+		// Model keys are wrapped by master keys of the controller.
+		secretBackendModelKeysC,
+		// Secrets migrations move content between the backends of
+		// the source controller.
+		secretsMigrationsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -396,6 +409,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Autoscale policies aren't in the model description, so
+		// need to be set again after migration.
+		"Autoscale",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -753,6 +769,9 @@ func (s *MigrationSuite) TestOperationDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
 		"CompleteTaskCount",
+		// Rollouts aren't migrated; batches of a rollout
+		// which have not been enqueued are dropped.
+		"Rollout",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -961,6 +980,13 @@ func (s *MigrationSuite) TestSecretRevisionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"DocID",
 		"TxnRevno",
+		// The description's SecretRevision (juju/description/v5)
+		// has no author or rotation fields. Both only annotate the
+		// revision history shown by show-secret --history; the
+		// imported revisions and their content are unaffected and
+		// later revisions record them again.
+		"Author",
+		"Rotated",
 	)
 	migrated := set.NewStrings(
 		"Revision",
@@ -76,6 +76,23 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// Checkpoint returns the progress made by the migration.
+	Checkpoint() (migration.Checkpoint, error)
+
+	// SetCheckpoint records the progress made by the migration. An
+	// error will be returned if the migration's phase has changed.
+	SetCheckpoint(checkpoint migration.Checkpoint) error
+
+	// Resume retries the phase of a migration which failed in a
+	// resumable phase. An error satisfying errors.NotValid is
+	// returned if the migration isn't waiting to be resumed.
+	Resume() error
+
+	// Abort aborts a migration which failed in a resumable phase. An
+	// error satisfying errors.NotValid is returned if the migration
+	// isn't waiting to be resumed.
+	Abort() error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -205,6 +222,27 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// FailedPhase holds the phase which failed, if the migration is
+	// waiting to be resumed or aborted. It's kept here, rather than
+	// with the rest of the checkpoint, so that resuming or aborting
+	// the migration wakes its status watchers.
+	FailedPhase string `bson:"failed-phase,omitempty"`
+}
+
+// modelMigCheckpointDoc records the progress made by a migration
+// attempt. See core/migration.Checkpoint. These are written into
+// migrationsCheckpointC, and are kept apart from the status docs as
+// they're written as each binary is uploaded and as logs are
+// transferred, which mustn't wake the status watchers.
+type modelMigCheckpointDoc struct {
+	// Id is the same as the id in migrationsC.
+	Id        string   `bson:"_id"`
+	Imported  bool     `bson:"imported,omitempty"`
+	Charms    []string `bson:"charms,omitempty"`
+	Tools     []string `bson:"tools,omitempty"`
+	Resources []string `bson:"resources,omitempty"`
+	LogsSent  int      `bson:"logs-sent,omitempty"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -427,6 +465,168 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// Checkpoint implements ModelMigration.
+func (mig *modelMigration) Checkpoint() (migration.Checkpoint, error) {
+	var checkpoint migration.Checkpoint
+	if failed := mig.statusDoc.FailedPhase; failed != "" {
+		phase, ok := migration.ParsePhase(failed)
+		if !ok {
+			return migration.Checkpoint{}, errors.Errorf("invalid failed phase in DB: %v", failed)
+		}
+		checkpoint.FailedPhase = phase
+	}
+
+	coll, closer := mig.st.db().GetCollection(migrationsCheckpointC)
+	defer closer()
+	var doc modelMigCheckpointDoc
+	err := coll.FindId(mig.doc.Id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return checkpoint, nil
+	} else if err != nil {
+		return migration.Checkpoint{}, errors.Annotate(err, "migration checkpoint lookup failed")
+	}
+	checkpoint.Imported = doc.Imported
+	checkpoint.Charms = doc.Charms
+	checkpoint.Tools = doc.Tools
+	checkpoint.Resources = doc.Resources
+	checkpoint.LogsSent = doc.LogsSent
+	return checkpoint, nil
+}
+
+// SetCheckpoint implements ModelMigration.
+func (mig *modelMigration) SetCheckpoint(checkpoint migration.Checkpoint) error {
+	var failedPhase string
+	if checkpoint.IsFailed() {
+		if !checkpoint.FailedPhase.IsResumable() {
+			return errors.NotValidf("failed phase %s", checkpoint.FailedPhase)
+		}
+		failedPhase = checkpoint.FailedPhase.String()
+	}
+	doc := modelMigCheckpointDoc{
+		Id:        mig.doc.Id,
+		Imported:  checkpoint.Imported,
+		Charms:    checkpoint.Charms,
+		Tools:     checkpoint.Tools,
+		Resources: checkpoint.Resources,
+		LogsSent:  checkpoint.LogsSent,
+	}
+
+	coll, closer := mig.st.db().GetCollection(migrationsCheckpointC)
+	defer closer()
+
+	phase := mig.statusDoc.Phase
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := mig.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if mig.statusDoc.Phase != phase {
+				return nil, errors.New("phase already changed")
+			}
+		}
+		// Ensure phase hasn't changed underneath us. The status doc
+		// is only updated when the failed phase changes.
+		statusOp := txn.Op{
+			C:      migrationsStatusC,
+			Id:     mig.statusDoc.Id,
+			Assert: bson.M{"phase": mig.statusDoc.Phase},
+		}
+		if failedPhase != mig.statusDoc.FailedPhase {
+			if failedPhase == "" {
+				statusOp.Update = bson.M{"$unset": bson.M{"failed-phase": nil}}
+			} else {
+				statusOp.Update = bson.M{"$set": bson.M{"failed-phase": failedPhase}}
+			}
+		}
+		ops := []txn.Op{statusOp}
+
+		n, err := coll.FindId(doc.Id).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			ops = append(ops, txn.Op{
+				C:      migrationsCheckpointC,
+				Id:     doc.Id,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      migrationsCheckpointC,
+				Id:     doc.Id,
+				Assert: txn.DocExists,
+				Update: bson.M{"$set": bson.M{
+					"imported":  doc.Imported,
+					"charms":    doc.Charms,
+					"tools":     doc.Tools,
+					"resources": doc.Resources,
+					"logs-sent": doc.LogsSent,
+				}},
+			})
+		}
+		return ops, nil
+	}
+	if err := mig.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "failed to set migration checkpoint")
+	}
+	mig.statusDoc.FailedPhase = failedPhase
+	return nil
+}
+
+// Resume implements ModelMigration.
+func (mig *modelMigration) Resume() error {
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$unset": bson.M{"failed-phase": nil}},
+		Assert: bson.M{
+			"phase":        mig.statusDoc.Phase,
+			"failed-phase": bson.M{"$exists": true},
+		},
+	}}
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NewNotValid(nil, "migration is not waiting to be resumed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to resume migration")
+	}
+	mig.statusDoc.FailedPhase = ""
+	return nil
+}
+
+// Abort implements ModelMigration.
+func (mig *modelMigration) Abort() error {
+	now := mig.st.clock().Now().UnixNano()
+	ops, err := migStatusHistoryAndOps(mig.st, migration.ABORT, now, mig.StatusMessage())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, txn.Op{
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{
+			"$set": bson.M{
+				"phase":              migration.ABORT.String(),
+				"phase-changed-time": now,
+			},
+			"$unset": bson.M{"failed-phase": nil},
+		},
+		Assert: bson.M{
+			"phase":        mig.statusDoc.Phase,
+			"failed-phase": bson.M{"$exists": true},
+		},
+	})
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NewNotValid(nil, "migration is not waiting to be resumed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to abort migration")
+	}
+	mig.statusDoc.Phase = migration.ABORT.String()
+	mig.statusDoc.PhaseChangedTime = now
+	mig.statusDoc.FailedPhase = ""
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -534,6 +534,101 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestCheckpoint(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	checkpoint, err := mig.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checkpoint, jc.DeepEquals, migration.Checkpoint{})
+
+	expected := migration.Checkpoint{
+		FailedPhase: migration.IMPORT,
+		Imported:    true,
+		Charms:      []string{"ch:foo-1"},
+		Tools:       []string{"3.3.0-ubuntu-amd64"},
+		Resources:   []string{"foo/bar"},
+	}
+	err = mig.SetCheckpoint(expected)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	checkpoint, err = mig2.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checkpoint, jc.DeepEquals, expected)
+}
+
+func (s *MigrationSuite) TestSetCheckpointNotResumable(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.SetCheckpoint(migration.Checkpoint{FailedPhase: migration.QUIESCE})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *MigrationSuite) TestSetCheckpointPhaseChanged(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig2.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	err = mig.SetCheckpoint(migration.Checkpoint{Imported: true})
+	c.Assert(err, gc.ErrorMatches, "phase already changed")
+}
+
+func (s *MigrationSuite) TestResume(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	err = mig.Resume()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = mig.SetCheckpoint(migration.Checkpoint{
+		FailedPhase: migration.IMPORT,
+		Imported:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig2.Resume(), jc.ErrorIsNil)
+
+	// The progress made is kept.
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	checkpoint, err := mig.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checkpoint, jc.DeepEquals, migration.Checkpoint{Imported: true})
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.IMPORT)
+}
+
+func (s *MigrationSuite) TestAbort(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	err = mig.Abort()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = mig.SetCheckpoint(migration.Checkpoint{FailedPhase: migration.IMPORT})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.Abort(), jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig2.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.ABORT)
+	checkpoint, err := mig2.Checkpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checkpoint.IsFailed(), jc.IsFalse)
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
@@ -634,6 +729,32 @@ func (s *MigrationSuite) TestWatchMigrationStatus(c *gc.C) {
 	wc.AssertClosed()
 }
 
+func (s *MigrationSuite) TestWatchMigrationStatusCheckpoint(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	w, wc := s.createStatusWatcher(c, s.State2)
+	wc.AssertOneChange() // Initial event.
+
+	// Recording progress doesn't wake the watcher.
+	c.Assert(mig.SetCheckpoint(migration.Checkpoint{Charms: []string{"ch:foo-1"}}), jc.ErrorIsNil)
+	c.Assert(mig.SetCheckpoint(migration.Checkpoint{Imported: true}), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Failing and resuming the migration does.
+	c.Assert(mig.SetCheckpoint(migration.Checkpoint{
+		FailedPhase: migration.IMPORT,
+		Imported:    true,
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange()
+	c.Assert(mig.Resume(), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *MigrationSuite) TestWatchMigrationStatusPreexisting(c *gc.C) {
 	// Create an aborted migration.
 	mig, err := s.State2.CreateMigration(s.stdSpec)
@@ -56,6 +56,10 @@ type Operation interface {
 
 	// SpawnedTaskCount returns the number of spawned actions.
 	SpawnedTaskCount() int
+
+	// Rollout returns how the operation's tasks are enqueued in
+	// batches, or nil if they were all enqueued at once.
+	Rollout() *OperationRollout
 }
 
 type operationDoc struct {
@@ -90,6 +94,10 @@ type operationDoc struct {
 	// this operation. It is used internally for mgo asserts and
 	// not exposed via the Operation interface.
 	SpawnedTaskCount int `bson:"spawned-task-count"`
+
+	// Rollout is set when the operation's tasks are enqueued
+	// in batches rather than all at once.
+	Rollout *operationRolloutDoc `bson:"rollout,omitempty"`
 }
 
 // operation represents a group of associated actions.
@@ -159,12 +167,24 @@ func (op *operation) Status() ActionStatus {
 	}
 	for _, s := range statusOrder {
 		if statusStats.Contains(string(s)) {
+			if op.rolloutPending() && !activeStatus.Contains(string(s)) {
+				// The tasks enqueued so far are done, but there
+				// are batches of the rollout still to come.
+				return ActionRunning
+			}
 			return s
 		}
 	}
 	return op.doc.Status
 }
 
+// rolloutPending returns true if the operation has batches of tasks
+// still to be enqueued.
+func (op *operation) rolloutPending() bool {
+	rollout := op.doc.Rollout
+	return rollout != nil && rollout.Stopped == "" && rollout.BatchesEnqueued < len(rollout.Batches)
+}
+
 // Refresh refreshes the contents of the operation.
 func (op *operation) Refresh() error {
 	doc, taskStatus, err := op.st.getOperationDoc(op.Id())
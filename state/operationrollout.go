@@ -0,0 +1,294 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	jujutxn "github.com/juju/txn/v3"
+)
+
+// OperationRollout describes an operation whose tasks are enqueued in
+// batches, each batch being enqueued once the previous one has finished.
+type OperationRollout struct {
+	// BatchSize is the maximum number of tasks in each batch.
+	BatchSize int
+
+	// BatchDelay is how long to wait after a batch has finished
+	// before the next one is enqueued.
+	BatchDelay time.Duration
+
+	// StopOnFailure is true if no further batches should be
+	// enqueued once a task has failed.
+	StopOnFailure bool
+
+	// Batches holds the tasks of each batch, in the order in
+	// which the batches are to be enqueued.
+	Batches [][]RolloutTask
+
+	// BatchesEnqueued is the number of batches enqueued so far.
+	BatchesEnqueued int
+
+	// Stopped records why the rollout finished before all of
+	// its batches were enqueued.
+	Stopped string
+}
+
+// RolloutTask describes a task to be enqueued as part of a later
+// batch of a rollout.
+type RolloutTask struct {
+	Receiver       string
+	Name           string
+	Parameters     map[string]interface{}
+	Parallel       *bool
+	ExecutionGroup *string
+}
+
+type operationRolloutDoc struct {
+	BatchSize       int                `bson:"batch-size"`
+	BatchDelay      time.Duration      `bson:"batch-delay"`
+	StopOnFailure   bool               `bson:"stop-on-failure"`
+	Batches         [][]rolloutTaskDoc `bson:"batches"`
+	BatchesEnqueued int                `bson:"batches-enqueued"`
+	Stopped         string             `bson:"stopped,omitempty"`
+}
+
+type rolloutTaskDoc struct {
+	Receiver       string                 `bson:"receiver"`
+	Name           string                 `bson:"name"`
+	Parameters     map[string]interface{} `bson:"parameters"`
+	Parallel       *bool                  `bson:"parallel,omitempty"`
+	ExecutionGroup *string                `bson:"execution-group,omitempty"`
+}
+
+func newOperationRolloutDoc(rollout OperationRollout) *operationRolloutDoc {
+	doc := &operationRolloutDoc{
+		BatchSize:     rollout.BatchSize,
+		BatchDelay:    rollout.BatchDelay,
+		StopOnFailure: rollout.StopOnFailure,
+		Batches:       make([][]rolloutTaskDoc, len(rollout.Batches)),
+	}
+	for i, batch := range rollout.Batches {
+		doc.Batches[i] = make([]rolloutTaskDoc, len(batch))
+		for j, task := range batch {
+			doc.Batches[i][j] = rolloutTaskDoc{
+				Receiver:       task.Receiver,
+				Name:           task.Name,
+				Parameters:     task.Parameters,
+				Parallel:       task.Parallel,
+				ExecutionGroup: task.ExecutionGroup,
+			}
+		}
+	}
+	return doc
+}
+
+// Rollout returns how the operation's tasks are enqueued in
+// batches, or nil if they were all enqueued at once.
+func (op *operation) Rollout() *OperationRollout {
+	doc := op.doc.Rollout
+	if doc == nil {
+		return nil
+	}
+	rollout := &OperationRollout{
+		BatchSize:       doc.BatchSize,
+		BatchDelay:      doc.BatchDelay,
+		StopOnFailure:   doc.StopOnFailure,
+		Batches:         make([][]RolloutTask, len(doc.Batches)),
+		BatchesEnqueued: doc.BatchesEnqueued,
+		Stopped:         doc.Stopped,
+	}
+	for i, batch := range doc.Batches {
+		rollout.Batches[i] = make([]RolloutTask, len(batch))
+		for j, task := range batch {
+			rollout.Batches[i][j] = RolloutTask{
+				Receiver:       task.Receiver,
+				Name:           task.Name,
+				Parameters:     task.Parameters,
+				Parallel:       task.Parallel,
+				ExecutionGroup: task.ExecutionGroup,
+			}
+		}
+	}
+	return rollout
+}
+
+// rolloutActiveStatus holds the statuses of an operation whose rollout
+// may still enqueue batches. The operation is marked as running when
+// the first task of its first batch starts, and stays so until the
+// rollout finishes.
+var rolloutActiveStatus = []interface{}{ActionPending, ActionRunning}
+
+func isRolloutActive(status ActionStatus) bool {
+	return status == ActionPending || status == ActionRunning
+}
+
+// EnqueueRolloutOperation records the start of an operation whose count
+// tasks are enqueued in batches. No batches are marked as enqueued; the
+// caller is expected to start the first one with StartRolloutBatch.
+func (m *Model) EnqueueRolloutOperation(summary string, count int, rollout OperationRollout) (string, error) {
+	if rollout.BatchSize < 1 {
+		return "", errors.NotValidf("batch size %d", rollout.BatchSize)
+	}
+	if rollout.BatchDelay < 0 {
+		return "", errors.NotValidf("negative batch delay")
+	}
+	if len(rollout.Batches) == 0 {
+		return "", errors.NotValidf("rollout without batches")
+	}
+	var operationID string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var doc operationDoc
+		var err error
+		doc, operationID, err = newOperationDoc(m.st, summary, count)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Rollout = newOperationRolloutDoc(rollout)
+
+		return []txn.Op{{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return operationID, errors.Trace(err)
+}
+
+// StartRolloutBatch records that the given batch of the operation's
+// rollout is being enqueued. It fails if that batch is not the next
+// one, so that concurrent callers cannot enqueue a batch twice.
+func (m *Model) StartRolloutBatch(operationID string, batch int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, _, err := m.st.getOperationDoc(operationID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Rollout == nil {
+			return nil, errors.NotValidf("operation %q without rollout", operationID)
+		}
+		if !isRolloutActive(doc.Status) || doc.Rollout.Stopped != "" {
+			return nil, errors.Errorf("rollout of operation %q has finished", operationID)
+		}
+		if doc.Rollout.BatchesEnqueued != batch {
+			return nil, errors.Errorf(
+				"cannot start batch %d of operation %q: next batch is %d",
+				batch, operationID, doc.Rollout.BatchesEnqueued,
+			)
+		}
+		if batch >= len(doc.Rollout.Batches) {
+			return nil, errors.NotFoundf("batch %d of operation %q", batch, operationID)
+		}
+		return []txn.Op{{
+			C:  operationsC,
+			Id: m.st.docID(operationID),
+			Assert: bson.D{
+				{"status", bson.D{{"$in", rolloutActiveStatus}}},
+				{"rollout.batches-enqueued", batch},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"rollout.batches-enqueued", batch + 1},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// FinishRollout completes an operation whose rollout will enqueue no
+// further tasks, either because all of its batches are done or because
+// it has been stopped; a non-empty reason records why it was stopped.
+// The operation's status is derived from those of the tasks enqueued,
+// all of which must have finished.
+func (m *Model) FinishRollout(operationID, reason string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, taskStatus, err := m.st.getOperationDoc(operationID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Rollout == nil {
+			return nil, errors.NotValidf("operation %q without rollout", operationID)
+		}
+		if !isRolloutActive(doc.Status) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		statusStats := make(map[ActionStatus]bool)
+		for _, s := range taskStatus {
+			if activeStatus.Contains(string(s)) {
+				return nil, errors.Errorf("operation %q has unfinished tasks", operationID)
+			}
+			statusStats[s] = true
+		}
+		finalStatus := ActionError
+		for _, s := range statusCompletedOrder {
+			if statusStats[s] {
+				finalStatus = s
+				break
+			}
+		}
+		if finalStatus == ActionCompleted && doc.Fail != "" {
+			finalStatus = ActionError
+		}
+		fail := doc.Fail
+		if reason != "" {
+			fail = strings.TrimPrefix(fail+"; "+reason, "; ")
+		}
+		return []txn.Op{{
+			C:  operationsC,
+			Id: m.st.docID(operationID),
+			Assert: bson.D{
+				{"status", bson.D{{"$in", rolloutActiveStatus}}},
+				{"complete-task-count", doc.CompleteTaskCount},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"status", finalStatus},
+				{"fail", fail},
+				{"completed", m.st.nowToTheSecond()},
+				{"spawned-task-count", len(taskStatus)},
+				{"complete-task-count", len(taskStatus)},
+				{"rollout.stopped", reason},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// ActiveRollouts returns the operations whose tasks are being enqueued
+// in batches and which have not yet completed.
+func (m *Model) ActiveRollouts() ([]OperationInfo, error) {
+	operations, closer := m.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	err := operations.Find(bson.D{
+		{"rollout", bson.D{{"$exists", true}}},
+		{"status", bson.D{{"$in", rolloutActiveStatus}}},
+	}).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get active rollouts")
+	}
+	result := make([]OperationInfo, 0, len(docs))
+	for _, doc := range docs {
+		info, err := m.OperationWithActions(m.st.localID(doc.DocId))
+		if errors.Is(err, errors.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, *info)
+	}
+	return result, nil
+}
+
+// WatchRollouts returns a NotifyWatcher which triggers whenever an
+// operation of the model is enqueued or changes, which includes each
+// time one of its tasks completes.
+func (m *Model) WatchRollouts() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, operationsC, isLocalID(m.st))
+}
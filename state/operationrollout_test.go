@@ -0,0 +1,262 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type OperationRolloutSuite struct {
+	ConnSuite
+
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&OperationRolloutSuite{})
+
+func (s *OperationRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *OperationRolloutSuite) rollout() state.OperationRollout {
+	task := func(unit *state.Unit) state.RolloutTask {
+		return state.RolloutTask{
+			Receiver:   unit.Tag().String(),
+			Name:       "backup",
+			Parameters: map[string]interface{}{},
+		}
+	}
+	return state.OperationRollout{
+		BatchSize:     2,
+		BatchDelay:    time.Minute,
+		StopOnFailure: true,
+		Batches: [][]state.RolloutTask{
+			{task(s.units[0]), task(s.units[1])},
+			{task(s.units[2])},
+		},
+	}
+}
+
+func (s *OperationRolloutSuite) enqueueBatch(c *gc.C, operationID string, batch int, units ...*state.Unit) []state.Action {
+	err := s.Model.StartRolloutBatch(operationID, batch)
+	c.Assert(err, jc.ErrorIsNil)
+	var result []state.Action
+	for _, unit := range units {
+		a, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil, false, "", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		result = append(result, a)
+	}
+	return result
+}
+
+func (s *OperationRolloutSuite) TestEnqueueRolloutOperation(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.SpawnedTaskCount(), gc.Equals, 3)
+	c.Assert(operation.Rollout(), jc.DeepEquals, &state.OperationRollout{
+		BatchSize:     2,
+		BatchDelay:    time.Minute,
+		StopOnFailure: true,
+		Batches:       s.rollout().Batches,
+	})
+}
+
+func (s *OperationRolloutSuite) TestEnqueueRolloutOperationInvalid(c *gc.C) {
+	rollout := s.rollout()
+	rollout.BatchSize = 0
+	_, err := s.Model.EnqueueRolloutOperation("a rollout", 3, rollout)
+	c.Assert(err, gc.ErrorMatches, "batch size 0 not valid")
+
+	rollout = s.rollout()
+	rollout.Batches = nil
+	_, err = s.Model.EnqueueRolloutOperation("a rollout", 3, rollout)
+	c.Assert(err, gc.ErrorMatches, "rollout without batches not valid")
+}
+
+func (s *OperationRolloutSuite) TestOperationWithoutRollout(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("an operation", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Rollout(), gc.IsNil)
+}
+
+func (s *OperationRolloutSuite) TestStartRolloutBatch(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.StartRolloutBatch(operationID, 1)
+	c.Assert(err, gc.ErrorMatches, `cannot start batch 1 of operation ".*": next batch is 0`)
+
+	err = s.Model.StartRolloutBatch(operationID, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.StartRolloutBatch(operationID, 0)
+	c.Assert(err, gc.ErrorMatches, `cannot start batch 0 of operation ".*": next batch is 1`)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Rollout().BatchesEnqueued, gc.Equals, 1)
+}
+
+func (s *OperationRolloutSuite) TestStatusRunningBetweenBatches(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.enqueueBatch(c, operationID, 0, s.units[0], s.units[1])
+	for _, a := range actions {
+		_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(operation.Completed().IsZero(), jc.IsTrue)
+
+	actions = s.enqueueBatch(c, operationID, 1, s.units[2])
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err = s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(operation.Completed().IsZero(), jc.IsFalse)
+
+	active, err := s.Model.ActiveRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, gc.HasLen, 0)
+}
+
+func (s *OperationRolloutSuite) TestRolloutActiveOnceRunning(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.enqueueBatch(c, operationID, 0, s.units[0], s.units[1])
+	for _, a := range actions {
+		a, err = a.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionRunning)
+
+	active, err := s.Model.ActiveRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, gc.HasLen, 1)
+	c.Assert(active[0].Operation.Id(), gc.Equals, operationID)
+
+	actions = s.enqueueBatch(c, operationID, 1, s.units[2])
+	a, err := actions[0].Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err = s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(operation.Rollout().BatchesEnqueued, gc.Equals, 2)
+
+	active, err = s.Model.ActiveRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, gc.HasLen, 0)
+}
+
+func (s *OperationRolloutSuite) TestFinishRolloutRunning(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.enqueueBatch(c, operationID, 0, s.units[0], s.units[1])
+	for _, a := range actions {
+		a, err = a.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = a.Finish(state.ActionResults{Status: state.ActionFailed})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err = s.Model.FinishRollout(operationID, "stopped")
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Rollout().Stopped, gc.Equals, "stopped")
+	c.Assert(operation.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationRolloutSuite) TestFinishRolloutStopped(c *gc.C) {
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.enqueueBatch(c, operationID, 0, s.units[0], s.units[1])
+
+	active, err := s.Model.ActiveRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, gc.HasLen, 1)
+	c.Assert(active[0].Operation.Id(), gc.Equals, operationID)
+	c.Assert(active[0].Actions, gc.HasLen, 2)
+
+	err = s.Model.FinishRollout(operationID, "stopped")
+	c.Assert(err, gc.ErrorMatches, `operation ".*" has unfinished tasks`)
+
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = actions[1].Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.FinishRollout(operationID, "stopped")
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Fail(), gc.Equals, "stopped")
+	c.Assert(operation.SpawnedTaskCount(), gc.Equals, 2)
+	c.Assert(operation.Rollout().Stopped, gc.Equals, "stopped")
+	c.Assert(operation.Completed().IsZero(), jc.IsFalse)
+
+	err = s.Model.StartRolloutBatch(operationID, 1)
+	c.Assert(err, gc.ErrorMatches, `rollout of operation ".*" has finished`)
+
+	active, err = s.Model.ActiveRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, gc.HasLen, 0)
+}
+
+func (s *OperationRolloutSuite) TestWatchRollouts(c *gc.C) {
+	w := s.Model.WatchRollouts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	operationID, err := s.Model.EnqueueRolloutOperation("a rollout", 3, s.rollout())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	actions := s.enqueueBatch(c, operationID, 0, s.units[0], s.units[1])
+	wc.AssertOneChange()
+
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	_, err = actions[1].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -48,6 +48,11 @@ type SecretBackendsStorage interface {
 	GetSecretBackend(name string) (*secrets.SecretBackend, error)
 	GetSecretBackendByID(ID string) (*secrets.SecretBackend, error)
 	SecretBackendRotated(ID string, next time.Time) error
+
+	GetSecretBackendModelKey(backendID, modelUUID string) (*secrets.WrappedKey, error)
+	AddSecretBackendModelKey(backendID, modelUUID string, key secrets.WrappedKey) error
+	UpdateSecretBackendModelKey(backendID, modelUUID string, key secrets.WrappedKey) error
+	ListSecretBackendModelKeys(backendID string) (map[string]secrets.WrappedKey, error)
 }
 
 // NewSecretBackends creates a new mongo backed secrets storage.
@@ -322,12 +327,146 @@ func (s *secretBackendsStorage) DeleteSecretBackend(name string, force bool) err
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		modelKeyOps, err := s.st.removeSecretBackendModelKeysOps(bson.D{{"backend-id", b.ID}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
-		return append([]txn.Op{deleteOp}, refCountOp...), nil
+		ops := append([]txn.Op{deleteOp}, refCountOp...)
+		return append(ops, modelKeyOps...), nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
+}
+
+// secretBackendModelKeyDoc holds the data key of a model, wrapped by
+// a master key, for backends which encrypt content with model keys.
+type secretBackendModelKeyDoc struct {
+	DocID       string `bson:"_id"`
+	BackendID   string `bson:"backend-id"`
+	ModelUUID   string `bson:"model-uuid"`
+	MasterKeyID string `bson:"master-key-id"`
+	WrappedKey  []byte `bson:"wrapped-key"`
+}
+
+func secretBackendModelKeyID(backendID, modelUUID string) string {
+	return fmt.Sprintf("%s#%s", backendID, modelUUID)
+}
+
+// GetSecretBackendModelKey returns the wrapped data key of the model
+// for the specified backend.
+func (s *secretBackendsStorage) GetSecretBackendModelKey(backendID, modelUUID string) (*secrets.WrappedKey, error) {
+	modelKeysColl, closer := s.st.db().GetCollection(secretBackendModelKeysC)
+	defer closer()
+
+	var doc secretBackendModelKeyDoc
+	err := modelKeysColl.FindId(secretBackendModelKeyID(backendID, modelUUID)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("model key for secret backend %q", backendID)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secrets.WrappedKey{MasterKeyID: doc.MasterKeyID, Key: doc.WrappedKey}, nil
+}
+
+// AddSecretBackendModelKey records the wrapped data key of the model
+// for the specified backend. Each model has only one key for a backend;
+// if the key has already been added, an AlreadyExists error is returned.
+func (s *secretBackendsStorage) AddSecretBackendModelKey(backendID, modelUUID string, key secrets.WrappedKey) error {
+	id := secretBackendModelKeyID(backendID, modelUUID)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := s.st.checkBackendExists(backendID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 {
+			if _, err := s.GetSecretBackendModelKey(backendID, modelUUID); err == nil {
+				return nil, errors.AlreadyExistsf("model key for secret backend %q", backendID)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:      secretBackendsC,
+			Id:     backendID,
+			Assert: txn.DocExists,
+		}, {
+			C:      secretBackendModelKeysC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: secretBackendModelKeyDoc{
+				DocID:       id,
+				BackendID:   backendID,
+				ModelUUID:   modelUUID,
+				MasterKeyID: key.MasterKeyID,
+				WrappedKey:  key.Key,
+			},
+		}}, nil
 	}
 	return errors.Trace(s.st.db().Run(buildTxn))
 }
 
+// UpdateSecretBackendModelKey replaces the wrapped data key of the
+// model for the specified backend, after it has been re-wrapped by
+// another master key.
+func (s *secretBackendsStorage) UpdateSecretBackendModelKey(backendID, modelUUID string, key secrets.WrappedKey) error {
+	id := secretBackendModelKeyID(backendID, modelUUID)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := s.GetSecretBackendModelKey(backendID, modelUUID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      secretBackendModelKeysC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.M{
+				"master-key-id": key.MasterKeyID,
+				"wrapped-key":   key.Key,
+			}},
+		}}, nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
+}
+
+// ListSecretBackendModelKeys returns the wrapped data keys of every
+// model for the specified backend, keyed on model UUID.
+func (s *secretBackendsStorage) ListSecretBackendModelKeys(backendID string) (map[string]secrets.WrappedKey, error) {
+	modelKeysColl, closer := s.st.db().GetCollection(secretBackendModelKeysC)
+	defer closer()
+
+	var docs []secretBackendModelKeyDoc
+	if err := modelKeysColl.Find(bson.D{{"backend-id", backendID}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]secrets.WrappedKey, len(docs))
+	for _, doc := range docs {
+		result[doc.ModelUUID] = secrets.WrappedKey{MasterKeyID: doc.MasterKeyID, Key: doc.WrappedKey}
+	}
+	return result, nil
+}
+
+// removeSecretBackendModelKeysOps returns the ops to remove the model
+// keys matching the query.
+func (st *State) removeSecretBackendModelKeysOps(query bson.D) ([]txn.Op, error) {
+	modelKeysColl, closer := st.db().GetCollection(secretBackendModelKeysC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := modelKeysColl.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretBackendModelKeysC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
 func secretBackendRefCountKey(backendID string) string {
 	return fmt.Sprintf("secretbackend#revisions#%s", backendID)
 }
@@ -520,6 +520,52 @@ func (s *SecretBackendsSuite) TestSecretBackendRotatedConcurrent(c *gc.C) {
 	c.Assert(nextTime, gc.Equals, later)
 }
 
+func (s *SecretBackendsSuite) TestModelKeys(c *gc.C) {
+	id, err := s.storage.CreateSecretBackend(state.CreateSecretBackendParams{
+		Name:        "myencrypted",
+		BackendType: "encrypted",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUUID := s.State.ModelUUID()
+
+	_, err = s.storage.GetSecretBackendModelKey(id, modelUUID)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	key := secrets.WrappedKey{MasterKeyID: "first", Key: []byte("wrapped")}
+	err = s.storage.AddSecretBackendModelKey(id, modelUUID, key)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storage.AddSecretBackendModelKey(id, modelUUID, secrets.WrappedKey{MasterKeyID: "first", Key: []byte("other")})
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	got, err := s.storage.GetSecretBackendModelKey(id, modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*got, jc.DeepEquals, key)
+
+	rewrapped := secrets.WrappedKey{MasterKeyID: "second", Key: []byte("rewrapped")}
+	err = s.storage.UpdateSecretBackendModelKey(id, modelUUID, rewrapped)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.storage.ListSecretBackendModelKeys(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, jc.DeepEquals, map[string]secrets.WrappedKey{modelUUID: rewrapped})
+
+	// The keys are removed with the backend.
+	err = s.storage.DeleteSecretBackend("myencrypted", false)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err = s.storage.ListSecretBackendModelKeys(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 0)
+}
+
+func (s *SecretBackendsSuite) TestAddModelKeyBackendNotFound(c *gc.C) {
+	err := s.storage.AddSecretBackendModelKey("missing", s.State.ModelUUID(), secrets.WrappedKey{MasterKeyID: "first"})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretBackendsSuite) TestUpdateModelKeyNotFound(c *gc.C) {
+	err := s.storage.UpdateSecretBackendModelKey("missing", s.State.ModelUUID(), secrets.WrappedKey{MasterKeyID: "first"})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type SecretBackendWatcherSuite struct {
 	testing.StateSuite
 	storage state.SecretBackendsStorage
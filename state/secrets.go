@@ -5,6 +5,8 @@ package state
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -48,6 +50,11 @@ type UpdateSecretParams struct {
 	Data           secrets.SecretData
 	ValueRef       *secrets.ValueRef
 	AutoPrune      *bool
+
+	// Author is the entity creating any new revision.
+	// It is recorded against the revision but is not
+	// itself an update.
+	Author names.Tag
 }
 
 func (u *UpdateSecretParams) hasUpdate() bool {
@@ -91,6 +98,7 @@ type SecretsStore interface {
 	ListSecretRevisions(uri *secrets.URI) ([]*secrets.SecretRevisionMetadata, error)
 	ListUnusedSecretRevisions(uri *secrets.URI) ([]int, error)
 	GetSecretRevision(uri *secrets.URI, revision int) (*secrets.SecretRevisionMetadata, error)
+	ListSecretRevisionConsumers(uri *secrets.URI) (map[int][]string, error)
 	WatchObsolete(owners []names.Tag) (StringsWatcher, error)
 	WatchRevisionsToPrune(ownerTags []names.Tag) (StringsWatcher, error)
 	ChangeSecretBackend(ChangeSecretBackendParams) error
@@ -148,6 +156,13 @@ type secretRevisionDoc struct {
 	// It will not be drained to a new active backend.
 	PendingDelete bool `bson:"pending-delete"`
 
+	// Author is the tag of the entity which created the revision.
+	Author string `bson:"author,omitempty"`
+
+	// Rotated is true if the revision was created while the
+	// secret was due to be rotated.
+	Rotated bool `bson:"rotated,omitempty"`
+
 	// OwnerTag is denormalised here so that watchers do not need
 	// to do an extra query on the secret metadata collection to
 	// filter on owner.
@@ -283,6 +298,9 @@ func (s *secretsStore) CreateSecret(uri *secrets.URI, p CreateSecretParams) (*se
 	}
 	revision := 1
 	valueDoc := s.secretRevisionDoc(uri, p.Owner.String(), revision, p.ExpireTime, p.Data, p.ValueRef)
+	if p.Author != nil {
+		valueDoc.Author = p.Author.String()
+	}
 	// OwnerTag has already been validated.
 	owner, _ := names.ParseTag(metadataDoc.OwnerTag)
 	entity, scopeCollName, scopeDocID, err := s.st.findSecretEntity(owner)
@@ -435,6 +453,12 @@ func (s *secretsStore) UpdateSecret(uri *secrets.URI, p UpdateSecretParams) (*se
 				return nil, errors.AlreadyExistsf("secret value with revision %d for %q", metadataDoc.LatestRevision, uri.String())
 			}
 			revisionDoc := s.secretRevisionDoc(uri, metadataDoc.OwnerTag, metadataDoc.LatestRevision, newExpireTime, p.Data, p.ValueRef)
+			if p.Author != nil {
+				revisionDoc.Author = p.Author.String()
+			}
+			// A revision created once the rotate time has passed
+			// is taken to be the result of the rotation.
+			revisionDoc.Rotated = nextRotateTime != nil && !nextRotateTime.After(revisionDoc.CreateTime)
 			ops = append(ops, txn.Op{
 				C:      secretRevisionsC,
 				Id:     revisionDoc.DocID,
@@ -928,6 +952,20 @@ func secretOwnerTerm(owners []string) bson.DocElem {
 	return bson.DocElem{Name: "owner-tag", Value: bson.D{{Name: "$in", Value: owners}}}
 }
 
+// secretOwnersQuery returns a query for the secret docs with the given
+// id, or all of them if id is nil, owned by any of the given owners,
+// or by anyone if there are no owners.
+func secretOwnersQuery(id interface{}, owners []string) bson.D {
+	q := bson.D{}
+	if id != nil {
+		q = append(q, bson.DocElem{Name: "_id", Value: id})
+	}
+	if owners != nil {
+		q = append(q, secretOwnerTerm(owners))
+	}
+	return q
+}
+
 // ListSecrets list the secrets using the specified filter.
 func (s *secretsStore) ListSecrets(filter SecretsFilter) ([]*secrets.SecretMetadata, error) {
 	secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
@@ -1195,7 +1233,34 @@ func (s *secretsStore) listSecretRevisions(uri *secrets.URI, revision *int) ([]*
 			CreateTime:  doc.CreateTime,
 			UpdateTime:  doc.UpdateTime,
 			ExpireTime:  doc.ExpireTime,
+			Author:      doc.Author,
+			Rotated:     doc.Rotated,
+		}
+	}
+	return result, nil
+}
+
+// ListSecretRevisionConsumers returns the tags of the local and remote
+// consumers of the given secret, keyed on the revision they are tracking.
+func (s *secretsStore) ListSecretRevisionConsumers(uri *secrets.URI) (map[int][]string, error) {
+	result := make(map[int][]string)
+	for _, collName := range []string{secretConsumersC, secretRemoteConsumersC} {
+		coll, closer := s.st.db().GetCollection(collName)
+		var docs []secretConsumerDoc
+		// Consumer doc ids are "<model-uuid>:<secret-id>#<consumer>",
+		// so an anchored literal prefix is answered from the _id index.
+		prefix := "^" + regexp.QuoteMeta(s.st.docID(uri.ID+"#"))
+		err := coll.Find(bson.D{{"_id", bson.D{{"$regex", prefix}}}}).All(&docs)
+		closer()
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		for _, doc := range docs {
+			result[doc.CurrentRevision] = append(result[doc.CurrentRevision], doc.ConsumerTag)
+		}
+	}
+	for _, consumers := range result {
+		sort.Strings(consumers)
 	}
 	return result, nil
 }
@@ -2784,6 +2849,12 @@ func (st *State) WatchSecretsRotationChanges(ownerTags []names.Tag) (SecretsTrig
 	return newSecretsRotationWatcher(st, owners), nil
 }
 
+// WatchAllSecretsRotationChanges returns a watcher for rotation updates to
+// all secrets in the model, whoever owns them.
+func (st *State) WatchAllSecretsRotationChanges() SecretsTriggerWatcher {
+	return newSecretsRotationWatcher(st, nil)
+}
+
 // SecretsTriggerWatcher defines a watcher for changes to secret
 // event trigger config.
 type SecretsTriggerWatcher interface {
@@ -2831,7 +2902,7 @@ func (w *secretsRotationWatcher) initial() ([]corewatcher.SecretTriggerChange, e
 	secretRotateCollection, closer := w.db.GetCollection(secretRotateC)
 	defer closer()
 
-	iter := secretRotateCollection.Find(bson.D{secretOwnerTerm(w.owners)}).Iter()
+	iter := secretRotateCollection.Find(secretOwnersQuery(nil, w.owners)).Iter()
 	for iter.Next(&doc) {
 		uriStr := w.backend.localID(doc.DocID)
 		uri, err := secrets.ParseURI(uriStr)
@@ -2860,7 +2931,7 @@ func (w *secretsRotationWatcher) merge(details []corewatcher.SecretTriggerChange
 		// Record added or updated.
 		secretsRotationColl, closer := w.db.GetCollection(secretRotateC)
 		defer closer()
-		err := secretsRotationColl.Find(bson.D{{"_id", change.Id}, secretOwnerTerm(w.owners)}).One(&doc)
+		err := secretsRotationColl.Find(secretOwnersQuery(change.Id, w.owners)).One(&doc)
 		if err != nil && err != mgo.ErrNotFound {
 			return nil, errors.Trace(err)
 		}
@@ -2946,6 +3017,12 @@ func (st *State) WatchSecretRevisionsExpiryChanges(ownerTags []names.Tag) (Secre
 	return newSecretsExpiryWatcher(st, owners), nil
 }
 
+// WatchAllSecretRevisionsExpiryChanges returns a watcher for expiry time
+// updates to all secret revisions in the model, whoever owns them.
+func (st *State) WatchAllSecretRevisionsExpiryChanges() SecretsTriggerWatcher {
+	return newSecretsExpiryWatcher(st, nil)
+}
+
 type expiryWatcherDetails struct {
 	txnRevNo   int64
 	uri        *secrets.URI
@@ -2988,7 +3065,7 @@ func (w *secretsExpiryWatcher) initial() ([]corewatcher.SecretTriggerChange, err
 	secretRevisionCollection, closer := w.db.GetCollection(secretRevisionsC)
 	defer closer()
 
-	iter := secretRevisionCollection.Find(bson.D{secretOwnerTerm(w.owners)}).Iter()
+	iter := secretRevisionCollection.Find(secretOwnersQuery(nil, w.owners)).Iter()
 	for iter.Next(&doc) {
 		uriStr, _ := splitSecretRevision(w.backend.localID(doc.DocID))
 		uri, err := secrets.ParseURI(uriStr)
@@ -3023,7 +3100,7 @@ func (w *secretsExpiryWatcher) merge(details []corewatcher.SecretTriggerChange,
 	if change.Revno >= 0 {
 		secretRevisionCollection, closer := w.db.GetCollection(secretRevisionsC)
 		defer closer()
-		err := secretRevisionCollection.Find(bson.D{{"_id", change.Id}, secretOwnerTerm(w.owners)}).One(&doc)
+		err := secretRevisionCollection.Find(secretOwnersQuery(change.Id, w.owners)).One(&doc)
 		if err != nil && err != mgo.ErrNotFound {
 			return nil, errors.Trace(err)
 		}
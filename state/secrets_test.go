@@ -1391,6 +1391,7 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 	s.assertUpdatedSecret(c, md, 2, state.UpdateSecretParams{
 		LeaderToken: &fakeToken{},
 		Data:        newData,
+		Author:      names.NewUnitTag("mariadb/0"),
 	})
 
 	backendStore := state.NewSecretBackends(s.State)
@@ -1414,6 +1415,7 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 	mc := jc.NewMultiChecker()
 	mc.AddExpr(`_.CreateTime`, jc.Almost, jc.ExpectedValue)
 	mc.AddExpr(`_.UpdateTime`, jc.Almost, jc.ExpectedValue)
+	// Revisions 2 and 3 are created after the rotate time has passed.
 	c.Assert(r, mc, []*secrets.SecretRevisionMetadata{{
 		Revision:   1,
 		CreateTime: now,
@@ -1422,6 +1424,8 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 		Revision:   2,
 		CreateTime: updateTime,
 		UpdateTime: updateTime,
+		Author:     "unit-mariadb-0",
+		Rotated:    true,
 	}, {
 		Revision: 3,
 		ValueRef: &secrets.ValueRef{
@@ -1431,6 +1435,7 @@ func (s *SecretsSuite) TestListSecretRevisions(c *gc.C) {
 		BackendName: ptr("myvault"),
 		CreateTime:  updateTime2,
 		UpdateTime:  updateTime2,
+		Rotated:     true,
 	}})
 }
 
@@ -1511,6 +1516,52 @@ func (s *SecretsSuite) TestGetSecretRevision(c *gc.C) {
 	})
 }
 
+func (s *SecretsSuite) TestListSecretRevisionConsumers(c *gc.C) {
+	uri := secrets.NewURI()
+	cp := state.CreateSecretParams{
+		Version: 1,
+		Owner:   s.owner.Tag(),
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken: &fakeToken{},
+			Data:        map[string]string{"foo": "bar"},
+		},
+	}
+	md, err := s.store.CreateSecret(uri, cp)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SaveSecretConsumer(uri, names.NewUnitTag("mariadb/0"), &secrets.SecretConsumerMetadata{
+		CurrentRevision: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpdatedSecret(c, md, 2, state.UpdateSecretParams{
+		LeaderToken: &fakeToken{},
+		Data:        map[string]string{"foo": "bar2"},
+	})
+	err = s.State.SaveSecretConsumer(uri, names.NewUnitTag("mysql/0"), &secrets.SecretConsumerMetadata{
+		CurrentRevision: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SaveSecretConsumer(uri, names.NewUnitTag("mariadb/1"), &secrets.SecretConsumerMetadata{
+		CurrentRevision: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Consumers of other secrets are not included.
+	other := secrets.NewURI()
+	_, err = s.store.CreateSecret(other, cp)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SaveSecretConsumer(other, names.NewUnitTag("wordpress/0"), &secrets.SecretConsumerMetadata{
+		CurrentRevision: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	consumers, err := s.store.ListSecretRevisionConsumers(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(consumers, jc.DeepEquals, map[int][]string{
+		1: {"unit-mariadb-0", "unit-mariadb-1"},
+		2: {"unit-mysql-0"},
+	})
+}
+
 func (s *SecretsSuite) TestGetSecretConsumerAndGetSecretConsumerURI(c *gc.C) {
 	cp := state.CreateSecretParams{
 		Version: 1,
@@ -2439,6 +2490,49 @@ func (s *SecretsRotationWatcherSuite) TestWatchRestartChangeOwners(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *SecretsRotationWatcherSuite) TestWatchAllOwners(c *gc.C) {
+	now := s.Clock.Now().Round(time.Second).UTC()
+	next := now.Add(time.Minute).Round(time.Second).UTC()
+	var uris []*secrets.URI
+	for _, owner := range []names.Tag{s.ownerApp.Tag(), s.ownerUnit.Tag()} {
+		uri := secrets.NewURI()
+		cp := state.CreateSecretParams{
+			Version: 1,
+			Owner:   owner,
+			UpdateSecretParams: state.UpdateSecretParams{
+				LeaderToken:    &fakeToken{},
+				RotatePolicy:   ptr(secrets.RotateDaily),
+				NextRotateTime: ptr(next),
+				Data:           map[string]string{"foo": "bar"},
+			},
+		}
+		_, err := s.store.CreateSecret(uri, cp)
+		c.Assert(err, jc.ErrorIsNil)
+		uris = append(uris, uri)
+	}
+
+	w := s.State.WatchAllSecretsRotationChanges()
+	wc := testing.NewSecretsTriggerWatcherC(c, w)
+	defer testing.AssertStop(c, w)
+	wc.AssertChange(watcher.SecretTriggerChange{
+		URI:             uris[0],
+		NextTriggerTime: next,
+	}, watcher.SecretTriggerChange{
+		URI:             uris[1],
+		NextTriggerTime: next,
+	})
+	wc.AssertNoChange()
+
+	next2 := now.Add(time.Hour).Round(time.Second).UTC()
+	err := s.State.SecretRotated(uris[1], next2)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(watcher.SecretTriggerChange{
+		URI:             uris[1],
+		NextTriggerTime: next2,
+	})
+	wc.AssertNoChange()
+}
+
 type SecretsExpiryWatcherSuite struct {
 	testing.StateSuite
 	store state.SecretsStore
@@ -2716,6 +2810,41 @@ func (s *SecretsExpiryWatcherSuite) TestWatchRestartChangeOwners(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *SecretsExpiryWatcherSuite) TestWatchAllOwners(c *gc.C) {
+	now := s.Clock.Now().Round(time.Second).UTC()
+	next := now.Add(time.Minute).Round(time.Second).UTC()
+	var uris []*secrets.URI
+	for _, owner := range []names.Tag{s.ownerApp.Tag(), s.ownerUnit.Tag()} {
+		uri := secrets.NewURI()
+		cp := state.CreateSecretParams{
+			Version: 1,
+			Owner:   owner,
+			UpdateSecretParams: state.UpdateSecretParams{
+				LeaderToken: &fakeToken{},
+				ExpireTime:  ptr(next),
+				Data:        map[string]string{"foo": "bar"},
+			},
+		}
+		_, err := s.store.CreateSecret(uri, cp)
+		c.Assert(err, jc.ErrorIsNil)
+		uris = append(uris, uri)
+	}
+
+	w := s.State.WatchAllSecretRevisionsExpiryChanges()
+	wc := testing.NewSecretsTriggerWatcherC(c, w)
+	defer testing.AssertStop(c, w)
+	wc.AssertChange(watcher.SecretTriggerChange{
+		URI:             uris[0],
+		Revision:        1,
+		NextTriggerTime: next,
+	}, watcher.SecretTriggerChange{
+		URI:             uris[1],
+		Revision:        1,
+		NextTriggerTime: next,
+	})
+	wc.AssertNoChange()
+}
+
 type SecretsConsumedWatcherSuite struct {
 	testing.StateSuite
 	store state.SecretsStore
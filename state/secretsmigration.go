@@ -0,0 +1,263 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+)
+
+// SecretsMigrationStatus describes how far a migration of secret
+// revisions to another secret backend has got.
+type SecretsMigrationStatus string
+
+const (
+	// SecretsMigrationPending means the migration has been requested
+	// but not yet started.
+	SecretsMigrationPending SecretsMigrationStatus = "pending"
+
+	// SecretsMigrationRunning means the migration is moving revisions.
+	SecretsMigrationRunning SecretsMigrationStatus = "running"
+
+	// SecretsMigrationCompleted means all the revisions were moved.
+	SecretsMigrationCompleted SecretsMigrationStatus = "completed"
+
+	// SecretsMigrationFailed means the migration stopped after some
+	// revisions could not be moved.
+	SecretsMigrationFailed SecretsMigrationStatus = "failed"
+)
+
+// Finished returns true if the migration will move no more revisions.
+func (s SecretsMigrationStatus) Finished() bool {
+	return s == SecretsMigrationCompleted || s == SecretsMigrationFailed
+}
+
+// secretsMigrationKey is the ID of the document holding the secrets
+// migration of a model; a model has at most one migration at a time.
+const secretsMigrationKey = "secrets-migration"
+
+// SecretsMigrationParams are used to start a secrets migration.
+type SecretsMigrationParams struct {
+	// BackendID and BackendName identify the backend
+	// to move the revisions to.
+	BackendID   string
+	BackendName string
+
+	// URIs, if set, restricts the secrets moved to those given.
+	URIs []string
+
+	// Applications, if set, restricts the secrets moved to those
+	// owned by the given applications or their units.
+	Applications []string
+
+	// Total is the number of revisions to move.
+	Total int
+}
+
+// SecretsMigrationFailure records a secret revision which could not
+// be moved.
+type SecretsMigrationFailure struct {
+	URI      string
+	Revision int
+	Message  string
+}
+
+// SecretsMigration describes the moving of a model's secret revisions
+// to another secret backend, which is carried out by a controller worker.
+type SecretsMigration struct {
+	SecretsMigrationParams
+
+	Status   SecretsMigrationStatus
+	Moved    int
+	Failures []SecretsMigrationFailure
+	Started  time.Time
+	Updated  time.Time
+}
+
+// SecretsMigrationProgress is used to record the progress of a secrets
+// migration.
+type SecretsMigrationProgress struct {
+	Status   SecretsMigrationStatus
+	Moved    int
+	Failures []SecretsMigrationFailure
+}
+
+type secretsMigrationDoc struct {
+	DocID        string                       `bson:"_id"`
+	BackendID    string                       `bson:"backend-id"`
+	BackendName  string                       `bson:"backend-name"`
+	URIs         []string                     `bson:"uris,omitempty"`
+	Applications []string                     `bson:"applications,omitempty"`
+	Total        int                          `bson:"total"`
+	Status       SecretsMigrationStatus       `bson:"status"`
+	Moved        int                          `bson:"moved"`
+	Failures     []secretsMigrationFailureDoc `bson:"failures,omitempty"`
+	Started      time.Time                    `bson:"started"`
+	Updated      time.Time                    `bson:"updated"`
+}
+
+type secretsMigrationFailureDoc struct {
+	URI      string `bson:"uri"`
+	Revision int    `bson:"revision"`
+	Message  string `bson:"message"`
+}
+
+func (doc *secretsMigrationDoc) migration() *SecretsMigration {
+	m := &SecretsMigration{
+		SecretsMigrationParams: SecretsMigrationParams{
+			BackendID:    doc.BackendID,
+			BackendName:  doc.BackendName,
+			URIs:         doc.URIs,
+			Applications: doc.Applications,
+			Total:        doc.Total,
+		},
+		Status:  doc.Status,
+		Moved:   doc.Moved,
+		Started: doc.Started,
+		Updated: doc.Updated,
+	}
+	for _, f := range doc.Failures {
+		m.Failures = append(m.Failures, SecretsMigrationFailure{
+			URI:      f.URI,
+			Revision: f.Revision,
+			Message:  f.Message,
+		})
+	}
+	return m
+}
+
+func failureDocs(failures []SecretsMigrationFailure) []secretsMigrationFailureDoc {
+	var docs []secretsMigrationFailureDoc
+	for _, f := range failures {
+		docs = append(docs, secretsMigrationFailureDoc{
+			URI:      f.URI,
+			Revision: f.Revision,
+			Message:  f.Message,
+		})
+	}
+	return docs
+}
+
+func (st *State) getSecretsMigrationDoc() (*secretsMigrationDoc, error) {
+	coll, closer := st.db().GetCollection(secretsMigrationsC)
+	defer closer()
+
+	var doc secretsMigrationDoc
+	err := coll.FindId(secretsMigrationKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secrets migration")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// SecretsMigration returns the model's current or most recent secrets
+// migration.
+func (st *State) SecretsMigration() (*SecretsMigration, error) {
+	doc, err := st.getSecretsMigrationDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.migration(), nil
+}
+
+// StartSecretsMigration records a new secrets migration for the model,
+// replacing any which has finished. It fails with an AlreadyExists error
+// if another migration has yet to finish.
+func (st *State) StartSecretsMigration(p SecretsMigrationParams) error {
+	if p.BackendID == "" {
+		return errors.NotValidf("secrets migration without backend")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		now := st.nowToTheSecond()
+		existing, err := st.getSecretsMigrationDoc()
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+		if existing == nil {
+			return []txn.Op{{
+				C:      secretsMigrationsC,
+				Id:     st.docID(secretsMigrationKey),
+				Assert: txn.DocMissing,
+				Insert: &secretsMigrationDoc{
+					DocID:        st.docID(secretsMigrationKey),
+					BackendID:    p.BackendID,
+					BackendName:  p.BackendName,
+					URIs:         p.URIs,
+					Applications: p.Applications,
+					Total:        p.Total,
+					Status:       SecretsMigrationPending,
+					Started:      now,
+					Updated:      now,
+				},
+			}}, nil
+		}
+		if !existing.Status.Finished() {
+			return nil, errors.AlreadyExistsf("secrets migration to backend %q", existing.BackendName)
+		}
+		return []txn.Op{{
+			C:      secretsMigrationsC,
+			Id:     st.docID(secretsMigrationKey),
+			Assert: bson.D{{"status", existing.Status}, {"started", existing.Started}},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"backend-id", p.BackendID},
+					{"backend-name", p.BackendName},
+					{"uris", p.URIs},
+					{"applications", p.Applications},
+					{"total", p.Total},
+					{"status", SecretsMigrationPending},
+					{"moved", 0},
+					{"started", now},
+					{"updated", now},
+				}},
+				{"$unset", bson.D{{"failures", nil}}},
+			},
+		}}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// SetSecretsMigrationProgress records the progress of the model's
+// secrets migration, which must not have finished.
+func (st *State) SetSecretsMigrationProgress(p SecretsMigrationProgress) error {
+	switch p.Status {
+	case SecretsMigrationRunning, SecretsMigrationCompleted, SecretsMigrationFailed:
+	default:
+		return errors.NotValidf("secrets migration status %q", p.Status)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.getSecretsMigrationDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.Status.Finished() {
+			return nil, errors.Errorf("secrets migration to backend %q has finished", existing.BackendName)
+		}
+		return []txn.Op{{
+			C:      secretsMigrationsC,
+			Id:     st.docID(secretsMigrationKey),
+			Assert: bson.D{{"status", existing.Status}, {"started", existing.Started}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", p.Status},
+				{"moved", p.Moved},
+				{"failures", failureDocs(p.Failures)},
+				{"updated", st.nowToTheSecond()},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// WatchSecretsMigration returns a watcher notifying when the model's
+// secrets migration is started or makes progress.
+func (st *State) WatchSecretsMigration() NotifyWatcher {
+	return newEntityWatcher(st, secretsMigrationsC, st.docID(secretsMigrationKey))
+}
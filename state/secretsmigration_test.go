@@ -0,0 +1,132 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsMigrationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SecretsMigrationSuite{})
+
+func (s *SecretsMigrationSuite) params() state.SecretsMigrationParams {
+	return state.SecretsMigrationParams{
+		BackendID:    "backend-id",
+		BackendName:  "myvault",
+		Applications: []string{"mariadb"},
+		Total:        3,
+	}
+}
+
+func (s *SecretsMigrationSuite) TestSecretsMigrationNotFound(c *gc.C) {
+	_, err := s.State.SecretsMigration()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *SecretsMigrationSuite) TestStartSecretsMigration(c *gc.C) {
+	err := s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.SecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.SecretsMigrationParams, jc.DeepEquals, s.params())
+	c.Assert(m.Status, gc.Equals, state.SecretsMigrationPending)
+	c.Assert(m.Moved, gc.Equals, 0)
+	c.Assert(m.Started.IsZero(), jc.IsFalse)
+}
+
+func (s *SecretsMigrationSuite) TestStartSecretsMigrationInProgress(c *gc.C) {
+	err := s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIs, errors.AlreadyExists)
+}
+
+func (s *SecretsMigrationSuite) TestSetSecretsMigrationProgress(c *gc.C) {
+	err := s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationRunning,
+		Moved:  2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	failures := []state.SecretsMigrationFailure{{
+		URI:      "secret:9m4e2mr0ui3e8a215n4g",
+		Revision: 1,
+		Message:  "checksum mismatch",
+	}}
+	err = s.State.SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status:   state.SecretsMigrationFailed,
+		Moved:    2,
+		Failures: failures,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.SecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Status, gc.Equals, state.SecretsMigrationFailed)
+	c.Assert(m.Moved, gc.Equals, 2)
+	c.Assert(m.Failures, jc.DeepEquals, failures)
+
+	err = s.State.SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationCompleted,
+		Moved:  3,
+	})
+	c.Assert(err, gc.ErrorMatches, `secrets migration to backend "myvault" has finished`)
+}
+
+func (s *SecretsMigrationSuite) TestStartSecretsMigrationReplacesFinished(c *gc.C) {
+	err := s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationFailed,
+		Moved:  1,
+		Failures: []state.SecretsMigrationFailure{{
+			URI: "secret:9m4e2mr0ui3e8a215n4g", Revision: 2, Message: "boom",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	p := state.SecretsMigrationParams{
+		BackendID:   "other-id",
+		BackendName: "internal",
+		Total:       1,
+	}
+	err = s.State.StartSecretsMigration(p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.SecretsMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.SecretsMigrationParams, jc.DeepEquals, p)
+	c.Assert(m.Status, gc.Equals, state.SecretsMigrationPending)
+	c.Assert(m.Moved, gc.Equals, 0)
+	c.Assert(m.Failures, gc.HasLen, 0)
+}
+
+func (s *SecretsMigrationSuite) TestWatchSecretsMigration(c *gc.C) {
+	w := s.State.WatchSecretsMigration()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	err := s.State.StartSecretsMigration(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetSecretsMigrationProgress(state.SecretsMigrationProgress{
+		Status: state.SecretsMigrationRunning,
+		Moved:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
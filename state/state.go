@@ -360,6 +360,13 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	if !st.IsController() {
 		ops = append(ops, decHostedModelCountOp())
 	}
+
+	// The model keys of secret backends are held outside the model.
+	modelKeyOps, err := st.removeSecretBackendModelKeysOps(bson.D{{"model-uuid", modelUUID}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, modelKeyOps...)
 	return errors.Trace(st.db().RunTransaction(ops))
 }
 
@@ -2074,6 +2074,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's config settings, such as trust and the provider specific
+// settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -3662,6 +3669,100 @@ func (w *notifyCollWatcher) loop() error {
 	}
 }
 
+// actionSchedulesWatcher implements NotifyWatcher, triggering when an
+// action schedule of the model is added or removed. Updates to the
+// schedules, made as they are run, are not reported.
+type actionSchedulesWatcher struct {
+	commonWatcher
+	filter func(interface{}) bool
+	known  set.Strings
+	sink   chan struct{}
+}
+
+func newActionSchedulesWatcher(backend modelBackend, filter func(interface{}) bool) NotifyWatcher {
+	w := &actionSchedulesWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		filter:        filter,
+		known:         set.NewStrings(),
+		sink:          make(chan struct{}),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.sink)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *actionSchedulesWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *actionSchedulesWatcher) initial() error {
+	schedules, closer := w.db.GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	if err := schedules.Find(nil).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		w.known.Add(doc.DocId)
+	}
+	return nil
+}
+
+// merge records the existence of the changed schedules, returning
+// whether any has been added or removed.
+func (w *actionSchedulesWatcher) merge(ids map[interface{}]bool) bool {
+	changed := false
+	for id, exists := range ids {
+		docID, ok := id.(string)
+		if !ok || w.known.Contains(docID) == exists {
+			continue
+		}
+		if exists {
+			w.known.Add(docID)
+		} else {
+			w.known.Remove(docID)
+		}
+		changed = true
+	}
+	return changed
+}
+
+func (w *actionSchedulesWatcher) loop() error {
+	in := make(chan watcher.Change)
+
+	w.watcher.WatchCollectionWithFilter(actionSchedulesC, in, w.filter)
+	defer w.watcher.UnwatchCollection(actionSchedulesC, in)
+
+	if err := w.initial(); err != nil {
+		return err
+	}
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			ids, ok := collect(change, in, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			if w.merge(ids) {
+				out = w.sink
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // WatchRemoteRelations returns a StringsWatcher that notifies of changes to
 // the lifecycles of the remote relations in the model.
 func (st *State) WatchRemoteRelations() StringsWatcher {
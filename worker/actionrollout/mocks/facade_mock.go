@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/worker/actionrollout (interfaces: Facade)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/actionrollout Facade
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	actionrollout "github.com/juju/juju/api/controller/actionrollout"
+	watcher "github.com/juju/juju/core/watcher"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFacade is a mock of Facade interface.
+type MockFacade struct {
+	ctrl     *gomock.Controller
+	recorder *MockFacadeMockRecorder
+}
+
+// MockFacadeMockRecorder is the mock recorder for MockFacade.
+type MockFacadeMockRecorder struct {
+	mock *MockFacade
+}
+
+// NewMockFacade creates a new mock instance.
+func NewMockFacade(ctrl *gomock.Controller) *MockFacade {
+	mock := &MockFacade{ctrl: ctrl}
+	mock.recorder = &MockFacadeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFacade) EXPECT() *MockFacadeMockRecorder {
+	return m.recorder
+}
+
+// AdvanceRollouts mocks base method.
+func (m *MockFacade) AdvanceRollouts() ([]actionrollout.RolloutResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceRollouts")
+	ret0, _ := ret[0].([]actionrollout.RolloutResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceRollouts indicates an expected call of AdvanceRollouts.
+func (mr *MockFacadeMockRecorder) AdvanceRollouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceRollouts", reflect.TypeOf((*MockFacade)(nil).AdvanceRollouts))
+}
+
+// WatchRollouts mocks base method.
+func (m *MockFacade) WatchRollouts() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRollouts")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchRollouts indicates an expected call of WatchRollouts.
+func (mr *MockFacadeMockRecorder) WatchRollouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRollouts", reflect.TypeOf((*MockFacade)(nil).WatchRollouts))
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/api/base"
+	api "github.com/juju/juju/api/controller/actionrollout"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/actionrollout Facade
+type Facade interface {
+	WatchRollouts() (watcher.NotifyWatcher, error)
+	AdvanceRollouts() ([]api.RolloutResult, error)
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Facade Facade
+	Logger Logger
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("Facade is missing")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("Logger is missing")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("Clock is missing")
+	}
+	return nil
+}
+
+type rolloutWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewFacade returns a facade for the actionrollout worker to use.
+func NewFacade(caller base.APICaller) (Facade, error) {
+	return api.NewClient(caller)
+}
+
+// NewWorker returns a worker that enqueues the next batch of each
+// rolling operation as its current batch finishes, so that rollouts
+// continue without the client that started them.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &rolloutWorker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *rolloutWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *rolloutWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *rolloutWorker) loop() error {
+	watcher, err := w.config.Facade.WatchRollouts()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	// A batch delay passing doesn't change the operation, so
+	// the rollouts are advanced again when the shortest delay
+	// reported has passed.
+	var delay <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("rollout watcher closed")
+			}
+		case <-delay:
+		}
+		wait, err := w.advance()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		delay = nil
+		if wait > 0 {
+			delay = w.config.Clock.After(wait)
+		}
+	}
+}
+
+// advance advances the model's rollouts and returns how long until
+// the first of those waiting out a batch delay is due, or zero if
+// none are.
+func (w *rolloutWorker) advance() (time.Duration, error) {
+	results, err := w.config.Facade.AdvanceRollouts()
+	if err != nil {
+		return 0, errors.Annotate(err, "advancing rollouts")
+	}
+	logger := w.config.Logger
+	var wait time.Duration
+	for _, result := range results {
+		switch {
+		case result.Error != nil:
+			// A failure of one rollout should not hold up the
+			// others; it is retried when the rollouts next change.
+			logger.Warningf("cannot advance rollout of operation %s: %v", result.OperationID, result.Error)
+		case result.Finished:
+			logger.Infof("rollout of operation %s finished", result.OperationID)
+		case result.BatchEnqueued > 0:
+			logger.Infof("enqueued batch %d of operation %s", result.BatchEnqueued, result.OperationID)
+		case result.NextBatchIn > 0:
+			if wait == 0 || result.NextBatchIn < wait {
+				wait = result.NextBatchIn
+			}
+		}
+	}
+	return wait, nil
+}
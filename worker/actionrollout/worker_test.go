@@ -0,0 +1,151 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	api "github.com/juju/juju/api/controller/actionrollout"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionrollout"
+	"github.com/juju/juju/worker/actionrollout/mocks"
+)
+
+var _ = gc.Suite(&workerSuite{})
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade  *mocks.MockFacade
+	clock   *testclock.Clock
+	changes chan struct{}
+	done    chan struct{}
+}
+
+func (s *workerSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.facade = mocks.NewMockFacade(ctrl)
+	s.clock = testclock.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.changes <- struct{}{}
+	s.done = make(chan struct{})
+	return ctrl
+}
+
+func (s *workerSuite) expectWatch() {
+	s.facade.EXPECT().WatchRollouts().Return(watchertest.NewMockNotifyWatcher(s.changes), nil)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := actionrollout.NewWorker(actionrollout.Config{
+		Facade: s.facade,
+		Logger: loggo.GetLogger("test"),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) waitDone(c *gc.C) {
+	select {
+	case <-s.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the rollout worker")
+	}
+}
+
+func (s *workerSuite) TestConfigValidate(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := actionrollout.Config{}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Facade is missing not valid`)
+	cfg.Facade = s.facade
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Logger is missing not valid`)
+	cfg.Logger = loggo.GetLogger("test")
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Clock is missing not valid`)
+	cfg.Clock = s.clock
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestAdvancesOnChange(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	gomock.InOrder(
+		s.facade.EXPECT().AdvanceRollouts().DoAndReturn(func() ([]api.RolloutResult, error) {
+			// The rollouts change as the batch is enqueued.
+			s.changes <- struct{}{}
+			return []api.RolloutResult{
+				{OperationID: "1", BatchEnqueued: 2},
+				{OperationID: "5", Finished: true},
+				// A failed rollout does not stop the worker.
+				{OperationID: "7", Error: errors.New("boom")},
+			}, nil
+		}),
+		s.facade.EXPECT().AdvanceRollouts().DoAndReturn(func() ([]api.RolloutResult, error) {
+			close(s.done)
+			return nil, nil
+		}),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestAdvancesAfterBatchDelay(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	gomock.InOrder(
+		s.facade.EXPECT().AdvanceRollouts().Return([]api.RolloutResult{
+			{OperationID: "1", NextBatchIn: 2 * time.Minute},
+			{OperationID: "5", NextBatchIn: 40 * time.Second},
+		}, nil),
+		s.facade.EXPECT().AdvanceRollouts().DoAndReturn(func() ([]api.RolloutResult, error) {
+			close(s.done)
+			return nil, nil
+		}),
+	)
+
+	w := s.startWorker(c)
+	// The rollouts are advanced again once the shortest delay
+	// has passed.
+	err := s.clock.WaitAdvance(40*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestWatchError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().WatchRollouts().Return(nil, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	s.facade.EXPECT().AdvanceRollouts().Return(nil, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "advancing rollouts: boom")
+}
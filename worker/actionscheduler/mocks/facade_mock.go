@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/worker/actionscheduler (interfaces: Facade)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/actionscheduler Facade
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	actionscheduler "github.com/juju/juju/api/controller/actionscheduler"
+	watcher "github.com/juju/juju/core/watcher"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFacade is a mock of Facade interface.
+type MockFacade struct {
+	ctrl     *gomock.Controller
+	recorder *MockFacadeMockRecorder
+}
+
+// MockFacadeMockRecorder is the mock recorder for MockFacade.
+type MockFacadeMockRecorder struct {
+	mock *MockFacade
+}
+
+// NewMockFacade creates a new mock instance.
+func NewMockFacade(ctrl *gomock.Controller) *MockFacade {
+	mock := &MockFacade{ctrl: ctrl}
+	mock.recorder = &MockFacadeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFacade) EXPECT() *MockFacadeMockRecorder {
+	return m.recorder
+}
+
+// RunDueSchedules mocks base method.
+func (m *MockFacade) RunDueSchedules() ([]actionscheduler.ScheduledRunResult, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDueSchedules")
+	ret0, _ := ret[0].([]actionscheduler.ScheduledRunResult)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunDueSchedules indicates an expected call of RunDueSchedules.
+func (mr *MockFacadeMockRecorder) RunDueSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDueSchedules", reflect.TypeOf((*MockFacade)(nil).RunDueSchedules))
+}
+
+// WatchActionSchedules mocks base method.
+func (m *MockFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchActionSchedules")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchActionSchedules indicates an expected call of WatchActionSchedules.
+func (mr *MockFacadeMockRecorder) WatchActionSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchActionSchedules", reflect.TypeOf((*MockFacade)(nil).WatchActionSchedules))
+}
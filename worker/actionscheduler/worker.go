@@ -0,0 +1,159 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/api/base"
+	api "github.com/juju/juju/api/controller/actionscheduler"
+	"github.com/juju/juju/core/watcher"
+)
+
+// retryDelay is how long to wait before running a schedule which is
+// still due because its run failed to start. Schedules are no finer
+// than a minute, so retrying sooner gains nothing.
+const retryDelay = time.Minute
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/actionscheduler Facade
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	RunDueSchedules() ([]api.ScheduledRunResult, time.Time, error)
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Facade Facade
+	Logger Logger
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("Facade is missing")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("Logger is missing")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("Clock is missing")
+	}
+	return nil
+}
+
+type schedulerWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewFacade returns a facade for the actionscheduler worker to use.
+func NewFacade(caller base.APICaller) (Facade, error) {
+	return api.NewClient(caller)
+}
+
+// NewWorker returns a worker that starts the operations of the
+// model's action schedules as they fall due.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *schedulerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *schedulerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *schedulerWorker) loop() error {
+	watcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	// Schedules which are added or removed may change when the
+	// next run is due, so it is worked out again on each change.
+	var due <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+		case <-due:
+		}
+		started := w.config.Clock.Now()
+		nextDue, err := w.runDue()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		due = nil
+		switch {
+		case nextDue.IsZero():
+		case !nextDue.After(started):
+			due = w.config.Clock.After(retryDelay)
+		default:
+			due = w.config.Clock.After(nextDue.Sub(w.config.Clock.Now()))
+		}
+	}
+}
+
+// runDue runs the schedules which are due and returns when the first
+// schedule is next due, which is zero if there are no schedules.
+func (w *schedulerWorker) runDue() (time.Time, error) {
+	results, nextDue, err := w.config.Facade.RunDueSchedules()
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "running due schedules")
+	}
+	logger := w.config.Logger
+	for _, result := range results {
+		switch {
+		case result.Error != nil:
+			// A failure of one schedule should not hold up the
+			// others. A schedule whose operation could not be
+			// started is still due, so it is retried after the
+			// retry delay.
+			logger.Warningf("cannot run schedule %q: %v", result.Schedule, result.Error)
+		case result.Skipped != "":
+			logger.Infof("skipped run of schedule %q: %s", result.Schedule, result.Skipped)
+		default:
+			logger.Infof("schedule %q started operation %s, next run at %v",
+				result.Schedule, result.OperationID, result.NextRun)
+		}
+	}
+	return nextDue, nil
+}
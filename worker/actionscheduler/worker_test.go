@@ -0,0 +1,167 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	api "github.com/juju/juju/api/controller/actionscheduler"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/actionscheduler/mocks"
+)
+
+var _ = gc.Suite(&workerSuite{})
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade  *mocks.MockFacade
+	clock   *testclock.Clock
+	changes chan struct{}
+	done    chan struct{}
+}
+
+func (s *workerSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.facade = mocks.NewMockFacade(ctrl)
+	s.clock = testclock.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.changes <- struct{}{}
+	s.done = make(chan struct{})
+	return ctrl
+}
+
+func (s *workerSuite) expectWatch() {
+	s.facade.EXPECT().WatchActionSchedules().Return(watchertest.NewMockNotifyWatcher(s.changes), nil)
+}
+
+func (s *workerSuite) expectRunDueDone() *gomock.Call {
+	return s.facade.EXPECT().RunDueSchedules().DoAndReturn(func() ([]api.ScheduledRunResult, time.Time, error) {
+		close(s.done)
+		return nil, time.Time{}, nil
+	})
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Logger: loggo.GetLogger("test"),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) waitDone(c *gc.C) {
+	select {
+	case <-s.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the scheduler worker")
+	}
+}
+
+func (s *workerSuite) TestConfigValidate(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := actionscheduler.Config{}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Facade is missing not valid`)
+	cfg.Facade = s.facade
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Logger is missing not valid`)
+	cfg.Logger = loggo.GetLogger("test")
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Clock is missing not valid`)
+	cfg.Clock = s.clock
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestRunsWhenDue(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	next := s.clock.Now().Add(time.Hour)
+	s.expectWatch()
+	gomock.InOrder(
+		s.facade.EXPECT().RunDueSchedules().Return([]api.ScheduledRunResult{
+			{Schedule: "hourly", OperationID: "1", NextRun: next},
+			{Schedule: "nightly", Skipped: "no units to run on", NextRun: next},
+		}, next, nil),
+		s.expectRunDueDone(),
+	)
+
+	w := s.startWorker(c)
+	// The schedules are run again when the next is due.
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestRunsOnChange(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	gomock.InOrder(
+		s.facade.EXPECT().RunDueSchedules().DoAndReturn(func() ([]api.ScheduledRunResult, time.Time, error) {
+			// A schedule is added to a model without any.
+			s.changes <- struct{}{}
+			return nil, time.Time{}, nil
+		}),
+		s.expectRunDueDone(),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestRetriesFailedRun(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	gomock.InOrder(
+		// A failed schedule does not stop the worker, and is
+		// still due afterwards.
+		s.facade.EXPECT().RunDueSchedules().Return([]api.ScheduledRunResult{
+			{Schedule: "weekly", Error: errors.New("boom")},
+		}, s.clock.Now(), nil),
+		s.expectRunDueDone(),
+	)
+
+	w := s.startWorker(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestWatchError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().WatchActionSchedules().Return(nil, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectWatch()
+	s.facade.EXPECT().RunDueSchedules().Return(nil, time.Time{}, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "running due schedules: boom")
+}
@@ -85,6 +85,12 @@ func (w *agentConfigUpdater) loop(started chan struct{}) error {
 		return errors.Trace(err)
 	}
 	defer unsubscribe()
+	unsubscribeRestored, err := w.config.Hub.Subscribe(controllermsg.Restored, w.onRestored)
+	if err != nil {
+		w.config.Logger.Criticalf("programming error in subscribe function: %v", err)
+		return errors.Trace(err)
+	}
+	defer unsubscribeRestored()
 	// Let the caller know we are done.
 	close(started)
 	// Don't exit until we are told to. Exiting unsubscribes.
@@ -144,6 +150,17 @@ func (w *agentConfigUpdater) onConfigChanged(topic string, data controllermsg.Co
 	w.tomb.Kill(jworker.ErrRestartAgent)
 }
 
+func (w *agentConfigUpdater) onRestored(topic string, data controllermsg.RestoredMessage, err error) {
+	if err != nil {
+		w.config.Logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	// The agent is restarted so that its workers, including the Dqlite
+	// node, start again from the restored state.
+	w.config.Logger.Infof("controller restored from backups %v, restarting agent", data.BackupIDs)
+	w.tomb.Kill(jworker.ErrRestartAgent)
+}
+
 // Kill implements Worker.Kill().
 func (w *agentConfigUpdater) Kill() {
 	w.tomb.Kill(nil)
@@ -251,3 +251,22 @@ func (s *WorkerSuite) TestUpdateQueryTracingThreshold(c *gc.C) {
 
 	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
 }
+
+func (s *WorkerSuite) TestRestoredRestartsAgent(c *gc.C) {
+	w, err := agentconfigupdater.NewWorker(s.config)
+	c.Assert(w, gc.NotNil)
+	c.Check(err, jc.ErrorIsNil)
+
+	handled, err := s.hub.Publish(controllermsg.Restored, controllermsg.RestoredMessage{
+		BackupIDs: []string{"juju-backup-20230501-000000.tar.gz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-pubsub.Wait(handled):
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+}
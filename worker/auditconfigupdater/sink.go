@@ -0,0 +1,66 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+const (
+	// webhookTimeout is the maximum time allowed for a single
+	// request to the audit log webhook.
+	webhookTimeout = 30 * time.Second
+
+	// webhookRetryAttempts is the number of times a batch of audit
+	// records is sent to the webhook before it is spooled.
+	webhookRetryAttempts = 5
+
+	// webhookRetryDelay is the initial delay between attempts to send
+	// a batch of audit records to the webhook.
+	webhookRetryDelay = time.Second
+
+	// webhookBufferSize is the number of audit records held while
+	// waiting to be sent to the webhook. Records added once the buffer
+	// is full are dropped, so a slow or unreachable webhook never holds
+	// up API requests.
+	webhookBufferSize = 10000
+
+	// syslogBufferSize is the number of audit records held while
+	// waiting to be sent to the syslog host. As with the webhook,
+	// records added once the buffer is full are dropped.
+	syslogBufferSize = 10000
+)
+
+// NewAuditLog returns the audit log sink described by the config.
+// If the configured sink can't be created, the error is logged and
+// records are written to the audit log file in logDir instead, so
+// that no audit records are lost.
+func NewAuditLog(cfg auditlog.Config, logDir string, clock clock.Clock) auditlog.AuditLog {
+	switch cfg.Sink {
+	case auditlog.SyslogSink:
+		target, err := auditlog.NewSyslog(cfg.Syslog, syslogBufferSize, clock)
+		if err == nil {
+			return target
+		}
+		logger.Errorf("unable to create syslog audit log, falling back to file: %v", err)
+	case auditlog.WebhookSink:
+		webhookConfig := cfg.Webhook
+		webhookConfig.BufferSize = webhookBufferSize
+		webhookConfig.RetryAttempts = webhookRetryAttempts
+		webhookConfig.RetryDelay = webhookRetryDelay
+		webhookConfig.SpoolDir = logDir
+		client := &http.Client{Timeout: webhookTimeout}
+		target, err := auditlog.NewWebhook(webhookConfig, client, clock)
+		if err == nil {
+			return target
+		}
+		logger.Errorf("unable to create webhook audit log, falling back to file: %v", err)
+	}
+	return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+}
@@ -7,14 +7,18 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/catacomb"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ConfigSource lets us get notifications of changes to controller
 // configuration, and then get the changed config. (Primary
 // implementation is State.)
@@ -28,7 +32,16 @@ type ConfigSource interface {
 type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 
 // New returns a worker that will keep an up-to-date audit log config.
+// The target in the config is always an *auditlog.SwitchableLog, which
+// stays the same for the life of the worker; when the sink changes the
+// new log is switched in behind it, so API connections which captured
+// the target when they logged in keep auditing to the current sink.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
+	if initial.Target != nil {
+		if _, ok := initial.Target.(*auditlog.SwitchableLog); !ok {
+			initial.Target = auditlog.NewSwitchableLog(initial.Target)
+		}
+	}
 	u := &updater{
 		source:     source,
 		current:    initial,
@@ -89,20 +102,33 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	if result.Enabled && u.current.Target == nil {
-		result.Target = u.logFactory(result)
-	} else {
+	result := configFromController(cfg)
+	switch {
+	case result.Enabled && u.current.Target == nil:
+		result.Target = auditlog.NewSwitchableLog(u.logFactory(result))
+	case result.Enabled && !result.SameSink(u.current):
+		// The audit records are now going somewhere else, so switch
+		// to a new log and stop the one we were using. Connections
+		// hold the switchable target, not the log being closed.
+		target := u.current.Target.(*auditlog.SwitchableLog)
+		previous := target.Switch(u.logFactory(result))
+		if err := previous.Close(); err != nil {
+			logger.Warningf("closing previous audit log: %v", err)
+		}
+		result.Target = target
+	default:
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
 		// because enabled is false.
 		result.Target = u.current.Target
+		if !result.Enabled {
+			// Retain the sink settings of the target we're
+			// keeping, so we know whether it can be reused when
+			// auditing is enabled again.
+			result.Sink = u.current.Sink
+			result.Syslog = u.current.Syslog
+			result.Webhook = u.current.Webhook
+		}
 	}
 	return result, nil
 }
@@ -119,3 +145,29 @@ func (u *updater) CurrentConfig() auditlog.Config {
 	defer u.mu.Unlock()
 	return u.current
 }
+
+// configFromController returns the audit log config described by the
+// controller config.
+func configFromController(cfg controller.Config) auditlog.Config {
+	return auditlog.Config{
+		Enabled:        cfg.AuditingEnabled(),
+		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
+		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
+		MaxBackups:     cfg.AuditLogMaxBackups(),
+		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sink:           cfg.AuditLogSink(),
+		Syslog: syslog.RawConfig{
+			Enabled:    cfg.AuditLogSink() == controller.AuditLogSinkSyslog,
+			Host:       cfg.AuditLogSyslogHost(),
+			CACert:     cfg.AuditLogSyslogCACert(),
+			ClientCert: cfg.AuditLogSyslogClientCert(),
+			ClientKey:  cfg.AuditLogSyslogClientKey(),
+		},
+		Webhook: auditlog.WebhookConfig{
+			URL:            cfg.AuditLogWebhookURL(),
+			BatchSize:      cfg.AuditLogWebhookBatchSize(),
+			FlushInterval:  cfg.AuditLogWebhookFlushInterval(),
+			MaxSpoolSizeMB: cfg.AuditLogWebhookSpoolSizeMB(),
+		},
+	}
+}
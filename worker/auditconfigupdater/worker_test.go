@@ -62,10 +62,16 @@ func (s *updaterSuite) TestWorker(c *gc.C) {
 	c.Assert(newConfig.Enabled, gc.Equals, true)
 	c.Assert(newConfig.CaptureAPIArgs, gc.Equals, false)
 	c.Assert(newConfig.ExcludeMethods, gc.DeepEquals, set.NewStrings())
-	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(&fakeTarget))
+	c.Assert(currentTarget(c, newConfig), gc.Equals, auditlog.AuditLog(&fakeTarget))
 	c.Assert(calls, gc.HasLen, 1)
 }
 
+func currentTarget(c *gc.C, cfg auditlog.Config) auditlog.AuditLog {
+	target, ok := cfg.Target.(*auditlog.SwitchableLog)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("target %T", cfg.Target))
+	return target.Target()
+}
+
 func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) bool) auditlog.Config {
 	for a := jujutesting.LongAttempt.Start(); a.Next(); {
 		config := getWorkerConfig(c, w)
@@ -102,7 +108,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, false)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	c.Assert(currentTarget(c, newConfig), gc.Equals, initial.Target)
 }
 
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
@@ -130,7 +136,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, true)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	c.Assert(currentTarget(c, newConfig), gc.Equals, initial.Target)
 }
 
 func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
@@ -188,6 +194,55 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingSinkReplacesTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	initialTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Sink:    "file",
+		Target:  initialTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	// Connections capture the target when they log in.
+	connTarget := getWorkerConfig(c, w).Target
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sink"] = "webhook"
+	cfg["audit-log-webhook-url"] = "https://siem.example.com/ingest"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Sink == "webhook"
+	})
+
+	c.Assert(newConfig.Target, gc.Equals, connTarget)
+	c.Assert(currentTarget(c, newConfig), gc.Equals, auditlog.AuditLog(newTarget))
+	c.Assert(newConfig.Webhook.URL, gc.Equals, "https://siem.example.com/ingest")
+	c.Assert(calls, gc.HasLen, 1)
+	initialTarget.CheckCallNames(c, "Close")
+
+	// Records from existing connections go to the new sink.
+	err = connTarget.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	initialTarget.CheckCallNames(c, "Close")
+	newTarget.CheckCallNames(c, "AddConversation")
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
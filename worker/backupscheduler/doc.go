@@ -0,0 +1,11 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes scheduled full
+// and incremental backups of the controller, as configured by the
+// backup-* controller config attributes. The archives are uploaded to
+// the configured S3 compatible object store, or kept on the controller
+// machine, and the oldest are pruned according to the retention count.
+//
+// The worker runs on the primary controller only.
+package backupscheduler
@@ -0,0 +1,128 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information needed to run a backup
+// scheduler in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName string
+	StateName string
+
+	Clock          clock.Clock
+	Logger         Logger
+	NewObjectStore func(endpoint string) (backups.ObjectStore, error)
+	NewWorker      func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewObjectStore == nil {
+		return errors.NotValidf("nil NewObjectStore")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold to run a backup scheduler.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent jujuagent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConfig := agent.CurrentConfig()
+	machineTag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.NotValidf("agent tag %q", agentConfig.Tag())
+	}
+	mongoInfo, ok := agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info in agent config")
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = stTracker.Done()
+		}
+	}()
+
+	st, err := statePool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	dataDir := agentConfig.DataDir()
+	w, err := config.NewWorker(Config{
+		Clock:            config.Clock,
+		Logger:           config.Logger,
+		ControllerConfig: st.ControllerConfig,
+		Backups: &backupsShim{
+			st:        st,
+			machineID: machineTag.Id(),
+			mongoInfo: mongoInfo,
+			dataDir:   dataDir,
+			logsDir:   agentConfig.LogDir(),
+		},
+		NewStorage: func(cfg controller.Config) (backups.ArchiveStorage, error) {
+			nodes, err := st.ControllerNodes()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return backups.NewStorage(cfg, dataDir, len(nodes), config.NewObjectStore)
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/names/v5"
+
+	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// backupsShim creates backups of the controller machine running the
+// worker, in the same way as the Backups facade.
+type backupsShim struct {
+	st        *state.State
+	machineID string
+	mongoInfo *mongo.MongoInfo
+	dataDir   string
+	logsDir   string
+}
+
+// CreateFull is part of Backups.
+func (b *backupsShim) CreateFull() (*backups.Metadata, string, error) {
+	meta, dbInfo, paths, err := b.prepare()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta.Notes = "scheduled backup"
+	filename, err := backups.NewBackups(paths).Create(meta, dbInfo)
+	return meta, filename, errors.Trace(err)
+}
+
+// CreateIncremental is part of Backups.
+func (b *backupsShim) CreateIncremental(parent string, since time.Time) (*backups.Metadata, string, error) {
+	meta, dbInfo, paths, err := b.prepare()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	if err := backups.CheckOplogCovers(sessionShim{session}, since); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta.Notes = "scheduled incremental backup"
+	meta.Parent = parent
+	meta.OplogSince = &since
+	filename, err := backups.NewBackups(paths).CreateIncremental(meta, dbInfo)
+	return meta, filename, errors.Trace(err)
+}
+
+// prepare gathers the metadata and database information for a new
+// backup.
+func (b *backupsShim) prepare() (*backups.Metadata, *backups.DBInfo, *backups.Paths, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	paths := &backups.Paths{
+		BackupDir: backups.BackupDirToUse(modelConfig.BackupDir()),
+		DataDir:   b.dataDir,
+		LogsDir:   b.logsDir,
+	}
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	dbInfo, err := backups.NewDBInfo(b.mongoInfo, sessionShim{session})
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+
+	m, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	mBase, err := corebase.ParseBase(m.Base().OS, m.Base().Channel)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(metadataShim{State: b.st, model: model}, b.machineID, mBase.DisplayString())
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	meta.Controller.MachineID = b.machineID
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+	return meta, dbInfo, paths, nil
+}
+
+// metadataShim disambiguates the ModelTag method of the controller
+// model, needed to compose the backup metadata.
+type metadataShim struct {
+	*state.State
+	model *state.Model
+}
+
+func (s metadataShim) ModelTag() names.ModelTag {
+	return s.model.ModelTag()
+}
+
+type sessionShim struct {
+	*mgo.Session
+}
+
+func (s sessionShim) DB(name string) backups.Database {
+	return s.Session.DB(name)
+}
@@ -0,0 +1,278 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead use the one passed as manifold config.
+type logger interface{}
+
+var _ logger = struct{}{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+const (
+	// configCheckPeriod is the longest the worker waits before reading
+	// the controller config again, so that changes to the schedule
+	// take effect.
+	configCheckPeriod = 5 * time.Minute
+
+	// retryDelay is how long the worker waits before trying again
+	// after a scheduled backup fails.
+	retryDelay = 15 * time.Minute
+)
+
+// Backups creates backup archives on the controller machine.
+type Backups interface {
+	// CreateFull creates a full backup archive, returning its
+	// metadata and filename.
+	CreateFull() (*backups.Metadata, string, error)
+
+	// CreateIncremental creates an archive of the database changes
+	// since the given time, building upon the parent full backup. An
+	// error satisfying backups.ErrFullBackupRequired is returned if
+	// the archive couldn't hold all the changes.
+	CreateIncremental(parent string, since time.Time) (*backups.Metadata, string, error)
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	Clock            clock.Clock
+	Logger           Logger
+	ControllerConfig func() (controller.Config, error)
+	Backups          Backups
+	NewStorage       func(controller.Config) (backups.ArchiveStorage, error)
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.ControllerConfig == nil {
+		return errors.NotValidf("nil ControllerConfig")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.NewStorage == nil {
+		return errors.NotValidf("nil NewStorage")
+	}
+	return nil
+}
+
+// NewWorker returns a backup scheduling Worker backed by config, or an
+// error.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker takes scheduled backups of the controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// failed records when the last scheduled backup failed, so
+	// that the worker doesn't repeatedly retry.
+	failed time.Time
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		wait, err := w.check(w.config.Clock.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(wait):
+		}
+	}
+}
+
+// scheduled describes the next backup due to be taken.
+type scheduled struct {
+	due time.Time
+
+	// parent and since are set for incremental backups.
+	parent string
+	since  time.Time
+}
+
+// nextBackup returns the next backup to be taken, given the backups
+// already stored.
+func nextBackup(stored []*backups.Metadata, interval, incrementalInterval time.Duration) scheduled {
+	var full, latest *backups.Metadata
+	for _, meta := range stored {
+		if !meta.Incremental() {
+			full, latest = meta, meta
+		} else if full != nil && meta.Parent == full.ID() {
+			latest = meta
+		}
+	}
+	if full == nil {
+		return scheduled{}
+	}
+	next := scheduled{due: full.Started.Add(interval)}
+	if incrementalInterval <= 0 {
+		return next
+	}
+	if due := latest.Started.Add(incrementalInterval); due.Before(next.due) {
+		next = scheduled{
+			due:    due,
+			parent: full.ID(),
+			since:  latest.Started,
+		}
+	}
+	return next
+}
+
+// check takes a backup if one is due, and returns how long to wait
+// before checking again.
+func (w *Worker) check(now time.Time) (time.Duration, error) {
+	cfg, err := w.config.ControllerConfig()
+	if err != nil {
+		return 0, errors.Annotate(err, "getting controller config")
+	}
+	interval := cfg.BackupInterval()
+	if interval == 0 {
+		return configCheckPeriod, nil
+	}
+	if !w.failed.IsZero() && now.Before(w.failed.Add(retryDelay)) {
+		return minDuration(configCheckPeriod, w.failed.Add(retryDelay).Sub(now)), nil
+	}
+
+	ctx := w.catacomb.Context(context.Background())
+	wait, err := w.schedule(ctx, cfg, now)
+	if err != nil {
+		w.config.Logger.Errorf("scheduled backup failed, retrying in %v: %v", retryDelay, err)
+		w.failed = now
+		return minDuration(configCheckPeriod, retryDelay), nil
+	}
+	w.failed = time.Time{}
+	return minDuration(configCheckPeriod, wait), nil
+}
+
+// schedule takes the next backup if it is due, and prunes the oldest
+// backups afterwards. It returns how long to wait until the next backup
+// is due.
+func (w *Worker) schedule(ctx context.Context, cfg controller.Config, now time.Time) (time.Duration, error) {
+	storage, err := w.config.NewStorage(cfg)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	stored, err := storage.List(ctx)
+	if err != nil {
+		return 0, errors.Annotate(err, "listing backups")
+	}
+
+	next := nextBackup(stored, cfg.BackupInterval(), cfg.BackupIncrementalInterval())
+	if now.Before(next.due) {
+		return next.due.Sub(now), nil
+	}
+	if err := w.backup(ctx, storage, next); err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	removed, err := backups.Prune(ctx, storage, cfg.BackupRetentionCount())
+	for _, meta := range removed {
+		w.config.Logger.Infof("pruned backup %q", meta.ID())
+	}
+	if err != nil {
+		w.config.Logger.Errorf("pruning backups: %v", err)
+	}
+	// Check again straight away, in case another backup is due.
+	return 0, nil
+}
+
+// backup creates the scheduled backup and adds it to the storage.
+func (w *Worker) backup(ctx context.Context, storage backups.ArchiveStorage, next scheduled) error {
+	var (
+		meta     *backups.Metadata
+		filename string
+		err      error
+	)
+	if next.parent == "" {
+		w.config.Logger.Infof("taking scheduled full backup")
+		meta, filename, err = w.config.Backups.CreateFull()
+	} else {
+		w.config.Logger.Infof("taking incremental backup of %q since %v", next.parent, next.since)
+		meta, filename, err = w.config.Backups.CreateIncremental(next.parent, next.since)
+		if errors.Is(err, backups.ErrFullBackupRequired) {
+			w.config.Logger.Infof("taking full backup instead: %v", err)
+			meta, filename, err = w.config.Backups.CreateFull()
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			w.config.Logger.Errorf("removing backup archive %q: %v", filename, err)
+		}
+	}()
+
+	archive, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	meta.SetID(filepath.Base(filename))
+	stored := w.config.Clock.Now().UTC()
+	meta.SetStored(&stored)
+	if err := storage.Add(ctx, archive, meta); err != nil {
+		return errors.Annotatef(err, "storing backup %q", meta.ID())
+	}
+	w.config.Logger.Infof("stored backup %q", meta.ID())
+	return nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
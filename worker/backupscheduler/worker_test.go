@@ -0,0 +1,319 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workerSuite struct {
+	clock   *testclock.Clock
+	backups *fakeBackups
+	storage backups.ArchiveStorage
+	config  controller.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backups = &fakeBackups{
+		clock: s.clock,
+		dir:   c.MkDir(),
+	}
+	s.storage = backups.NewDirStorage(c.MkDir())
+	s.config = coretesting.FakeControllerConfig()
+	s.config[controller.BackupInterval] = "@daily"
+	s.config[controller.BackupRetentionCount] = 2
+}
+
+func (s *workerSuite) newWorker(c *gc.C) *Worker {
+	w, err := NewWorker(Config{
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+		ControllerConfig: func() (controller.Config, error) {
+			return s.config, nil
+		},
+		Backups: s.backups,
+		NewStorage: func(controller.Config) (backups.ArchiveStorage, error) {
+			return s.storage, nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w.(*Worker)
+}
+
+// addStored adds a backup to the storage, as if it had been taken in
+// the past.
+func (s *workerSuite) addStored(c *gc.C, id string, started time.Time, parent string) {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Parent = parent
+	if parent != "" {
+		meta.OplogSince = &started
+	}
+	err := s.storage.Add(context.Background(), strings.NewReader("archive"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) storedIDs(c *gc.C) []string {
+	metaList, err := s.storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, meta := range metaList {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+// waitIdle waits for the worker to wait on the clock, and then
+// advances the clock by d.
+func (s *workerSuite) waitIdle(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := NewWorker(Config{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *workerSuite) TestFullBackupWhenNoneStored(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full"})
+	c.Assert(s.storedIDs(c), jc.DeepEquals, []string{"juju-backup-1.tar.gz"})
+
+	// The local copy of the archive is removed once stored.
+	_, err := os.Stat(filepath.Join(s.backups.dir, "juju-backup-1.tar.gz"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *workerSuite) TestFullBackupDue(c *gc.C) {
+	s.addStored(c, "juju-backup-old.tar.gz", s.clock.Now().Add(-23*time.Hour), "")
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Nothing is due for another hour, but the config is checked
+	// again in the meantime.
+	s.waitIdle(c, configCheckPeriod)
+	c.Assert(s.backups.calls(), gc.HasLen, 0)
+	for i := 0; i < 11; i++ {
+		s.waitIdle(c, configCheckPeriod)
+	}
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full"})
+}
+
+func (s *workerSuite) TestIncrementalBackup(c *gc.C) {
+	s.config[controller.BackupIncrementalInterval] = "1h"
+	started := s.clock.Now().Add(-2 * time.Hour)
+	s.addStored(c, "juju-backup-full.tar.gz", started, "")
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{
+		fmt.Sprintf("incremental juju-backup-full.tar.gz %v", started),
+	})
+	metaList, err := s.storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 2)
+	c.Assert(metaList[1].ID(), gc.Equals, "juju-backup-1.tar.gz")
+	c.Assert(metaList[1].Parent, gc.Equals, "juju-backup-full.tar.gz")
+}
+
+func (s *workerSuite) TestFullBackupWhenIncrementalNotPossible(c *gc.C) {
+	s.config[controller.BackupIncrementalInterval] = "1h"
+	started := s.clock.Now().Add(-2 * time.Hour)
+	s.addStored(c, "juju-backup-full.tar.gz", started, "")
+	s.backups.incrementalErr = errors.Annotate(backups.ErrFullBackupRequired, "oplog truncated")
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{
+		fmt.Sprintf("incremental juju-backup-full.tar.gz %v", started),
+		"full",
+	})
+	metaList, err := s.storage.List(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 2)
+	c.Assert(metaList[1].ID(), gc.Equals, "juju-backup-2.tar.gz")
+	c.Assert(metaList[1].Incremental(), jc.IsFalse)
+}
+
+func (s *workerSuite) TestPrunesAfterBackup(c *gc.C) {
+	now := s.clock.Now()
+	s.addStored(c, "juju-backup-a.tar.gz", now.Add(-50*time.Hour), "")
+	s.addStored(c, "juju-backup-a-incr.tar.gz", now.Add(-49*time.Hour), "juju-backup-a.tar.gz")
+	s.addStored(c, "juju-backup-b.tar.gz", now.Add(-25*time.Hour), "")
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full"})
+	c.Assert(s.storedIDs(c), jc.DeepEquals, []string{
+		"juju-backup-b.tar.gz", "juju-backup-1.tar.gz",
+	})
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	s.config[controller.BackupInterval] = ""
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, configCheckPeriod)
+	c.Assert(s.backups.calls(), gc.HasLen, 0)
+
+	// Enabling backups takes effect when the config is next checked.
+	s.config[controller.BackupInterval] = "@daily"
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full"})
+}
+
+func (s *workerSuite) TestRetriesAfterFailure(c *gc.C) {
+	s.backups.setError(errors.New("boom"))
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitIdle(c, configCheckPeriod)
+	s.waitIdle(c, configCheckPeriod)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full"})
+
+	s.backups.setError(nil)
+	s.waitIdle(c, configCheckPeriod)
+	s.waitIdle(c, time.Minute)
+	c.Assert(s.backups.calls(), jc.DeepEquals, []string{"full", "full"})
+	c.Assert(s.storedIDs(c), jc.DeepEquals, []string{"juju-backup-2.tar.gz"})
+}
+
+func (s *workerSuite) TestNextBackup(c *gc.C) {
+	now := s.clock.Now()
+	meta := func(id string, started time.Time, parent string) *backups.Metadata {
+		m := backups.NewMetadata()
+		m.SetID(id)
+		m.Started = started
+		m.Parent = parent
+		return m
+	}
+	full := meta("full", now, "")
+	incr := meta("incr", now.Add(time.Hour), "full")
+	orphan := meta("orphan", now.Add(2*time.Hour), "gone")
+
+	for i, test := range []struct {
+		stored      []*backups.Metadata
+		incremental time.Duration
+		expected    scheduled
+	}{{
+		expected: scheduled{},
+	}, {
+		stored:   []*backups.Metadata{full},
+		expected: scheduled{due: now.Add(24 * time.Hour)},
+	}, {
+		stored:      []*backups.Metadata{full},
+		incremental: time.Hour,
+		expected:    scheduled{due: now.Add(time.Hour), parent: "full", since: now},
+	}, {
+		stored:      []*backups.Metadata{full, incr, orphan},
+		incremental: time.Hour,
+		expected:    scheduled{due: now.Add(2 * time.Hour), parent: "full", since: now.Add(time.Hour)},
+	}, {
+		stored:      []*backups.Metadata{full},
+		incremental: 48 * time.Hour,
+		expected:    scheduled{due: now.Add(24 * time.Hour)},
+	}} {
+		c.Logf("test %d", i)
+		c.Check(nextBackup(test.stored, 24*time.Hour, test.incremental), jc.DeepEquals, test.expected)
+	}
+}
+
+// fakeBackups creates empty archives, recording the calls made.
+type fakeBackups struct {
+	clock *testclock.Clock
+	dir   string
+
+	mu     sync.Mutex
+	called []string
+	err    error
+
+	// incrementalErr is returned by CreateIncremental.
+	incrementalErr error
+}
+
+func (f *fakeBackups) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.called...)
+}
+
+func (f *fakeBackups) setError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeBackups) create(call string) (*backups.Metadata, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.called = append(f.called, call)
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	filename := filepath.Join(f.dir, fmt.Sprintf("juju-backup-%d.tar.gz", len(f.called)))
+	if err := os.WriteFile(filename, []byte("archive"), 0600); err != nil {
+		return nil, "", err
+	}
+	meta := backups.NewMetadata()
+	meta.Started = f.clock.Now()
+	return meta, filename, nil
+}
+
+// CreateFull is part of Backups.
+func (f *fakeBackups) CreateFull() (*backups.Metadata, string, error) {
+	return f.create("full")
+}
+
+// CreateIncremental is part of Backups.
+func (f *fakeBackups) CreateIncremental(parent string, since time.Time) (*backups.Metadata, string, error) {
+	if f.incrementalErr != nil {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.called = append(f.called, fmt.Sprintf("incremental %s %v", parent, since))
+		return nil, "", f.incrementalErr
+	}
+	meta, filename, err := f.create(fmt.Sprintf("incremental %s %v", parent, since))
+	if err == nil {
+		meta.Parent = parent
+		meta.OplogSince = &since
+	}
+	return meta, filename, err
+}
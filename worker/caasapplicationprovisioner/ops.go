@@ -209,6 +209,8 @@ func appAlive(appName string, app caas.Application, password string, lastApplied
 		CharmModifiedVersion: provisionInfo.CharmModifiedVersion,
 		Trust:                provisionInfo.Trust,
 		InitialScale:         provisionInfo.Scale,
+		MaxUnavailable:       provisionInfo.MaxUnavailable,
+		SpreadBy:             provisionInfo.SpreadBy,
 	}
 	reason := "unchanged"
 	// TODO(sidecar): implement Equals method for caas.ApplicationConfig
@@ -552,9 +552,11 @@ func (s *OpsSuite) TestAppAlive(c *gc.C) {
 		Tags: map[string]string{
 			"tag": "tag-value",
 		},
-		Trust:       true,
-		Scale:       10,
-		Constraints: constraints.MustParse("mem=1G"),
+		Trust:          true,
+		Scale:          10,
+		MaxUnavailable: "1",
+		SpreadBy:       "zone",
+		Constraints:    constraints.MustParse("mem=1G"),
 		Filesystems: []storage.KubernetesFilesystemParams{{
 			StorageName: "data",
 			Size:        100,
@@ -611,9 +613,11 @@ func (s *OpsSuite) TestAppAlive(c *gc.C) {
 			StorageName: "data",
 			Size:        100,
 		}},
-		Devices:      []devices.KubernetesDeviceParams{},
-		Trust:        true,
-		InitialScale: 10,
+		Devices:        []devices.KubernetesDeviceParams{},
+		Trust:          true,
+		InitialScale:   10,
+		MaxUnavailable: "1",
+		SpreadBy:       "zone",
 	}
 	gomock.InOrder(
 		facade.EXPECT().ProvisioningInfo("test").Return(pi, nil),
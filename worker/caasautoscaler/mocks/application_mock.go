@@ -0,0 +1,291 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/caas (interfaces: Application)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/application_mock.go github.com/juju/juju/caas Application
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	caas "github.com/juju/juju/caas"
+	application "github.com/juju/juju/core/application"
+	watcher "github.com/juju/juju/core/watcher"
+	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MockApplication is a mock of Application interface.
+type MockApplication struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMockRecorder
+}
+
+// MockApplicationMockRecorder is the mock recorder for MockApplication.
+type MockApplicationMockRecorder struct {
+	mock *MockApplication
+}
+
+// NewMockApplication creates a new mock instance.
+func NewMockApplication(ctrl *gomock.Controller) *MockApplication {
+	mock := &MockApplication{ctrl: ctrl}
+	mock.recorder = &MockApplicationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
+	return m.recorder
+}
+
+// ApplicationPodSpec mocks base method.
+func (m *MockApplication) ApplicationPodSpec(arg0 caas.ApplicationConfig) (*v1.PodSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationPodSpec", arg0)
+	ret0, _ := ret[0].(*v1.PodSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationPodSpec indicates an expected call of ApplicationPodSpec.
+func (mr *MockApplicationMockRecorder) ApplicationPodSpec(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationPodSpec", reflect.TypeOf((*MockApplication)(nil).ApplicationPodSpec), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockApplication) Delete() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockApplicationMockRecorder) Delete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApplication)(nil).Delete))
+}
+
+// DeleteNetworkPolicy mocks base method.
+func (m *MockApplication) DeleteNetworkPolicy() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkPolicy")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkPolicy indicates an expected call of DeleteNetworkPolicy.
+func (mr *MockApplicationMockRecorder) DeleteNetworkPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).DeleteNetworkPolicy))
+}
+
+// Ensure mocks base method.
+func (m *MockApplication) Ensure(arg0 caas.ApplicationConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ensure", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ensure indicates an expected call of Ensure.
+func (mr *MockApplicationMockRecorder) Ensure(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ensure", reflect.TypeOf((*MockApplication)(nil).Ensure), arg0)
+}
+
+// EnsureNetworkPolicy mocks base method.
+func (m *MockApplication) EnsureNetworkPolicy(arg0 []caas.NetworkPolicyRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetworkPolicy indicates an expected call of EnsureNetworkPolicy.
+func (mr *MockApplicationMockRecorder) EnsureNetworkPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetworkPolicy", reflect.TypeOf((*MockApplication)(nil).EnsureNetworkPolicy), arg0)
+}
+
+// Exists mocks base method.
+func (m *MockApplication) Exists() (caas.DeploymentState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists")
+	ret0, _ := ret[0].(caas.DeploymentState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockApplicationMockRecorder) Exists() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockApplication)(nil).Exists))
+}
+
+// Scale mocks base method.
+func (m *MockApplication) Scale(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scale", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scale indicates an expected call of Scale.
+func (mr *MockApplicationMockRecorder) Scale(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scale", reflect.TypeOf((*MockApplication)(nil).Scale), arg0)
+}
+
+// Service mocks base method.
+func (m *MockApplication) Service() (*caas.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service")
+	ret0, _ := ret[0].(*caas.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockApplicationMockRecorder) Service() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockApplication)(nil).Service))
+}
+
+// State mocks base method.
+func (m *MockApplication) State() (caas.ApplicationState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].(caas.ApplicationState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// State indicates an expected call of State.
+func (mr *MockApplicationMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockApplication)(nil).State))
+}
+
+// Trust mocks base method.
+func (m *MockApplication) Trust(arg0 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trust", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trust indicates an expected call of Trust.
+func (mr *MockApplicationMockRecorder) Trust(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trust", reflect.TypeOf((*MockApplication)(nil).Trust), arg0)
+}
+
+// Units mocks base method.
+func (m *MockApplication) Units() ([]caas.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Units")
+	ret0, _ := ret[0].([]caas.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Units indicates an expected call of Units.
+func (mr *MockApplicationMockRecorder) Units() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Units", reflect.TypeOf((*MockApplication)(nil).Units))
+}
+
+// UnitsToRemove mocks base method.
+func (m *MockApplication) UnitsToRemove(arg0 context.Context, arg1 int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnitsToRemove", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnitsToRemove indicates an expected call of UnitsToRemove.
+func (mr *MockApplicationMockRecorder) UnitsToRemove(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnitsToRemove", reflect.TypeOf((*MockApplication)(nil).UnitsToRemove), arg0, arg1)
+}
+
+// UpdatePorts mocks base method.
+func (m *MockApplication) UpdatePorts(arg0 []caas.ServicePort, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePorts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePorts indicates an expected call of UpdatePorts.
+func (mr *MockApplicationMockRecorder) UpdatePorts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePorts", reflect.TypeOf((*MockApplication)(nil).UpdatePorts), arg0, arg1)
+}
+
+// UpdateService mocks base method.
+func (m *MockApplication) UpdateService(arg0 caas.ServiceParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateService", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateService indicates an expected call of UpdateService.
+func (mr *MockApplicationMockRecorder) UpdateService(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockApplication)(nil).UpdateService), arg0)
+}
+
+// Utilization mocks base method.
+func (m *MockApplication) Utilization() (application.Utilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Utilization")
+	ret0, _ := ret[0].(application.Utilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Utilization indicates an expected call of Utilization.
+func (mr *MockApplicationMockRecorder) Utilization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Utilization", reflect.TypeOf((*MockApplication)(nil).Utilization))
+}
+
+// Watch mocks base method.
+func (m *MockApplication) Watch() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockApplicationMockRecorder) Watch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockApplication)(nil).Watch))
+}
+
+// WatchReplicas mocks base method.
+func (m *MockApplication) WatchReplicas() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchReplicas")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchReplicas indicates an expected call of WatchReplicas.
+func (mr *MockApplicationMockRecorder) WatchReplicas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchReplicas", reflect.TypeOf((*MockApplication)(nil).WatchReplicas))
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/worker/caasautoscaler (interfaces: Facade)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/caasautoscaler Facade
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	caasautoscaler "github.com/juju/juju/api/controller/caasautoscaler"
+	application "github.com/juju/juju/core/application"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFacade is a mock of Facade interface.
+type MockFacade struct {
+	ctrl     *gomock.Controller
+	recorder *MockFacadeMockRecorder
+}
+
+// MockFacadeMockRecorder is the mock recorder for MockFacade.
+type MockFacadeMockRecorder struct {
+	mock *MockFacade
+}
+
+// NewMockFacade creates a new mock instance.
+func NewMockFacade(ctrl *gomock.Controller) *MockFacade {
+	mock := &MockFacade{ctrl: ctrl}
+	mock.recorder = &MockFacadeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFacade) EXPECT() *MockFacadeMockRecorder {
+	return m.recorder
+}
+
+// AutoscaledApplications mocks base method.
+func (m *MockFacade) AutoscaledApplications() ([]caasautoscaler.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscaledApplications")
+	ret0, _ := ret[0].([]caasautoscaler.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoscaledApplications indicates an expected call of AutoscaledApplications.
+func (mr *MockFacadeMockRecorder) AutoscaledApplications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscaledApplications", reflect.TypeOf((*MockFacade)(nil).AutoscaledApplications))
+}
+
+// SetAutoscaleStatus mocks base method.
+func (m *MockFacade) SetAutoscaleStatus(arg0 string, arg1 application.AutoscaleStatus, arg2 *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoscaleStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutoscaleStatus indicates an expected call of SetAutoscaleStatus.
+func (mr *MockFacadeMockRecorder) SetAutoscaleStatus(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoscaleStatus", reflect.TypeOf((*MockFacade)(nil).SetAutoscaleStatus), arg0, arg1, arg2)
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/api/base"
+	api "github.com/juju/juju/api/controller/caasautoscaler"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+// pollInterval is how often the resource usage of autoscaled
+// applications is measured.
+const pollInterval = 30 * time.Second
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/caasautoscaler Facade
+type Facade interface {
+	AutoscaledApplications() ([]api.Application, error)
+	SetAutoscaleStatus(appName string, status application.AutoscaleStatus, scale *int) error
+}
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/broker_mock.go github.com/juju/juju/worker/caasautoscaler CAASBroker
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/application_mock.go github.com/juju/juju/caas Application
+type CAASBroker interface {
+	Application(string, caas.DeploymentType) caas.Application
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Facade Facade
+	Broker CAASBroker
+	Logger Logger
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("Facade is missing")
+	}
+	if config.Broker == nil {
+		return errors.NotValidf("Broker is missing")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("Logger is missing")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("Clock is missing")
+	}
+	return nil
+}
+
+type autoscaler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewFacade returns a facade for the caasautoscaler worker to use.
+func NewFacade(caller base.APICaller) (Facade, error) {
+	return api.NewClient(caller)
+}
+
+// NewWorker returns a worker that periodically measures the resource
+// usage of autoscaled applications, and scales them to meet the targets
+// of their autoscale policies.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &autoscaler{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *autoscaler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *autoscaler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *autoscaler) loop() error {
+	timer := w.config.Clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.autoscale(); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+func (w *autoscaler) autoscale() error {
+	apps, err := w.config.Facade.AutoscaledApplications()
+	if err != nil {
+		return errors.Annotate(err, "getting autoscaled applications")
+	}
+	for _, app := range apps {
+		err := w.autoscaleApplication(app)
+		if errors.Is(err, errors.NotFound) {
+			// The application was removed, or stopped being autoscaled.
+			w.config.Logger.Debugf("not autoscaling %q: %v", app.Name, err)
+		} else if err != nil {
+			return errors.Annotatef(err, "autoscaling %q", app.Name)
+		}
+	}
+	return nil
+}
+
+func (w *autoscaler) autoscaleApplication(app api.Application) error {
+	status := app.Status
+	usage, err := w.config.Broker.Application(app.Name, caas.DeploymentStateful).Utilization()
+	if err != nil {
+		// The application can't be autoscaled until its resource usage
+		// can be measured, but the others may still be.
+		w.config.Logger.Warningf("cannot measure resource usage of %q: %v", app.Name, err)
+		status.Utilization = application.Utilization{}
+		status.TargetScale = app.Scale
+		status.Message = fmt.Sprintf("cannot measure resource usage: %v", err)
+		return w.setStatus(app, status, nil)
+	}
+
+	status.Utilization = usage
+	status.TargetScale = app.Policy.DesiredScale(app.Scale, usage)
+	status.Message = ""
+	if status.TargetScale == app.Scale {
+		return w.setStatus(app, status, nil)
+	}
+	now := w.config.Clock.Now()
+	if cooldownEnd := status.LastScaled.Add(app.Policy.Cooldown); !status.LastScaled.IsZero() && now.Before(cooldownEnd) {
+		status.Message = fmt.Sprintf("waiting for cooldown before scaling to %d", status.TargetScale)
+		return w.setStatus(app, status, nil)
+	}
+	w.config.Logger.Infof("scaling %q from %d to %d", app.Name, app.Scale, status.TargetScale)
+	status.LastScaled = now
+	scale := status.TargetScale
+	return w.setStatus(app, status, &scale)
+}
+
+func (w *autoscaler) setStatus(app api.Application, status application.AutoscaleStatus, scale *int) error {
+	if scale == nil && reflect.DeepEqual(status, app.Status) {
+		return nil
+	}
+	return errors.Trace(w.config.Facade.SetAutoscaleStatus(app.Name, status, scale))
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	api "github.com/juju/juju/api/controller/caasautoscaler"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasautoscaler"
+	"github.com/juju/juju/worker/caasautoscaler/mocks"
+)
+
+var _ = gc.Suite(&workerSuite{})
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade    *mocks.MockFacade
+	broker    *mocks.MockCAASBroker
+	brokerApp *mocks.MockApplication
+	clock     *testclock.Clock
+	done      chan struct{}
+}
+
+var now = time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+
+var gitlabPolicy = application.AutoscalePolicy{
+	MinUnits:  1,
+	MaxUnits:  5,
+	TargetCPU: 50,
+	Cooldown:  5 * time.Minute,
+}
+
+func (s *workerSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.facade = mocks.NewMockFacade(ctrl)
+	s.broker = mocks.NewMockCAASBroker(ctrl)
+	s.brokerApp = mocks.NewMockApplication(ctrl)
+	s.clock = testclock.NewClock(now)
+	s.done = make(chan struct{})
+	return ctrl
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := caasautoscaler.NewWorker(caasautoscaler.Config{
+		Facade: s.facade,
+		Broker: s.broker,
+		Logger: loggo.GetLogger("test"),
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) waitDone(c *gc.C) {
+	select {
+	case <-s.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the autoscaler")
+	}
+}
+
+func (s *workerSuite) TestConfigValidate(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := caasautoscaler.Config{}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Facade is missing not valid`)
+	cfg.Facade = s.facade
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Broker is missing not valid`)
+	cfg.Broker = s.broker
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Logger is missing not valid`)
+	cfg.Logger = loggo.GetLogger("test")
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Clock is missing not valid`)
+	cfg.Clock = s.clock
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) expectUtilization(units int, cpu float64) {
+	s.broker.EXPECT().Application("gitlab", caas.DeploymentStateful).Return(s.brokerApp)
+	s.brokerApp.EXPECT().Utilization().Return(application.Utilization{Units: units, CPU: &cpu}, nil)
+}
+
+func (s *workerSuite) TestScaleUp(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  2,
+		Policy: gitlabPolicy,
+	}}, nil)
+	s.expectUtilization(2, 90)
+	cpu := 90.0
+	scale := 4
+	s.facade.EXPECT().SetAutoscaleStatus("gitlab", application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 2, CPU: &cpu},
+		TargetScale: 4,
+		LastScaled:  now,
+	}, &scale).DoAndReturn(func(string, application.AutoscaleStatus, *int) error {
+		close(s.done)
+		return nil
+	})
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestScaleWithinBounds(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  4,
+		Policy: gitlabPolicy,
+	}}, nil)
+	s.expectUtilization(4, 100)
+	cpu := 100.0
+	scale := 5
+	s.facade.EXPECT().SetAutoscaleStatus("gitlab", application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 4, CPU: &cpu},
+		TargetScale: 5,
+		LastScaled:  now,
+	}, &scale).DoAndReturn(func(string, application.AutoscaleStatus, *int) error {
+		close(s.done)
+		return nil
+	})
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestCooldown(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	lastScaled := now.Add(-time.Minute)
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  4,
+		Policy: gitlabPolicy,
+		Status: application.AutoscaleStatus{
+			TargetScale: 4,
+			LastScaled:  lastScaled,
+		},
+	}}, nil)
+	s.expectUtilization(4, 10)
+	cpu := 10.0
+	s.facade.EXPECT().SetAutoscaleStatus("gitlab", application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 4, CPU: &cpu},
+		TargetScale: 1,
+		LastScaled:  lastScaled,
+		Message:     "waiting for cooldown before scaling to 1",
+	}, nil).DoAndReturn(func(string, application.AutoscaleStatus, *int) error {
+		close(s.done)
+		return nil
+	})
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestUnchangedStatusNotSet(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cpu := 50.0
+	status := application.AutoscaleStatus{
+		Utilization: application.Utilization{Units: 2, CPU: &cpu},
+		TargetScale: 2,
+	}
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  2,
+		Policy: gitlabPolicy,
+		Status: status,
+	}}, nil)
+	s.broker.EXPECT().Application("gitlab", caas.DeploymentStateful).Return(s.brokerApp)
+	s.brokerApp.EXPECT().Utilization().DoAndReturn(func() (application.Utilization, error) {
+		return status.Utilization, nil
+	})
+	// The next poll is after the interval.
+	s.facade.EXPECT().AutoscaledApplications().DoAndReturn(func() ([]api.Application, error) {
+		close(s.done)
+		return nil, nil
+	})
+
+	w := s.startWorker(c)
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestUtilizationError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  2,
+		Policy: gitlabPolicy,
+	}}, nil)
+	s.broker.EXPECT().Application("gitlab", caas.DeploymentStateful).Return(s.brokerApp)
+	s.brokerApp.EXPECT().Utilization().Return(application.Utilization{},
+		errors.NotFoundf("resource metrics API (is metrics-server installed?)"))
+	s.facade.EXPECT().SetAutoscaleStatus("gitlab", application.AutoscaleStatus{
+		TargetScale: 2,
+		Message:     "cannot measure resource usage: resource metrics API (is metrics-server installed?) not found",
+	}, nil).DoAndReturn(func(string, application.AutoscaleStatus, *int) error {
+		close(s.done)
+		return nil
+	})
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestApplicationRemoved(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().AutoscaledApplications().Return([]api.Application{{
+		Name:   "gitlab",
+		Scale:  2,
+		Policy: gitlabPolicy,
+	}}, nil)
+	s.expectUtilization(2, 90)
+	s.facade.EXPECT().SetAutoscaleStatus("gitlab", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(string, application.AutoscaleStatus, *int) error {
+			close(s.done)
+			return errors.NotFoundf(`application "gitlab"`)
+		})
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().AutoscaledApplications().Return(nil, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting autoscaled applications: boom")
+}
@@ -4,6 +4,7 @@
 package caasfirewallersidecar
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -23,12 +24,15 @@ type applicationWorker struct {
 
 	firewallerAPI CAASFirewallerAPI
 
-	broker         CAASBroker
-	portMutator    PortMutator
-	serviceUpdater ServiceUpdater
+	broker               CAASBroker
+	portMutator          PortMutator
+	serviceUpdater       ServiceUpdater
+	networkPolicyMutator NetworkPolicyMutator
 
-	appWatcher   watcher.NotifyWatcher
-	portsWatcher watcher.StringsWatcher
+	appWatcher     watcher.NotifyWatcher
+	portsWatcher   watcher.StringsWatcher
+	configWatcher  watcher.NotifyWatcher
+	ingressWatcher watcher.NotifyWatcher
 
 	lifeGetter LifeGetter
 
@@ -37,6 +41,14 @@ type applicationWorker struct {
 
 	currentPorts network.GroupedPortRanges
 
+	// networkPolicies is true if the model config enables
+	// network policies, and policyApplied is true once the
+	// current rules have been applied to the application.
+	networkPolicies   bool
+	policyApplied     bool
+	configInitialised bool
+	currentRules      []caas.NetworkPolicyRule
+
 	logger Logger
 }
 
@@ -95,10 +107,27 @@ func (w *applicationWorker) setUp() (err error) {
 		return errors.Trace(err)
 	}
 
+	w.configWatcher, err = w.firewallerAPI.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(w.configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	w.ingressWatcher, err = w.firewallerAPI.WatchApplicationIngressRules(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(w.ingressWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	// TODO(sidecar): support deployment other than statefulset
 	app := w.broker.Application(w.appName, caas.DeploymentStateful)
 	w.portMutator = app
 	w.serviceUpdater = app
+	w.networkPolicyMutator = app
 
 	if w.currentPorts, err = w.firewallerAPI.GetOpenedPorts(w.appName); err != nil {
 		return errors.Annotatef(err, "failed to get initial openned ports for application")
@@ -136,6 +165,11 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+			// Relations being added or removed change the application,
+			// so the ingress rules may have changed.
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-w.portsWatcher.Changes():
 			if !ok {
 				return errors.New("application watcher closed")
@@ -147,6 +181,26 @@ func (w *applicationWorker) loop() (err error) {
 			if err := w.onPortChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-w.configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.onModelConfigChanged(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-w.ingressWatcher.Changes():
+			if !ok {
+				return errors.New("ingress rules watcher closed")
+			}
+			// Relations being suspended or resumed, or the ingress
+			// networks of cross model relations changing, change
+			// the ingress rules.
+			if err := w.updateNetworkPolicy(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
@@ -223,6 +277,61 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	return errors.Trace(unExposeService(w.serviceUpdater))
 }
 
+func (w *applicationWorker) onModelConfigChanged() error {
+	cfg, err := w.firewallerAPI.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	enabled := cfg.NetworkPolicies()
+	if w.configInitialised && enabled == w.networkPolicies {
+		return nil
+	}
+	initial := !w.configInitialised
+	w.configInitialised = true
+	w.networkPolicies = enabled
+	if enabled {
+		return errors.Trace(w.updateNetworkPolicy())
+	}
+	// Network policies may have been disabled while the worker
+	// wasn't running, so remove any policy left behind on start up.
+	if !initial && !w.policyApplied {
+		return nil
+	}
+	if err := w.networkPolicyMutator.DeleteNetworkPolicy(); err != nil {
+		return errors.Annotatef(err, "cannot delete network policy for application %q", w.appName)
+	}
+	w.policyApplied = false
+	w.currentRules = nil
+	return nil
+}
+
+// updateNetworkPolicy ensures the application's network policy allows
+// ingress from its related applications and the networks it's exposed
+// to, if network policies are enabled for the model.
+func (w *applicationWorker) updateNetworkPolicy() error {
+	if !w.networkPolicies {
+		return nil
+	}
+	rules, err := w.firewallerAPI.ApplicationIngressRules(w.appName)
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if w.policyApplied && reflect.DeepEqual(rules, w.currentRules) {
+		w.logger.Debugf("no ingress rule changes for app %q", w.appName)
+		return nil
+	}
+	w.logger.Debugf("ingress rules changed for app %q, %v", w.appName, rules)
+	if err := w.networkPolicyMutator.EnsureNetworkPolicy(rules); err != nil {
+		return errors.Annotatef(err, "cannot update network policy for application %q", w.appName)
+	}
+	w.policyApplied = true
+	w.currentRules = rules
+	return nil
+}
+
 func exposeService(app ServiceUpdater) error {
 	// TODO(sidecar): implement expose once it's modelled.
 	// app.UpdateService()
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasfirewallersidecar"
 	"github.com/juju/juju/worker/caasfirewallersidecar/mocks"
@@ -35,9 +36,13 @@ type appWorkerSuite struct {
 
 	applicationChanges chan struct{}
 	portsChanges       chan []string
+	configChanges      chan struct{}
+	ingressChanges     chan struct{}
 
-	appsWatcher  watcher.NotifyWatcher
-	portsWatcher watcher.StringsWatcher
+	appsWatcher    watcher.NotifyWatcher
+	portsWatcher   watcher.StringsWatcher
+	configWatcher  watcher.NotifyWatcher
+	ingressWatcher watcher.NotifyWatcher
 }
 
 var _ = gc.Suite(&appWorkerSuite{})
@@ -48,6 +53,8 @@ func (s *appWorkerSuite) SetUpTest(c *gc.C) {
 	s.appName = "app1"
 	s.applicationChanges = make(chan struct{})
 	s.portsChanges = make(chan []string)
+	s.configChanges = make(chan struct{})
+	s.ingressChanges = make(chan struct{})
 }
 
 func (s *appWorkerSuite) getController(c *gc.C) *gomock.Controller {
@@ -55,6 +62,8 @@ func (s *appWorkerSuite) getController(c *gc.C) *gomock.Controller {
 
 	s.appsWatcher = watchertest.NewMockNotifyWatcher(s.applicationChanges)
 	s.portsWatcher = watchertest.NewMockStringsWatcher(s.portsChanges)
+	s.configWatcher = watchertest.NewMockNotifyWatcher(s.configChanges)
+	s.ingressWatcher = watchertest.NewMockNotifyWatcher(s.ingressChanges)
 
 	s.firewallerAPI = mocks.NewMockCAASFirewallerAPI(ctrl)
 
@@ -114,6 +123,8 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 	gomock.InOrder(
 		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
 		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchForModelConfigChanges().Return(s.configWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchApplicationIngressRules(s.appName).Return(s.ingressWatcher, nil),
 		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
 
 		// initial fetch.
@@ -165,3 +176,123 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 	}
 	workertest.CleanKill(c, w)
 }
+
+func (s *appWorkerSuite) modelConfig(c *gc.C, networkPolicies bool) *config.Config {
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		config.NetworkPoliciesKey: networkPolicies,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *appWorkerSuite) TestNetworkPolicy(c *gc.C) {
+	ctrl := s.getController(c)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	go func() {
+		// Network policies are enabled.
+		s.configChanges <- struct{}{}
+		// A relation is added.
+		s.applicationChanges <- struct{}{}
+		// Ports change, but the rules don't.
+		s.portsChanges <- []string{"port changes"}
+		// A relation is suspended.
+		s.ingressChanges <- struct{}{}
+		// Network policies are disabled.
+		s.configChanges <- struct{}{}
+		// Changes no longer update the policy.
+		s.applicationChanges <- struct{}{}
+	}()
+
+	dbRule := caas.NetworkPolicyRule{
+		FromApplication: "postgresql",
+		PortRanges:      []network.PortRange{network.MustParsePortRange("5432/tcp")},
+	}
+	cacheRule := caas.NetworkPolicyRule{
+		FromApplication: "redis",
+		PortRanges:      []network.PortRange{network.MustParsePortRange("6379/tcp")},
+	}
+
+	gomock.InOrder(
+		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchForModelConfigChanges().Return(s.configWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchApplicationIngressRules(s.appName).Return(s.ingressWatcher, nil),
+		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
+		s.firewallerAPI.EXPECT().GetOpenedPorts(s.appName).Return(network.GroupedPortRanges{}, nil),
+
+		// Enabled.
+		s.firewallerAPI.EXPECT().ModelConfig().Return(s.modelConfig(c, true), nil),
+		s.firewallerAPI.EXPECT().ApplicationIngressRules(s.appName).Return([]caas.NetworkPolicyRule{dbRule}, nil),
+		s.brokerApp.EXPECT().EnsureNetworkPolicy([]caas.NetworkPolicyRule{dbRule}).Return(nil),
+
+		// Relation added.
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().ApplicationIngressRules(s.appName).Return([]caas.NetworkPolicyRule{dbRule, cacheRule}, nil),
+		s.brokerApp.EXPECT().EnsureNetworkPolicy([]caas.NetworkPolicyRule{dbRule, cacheRule}).Return(nil),
+
+		// Ports changed.
+		s.firewallerAPI.EXPECT().GetOpenedPorts(s.appName).Return(network.GroupedPortRanges{}, nil),
+		s.firewallerAPI.EXPECT().ApplicationIngressRules(s.appName).Return([]caas.NetworkPolicyRule{dbRule, cacheRule}, nil),
+
+		// Relation suspended.
+		s.firewallerAPI.EXPECT().ApplicationIngressRules(s.appName).Return([]caas.NetworkPolicyRule{cacheRule}, nil),
+		s.brokerApp.EXPECT().EnsureNetworkPolicy([]caas.NetworkPolicyRule{cacheRule}).Return(nil),
+
+		// Disabled.
+		s.firewallerAPI.EXPECT().ModelConfig().Return(s.modelConfig(c, false), nil),
+		s.brokerApp.EXPECT().DeleteNetworkPolicy().Return(nil),
+
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).DoAndReturn(func(_ string) (bool, error) {
+			close(done)
+			return false, nil
+		}),
+	)
+
+	w := s.getWorker(c)
+
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Errorf("timed out waiting for worker")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *appWorkerSuite) TestNetworkPolicyDisabledOnStart(c *gc.C) {
+	ctrl := s.getController(c)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.configChanges <- struct{}{}
+	}()
+
+	gomock.InOrder(
+		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchForModelConfigChanges().Return(s.configWatcher, nil),
+		s.firewallerAPI.EXPECT().WatchApplicationIngressRules(s.appName).Return(s.ingressWatcher, nil),
+		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
+		s.firewallerAPI.EXPECT().GetOpenedPorts(s.appName).Return(network.GroupedPortRanges{}, nil),
+
+		// Any policy left from when network policies were enabled is removed.
+		s.firewallerAPI.EXPECT().ModelConfig().Return(s.modelConfig(c, false), nil),
+		s.brokerApp.EXPECT().DeleteNetworkPolicy().DoAndReturn(func() error {
+			close(done)
+			return nil
+		}),
+	)
+
+	w := s.getWorker(c)
+
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Errorf("timed out waiting for worker")
+	}
+	workertest.CleanKill(c, w)
+}
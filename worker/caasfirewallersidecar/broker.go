@@ -21,3 +21,9 @@ type PortMutator interface {
 type ServiceUpdater interface {
 	UpdateService(caas.ServiceParam) error
 }
+
+// NetworkPolicyMutator exposes CAAS application functionality to a worker.
+type NetworkPolicyMutator interface {
+	EnsureNetworkPolicy(rules []caas.NetworkPolicyRule) error
+	DeleteNetworkPolicy() error
+}
@@ -5,10 +5,12 @@ package caasfirewallersidecar
 
 import (
 	charmscommon "github.com/juju/juju/api/common/charms"
-	"github.com/juju/juju/core/config"
+	"github.com/juju/juju/caas"
+	coreconfig "github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/client_mock.go github.com/juju/juju/worker/caasfirewallersidecar Client,CAASFirewallerAPI,LifeGetter
@@ -34,9 +36,14 @@ type CAASFirewallerAPI interface {
 	GetOpenedPorts(appName string) (network.GroupedPortRanges, error)
 
 	IsExposed(string) (bool, error)
-	ApplicationConfig(string) (config.ConfigAttributes, error)
+	ApplicationConfig(string) (coreconfig.ConfigAttributes, error)
 
 	ApplicationCharmInfo(appName string) (*charmscommon.CharmInfo, error)
+
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	ApplicationIngressRules(appName string) ([]caas.NetworkPolicyRule, error)
+	WatchApplicationIngressRules(appName string) (watcher.NotifyWatcher, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -13,10 +13,12 @@ import (
 	reflect "reflect"
 
 	charms "github.com/juju/juju/api/common/charms"
+	caas "github.com/juju/juju/caas"
 	config "github.com/juju/juju/core/config"
 	life "github.com/juju/juju/core/life"
 	network "github.com/juju/juju/core/network"
 	watcher "github.com/juju/juju/core/watcher"
+	config0 "github.com/juju/juju/environs/config"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -73,6 +75,21 @@ func (mr *MockClientMockRecorder) ApplicationConfig(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationConfig", reflect.TypeOf((*MockClient)(nil).ApplicationConfig), arg0)
 }
 
+// ApplicationIngressRules mocks base method.
+func (m *MockClient) ApplicationIngressRules(arg0 string) ([]caas.NetworkPolicyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationIngressRules", arg0)
+	ret0, _ := ret[0].([]caas.NetworkPolicyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationIngressRules indicates an expected call of ApplicationIngressRules.
+func (mr *MockClientMockRecorder) ApplicationIngressRules(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationIngressRules", reflect.TypeOf((*MockClient)(nil).ApplicationIngressRules), arg0)
+}
+
 // GetOpenedPorts mocks base method.
 func (m *MockClient) GetOpenedPorts(arg0 string) (network.GroupedPortRanges, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +135,21 @@ func (mr *MockClientMockRecorder) Life(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Life", reflect.TypeOf((*MockClient)(nil).Life), arg0)
 }
 
+// ModelConfig mocks base method.
+func (m *MockClient) ModelConfig() (*config0.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConfig")
+	ret0, _ := ret[0].(*config0.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConfig indicates an expected call of ModelConfig.
+func (mr *MockClientMockRecorder) ModelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfig", reflect.TypeOf((*MockClient)(nil).ModelConfig))
+}
+
 // WatchApplication mocks base method.
 func (m *MockClient) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +165,21 @@ func (mr *MockClientMockRecorder) WatchApplication(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplication", reflect.TypeOf((*MockClient)(nil).WatchApplication), arg0)
 }
 
+// WatchApplicationIngressRules mocks base method.
+func (m *MockClient) WatchApplicationIngressRules(arg0 string) (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchApplicationIngressRules", arg0)
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchApplicationIngressRules indicates an expected call of WatchApplicationIngressRules.
+func (mr *MockClientMockRecorder) WatchApplicationIngressRules(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationIngressRules", reflect.TypeOf((*MockClient)(nil).WatchApplicationIngressRules), arg0)
+}
+
 // WatchApplications mocks base method.
 func (m *MockClient) WatchApplications() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +195,21 @@ func (mr *MockClientMockRecorder) WatchApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplications", reflect.TypeOf((*MockClient)(nil).WatchApplications))
 }
 
+// WatchForModelConfigChanges mocks base method.
+func (m *MockClient) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchForModelConfigChanges")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchForModelConfigChanges indicates an expected call of WatchForModelConfigChanges.
+func (mr *MockClientMockRecorder) WatchForModelConfigChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchForModelConfigChanges", reflect.TypeOf((*MockClient)(nil).WatchForModelConfigChanges))
+}
+
 // WatchOpenedPorts mocks base method.
 func (m *MockClient) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +278,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) ApplicationConfig(arg0 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationConfig", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ApplicationConfig), arg0)
 }
 
+// ApplicationIngressRules mocks base method.
+func (m *MockCAASFirewallerAPI) ApplicationIngressRules(arg0 string) ([]caas.NetworkPolicyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationIngressRules", arg0)
+	ret0, _ := ret[0].([]caas.NetworkPolicyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationIngressRules indicates an expected call of ApplicationIngressRules.
+func (mr *MockCAASFirewallerAPIMockRecorder) ApplicationIngressRules(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationIngressRules", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ApplicationIngressRules), arg0)
+}
+
 // GetOpenedPorts mocks base method.
 func (m *MockCAASFirewallerAPI) GetOpenedPorts(arg0 string) (network.GroupedPortRanges, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +323,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) IsExposed(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExposed", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).IsExposed), arg0)
 }
 
+// ModelConfig mocks base method.
+func (m *MockCAASFirewallerAPI) ModelConfig() (*config0.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConfig")
+	ret0, _ := ret[0].(*config0.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConfig indicates an expected call of ModelConfig.
+func (mr *MockCAASFirewallerAPIMockRecorder) ModelConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfig", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).ModelConfig))
+}
+
 // WatchApplication mocks base method.
 func (m *MockCAASFirewallerAPI) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -261,6 +353,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) WatchApplication(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplication", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchApplication), arg0)
 }
 
+// WatchApplicationIngressRules mocks base method.
+func (m *MockCAASFirewallerAPI) WatchApplicationIngressRules(arg0 string) (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchApplicationIngressRules", arg0)
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchApplicationIngressRules indicates an expected call of WatchApplicationIngressRules.
+func (mr *MockCAASFirewallerAPIMockRecorder) WatchApplicationIngressRules(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplicationIngressRules", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchApplicationIngressRules), arg0)
+}
+
 // WatchApplications mocks base method.
 func (m *MockCAASFirewallerAPI) WatchApplications() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +383,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) WatchApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchApplications", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchApplications))
 }
 
+// WatchForModelConfigChanges mocks base method.
+func (m *MockCAASFirewallerAPI) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchForModelConfigChanges")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchForModelConfigChanges indicates an expected call of WatchForModelConfigChanges.
+func (mr *MockCAASFirewallerAPIMockRecorder) WatchForModelConfigChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchForModelConfigChanges", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).WatchForModelConfigChanges))
+}
+
 // WatchOpenedPorts mocks base method.
 func (m *MockCAASFirewallerAPI) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	m.ctrl.T.Helper()
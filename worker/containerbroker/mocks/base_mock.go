@@ -58,6 +58,20 @@ func (mr *MockAPICallerMockRecorder) APICall(arg0, arg1, arg2, arg3, arg4, arg5
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APICall", reflect.TypeOf((*MockAPICaller)(nil).APICall), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// APICallContext mocks base method.
+func (m *MockAPICaller) APICallContext(arg0 context.Context, arg1 string, arg2 int, arg3, arg4 string, arg5, arg6 any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APICallContext", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// APICallContext indicates an expected call of APICallContext.
+func (mr *MockAPICallerMockRecorder) APICallContext(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APICallContext", reflect.TypeOf((*MockAPICaller)(nil).APICallContext), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // BakeryClient mocks base method.
 func (m *MockAPICaller) BakeryClient() base.MacaroonDischarger {
 	m.ctrl.T.Helper()
@@ -291,6 +291,21 @@ func (m *MockNodeManager) EXPECT() *MockNodeManagerMockRecorder {
 	return m.recorder
 }
 
+// ApplyStagedRestore mocks base method.
+func (m *MockNodeManager) ApplyStagedRestore(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyStagedRestore", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyStagedRestore indicates an expected call of ApplyStagedRestore.
+func (mr *MockNodeManagerMockRecorder) ApplyStagedRestore(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyStagedRestore", reflect.TypeOf((*MockNodeManager)(nil).ApplyStagedRestore), arg0)
+}
+
 // ClusterServers mocks base method.
 func (m *MockNodeManager) ClusterServers(arg0 context.Context) ([]dqlite.NodeInfo, error) {
 	m.ctrl.T.Helper()
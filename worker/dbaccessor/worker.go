@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/database/app"
 	"github.com/juju/juju/database/dqlite"
 	"github.com/juju/juju/pubsub/apiserver"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 )
 
 const (
@@ -49,6 +50,10 @@ type NodeManager interface {
 	// a path determined by the agent config, then returns that path.
 	EnsureDataDir() (string, error)
 
+	// ApplyStagedRestore replaces the Dqlite data with any staged by a
+	// backup restore, reporting whether it did so.
+	ApplyStagedRestore(context.Context) (bool, error)
+
 	// ClusterServers returns the node information for
 	// Dqlite nodes configured to be in the cluster.
 	ClusterServers(context.Context) ([]dqlite.NodeInfo, error)
@@ -173,6 +178,18 @@ type dbWorker struct {
 	// apiServerChanges is used to handle incoming changes
 	// to API server details within the worker loop.
 	apiServerChanges chan apiserver.Details
+
+	// restoreChanges is used to handle the start and failure of
+	// controller restores within the worker loop.
+	restoreChanges chan restoreChange
+}
+
+// restoreChange is used to pass notifications of a controller restore
+// into the worker loop. The done channel is closed once the worker has
+// acted on the change.
+type restoreChange struct {
+	inProgress bool
+	done       chan struct{}
 }
 
 func newWorker(cfg WorkerConfig) (*dbWorker, error) {
@@ -200,6 +217,7 @@ func newWorker(cfg WorkerConfig) (*dbWorker, error) {
 		dbReady:          make(chan struct{}),
 		dbRequests:       make(chan dbRequest),
 		apiServerChanges: make(chan apiserver.Details),
+		restoreChanges:   make(chan restoreChange),
 	}
 
 	if err = catacomb.Invoke(catacomb.Plan{
@@ -232,6 +250,18 @@ func (w *dbWorker) loop() (err error) {
 		cancel()
 	}()
 
+	// Data restored from a backup is applied before the node is started,
+	// so that it starts as the sole member of a restored cluster.
+	ctx, cancel := w.scopedContext()
+	restored, err := w.cfg.NodeManager.ApplyStagedRestore(ctx)
+	cancel()
+	if err != nil {
+		return errors.Annotate(err, "applying restored Dqlite data")
+	}
+	if restored {
+		w.cfg.Logger.Infof("restored Dqlite data from backup")
+	}
+
 	extant, err := w.cfg.NodeManager.IsExistingNode()
 	if err != nil {
 		return errors.Trace(err)
@@ -249,6 +279,14 @@ func (w *dbWorker) loop() (err error) {
 	}
 	defer unsub()
 
+	// The Dqlite data is replaced when the controller is restored from
+	// a backup, so the node must not be running while that happens.
+	unsubRestore, err := w.cfg.Hub.Subscribe(controllermsg.Restoring, w.handleRestoringMsg)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to controller restores")
+	}
+	defer unsubRestore()
+
 	// If this is an existing node, we start it up immediately.
 	// Otherwise, this host is entering a HA cluster, and we need to wait for
 	// the peer-grouper to determine and broadcast addresses satisfying the
@@ -264,19 +302,39 @@ func (w *dbWorker) loop() (err error) {
 		}
 	}
 
+	var restoring bool
 	for {
 		select {
 		case req := <-w.dbRequests:
-			if err := w.openDatabase(req.namespace); err != nil {
+			if restoring {
+				w.cfg.Logger.Errorf("opening database %q: restore in progress", req.namespace)
+			} else if err := w.openDatabase(req.namespace); err != nil {
 				w.cfg.Logger.Errorf("opening database %q: %s", req.namespace, err.Error())
 			}
 			close(req.done)
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
 		case apiDetails := <-w.apiServerChanges:
+			if restoring {
+				continue
+			}
 			if err := w.processAPIServerChange(apiDetails); err != nil {
 				return errors.Trace(err)
 			}
+		case change := <-w.restoreChanges:
+			if change.inProgress {
+				w.stopForRestore()
+				restoring = true
+				close(change.done)
+				continue
+			}
+			close(change.done)
+			if restoring {
+				// The restore failed. Bounce, to start the node again
+				// with whatever data is now in place.
+				w.cfg.Logger.Infof("restore failed; restarting Dqlite node")
+				return dependency.ErrBounce
+			}
 		}
 	}
 }
@@ -543,6 +601,49 @@ func (w *dbWorker) handleAPIServerChangeMsg(_ string, apiDetails apiserver.Detai
 	}
 }
 
+// handleRestoringMsg is the callback supplied to the pub/sub
+// subscription for controller restores. It blocks until the worker
+// loop has acted on the message, so that the publisher knows the
+// Dqlite node is stopped before it replaces the data.
+func (w *dbWorker) handleRestoringMsg(_ string, msg controllermsg.RestoringMessage, err error) {
+	if err != nil {
+		// This should never happen.
+		w.cfg.Logger.Errorf("pub/sub callback error: %v", err)
+		return
+	}
+
+	change := restoreChange{
+		inProgress: msg.InProgress,
+		done:       make(chan struct{}),
+	}
+	select {
+	case <-w.catacomb.Dying():
+		return
+	case w.restoreChanges <- change:
+	}
+	select {
+	case <-w.catacomb.Dying():
+	case <-change.done:
+	}
+}
+
+// stopForRestore stops the TrackedDB workers and shuts down the Dqlite
+// node, so that its data can be replaced by a restore. The node is not
+// started again until the agent restarts, at which point the restored
+// data is applied.
+func (w *dbWorker) stopForRestore() {
+	w.cfg.Logger.Infof("restore in progress; stopping Dqlite node")
+	for _, namespace := range w.dbRunner.WorkerNames() {
+		if err := w.dbRunner.StopAndRemoveWorker(namespace, w.catacomb.Dying()); err != nil {
+			w.cfg.Logger.Warningf("stopping database %q: %v", namespace, err)
+		}
+	}
+
+	ctx, cancel := w.scopedContext()
+	defer cancel()
+	w.shutdownDqlite(ctx, false)
+}
+
 // processAPIServerChange deals with cluster topology changes.
 // Note that this is always invoked from the worker loop and will never
 // race with Dqlite initialisation. If this is called then we either came
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/database/app"
 	"github.com/juju/juju/database/dqlite"
 	"github.com/juju/juju/pubsub/apiserver"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/testing"
 )
 
@@ -38,6 +39,7 @@ func (s *workerSuite) TestStartupTimeoutSingleControllerReconfigure(c *gc.C) {
 
 	mgrExp := s.nodeManager.EXPECT()
 	mgrExp.EnsureDataDir().Return(c.MkDir(), nil)
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(true, nil).Times(2)
 	mgrExp.IsLoopbackBound(gomock.Any()).Return(false, nil).Times(3)
 	mgrExp.WithTLSOption().Return(nil, nil)
@@ -52,6 +54,7 @@ func (s *workerSuite) TestStartupTimeoutSingleControllerReconfigure(c *gc.C) {
 
 	// We expect to request API details.
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 	s.hub.EXPECT().Publish(apiserver.DetailsRequestTopic, gomock.Any()).Return(func() {}, nil)
 
 	w := s.newWorker(c)
@@ -79,6 +82,7 @@ func (s *workerSuite) TestStartupTimeoutMultipleControllerRetry(c *gc.C) {
 
 	mgrExp := s.nodeManager.EXPECT()
 	mgrExp.EnsureDataDir().Return(c.MkDir(), nil).Times(2)
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(true, nil).Times(2)
 	mgrExp.IsLoopbackBound(gomock.Any()).Return(false, nil).Times(4)
 
@@ -94,6 +98,7 @@ func (s *workerSuite) TestStartupTimeoutMultipleControllerRetry(c *gc.C) {
 
 	// We expect to request API details.
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 	s.hub.EXPECT().Publish(apiserver.DetailsRequestTopic, gomock.Any()).Return(func() {}, nil).Times(2)
 
 	w := s.newWorker(c)
@@ -131,6 +136,7 @@ func (s *workerSuite) TestStartupNotExistingNodeThenCluster(c *gc.C) {
 
 	mgrExp := s.nodeManager.EXPECT()
 	mgrExp.EnsureDataDir().Return(c.MkDir(), nil)
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(false, nil).Times(4)
 	mgrExp.WithAddressOption("10.6.6.6").Return(nil)
 	mgrExp.WithClusterOption([]string{"10.6.6.7"}).Return(nil)
@@ -147,6 +153,7 @@ func (s *workerSuite) TestStartupNotExistingNodeThenCluster(c *gc.C) {
 	// When we are starting up as a new node,
 	// we request details immediately.
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 	s.hub.EXPECT().Publish(apiserver.DetailsRequestTopic, gomock.Any()).Return(func() {}, nil)
 
 	w := s.newWorker(c)
@@ -228,6 +235,7 @@ func (s *workerSuite) TestWorkerStartupExistingNode(c *gc.C) {
 	// part of a cluster, and uses the TLS option.
 	// IsBootstrapped node is called twice - once to check the startup
 	// conditions and then again upon worker shutdown.
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(true, nil)
 	mgrExp.IsLoopbackBound(gomock.Any()).Return(false, nil).Times(2)
 	mgrExp.WithLogFuncOption().Return(nil)
@@ -240,6 +248,7 @@ func (s *workerSuite) TestWorkerStartupExistingNode(c *gc.C) {
 	s.dbApp.EXPECT().Handover(gomock.Any()).Return(nil)
 
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 
 	w := s.newWorker(c)
 	defer workertest.DirtyKill(c, w)
@@ -249,6 +258,55 @@ func (s *workerSuite) TestWorkerStartupExistingNode(c *gc.C) {
 	workertest.CleanKill(c, w)
 }
 
+func (s *workerSuite) TestWorkerStopsNodeForRestore(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.expectAnyLogs()
+	s.expectClock()
+	s.expectTrackedDBKill()
+
+	mgrExp := s.nodeManager.EXPECT()
+	mgrExp.EnsureDataDir().Return(c.MkDir(), nil)
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
+	mgrExp.IsExistingNode().Return(true, nil)
+	mgrExp.IsLoopbackBound(gomock.Any()).Return(false, nil).Times(2)
+	mgrExp.WithLogFuncOption().Return(nil)
+	mgrExp.WithTLSOption().Return(nil, nil)
+	mgrExp.WithTracingOption().Return(nil)
+
+	s.client.EXPECT().Cluster(gomock.Any()).Return(nil, nil)
+
+	// The node is shut down without handover when the restore starts.
+	s.expectNodeStartupAndShutdown()
+
+	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	var restoring func(string, controllermsg.RestoringMessage, error)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).DoAndReturn(
+		func(_ string, handler any) (func(), error) {
+			restoring = handler.(func(string, controllermsg.RestoringMessage, error))
+			return func() {}, nil
+		})
+
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+	dbw := w.(*dbWorker)
+
+	ensureStartup(c, dbw)
+
+	// The handler returns once the node is stopped.
+	restoring(controllermsg.Restoring, controllermsg.RestoringMessage{InProgress: true}, nil)
+	dbw.mu.RLock()
+	c.Check(dbw.dbApp, gc.IsNil)
+	dbw.mu.RUnlock()
+	c.Check(dbw.dbRunner.WorkerNames(), gc.HasLen, 0)
+	workertest.CheckAlive(c, w)
+
+	// A failed restore bounces the worker, to start the node again.
+	restoring(controllermsg.Restoring, controllermsg.RestoringMessage{}, nil)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(errors.Is(err, dependency.ErrBounce), jc.IsTrue)
+}
+
 func (s *workerSuite) TestWorkerStartupAsBootstrapNodeSingleServerNoRebind(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -262,6 +320,7 @@ func (s *workerSuite) TestWorkerStartupAsBootstrapNodeSingleServerNoRebind(c *gc
 
 	// If this is an existing node, we do not
 	// invoke the address or cluster options.
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(true, nil).Times(3)
 	mgrExp.IsLoopbackBound(gomock.Any()).Return(true, nil).Times(4)
 	mgrExp.WithLogFuncOption().Return(nil)
@@ -272,6 +331,7 @@ func (s *workerSuite) TestWorkerStartupAsBootstrapNodeSingleServerNoRebind(c *gc
 	s.expectNodeStartupAndShutdown()
 
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 
 	w := s.newWorker(c)
 	defer workertest.DirtyKill(c, w)
@@ -322,6 +382,7 @@ func (s *workerSuite) TestWorkerStartupAsBootstrapNodeThenReconfigure(c *gc.C) {
 
 	// If this is an existing node, we do not
 	// invoke the address or cluster options.
+	mgrExp.ApplyStagedRestore(gomock.Any()).Return(false, nil)
 	mgrExp.IsExistingNode().Return(true, nil).Times(2)
 	gomock.InOrder(
 		mgrExp.IsLoopbackBound(gomock.Any()).Return(true, nil).Times(2),
@@ -360,6 +421,7 @@ func (s *workerSuite) TestWorkerStartupAsBootstrapNodeThenReconfigure(c *gc.C) {
 	s.expectNodeStartupAndShutdown()
 
 	s.hub.EXPECT().Subscribe(apiserver.DetailsTopic, gomock.Any()).Return(func() {}, nil)
+	s.hub.EXPECT().Subscribe(controllermsg.Restoring, gomock.Any()).Return(func() {}, nil)
 
 	w := s.newWorker(c)
 	defer workertest.DirtyKill(c, w)
@@ -193,27 +193,25 @@ juju_start_unit () {
 }
 
 juju_leases () {
-  # This requires some arguments.
-  local query
-  local model
+  # The arguments are all optional.
+  local query="q=y"
+  if [ "$1" = "--json" ]; then
+    query="$query&format=json"
+    shift
+  fi
   if [ "$1" = "-m" ]; then
     if [ "$#" -lt 2 ]; then
-      echo "usage: juju_leases [-m <partial-model-uuid>] [<partial-app-name>...]"
+      echo "usage: juju_leases [--json] [-m <partial-model-uuid>] [<partial-app-name>...]"
       return 1
     fi
     shift
-    model=$1
+    query="$query&model=$1"
     shift
-    query="&model=$model"
   fi
   for i in "$@"; do
     query="$query&app=$i"
   done
-  if [ -z "$query" ]; then
-    juju_agent leases
-  else
-    juju_agent "leases?q=y&$query"
-  fi
+  juju_agent "leases?$query"
 }
 
 juju_revoke_lease () {
@@ -4,11 +4,14 @@
 package introspection
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +23,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/agent"
@@ -41,6 +45,17 @@ type Reporter interface {
 	IntrospectionReport() string
 }
 
+// LeaseStore provides access to the leases held in the controller
+// database, along with the entities pinning them.
+type LeaseStore interface {
+	// Leases returns all leases in the store.
+	Leases(ctx context.Context, keys ...lease.Key) (map[lease.Key]lease.Info, error)
+
+	// Pinned returns all pinned leases and the entities requiring them
+	// to be pinned.
+	Pinned(ctx context.Context) (map[lease.Key][]string, error)
+}
+
 // Clock represents the ability to wait for a bit.
 type Clock interface {
 	Now() time.Time
@@ -72,6 +87,7 @@ type Config struct {
 	Clock              Clock
 	LocalHub           SimpleHub
 	CentralHub         StructuredHub
+	LeaseStore         LeaseStore
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -101,6 +117,7 @@ type socketListener struct {
 	clock              Clock
 	localHub           SimpleHub
 	centralHub         StructuredHub
+	leaseStore         LeaseStore
 	done               chan struct{}
 }
 
@@ -137,6 +154,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		clock:              config.Clock,
 		localHub:           config.LocalHub,
 		centralHub:         config.CentralHub,
+		leaseStore:         config.LeaseStore,
 		done:               make(chan struct{}),
 	}
 	go w.serve()
@@ -223,8 +241,11 @@ func (w *socketListener) RegisterHTTPHandlers(
 	} else {
 		handle("/units", notSupportedHandler{"Units"})
 	}
-	// TODO(leases) - add metrics
-	handle("/leases", notSupportedHandler{"Leases"})
+	if w.leaseStore != nil {
+		handle("/leases", leasesHandler{w.leaseStore})
+	} else {
+		handle("/leases", notSupportedHandler{"Leases"})
+	}
 }
 
 type notSupportedHandler struct {
@@ -435,3 +456,102 @@ func (h unitsHandler) publishAndAwaitResponse(w http.ResponseWriter, topic, resp
 		http.Error(w, "response timed out", http.StatusInternalServerError)
 	}
 }
+
+type leasesHandler struct {
+	store LeaseStore
+}
+
+// leaseReport holds the details of a single lease, as rendered by
+// the leases handler.
+type leaseReport struct {
+	Holder   string   `json:"holder" yaml:"holder"`
+	Expiry   string   `json:"expiry" yaml:"expiry"`
+	PinnedBy []string `json:"pinned-by,omitempty" yaml:"pinned-by,omitempty"`
+}
+
+// ServeHTTP is part of the http.Handler interface.
+//
+// The leases are grouped by lease type, then by model UUID, then by
+// lease name. The output is YAML unless the "format" query parameter
+// is "json". The "model" and "app" query parameters may be supplied to
+// restrict the output to leases with a model UUID or a name having
+// the given prefixes.
+func (h leasesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	leases, err := h.store.Leases(ctx)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+		return
+	}
+	pinned, err := h.store.Pinned(ctx)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	report := make(map[string]map[string]map[string]leaseReport)
+	for key, info := range leases {
+		if !matchesAnyPrefix(key.ModelUUID, r.Form["model"]) || !matchesAnyPrefix(key.Lease, r.Form["app"]) {
+			continue
+		}
+
+		models, ok := report[key.Namespace]
+		if !ok {
+			models = make(map[string]map[string]leaseReport)
+			report[key.Namespace] = models
+		}
+		names, ok := models[key.ModelUUID]
+		if !ok {
+			names = make(map[string]leaseReport)
+			models[key.ModelUUID] = names
+		}
+
+		entities := append([]string(nil), pinned[key]...)
+		sort.Strings(entities)
+		names[key.Lease] = leaseReport{
+			Holder:   info.Holder,
+			Expiry:   info.Expiry.UTC().Format(time.RFC3339),
+			PinnedBy: entities,
+		}
+	}
+
+	switch format := r.Form.Get("format"); format {
+	case "", "yaml":
+		bytes, err := yaml.Marshal(report)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write(bytes)
+	case "json":
+		bytes, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bytes)
+	default:
+		http.Error(w, fmt.Sprintf("unknown format: %q", format), http.StatusBadRequest)
+	}
+}
+
+// matchesAnyPrefix returns true if there are no prefixes,
+// or if the value starts with any of them.
+func matchesAnyPrefix(value string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
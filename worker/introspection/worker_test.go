@@ -4,6 +4,7 @@
 package introspection_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub/v2"
 	"github.com/juju/testing"
@@ -24,6 +26,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/agent"
 	_ "github.com/juju/juju/state"
@@ -77,6 +80,7 @@ type introspectionSuite struct {
 	recorder   presence.Recorder
 	localHub   *pubsub.SimpleHub
 	centralHub introspection.StructuredHub
+	leaseStore introspection.LeaseStore
 	clock      *testclock.Clock
 }
 
@@ -90,6 +94,7 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.reporter = nil
 	s.worker = nil
 	s.recorder = nil
+	s.leaseStore = nil
 	s.gatherer = newPrometheusGatherer()
 	s.localHub = pubsub.NewSimpleHub(&pubsub.SimpleHubConfig{Logger: loggo.GetLogger("test.localhub")})
 	s.centralHub = pubsub.NewStructuredHub(&pubsub.StructuredHubConfig{Logger: loggo.GetLogger("test.centralhub")})
@@ -107,6 +112,7 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 		Clock:              s.clock,
 		LocalHub:           s.localHub,
 		CentralHub:         s.centralHub,
+		LeaseStore:         s.leaseStore,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -270,6 +276,93 @@ func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	s.assertContains(c, body, "tau 6.283185")
 }
 
+func (s *introspectionSuite) TestMissingLeaseStore(c *gc.C) {
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusNotFound)
+	s.assertBody(c, response, `"Leases" introspection not supported`)
+}
+
+func (s *introspectionSuite) startWorkerWithLeases(c *gc.C) {
+	// We need to make sure the existing worker is shut down
+	// so we can connect to the socket.
+	workertest.CheckKill(c, s.worker)
+	expiry := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.leaseStore = &leaseStore{
+		leases: map[lease.Key]lease.Info{
+			{Namespace: "application-leadership", ModelUUID: "model-a", Lease: "mysql"}: {
+				Holder: "mysql/0",
+				Expiry: expiry,
+			},
+			{Namespace: "application-leadership", ModelUUID: "model-b", Lease: "wordpress"}: {
+				Holder: "wordpress/1",
+				Expiry: expiry,
+			},
+			{Namespace: "singular-controller", ModelUUID: "controller", Lease: "controller"}: {
+				Holder: "machine-0",
+				Expiry: expiry,
+			},
+		},
+		pinned: map[lease.Key][]string{
+			{Namespace: "application-leadership", ModelUUID: "model-a", Lease: "mysql"}: {"machine-1", "machine-0"},
+		},
+	}
+	s.startWorker(c)
+}
+
+func (s *introspectionSuite) TestLeasesYAML(c *gc.C) {
+	s.startWorkerWithLeases(c)
+
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(s.body(c, response), gc.Equals, `
+application-leadership:
+  model-a:
+    mysql:
+      holder: mysql/0
+      expiry: "2023-03-01T12:00:00Z"
+      pinned-by:
+      - machine-0
+      - machine-1
+  model-b:
+    wordpress:
+      holder: wordpress/1
+      expiry: "2023-03-01T12:00:00Z"
+singular-controller:
+  controller:
+    controller:
+      holder: machine-0
+      expiry: "2023-03-01T12:00:00Z"
+`[1:])
+}
+
+func (s *introspectionSuite) TestLeasesJSONFiltered(c *gc.C) {
+	s.startWorkerWithLeases(c)
+
+	response := s.call(c, "/leases?format=json&model=model-&app=my")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(response.Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Assert(s.body(c, response), gc.Equals,
+		`{"application-leadership":{"model-a":{"mysql":{"holder":"mysql/0","expiry":"2023-03-01T12:00:00Z","pinned-by":["machine-0","machine-1"]}}}}`)
+}
+
+func (s *introspectionSuite) TestLeasesUnknownFormat(c *gc.C) {
+	s.startWorkerWithLeases(c)
+
+	response := s.call(c, "/leases?format=xml")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusBadRequest)
+	s.assertBody(c, response, `unknown format: "xml"`)
+}
+
+func (s *introspectionSuite) TestLeasesStoreError(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leaseStore = &leaseStore{err: errors.New("boom")}
+	s.startWorker(c)
+
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusInternalServerError)
+	s.assertBody(c, response, "error: boom")
+}
+
 func (s *introspectionSuite) TestUnitMissingAction(c *gc.C) {
 	response := s.call(c, "/units")
 	c.Assert(response.StatusCode, gc.Equals, http.StatusBadRequest)
@@ -379,6 +472,20 @@ func (r *reporter) Report() map[string]interface{} {
 	return r.values
 }
 
+type leaseStore struct {
+	leases map[lease.Key]lease.Info
+	pinned map[lease.Key][]string
+	err    error
+}
+
+func (s *leaseStore) Leases(context.Context, ...lease.Key) (map[lease.Key]lease.Info, error) {
+	return s.leases, s.err
+}
+
+func (s *leaseStore) Pinned(context.Context) (map[lease.Key][]string, error) {
+	return s.pinned, s.err
+}
+
 func newPrometheusGatherer() prometheus.Gatherer {
 	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "tau", Help: "Tau."})
 	counter.Add(6.283185)
@@ -44,6 +44,13 @@ type ManifoldConfig struct {
 	PrometheusRegisterer prometheus.Registerer
 	NewWorker            func(lease.ManagerConfig) (worker.Worker, error)
 	NewStore             func(lease.StoreConfig) *lease.Store
+
+	// SetLeaseStore is called with the lease store when it is created,
+	// and called again with nil when the lease manager stops.
+	// This is used for publishing the store to the agent's
+	// introspection worker, which runs outside of the dependency
+	// engine; hence the manifold's Output cannot be relied upon.
+	SetLeaseStore func(*lease.Store)
 }
 
 // Validate checks that the config has all the required values.
@@ -69,6 +76,9 @@ func (c ManifoldConfig) Validate() error {
 	if c.NewStore == nil {
 		return errors.NotValidf("nil NewStore")
 	}
+	if c.SetLeaseStore == nil {
+		return errors.NotValidf("nil SetLeaseStore")
+	}
 	return nil
 }
 
@@ -118,7 +128,14 @@ func (s *manifoldState) start(context dependency.Context) (worker.Worker, error)
 		LogDir:               s.config.LogDir,
 		PrometheusRegisterer: s.config.PrometheusRegisterer,
 	})
-	return w, errors.Trace(err)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s.config.SetLeaseStore(s.store)
+	return common.NewCleanupWorker(w, func() {
+		s.config.SetLeaseStore(nil)
+	}), nil
 }
 
 func (s *manifoldState) output(in worker.Worker, out interface{}) error {
@@ -84,6 +84,7 @@ func (s *manifoldSuite) SetUpTest(c *gc.C) {
 		PrometheusRegisterer: s.metrics,
 		NewWorker:            s.newWorker,
 		NewStore:             s.newStore,
+		SetLeaseStore:        s.setLeaseStore,
 	})
 }
 
@@ -112,6 +113,10 @@ func (s *manifoldSuite) newStore(config lease.StoreConfig) *lease.Store {
 	return s.store
 }
 
+func (s *manifoldSuite) setLeaseStore(store *lease.Store) {
+	s.stub.MethodCall(s, "SetLeaseStore", store)
+}
+
 var expectedInputs = []string{
 	"agent", "clock", "db-accessor",
 }
@@ -134,7 +139,7 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	_, err := s.manifold.Start(s.context)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "NewStore", "NewWorker")
+	s.stub.CheckCallNames(c, "NewStore", "NewWorker", "SetLeaseStore")
 
 	args := s.stub.Calls()[0].Args
 	c.Assert(args, gc.HasLen, 1)
@@ -169,6 +174,17 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	})
 }
 
+func (s *manifoldSuite) TestSetLeaseStore(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 2, "SetLeaseStore", s.store)
+
+	err = w.Wait()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "NewStore", "NewWorker", "SetLeaseStore", "SetLeaseStore")
+	s.stub.CheckCall(c, 3, "SetLeaseStore", (*lease.Store)(nil))
+}
+
 func (s *manifoldSuite) TestOutput(c *gc.C) {
 	s.worker = &lease.Manager{}
 	w, err := s.manifold.Start(s.context)
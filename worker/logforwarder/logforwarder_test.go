@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/rpc/params"
 	coretesting "github.com/juju/juju/testing"
@@ -85,8 +86,15 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			switch cfg := cfg.(type) {
+			case *syslog.RawConfig:
+				sender.host = cfg.Host
+			case *httpjson.RawConfig:
+				sender.host = cfg.Endpoint
+			default:
+				c.Fatalf("unexpected config %T", cfg)
+			}
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -153,6 +161,48 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestHTTPSinkConfig(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled:      true,
+		httpEndpoint: "https://logs.example.com",
+	}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.SinkConfig = logforwarder.HTTPConfig
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	rec := s.rec
+	rec.Message = "send to https://logs.example.com"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestHTTPSinkNotConfigured(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.SinkConfig = logforwarder.HTTPConfig
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// Only syslog forwarding is configured, so nothing is sent to
+	// the HTTP sink.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -208,9 +258,10 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	enabled      bool
+	host         string
+	httpEndpoint string
+	changes      chan struct{}
 }
 
 type mockWatcher struct {
@@ -259,6 +310,16 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) LogForwardHTTPConfig() (*httpjson.RawConfig, bool, error) {
+	if c.httpEndpoint == "" {
+		return nil, false, nil
+	}
+	return &httpjson.RawConfig{
+		Enabled:  c.enabled,
+		Endpoint: c.httpEndpoint,
+	}, true, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
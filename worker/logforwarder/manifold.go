@@ -83,7 +83,10 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				OpenLogForwarder: openForwarder,
 				Logger:           config.Logger,
 			})
-			return orchestrator, errors.Annotate(err, "creating log forwarding orchestrator")
+			if err != nil {
+				return nil, errors.Annotate(err, "creating log forwarding orchestrator")
+			}
+			return orchestrator, nil
 		},
 	}
 }
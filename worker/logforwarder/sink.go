@@ -4,7 +4,11 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -14,22 +18,76 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
+	// LogForwardConfig returns the current syslog log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// LogForwardHTTPConfig returns the current HTTP log forward
+	// configuration.
+	LogForwardHTTPConfig() (*httpjson.RawConfig, bool, error)
+}
+
+// SinkConfig is the configuration of a single log sink.
+type SinkConfig interface {
+	// Validate ensures that the config is currently valid.
+	Validate() error
+}
+
+// SinkConfigFn returns the current configuration for a log sink, and
+// whether forwarding to that sink is enabled.
+type SinkConfigFn func(LogForwardConfig) (SinkConfig, bool, error)
+
+// SyslogConfig is a SinkConfigFn which returns the syslog log
+// forwarding configuration.
+func SyslogConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok || !cfg.Enabled {
+		return nil, false, nil
+	}
+	return cfg, true, nil
+}
+
+// HTTPConfig is a SinkConfigFn which returns the HTTP log forwarding
+// configuration.
+func HTTPConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardHTTPConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok || !cfg.Enabled {
+		return nil, false, nil
+	}
+	return cfg, true, nil
 }
 
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// Config returns the current configuration for the log sink.
+	// If not set, the syslog configuration is used.
+	Config SinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 }
+
+// DeferredSender is implemented by senders which hold records back
+// and deliver them asynchronously, e.g. in batches. Such senders
+// report the records they have actually delivered, so that only those
+// records are recorded as sent.
+type DeferredSender interface {
+	// OnSent registers a function which is called with each batch
+	// of records once it has been delivered.
+	OnSent(func([]logfwd.Record) error)
+}
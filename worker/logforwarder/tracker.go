@@ -10,13 +10,12 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/controller/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -37,10 +36,17 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 		return nil, errors.Trace(err)
 	}
 
+	tracker := newLastSentTracker(args.Name, args.Caller)
+	if deferred, ok := sink.SendCloser.(DeferredSender); ok {
+		// The sink reports records once they are delivered, so we
+		// only record those as sent.
+		deferred.OnSent(tracker.setLastSent)
+		return sink, nil
+	}
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
-			tracker:    newLastSentTracker(args.Name, args.Caller),
+			tracker:    tracker,
 		},
 	}, nil
 }
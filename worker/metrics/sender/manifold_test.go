@@ -145,6 +145,10 @@ func (s *stubAPICaller) APICall(objType string, version int, id, request string,
 	return s.NextErr()
 }
 
+func (s *stubAPICaller) APICallContext(_ context.Context, objType string, version int, id, request string, params, response interface{}) error {
+	return s.APICall(objType, version, id, request, params, response)
+}
+
 func (s *stubAPICaller) BestFacadeVersion(facade string) int {
 	s.MethodCall(s, "BestFacadeVersion", facade)
 	return 42
@@ -56,6 +56,12 @@ var (
 // to the newly-migrated model.
 const progressUpdateInterval = 30 * time.Second
 
+// resumeTimeout is how long a migration which failed in a resumable
+// phase waits to be resumed before it's aborted. The model's agents
+// remain locked down while the migration waits, so it mustn't wait
+// indefinitely.
+const resumeTimeout = time.Hour
+
 // Facade exposes controller functionality to a Worker.
 type Facade interface {
 	// Watch returns a watcher which reports when a migration is
@@ -74,6 +80,14 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// WatchStatus returns a watcher which reports when the status of
+	// the current migration changes.
+	WatchStatus() (watcher.NotifyWatcher, error)
+
+	// SetCheckpoint records the progress made by the current phase
+	// of the migration, so that it can be resumed after a failure.
+	SetCheckpoint(coremigration.Checkpoint) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -195,6 +209,7 @@ type Worker struct {
 	logger              loggo.Logger
 	lastFailure         string
 	minionReportTimeout time.Duration
+	checkpoint          coremigration.Checkpoint
 }
 
 // Kill implements worker.Worker.
@@ -225,6 +240,7 @@ func (w *Worker) run() error {
 	}
 
 	phase := status.Phase
+	w.checkpoint = status.Checkpoint
 
 	for {
 		var err error
@@ -232,7 +248,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.PROCESSRELATIONS:
 			phase, err = w.doPROCESSRELATIONS(status)
 		case coremigration.VALIDATION:
@@ -381,15 +397,143 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	ok, err := w.runResumable(status, func() error {
+		err := w.transferModel(status.TargetInfo, status.ModelUUID)
+		if err != nil {
+			w.setErrorStatus("model data transfer failed, %v", err)
+		}
+		return err
+	})
 	if err != nil {
-		w.setErrorStatus("model data transfer failed, %v", err)
+		return coremigration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
 		return coremigration.ABORT, nil
 	}
 	return coremigration.PROCESSRELATIONS, nil
 }
 
+// runResumable runs the work of a resumable migration phase. If the
+// work fails, the failure is recorded in the migration checkpoint and
+// the worker waits until the migration is either resumed, in which
+// case the work is retried, or aborted. It returns false if the
+// migration should be aborted.
+func (w *Worker) runResumable(status coremigration.MigrationStatus, work func() error) (bool, error) {
+	for {
+		if w.checkpoint.IsFailed() {
+			resumed, err := w.waitForResume(status)
+			if err != nil || !resumed {
+				return false, errors.Trace(err)
+			}
+		}
+		if err := work(); err == nil {
+			return true, nil
+		}
+
+		w.checkpoint.FailedPhase = status.Phase
+		if err := w.saveCheckpoint(); err != nil {
+			// Without a checkpoint the migration can't be resumed,
+			// so fall back to aborting it.
+			w.logger.Errorf("%v", err)
+			return false, nil
+		}
+	}
+}
+
+// waitForResume waits for a failed migration phase to be resumed or
+// aborted. It returns true if the phase should be retried. If the phase
+// isn't resumed within resumeTimeout, the migration is aborted. The
+// timeout starts again if the worker is restarted while waiting.
+func (w *Worker) waitForResume(status coremigration.MigrationStatus) (bool, error) {
+	failure := w.lastFailure
+	if failure == "" {
+		failure = fmt.Sprintf("%s failed", strings.ToLower(status.Phase.String()))
+	}
+	w.setInfoStatus(`%s, use "juju migrate --resume" within %s to retry or "juju migrate --abort" to abort`,
+		failure, truncDuration(resumeTimeout))
+
+	watch, err := w.config.Facade.WatchStatus()
+	if err != nil {
+		return false, errors.Annotate(err, "watching migration status")
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return false, errors.Trace(err)
+	}
+	defer watch.Kill()
+
+	timeout := w.config.Clock.After(resumeTimeout)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return false, w.catacomb.ErrDying()
+		case <-timeout:
+			w.logger.Errorf("migration phase %s not resumed within %s, aborting", status.Phase, truncDuration(resumeTimeout))
+			return false, nil
+		case <-watch.Changes():
+		}
+
+		current, err := w.config.Facade.MigrationStatus()
+		if err != nil {
+			return false, errors.Annotate(err, "retrieving migration status")
+		}
+		if current.MigrationId != status.MigrationId {
+			return false, ErrInactive
+		}
+		switch {
+		case current.Phase == coremigration.ABORT:
+			return false, nil
+		case current.Phase != status.Phase:
+			return false, ErrInactive
+		case !current.Checkpoint.IsFailed():
+			w.logger.Infof("resuming migration phase %s", status.Phase)
+			w.checkpoint = current.Checkpoint
+			return true, nil
+		}
+	}
+}
+
+func (w *Worker) saveCheckpoint() error {
+	err := w.config.Facade.SetCheckpoint(w.checkpoint)
+	return errors.Annotate(err, "failed to save migration checkpoint")
+}
+
+// checkpointProgress records the binaries uploaded to the target
+// controller in the migration checkpoint, so that those already
+// uploaded are skipped when the import is resumed. A binary whose
+// upload was interrupted is uploaded again in full.
+type checkpointProgress struct {
+	w *Worker
+}
+
+func (p checkpointProgress) ids(kind migration.BinaryKind) *[]string {
+	switch kind {
+	case migration.CharmBinary:
+		return &p.w.checkpoint.Charms
+	case migration.ToolsBinary:
+		return &p.w.checkpoint.Tools
+	default:
+		return &p.w.checkpoint.Resources
+	}
+}
+
+// Uploaded is part of migration.UploadProgress.
+func (p checkpointProgress) Uploaded(kind migration.BinaryKind, id string) bool {
+	for _, uploaded := range *p.ids(kind) {
+		if uploaded == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUploaded is part of migration.UploadProgress.
+func (p checkpointProgress) SetUploaded(kind migration.BinaryKind, id string) error {
+	ids := p.ids(kind)
+	*ids = append(*ids, id)
+	return errors.Trace(p.w.saveCheckpoint())
+}
+
 type uploadWrapper struct {
 	client    *migrationtarget.Client
 	modelUUID string
@@ -434,9 +578,17 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
-	if err != nil {
-		return errors.Annotate(err, "failed to import model into target controller")
+	if w.checkpoint.Imported {
+		w.logger.Infof("model already imported into target controller")
+	} else {
+		err = targetClient.Import(serialized.Bytes)
+		if err != nil {
+			return errors.Annotate(err, "failed to import model into target controller")
+		}
+		w.checkpoint.Imported = true
+		if err := w.saveCheckpoint(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
@@ -458,14 +610,24 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Resources:          serialized.Resources,
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		Progress: checkpointProgress{w},
 	})
 	return errors.Annotate(err, "failed to migrate binaries")
 }
 
 func (w *Worker) doPROCESSRELATIONS(status coremigration.MigrationStatus) (coremigration.Phase, error) {
-	err := w.processRelations(status.TargetInfo, status.ModelUUID)
+	ok, err := w.runResumable(status, func() error {
+		err := w.processRelations(status.TargetInfo, status.ModelUUID)
+		if err != nil {
+			w.setErrorStatus("processing relations failed, %v", err)
+		}
+		return err
+	})
 	if err != nil {
-		w.setErrorStatus("processing relations failed, %v", err)
+		return coremigration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
 		return coremigration.ABORT, nil
 	}
 	return coremigration.VALIDATION, nil
@@ -604,6 +766,9 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 
 	if latestLogTime != utcZero {
 		w.logger.Debugf("log transfer was interrupted - restarting from %s", latestLogTime)
+		// The count carries on only so that the progress reported
+		// covers the records sent before the interruption.
+		sent = w.checkpoint.LogsSent
 	}
 
 	throwWrench := latestLogTime == utcZero && wrench.IsActive("migrationmaster", "die-after-500-log-messages")
@@ -655,6 +820,11 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 			}
 		case <-logProgress:
 			reportProgress(false, sent)
+			w.checkpoint.LogsSent = sent
+			if err := w.saveCheckpoint(); err != nil {
+				// The count is only used for progress reporting.
+				w.logger.Warningf("%v", err)
+			}
 			logProgress = clk.After(progressUpdateInterval)
 		}
 	}
@@ -146,6 +146,13 @@ var (
 		apiCloseCall,
 		{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
 	}
+	waitForAbortCalls = func(phase coremigration.Phase) []jujutesting.StubCall {
+		return []jujutesting.StubCall{
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{FailedPhase: phase}}},
+			{"facade.WatchStatus", nil},
+			{"facade.MigrationStatus", nil},
+		}
+	}
 	openDestLogStreamCall = jujutesting.StubCall{FuncName: "ConnectControllerStream", Args: []interface{}{
 		"/migrate/logtransfer",
 		url.Values{},
@@ -249,6 +256,7 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			{"facade.Export", nil},
 			apiOpenControllerCall,
 			importCall,
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{Imported: true}}},
 			{"UploadBinaries", []interface{}{
 				[]string{"charm0", "charm1"},
 				fakeCharmDownloader,
@@ -541,6 +549,7 @@ func (s *Suite) TestQUIESCETargetChecksFail(c *gc.C) {
 func (s *Suite) TestProcessRelationsFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.PROCESSRELATIONS))
 	s.facade.processRelationsErr = errors.New("boom")
+	s.facade.queueStatusChange(s.makeStatus(coremigration.ABORT))
 
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
@@ -548,14 +557,25 @@ func (s *Suite) TestProcessRelationsFailure(c *gc.C) {
 		[]jujutesting.StubCall{
 			{"facade.MinionReportTimeout", nil},
 			{"facade.ProcessRelations", []interface{}{""}},
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{
+				FailedPhase: coremigration.PROCESSRELATIONS,
+			}}},
+			{"facade.WatchStatus", nil},
+			{"facade.MigrationStatus", nil},
 		},
 		abortCalls,
 	))
+	c.Assert(s.facade.statuses[1:], jc.DeepEquals, []string{
+		"processing relations failed, processing relations failed: boom",
+		`processing relations failed, processing relations failed: boom, use "juju migrate --resume" within 1h0m0s to retry or "juju migrate --abort" to abort`,
+		"aborted, removing model from target controller: processing relations failed, processing relations failed: boom",
+	})
 }
 
 func (s *Suite) TestExportFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.facade.exportErr = errors.New("boom")
+	s.facade.queueStatusChange(s.makeStatus(coremigration.ABORT))
 
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
@@ -564,6 +584,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 			{"facade.MinionReportTimeout", nil},
 			{"facade.Export", nil},
 		},
+		waitForAbortCalls(coremigration.IMPORT),
 		abortCalls,
 	))
 }
@@ -571,6 +592,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.connectionErr = errors.New("boom")
+	s.facade.queueStatusChange(s.makeStatus(coremigration.ABORT))
 
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
@@ -579,6 +601,9 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 			{"facade.MinionReportTimeout", nil},
 			{"facade.Export", nil},
 			apiOpenControllerCall,
+		},
+		waitForAbortCalls(coremigration.IMPORT),
+		[]jujutesting.StubCall{
 			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
 			apiOpenControllerCall,
 			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
@@ -589,6 +614,62 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 func (s *Suite) TestImportFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.connection.importErr = errors.New("boom")
+	s.facade.queueStatusChange(s.makeStatus(coremigration.ABORT))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		waitForAbortCalls(coremigration.IMPORT),
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestSetCheckpointFailure(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.exportErr = errors.New("boom")
+	s.facade.setCheckpointErr = errors.New("kaboom")
+
+	// The migration can't be resumed without a checkpoint, so it is
+	// aborted straight away.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{
+				FailedPhase: coremigration.IMPORT,
+			}}},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportResumed(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	uploadErrs := []error{errors.New("boom"), nil}
+	s.config.UploadBinaries = func(migration.UploadBinariesConfig) error {
+		s.stub.AddCall("UploadBinaries")
+		err := uploadErrs[0]
+		uploadErrs = uploadErrs[1:]
+		return err
+	}
+	// The user resumes the migration, which clears the failed phase
+	// but retains the rest of the checkpoint.
+	resumed := s.makeStatus(coremigration.IMPORT)
+	resumed.Checkpoint = coremigration.Checkpoint{Imported: true}
+	s.facade.queueStatusChange(resumed)
+
+	// Then gives up when processing relations fails.
+	s.facade.processRelationsErr = errors.New("boom")
+	s.facade.queueStatusChange(s.makeStatus(coremigration.ABORT))
 
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
@@ -598,12 +679,120 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 			{"facade.Export", nil},
 			apiOpenControllerCall,
 			importCall,
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{Imported: true}}},
+			{"UploadBinaries", nil},
+			apiCloseCall,
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{
+				FailedPhase: coremigration.IMPORT,
+				Imported:    true,
+			}}},
+			{"facade.WatchStatus", nil},
+			{"facade.MigrationStatus", nil},
+
+			// The model isn't imported again.
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			{"UploadBinaries", nil},
 			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.PROCESSRELATIONS}},
+
+			{"facade.ProcessRelations", []interface{}{""}},
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{
+				FailedPhase: coremigration.PROCESSRELATIONS,
+				Imported:    true,
+			}}},
+			{"facade.WatchStatus", nil},
+			{"facade.MigrationStatus", nil},
 		},
 		abortCalls,
 	))
 }
 
+func (s *Suite) TestFailedPhaseWaitsForResume(c *gc.C) {
+	// The worker was restarted while waiting for a failed phase to
+	// be resumed.
+	status := s.makeStatus(coremigration.PROCESSRELATIONS)
+	status.Checkpoint = coremigration.Checkpoint{
+		FailedPhase: coremigration.PROCESSRELATIONS,
+		Imported:    true,
+	}
+	s.facade.queueStatus(status)
+	s.facade.queueStatusChange(status)
+	resumed := s.makeStatus(coremigration.PROCESSRELATIONS)
+	resumed.Checkpoint = coremigration.Checkpoint{Imported: true}
+	s.facade.queueStatusChange(resumed)
+
+	w, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"facade.Watch",
+		"facade.MigrationStatus",
+		"guard.Lockdown",
+		"facade.MinionReportTimeout",
+		"facade.WatchStatus",
+		"facade.MigrationStatus",
+		"facade.MigrationStatus",
+		"facade.ProcessRelations",
+		"facade.SetPhase",
+		"facade.WatchMinionReports",
+	})
+	c.Assert(s.facade.statuses[0], gc.Equals,
+		`processrelations failed, use "juju migrate --resume" within 1h0m0s to retry or "juju migrate --abort" to abort`)
+}
+
+func (s *Suite) TestFailedPhaseNotResumed(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.PROCESSRELATIONS))
+	s.facade.processRelationsErr = errors.New("boom")
+
+	w, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// The migration is aborted once the resume timeout expires.
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for clock.After call")
+	}
+	s.clock.Advance(time.Hour)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(errors.Cause(err), gc.Equals, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.ProcessRelations", []interface{}{""}},
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{
+				FailedPhase: coremigration.PROCESSRELATIONS,
+			}}},
+			{"facade.WatchStatus", nil},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestFailedPhaseMigrationChanged(c *gc.C) {
+	status := s.makeStatus(coremigration.IMPORT)
+	status.Checkpoint = coremigration.Checkpoint{FailedPhase: coremigration.IMPORT}
+	s.facade.queueStatus(status)
+	other := s.makeStatus(coremigration.QUIESCE)
+	other.MigrationId = "model-uuid:3"
+	s.facade.queueStatusChange(other)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.WatchStatus", nil},
+			{"facade.MigrationStatus", nil},
+		},
+	))
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1070,6 +1259,47 @@ func (s *Suite) TestLogTransferReportsProgress(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestLogTransferResumesProgress(c *gc.C) {
+	status := s.makeStatus(coremigration.LOGTRANSFER)
+	status.Checkpoint = coremigration.Checkpoint{LogsSent: 40}
+	s.facade.queueStatus(status)
+	s.connection.latestLogTime = time.Date(2016, 12, 2, 10, 39, 10, 20, time.UTC)
+	s.facade.logMessages = func(d chan<- common.LogMessage) {
+		safeSend(c, d, common.LogMessage{Message: "lambchop"})
+		c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+		// Wait for the progress to be recorded before finishing.
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			names := stubCallNames(s.stub)
+			if names[len(names)-1] == "facade.SetCheckpoint" {
+				break
+			}
+		}
+		safeSend(c, d, common.LogMessage{Message: "low"})
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{s.connection.latestLogTime}},
+			openDestLogStreamCall,
+			{"facade.SetCheckpoint", []interface{}{coremigration.Checkpoint{LogsSent: 41}}},
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+	c.Assert(s.facade.statuses, jc.DeepEquals, []string{
+		"successful, transferring logs to target controller (0 sent)",
+		"successful, transferring logs to target controller (41 sent)",
+		"successful, transferred logs to target controller (42 sent)",
+		"successful, removing model from source controller",
+	})
+}
+
 func (s *Suite) TestLogTransfer_ChecksLatestTime(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.LOGTRANSFER))
 	t := time.Date(2016, 12, 2, 10, 39, 10, 20, time.UTC)
@@ -1197,6 +1427,7 @@ func newStubMasterFacade(stub *jujutesting.Stub) *stubMasterFacade {
 	return &stubMasterFacade{
 		stub:           stub,
 		watcherChanges: make(chan struct{}, 999),
+		statusChanges:  make(chan struct{}, 999),
 
 		// Give minionReportsChanges a larger-than-required buffer to
 		// support waits at a number of phases.
@@ -1215,6 +1446,9 @@ type stubMasterFacade struct {
 	status         []coremigration.MigrationStatus
 	statusErr      error
 
+	statusChanges    chan struct{}
+	setCheckpointErr error
+
 	prechecksErr        error
 	modelInfoErr        error
 	exportErr           error
@@ -1247,6 +1481,17 @@ func (f *stubMasterFacade) queueStatus(status coremigration.MigrationStatus) {
 	f.triggerWatcher()
 }
 
+// queueStatusChange queues a status to be reported after a change is
+// seen by the migration status watcher.
+func (f *stubMasterFacade) queueStatusChange(status coremigration.MigrationStatus) {
+	f.status = append(f.status, status)
+	select {
+	case f.statusChanges <- struct{}{}:
+	default:
+		panic("migration status watcher channel unexpectedly closed")
+	}
+}
+
 func (f *stubMasterFacade) triggerMinionReports() {
 	select {
 	case f.minionReportsChanges <- struct{}{}:
@@ -1364,6 +1609,16 @@ func (f *stubMasterFacade) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (f *stubMasterFacade) WatchStatus() (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("facade.WatchStatus")
+	return newMockWatcher(f.statusChanges), nil
+}
+
+func (f *stubMasterFacade) SetCheckpoint(checkpoint coremigration.Checkpoint) error {
+	f.stub.AddCall("facade.SetCheckpoint", checkpoint)
+	return f.setCheckpointErr
+}
+
 func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	f.statuses = append(f.statuses, message)
 	return nil
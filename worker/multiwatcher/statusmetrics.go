@@ -0,0 +1,146 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package multiwatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/state"
+)
+
+const (
+	statusMetricsNamespace = "juju_status"
+
+	modelUUIDLabel   = "model_uuid"
+	modelNameLabel   = "model"
+	applicationLabel = "application"
+	unitLabel        = "unit"
+	machineLabel     = "machine"
+	statusLabel      = "status"
+)
+
+var (
+	modelStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "model"),
+		"The status of each model; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, statusLabel}, nil,
+	)
+	applicationStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "application"),
+		"The status of each application; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, applicationLabel, statusLabel}, nil,
+	)
+	unitWorkloadStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "unit_workload"),
+		"The workload status of each unit; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, applicationLabel, unitLabel, statusLabel}, nil,
+	)
+	unitAgentStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "unit_agent"),
+		"The agent status of each unit; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, applicationLabel, unitLabel, statusLabel}, nil,
+	)
+	machineAgentStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "machine_agent"),
+		"The agent status of each machine; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, machineLabel, statusLabel}, nil,
+	)
+	machineInstanceStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "machine_instance"),
+		"The instance status of each machine; the value is always 1.",
+		[]string{modelUUIDLabel, modelNameLabel, machineLabel, statusLabel}, nil,
+	)
+	relationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "relations"),
+		"The number of relations in each model.",
+		[]string{modelUUIDLabel, modelNameLabel}, nil,
+	)
+	pendingActionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statusMetricsNamespace, "", "pending_actions"),
+		"The number of actions waiting to run in each model.",
+		[]string{modelUUIDLabel, modelNameLabel}, nil,
+	)
+)
+
+// StatusCollector is a prometheus.Collector that publishes the status
+// of the entities in every model, as held by the multiwatcher store.
+//
+// Each status metric has the value 1, with the status as a label, so
+// alerts can match on entities in a given status, for example:
+//
+//	juju_status_unit_workload{status="blocked"} == 1
+type StatusCollector struct {
+	worker *Worker
+}
+
+// NewStatusCollector returns a new StatusCollector.
+func NewStatusCollector(worker *Worker) *StatusCollector {
+	return &StatusCollector{worker: worker}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *StatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- modelStatusDesc
+	ch <- applicationStatusDesc
+	ch <- unitWorkloadStatusDesc
+	ch <- unitAgentStatusDesc
+	ch <- machineAgentStatusDesc
+	ch <- machineInstanceStatusDesc
+	ch <- relationsDesc
+	ch <- pendingActionsDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *StatusCollector) Collect(ch chan<- prometheus.Metric) {
+	entities := c.worker.allEntities()
+
+	// Models are needed first, to label the other entities with the
+	// model name.
+	modelNames := make(map[string]string)
+	relations := make(map[string]int)
+	pendingActions := make(map[string]int)
+	for _, entity := range entities {
+		if info, ok := entity.(*multiwatcher.ModelInfo); ok {
+			modelNames[info.ModelUUID] = info.Name
+			relations[info.ModelUUID] = 0
+			pendingActions[info.ModelUUID] = 0
+			ch <- prometheus.MustNewConstMetric(modelStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, info.Name, string(info.Status.Current))
+		}
+	}
+
+	for _, entity := range entities {
+		switch info := entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			ch <- prometheus.MustNewConstMetric(applicationStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, modelNames[info.ModelUUID], info.Name, string(info.Status.Current))
+		case *multiwatcher.UnitInfo:
+			ch <- prometheus.MustNewConstMetric(unitWorkloadStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, modelNames[info.ModelUUID], info.Application, info.Name, string(info.WorkloadStatus.Current))
+			ch <- prometheus.MustNewConstMetric(unitAgentStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, modelNames[info.ModelUUID], info.Application, info.Name, string(info.AgentStatus.Current))
+		case *multiwatcher.MachineInfo:
+			ch <- prometheus.MustNewConstMetric(machineAgentStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, modelNames[info.ModelUUID], info.ID, string(info.AgentStatus.Current))
+			ch <- prometheus.MustNewConstMetric(machineInstanceStatusDesc, prometheus.GaugeValue, 1,
+				info.ModelUUID, modelNames[info.ModelUUID], info.ID, string(info.InstanceStatus.Current))
+		case *multiwatcher.RelationInfo:
+			relations[info.ModelUUID]++
+		case *multiwatcher.ActionInfo:
+			if info.Status == string(state.ActionPending) {
+				pendingActions[info.ModelUUID]++
+			}
+		}
+	}
+
+	for modelUUID, count := range relations {
+		ch <- prometheus.MustNewConstMetric(relationsDesc, prometheus.GaugeValue, float64(count),
+			modelUUID, modelNames[modelUUID])
+	}
+	for modelUUID, count := range pendingActions {
+		ch <- prometheus.MustNewConstMetric(pendingActionsDesc, prometheus.GaugeValue, float64(count),
+			modelUUID, modelNames[modelUUID])
+	}
+}
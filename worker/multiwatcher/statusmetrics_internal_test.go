@@ -0,0 +1,124 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package multiwatcher
+
+import (
+	"strings"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/core/status"
+)
+
+type statusMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&statusMetricsSuite{})
+
+func (*statusMetricsSuite) TestCollect(c *gc.C) {
+	w := &Worker{
+		store: multiwatcher.NewStore(loggo.GetLogger("test.store")),
+	}
+	for _, info := range []multiwatcher.EntityInfo{
+		&multiwatcher.ModelInfo{
+			ModelUUID: "uuid-1",
+			Name:      "prod",
+			Status:    multiwatcher.StatusInfo{Current: status.Available},
+		},
+		&multiwatcher.ApplicationInfo{
+			ModelUUID: "uuid-1",
+			Name:      "mysql",
+			Status:    multiwatcher.StatusInfo{Current: status.Active},
+		},
+		&multiwatcher.UnitInfo{
+			ModelUUID:      "uuid-1",
+			Name:           "mysql/0",
+			Application:    "mysql",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.Blocked},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		},
+		&multiwatcher.MachineInfo{
+			ModelUUID:      "uuid-1",
+			ID:             "0",
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Started},
+			InstanceStatus: multiwatcher.StatusInfo{Current: status.Running},
+		},
+		&multiwatcher.RelationInfo{
+			ModelUUID: "uuid-1",
+			Key:       "mysql:cluster",
+		},
+		&multiwatcher.ActionInfo{
+			ModelUUID: "uuid-1",
+			ID:        "1",
+			Receiver:  "mysql/0",
+			Status:    "pending",
+		},
+		&multiwatcher.ActionInfo{
+			ModelUUID: "uuid-1",
+			ID:        "2",
+			Receiver:  "mysql/0",
+			Status:    "running",
+		},
+	} {
+		w.store.Update(info)
+	}
+
+	expected := `
+# HELP juju_status_application The status of each application; the value is always 1.
+# TYPE juju_status_application gauge
+juju_status_application{application="mysql",model="prod",model_uuid="uuid-1",status="active"} 1
+# HELP juju_status_machine_agent The agent status of each machine; the value is always 1.
+# TYPE juju_status_machine_agent gauge
+juju_status_machine_agent{machine="0",model="prod",model_uuid="uuid-1",status="started"} 1
+# HELP juju_status_machine_instance The instance status of each machine; the value is always 1.
+# TYPE juju_status_machine_instance gauge
+juju_status_machine_instance{machine="0",model="prod",model_uuid="uuid-1",status="running"} 1
+# HELP juju_status_model The status of each model; the value is always 1.
+# TYPE juju_status_model gauge
+juju_status_model{model="prod",model_uuid="uuid-1",status="available"} 1
+# HELP juju_status_pending_actions The number of actions waiting to run in each model.
+# TYPE juju_status_pending_actions gauge
+juju_status_pending_actions{model="prod",model_uuid="uuid-1"} 1
+# HELP juju_status_relations The number of relations in each model.
+# TYPE juju_status_relations gauge
+juju_status_relations{model="prod",model_uuid="uuid-1"} 1
+# HELP juju_status_unit_agent The agent status of each unit; the value is always 1.
+# TYPE juju_status_unit_agent gauge
+juju_status_unit_agent{application="mysql",model="prod",model_uuid="uuid-1",status="idle",unit="mysql/0"} 1
+# HELP juju_status_unit_workload The workload status of each unit; the value is always 1.
+# TYPE juju_status_unit_workload gauge
+juju_status_unit_workload{application="mysql",model="prod",model_uuid="uuid-1",status="blocked",unit="mysql/0"} 1
+`
+	err := testutil.CollectAndCompare(NewStatusCollector(w), strings.NewReader(expected))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*statusMetricsSuite) TestCollectEmptyModel(c *gc.C) {
+	w := &Worker{
+		store: multiwatcher.NewStore(loggo.GetLogger("test.store")),
+	}
+	w.store.Update(&multiwatcher.ModelInfo{
+		ModelUUID: "uuid-1",
+		Name:      "empty",
+		Status:    multiwatcher.StatusInfo{Current: status.Available},
+	})
+
+	expected := `
+# HELP juju_status_pending_actions The number of actions waiting to run in each model.
+# TYPE juju_status_pending_actions gauge
+juju_status_pending_actions{model="empty",model_uuid="uuid-1"} 0
+# HELP juju_status_relations The number of relations in each model.
+# TYPE juju_status_relations gauge
+juju_status_relations{model="empty",model_uuid="uuid-1"} 0
+`
+	err := testutil.CollectAndCompare(NewStatusCollector(w), strings.NewReader(expected),
+		"juju_status_pending_actions", "juju_status_relations")
+	c.Assert(err, jc.ErrorIsNil)
+}
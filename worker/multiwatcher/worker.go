@@ -48,8 +48,9 @@ func (config Config) Validate() error {
 type Worker struct {
 	config Config
 
-	tomb    tomb.Tomb
-	metrics *Collector
+	tomb          tomb.Tomb
+	metrics       *Collector
+	statusMetrics *StatusCollector
 
 	// store holds information about all known entities.
 	store multiwatcher.Store
@@ -133,6 +134,7 @@ func NewWorker(config Config) (*Worker, error) {
 		closed:  closed,
 	}
 	w.metrics = NewMetricsCollector(w)
+	w.statusMetrics = NewStatusCollector(w)
 	w.tomb.Go(w.loop)
 	return w, nil
 }
@@ -182,6 +184,13 @@ func (w *Worker) Report() map[string]interface{} {
 	return report
 }
 
+// allEntities returns the entities currently held in the store.
+func (w *Worker) allEntities() []multiwatcher.EntityInfo {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.store.All()
+}
+
 // WatchController returns entity delta events for all models in the controller.
 func (w *Worker) WatchController() multiwatcher.Watcher {
 	return w.newWatcher(nil)
@@ -231,6 +240,8 @@ func (w *Worker) loop() error {
 
 	_ = w.config.PrometheusRegisterer.Register(w.metrics)
 	defer w.config.PrometheusRegisterer.Unregister(w.metrics)
+	_ = w.config.PrometheusRegisterer.Register(w.statusMetrics)
+	defer w.config.PrometheusRegisterer.Unregister(w.statusMetrics)
 
 	for {
 		err := w.inner()
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/version/v2"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/catacomb"
+	"go.opentelemetry.io/otel/attribute"
 
 	apiprovisioner "github.com/juju/juju/api/agent/provisioner"
 	"github.com/juju/juju/cloudconfig/instancecfg"
@@ -32,6 +33,7 @@ import (
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/workerpool"
 	"github.com/juju/juju/environs"
@@ -1316,6 +1318,11 @@ func (task *provisionerTask) doStartMachine(
 	distributionGroupMachineIds []string,
 	pInfoResult params.ProvisioningInfoResult,
 ) (startErr error) {
+	spanCtx, span := trace.Start(ctx, "provisioner.start-machine",
+		attribute.String("juju.machine", machine.Id()),
+	)
+	defer func() { trace.End(span, startErr) }()
+
 	defer func() {
 		if startErr == nil {
 			return
@@ -1375,7 +1382,12 @@ func (task *provisionerTask) doStartMachine(
 				machine, startInstanceParams.AvailabilityZone)
 		}
 
+		_, attemptSpan := trace.Start(spanCtx, "provisioner.start-instance",
+			attribute.String("juju.availability-zone", startInstanceParams.AvailabilityZone),
+			attribute.Int("juju.attempts-left", attemptsLeft),
+		)
 		attemptResult, err := task.broker.StartInstance(ctx, startInstanceParams)
+		trace.End(attemptSpan, err)
 		if err == nil {
 			result = attemptResult
 			break
@@ -1455,7 +1467,11 @@ func (task *provisionerTask) doStartMachine(
 		return errors.Trace(err)
 	}
 
-	if err := machine.SetInstanceInfo(
+	infoCtx, infoSpan := trace.Start(spanCtx, "provisioner.set-instance-info",
+		attribute.String("juju.instance-id", string(instanceID)),
+	)
+	err = machine.SetInstanceInfo(
+		infoCtx,
 		instanceID,
 		result.DisplayName,
 		startInstanceParams.InstanceConfig.MachineNonce,
@@ -1464,7 +1480,9 @@ func (task *provisionerTask) doStartMachine(
 		volumes,
 		volumeNameToAttachmentInfo,
 		charmLXDProfiles,
-	); err != nil {
+	)
+	trace.End(infoSpan, err)
+	if err != nil {
 		// We need to stop the instance right away here, set error status and go on.
 		if err2 := task.setErrorStatus("cannot register instance for machine %v: %v", machine, err); err2 != nil {
 			task.logger.Errorf("%v", errors.Annotate(err2, "setting machine status"))
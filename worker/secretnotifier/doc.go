@@ -0,0 +1,33 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretnotifier provides a worker that posts secret expiry and
+// rotation events, for the secrets in every model, to the webhook set
+// by the secret-notify-webhook-url controller config attribute. This
+// lets rotation SLAs be tracked outside of Juju; the secretexpire and
+// secretrotate workers only notify the charms owning the secrets.
+//
+// Three kinds of event are posted, each as a JSON document:
+//   - expiry-approaching, when a secret revision is due to expire
+//     within secret-notify-expiry-warning;
+//   - expired, when a secret revision has expired;
+//   - rotated, when a secret revision has been created because the
+//     secret was due to be rotated.
+//
+// The worker runs a worker for each alive model, which is driven by the
+// model's secret revision expiry and secret rotation watchers, the same
+// watchers used by the secretexpire and secretrotate workers but for the
+// secrets of every owner. Expiry events are posted when a revision's
+// expiry time, or the warning before it, is reached; rotated events are
+// posted when a rotated secret's next rotation time is moved on. If the
+// webhook fails, the events are posted again a minute later. Changes to
+// the secret-notify-* controller config attributes restart the worker.
+//
+// Events are delivered at least once. Each event has an ID which is
+// the same every time it is posted, so the receiver can discard any
+// duplicates, which are sent when the worker restarts. Expired and
+// rotated events are only reported for a day after they happen, which
+// bounds the duplicates sent on restart.
+//
+// The worker runs on the primary controller only.
+package secretnotifier
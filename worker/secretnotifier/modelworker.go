@@ -0,0 +1,359 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretnotifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/worker/v3/catacomb"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// revisionExpiry records the expiry of a secret revision, and which
+// of its events have been posted.
+type revisionExpiry struct {
+	uri        *coresecrets.URI
+	revision   int
+	expireTime time.Time
+
+	approachingQueued bool
+	expiredQueued     bool
+}
+
+// modelWorker posts the events for the secrets in a model. It is
+// driven by the secret expiry and rotation watchers, and wakes up when
+// a revision is due to expire or is within the expiry warning of doing
+// so.
+type modelWorker struct {
+	catacomb  catacomb.Catacomb
+	config    Config
+	settings  settings
+	modelUUID string
+	model     ModelSecrets
+
+	// expiries holds the revisions due to expire, keyed on
+	// secret ID and revision.
+	expiries map[string]*revisionExpiry
+
+	// rotated holds the latest rotated revision of each secret
+	// which has been queued, keyed on secret ID.
+	rotated map[string]int
+
+	// pending holds the events waiting to be posted, in order.
+	pending []Event
+
+	// retryTime is when to try posting the pending events again
+	// after the webhook failed.
+	retryTime time.Time
+
+	timer       clock.Timer
+	nextTrigger time.Time
+}
+
+func newModelWorker(config Config, s settings, modelUUID string, model ModelSecrets) (*modelWorker, error) {
+	w := &modelWorker{
+		config:    config,
+		settings:  s,
+		modelUUID: modelUUID,
+		model:     model,
+		expiries:  make(map[string]*revisionExpiry),
+		rotated:   make(map[string]int),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *modelWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *modelWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *modelWorker) loop() error {
+	defer w.model.Release()
+
+	expiryWatcher, err := w.model.WatchSecretRevisionsExpiryChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(expiryWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	rotateWatcher, err := w.model.WatchSecretsRotationChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(rotateWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		var timeout <-chan time.Time
+		if w.timer != nil {
+			timeout = w.timer.Chan()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case changes, ok := <-expiryWatcher.Changes():
+			if !ok {
+				return errors.New("secret revision expiry watcher closed")
+			}
+			w.expiryChanged(changes)
+		case changes, ok := <-rotateWatcher.Changes():
+			if !ok {
+				return errors.New("secret rotation watcher closed")
+			}
+			if err := w.rotationChanged(changes, w.config.Clock.Now()); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timeout:
+			w.nextTrigger = time.Time{}
+		}
+
+		now := w.config.Clock.Now()
+		if err := w.queueExpiryEvents(now); err != nil {
+			return errors.Trace(err)
+		}
+		if !now.Before(w.retryTime) {
+			w.postPending(now)
+		}
+		w.resetTimer(now)
+	}
+}
+
+func expiryKey(uri *coresecrets.URI, revision int) string {
+	return fmt.Sprintf("%s/%d", uri.ID, revision)
+}
+
+func (w *modelWorker) expiryChanged(changes []watcher.SecretTriggerChange) {
+	for _, ch := range changes {
+		key := expiryKey(ch.URI, ch.Revision)
+		// A zero trigger time means the revision no
+		// longer expires.
+		if ch.NextTriggerTime.IsZero() {
+			delete(w.expiries, key)
+			continue
+		}
+		if e, ok := w.expiries[key]; ok && e.expireTime.Equal(ch.NextTriggerTime) {
+			continue
+		}
+		w.expiries[key] = &revisionExpiry{
+			uri:        ch.URI,
+			revision:   ch.Revision,
+			expireTime: ch.NextTriggerTime,
+		}
+	}
+}
+
+// rotationChanged queues the rotated events for the secrets whose next
+// rotation time has changed. Once a secret has been rotated, its owner
+// moves the next rotation time on, by which time the revision created
+// by the rotation exists.
+func (w *modelWorker) rotationChanged(changes []watcher.SecretTriggerChange, now time.Time) error {
+	since := now.Add(-eventLookback)
+	for _, ch := range changes {
+		if ch.NextTriggerTime.IsZero() {
+			delete(w.rotated, ch.URI.ID)
+			continue
+		}
+		revisions, err := w.model.ListSecretRevisions(ch.URI)
+		if errors.Is(err, errors.NotFound) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "listing revisions of secret %q", ch.URI)
+		}
+		md, err := w.model.GetSecret(ch.URI)
+		if errors.Is(err, errors.NotFound) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting secret %q", ch.URI)
+		}
+		due := ch.NextTriggerTime
+		for _, rev := range revisions {
+			if !rev.Rotated || rev.Revision <= w.rotated[ch.URI.ID] {
+				continue
+			}
+			w.rotated[ch.URI.ID] = rev.Revision
+			if rev.CreateTime.After(since) {
+				w.pending = append(w.pending, w.newEvent(md, EventRotated, rev.Revision, &due, rev.CreateTime))
+			}
+		}
+	}
+	return nil
+}
+
+// queueExpiryEvents queues the expiry events which are due.
+func (w *modelWorker) queueExpiryEvents(now time.Time) error {
+	keys := make([]string, 0, len(w.expiries))
+	for key := range w.expiries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	since := now.Add(-eventLookback)
+	for _, key := range keys {
+		e := w.expiries[key]
+		var (
+			eventType string
+			at        time.Time
+		)
+		switch {
+		case e.expiredQueued:
+			continue
+		case !now.Before(e.expireTime):
+			e.approachingQueued = true
+			e.expiredQueued = true
+			if !e.expireTime.After(since) {
+				continue
+			}
+			eventType, at = EventExpired, e.expireTime
+		case !e.approachingQueued && !now.Before(e.expireTime.Add(-w.settings.expiryWarning)):
+			e.approachingQueued = true
+			eventType, at = EventExpiryApproaching, now
+		default:
+			continue
+		}
+		md, err := w.model.GetSecret(e.uri)
+		if errors.Is(err, errors.NotFound) {
+			delete(w.expiries, key)
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting secret %q", e.uri)
+		}
+		due := e.expireTime
+		w.pending = append(w.pending, w.newEvent(md, eventType, e.revision, &due, at))
+	}
+	return nil
+}
+
+func (w *modelWorker) newEvent(md *coresecrets.SecretMetadata, eventType string, revision int, due *time.Time, at time.Time) Event {
+	return Event{
+		ID:             fmt.Sprintf("%s/%d/%s", md.URI.ID, revision, eventType),
+		Type:           eventType,
+		ControllerUUID: w.config.ControllerUUID,
+		ModelUUID:      w.modelUUID,
+		ModelName:      w.model.ModelName(),
+		URI:            md.URI.String(),
+		Revision:       revision,
+		Owner:          md.OwnerTag,
+		Label:          md.Label,
+		Due:            due,
+		Time:           at,
+	}
+}
+
+// postPending posts the pending events in order. If the webhook fails
+// to accept one, it and the rest are left until the retry period has
+// passed.
+func (w *modelWorker) postPending(now time.Time) {
+	ctx := w.catacomb.Context(context.Background())
+	for len(w.pending) > 0 {
+		event := w.pending[0]
+		if err := w.postWithRetry(ctx, event); err != nil {
+			w.config.Logger.Warningf("unable to post secret event %q, retrying in %v: %v", event.ID, retryPeriod, err)
+			w.retryTime = now.Add(retryPeriod)
+			return
+		}
+		w.config.Logger.Debugf("posted secret event %q", event.ID)
+		w.pending = w.pending[1:]
+	}
+	w.pending = nil
+}
+
+// resetTimer sets the timer for the next expiry event, or retry.
+func (w *modelWorker) resetTimer(now time.Time) {
+	var next time.Time
+	earliest := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	for _, e := range w.expiries {
+		if !e.approachingQueued {
+			earliest(e.expireTime.Add(-w.settings.expiryWarning))
+		} else if !e.expiredQueued {
+			earliest(e.expireTime)
+		}
+	}
+	if len(w.pending) > 0 {
+		earliest(w.retryTime)
+	}
+	if next.IsZero() || next.Equal(w.nextTrigger) {
+		return
+	}
+	w.nextTrigger = next
+
+	d := next.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	if w.timer == nil {
+		w.timer = w.config.Clock.NewTimer(d)
+		return
+	}
+	// See the docs on Timer.Reset(); make an attempt to drain the
+	// channel if the timer had already fired.
+	if !w.timer.Stop() {
+		select {
+		case <-w.timer.Chan():
+		default:
+		}
+	}
+	w.timer.Reset(d)
+}
+
+func (w *modelWorker) postWithRetry(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return retry.Call(retry.CallArgs{
+		Func: func() error {
+			return w.post(ctx, body)
+		},
+		Attempts:    retryAttempts,
+		Delay:       retryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       w.config.Clock,
+		Stop:        w.catacomb.Dying(),
+	})
+}
+
+func (w *modelWorker) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.settings.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretnotifier
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// stateShim provides the models in the state pool and their secrets.
+type stateShim struct {
+	pool *state.StatePool
+	st   *state.State
+}
+
+// ControllerConfig is part of SecretsState.
+func (s *stateShim) ControllerConfig() (controller.Config, error) {
+	return s.st.ControllerConfig()
+}
+
+// WatchControllerConfig is part of SecretsState.
+func (s *stateShim) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	return notifyWatcher{s.st.WatchControllerConfig()}, nil
+}
+
+// WatchModels is part of SecretsState.
+func (s *stateShim) WatchModels() (watcher.StringsWatcher, error) {
+	return stringsWatcher{s.st.WatchModels()}, nil
+}
+
+// ModelSecrets is part of SecretsState.
+func (s *stateShim) ModelSecrets(modelUUID string) (ModelSecrets, error) {
+	model, ph, err := s.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name, life := model.Name(), model.Life()
+	ph.Release()
+	if life != state.Alive {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	st, err := s.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelShim{
+		SecretsStore: state.NewSecrets(st.State),
+		st:           st,
+		name:         name,
+	}, nil
+}
+
+// modelShim provides the secrets in a model.
+type modelShim struct {
+	state.SecretsStore
+	st   *state.PooledState
+	name string
+}
+
+// ModelName is part of ModelSecrets.
+func (m *modelShim) ModelName() string {
+	return m.name
+}
+
+// WatchSecretRevisionsExpiryChanges is part of ModelSecrets.
+func (m *modelShim) WatchSecretRevisionsExpiryChanges() (watcher.SecretTriggerWatcher, error) {
+	return m.st.WatchAllSecretRevisionsExpiryChanges(), nil
+}
+
+// WatchSecretsRotationChanges is part of ModelSecrets.
+func (m *modelShim) WatchSecretsRotationChanges() (watcher.SecretTriggerWatcher, error) {
+	return m.st.WatchAllSecretsRotationChanges(), nil
+}
+
+// Release is part of ModelSecrets.
+func (m *modelShim) Release() {
+	_ = m.st.Release()
+}
+
+type notifyWatcher struct {
+	state.NotifyWatcher
+}
+
+func (w notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.NotifyWatcher.Changes()
+}
+
+type stringsWatcher struct {
+	state.StringsWatcher
+}
+
+func (w stringsWatcher) Changes() watcher.StringsChannel {
+	return w.StringsWatcher.Changes()
+}
@@ -0,0 +1,324 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretnotifier
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+	"github.com/juju/worker/v3/dependency"
+
+	"github.com/juju/juju/controller"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead use the one passed as manifold config.
+type logger interface{}
+
+var _ logger = struct{}{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+const (
+	// eventLookback is how long after a revision expires, or a
+	// rotated revision is created, that the event is reported. Events
+	// which happen while the worker isn't running are only reported
+	// if it starts again within this time. It also bounds the events
+	// posted again when the worker restarts, since the events already
+	// posted are only remembered while it runs.
+	eventLookback = 24 * time.Hour
+
+	// retryAttempts is the number of times an event is posted before
+	// giving up until the retry period has passed.
+	retryAttempts = 3
+
+	// retryDelay is the initial delay between attempts to post an
+	// event. The delay doubles on each subsequent attempt.
+	retryDelay = time.Second
+
+	// retryPeriod is how long to wait to post events again after
+	// the webhook has failed to accept one.
+	retryPeriod = time.Minute
+
+	// restartDelay is how long to wait to restart the worker for a
+	// model after it fails.
+	restartDelay = 10 * time.Second
+)
+
+// The kinds of event posted to the webhook.
+const (
+	EventExpiryApproaching = "expiry-approaching"
+	EventExpired           = "expired"
+	EventRotated           = "rotated"
+)
+
+// Event is posted to the webhook, as JSON, for each secret event.
+type Event struct {
+	// ID identifies the event, and is the same each time the
+	// event is posted.
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	ControllerUUID string `json:"controller-uuid"`
+	ModelUUID      string `json:"model-uuid"`
+	ModelName      string `json:"model-name"`
+
+	URI      string `json:"uri"`
+	Revision int    `json:"revision"`
+	Owner    string `json:"owner"`
+	Label    string `json:"label,omitempty"`
+
+	// Due is when the revision expires for expiry events, and when
+	// the secret is next due to be rotated for rotated events.
+	Due *time.Time `json:"due,omitempty"`
+
+	// Time is when the event happened: when it was first seen for
+	// expiry-approaching events, when the revision expired for
+	// expired events and when the revision was created for rotated
+	// events.
+	Time time.Time `json:"time"`
+}
+
+// SecretsState provides the models on the controller and their
+// secrets.
+type SecretsState interface {
+	// ControllerConfig returns the controller config.
+	ControllerConfig() (controller.Config, error)
+
+	// WatchControllerConfig returns a watcher for changes to the
+	// controller config.
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+
+	// WatchModels returns a watcher for the models on the controller.
+	WatchModels() (watcher.StringsWatcher, error)
+
+	// ModelSecrets returns the secrets in the model with the given
+	// UUID, or a NotFound error if the model isn't alive. The result
+	// must be released once it is no longer needed.
+	ModelSecrets(modelUUID string) (ModelSecrets, error)
+}
+
+// ModelSecrets provides the secrets in a model.
+type ModelSecrets interface {
+	// ModelName returns the name of the model.
+	ModelName() string
+
+	// WatchSecretRevisionsExpiryChanges returns a watcher for the
+	// expiry times of all the secret revisions in the model.
+	WatchSecretRevisionsExpiryChanges() (watcher.SecretTriggerWatcher, error)
+
+	// WatchSecretsRotationChanges returns a watcher for the next
+	// rotation times of all the secrets in the model.
+	WatchSecretsRotationChanges() (watcher.SecretTriggerWatcher, error)
+
+	// GetSecret returns the metadata of the secret.
+	GetSecret(uri *coresecrets.URI) (*coresecrets.SecretMetadata, error)
+
+	// ListSecretRevisions returns the metadata of the revisions of
+	// the secret.
+	ListSecretRevisions(uri *coresecrets.URI) ([]*coresecrets.SecretRevisionMetadata, error)
+
+	// Release releases the model's resources.
+	Release()
+}
+
+// HTTPClient is the subset of *http.Client used to post events.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	Clock          clock.Clock
+	Logger         Logger
+	ControllerUUID string
+	State          SecretsState
+	HTTPClient     HTTPClient
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.ControllerUUID == "" {
+		return errors.NotValidf("empty ControllerUUID")
+	}
+	if config.State == nil {
+		return errors.NotValidf("nil State")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	return nil
+}
+
+// settings are the controller config attributes used by the worker.
+type settings struct {
+	webhookURL    string
+	expiryWarning time.Duration
+}
+
+func settingsFromConfig(cfg controller.Config) settings {
+	return settings{
+		webhookURL:    cfg.SecretNotifyWebhookURL(),
+		expiryWarning: cfg.SecretNotifyExpiryWarning(),
+	}
+}
+
+// NewWorker returns a secret notifying Worker backed by config, or an
+// error.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		runner: worker.NewRunner(worker.RunnerParams{
+			IsFatal:       func(error) bool { return false },
+			MoreImportant: func(error, error) bool { return false },
+			RestartDelay:  restartDelay,
+			Clock:         config.Clock,
+			Logger:        config.Logger,
+		}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{w.runner},
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker posts secret expiry and rotation events to a webhook. It runs
+// a worker for each model, which watches the secrets in the model.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	runner   *worker.Runner
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Report shows up in the dependency engine report.
+func (w *Worker) Report() map[string]interface{} {
+	return w.runner.Report()
+}
+
+func (w *Worker) loop() error {
+	configWatcher, err := w.config.State.WatchControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		current      *settings
+		modelChanges watcher.StringsChannel
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			cfg, err := w.config.State.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "getting controller config")
+			}
+			s := settingsFromConfig(cfg)
+			if current != nil {
+				if s != *current {
+					// The model workers use the settings they
+					// were started with, so start again.
+					w.config.Logger.Infof("secret notification settings changed, restarting")
+					return dependency.ErrBounce
+				}
+				continue
+			}
+			current = &s
+			if s.webhookURL == "" {
+				w.config.Logger.Debugf("secret notifications are disabled")
+				continue
+			}
+			modelWatcher, err := w.config.State.WatchModels()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.catacomb.Add(modelWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			modelChanges = modelWatcher.Changes()
+		case uuids, ok := <-modelChanges:
+			if !ok {
+				return errors.New("model watcher closed")
+			}
+			for _, uuid := range uuids {
+				if err := w.modelChanged(uuid, *current); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+// modelChanged starts the worker for the model, or stops it if the
+// model is no longer alive.
+func (w *Worker) modelChanged(modelUUID string, s settings) error {
+	model, err := w.config.State.ModelSecrets(modelUUID)
+	if errors.Is(err, errors.NotFound) {
+		err := w.runner.StopAndRemoveWorker(modelUUID, w.catacomb.Dying())
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			w.config.Logger.Debugf("stopping secret notifier for model %q: %v", modelUUID, err)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	model.Release()
+
+	err = w.runner.StartWorker(modelUUID, func() (worker.Worker, error) {
+		model, err := w.config.State.ModelSecrets(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mw, err := newModelWorker(w.config, s, modelUUID, model)
+		if err != nil {
+			model.Release()
+			return nil, errors.Trace(err)
+		}
+		return mw, nil
+	})
+	if err != nil && !errors.Is(err, errors.AlreadyExists) {
+		return errors.Trace(err)
+	}
+	return nil
+}
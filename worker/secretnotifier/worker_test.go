@@ -0,0 +1,554 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretnotifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/dependency"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workerSuite struct {
+	clock  *testclock.Clock
+	state  *fakeState
+	client *fakeClient
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.state = newFakeState()
+	s.state.config[controller.SecretNotifyWebhookURL] = "https://example.com/secrets"
+	s.state.config[controller.SecretNotifyExpiryWarning] = time.Hour
+	s.client = &fakeClient{}
+}
+
+func (s *workerSuite) newWorker(c *gc.C) *Worker {
+	w, err := NewWorker(Config{
+		Clock:          s.clock,
+		Logger:         loggo.GetLogger("test"),
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		State:          s.state,
+		HTTPClient:     s.client,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w.(*Worker)
+}
+
+// waitIdle waits for the model worker to wait on the clock, and then
+// advances the clock by d.
+func (s *workerSuite) waitIdle(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) addSecret(id string, md coresecrets.SecretMetadata, revisions ...*coresecrets.SecretRevisionMetadata) *coresecrets.URI {
+	uri := &coresecrets.URI{ID: id}
+	md.URI = uri
+	md.OwnerTag = "application-mariadb"
+	s.state.model.setSecret(&md, revisions)
+	return uri
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := NewWorker(Config{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	delete(s.state.config, controller.SecretNotifyWebhookURL)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The models aren't watched until the webhook is configured.
+	s.state.configChanges <- struct{}{}
+	s.state.configChanges <- struct{}{}
+	c.Assert(s.state.watchingModels(), jc.IsFalse)
+}
+
+func (s *workerSuite) TestSettingsChanged(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+	s.state.model.waitWatching(c)
+
+	s.state.setConfig(controller.SecretNotifyExpiryWarning, 2*time.Hour)
+	s.state.configChanges <- struct{}{}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (s *workerSuite) TestExpiryEvents(c *gc.C) {
+	now := s.clock.Now()
+	expiring := s.addSecret("9m4e2mr0ui3e8a215n4g", coresecrets.SecretMetadata{Label: "expiring"})
+	expired := s.addSecret("9m4e2mr0ui3e8a215n4h", coresecrets.SecretMetadata{})
+	tooOld := s.addSecret("9m4e2mr0ui3e8a215n4j", coresecrets.SecretMetadata{})
+	later := s.addSecret("9m4e2mr0ui3e8a215n4k", coresecrets.SecretMetadata{})
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{
+		{URI: expiring, Revision: 2, NextTriggerTime: now.Add(30 * time.Minute)},
+		{URI: expired, Revision: 1, NextTriggerTime: now.Add(-time.Minute)},
+		// Expired too long ago to be reported.
+		{URI: tooOld, Revision: 1, NextTriggerTime: now.Add(-25 * time.Hour)},
+		{URI: later, Revision: 1, NextTriggerTime: now.Add(3 * time.Hour)},
+	}
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	base := Event{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		Owner:          "application-mariadb",
+	}
+	approaching, expiredEvent := base, base
+	approaching.ID = "9m4e2mr0ui3e8a215n4g/2/expiry-approaching"
+	approaching.Type = EventExpiryApproaching
+	approaching.URI = "secret:9m4e2mr0ui3e8a215n4g"
+	approaching.Revision = 2
+	approaching.Label = "expiring"
+	approaching.Due = ptr(now.Add(30 * time.Minute))
+	approaching.Time = now
+	expiredEvent.ID = "9m4e2mr0ui3e8a215n4h/1/expired"
+	expiredEvent.Type = EventExpired
+	expiredEvent.URI = "secret:9m4e2mr0ui3e8a215n4h"
+	expiredEvent.Revision = 1
+	expiredEvent.Due = ptr(now.Add(-time.Minute))
+	expiredEvent.Time = now.Add(-time.Minute)
+	c.Assert(s.client.waitEvents(c, 2), jc.DeepEquals, []Event{approaching, expiredEvent})
+
+	// The expiry of the approaching revision is posted when it
+	// happens.
+	s.waitIdle(c, 30*time.Minute)
+	events := s.client.waitEvents(c, 3)
+	c.Assert(events[2].ID, gc.Equals, "9m4e2mr0ui3e8a215n4g/2/expired")
+
+	// The expiry of the last revision is removed before it's
+	// approaching, so nothing more is posted.
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{{URI: later, Revision: 1}}
+	// Once another change can be sent, the removal is being handled.
+	s.state.model.expiryChanges <- nil
+	s.waitIdle(c, 3*time.Hour)
+	s.client.checkNoMoreEvents(c, 3)
+}
+
+func (s *workerSuite) TestExpiryRescheduled(c *gc.C) {
+	now := s.clock.Now()
+	uri := s.addSecret("9m4e2mr0ui3e8a215n4g", coresecrets.SecretMetadata{})
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{
+		{URI: uri, Revision: 1, NextTriggerTime: now.Add(-time.Minute)},
+	}
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.client.waitEvents(c, 1)
+
+	// A new expiry time is reported again when it's due.
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{
+		{URI: uri, Revision: 1, NextTriggerTime: now.Add(2 * time.Hour)},
+	}
+	s.waitIdle(c, time.Hour)
+	events := s.client.waitEvents(c, 2)
+	c.Assert(events[1].ID, gc.Equals, "9m4e2mr0ui3e8a215n4g/1/expiry-approaching")
+}
+
+func (s *workerSuite) TestRotatedEvents(c *gc.C) {
+	now := s.clock.Now()
+	next := now.Add(23 * time.Hour)
+	uri := s.addSecret("9m4e2mr0ui3e8a215n4i", coresecrets.SecretMetadata{},
+		&coresecrets.SecretRevisionMetadata{
+			Revision:   1,
+			CreateTime: now.Add(-72 * time.Hour),
+		}, &coresecrets.SecretRevisionMetadata{
+			// Rotated too long ago to be reported.
+			Revision:   2,
+			Rotated:    true,
+			CreateTime: now.Add(-25 * time.Hour),
+		}, &coresecrets.SecretRevisionMetadata{
+			Revision:   3,
+			Rotated:    true,
+			CreateTime: now.Add(-time.Hour),
+		})
+	s.state.model.rotateChanges <- []watcher.SecretTriggerChange{
+		{URI: uri, NextTriggerTime: next},
+	}
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.client.waitEvents(c, 1), jc.DeepEquals, []Event{{
+		ID:             "9m4e2mr0ui3e8a215n4i/3/rotated",
+		Type:           EventRotated,
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		URI:            "secret:9m4e2mr0ui3e8a215n4i",
+		Revision:       3,
+		Owner:          "application-mariadb",
+		Due:            ptr(next),
+		Time:           now.Add(-time.Hour),
+	}})
+
+	// Once the secret is rotated again, only the new revision
+	// is reported.
+	s.state.model.addRevision(uri, &coresecrets.SecretRevisionMetadata{
+		Revision:   4,
+		Rotated:    true,
+		CreateTime: now,
+	})
+	s.state.model.rotateChanges <- []watcher.SecretTriggerChange{
+		{URI: uri, NextTriggerTime: now.Add(24 * time.Hour)},
+	}
+	events := s.client.waitEvents(c, 2)
+	c.Assert(events[1].ID, gc.Equals, "9m4e2mr0ui3e8a215n4i/4/rotated")
+	s.client.checkNoMoreEvents(c, 2)
+}
+
+func (s *workerSuite) TestRestartOnlyRepostsRecentEvents(c *gc.C) {
+	uri := s.addSecret("9m4e2mr0ui3e8a215n4g", coresecrets.SecretMetadata{})
+	expired := []watcher.SecretTriggerChange{
+		{URI: uri, Revision: 1, NextTriggerTime: s.clock.Now().Add(-time.Minute)},
+	}
+
+	s.state.model.expiryChanges <- expired
+	w := s.newWorker(c)
+	s.client.waitEvents(c, 1)
+	workertest.CleanKill(c, w)
+
+	// A restarted worker reports the expiry again while it's
+	// recent, but not once it's older than the lookback.
+	s.clock.Advance(eventLookback / 2)
+	s.state.sendInitialEvents()
+	s.state.model.expiryChanges <- expired
+	w = s.newWorker(c)
+	s.client.waitEvents(c, 2)
+	workertest.CleanKill(c, w)
+
+	s.clock.Advance(eventLookback / 2)
+	s.state.sendInitialEvents()
+	s.state.model.expiryChanges <- expired
+	w = s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.client.checkNoMoreEvents(c, 2)
+}
+
+func (s *workerSuite) TestRetry(c *gc.C) {
+	uri := s.addSecret("9m4e2mr0ui3e8a215n4g", coresecrets.SecretMetadata{})
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{
+		{URI: uri, Revision: 1, NextTriggerTime: s.clock.Now().Add(-time.Minute)},
+	}
+	s.client.failures = 1
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The first attempt fails, and is retried after a delay.
+	s.waitIdle(c, retryDelay)
+	s.client.waitEvents(c, 1)
+	c.Assert(s.client.attempts(), gc.Equals, 2)
+}
+
+func (s *workerSuite) TestRetryLater(c *gc.C) {
+	uri1 := s.addSecret("9m4e2mr0ui3e8a215n4g", coresecrets.SecretMetadata{})
+	uri2 := s.addSecret("9m4e2mr0ui3e8a215n4h", coresecrets.SecretMetadata{})
+	s.state.model.expiryChanges <- []watcher.SecretTriggerChange{
+		{URI: uri1, Revision: 1, NextTriggerTime: s.clock.Now().Add(-time.Minute)},
+		{URI: uri2, Revision: 1, NextTriggerTime: s.clock.Now().Add(-time.Minute)},
+	}
+	s.client.failures = retryAttempts
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// Once all the attempts to post the first event fail, the
+	// second isn't attempted until the retry period has passed.
+	s.waitIdle(c, retryDelay)
+	s.waitIdle(c, 2*retryDelay)
+	s.waitIdle(c, retryPeriod)
+	events := s.client.waitEvents(c, 2)
+	c.Assert(events[0].ID, gc.Equals, "9m4e2mr0ui3e8a215n4g/1/expired")
+	c.Assert(events[1].ID, gc.Equals, "9m4e2mr0ui3e8a215n4h/1/expired")
+	c.Assert(s.client.attempts(), gc.Equals, retryAttempts+2)
+}
+
+func (s *workerSuite) TestModelRemoved(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.state.model.waitWatching(c)
+	// The model is released once it has been checked, before
+	// its worker is started.
+	s.state.model.waitReleased(c)
+
+	s.state.removeModel()
+	s.state.modelChanges <- []string{coretesting.ModelTag.Id()}
+	s.state.model.waitReleased(c)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+type fakeState struct {
+	mu            sync.Mutex
+	config        controller.Config
+	configChanges chan struct{}
+	modelChanges  chan []string
+	watching      bool
+	model         *fakeModel
+	removed       bool
+}
+
+func newFakeState() *fakeState {
+	s := &fakeState{
+		config:        coretesting.FakeControllerConfig(),
+		configChanges: make(chan struct{}, 1),
+		modelChanges:  make(chan []string, 1),
+		model: &fakeModel{
+			secrets:       make(map[string]*coresecrets.SecretMetadata),
+			revisions:     make(map[string][]*coresecrets.SecretRevisionMetadata),
+			expiryChanges: make(chan []watcher.SecretTriggerChange, 1),
+			rotateChanges: make(chan []watcher.SecretTriggerChange, 1),
+			watching:      make(chan struct{}, 10),
+			released:      make(chan struct{}, 10),
+		},
+	}
+	s.sendInitialEvents()
+	return s
+}
+
+// sendInitialEvents sends the initial events of the controller config
+// and model watchers, as a new worker expects.
+func (s *fakeState) sendInitialEvents() {
+	s.configChanges <- struct{}{}
+	s.modelChanges <- []string{coretesting.ModelTag.Id()}
+}
+
+func (s *fakeState) setConfig(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config[key] = value
+}
+
+func (s *fakeState) removeModel() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removed = true
+}
+
+func (s *fakeState) watchingModels() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watching
+}
+
+func (s *fakeState) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg := make(controller.Config)
+	for k, v := range s.config {
+		cfg[k] = v
+	}
+	return cfg, nil
+}
+
+func (s *fakeState) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(s.configChanges), nil
+}
+
+func (s *fakeState) WatchModels() (watcher.StringsWatcher, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watching = true
+	return watchertest.NewMockStringsWatcher(s.modelChanges), nil
+}
+
+func (s *fakeState) ModelSecrets(modelUUID string) (ModelSecrets, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.removed || modelUUID != coretesting.ModelTag.Id() {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return s.model, nil
+}
+
+type fakeModel struct {
+	mu        sync.Mutex
+	secrets   map[string]*coresecrets.SecretMetadata
+	revisions map[string][]*coresecrets.SecretRevisionMetadata
+
+	expiryChanges chan []watcher.SecretTriggerChange
+	rotateChanges chan []watcher.SecretTriggerChange
+	watching      chan struct{}
+	released      chan struct{}
+}
+
+func (m *fakeModel) setSecret(md *coresecrets.SecretMetadata, revisions []*coresecrets.SecretRevisionMetadata) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[md.URI.ID] = md
+	m.revisions[md.URI.ID] = revisions
+}
+
+func (m *fakeModel) addRevision(uri *coresecrets.URI, rev *coresecrets.SecretRevisionMetadata) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revisions[uri.ID] = append(m.revisions[uri.ID], rev)
+}
+
+func (m *fakeModel) waitWatching(c *gc.C) {
+	select {
+	case <-m.watching:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the model's secrets to be watched")
+	}
+}
+
+func (m *fakeModel) waitReleased(c *gc.C) {
+	select {
+	case <-m.released:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the model to be released")
+	}
+}
+
+func (m *fakeModel) ModelName() string {
+	return "fred"
+}
+
+func (m *fakeModel) WatchSecretRevisionsExpiryChanges() (watcher.SecretTriggerWatcher, error) {
+	return newFakeTriggerWatcher(m.expiryChanges), nil
+}
+
+func (m *fakeModel) WatchSecretsRotationChanges() (watcher.SecretTriggerWatcher, error) {
+	m.watching <- struct{}{}
+	return newFakeTriggerWatcher(m.rotateChanges), nil
+}
+
+func (m *fakeModel) GetSecret(uri *coresecrets.URI) (*coresecrets.SecretMetadata, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	md, ok := m.secrets[uri.ID]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	return md, nil
+}
+
+func (m *fakeModel) ListSecretRevisions(uri *coresecrets.URI) ([]*coresecrets.SecretRevisionMetadata, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.revisions[uri.ID], nil
+}
+
+func (m *fakeModel) Release() {
+	m.released <- struct{}{}
+}
+
+type fakeTriggerWatcher struct {
+	*watchertest.MockNotifyWatcher
+	changes chan []watcher.SecretTriggerChange
+}
+
+func newFakeTriggerWatcher(changes chan []watcher.SecretTriggerChange) *fakeTriggerWatcher {
+	return &fakeTriggerWatcher{
+		MockNotifyWatcher: watchertest.NewMockNotifyWatcher(nil),
+		changes:           changes,
+	}
+}
+
+func (w *fakeTriggerWatcher) Changes() watcher.SecretTriggerChannel {
+	return w.changes
+}
+
+// fakeClient records the events posted to it, failing the first
+// failures requests.
+type fakeClient struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	bodies   [][]byte
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+	if f.failures > 0 {
+		f.failures--
+		return &http.Response{
+			Status:     "503 Service Unavailable",
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		return nil, errors.Errorf("unexpected content type %q", req.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.bodies = append(f.bodies, body)
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func (f *fakeClient) events(c *gc.C) []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var events []Event
+	for _, body := range f.bodies {
+		var event Event
+		err := json.Unmarshal(body, &event)
+		c.Assert(err, jc.ErrorIsNil)
+		events = append(events, event)
+	}
+	return events
+}
+
+// waitEvents waits for n events to have been posted, and returns them.
+func (f *fakeClient) waitEvents(c *gc.C, n int) []Event {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if events := f.events(c); len(events) >= n {
+			c.Assert(events, gc.HasLen, n)
+			return events
+		}
+	}
+	c.Fatalf("timed out waiting for %d events", n)
+	return nil
+}
+
+// checkNoMoreEvents checks that no more than n events are posted.
+func (f *fakeClient) checkNoMoreEvents(c *gc.C, n int) {
+	time.Sleep(coretesting.ShortWait)
+	c.Assert(f.events(c), gc.HasLen, n)
+}
+
+func (f *fakeClient) attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
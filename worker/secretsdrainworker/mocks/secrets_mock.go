@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	secrets "github.com/juju/juju/core/secrets"
+	secrets0 "github.com/juju/juju/secrets"
 	provider "github.com/juju/juju/secrets/provider"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -115,10 +116,10 @@ func (mr *MockBackendsClientMockRecorder) GetRevisionContent(arg0, arg1 any) *go
 }
 
 // SaveContent mocks base method.
-func (m *MockBackendsClient) SaveContent(arg0 *secrets.URI, arg1 int, arg2 secrets.SecretValue) (secrets.ValueRef, error) {
+func (m *MockBackendsClient) SaveContent(arg0 *secrets.URI, arg1 int, arg2 secrets.SecretValue) (secrets0.ContentParams, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveContent", arg0, arg1, arg2)
-	ret0, _ := ret[0].(secrets.ValueRef)
+	ret0, _ := ret[0].(secrets0.ContentParams)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -14,6 +14,7 @@ import (
 	coresecrets "github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/watcher"
 	jujusecrets "github.com/juju/juju/secrets"
+	"github.com/juju/juju/secrets/provider"
 )
 
 // logger is here to stop the desire of creating a package level logger.
@@ -152,7 +153,7 @@ func (w *Worker) drainSecret(md coresecrets.SecretMetadataForDrain, client jujus
 		if err != nil {
 			return errors.Trace(err)
 		}
-		newRevId, err := activeBackend.SaveContent(context.TODO(), md.Metadata.URI, rev.Revision, secretVal)
+		newRevId, sealed, err := provider.SaveContent(context.TODO(), activeBackend, md.Metadata.URI, rev.Revision, secretVal)
 		if err != nil && !errors.Is(err, errors.NotSupported) {
 			return errors.Trace(err)
 		}
@@ -166,8 +167,12 @@ func (w *Worker) drainSecret(md coresecrets.SecretMetadataForDrain, client jujus
 				RevisionID: newRevId,
 			}
 			// The content has successfully saved into the external backend.
-			// So we won't save the content into the Juju database.
+			// So we won't save the content into the Juju database,
+			// unless the backend sealed it for Juju to store.
 			data = nil
+			if sealed != nil {
+				data = sealed.EncodedValues()
+			}
 		}
 
 		cleanUpInExternalBackend := func() error { return nil }
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/worker/secretsmigration (interfaces: Facade)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/secretsmigration Facade
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	secretsmigration "github.com/juju/juju/api/controller/secretsmigration"
+	watcher "github.com/juju/juju/core/watcher"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFacade is a mock of Facade interface.
+type MockFacade struct {
+	ctrl     *gomock.Controller
+	recorder *MockFacadeMockRecorder
+}
+
+// MockFacadeMockRecorder is the mock recorder for MockFacade.
+type MockFacadeMockRecorder struct {
+	mock *MockFacade
+}
+
+// NewMockFacade creates a new mock instance.
+func NewMockFacade(ctrl *gomock.Controller) *MockFacade {
+	mock := &MockFacade{ctrl: ctrl}
+	mock.recorder = &MockFacadeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFacade) EXPECT() *MockFacadeMockRecorder {
+	return m.recorder
+}
+
+// GetSecretsMigration mocks base method.
+func (m *MockFacade) GetSecretsMigration() (secretsmigration.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretsMigration")
+	ret0, _ := ret[0].(secretsmigration.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretsMigration indicates an expected call of GetSecretsMigration.
+func (mr *MockFacadeMockRecorder) GetSecretsMigration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretsMigration", reflect.TypeOf((*MockFacade)(nil).GetSecretsMigration))
+}
+
+// MigrateSecretRevisions mocks base method.
+func (m *MockFacade) MigrateSecretRevisions(arg0 string, arg1 []secretsmigration.Revision) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateSecretRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateSecretRevisions indicates an expected call of MigrateSecretRevisions.
+func (mr *MockFacadeMockRecorder) MigrateSecretRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateSecretRevisions", reflect.TypeOf((*MockFacade)(nil).MigrateSecretRevisions), arg0, arg1)
+}
+
+// SetSecretsMigrationProgress mocks base method.
+func (m *MockFacade) SetSecretsMigrationProgress(arg0 string, arg1 int, arg2 []secretsmigration.Failure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecretsMigrationProgress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecretsMigrationProgress indicates an expected call of SetSecretsMigrationProgress.
+func (mr *MockFacadeMockRecorder) SetSecretsMigrationProgress(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecretsMigrationProgress", reflect.TypeOf((*MockFacade)(nil).SetSecretsMigrationProgress), arg0, arg1, arg2)
+}
+
+// WatchSecretsMigration mocks base method.
+func (m *MockFacade) WatchSecretsMigration() (watcher.NotifyWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSecretsMigration")
+	ret0, _ := ret[0].(watcher.NotifyWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchSecretsMigration indicates an expected call of WatchSecretsMigration.
+func (mr *MockFacadeMockRecorder) WatchSecretsMigration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSecretsMigration", reflect.TypeOf((*MockFacade)(nil).WatchSecretsMigration))
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/api/base"
+	api "github.com/juju/juju/api/controller/secretsmigration"
+	"github.com/juju/juju/core/watcher"
+)
+
+// batchSize is the number of revisions moved between each update of
+// the migration's progress.
+const batchSize = 10
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/worker/secretsmigration Facade
+type Facade interface {
+	WatchSecretsMigration() (watcher.NotifyWatcher, error)
+	GetSecretsMigration() (api.Migration, error)
+	MigrateSecretRevisions(backendID string, revisions []api.Revision) ([]error, error)
+	SetSecretsMigrationProgress(status string, moved int, failures []api.Failure) error
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Facade Facade
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("Facade is missing")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("Logger is missing")
+	}
+	return nil
+}
+
+// NewFacade returns a facade for the secretsmigration worker to use.
+func NewFacade(caller base.APICaller) (Facade, error) {
+	return api.NewClient(caller)
+}
+
+// NewWorker returns a worker that carries out the model's secrets
+// migration when one is started, moving the revisions in batches and
+// recording its progress after each one. The migration stops as failed
+// after a batch in which any revision could not be moved.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &migrationWorker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type migrationWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *migrationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *migrationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *migrationWorker) loop() error {
+	watcher, err := w.config.Facade.WatchSecretsMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("secrets migration watcher closed")
+			}
+			if err := w.migrate(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *migrationWorker) migrate() error {
+	m, err := w.config.Facade.GetSecretsMigration()
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting secrets migration")
+	}
+	if m.Finished() {
+		return nil
+	}
+	logger := w.config.Logger
+	logger.Infof("moving %d secret revisions to backend %q", len(m.Revisions), m.BackendID)
+
+	// Revisions already moved are not returned, so a migration
+	// interrupted by a restart carries on from where it stopped.
+	moved := m.Moved
+	revisions := m.Revisions
+	for len(revisions) > 0 {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		default:
+		}
+		batch := revisions
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		revisions = revisions[len(batch):]
+
+		errs, err := w.config.Facade.MigrateSecretRevisions(m.BackendID, batch)
+		if err != nil {
+			return errors.Annotate(err, "moving secret revisions")
+		}
+		var failures []api.Failure
+		for i, err := range errs {
+			if err == nil {
+				moved++
+				continue
+			}
+			logger.Warningf("cannot move secret %s/%d: %v", batch[i].URI, batch[i].Revision, err)
+			failures = append(failures, api.Failure{Revision: batch[i], Message: err.Error()})
+		}
+		status := api.StatusRunning
+		switch {
+		case len(failures) > 0:
+			status = api.StatusFailed
+		case len(revisions) == 0:
+			status = api.StatusCompleted
+		}
+		if err := w.config.Facade.SetSecretsMigrationProgress(status, moved, failures); err != nil {
+			return errors.Annotate(err, "recording secrets migration progress")
+		}
+		if status == api.StatusFailed {
+			logger.Warningf("secrets migration to backend %q failed after moving %d revisions", m.BackendID, moved)
+			return nil
+		}
+	}
+	if len(m.Revisions) == 0 {
+		// There was nothing left to move.
+		if err := w.config.Facade.SetSecretsMigrationProgress(api.StatusCompleted, moved, nil); err != nil {
+			return errors.Annotate(err, "recording secrets migration progress")
+		}
+	}
+	logger.Infof("secrets migration to backend %q completed", m.BackendID)
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmigration_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	api "github.com/juju/juju/api/controller/secretsmigration"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/secretsmigration"
+	"github.com/juju/juju/worker/secretsmigration/mocks"
+)
+
+var _ = gc.Suite(&workerSuite{})
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade  *mocks.MockFacade
+	changes chan struct{}
+	done    chan struct{}
+}
+
+func (s *workerSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.facade = mocks.NewMockFacade(ctrl)
+	s.changes = make(chan struct{}, 1)
+	s.changes <- struct{}{}
+	s.done = make(chan struct{})
+	s.facade.EXPECT().WatchSecretsMigration().Return(watchertest.NewMockNotifyWatcher(s.changes), nil)
+	return ctrl
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := secretsmigration.NewWorker(secretsmigration.Config{
+		Facade: s.facade,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) waitDone(c *gc.C) {
+	select {
+	case <-s.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the secrets migration worker")
+	}
+}
+
+func (s *workerSuite) signalDone() {
+	close(s.done)
+}
+
+func revisions(n int) []api.Revision {
+	result := make([]api.Revision, n)
+	for i := range result {
+		result[i] = api.Revision{URI: fmt.Sprintf("secret:%d", i), Revision: 1}
+	}
+	return result
+}
+
+func (s *workerSuite) TestConfigValidate(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cfg := secretsmigration.Config{}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Facade is missing not valid`)
+	cfg.Facade = mocks.NewMockFacade(ctrl)
+	c.Check(cfg.Validate(), gc.ErrorMatches, `Logger is missing not valid`)
+	cfg.Logger = loggo.GetLogger("test")
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestMigratesInBatches(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	revs := revisions(12)
+	gomock.InOrder(
+		s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{
+			BackendID: "backend-id",
+			Status:    api.StatusPending,
+			Total:     12,
+			Revisions: revs,
+		}, nil),
+		s.facade.EXPECT().MigrateSecretRevisions("backend-id", revs[:10]).Return(make([]error, 10), nil),
+		s.facade.EXPECT().SetSecretsMigrationProgress(api.StatusRunning, 10, nil).Return(nil),
+		s.facade.EXPECT().MigrateSecretRevisions("backend-id", revs[10:]).Return(make([]error, 2), nil),
+		s.facade.EXPECT().SetSecretsMigrationProgress(api.StatusCompleted, 12, nil).DoAndReturn(
+			func(string, int, []api.Failure) error {
+				s.signalDone()
+				return nil
+			}),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestResumesRunning(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	revs := revisions(2)
+	gomock.InOrder(
+		s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{
+			BackendID: "backend-id",
+			Status:    api.StatusRunning,
+			Total:     5,
+			Moved:     3,
+			Revisions: revs,
+		}, nil),
+		s.facade.EXPECT().MigrateSecretRevisions("backend-id", revs).Return(make([]error, 2), nil),
+		s.facade.EXPECT().SetSecretsMigrationProgress(api.StatusCompleted, 5, nil).DoAndReturn(
+			func(string, int, []api.Failure) error {
+				s.signalDone()
+				return nil
+			}),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestStopsAfterFailedBatch(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	revs := revisions(12)
+	gomock.InOrder(
+		s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{
+			BackendID: "backend-id",
+			Status:    api.StatusPending,
+			Total:     12,
+			Revisions: revs,
+		}, nil),
+		s.facade.EXPECT().MigrateSecretRevisions("backend-id", revs[:10]).DoAndReturn(
+			func(string, []api.Revision) ([]error, error) {
+				errs := make([]error, 10)
+				errs[3] = errors.New("checksum mismatch")
+				return errs, nil
+			}),
+		// The rest of the revisions are left where they are.
+		s.facade.EXPECT().SetSecretsMigrationProgress(api.StatusFailed, 9, []api.Failure{{
+			Revision: revs[3],
+			Message:  "checksum mismatch",
+		}}).DoAndReturn(
+			func(string, int, []api.Failure) error {
+				s.signalDone()
+				return nil
+			}),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestNothingToMove(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	gomock.InOrder(
+		s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{
+			BackendID: "backend-id",
+			Status:    api.StatusPending,
+			Total:     1,
+		}, nil),
+		s.facade.EXPECT().SetSecretsMigrationProgress(api.StatusCompleted, 0, nil).DoAndReturn(
+			func(string, int, []api.Failure) error {
+				s.signalDone()
+				return nil
+			}),
+	)
+
+	w := s.startWorker(c)
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestIgnoresFinishedOrMissing(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	gomock.InOrder(
+		s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{}, errors.NotFoundf("secrets migration")),
+		s.facade.EXPECT().GetSecretsMigration().DoAndReturn(func() (api.Migration, error) {
+			s.signalDone()
+			return api.Migration{BackendID: "backend-id", Status: api.StatusCompleted}, nil
+		}),
+	)
+
+	w := s.startWorker(c)
+	s.changes <- struct{}{}
+	s.waitDone(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestMigrateError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	revs := revisions(1)
+	s.facade.EXPECT().GetSecretsMigration().Return(api.Migration{
+		BackendID: "backend-id",
+		Status:    api.StatusPending,
+		Total:     1,
+		Revisions: revs,
+	}, nil)
+	s.facade.EXPECT().MigrateSecretRevisions("backend-id", revs).Return(nil, errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "moving secret revisions: boom")
+}
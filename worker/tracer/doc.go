@@ -0,0 +1,8 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracer provides a worker which installs an OpenTelemetry
+// tracer provider for the agent, so that spans created with the
+// core/trace package are exported to the collector configured in the
+// controller config.
+package tracer
@@ -0,0 +1,63 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer_test
+
+import (
+	"context"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+)
+
+type stubConfigGetter struct {
+	mu      sync.Mutex
+	config  controller.Config
+	err     error
+	changes chan struct{}
+}
+
+func (s *stubConfigGetter) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(s.changes), nil
+}
+
+func (s *stubConfigGetter) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config, s.err
+}
+
+func (s *stubConfigGetter) setConfig(config controller.Config) {
+	s.mu.Lock()
+	s.config = config
+	s.mu.Unlock()
+	s.changes <- struct{}{}
+}
+
+// recordingExporter records the spans exported to it. Unlike
+// tracetest.InMemoryExporter, it keeps them after shutdown.
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *recordingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *recordingExporter) exported() []sdktrace.ReadOnlySpan {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.spans
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+	"github.com/juju/worker/v3/dependency"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// shutdownTimeout is the maximum time spent flushing spans to the
+// collector when the worker stops.
+const shutdownTimeout = 5 * time.Second
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// ControllerConfigGetter provides the controller config, which holds
+// the tracing settings, and notifies when it changes.
+type ControllerConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+}
+
+// NewExporterFunc returns an exporter which sends spans to the
+// collector described by the controller config.
+type NewExporterFunc func(controller.Config) (sdktrace.SpanExporter, error)
+
+// Config holds the configuration and dependencies for a tracer worker.
+type Config struct {
+	ConfigGetter ControllerConfigGetter
+	Tag          names.Tag
+	NewExporter  NewExporterFunc
+	Logger       Logger
+}
+
+// Validate checks the tracer worker configuration.
+func (config Config) Validate() error {
+	if config.ConfigGetter == nil {
+		return errors.NotValidf("nil ConfigGetter")
+	}
+	if config.Tag == nil {
+		return errors.NotValidf("nil Tag")
+	}
+	if config.NewExporter == nil {
+		return errors.NotValidf("nil NewExporter")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// settings holds the controller config values which affect tracing.
+type settings struct {
+	enabled     bool
+	endpoint    string
+	insecure    bool
+	sampleRatio float64
+}
+
+func settingsFromConfig(cfg controller.Config) settings {
+	return settings{
+		enabled:     cfg.OpenTelemetryEnabled(),
+		endpoint:    cfg.OpenTelemetryEndpoint(),
+		insecure:    cfg.OpenTelemetryInsecure(),
+		sampleRatio: cfg.OpenTelemetrySampleRatio(),
+	}
+}
+
+type tracerWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	settings settings
+	provider *sdktrace.TracerProvider
+}
+
+// NewWorker returns a worker which, when OpenTelemetry tracing is
+// enabled in the controller config, installs a global tracer provider
+// that exports spans to the configured collector. The provider is
+// removed, after flushing any pending spans, when the worker stops.
+// The worker bounces when the tracing settings change.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := config.ConfigGetter.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	w := &tracerWorker{
+		config:   config,
+		settings: settingsFromConfig(cfg),
+	}
+	if w.settings.enabled {
+		exporter, err := config.NewExporter(cfg)
+		if err != nil {
+			return nil, errors.Annotate(err, "creating span exporter")
+		}
+		w.provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(w.settings.sampleRatio))),
+			sdktrace.WithResource(resource.NewSchemaless(
+				semconv.ServiceName("jujud"),
+				semconv.ServiceInstanceID(config.Tag.String()),
+				semconv.ServiceVersion(jujuversion.Current.String()),
+			)),
+		)
+		otel.SetTracerProvider(w.provider)
+		config.Logger.Infof("exporting traces to %s", w.settings.endpoint)
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		w.shutdown()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *tracerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *tracerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *tracerWorker) loop() error {
+	defer w.shutdown()
+	configWatcher, err := w.config.ConfigGetter.WatchControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "watching controller config")
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			cfg, err := w.config.ConfigGetter.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "getting controller config")
+			}
+			if settingsFromConfig(cfg) != w.settings {
+				w.config.Logger.Infof("tracing config changed, restarting")
+				return dependency.ErrBounce
+			}
+		}
+	}
+}
+
+func (w *tracerWorker) shutdown() {
+	if w.provider == nil {
+		return
+	}
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := w.provider.Shutdown(ctx); err != nil {
+		w.config.Logger.Warningf("flushing spans: %v", err)
+	}
+}
+
+// NewExporter returns an exporter which sends spans to the OTLP/HTTP
+// collector described by the controller config.
+func NewExporter(cfg controller.Config) (sdktrace.SpanExporter, error) {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.OpenTelemetryEndpoint()),
+	}
+	if cfg.OpenTelemetryInsecure() {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	return exporter, errors.Trace(err)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracer_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/dependency"
+	"github.com/juju/worker/v3/workertest"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	coretrace "github.com/juju/juju/core/trace"
+	"github.com/juju/juju/worker/tracer"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	getter   *stubConfigGetter
+	exporter *recordingExporter
+	config   tracer.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.AddCleanup(func(*gc.C) { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	s.getter = &stubConfigGetter{config: controller.Config{
+		controller.OpenTelemetryEnabled:     true,
+		controller.OpenTelemetryEndpoint:    "localhost:4318",
+		controller.OpenTelemetrySampleRatio: 1.0,
+	}, changes: make(chan struct{})}
+	s.exporter = &recordingExporter{}
+	s.config = tracer.Config{
+		ConfigGetter: s.getter,
+		Tag:          names.NewMachineTag("0"),
+		NewExporter: func(controller.Config) (sdktrace.SpanExporter, error) {
+			return s.exporter, nil
+		},
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.ConfigGetter = nil
+	_, err := tracer.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil ConfigGetter not valid")
+
+	config = s.config
+	config.NewExporter = nil
+	_, err = tracer.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil NewExporter not valid")
+}
+
+func (s *WorkerSuite) TestExportsSpans(c *gc.C) {
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, span := coretrace.Start(context.Background(), "test-span")
+	span.End()
+
+	// Spans are flushed when the worker stops.
+	workertest.CleanKill(c, w)
+	spans := s.exporter.exported()
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].Name(), gc.Equals, "test-span")
+
+	// Once stopped, spans are no longer exported.
+	_, span = coretrace.Start(context.Background(), "dropped")
+	span.End()
+	c.Check(span.SpanContext().IsValid(), jc.IsFalse)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.getter.config[controller.OpenTelemetryEnabled] = false
+	s.config.NewExporter = func(controller.Config) (sdktrace.SpanExporter, error) {
+		c.Fatalf("unexpected exporter")
+		return nil, nil
+	}
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	_, span := coretrace.Start(context.Background(), "test-span")
+	span.End()
+	c.Check(span.SpanContext().IsValid(), jc.IsFalse)
+}
+
+func (s *WorkerSuite) TestBouncesOnConfigChange(c *gc.C) {
+	w, err := tracer.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// Changes to config unrelated to tracing leave the worker running.
+	s.getter.setConfig(controller.Config{
+		controller.OpenTelemetryEnabled:     true,
+		controller.OpenTelemetryEndpoint:    "localhost:4318",
+		controller.OpenTelemetrySampleRatio: 1.0,
+		controller.AuditingEnabled:          true,
+	})
+	// A further event is only received once the first is handled.
+	s.getter.changes <- struct{}{}
+	workertest.CheckAlive(c, w)
+
+	s.getter.setConfig(controller.Config{
+		controller.OpenTelemetryEnabled:     true,
+		controller.OpenTelemetryEndpoint:    "localhost:4318",
+		controller.OpenTelemetrySampleRatio: 0.5,
+	})
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (s *WorkerSuite) TestConfigError(c *gc.C) {
+	s.getter.err = errors.New("boom")
+	_, err := tracer.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "getting controller config: boom")
+}
@@ -4,14 +4,18 @@
 package operation
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/juju/errors"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
 
 type executorStep struct {
+	name string
 	verb string
 	run  func(op Operation, state State) (*State, error)
 }
@@ -21,12 +25,13 @@ func (step executorStep) message(op Operation, unitName string) string {
 }
 
 var (
-	stepPrepare = executorStep{"preparing", Operation.Prepare}
-	stepExecute = executorStep{"executing", Operation.Execute}
-	stepCommit  = executorStep{"committing", Operation.Commit}
+	stepPrepare = executorStep{"prepare", "preparing", Operation.Prepare}
+	stepExecute = executorStep{"execute", "executing", Operation.Execute}
+	stepCommit  = executorStep{"commit", "committing", Operation.Commit}
 )
 
 type executor struct {
+	ctx                context.Context
 	unitName           string
 	stateOps           *StateOps
 	state              *State
@@ -36,6 +41,10 @@ type executor struct {
 
 // ExecutorConfig defines configuration for an Executor.
 type ExecutorConfig struct {
+	// Context is the parent of the spans which trace the running of
+	// operations, typically the context of the worker running them.
+	// If nil, context.Background() is used.
+	Context         context.Context
 	StateReadWriter UnitStateReadWriter
 	InitialState    State
 	AcquireLock     func(string, string) (func(), error)
@@ -66,7 +75,12 @@ func NewExecutor(unitName string, cfg ExecutorConfig) (Executor, error) {
 	} else if err != nil {
 		return nil, err
 	}
+	ctx := cfg.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return &executor{
+		ctx:                ctx,
 		unitName:           unitName,
 		stateOps:           stateOps,
 		state:              state,
@@ -81,8 +95,10 @@ func (x *executor) State() State {
 }
 
 // Run is part of the Executor interface.
-func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapshot) error {
+func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapshot) (err error) {
 	x.logger.Debugf("running operation %v for %s", op, x.unitName)
+	ctx, span := x.startSpan(op, false)
+	defer func() { trace.End(span, err) }()
 
 	if op.NeedsGlobalMachineLock() {
 		x.logger.Debugf("acquiring machine lock for %s", x.unitName)
@@ -96,7 +112,7 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 		x.logger.Debugf("no machine lock needed for %s", x.unitName)
 	}
 
-	switch err := x.do(op, stepPrepare); errors.Cause(err) {
+	switch err := x.do(ctx, op, stepPrepare); errors.Cause(err) {
 	case ErrSkipExecute:
 	case nil:
 		done := make(chan struct{})
@@ -113,7 +129,7 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 				}
 			}
 		}()
-		if err := x.do(op, stepExecute); err != nil {
+		if err := x.do(ctx, op, stepExecute); err != nil {
 			close(done)
 			return err
 		}
@@ -121,21 +137,36 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 	default:
 		return err
 	}
-	return x.do(op, stepCommit)
+	return x.do(ctx, op, stepCommit)
 }
 
 // Skip is part of the Executor interface.
-func (x *executor) Skip(op Operation) error {
+func (x *executor) Skip(op Operation) (err error) {
 	x.logger.Debugf("skipping operation %v for %s", op, x.unitName)
-	return x.do(op, stepCommit)
+	ctx, span := x.startSpan(op, true)
+	defer func() { trace.End(span, err) }()
+	return x.do(ctx, op, stepCommit)
+}
+
+// startSpan starts the span which traces the running of an operation;
+// each step of the operation is traced by a child span.
+func (x *executor) startSpan(op Operation, skipped bool) (context.Context, trace.Span) {
+	return trace.Start(x.ctx, "uniter.operation",
+		attribute.String("juju.unit", x.unitName),
+		attribute.String("juju.operation", op.String()),
+		attribute.Bool("juju.operation.skipped", skipped),
+	)
 }
 
-func (x *executor) do(op Operation, step executorStep) (err error) {
+func (x *executor) do(ctx context.Context, op Operation, step executorStep) (err error) {
+	ctx, span := trace.Start(ctx, "uniter.operation."+step.name)
+	defer func() { trace.End(span, err) }()
+
 	message := step.message(op, x.unitName)
 	x.logger.Debugf(message)
 	newState, firstErr := step.run(op, *x.state)
 	if newState != nil {
-		writeErr := x.writeState(*newState)
+		writeErr := x.writeState(ctx, *newState)
 		if firstErr == nil {
 			firstErr = writeErr
 		} else if writeErr != nil {
@@ -145,14 +176,14 @@ func (x *executor) do(op Operation, step executorStep) (err error) {
 	return errors.Annotatef(firstErr, message)
 }
 
-func (x *executor) writeState(newState State) error {
+func (x *executor) writeState(ctx context.Context, newState State) error {
 	if err := newState.Validate(); err != nil {
 		return err
 	}
 	if x.state != nil && x.state.match(newState) {
 		return nil
 	}
-	if err := x.stateOps.Write(&newState); err != nil {
+	if err := x.stateOps.Write(ctx, &newState); err != nil {
 		return errors.Annotatef(err, "writing state")
 	}
 	x.state = &newState
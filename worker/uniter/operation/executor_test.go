@@ -4,6 +4,7 @@
 package operation_test
 
 import (
+	"context"
 	"time"
 
 	"github.com/juju/charm/v12/hooks"
@@ -11,10 +12,18 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/yaml.v2"
 
+	coretrace "github.com/juju/juju/core/trace"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -129,7 +138,7 @@ func (s *ExecutorSuite) expectSetState(c *gc.C, st operation.State) {
 	strUniterState := string(data)
 
 	mExp := s.mockStateRW.EXPECT()
-	mExp.SetState(unitStateMatcher{c: c, expected: strUniterState}).Return(nil)
+	mExp.SetStateContext(gomock.Any(), unitStateMatcher{c: c, expected: strUniterState}).Return(nil)
 }
 
 func (s *ExecutorSuite) expectState(c *gc.C, st operation.State) {
@@ -397,6 +406,88 @@ func (s *ExecutorSuite) TestFailExecuteNoStateChange(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, initialState)
 }
 
+func (s *ExecutorSuite) TestRunTraced(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	initialState := justInstalledState()
+	executor := s.newExecutor(c, &initialState)
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, errors.New("splat")),
+	}
+	err := executor.Run(op, nil)
+	c.Assert(err, gc.ErrorMatches, `executing operation "mock operation" for test: splat`)
+
+	spans := recorder.Ended()
+	c.Assert(spans, gc.HasLen, 3)
+	c.Check(spans[0].Name(), gc.Equals, "uniter.operation.prepare")
+	c.Check(spans[1].Name(), gc.Equals, "uniter.operation.execute")
+	c.Check(spans[1].Status().Code, gc.Equals, codes.Error)
+	c.Check(spans[2].Name(), gc.Equals, "uniter.operation")
+	c.Check(spans[2].Attributes(), jc.DeepEquals, []attribute.KeyValue{
+		attribute.String("juju.unit", "test"),
+		attribute.String("juju.operation", "mock operation"),
+		attribute.Bool("juju.operation.skipped", false),
+	})
+	for _, span := range spans[:2] {
+		c.Check(span.Parent().SpanID(), gc.Equals, spans[2].SpanContext().SpanID())
+	}
+}
+
+func (s *ExecutorSuite) TestRunTracedFromContext(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	parentCtx, parent := coretrace.Start(context.Background(), "uniter")
+	defer parent.End()
+
+	initialState := justInstalledState()
+	s.expectState(c, initialState)
+	executor, err := operation.NewExecutor("test", operation.ExecutorConfig{
+		Context:         parentCtx,
+		StateReadWriter: s.mockStateRW,
+		InitialState:    operation.State{Step: operation.Queued},
+		AcquireLock:     failAcquireLock,
+		Logger:          loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The state written by a step is sent as part of that step's span.
+	prepareOp := operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &hook.Info{Kind: hooks.ConfigChanged},
+	}
+	var writeCtx context.Context
+	s.mockStateRW.EXPECT().SetStateContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ params.SetUnitStateArg) error {
+			writeCtx = ctx
+			return nil
+		})
+	op := &mockOperation{
+		prepare: newStep(&prepareOp, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+	err = executor.Run(op, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spans := recorder.Ended()
+	c.Assert(spans, gc.HasLen, 4)
+	c.Check(spans[0].Name(), gc.Equals, "uniter.operation.prepare")
+	c.Check(spans[3].Name(), gc.Equals, "uniter.operation")
+	c.Check(spans[3].Parent().SpanID(), gc.Equals, parent.SpanContext().SpanID())
+	c.Assert(writeCtx, gc.NotNil)
+	c.Check(oteltrace.SpanContextFromContext(writeCtx).SpanID(), gc.Equals, spans[0].SpanContext().SpanID())
+}
+
 func (s *ExecutorSuite) TestFailExecuteWithStateChange(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	executeOp := s.expectStartPendingOp(c)
@@ -468,7 +559,7 @@ func (s *ExecutorSuite) TestFailCommitWithStateChange(c *gc.C) {
 
 func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func(string, string) (func(), error)) operation.Executor {
 	initialState := justInstalledState()
-	err := operation.NewStateOps(s.mockStateRW).Write(&initialState)
+	err := operation.NewStateOps(s.mockStateRW).Write(context.Background(), &initialState)
 	c.Assert(err, jc.ErrorIsNil)
 	cfg := operation.ExecutorConfig{
 		StateReadWriter: s.mockStateRW,
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	params "github.com/juju/juju/rpc/params"
@@ -53,6 +54,20 @@ func (mr *MockUnitStateReadWriterMockRecorder) SetState(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockUnitStateReadWriter)(nil).SetState), arg0)
 }
 
+// SetStateContext mocks base method.
+func (m *MockUnitStateReadWriter) SetStateContext(arg0 context.Context, arg1 params.SetUnitStateArg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStateContext", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStateContext indicates an expected call of SetStateContext.
+func (mr *MockUnitStateReadWriterMockRecorder) SetStateContext(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStateContext", reflect.TypeOf((*MockUnitStateReadWriter)(nil).SetStateContext), arg0, arg1)
+}
+
 // State mocks base method.
 func (m *MockUnitStateReadWriter) State() (params.UnitStateResult, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
 
@@ -246,6 +248,7 @@ func NewStateOps(readwriter UnitStateReadWriter) *StateOps {
 type UnitStateReadWriter interface {
 	State() (params.UnitStateResult, error)
 	SetState(unitState params.SetUnitStateArg) error
+	SetStateContext(ctx context.Context, unitState params.SetUnitStateArg) error
 }
 
 // Read a State from the controller. If the saved state does not exist
@@ -268,8 +271,9 @@ func (f *StateOps) Read() (*State, error) {
 	return &st, nil
 }
 
-// Write stores the supplied state on the controller.
-func (f *StateOps) Write(st *State) error {
+// Write stores the supplied state on the controller. Any trace span held
+// in ctx is propagated to the controller.
+func (f *StateOps) Write(ctx context.Context, st *State) error {
 	if err := st.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -278,5 +282,5 @@ func (f *StateOps) Write(st *State) error {
 		return errors.Trace(err)
 	}
 	s := string(data)
-	return f.unitStateRW.SetState(params.SetUnitStateArg{UniterState: &s})
+	return f.unitStateRW.SetStateContext(ctx, params.SetUnitStateArg{UniterState: &s})
 }
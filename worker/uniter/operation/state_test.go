@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"context"
+
 	"github.com/juju/charm/v12/hooks"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -258,7 +260,7 @@ func (s *StateOpsSuite) runTest(c *gc.C, t stateTest) {
 	if t.err == "" {
 		s.expectSetState(c, t.st, t.err)
 	}
-	err = ops.Write(&t.st)
+	err = ops.Write(context.Background(), &t.st)
 	if t.err == "" {
 		c.Assert(err, jc.ErrorIsNil)
 	} else {
@@ -292,7 +294,7 @@ func (s *StateOpsSuite) expectSetState(c *gc.C, st operation.State, errStr strin
 	}
 
 	mExp := s.mockStateRW.EXPECT()
-	mExp.SetState(unitStateMatcher{c: c, expected: strUniterState}).Return(err)
+	mExp.SetStateContext(gomock.Any(), unitStateMatcher{c: c, expected: strUniterState}).Return(err)
 }
 
 func (s *StateOpsSuite) expectState(c *gc.C, st operation.State) {
@@ -1609,7 +1609,7 @@ func (ctx *HookContext) doFlush(process string) error {
 		pendingTrackLatest []string
 	)
 	for _, c := range ctx.secretChanges.pendingCreates {
-		ref, err := secretsBackend.SaveContent(c.URI, 1, c.Value)
+		content, err := secretsBackend.SaveContent(c.URI, 1, c.Value)
 		if errors.Is(err, errors.NotSupported) {
 			pendingCreates = append(pendingCreates, c)
 			continue
@@ -1617,9 +1617,10 @@ func (ctx *HookContext) doFlush(process string) error {
 		if err != nil {
 			return errors.Annotatef(err, "saving content for secret %q", c.URI.ID)
 		}
-		cleanups = append(cleanups, ref)
-		c.ValueRef = &ref
-		c.Value = nil
+		cleanups = append(cleanups, *content.ValueRef)
+		c.ValueRef = content.ValueRef
+		// Any sealed content is stored by Juju along with the reference.
+		c.Value = content.SecretValue
 		pendingCreates = append(pendingCreates, c)
 	}
 	for _, u := range ctx.secretChanges.pendingUpdates {
@@ -1629,7 +1630,7 @@ func (ctx *HookContext) doFlush(process string) error {
 			pendingUpdates = append(pendingUpdates, u.SecretUpsertArg)
 			continue
 		}
-		ref, err := secretsBackend.SaveContent(u.URI, u.CurrentRevision+1, u.Value)
+		content, err := secretsBackend.SaveContent(u.URI, u.CurrentRevision+1, u.Value)
 		if errors.Is(err, errors.NotSupported) {
 			pendingUpdates = append(pendingUpdates, u.SecretUpsertArg)
 			continue
@@ -1637,9 +1638,9 @@ func (ctx *HookContext) doFlush(process string) error {
 		if err != nil {
 			return errors.Annotatef(err, "saving content for secret %q", u.URI.ID)
 		}
-		cleanups = append(cleanups, ref)
-		u.ValueRef = &ref
-		u.Value = nil
+		cleanups = append(cleanups, *content.ValueRef)
+		u.ValueRef = content.ValueRef
+		u.Value = content.SecretValue
 		pendingUpdates = append(pendingUpdates, u.SecretUpsertArg)
 	}
 
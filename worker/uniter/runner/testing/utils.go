@@ -146,8 +146,8 @@ func (s SecretsContextAccessor) SecretMetadata() ([]secrets.SecretOwnerMetadata,
 	}}, nil
 }
 
-func (s SecretsContextAccessor) SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (secrets.ValueRef, error) {
-	return secrets.ValueRef{}, errors.NotSupportedf("")
+func (s SecretsContextAccessor) SaveContent(uri *secrets.URI, revision int, value secrets.SecretValue) (jujusecrets.ContentParams, error) {
+	return jujusecrets.ContentParams{}, errors.NotSupportedf("")
 }
 
 func (s SecretsContextAccessor) DeleteContent(uri *secrets.URI, revision int) error {
@@ -4,6 +4,7 @@
 package uniter
 
 import (
+	stdcontext "context"
 	"fmt"
 	"os"
 	"sync"
@@ -914,6 +915,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	}
 
 	operationExecutor, err := u.newOperationExecutor(u.unit.Name(), operation.ExecutorConfig{
+		Context:         u.catacomb.Context(stdcontext.Background()),
 		StateReadWriter: u.unit,
 		InitialState:    initialState,
 		AcquireLock:     u.acquireExecutionLock,